@@ -8,6 +8,7 @@ import (
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
@@ -46,6 +47,7 @@ func procPackage(pkg *packages.Package, w io.Writer) {
 		aliases:     make(map[string]string),
 		handled:     map[string]struct{}{},
 		funcs:       map[string]*ast.FuncDecl{},
+		hashFuncs:   map[string]struct{}{},
 		blockFields: map[string][]*ast.Field{},
 	}
 	b.readStructFields(pkg)
@@ -69,6 +71,7 @@ type hashBuilder struct {
 	pkg         *packages.Package
 	fields      map[string][]*ast.Field
 	funcs       map[string]*ast.FuncDecl
+	hashFuncs   map[string]struct{}
 	aliases     map[string]string
 	handled     map[string]struct{}
 	blockFields map[string][]*ast.Field
@@ -254,7 +257,8 @@ func (b *hashBuilder) ftype(structName, s string, expr ast.Expr, directives map[
 	case "CoralType", "SkullType":
 		return "uint64(" + s + ".Uint8())", 3
 	case "AnvilType", "SandstoneType", "PrismarineType", "StoneBricksType", "NetherBricksType", "FroglightType",
-		"WallConnectionType", "BlackstoneType", "DeepslateType", "TallGrassType", "CopperType", "OxidationType":
+		"WallConnectionType", "BlackstoneType", "DeepslateType", "TallGrassType", "CopperType", "OxidationType",
+		"CommandBlockMode":
 		return "uint64(" + s + ".Uint8())", 2
 	case "OreType", "FireType", "DoubleTallGrassType":
 		return "uint64(" + s + ".Uint8())", 1
@@ -272,6 +276,9 @@ func (b *hashBuilder) ftype(structName, s string, expr ast.Expr, directives map[
 
 func (b *hashBuilder) resolveBlocks() {
 	for bl, fields := range b.fields {
+		if _, ok := b.hashFuncs[bl]; ok {
+			continue
+		}
 		if _, ok := b.funcs[bl]; ok {
 			b.blockFields[bl] = fields
 		}
@@ -291,6 +298,11 @@ func (b *hashBuilder) readFuncDecls(node ast.Node) bool {
 		if fun.Name.Name == "EncodeBlock" && fun.Recv != nil {
 			b.funcs[fun.Recv.List[0].Type.(*ast.Ident).Name] = fun
 		}
+		// Blocks that implement a Hash method themselves, outside the generated file, compute their hash in a
+		// way that cannot be generated and must be skipped.
+		if fun.Name.Name == "Hash" && fun.Recv != nil && filepath.Base(b.pkg.Fset.Position(fun.Pos()).Filename) != "hash.go" {
+			b.hashFuncs[fun.Recv.List[0].Type.(*ast.Ident).Name] = struct{}{}
+		}
 	}
 	return true
 }
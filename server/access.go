@@ -0,0 +1,296 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+)
+
+// AccessEntry is an entry of an AccessList. It matches players by their name,
+// UUID or IP address. Fields left empty are not matched against.
+type AccessEntry struct {
+	// Name is the name of the player. Names are matched case-insensitively.
+	Name string
+	// UUID is the UUID of the player.
+	UUID uuid.UUID
+	// IP is the IP address that the player connects from, without port.
+	IP string
+	// Reason is the reason for a ban. It is shown to banned players when they
+	// are disconnected.
+	Reason string
+	// Expiry is the time at which the entry expires. An entry with a zero
+	// Expiry, such as a permanent ban, never expires.
+	Expiry time.Time
+}
+
+// matches checks if the AccessEntry matches a player with the name, UUID and
+// IP address passed.
+func (e AccessEntry) matches(name string, id uuid.UUID, ip string) bool {
+	return (e.Name != "" && strings.EqualFold(e.Name, name)) ||
+		(e.UUID != uuid.Nil && e.UUID == id) ||
+		(e.IP != "" && e.IP == ip)
+}
+
+// sameTarget checks if the AccessEntry passed targets the same player as e.
+func (e AccessEntry) sameTarget(other AccessEntry) bool {
+	return strings.EqualFold(e.Name, other.Name) && e.UUID == other.UUID && e.IP == other.IP
+}
+
+// expired checks if the AccessEntry has expired at the time passed.
+func (e AccessEntry) expired(t time.Time) bool {
+	return !e.Expiry.IsZero() && !t.Before(e.Expiry)
+}
+
+// AccessListConfig holds options for creating an AccessList.
+type AccessListConfig struct {
+	// File is the path of the JSON file that the AccessList is loaded from and
+	// saved to. If empty, the AccessList is kept in memory only.
+	File string
+	// AllowlistEnabled specifies if only players on the allowlist may join
+	// the server. AllowlistEnabled is overwritten by the value stored in File
+	// if it exists.
+	AllowlistEnabled bool
+	// BanMessage returns the message that a player matched by the ban passed
+	// is disconnected with. If nil, a default message holding the reason and
+	// expiry of the ban is used.
+	BanMessage func(ban AccessEntry) string
+	// AllowlistMessage is the message that players not on the allowlist are
+	// disconnected with if the allowlist is enabled. If empty, a default
+	// message is used.
+	AllowlistMessage string
+}
+
+// New creates an AccessList using the options in conf. If conf.File exists,
+// the allowlist and bans are loaded from it. An error is returned if the file
+// could not be read or decoded.
+func (conf AccessListConfig) New() (*AccessList, error) {
+	if conf.BanMessage == nil {
+		conf.BanMessage = defaultBanMessage
+	}
+	if conf.AllowlistMessage == "" {
+		conf.AllowlistMessage = "You are not on the allowlist of this server."
+	}
+	l := &AccessList{conf: conf, data: accessListData{AllowlistEnabled: conf.AllowlistEnabled}}
+	if conf.File == "" {
+		return l, nil
+	}
+	b, err := os.ReadFile(conf.File)
+	if errors.Is(err, fs.ErrNotExist) {
+		return l, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read access list: %w", err)
+	}
+	if err := json.Unmarshal(b, &l.data); err != nil {
+		return nil, fmt.Errorf("decode access list: %w", err)
+	}
+	return l, nil
+}
+
+// defaultBanMessage returns the default message that players matched by a ban
+// are disconnected with.
+func defaultBanMessage(ban AccessEntry) string {
+	msg := "You are banned from this server."
+	if ban.Reason != "" {
+		msg += "\nReason: " + ban.Reason
+	}
+	if !ban.Expiry.IsZero() {
+		msg += "\nExpires: " + ban.Expiry.Format(time.DateTime)
+	}
+	return msg
+}
+
+// AccessList is an Allower that keeps an allowlist and a list of bans of
+// players, optionally persisted to a JSON file. An AccessList is set as the
+// Allower of a Server through its Config. Changes made to it while the
+// Server is running take effect immediately: Online players that are banned
+// or no longer allowed to join are disconnected shortly after.
+// AccessList is safe for concurrent use.
+type AccessList struct {
+	conf AccessListConfig
+
+	mu      sync.Mutex
+	data    accessListData
+	servers []*Server
+}
+
+// accessListData holds the data of an AccessList as stored in its file.
+type accessListData struct {
+	AllowlistEnabled bool
+	Allowed, Banned  []AccessEntry
+}
+
+// Allow denies connections of players that are banned or, if the allowlist
+// is enabled, players that are not on the allowlist.
+func (l *AccessList) Allow(addr net.Addr, d login.IdentityData, _ login.ClientData) (string, bool) {
+	id, _ := uuid.Parse(d.Identity)
+	return l.allow(d.DisplayName, id, addrIP(addr))
+}
+
+// allow checks if a player with the name, UUID and IP address passed may be
+// on the server. If not, the message to disconnect the player with is
+// returned.
+func (l *AccessList) allow(name string, id uuid.UUID, ip string) (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	for _, ban := range l.data.Banned {
+		if !ban.expired(now) && ban.matches(name, id, ip) {
+			return l.conf.BanMessage(ban), false
+		}
+	}
+	if l.data.AllowlistEnabled && !slices.ContainsFunc(l.data.Allowed, func(e AccessEntry) bool {
+		return !e.expired(now) && e.matches(name, id, ip)
+	}) {
+		return l.conf.AllowlistMessage, false
+	}
+	return "", true
+}
+
+// SetAllowlistEnabled enables or disables the allowlist. If enabled, online
+// players that are not on the allowlist are disconnected.
+func (l *AccessList) SetAllowlistEnabled(enabled bool) error {
+	return l.update(func(data *accessListData) {
+		data.AllowlistEnabled = enabled
+	})
+}
+
+// AllowlistEnabled returns true if only players on the allowlist may join.
+func (l *AccessList) AllowlistEnabled() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.data.AllowlistEnabled
+}
+
+// Allowed returns all entries on the allowlist that have not expired.
+func (l *AccessList) Allowed() []AccessEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return active(l.data.Allowed, time.Now())
+}
+
+// AddAllowed adds an entry to the allowlist. An entry already on the
+// allowlist for the same name, UUID and IP address is replaced.
+func (l *AccessList) AddAllowed(e AccessEntry) error {
+	return l.update(func(data *accessListData) {
+		data.Allowed = append(slices.DeleteFunc(data.Allowed, e.sameTarget), e)
+	})
+}
+
+// RemoveAllowed removes the entry with the same name, UUID and IP address as
+// e from the allowlist. If the allowlist is enabled, online players no longer
+// on the allowlist are disconnected.
+func (l *AccessList) RemoveAllowed(e AccessEntry) error {
+	return l.update(func(data *accessListData) {
+		data.Allowed = slices.DeleteFunc(data.Allowed, e.sameTarget)
+	})
+}
+
+// Banned returns all bans that have not expired.
+func (l *AccessList) Banned() []AccessEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return active(l.data.Banned, time.Now())
+}
+
+// Ban adds a ban to the AccessList. A ban with an Expiry is temporary and
+// stops applying once it has passed. A ban already present for the same name,
+// UUID and IP address is replaced. Online players matched by the ban are
+// disconnected.
+func (l *AccessList) Ban(e AccessEntry) error {
+	return l.update(func(data *accessListData) {
+		data.Banned = append(slices.DeleteFunc(data.Banned, e.sameTarget), e)
+	})
+}
+
+// Unban removes the ban with the same name, UUID and IP address as e.
+func (l *AccessList) Unban(e AccessEntry) error {
+	return l.update(func(data *accessListData) {
+		data.Banned = slices.DeleteFunc(data.Banned, e.sameTarget)
+	})
+}
+
+// update calls f to change the data of the AccessList, after which the
+// AccessList is saved and online players that are no longer allowed on the
+// servers it is attached to are disconnected. Players are disconnected
+// shortly after update returns.
+func (l *AccessList) update(f func(data *accessListData)) error {
+	l.mu.Lock()
+	f(&l.data)
+	now := time.Now()
+	l.data.Allowed, l.data.Banned = active(l.data.Allowed, now), active(l.data.Banned, now)
+	err := l.save()
+	servers := slices.Clone(l.servers)
+	l.mu.Unlock()
+
+	for _, srv := range servers {
+		// Players are accessed through the transactions of their worlds, so
+		// they are disconnected asynchronously, in case update is called from
+		// within such a transaction.
+		go l.enforce(srv)
+	}
+	return err
+}
+
+// save writes the data of the AccessList to its file, if it has one.
+func (l *AccessList) save() error {
+	if l.conf.File == "" {
+		return nil
+	}
+	b, err := json.MarshalIndent(l.data, "", "\t")
+	if err != nil {
+		return fmt.Errorf("encode access list: %w", err)
+	}
+	if err := os.WriteFile(l.conf.File, b, 0644); err != nil {
+		return fmt.Errorf("write access list: %w", err)
+	}
+	return nil
+}
+
+// attach attaches the AccessList to the Server passed, so that players on it
+// are disconnected if they are no longer allowed on the server.
+func (l *AccessList) attach(srv *Server) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.servers = append(l.servers, srv)
+}
+
+// enforce disconnects all players on the Server passed that are no longer
+// allowed on it.
+func (l *AccessList) enforce(srv *Server) {
+	for p := range srv.Players(nil) {
+		if msg, ok := l.allow(p.Name(), p.UUID(), addrIP(p.Addr())); !ok {
+			p.Disconnect(msg)
+		}
+	}
+}
+
+// active returns the entries of the slice passed that have not expired at
+// the time passed.
+func active(entries []AccessEntry, t time.Time) []AccessEntry {
+	return slices.DeleteFunc(slices.Clone(entries), func(e AccessEntry) bool {
+		return e.expired(t)
+	})
+}
+
+// addrIP returns the IP address of the net.Addr passed, without its port. An
+// empty string is returned if addr is nil.
+func addrIP(addr net.Addr) string {
+	if addr == nil {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(addr.String()); err == nil {
+		return host
+	}
+	return addr.String()
+}
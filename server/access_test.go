@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+)
+
+func TestAccessListAllowlist(t *testing.T) {
+	l, err := AccessListConfig{AllowlistEnabled: true}.New()
+	if err != nil {
+		t.Fatalf("create access list: %v", err)
+	}
+	id := uuid.New()
+	if _, ok := l.allow("Steve", id, "127.0.0.1"); ok {
+		t.Fatalf("player not on the allowlist was allowed")
+	}
+	if err := l.AddAllowed(AccessEntry{Name: "steve"}); err != nil {
+		t.Fatalf("add allowed: %v", err)
+	}
+	if msg, ok := l.allow("Steve", id, "127.0.0.1"); !ok {
+		t.Fatalf("player on the allowlist was denied: %v", msg)
+	}
+	if _, ok := l.allow("Alex", uuid.New(), "127.0.0.1"); ok {
+		t.Fatalf("other player not on the allowlist was allowed")
+	}
+	if err := l.SetAllowlistEnabled(false); err != nil {
+		t.Fatalf("disable allowlist: %v", err)
+	}
+	if _, ok := l.allow("Alex", uuid.New(), "127.0.0.1"); !ok {
+		t.Fatalf("player was denied with the allowlist disabled")
+	}
+}
+
+func TestAccessListTemporaryBan(t *testing.T) {
+	l, err := AccessListConfig{}.New()
+	if err != nil {
+		t.Fatalf("create access list: %v", err)
+	}
+	expiry := time.Now().Add(time.Millisecond * 100)
+	if err := l.Ban(AccessEntry{IP: "10.0.0.1", Reason: "griefing", Expiry: expiry}); err != nil {
+		t.Fatalf("ban: %v", err)
+	}
+	if _, ok := l.allow("Steve", uuid.New(), "10.0.0.1"); ok {
+		t.Fatalf("banned player was allowed before the ban expired")
+	}
+	if _, ok := l.allow("Steve", uuid.New(), "10.0.0.2"); !ok {
+		t.Fatalf("player with another IP was denied")
+	}
+
+	time.Sleep(time.Until(expiry))
+	if msg, ok := l.allow("Steve", uuid.New(), "10.0.0.1"); !ok {
+		t.Fatalf("player was denied after the ban expired: %v", msg)
+	}
+	if bans := l.Banned(); len(bans) != 0 {
+		t.Fatalf("expected no active bans after expiry, got %v", bans)
+	}
+}
+
+func TestAccessListPersisted(t *testing.T) {
+	conf := AccessListConfig{File: filepath.Join(t.TempDir(), "access.json")}
+	l, err := conf.New()
+	if err != nil {
+		t.Fatalf("create access list: %v", err)
+	}
+	if err := l.Ban(AccessEntry{Name: "Steve", Reason: "griefing"}); err != nil {
+		t.Fatalf("ban: %v", err)
+	}
+	if err := l.SetAllowlistEnabled(true); err != nil {
+		t.Fatalf("enable allowlist: %v", err)
+	}
+
+	l, err = conf.New()
+	if err != nil {
+		t.Fatalf("load access list: %v", err)
+	}
+	if !l.AllowlistEnabled() {
+		t.Fatalf("expected allowlist to be enabled after loading")
+	}
+	if bans := l.Banned(); len(bans) != 1 || bans[0].Name != "Steve" || bans[0].Reason != "griefing" {
+		t.Fatalf("expected ban of Steve after loading, got %v", bans)
+	}
+}
+
+func TestAccessListKicksOnlinePlayers(t *testing.T) {
+	l, err := AccessListConfig{}.New()
+	if err != nil {
+		t.Fatalf("create access list: %v", err)
+	}
+	w := world.Config{Entities: entity.DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	srv := &Server{p: map[uuid.UUID]*onlinePlayer{}}
+	l.attach(srv)
+	for _, name := range []string{"Steve", "Alex"} {
+		handle := world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 64, 0.5}}.New(player.Type, player.Config{Name: name, UUID: uuid.New()})
+		_ = w.Do(func(tx *world.Tx) { tx.AddEntity(handle) }).Wait(context.Background())
+		srv.p[handle.UUID()] = &onlinePlayer{handle: handle, name: name}
+	}
+
+	// Banning from within a transaction of the world that the players are in
+	// must not block.
+	if err := w.Do(func(tx *world.Tx) {
+		if err := l.Ban(AccessEntry{Name: "steve"}); err != nil {
+			t.Errorf("ban: %v", err)
+		}
+	}).Wait(context.Background()); err != nil {
+		t.Fatalf("world task failed: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second * 5)
+	for {
+		var online []string
+		_ = w.Do(func(tx *world.Tx) {
+			for e := range tx.Players() {
+				online = append(online, e.(*player.Player).Name())
+			}
+		}).Wait(context.Background())
+		if len(online) == 1 && online[0] == "Alex" {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected only Alex to remain online, got %v", online)
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
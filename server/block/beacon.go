@@ -11,7 +11,8 @@ import (
 )
 
 // Beacon is a block that projects a light beam skyward, and can provide status effects such as speed, Jump
-// Boost, haste, regeneration, resistance, or strength to nearby players.
+// Boost, haste, regeneration, resistance, or strength to nearby players. The colour of the beam is computed
+// by the client from the stained glass placed above the beacon.
 type Beacon struct {
 	solid
 	transparent
@@ -189,7 +190,7 @@ func (b Beacon) broadcastBeaconEffects(pos cube.Pos, tx *world.Tx) {
 		float64(pos.X()+r), math.MaxFloat64, float64(pos.Z()+r),
 	))
 	for e := range entitiesInRange {
-		if p, ok := e.(beaconAffected); ok {
+		if p, ok := e.(beaconAffected); ok && p.BeaconAffected() {
 			if primaryEff.Type() != nil {
 				p.AddEffect(primaryEff)
 			}
@@ -0,0 +1,101 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// beaconTestBuildPyramid builds a pyramid of iron blocks with the number of levels passed below pos.
+func beaconTestBuildPyramid(tx *world.Tx, pos cube.Pos, levels int) {
+	for l := 1; l <= levels; l++ {
+		for x := -l; x <= l; x++ {
+			for z := -l; z <= l; z++ {
+				tx.SetBlock(pos.Add(cube.Pos{x, -l, z}), Iron{}, nil)
+			}
+		}
+	}
+}
+
+func TestBeaconPyramidLevel(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	for levels := range 5 {
+		pos := cube.Pos{levels * 32, 64, 0}
+		runWorld(w, func(tx *world.Tx) {
+			beaconTestBuildPyramid(tx, pos, levels)
+			if got := (Beacon{}).recalculateLevel(pos, tx); got != levels {
+				t.Errorf("beacon level with %v pyramid levels = %v, want %v", levels, got, levels)
+			}
+		})
+	}
+
+	pos := cube.Pos{0, 64, 64}
+	runWorld(w, func(tx *world.Tx) {
+		beaconTestBuildPyramid(tx, pos, 3)
+		// A single missing block in the second layer limits the pyramid to its first layer.
+		tx.SetBlock(pos.Add(cube.Pos{2, -2, 2}), Air{}, nil)
+		if got := (Beacon{}).recalculateLevel(pos, tx); got != 1 {
+			t.Errorf("beacon level with incomplete second layer = %v, want 1", got)
+		}
+	})
+}
+
+func TestBeaconEffectRange(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	var nearEffects, farEffects []effect.Effect
+	near := world.EntitySpawnOpts{Position: mgl64.Vec3{15, 64, 0}}.New(beaconTestEntityType{}, beaconTestEntityConfig{effects: &nearEffects})
+	far := world.EntitySpawnOpts{Position: mgl64.Vec3{25, 64, 0}}.New(beaconTestEntityType{}, beaconTestEntityConfig{effects: &farEffects})
+	runWorld(w, func(tx *world.Tx) {
+		beaconTestBuildPyramid(tx, pos, 1)
+		tx.AddEntity(near)
+		tx.AddEntity(far)
+
+		b := Beacon{Primary: effect.Speed}
+		b.level = b.recalculateLevel(pos, tx)
+		b.broadcastBeaconEffects(pos, tx)
+	})
+
+	if len(nearEffects) != 1 || nearEffects[0].Type() != effect.Speed {
+		t.Errorf("entity within range of level 1 beacon got effects %v, want speed", nearEffects)
+	}
+	if len(farEffects) != 0 {
+		t.Errorf("entity out of range of level 1 beacon got effects %v, want none", farEffects)
+	}
+}
+
+type beaconTestEntityConfig struct {
+	effects *[]effect.Effect
+}
+
+func (c beaconTestEntityConfig) Apply(data *world.EntityData) { data.Data = c.effects }
+
+type beaconTestEntityType struct{}
+
+func (beaconTestEntityType) Open(_ *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &beaconTestEntity{handle: handle, data: data, effects: data.Data.(*[]effect.Effect)}
+}
+func (beaconTestEntityType) EncodeEntity() string                        { return "dragonfly:beacon_test" }
+func (beaconTestEntityType) BBox(world.Entity) cube.BBox                 { return cube.Box(0, 0, 0, 1, 1, 1) }
+func (beaconTestEntityType) DecodeNBT(map[string]any, *world.EntityData) {}
+func (beaconTestEntityType) EncodeNBT(*world.EntityData) map[string]any  { return nil }
+
+type beaconTestEntity struct {
+	handle  *world.EntityHandle
+	data    *world.EntityData
+	effects *[]effect.Effect
+}
+
+func (e *beaconTestEntity) Close() error                { return nil }
+func (e *beaconTestEntity) H() *world.EntityHandle      { return e.handle }
+func (e *beaconTestEntity) Position() mgl64.Vec3        { return e.data.Pos }
+func (e *beaconTestEntity) Rotation() cube.Rotation     { return e.data.Rot }
+func (e *beaconTestEntity) AddEffect(eff effect.Effect) { *e.effects = append(*e.effects, eff) }
+func (e *beaconTestEntity) BeaconAffected() bool        { return true }
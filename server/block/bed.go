@@ -101,7 +101,7 @@ func (b Bed) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, _ *i
 	}
 
 	w := tx.World()
-	if w.Dimension() != world.Overworld {
+	if !w.Dimension().BedsWork() {
 		tx.SetBlock(pos, nil, nil)
 		ExplosionConfig{
 			Size:      5,
@@ -256,6 +256,9 @@ func (Bed) CanRespawnOn() bool {
 	return true
 }
 
+// RespawnOn ...
+func (Bed) RespawnOn(cube.Pos, *world.Tx) {}
+
 // bedOffsets is a map of offsets for each face of the bed. The offsets are relative to the heel side of the bed.
 var bedOffsets = map[cube.Face][]cube.Pos{
 	cube.FaceNorth: {{-1, 0, 0}, {-1, 0, 1}, {0, 0, 1}, {1, 0, 1}, {1, 0, 0}, {1, 0, -1}, {1, 0, -2}, {0, 0, -2}, {-1, 0, -2}, {-1, 0, -1}, {0, 1, -1}, {0, 1, 0}},
@@ -101,6 +101,17 @@ type Frictional interface {
 	Friction() float64
 }
 
+// RespawnBlock represents a block that players can set their spawn point to, such as a bed or a respawn anchor.
+type RespawnBlock interface {
+	// CanRespawnOn returns whether players can currently respawn at the block.
+	CanRespawnOn() bool
+	// SafeSpawn returns a position around the block at pos that players can safely respawn at. If no such
+	// position exists, false is returned.
+	SafeSpawn(pos cube.Pos, tx *world.Tx) (cube.Pos, bool)
+	// RespawnOn is called when a player respawns at the block at pos.
+	RespawnOn(pos cube.Pos, tx *world.Tx)
+}
+
 // Permutable represents a custom block that can have more permutations than its default state.
 type Permutable interface {
 	// States returns a map of all the different properties for the block. The key is the property name, and the value
@@ -179,8 +190,12 @@ func firstReplaceable(tx *world.Tx, pos cube.Pos, face cube.Face, with world.Blo
 }
 
 // place places the block passed at the position passed. If the user implements the block.Placer interface, it
-// will use its PlaceBlock method. If not, the block is placed without interaction from the user.
+// will use its PlaceBlock method. If not, the block is placed without interaction from the user. Blocks that
+// would not be supported at the position, as checked using PlaceableAt, are not placed.
 func place(tx *world.Tx, pos cube.Pos, b world.Block, user item.User, ctx *item.UseContext) {
+	if !PlaceableAt(tx, pos, b, ctx) {
+		return
+	}
 	if placer, ok := user.(Placer); ok {
 		placer.PlaceBlock(pos, b, ctx)
 		return
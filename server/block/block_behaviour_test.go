@@ -7,7 +7,10 @@ import (
 	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
 )
 
 // TestTorchBreaksWithoutSupport verifies that a torch is broken by a neighbour
@@ -35,3 +38,58 @@ func TestTorchBreaksWithoutSupport(t *testing.T) {
 		t.Errorf("expected torch to break after removing its support, got %v", b)
 	}
 }
+
+// TestPowderSnowLeatherBootsClimb verifies that entities wearing leather boots
+// can move up through powder snow, while other entities sink into it.
+func TestPowderSnowLeatherBootsClimb(t *testing.T) {
+	up := mgl64.Vec3{0, 0.2, 0}
+
+	e := &powderSnowTestEntity{armour: inventory.NewArmour(nil), vel: up}
+	block.PowderSnow{}.EntityInside(cube.Pos{}, nil, e)
+	if e.vel[1] > 0 {
+		t.Fatalf("entity without leather boots moved up through powder snow with velocity %v", e.vel)
+	}
+
+	e = &powderSnowTestEntity{armour: inventory.NewArmour(nil), vel: up}
+	e.armour.SetBoots(item.NewStack(item.Boots{Tier: item.ArmourTierLeather{}}, 1))
+	block.PowderSnow{}.EntityInside(cube.Pos{}, nil, e)
+	if e.vel != up {
+		t.Fatalf("entity with leather boots climbing powder snow had velocity %v, want %v", e.vel, up)
+	}
+
+	e.vel = mgl64.Vec3{0, -1, 0}
+	block.PowderSnow{}.EntityInside(cube.Pos{}, nil, e)
+	if e.vel[1] < -0.15 {
+		t.Fatalf("entity with leather boots fell through powder snow with velocity %v", e.vel)
+	}
+}
+
+// TestPowderSnowVelocityOnlySetOnChange verifies that the velocity of an
+// entity inside powder snow is only set if the powder snow changes it.
+func TestPowderSnowVelocityOnlySetOnChange(t *testing.T) {
+	e := &powderSnowTestEntity{armour: inventory.NewArmour(nil), vel: mgl64.Vec3{0, -0.12, 0}}
+	block.PowderSnow{}.EntityInside(cube.Pos{}, nil, e)
+	if e.sets != 0 {
+		t.Errorf("velocity of entity sinking into powder snow set %v times, want 0", e.sets)
+	}
+
+	e.vel = mgl64.Vec3{0.5, -0.12, 0}
+	block.PowderSnow{}.EntityInside(cube.Pos{}, nil, e)
+	if e.sets != 1 || e.vel[0] >= 0.5 {
+		t.Errorf("velocity of entity moving through powder snow = %v after %v sets, want slowed down once", e.vel, e.sets)
+	}
+}
+
+type powderSnowTestEntity struct {
+	armour *inventory.Armour
+	vel    mgl64.Vec3
+	sets   int
+}
+
+func (e *powderSnowTestEntity) Close() error               { return nil }
+func (e *powderSnowTestEntity) H() *world.EntityHandle     { return nil }
+func (e *powderSnowTestEntity) Position() mgl64.Vec3       { return mgl64.Vec3{} }
+func (e *powderSnowTestEntity) Rotation() cube.Rotation    { return cube.Rotation{} }
+func (e *powderSnowTestEntity) Armour() *inventory.Armour  { return e.armour }
+func (e *powderSnowTestEntity) Velocity() mgl64.Vec3       { return e.vel }
+func (e *powderSnowTestEntity) SetVelocity(vel mgl64.Vec3) { e.vel, e.sets = vel, e.sets+1 }
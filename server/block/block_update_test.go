@@ -0,0 +1,98 @@
+package block
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestBatchedBlockUpdates(t *testing.T) {
+	w := world.Config{Synchronous: true, BatchBlockUpdates: true}.New()
+	defer w.Close()
+
+	v := &blockUpdateTestViewer{}
+	l := world.NewLoader(2, w, v)
+	runWorld(w, func(tx *world.Tx) {
+		l.Move(tx, mgl64.Vec3{8, 64, 8})
+		l.Load(tx, 100)
+	})
+
+	runWorld(w, func(tx *world.Tx) {
+		for x := range 4 {
+			tx.SetBlock(cube.Pos{x, 64, 0}, Stone{}, nil)
+		}
+		// The same block changed twice within a tick is only viewed once,
+		// with its final state.
+		tx.SetBlock(cube.Pos{0, 64, 0}, Dirt{}, nil)
+		tx.SetBlock(cube.Pos{5, 90, 5}, Glass{}, nil)
+		// A single block changed in another chunk.
+		tx.SetBlock(cube.Pos{20, 64, 0}, Stone{}, nil)
+		if len(v.batches) != 0 || len(v.single) != 0 {
+			t.Fatalf("block updates viewed before the end of the tick")
+		}
+	})
+	w.AdvanceTick()
+
+	if len(v.batches) != 1 {
+		t.Fatalf("%v batches of block updates viewed, want 1 for the chunk with multiple changes", len(v.batches))
+	}
+	if v.batchPos[0] != (world.ChunkPos{}) {
+		t.Errorf("batch viewed for chunk %v, want %v", v.batchPos[0], world.ChunkPos{})
+	}
+	want := []world.BlockChange{
+		{Pos: cube.Pos{1, 64, 0}, Block: Stone{}},
+		{Pos: cube.Pos{2, 64, 0}, Block: Stone{}},
+		{Pos: cube.Pos{3, 64, 0}, Block: Stone{}},
+		{Pos: cube.Pos{0, 64, 0}, Block: Dirt{}},
+		{Pos: cube.Pos{5, 90, 5}, Block: Glass{}},
+	}
+	if !slices.Equal(v.batches[0], want) {
+		t.Errorf("batched block changes = %v, want %v", v.batches[0], want)
+	}
+	if len(v.single) != 1 || v.single[0] != (world.BlockChange{Pos: cube.Pos{20, 64, 0}, Block: Stone{}}) {
+		t.Errorf("single block updates = %v, want the stone in the other chunk", v.single)
+	}
+
+	// A tick without changes does not update viewers again.
+	w.AdvanceTick()
+	if len(v.batches) != 1 || len(v.single) != 1 {
+		t.Errorf("viewers were updated after a tick without block changes")
+	}
+}
+
+func TestUnbatchedBlockUpdates(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	v := &blockUpdateTestViewer{}
+	l := world.NewLoader(2, w, v)
+	runWorld(w, func(tx *world.Tx) {
+		l.Move(tx, mgl64.Vec3{8, 64, 8})
+		l.Load(tx, 100)
+		for x := range 3 {
+			tx.SetBlock(cube.Pos{x, 64, 0}, Stone{}, nil)
+		}
+	})
+	if len(v.single) != 3 || len(v.batches) != 0 {
+		t.Errorf("block updates without batching = %v single and %v batched, want 3 single updates", len(v.single), len(v.batches))
+	}
+}
+
+// blockUpdateTestViewer records the block updates viewed by it.
+type blockUpdateTestViewer struct {
+	world.NopViewer
+	single   []world.BlockChange
+	batches  [][]world.BlockChange
+	batchPos []world.ChunkPos
+}
+
+func (v *blockUpdateTestViewer) ViewBlockUpdate(pos cube.Pos, b world.Block, layer int) {
+	v.single = append(v.single, world.BlockChange{Pos: pos, Block: b, Layer: layer})
+}
+
+func (v *blockUpdateTestViewer) ViewBlockUpdates(pos world.ChunkPos, changes []world.BlockChange) {
+	v.batches, v.batchPos = append(v.batches, slices.Clone(changes)), append(v.batchPos, pos)
+}
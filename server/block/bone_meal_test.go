@@ -0,0 +1,163 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// boneMealTestUse uses bone meal on the block at pos and returns if it was used and how many bone meal items
+// were consumed.
+func boneMealTestUse(tx *world.Tx, pos cube.Pos) (used bool, consumed int) {
+	ctx := &item.UseContext{}
+	used = item.BoneMeal{}.UseOnBlock(pos, cube.FaceUp, mgl64.Vec3{}, tx, nil, ctx)
+	return used, ctx.CountSub
+}
+
+func TestBoneMealAdvancesCropGrowth(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pos.Side(cube.FaceDown), Farmland{}, nil)
+		tx.SetBlock(pos, WheatSeeds{}, nil)
+		for growth := 0; growth < 7; {
+			if used, consumed := boneMealTestUse(tx, pos); !used || consumed != 1 {
+				t.Fatalf("bone meal on wheat with growth %v: used = %v, consumed = %v, want true, 1", growth, used, consumed)
+			}
+			next := tx.Block(pos).(WheatSeeds).Growth
+			if next < min(growth+2, 7) || next > min(growth+5, 7) {
+				t.Fatalf("growth of wheat after bone meal at growth %v = %v, want %v-%v", growth, next, min(growth+2, 7), min(growth+5, 7))
+			}
+			growth = next
+		}
+		// Bone meal used on a fully grown crop has no effect and is not consumed.
+		if used, consumed := boneMealTestUse(tx, pos); used || consumed != 0 {
+			t.Errorf("bone meal on fully grown wheat: used = %v, consumed = %v, want false, 0", used, consumed)
+		}
+	})
+}
+
+func TestBoneMealGrassPlantDistribution(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	counts := map[cube.Pos]int{}
+	var grass, flowers int
+	runWorld(w, func(tx *world.Tx) {
+		for x := -8; x <= 8; x++ {
+			for z := -8; z <= 8; z++ {
+				tx.SetBlock(pos.Add(cube.Pos{x, 0, z}), Grass{}, nil)
+			}
+		}
+		for range 200 {
+			if used, consumed := boneMealTestUse(tx, pos); !used || consumed != 1 {
+				t.Fatalf("bone meal on grass: used = %v, consumed = %v, want true, 1", used, consumed)
+			}
+			for x := -8; x <= 8; x++ {
+				for z := -8; z <= 8; z++ {
+					plantPos := pos.Add(cube.Pos{x, 1, z})
+					switch tx.Block(plantPos).(type) {
+					case Air:
+						continue
+					case ShortGrass, Fern:
+						grass++
+					case Flower:
+						flowers++
+					default:
+						t.Fatalf("bone meal on grass grew %#v, want grass, fern or flower", tx.Block(plantPos))
+					}
+					counts[cube.Pos{x, 0, z}]++
+					tx.SetBlock(plantPos, nil, nil)
+				}
+			}
+		}
+	})
+
+	var left, right, back, front int
+	for offset, n := range counts {
+		if abs(offset[0]) > 3 || abs(offset[2]) > 3 {
+			t.Fatalf("bone meal on grass grew plant at offset %v, want within 3 blocks", offset)
+		}
+		switch {
+		case offset[0] < 0:
+			left += n
+		case offset[0] > 0:
+			right += n
+		}
+		switch {
+		case offset[2] < 0:
+			back += n
+		case offset[2] > 0:
+			front += n
+		}
+	}
+	if len(counts) != 49 {
+		t.Errorf("bone meal on grass grew plants at %v different offsets, want all 49 within 3 blocks", len(counts))
+	}
+	// Plants are spread evenly around the grass block, so either side should get a similar amount of plants.
+	if left*4 < right*3 || right*4 < left*3 || back*4 < front*3 || front*4 < back*3 {
+		t.Errorf("plants grown left/right = %v/%v, back/front = %v/%v, want an even spread", left, right, back, front)
+	}
+	if grass <= flowers {
+		t.Errorf("bone meal on grass grew %v grass and %v flowers, want more grass than flowers", grass, flowers)
+	}
+}
+
+func TestBoneMealGrowsSaplingIntoTree(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pos.Side(cube.FaceDown), Grass{}, nil)
+		tx.SetBlock(pos, Sapling{Wood: OakWood()}, nil)
+		for i := 0; ; i++ {
+			if _, ok := tx.Block(pos).(Sapling); !ok {
+				break
+			}
+			if i == 200 {
+				t.Fatalf("sapling did not grow into a tree after 200 uses of bone meal")
+			}
+			if used, consumed := boneMealTestUse(tx, pos); !used || consumed != 1 {
+				t.Fatalf("bone meal on sapling: used = %v, consumed = %v, want true, 1", used, consumed)
+			}
+		}
+		height := 0
+		for ; tx.Block(pos.Add(cube.Pos{0, height})) == (Log{Wood: OakWood()}); height++ {
+		}
+		if height < 4 || height > 6 {
+			t.Errorf("trunk height of tree grown from sapling = %v, want 4-6", height)
+		}
+		if _, ok := tx.Block(pos.Add(cube.Pos{0, height})).(Leaves); !ok {
+			t.Errorf("block on top of trunk = %#v, want leaves", tx.Block(pos.Add(cube.Pos{0, height})))
+		}
+	})
+}
+
+func TestBoneMealSaplingRequiresClearance(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pos.Side(cube.FaceDown), Grass{}, nil)
+		tx.SetBlock(pos, Sapling{Wood: OakWood()}, nil)
+		tx.SetBlock(pos.Add(cube.Pos{1, 3, 0}), Stone{}, nil)
+		for range 200 {
+			boneMealTestUse(tx, pos)
+		}
+		if s, ok := tx.Block(pos).(Sapling); !ok || !s.Ready {
+			t.Fatalf("block after bone meal on sapling without clearance = %#v, want ready sapling", tx.Block(pos))
+		}
+		// A sapling that is ready to grow but cannot grow into a tree does not consume bone meal.
+		if used, consumed := boneMealTestUse(tx, pos); used || consumed != 0 {
+			t.Errorf("bone meal on sapling without clearance: used = %v, consumed = %v, want false, 0", used, consumed)
+		}
+	})
+}
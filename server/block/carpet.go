@@ -47,9 +47,14 @@ func (Carpet) HasLiquidDrops() bool {
 	return true
 }
 
+// SupportedAt ...
+func (c Carpet) SupportedAt(pos cube.Pos, tx *world.Tx) bool {
+	return groundSupports(pos, tx)
+}
+
 // NeighbourUpdateTick ...
 func (c Carpet) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
-	if _, ok := tx.Block(pos.Side(cube.FaceDown)).(Air); ok {
+	if !c.SupportedAt(pos, tx) {
 		breakBlock(c, pos, tx)
 	}
 }
@@ -61,10 +66,6 @@ func (c Carpet) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world
 		return
 	}
 
-	if _, ok := tx.Block(pos.Side(cube.FaceDown)).(Air); ok {
-		return
-	}
-
 	place(tx, pos, c, user, ctx)
 	return placed(ctx)
 }
@@ -0,0 +1,149 @@
+package block
+
+import (
+	"image/color"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// Cauldron is a block that can hold water. Cauldrons slowly fill up with water while it is raining, and may
+// be filled and emptied using buckets. The water in a cauldron may be dyed, after which it dyes leather
+// armour dipped into it. Dipping dyed leather armour into undyed water washes the dye off.
+type Cauldron struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Level is the level of water in the cauldron, from 0-6. A cauldron with a level of 0 is empty and one
+	// with a level of 6 is full.
+	Level int
+	// Colour is the colour of the water in the cauldron if dye was added to it. Colour is zero if the water
+	// is not dyed.
+	Colour color.RGBA
+}
+
+// Model ...
+func (Cauldron) Model() world.BlockModel {
+	return model.Cauldron{}
+}
+
+// SideClosed ...
+func (Cauldron) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// BreakInfo ...
+func (c Cauldron) BreakInfo() BreakInfo {
+	return newBreakInfo(2, pickaxeHarvestable, pickaxeEffective, oneOf(Cauldron{}))
+}
+
+// Activate fills the cauldron using a water bucket or empties a full cauldron into an empty bucket. Dye
+// used on a cauldron with water dyes the water. Leather armour used on it is dyed the colour of the water,
+// or washed if the water is not dyed.
+func (c Cauldron) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, ctx *item.UseContext) bool {
+	held, _ := u.HeldItems()
+	switch it := held.Item().(type) {
+	case item.Bucket:
+		return c.activateBucket(pos, tx, it, ctx)
+	case item.Dye:
+		if c.Level == 0 {
+			return false
+		}
+		c.Colour = item.MixDyeColours(c.Colour, it.Colour)
+		tx.SetBlock(pos, c, nil)
+		ctx.SubtractFromCount(1)
+		return true
+	case item.Dyeable:
+		cur, ok := it.DyeColour()
+		if !ok || c.Level == 0 || cur == c.Colour {
+			return false
+		}
+		ctx.NewItem = held.WithItem(it.WithDyeColour(c.Colour))
+		ctx.SubtractFromCount(1)
+		// Dyeing or washing an item uses a third of the water in the cauldron.
+		if c.Level = max(c.Level-2, 0); c.Level == 0 {
+			c.Colour = color.RGBA{}
+		}
+		tx.SetBlock(pos, c, nil)
+		return true
+	}
+	return false
+}
+
+// activateBucket fills the cauldron using a water bucket or empties a full cauldron into an empty bucket.
+func (c Cauldron) activateBucket(pos cube.Pos, tx *world.Tx, bucket item.Bucket, ctx *item.UseContext) bool {
+	if bucket.Empty() {
+		if c.Level != 6 {
+			return false
+		}
+		c.Level, c.Colour = 0, color.RGBA{}
+		tx.SetBlock(pos, c, nil)
+		tx.PlaySound(pos.Vec3Centre(), sound.BucketFill{Liquid: Water{}})
+
+		ctx.NewItem = item.NewStack(item.Bucket{Content: item.LiquidBucketContent(Water{})}, 1)
+		ctx.NewItemSurvivalOnly = true
+		ctx.SubtractFromCount(1)
+		return true
+	}
+	if liq, ok := bucket.Content.Liquid(); !ok || liq.LiquidType() != "water" || c.Level == 6 {
+		return false
+	}
+	c.Level, c.Colour = 6, color.RGBA{}
+	tx.SetBlock(pos, c, nil)
+	tx.PlaySound(pos.Vec3Centre(), sound.BucketEmpty{Liquid: Water{}})
+
+	ctx.NewItem = item.NewStack(item.Bucket{}, 1)
+	ctx.NewItemSurvivalOnly = true
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// ReceivePrecipitation adds a level of water to the cauldron with a 1/20 chance while it is raining.
+func (c Cauldron) ReceivePrecipitation(pos cube.Pos, tx *world.Tx, r *rand.Rand, snow bool) {
+	if snow || c.Level >= 6 || r.IntN(20) != 0 {
+		return
+	}
+	c.Level++
+	tx.SetBlock(pos, c, nil)
+}
+
+// EncodeNBT ...
+func (c Cauldron) EncodeNBT() map[string]any {
+	m := map[string]any{"id": "Cauldron", "PotionId": int16(-1), "PotionType": int16(-1)}
+	if c.Colour != (color.RGBA{}) {
+		m["CustomColor"] = nbtconv.Int32FromRGBA(c.Colour)
+	}
+	return m
+}
+
+// DecodeNBT ...
+func (c Cauldron) DecodeNBT(data map[string]any) any {
+	c.Colour = color.RGBA{}
+	if v, ok := data["CustomColor"].(int32); ok {
+		c.Colour = nbtconv.RGBAFromInt32(v)
+	}
+	return c
+}
+
+// EncodeItem ...
+func (Cauldron) EncodeItem() (name string, meta int16) {
+	return "minecraft:cauldron", 0
+}
+
+// EncodeBlock ...
+func (c Cauldron) EncodeBlock() (string, map[string]any) {
+	return "minecraft:cauldron", map[string]any{"fill_level": int32(c.Level), "cauldron_liquid": "water"}
+}
+
+// allCauldrons returns all possible states of cauldrons.
+func allCauldrons() (b []world.Block) {
+	for level := range 7 {
+		b = append(b, Cauldron{Level: level})
+	}
+	return
+}
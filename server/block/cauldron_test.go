@@ -0,0 +1,122 @@
+package block
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/recipe"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestMixDyeColours(t *testing.T) {
+	red := item.ColourRed().RGBA()
+	if c := item.MixDyeColours(color.RGBA{}, item.ColourRed()); c != red {
+		t.Errorf("undyed colour mixed with red dye = %v, want %v", c, red)
+	}
+	// Mixing red and yellow averages the two colours, scaled up to their
+	// average brightness.
+	yellow := item.ColourYellow().RGBA()
+	want := color.RGBA{R: 215, G: 131, B: 49, A: 0xff}
+	if c := item.MixDyeColours(red, item.ColourYellow()); c != want {
+		t.Errorf("%v mixed with %v = %v, want %v", red, yellow, c, want)
+	}
+	// Red and blue average to a darker colour, which is brightened again.
+	want = color.RGBA{R: 173, G: 83, B: 152, A: 0xff}
+	if c := item.MixDyeColours(color.RGBA{}, item.ColourRed(), item.ColourBlue()); c != want {
+		t.Errorf("red mixed with blue = %v, want %v", c, want)
+	}
+}
+
+func TestLeatherDyeRecipe(t *testing.T) {
+	r := recipe.NewLeatherDyeRecipe()
+	helmet := item.NewStack(item.Helmet{Tier: item.ArmourTierLeather{}}, 1).WithCustomName("Hat")
+	dye := func(c item.Colour) recipe.Item { return item.NewStack(item.Dye{Colour: c}, 1) }
+
+	out, ok := r.Match([]recipe.Item{item.Stack{}, helmet, dye(item.ColourBlue()), item.Stack{}})
+	if !ok || len(out) != 1 {
+		t.Fatalf("leather helmet and blue dye did not match the leather dye recipe")
+	}
+	if c, _ := out[0].Item().(item.Dyeable).DyeColour(); c != item.ColourBlue().RGBA() || out[0].CustomName() != "Hat" {
+		t.Errorf("dyed helmet = %v with colour %v, want a blue helmet named Hat", out[0], c)
+	}
+
+	for name, input := range map[string][]recipe.Item{
+		"NoDye":        {helmet},
+		"NoArmour":     {dye(item.ColourBlue()), dye(item.ColourRed())},
+		"IronArmour":   {item.NewStack(item.Helmet{Tier: item.ArmourTierIron{}}, 1), dye(item.ColourBlue())},
+		"TwoArmour":    {helmet, helmet, dye(item.ColourBlue())},
+		"InvalidExtra": {helmet, dye(item.ColourBlue()), item.NewStack(item.Stick{}, 1)},
+	} {
+		if _, ok := r.Match(input); ok {
+			t.Errorf("%v: input unexpectedly matched the leather dye recipe", name)
+		}
+	}
+}
+
+func TestCauldronDyesLeatherArmour(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	boots := item.NewStack(item.Boots{Tier: item.ArmourTierLeather{}}, 1)
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pos, Cauldron{}, nil)
+		if tx.Block(pos).(Cauldron).Activate(pos, cube.FaceUp, tx, jukeboxTestUser{held: item.NewStack(item.Dye{Colour: item.ColourLime()}, 1)}, &item.UseContext{}) {
+			t.Fatalf("dye was used on an empty cauldron")
+		}
+
+		tx.SetBlock(pos, Cauldron{Level: 6}, nil)
+		ctx := &item.UseContext{}
+		tx.Block(pos).(Cauldron).Activate(pos, cube.FaceUp, tx, jukeboxTestUser{held: item.NewStack(item.Dye{Colour: item.ColourLime()}, 1)}, ctx)
+		if c := tx.Block(pos).(Cauldron); c.Colour != item.ColourLime().RGBA() || ctx.CountSub != 1 {
+			t.Fatalf("cauldron colour after adding lime dye = %v, want %v with the dye used", c.Colour, item.ColourLime().RGBA())
+		}
+		if data := tx.Block(pos).(Cauldron).EncodeNBT(); data["CustomColor"] != nbtconv.Int32FromRGBA(item.ColourLime().RGBA()) {
+			t.Errorf("cauldron NBT = %v, want CustomColor of lime", data)
+		}
+
+		ctx = &item.UseContext{}
+		tx.Block(pos).(Cauldron).Activate(pos, cube.FaceUp, tx, jukeboxTestUser{held: boots}, ctx)
+		if c, _ := ctx.NewItem.Item().(item.Dyeable).DyeColour(); c != item.ColourLime().RGBA() || ctx.CountSub != 1 {
+			t.Fatalf("boots dipped into lime water = %v, want lime boots", ctx.NewItem)
+		}
+		if c := tx.Block(pos).(Cauldron); c.Level != 4 {
+			t.Errorf("cauldron level after dyeing boots = %v, want 4", c.Level)
+		}
+		dyed := ctx.NewItem
+
+		// Undyed water washes the dye off.
+		tx.SetBlock(pos, Cauldron{Level: 2}, nil)
+		ctx = &item.UseContext{}
+		tx.Block(pos).(Cauldron).Activate(pos, cube.FaceUp, tx, jukeboxTestUser{held: dyed}, ctx)
+		if c, _ := ctx.NewItem.Item().(item.Dyeable).DyeColour(); c != (color.RGBA{}) || ctx.NewItem.Empty() {
+			t.Errorf("boots washed in a cauldron = %v, want undyed boots", ctx.NewItem)
+		}
+		if c := tx.Block(pos).(Cauldron); c.Level != 0 {
+			t.Errorf("cauldron level after washing boots = %v, want 0", c.Level)
+		}
+		if tx.Block(pos).(Cauldron).Activate(pos, cube.FaceUp, tx, jukeboxTestUser{held: boots}, &item.UseContext{}) {
+			t.Errorf("undyed boots were washed in an empty cauldron")
+		}
+	})
+}
+
+func TestDyedLeatherEncoding(t *testing.T) {
+	c := color.RGBA{R: 0x12, G: 0x34, B: 0x56, A: 0xff}
+	chestplate := item.NewStack(item.Chestplate{Tier: item.ArmourTierLeather{Colour: c}}, 1)
+	// Items in the armour equipment sent to viewers are encoded without the
+	// disk fields, holding the colour as an ARGB int32.
+	if data := nbtconv.WriteItem(chestplate, false); data["customColor"] != int32(-0xedcbaa) {
+		t.Errorf("network NBT of dyed leather chestplate = %v, want customColor %v", data, int32(-0xedcbaa))
+	}
+	decoded := nbtconv.Item(nbtconv.WriteItem(chestplate, true), nil)
+	if got, ok := decoded.Item().(item.Chestplate).DyeColour(); !ok || got != c {
+		t.Errorf("colour of decoded leather chestplate = %v, want %v", got, c)
+	}
+	if data := nbtconv.WriteItem(item.NewStack(item.Chestplate{Tier: item.ArmourTierLeather{}}, 1), false); data["customColor"] != nil {
+		t.Errorf("network NBT of undyed leather chestplate = %v, want no customColor", data)
+	}
+}
@@ -216,6 +216,15 @@ func (c Chest) Paired() bool {
 	return c.paired
 }
 
+// PistonPushReaction returns PistonPushBlock if the chest is paired, as the halves of a paired chest cannot
+// be moved separately.
+func (c Chest) PistonPushReaction() PistonPushReaction {
+	if c.paired {
+		return PistonPushBlock
+	}
+	return PistonPushNormal
+}
+
 // pair pairs this chest with the given chest position.
 func (c Chest) pair(tx *world.Tx, pos, pairPos cube.Pos) (ch, pair Chest, ok bool) {
 	pair, ok = tx.Block(pairPos).(Chest)
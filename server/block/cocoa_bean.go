@@ -86,6 +86,11 @@ func (c CocoaBean) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
 	}
 }
 
+// WantsRandomTick ...
+func (c CocoaBean) WantsRandomTick() bool {
+	return c.Age < 2
+}
+
 // BreakInfo ...
 func (c CocoaBean) BreakInfo() BreakInfo {
 	return newBreakInfo(0.2, alwaysHarvestable, axeEffective, func(item.Tool, []item.Enchantment) []item.Stack {
@@ -0,0 +1,265 @@
+package block
+
+import (
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// CommandBlock is a block that executes a command when triggered. Command blocks can only be obtained and
+// edited by players in creative mode and cannot be broken in survival mode.
+type CommandBlock struct {
+	solid
+	bassDrum
+
+	// Mode is the mode of the command block. It determines when the command of the command block is
+	// executed.
+	Mode CommandBlockMode
+	// Facing is the direction the command block is facing. Chain command blocks in front of the command
+	// block are triggered after it executes its command.
+	Facing cube.Face
+	// Conditional specifies if the command block only executes its command if the command block behind it
+	// executed its command successfully.
+	Conditional bool
+
+	// Command is the command line executed by the command block, such as '/say hello'.
+	Command string
+	// CustomName is the name of the command block used as the name of the source executing the command. If
+	// empty, '!' is used.
+	CustomName string
+	// LastOutput is the output of the last command executed, if TrackOutput is true.
+	LastOutput string
+	// TrackOutput specifies if the output of the last command executed is stored in LastOutput.
+	TrackOutput bool
+	// SuccessCount holds 1 if the last execution of the command was successful and 0 if not. Conditional
+	// command blocks in front of this command block use it to check if they may execute their command.
+	SuccessCount int
+	// Auto specifies if the command block is always active. If false, the command block must be powered by
+	// redstone to be active.
+	Auto bool
+	// Powered is whether the command block was powered during its last redstone update.
+	Powered bool
+	// TickDelay is the delay in ticks between the command block being triggered and executing its command.
+	// For repeating command blocks, it is the delay between two executions.
+	TickDelay int
+}
+
+// UseOnBlock ...
+func (c CommandBlock) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, c)
+	if !used {
+		return false
+	}
+	c.Facing = calculateFace(user, pos).Opposite()
+
+	place(tx, pos, c, user, ctx)
+	return placed(ctx)
+}
+
+// Activate ...
+func (c CommandBlock) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, _ *item.UseContext) bool {
+	if editor, ok := u.(interface{ CanEditCommandBlock(pos cube.Pos) bool }); !ok || !editor.CanEditCommandBlock(pos) {
+		return false
+	}
+	if opener, ok := u.(ContainerOpener); ok {
+		opener.OpenBlockContainer(pos, tx)
+		return true
+	}
+	return false
+}
+
+// Update updates the command block at the position passed with the values of c, as done when a player edits
+// the command block. If c is active without needing redstone, its command is scheduled to be executed.
+func (c CommandBlock) Update(pos cube.Pos, tx *world.Tx) {
+	tx.SetBlock(pos, c, nil)
+	if c.Auto && c.Mode != ChainCommandBlock() {
+		tx.ScheduleBlockUpdate(pos, c, c.delay())
+	}
+}
+
+// RedstonePowerUpdate records power changes. The command is scheduled in RedstonePowerPostUpdate so that
+// cancellation of the update also suppresses the execution.
+func (c CommandBlock) RedstonePowerUpdate(_ cube.Pos, _ *world.Tx, power int) (world.Block, bool) {
+	powered := power > 0
+	if powered == c.Powered {
+		return c, false
+	}
+	c.Powered = powered
+	return c, true
+}
+
+// RedstonePowerPostUpdate schedules the execution of the command after an uncancelled rising redstone edge.
+func (c CommandBlock) RedstonePowerPostUpdate(pos cube.Pos, tx *world.Tx, before, after world.Block, _, _ int) {
+	beforeBlock, beforeOK := before.(CommandBlock)
+	afterBlock, afterOK := after.(CommandBlock)
+	if !beforeOK || !afterOK || beforeBlock.Powered || !afterBlock.Powered || afterBlock.Auto {
+		return
+	}
+	if afterBlock.Mode != ChainCommandBlock() {
+		tx.ScheduleBlockUpdate(pos, afterBlock, afterBlock.delay())
+	}
+}
+
+// ScheduledTick executes the command of an impulse or repeating command block and triggers the chain
+// command blocks in front of it. Repeating command blocks reschedule their execution while active.
+func (c CommandBlock) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if c.Mode == ChainCommandBlock() || !c.active() {
+		return
+	}
+	c.execute(pos, tx)
+	c.triggerChain(pos, tx)
+
+	if current, ok := tx.Block(pos).(CommandBlock); ok && current.Mode == RepeatingCommandBlock() && current.active() {
+		tx.ScheduleBlockUpdate(pos, current, current.delay())
+	}
+}
+
+// triggerChain executes the commands of all chain command blocks in front of the command block at pos, in
+// order. No more chain command blocks are executed than the max command chain length of the world, and every
+// command block in the chain is executed at most once.
+func (c CommandBlock) triggerChain(pos cube.Pos, tx *world.Tx) {
+	visited := map[cube.Pos]struct{}{pos: {}}
+	next := pos.Side(c.Facing)
+	for range tx.World().MaxCommandChainLength() {
+		chain, ok := tx.Block(next).(CommandBlock)
+		if !ok || chain.Mode != ChainCommandBlock() {
+			return
+		}
+		if _, ok := visited[next]; ok {
+			return
+		}
+		visited[next] = struct{}{}
+		if chain.active() {
+			chain.execute(next, tx)
+		}
+		next = next.Side(chain.Facing)
+	}
+}
+
+// execute executes the command of the command block at pos if its condition is met and stores the result
+// of the execution in the command block. True is returned if the command was executed successfully.
+func (c CommandBlock) execute(pos cube.Pos, tx *world.Tx) bool {
+	success := false
+	if tx.World().CommandBlocksEnabled() && strings.TrimSpace(c.Command) != "" && (!c.Conditional || c.conditionMet(pos, tx)) {
+		src := cmd.NewVirtualSource(c.name(), pos.Vec3Centre(), tx.World(), cmd.PermissionLevelGameDirectors)
+		executed := cmd.ExecuteLine(c.Command, src, tx)
+		o, _ := src.LastOutput()
+		success = executed && o != nil && o.ErrorCount() == 0
+		if c.TrackOutput {
+			c.LastOutput = commandOutputString(o)
+		}
+	}
+	if _, ok := tx.Block(pos).(CommandBlock); !ok {
+		// The command replaced the command block itself, so there is nothing to store the result in.
+		return success
+	}
+	c.SuccessCount = 0
+	if success {
+		c.SuccessCount = 1
+	}
+	tx.SetBlock(pos, c, &world.SetOpts{DisableBlockUpdates: true})
+	return success
+}
+
+// conditionMet checks if the command block behind the command block at pos executed its command
+// successfully.
+func (c CommandBlock) conditionMet(pos cube.Pos, tx *world.Tx) bool {
+	behind, ok := tx.Block(pos.Side(c.Facing.Opposite())).(CommandBlock)
+	return ok && behind.SuccessCount > 0
+}
+
+// active checks if the command block is active, either because it is powered or because it does not need
+// redstone.
+func (c CommandBlock) active() bool {
+	return c.Auto || c.Powered
+}
+
+// delay returns the delay between the command block being triggered and executing its command. The delay is
+// at least one tick.
+func (c CommandBlock) delay() time.Duration {
+	return time.Duration(max(c.TickDelay, 1)) * time.Second / 20
+}
+
+// name returns the name of the source that executes the command of the command block.
+func (c CommandBlock) name() string {
+	if c.CustomName != "" {
+		return c.CustomName
+	}
+	return "!"
+}
+
+// EncodeItem ...
+func (c CommandBlock) EncodeItem() (name string, meta int16) {
+	return "minecraft:" + c.Mode.String(), 0
+}
+
+// EncodeBlock ...
+func (c CommandBlock) EncodeBlock() (string, map[string]any) {
+	return "minecraft:" + c.Mode.String(), map[string]any{"facing_direction": int32(c.Facing), "conditional_bit": c.Conditional}
+}
+
+// EncodeNBT ...
+func (c CommandBlock) EncodeNBT() map[string]any {
+	return map[string]any{
+		"id":              "CommandBlock",
+		"Command":         c.Command,
+		"CustomName":      c.CustomName,
+		"LastOutput":      c.LastOutput,
+		"TrackOutput":     boolByte(c.TrackOutput),
+		"SuccessCount":    int32(c.SuccessCount),
+		"auto":            boolByte(c.Auto),
+		"powered":         boolByte(c.Powered),
+		"conditionMet":    boolByte(c.SuccessCount > 0),
+		"TickDelay":       int32(c.TickDelay),
+		"LPCommandMode":   int32(c.Mode.Uint8()),
+		"LPCondionalMode": boolByte(c.Conditional),
+		"LPRedstoneMode":  boolByte(!c.Auto),
+		"Version":         int32(38),
+	}
+}
+
+// DecodeNBT ...
+func (c CommandBlock) DecodeNBT(data map[string]any) any {
+	c.Command = nbtconv.String(data, "Command")
+	c.CustomName = nbtconv.String(data, "CustomName")
+	c.LastOutput = nbtconv.String(data, "LastOutput")
+	c.TrackOutput = nbtconv.Bool(data, "TrackOutput")
+	c.SuccessCount = int(nbtconv.Int32(data, "SuccessCount"))
+	c.Auto = nbtconv.Bool(data, "auto")
+	c.Powered = nbtconv.Bool(data, "powered")
+	c.TickDelay = int(nbtconv.Int32(data, "TickDelay"))
+	return c
+}
+
+// allCommandBlocks returns all possible states of command blocks.
+func allCommandBlocks() (b []world.Block) {
+	for _, m := range CommandBlockModes() {
+		for _, f := range cube.Faces() {
+			b = append(b, CommandBlock{Mode: m, Facing: f})
+			b = append(b, CommandBlock{Mode: m, Facing: f, Conditional: true})
+		}
+	}
+	return
+}
+
+// commandOutputString returns the output of a command executed by a command block as a single string.
+func commandOutputString(o *cmd.Output) string {
+	if o == nil {
+		return ""
+	}
+	lines := make([]string, 0, o.MessageCount()+o.ErrorCount())
+	for _, m := range o.Messages() {
+		lines = append(lines, m.String())
+	}
+	for _, err := range o.Errors() {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
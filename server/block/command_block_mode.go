@@ -0,0 +1,50 @@
+package block
+
+// CommandBlockMode represents the mode of a command block, which determines when the command block executes
+// its command.
+type CommandBlockMode struct {
+	commandBlockMode
+}
+
+// ImpulseCommandBlock returns the impulse command block mode. Impulse command blocks execute their command
+// once every time they are powered.
+func ImpulseCommandBlock() CommandBlockMode {
+	return CommandBlockMode{0}
+}
+
+// RepeatingCommandBlock returns the repeating command block mode. Repeating command blocks execute their
+// command every tick while powered.
+func RepeatingCommandBlock() CommandBlockMode {
+	return CommandBlockMode{1}
+}
+
+// ChainCommandBlock returns the chain command block mode. Chain command blocks execute their command when
+// the command block pointing into them executed its command.
+func ChainCommandBlock() CommandBlockMode {
+	return CommandBlockMode{2}
+}
+
+// CommandBlockModes returns all command block modes.
+func CommandBlockModes() []CommandBlockMode {
+	return []CommandBlockMode{ImpulseCommandBlock(), RepeatingCommandBlock(), ChainCommandBlock()}
+}
+
+type commandBlockMode uint8
+
+// Uint8 returns the command block mode as a uint8.
+func (m commandBlockMode) Uint8() uint8 {
+	return uint8(m)
+}
+
+// String returns the command block mode as a string.
+func (m commandBlockMode) String() string {
+	switch m {
+	case 0:
+		return "command_block"
+	case 1:
+		return "repeating_command_block"
+	case 2:
+		return "chain_command_block"
+	}
+	panic("should never happen")
+}
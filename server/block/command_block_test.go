@@ -0,0 +1,190 @@
+package block
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// commandBlockTestRecord records the names of the sources that ran it.
+type commandBlockTestRecord struct {
+	names *[]string
+}
+
+func (r commandBlockTestRecord) Run(src cmd.Source, _ *cmd.Output, _ *world.Tx) {
+	*r.names = append(*r.names, src.(cmd.NamedTarget).Name())
+}
+
+// commandBlockTestWorld returns a synchronous world in which command blocks
+// are enabled.
+func commandBlockTestWorld() *world.World {
+	w := world.Config{Synchronous: true}.New()
+	w.SetCommandBlocksEnabled(true)
+	return w
+}
+
+func TestCommandBlocksDisabledByDefault(t *testing.T) {
+	var names []string
+	cmd.Register(cmd.New("cbtestdisabled", "", nil, commandBlockTestRecord{names: &names}))
+
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		if tx.World().CommandBlocksEnabled() {
+			t.Fatalf("command blocks enabled in new world, want them disabled")
+		}
+		tx.SetBlock(pos, CommandBlock{Mode: ImpulseCommandBlock(), Command: "/cbtestdisabled", CustomName: "a", Powered: true}, nil)
+		tx.Block(pos).(CommandBlock).ScheduledTick(pos, tx, nil)
+	})
+	if len(names) != 0 {
+		t.Fatalf("command block ran %v in world with command blocks disabled, want no commands run", names)
+	}
+}
+
+func TestCommandBlockChainOrder(t *testing.T) {
+	var names []string
+	cmd.Register(cmd.New("cbtestchain", "", nil, commandBlockTestRecord{names: &names}))
+
+	w := commandBlockTestWorld()
+	defer w.Close()
+
+	start := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(start, CommandBlock{Mode: ImpulseCommandBlock(), Facing: cube.FaceEast, Command: "/cbtestchain", CustomName: "a", Powered: true}, nil)
+		tx.SetBlock(start.Side(cube.FaceEast), CommandBlock{Mode: ChainCommandBlock(), Facing: cube.FaceUp, Command: "/cbtestchain", CustomName: "b", Auto: true}, nil)
+		tx.SetBlock(start.Side(cube.FaceEast).Side(cube.FaceUp), CommandBlock{Mode: ChainCommandBlock(), Facing: cube.FaceWest, Command: "/cbtestchain", CustomName: "c", Auto: true}, nil)
+		// The chain command block facing back into the impulse command block must not cause a loop.
+		tx.SetBlock(start.Side(cube.FaceUp), CommandBlock{Mode: ChainCommandBlock(), Facing: cube.FaceDown, Command: "/cbtestchain", CustomName: "d", Auto: true}, nil)
+
+		tx.Block(start).(CommandBlock).ScheduledTick(start, tx, nil)
+	})
+	if want := []string{"a", "b", "c", "d"}; !slices.Equal(names, want) {
+		t.Fatalf("chain execution order = %v, want %v", names, want)
+	}
+
+	names = nil
+	runWorld(w, func(tx *world.Tx) {
+		tx.World().SetMaxCommandChainLength(1)
+		tx.Block(start).(CommandBlock).ScheduledTick(start, tx, nil)
+	})
+	if want := []string{"a", "b"}; !slices.Equal(names, want) {
+		t.Fatalf("chain execution order with chain length 1 = %v, want %v", names, want)
+	}
+}
+
+func TestCommandBlockConditionalChain(t *testing.T) {
+	var names []string
+	cmd.Register(cmd.New("cbtestconditional", "", nil, commandBlockTestRecord{names: &names}))
+
+	w := commandBlockTestWorld()
+	defer w.Close()
+
+	start := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(start, CommandBlock{Mode: ImpulseCommandBlock(), Facing: cube.FaceEast, Command: "/unknowncommand", Powered: true}, nil)
+		tx.SetBlock(start.Side(cube.FaceEast), CommandBlock{Mode: ChainCommandBlock(), Facing: cube.FaceEast, Conditional: true, Command: "/cbtestconditional", CustomName: "b", Auto: true}, nil)
+
+		tx.Block(start).(CommandBlock).ScheduledTick(start, tx, nil)
+	})
+	if len(names) != 0 {
+		t.Fatalf("conditional chain command block ran after failed command: %v", names)
+	}
+}
+
+func TestRepeatingCommandBlockRunsEveryTickWhilePowered(t *testing.T) {
+	var names []string
+	cmd.Register(cmd.New("cbtestrepeat", "", nil, commandBlockTestRecord{names: &names}))
+
+	w := commandBlockTestWorld()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	c := CommandBlock{Mode: RepeatingCommandBlock(), Facing: cube.FaceEast, Command: "/cbtestrepeat", CustomName: "r"}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pos, c, nil)
+	})
+	redstoneWireTestSetBlockAndWait(t, w, pos.Side(cube.FaceWest), RedstoneBlock{})
+	for range 5 {
+		w.AdvanceTick()
+	}
+	if len(names) < 5 {
+		t.Fatalf("repeating command block ran %d times in 5 ticks while powered, want at least 5", len(names))
+	}
+
+	redstoneWireTestSetBlockAndWait(t, w, pos.Side(cube.FaceWest), Air{})
+	w.AdvanceTick()
+	n := len(names)
+	for range 5 {
+		w.AdvanceTick()
+	}
+	if len(names) != n {
+		t.Fatalf("repeating command block ran %d times after being unpowered, want 0", len(names)-n)
+	}
+}
+
+// commandBlockTestPosition prints the position passed to it.
+type commandBlockTestPosition struct {
+	Pos mgl64.Vec3 `cmd:"pos"`
+}
+
+func (r commandBlockTestPosition) Run(_ cmd.Source, o *cmd.Output, _ *world.Tx) {
+	o.Printf("%v %v %v", r.Pos[0], r.Pos[1], r.Pos[2])
+}
+
+func TestVirtualSourceResolvesRelativeCoordinates(t *testing.T) {
+	cmd.Register(cmd.New("cbtestpos", "", nil, commandBlockTestPosition{}))
+
+	var sunk []*cmd.Output
+	src := cmd.NewVirtualSource("rcon", mgl64.Vec3{10, 64, -5}, nil, cmd.PermissionLevelOwner).WithSink(func(o *cmd.Output) {
+		sunk = append(sunk, o)
+	})
+	if !cmd.ExecuteLine("/cbtestpos ~ ~1 ~-2.5", src, nil) {
+		t.Fatalf("command was not found")
+	}
+	o, ok := src.LastOutput()
+	if !ok || o.ErrorCount() != 0 || o.MessageCount() != 1 {
+		t.Fatalf("captured output = %v, want a single message", o)
+	}
+	if msg := o.Messages()[0].String(); msg != "10 65 -7.5" {
+		t.Errorf("resolved position = %v, want 10 65 -7.5", msg)
+	}
+	if len(sunk) != 1 || sunk[0] != o {
+		t.Errorf("outputs passed to sink = %v, want the captured output", sunk)
+	}
+
+	moved := src.WithPosition(mgl64.Vec3{0, 0, 0})
+	cmd.ExecuteLine("cbtestpos 1 ~ ~3", moved, nil)
+	if o, _ := moved.LastOutput(); o.Messages()[0].String() != "1 0 3" {
+		t.Errorf("resolved position after moving source = %v, want 1 0 3", o.Messages()[0])
+	}
+	if len(src.Outputs()) != 1 || len(sunk) != 2 {
+		t.Errorf("moved source captured output in original source or did not share its sink")
+	}
+	if level := cmd.SourcePermissionLevel(src); level != cmd.PermissionLevelOwner {
+		t.Errorf("permission level = %v, want %v", level, cmd.PermissionLevelOwner)
+	}
+}
+
+func TestCommandBlockCapturesOutput(t *testing.T) {
+	cmd.Register(cmd.New("cbtestpos", "", nil, commandBlockTestPosition{}))
+
+	w := commandBlockTestWorld()
+	defer w.Close()
+
+	pos := cube.Pos{3, 64, 7}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pos, CommandBlock{Mode: ImpulseCommandBlock(), Facing: cube.FaceEast, Command: "/cbtestpos ~ ~-1 ~", TrackOutput: true, Powered: true}, nil)
+		tx.Block(pos).(CommandBlock).ScheduledTick(pos, tx, nil)
+
+		c := tx.Block(pos).(CommandBlock)
+		if c.LastOutput != "3.5 63.5 7.5" || c.SuccessCount != 1 {
+			t.Errorf("command block output = %q with success count %v, want %q and 1", c.LastOutput, c.SuccessCount, "3.5 63.5 7.5")
+		}
+	})
+}
@@ -0,0 +1,226 @@
+package block
+
+import (
+	"math"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Conduit is a block that grants the Conduit Power effect to nearby players that are in water or rain, and
+// attacks nearby hostile mobs in water. A conduit is only active while it is surrounded by water and enclosed in
+// a frame of at least 16 prismarine or sea lantern blocks.
+type Conduit struct {
+	transparent
+	sourceWaterDisplacer
+
+	// frame is the amount of blocks in the frame around the conduit, as of its last update. It can be 0-42.
+	frame int
+	// active specifies if the conduit is currently active.
+	active bool
+}
+
+// ConduitFrame represents a block which is capable of being part of the frame of a conduit.
+type ConduitFrame interface {
+	// ConduitFrame returns a bool which indicates whether this block can be part of the frame of a conduit.
+	ConduitFrame() bool
+}
+
+// BreakInfo ...
+func (c Conduit) BreakInfo() BreakInfo {
+	return newBreakInfo(3, alwaysHarvestable, pickaxeEffective, oneOf(Conduit{}))
+}
+
+// Model ...
+func (Conduit) Model() world.BlockModel {
+	return model.Conduit{}
+}
+
+// LightEmissionLevel ...
+func (Conduit) LightEmissionLevel() uint8 {
+	return 15
+}
+
+// SideClosed ...
+func (Conduit) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// Active returns whether the conduit is currently active.
+func (c Conduit) Active() bool {
+	return c.active
+}
+
+// Range returns the range in blocks within which the conduit grants the Conduit Power effect. The range grows
+// by 16 blocks for every 7 blocks in the frame of the conduit, up to 96 blocks for a full frame. Range returns
+// 0 if the conduit is not active.
+func (c Conduit) Range() int {
+	if !c.active {
+		return 0
+	}
+	return c.frame / 7 * 16
+}
+
+// Tick recalculates the frame of the conduit, recalculates its active state and applies its effects to nearby
+// entities, once every 40 ticks (2 seconds).
+func (c Conduit) Tick(currentTick int64, pos cube.Pos, tx *world.Tx) {
+	if currentTick%40 != 0 {
+		return
+	}
+	before := c
+	c.frame = c.recalculateFrame(pos, tx)
+	c.active = c.frame >= 16 && c.submerged(pos, tx)
+	if before != c {
+		tx.SetBlock(pos, c, nil)
+	}
+	if !c.active {
+		return
+	}
+	c.broadcastConduitPower(pos, tx)
+	if c.frame == 42 {
+		c.attackHostile(pos, tx)
+	}
+}
+
+// recalculateFrame returns the amount of blocks in the frame of the conduit. The frame consists of three
+// 5x5 rings around the conduit, one on each axis, of which every block must implement ConduitFrame.
+func (c Conduit) recalculateFrame(pos cube.Pos, tx *world.Tx) int {
+	var frame int
+	for x := -2; x <= 2; x++ {
+		for y := -2; y <= 2; y++ {
+			for z := -2; z <= 2; z++ {
+				if !conduitFramePos(x, y, z) {
+					continue
+				}
+				if f, ok := tx.Block(pos.Add(cube.Pos{x, y, z})).(ConduitFrame); ok && f.ConduitFrame() {
+					frame++
+				}
+			}
+		}
+	}
+	return frame
+}
+
+// conduitFramePos checks if the offset passed from a conduit is part of the frame of the conduit.
+func conduitFramePos(x, y, z int) bool {
+	ax, ay, az := abs(x), abs(y), abs(z)
+	if ax <= 1 && ay <= 1 && az <= 1 {
+		return false
+	}
+	return (x == 0 && (ay == 2 || az == 2)) || (y == 0 && (ax == 2 || az == 2)) || (z == 0 && (ax == 2 || ay == 2))
+}
+
+// submerged checks if the conduit and all blocks directly around it are filled with water.
+func (c Conduit) submerged(pos cube.Pos, tx *world.Tx) bool {
+	for x := -1; x <= 1; x++ {
+		for y := -1; y <= 1; y++ {
+			for z := -1; z <= 1; z++ {
+				if l, ok := tx.Liquid(pos.Add(cube.Pos{x, y, z})); !ok {
+					return false
+				} else if _, ok := l.(Water); !ok {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// broadcastConduitPower grants the Conduit Power effect to all conduitAffected entities within the range of the
+// conduit that are in water or rain.
+func (c Conduit) broadcastConduitPower(pos cube.Pos, tx *world.Tx) {
+	r := float64(c.Range())
+	centre := pos.Vec3Centre()
+	for e := range tx.EntitiesWithin(cube.Box(-r, -r, -r, r, r, r).Translate(centre)) {
+		p, ok := e.(conduitAffected)
+		if !ok || !p.ConduitAffected() || e.Position().Sub(centre).Len() > r || !conduitWet(e, tx) {
+			continue
+		}
+		p.AddEffect(effect.NewAmbient(effect.ConduitPower, 1, time.Second*13))
+	}
+}
+
+// attackHostile deals damage to the closest conduitHostile entity in water within 8 blocks of the conduit.
+func (c Conduit) attackHostile(pos cube.Pos, tx *world.Tx) {
+	centre := pos.Vec3Centre()
+	var target conduitHostile
+	closest := math.MaxFloat64
+	for e := range tx.EntitiesWithin(cube.Box(-8, -8, -8, 8, 8, 8).Translate(centre)) {
+		h, ok := e.(conduitHostile)
+		if !ok || !h.ConduitHostile() || !conduitWet(e, tx) {
+			continue
+		}
+		if dist := e.Position().Sub(centre).Len(); dist <= 8 && dist < closest {
+			target, closest = h, dist
+		}
+	}
+	if target != nil {
+		target.Hurt(4, ConduitDamageSource{})
+	}
+}
+
+// conduitWet checks if the entity passed is in water or rain.
+func conduitWet(e world.Entity, tx *world.Tx) bool {
+	pos := cube.PosFromVec3(e.Position())
+	if l, ok := tx.Liquid(pos); ok {
+		if _, ok := l.(Water); ok {
+			return true
+		}
+	}
+	return tx.RainingAt(pos)
+}
+
+// conduitAffected represents an entity that can be granted the Conduit Power effect by a conduit.
+type conduitAffected interface {
+	world.Entity
+	// AddEffect adds a specific effect to the entity that implements this interface.
+	AddEffect(e effect.Effect)
+	// ConduitAffected returns whether this entity can be granted Conduit Power by a conduit.
+	ConduitAffected() bool
+}
+
+// conduitHostile represents a hostile entity that is attacked by a conduit with a full frame.
+type conduitHostile interface {
+	world.Entity
+	// Hurt hurts the entity for a given amount of damage.
+	Hurt(damage float64, src world.DamageSource) (n float64, vulnerable bool)
+	// ConduitHostile returns whether this entity is attacked by conduits.
+	ConduitHostile() bool
+}
+
+// ConduitDamageSource is used for damage caused by a conduit attacking a hostile entity.
+type ConduitDamageSource struct{}
+
+func (ConduitDamageSource) ReducedByResistance() bool { return true }
+func (ConduitDamageSource) ReducedByArmour() bool     { return false }
+func (ConduitDamageSource) Fire() bool                { return false }
+func (ConduitDamageSource) IgnoreTotem() bool         { return false }
+
+// DecodeNBT ...
+func (c Conduit) DecodeNBT(data map[string]any) any {
+	c.active = nbtconv.Bool(data, "Active")
+	return c
+}
+
+// EncodeNBT ...
+func (c Conduit) EncodeNBT() map[string]any {
+	return map[string]any{
+		"id":     "Conduit",
+		"Active": boolByte(c.active),
+		"Target": int64(-1),
+	}
+}
+
+// EncodeItem ...
+func (Conduit) EncodeItem() (name string, meta int16) {
+	return "minecraft:conduit", 0
+}
+
+// EncodeBlock ...
+func (Conduit) EncodeBlock() (string, map[string]any) {
+	return "minecraft:conduit", nil
+}
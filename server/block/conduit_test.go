@@ -0,0 +1,167 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// conduitTestFrame returns the positions of the frame of a conduit at pos.
+func conduitTestFrame(pos cube.Pos) (frame []cube.Pos) {
+	for x := -2; x <= 2; x++ {
+		for y := -2; y <= 2; y++ {
+			for z := -2; z <= 2; z++ {
+				if conduitFramePos(x, y, z) {
+					frame = append(frame, pos.Add(cube.Pos{x, y, z}))
+				}
+			}
+		}
+	}
+	return frame
+}
+
+// conduitTestBuild places a submerged conduit at pos with the first n blocks of its frame built from
+// prismarine.
+func conduitTestBuild(tx *world.Tx, pos cube.Pos, n int) {
+	for x := -1; x <= 1; x++ {
+		for y := -1; y <= 1; y++ {
+			for z := -1; z <= 1; z++ {
+				tx.SetBlock(pos.Add(cube.Pos{x, y, z}), Water{Depth: 8, Still: true}, nil)
+			}
+		}
+	}
+	tx.SetBlock(pos, Conduit{}, nil)
+	tx.SetLiquid(pos, Water{Depth: 8, Still: true})
+	for _, framePos := range conduitTestFrame(pos)[:n] {
+		tx.SetBlock(framePos, Prismarine{}, nil)
+	}
+}
+
+func TestConduitFrame(t *testing.T) {
+	if n := len(conduitTestFrame(cube.Pos{})); n != 42 {
+		t.Fatalf("conduit frame positions = %v, want 42", n)
+	}
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	for i, tc := range []struct {
+		frame  int
+		active bool
+		r      int
+	}{
+		{frame: 0},
+		{frame: 15},
+		{frame: 16, active: true, r: 32},
+		{frame: 27, active: true, r: 48},
+		{frame: 42, active: true, r: 96},
+	} {
+		pos := cube.Pos{i * 16, 64, 0}
+		runWorld(w, func(tx *world.Tx) {
+			conduitTestBuild(tx, pos, tc.frame)
+			Conduit{}.Tick(40, pos, tx)
+			c := tx.Block(pos).(Conduit)
+			if c.Active() != tc.active || c.Range() != tc.r {
+				t.Errorf("conduit with %v frame blocks: active = %v, range = %v, want %v, %v", tc.frame, c.Active(), c.Range(), tc.active, tc.r)
+			}
+		})
+	}
+
+	pos := cube.Pos{0, 64, 64}
+	runWorld(w, func(tx *world.Tx) {
+		conduitTestBuild(tx, pos, 42)
+		// A conduit that is not fully surrounded by water is inactive, even with a full frame.
+		tx.SetBlock(pos.Add(cube.Pos{1, 1, 1}), Air{}, nil)
+		Conduit{}.Tick(40, pos, tx)
+		if c := tx.Block(pos).(Conduit); c.Active() {
+			t.Errorf("conduit without surrounding water is active, want inactive")
+		}
+	})
+}
+
+func TestConduitEffectsAndAttack(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+	w.StopRaining()
+
+	pos := cube.Pos{0, 64, 0}
+	near := &conduitTestEntity{pos: mgl64.Vec3{20.5, 64, 0.5}, affected: true}
+	far := &conduitTestEntity{pos: mgl64.Vec3{40.5, 64, 0.5}, affected: true}
+	dry := &conduitTestEntity{pos: mgl64.Vec3{0.5, 64, 20.5}, affected: true, dry: true}
+	hostile := &conduitTestEntity{pos: mgl64.Vec3{0.5, 64, 5.5}, hostile: true}
+	distantHostile := &conduitTestEntity{pos: mgl64.Vec3{0.5, 64, 7.5}, hostile: true}
+	runWorld(w, func(tx *world.Tx) {
+		conduitTestBuild(tx, pos, 16)
+		for _, e := range []*conduitTestEntity{near, far, dry, hostile, distantHostile} {
+			if !e.dry {
+				tx.SetBlock(cube.PosFromVec3(e.pos), Water{Depth: 8, Still: true}, nil)
+			}
+			tx.AddEntity(world.EntitySpawnOpts{Position: e.pos}.New(conduitTestEntityType{}, conduitTestEntityConfig{e: e}))
+		}
+		Conduit{}.Tick(40, pos, tx)
+
+		if len(near.effects) != 1 || near.effects[0].Type() != effect.ConduitPower {
+			t.Errorf("entity in water within range got effects %v, want conduit power", near.effects)
+		}
+		if len(far.effects) != 0 || len(dry.effects) != 0 || len(hostile.effects) != 0 {
+			t.Errorf("entities out of range, out of water or not affected got effects %v, %v, %v, want none", far.effects, dry.effects, hostile.effects)
+		}
+		if hostile.damage != 0 {
+			t.Errorf("hostile entity took %v damage from conduit without a full frame, want 0", hostile.damage)
+		}
+
+		for _, framePos := range conduitTestFrame(pos) {
+			tx.SetBlock(framePos, Prismarine{}, nil)
+		}
+		Conduit{}.Tick(80, pos, tx)
+	})
+	if hostile.damage != 4 || distantHostile.damage != 0 {
+		t.Errorf("damage taken by closest and distant hostile entity from full conduit = %v, %v, want 4, 0", hostile.damage, distantHostile.damage)
+	}
+	if near.damage != 0 {
+		t.Errorf("entity that is not hostile took %v damage from conduit, want 0", near.damage)
+	}
+}
+
+type conduitTestEntityConfig struct {
+	e *conduitTestEntity
+}
+
+func (c conduitTestEntityConfig) Apply(data *world.EntityData) { data.Data = c.e }
+
+type conduitTestEntityType struct{}
+
+func (conduitTestEntityType) Open(_ *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	e := data.Data.(*conduitTestEntity)
+	e.handle = handle
+	return e
+}
+func (conduitTestEntityType) EncodeEntity() string { return "dragonfly:conduit_test" }
+func (conduitTestEntityType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.25, 0, -0.25, 0.25, 0.5, 0.25)
+}
+func (conduitTestEntityType) DecodeNBT(map[string]any, *world.EntityData) {}
+func (conduitTestEntityType) EncodeNBT(*world.EntityData) map[string]any  { return nil }
+
+type conduitTestEntity struct {
+	handle                 *world.EntityHandle
+	pos                    mgl64.Vec3
+	affected, hostile, dry bool
+
+	effects []effect.Effect
+	damage  float64
+}
+
+func (e *conduitTestEntity) Close() error                { return nil }
+func (e *conduitTestEntity) H() *world.EntityHandle      { return e.handle }
+func (e *conduitTestEntity) Position() mgl64.Vec3        { return e.pos }
+func (e *conduitTestEntity) Rotation() cube.Rotation     { return cube.Rotation{} }
+func (e *conduitTestEntity) AddEffect(eff effect.Effect) { e.effects = append(e.effects, eff) }
+func (e *conduitTestEntity) ConduitAffected() bool       { return e.affected }
+func (e *conduitTestEntity) ConduitHostile() bool        { return e.hostile }
+func (e *conduitTestEntity) Hurt(damage float64, _ world.DamageSource) (float64, bool) {
+	e.damage += damage
+	return damage, true
+}
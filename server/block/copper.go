@@ -64,6 +64,11 @@ func (c Copper) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
 	attemptOxidation(pos, tx, r, c)
 }
 
+// WantsRandomTick ...
+func (c Copper) WantsRandomTick() bool {
+	return canOxidise(c)
+}
+
 // EncodeItem ...
 func (c Copper) EncodeItem() (name string, meta int16) {
 	if c.Type == NormalCopper() && c.Oxidation == UnoxidisedOxidation() && !c.Waxed {
@@ -73,6 +73,11 @@ func (c CopperBars) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
 	attemptOxidation(pos, tx, r, c)
 }
 
+// WantsRandomTick ...
+func (c CopperBars) WantsRandomTick() bool {
+	return canOxidise(c)
+}
+
 // EncodeItem ...
 func (c CopperBars) EncodeItem() (name string, meta int16) {
 	return copperBlockName("copper_bars", c.Oxidation, c.Waxed), 0
@@ -88,6 +88,11 @@ func (c CopperChain) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
 	attemptOxidation(pos, tx, r, c)
 }
 
+// WantsRandomTick ...
+func (c CopperChain) WantsRandomTick() bool {
+	return canOxidise(c)
+}
+
 // EncodeItem ...
 func (c CopperChain) EncodeItem() (name string, meta int16) {
 	return copperBlockName("copper_chain", c.Oxidation, c.Waxed), 0
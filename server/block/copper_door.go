@@ -132,28 +132,37 @@ func (d CopperDoor) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *w
 	return placed(ctx)
 }
 
+// Activate opens or closes the door, along with a door forming a double door with it.
 func (d CopperDoor) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User, _ *item.UseContext) bool {
-	d.Open = !d.Open
-	tx.SetBlock(pos, d, nil)
-
-	otherPos := pos.Side(cube.Face(boolByte(!d.Top)))
-	other := tx.Block(otherPos)
-	if door, ok := other.(CopperDoor); ok {
-		door.Open = d.Open
-		tx.SetBlock(otherPos, door, nil)
-	}
-	if d.Open {
-		tx.PlaySound(pos.Vec3Centre(), sound.DoorOpen{Block: d})
-		return true
-	}
-	tx.PlaySound(pos.Vec3Centre(), sound.DoorClose{Block: d})
+	setDoorOpen(pos, tx, d, !d.Open, true)
 	return true
 }
 
+// RedstonePowerAction opens the door when it starts receiving redstone power and closes it when it stops.
+func (d CopperDoor) RedstonePowerAction(pos cube.Pos, tx *world.Tx, oldPower, newPower int) {
+	updateDoorPower(pos, tx, d, oldPower, newPower)
+}
+
+// doorState ...
+func (d CopperDoor) doorState() (facing cube.Direction, open, top, right bool) {
+	return d.Facing, d.Open, d.Top, d.Right
+}
+
+// withOpen ...
+func (d CopperDoor) withOpen(open bool) door {
+	d.Open = open
+	return d
+}
+
 func (d CopperDoor) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
 	attemptOxidation(pos, tx, r, d)
 }
 
+// WantsRandomTick ...
+func (d CopperDoor) WantsRandomTick() bool {
+	return canOxidise(d)
+}
+
 // BreakInfo ...
 func (d CopperDoor) BreakInfo() BreakInfo {
 	return newBreakInfo(3, func(t item.Tool) bool {
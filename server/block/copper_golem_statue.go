@@ -105,6 +105,11 @@ func (c CopperGolemStatue) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand)
 	attemptOxidation(pos, tx, r, c)
 }
 
+// WantsRandomTick ...
+func (c CopperGolemStatue) WantsRandomTick() bool {
+	return canOxidise(c)
+}
+
 // DecodeNBT ...
 func (c CopperGolemStatue) DecodeNBT(data map[string]any) any {
 	c.Pose = CopperGolemPose{pose(nbtconv.Int32(data, "Pose"))}
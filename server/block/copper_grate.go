@@ -67,6 +67,11 @@ func (c CopperGrate) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
 	attemptOxidation(pos, tx, r, c)
 }
 
+// WantsRandomTick ...
+func (c CopperGrate) WantsRandomTick() bool {
+	return canOxidise(c)
+}
+
 // EncodeItem ...
 func (c CopperGrate) EncodeItem() (name string, meta int16) {
 	return copperBlockName("copper_grate", c.Oxidation, c.Waxed), 0
@@ -125,6 +125,11 @@ func (c CopperLantern) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
 	attemptOxidation(pos, tx, r, c)
 }
 
+// WantsRandomTick ...
+func (c CopperLantern) WantsRandomTick() bool {
+	return canOxidise(c)
+}
+
 // EncodeItem ...
 func (c CopperLantern) EncodeItem() (name string, meta int16) {
 	return copperBlockName("copper_lantern", c.Oxidation, c.Waxed), 0
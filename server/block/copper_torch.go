@@ -54,9 +54,14 @@ func (t CopperTorch) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *
 	return placed(ctx)
 }
 
+// SupportedAt ...
+func (t CopperTorch) SupportedAt(pos cube.Pos, tx *world.Tx) bool {
+	return faceSupports(pos, t.Facing, tx)
+}
+
 // NeighbourUpdateTick ...
 func (t CopperTorch) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
-	if !tx.Block(pos.Side(t.Facing)).Model().FaceSolid(pos.Side(t.Facing), t.Facing.Opposite(), tx) {
+	if !t.SupportedAt(pos, tx) {
 		breakBlock(t, pos, tx)
 	}
 }
@@ -93,10 +93,30 @@ func (t CopperTrapdoor) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item
 	return true
 }
 
+// RedstonePowerAction opens the trapdoor when it starts receiving redstone power and closes it when it stops.
+func (t CopperTrapdoor) RedstonePowerAction(pos cube.Pos, tx *world.Tx, oldPower, newPower int) {
+	open, changed := redstoneOpen(t.Open, oldPower, newPower)
+	if !changed {
+		return
+	}
+	t.Open = open
+	tx.SetBlock(pos, t, nil)
+	if t.Open {
+		tx.PlaySound(pos.Vec3Centre(), sound.TrapdoorOpen{Block: t})
+		return
+	}
+	tx.PlaySound(pos.Vec3Centre(), sound.TrapdoorClose{Block: t})
+}
+
 func (t CopperTrapdoor) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
 	attemptOxidation(pos, tx, r, t)
 }
 
+// WantsRandomTick ...
+func (t CopperTrapdoor) WantsRandomTick() bool {
+	return canOxidise(t)
+}
+
 // BreakInfo ...
 func (t CopperTrapdoor) BreakInfo() BreakInfo {
 	return newBreakInfo(3, func(t item.Tool) bool {
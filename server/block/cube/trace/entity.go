@@ -40,7 +40,7 @@ func (r EntityResult) Entity() world.Entity {
 // EntityIntercept returns an EntityResult with the entity collided with and with the colliding vector closest to the start position,
 // if no colliding point was found, a zero BlockResult is returned ok is false.
 func EntityIntercept(e world.Entity, start, end mgl64.Vec3) (result EntityResult, ok bool) {
-	bb := e.H().Type().BBox(e).Translate(e.Position()).Grow(0.3)
+	bb := world.EntityBBox(e).Translate(e.Position()).Grow(0.3)
 
 	r, ok := BBoxIntercept(bb, start, end)
 	if !ok {
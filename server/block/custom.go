@@ -0,0 +1,193 @@
+package block
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/customblock"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// CustomConfig holds the configuration of a custom block registered using RegisterCustom. The textures and
+// geometry referred to by the Properties must be supplied to clients through a resource pack.
+type CustomConfig struct {
+	// Identifier is the namespaced identifier of the block, such as "example:ruby_block". Identifier may not
+	// use the minecraft namespace.
+	Identifier string
+	// States holds the state properties of the block, keyed by their name, such as "example:facing". Every
+	// value must be a bool, int32 or string. A block is registered for every combination of those values. The
+	// first value of every property forms the default state of the block.
+	States map[string][]any
+	// Properties are the properties of the block that apply to all of its states. The CollisionBox is used
+	// for the collision of entities with the block server-side. If it is zero, the block has the collision
+	// box of a full block.
+	Properties customblock.Properties
+	// Permutations are the client-side properties applied on top of Properties when their condition is met,
+	// such as a different geometry or rotation for specific states.
+	Permutations []customblock.Permutation
+	// Hardness is the hardness of the block, which influences the time it takes to break it.
+	Hardness float64
+	// PistonPushReaction specifies how the block reacts to pistons. If PistonPushDefault, the reaction is
+	// derived from the collision box and Hardness of the block.
+	PistonPushReaction PistonPushReaction
+}
+
+// Custom is a custom block registered using RegisterCustom. A Custom value represents one specific state of
+// the block.
+type Custom struct {
+	t     *customType
+	state int
+}
+
+// customType holds the data shared by all states of a custom block.
+type customType struct {
+	conf  CustomConfig
+	hash  uint64
+	model world.BlockModel
+	// names holds the names of the state properties of the block, sorted alphabetically.
+	names []string
+	// states holds the properties of every state of the block, indexed by the state index of Custom.
+	states []map[string]any
+}
+
+// RegisterCustom registers a Custom block for every combination of the state properties of the CustomConfig
+// passed in the world.BlockRegistry passed, and returns the block in its default state. The block states are
+// registered in a deterministic order, so that the same CustomConfig always leads to the same runtime IDs
+// for the same registry. RegisterCustom panics if the CustomConfig is invalid.
+func RegisterCustom(reg world.BlockRegistry, conf CustomConfig) Custom {
+	if conf.Identifier == "" || !strings.Contains(conf.Identifier, ":") || strings.HasPrefix(conf.Identifier, "minecraft:") {
+		panic(fmt.Sprintf("invalid custom block identifier %q: must be namespaced outside of minecraft", conf.Identifier))
+	}
+	t := &customType{conf: conf, hash: NextHash(), model: model.Solid{}}
+	if conf.Properties.CollisionBox != (cube.BBox{}) {
+		t.model = customModel{box: conf.Properties.CollisionBox}
+	}
+	for name, values := range conf.States {
+		if len(values) == 0 {
+			panic(fmt.Sprintf("custom block %v: state property %v has no values", conf.Identifier, name))
+		}
+		for _, v := range values {
+			switch v.(type) {
+			case bool, int32, string:
+			default:
+				panic(fmt.Sprintf("custom block %v: state property %v has value %v of unsupported type %T", conf.Identifier, name, v, v))
+			}
+		}
+		t.names = append(t.names, name)
+	}
+	slices.Sort(t.names)
+	t.states = t.permutations(0, map[string]any{})
+
+	for state := range t.states {
+		reg.RegisterBlock(Custom{t: t, state: state})
+	}
+	return Custom{t: t}
+}
+
+// permutations returns all combinations of the values of the state properties from index n onwards, merged
+// with the properties passed. The last property varies fastest.
+func (t *customType) permutations(n int, properties map[string]any) []map[string]any {
+	if n == len(t.names) {
+		return []map[string]any{properties}
+	}
+	var states []map[string]any
+	for _, v := range t.conf.States[t.names[n]] {
+		m := make(map[string]any, len(t.names))
+		for k, prev := range properties {
+			m[k] = prev
+		}
+		m[t.names[n]] = v
+		states = append(states, t.permutations(n+1, m)...)
+	}
+	return states
+}
+
+// State returns the value of the state property with the name passed, and false if the block has no such
+// property.
+func (c Custom) State(name string) (any, bool) {
+	v, ok := c.t.states[c.state][name]
+	return v, ok
+}
+
+// WithState returns the Custom block with the state property with the name passed set to the value passed.
+// WithState panics if the block has no such property or if the value is not valid for it.
+func (c Custom) WithState(name string, value any) Custom {
+	if !slices.Contains(c.t.conf.States[name], value) {
+		panic(fmt.Sprintf("custom block %v: invalid value %v for state property %v", c.t.conf.Identifier, value, name))
+	}
+	for state, properties := range c.t.states {
+		match := true
+		for k, v := range c.t.states[c.state] {
+			if k == name {
+				v = value
+			}
+			if properties[k] != v {
+				match = false
+				break
+			}
+		}
+		if match {
+			c.state = state
+			break
+		}
+	}
+	return c
+}
+
+// Properties ...
+func (c Custom) Properties() customblock.Properties {
+	return c.t.conf.Properties
+}
+
+// States ...
+func (c Custom) States() map[string][]any {
+	return c.t.conf.States
+}
+
+// Permutations ...
+func (c Custom) Permutations() []customblock.Permutation {
+	return c.t.conf.Permutations
+}
+
+// BreakInfo ...
+func (c Custom) BreakInfo() BreakInfo {
+	return newBreakInfo(c.t.conf.Hardness, alwaysHarvestable, nothingEffective, simpleDrops())
+}
+
+// PistonPushReaction ...
+func (c Custom) PistonPushReaction() PistonPushReaction {
+	return c.t.conf.PistonPushReaction
+}
+
+// Model ...
+func (c Custom) Model() world.BlockModel {
+	return c.t.model
+}
+
+// EncodeBlock ...
+func (c Custom) EncodeBlock() (string, map[string]any) {
+	return c.t.conf.Identifier, c.t.states[c.state]
+}
+
+// Hash ...
+func (c Custom) Hash() (uint64, uint64) {
+	return c.t.hash, uint64(c.state)
+}
+
+// customModel is the model of a Custom block with a collision box other than that of a full block.
+type customModel struct {
+	box cube.BBox
+}
+
+// BBox ...
+func (m customModel) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	return []cube.BBox{m.box}
+}
+
+// FaceSolid ...
+func (customModel) FaceSolid(cube.Pos, cube.Face, world.BlockSource) bool {
+	return false
+}
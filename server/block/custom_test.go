@@ -0,0 +1,141 @@
+package block
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/customblock"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+var (
+	testCustomLamp = CustomConfig{
+		Identifier: "test:lamp",
+		States: map[string][]any{
+			"test:lit":    {false, true},
+			"test:colour": {"red", "green", "blue"},
+		},
+		Properties: customblock.Properties{CollisionBox: cube.Box(0.25, 0, 0.25, 0.75, 0.5, 0.75), Cube: true},
+		Hardness:   0.3,
+	}
+	testCustomPillar = CustomConfig{
+		Identifier: "test:pillar",
+		States:     map[string][]any{"test:height": {int32(0), int32(1), int32(2), int32(3)}},
+	}
+)
+
+// newCustomTestRegistry returns a new world.BlockRegistry for custom blocks to be registered to. Hashes of blocks
+// holding other blocks, such as slabs, depend on the world.DefaultBlockRegistry being finalized.
+func newCustomTestRegistry() world.BlockRegistry {
+	world.DefaultBlockRegistry.Finalize()
+	return world.NewBlockRegistry()
+}
+
+func TestCustomRegistersAllStates(t *testing.T) {
+	reg := newCustomTestRegistry()
+	lamp := RegisterCustom(reg, testCustomLamp)
+	reg.Finalize()
+
+	seen := map[uint32]struct{}{}
+	for _, lit := range []bool{false, true} {
+		for _, colour := range []string{"red", "green", "blue"} {
+			b := lamp.WithState("test:lit", lit).WithState("test:colour", colour)
+			if v, _ := b.State("test:lit"); v != lit {
+				t.Errorf("custom block lit state = %v, want %v", v, lit)
+			}
+			if v, _ := b.State("test:colour"); v != colour {
+				t.Errorf("custom block colour state = %v, want %v", v, colour)
+			}
+			name, properties := b.EncodeBlock()
+			rid, ok := reg.StateToRuntimeID(name, properties)
+			if !ok {
+				t.Fatalf("custom block state %v %v not registered", name, properties)
+			}
+			if got := reg.BlockRuntimeID(b); got != rid {
+				t.Errorf("custom block runtime ID = %d, want %d", got, rid)
+			}
+			seen[rid] = struct{}{}
+		}
+	}
+	if len(seen) != 6 {
+		t.Errorf("custom block registered %d distinct states, want 6", len(seen))
+	}
+	if _, ok := reg.CustomBlocks()["test:lamp"]; !ok {
+		t.Errorf("custom block not found in custom blocks of registry")
+	}
+	if box := lamp.Model().BBox(cube.Pos{}, nil); len(box) != 1 || box[0] != testCustomLamp.Properties.CollisionBox {
+		t.Errorf("custom block bounding box = %v, want %v", box, testCustomLamp.Properties.CollisionBox)
+	}
+}
+
+func TestCustomChunkRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding chunk.Encoding
+	}{
+		{name: "disk", encoding: chunk.DiskEncoding},
+		{name: "network", encoding: chunk.NetworkEncoding},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The blocks are registered in a different order in the second registry, which mimics a server restart
+			// after which the runtime IDs must remain the same as those known by the client.
+			reg := newCustomTestRegistry()
+			lamp := RegisterCustom(reg, testCustomLamp).WithState("test:lit", true).WithState("test:colour", "green")
+			pillar := RegisterCustom(reg, testCustomPillar).WithState("test:height", int32(2))
+			reg.Finalize()
+			other := newCustomTestRegistry()
+			RegisterCustom(other, testCustomPillar)
+			RegisterCustom(other, testCustomLamp)
+			other.Finalize()
+
+			r := cube.Range{-64, 319}
+			c := chunk.New(reg, r)
+			c.SetBlock(1, 2, 3, 0, reg.BlockRuntimeID(lamp))
+			c.SetBlock(4, 5, 6, 0, reg.BlockRuntimeID(pillar))
+
+			data := chunk.Encode(c, test.encoding)
+			var decoded *chunk.Chunk
+			var err error
+			if test.encoding == chunk.DiskEncoding {
+				decoded, err = chunk.DiskDecode(other, data, r)
+			} else {
+				decoded, err = chunk.NetworkDecode(other, networkChunkData(data), len(data.SubChunks), r)
+			}
+			if err != nil {
+				t.Fatalf("decode chunk: %v", err)
+			}
+			for _, b := range []struct {
+				x, z  uint8
+				y     int16
+				block world.Block
+			}{{x: 1, y: 2, z: 3, block: lamp}, {x: 4, y: 5, z: 6, block: pillar}} {
+				rid := decoded.Block(b.x, b.y, b.z, 0)
+				if want := reg.BlockRuntimeID(b.block); rid != want {
+					t.Errorf("runtime ID of decoded %v = %d, want %d", b.block, rid, want)
+				}
+				got, ok := other.BlockByRuntimeID(rid)
+				if !ok {
+					t.Fatalf("no block with runtime ID %d", rid)
+				}
+				gotName, gotProperties := got.EncodeBlock()
+				wantName, wantProperties := b.block.EncodeBlock()
+				if gotName != wantName || !maps.Equal(gotProperties, wantProperties) {
+					t.Errorf("decoded block = %v %v, want %v %v", gotName, gotProperties, wantName, wantProperties)
+				}
+			}
+		})
+	}
+}
+
+// networkChunkData concatenates the sub chunks and biomes of the chunk.SerialisedData passed, as they are sent
+// over network.
+func networkChunkData(data chunk.SerialisedData) []byte {
+	var b []byte
+	for _, sub := range data.SubChunks {
+		b = append(b, sub...)
+	}
+	return append(b, data.Biomes...)
+}
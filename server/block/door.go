@@ -0,0 +1,106 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// door is implemented by all doors. It allows both halves of a door, and a second door forming a double door
+// with it, to be opened and closed together.
+type door interface {
+	world.Block
+	// doorState returns the direction the door faces, whether it is open, whether it is the top half and
+	// whether its hinge is on the right side.
+	doorState() (facing cube.Direction, open, top, right bool)
+	// withOpen returns the door with its open state set to the value passed.
+	withOpen(open bool) door
+}
+
+// setDoorOpen opens or closes the door at pos together with its other half and plays the door sound. If pair is
+// true, a door forming a double door with it is opened or closed too.
+func setDoorOpen(pos cube.Pos, tx *world.Tx, d door, open, pair bool) {
+	if _, wasOpen, _, _ := d.doorState(); wasOpen == open {
+		return
+	}
+	pairPos, paired, hasPair := pairedDoor(pos, tx, d)
+
+	d = d.withOpen(open)
+	tx.SetBlock(pos, d, nil)
+	if otherPos, other, ok := doorHalf(pos, tx, d); ok {
+		tx.SetBlock(otherPos, other.withOpen(open), nil)
+	}
+	if open {
+		tx.PlaySound(pos.Vec3Centre(), sound.DoorOpen{Block: d})
+	} else {
+		tx.PlaySound(pos.Vec3Centre(), sound.DoorClose{Block: d})
+	}
+	if pair && hasPair {
+		setDoorOpen(pairPos, tx, paired, open, false)
+	}
+}
+
+// doorHalf returns the other half of the door at pos. False is returned if the block above or below the door,
+// depending on its half, is not the other half of the same door.
+func doorHalf(pos cube.Pos, tx *world.Tx, d door) (cube.Pos, door, bool) {
+	_, _, top, _ := d.doorState()
+	otherPos := pos.Side(cube.FaceUp)
+	if top {
+		otherPos = pos.Side(cube.FaceDown)
+	}
+	other, ok := tx.Block(otherPos).(door)
+	if !ok || !sameDoor(d, other) {
+		return otherPos, nil, false
+	}
+	if _, _, otherTop, _ := other.doorState(); otherTop == top {
+		return otherPos, nil, false
+	}
+	return otherPos, other, true
+}
+
+// pairedDoor returns the door forming a double door with the door at pos. A door is paired with a door of the
+// same type next to it on the side opposite of its hinge, if that door faces the same direction, is in the same
+// state and has its hinge on the other side.
+func pairedDoor(pos cube.Pos, tx *world.Tx, d door) (cube.Pos, door, bool) {
+	facing, open, top, right := d.doorState()
+	side := facing.RotateRight()
+	if right {
+		side = facing.RotateLeft()
+	}
+	pairPos := pos.Side(side.Face())
+	paired, ok := tx.Block(pairPos).(door)
+	if !ok || !sameDoor(d, paired) {
+		return pairPos, nil, false
+	}
+	if pairFacing, pairOpen, pairTop, pairRight := paired.doorState(); pairFacing != facing || pairOpen != open || pairTop != top || pairRight == right {
+		return pairPos, nil, false
+	}
+	return pairPos, paired, true
+}
+
+// sameDoor checks if two doors are of the same type.
+func sameDoor(a, b door) bool {
+	aName, _ := a.EncodeBlock()
+	bName, _ := b.EncodeBlock()
+	return aName == bName
+}
+
+// updateDoorPower opens or closes the door at pos after the redstone power it receives changed from oldPower to
+// newPower. A door stays open as long as either of its halves is powered.
+func updateDoorPower(pos cube.Pos, tx *world.Tx, d door, oldPower, newPower int) {
+	if (oldPower > 0) == (newPower > 0) {
+		return
+	}
+	powered := newPower > 0
+	if otherPos, _, ok := doorHalf(pos, tx, d); ok && !powered {
+		powered = tx.RedstonePower(otherPos) > 0
+	}
+	setDoorOpen(pos, tx, d, powered, false)
+}
+
+// redstoneOpen returns whether a trapdoor or fence gate should be open after the redstone power it receives
+// changed from oldPower to newPower. False is returned if its open state should not change.
+func redstoneOpen(open bool, oldPower, newPower int) (bool, bool) {
+	powered := newPower > 0
+	return powered, (oldPower > 0) != powered && open != powered
+}
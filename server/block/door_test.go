@@ -0,0 +1,115 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestDoubleDoorOpensTogether(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	left := cube.Pos{0, 64, 0}
+	right := left.Side(cube.North.RotateRight().Face())
+	other := left.Side(cube.North.RotateLeft().Face())
+	runWorld(w, func(tx *world.Tx) {
+		placeDoorTest(tx, left, WoodDoor{Wood: OakWood(), Facing: cube.North})
+		placeDoorTest(tx, right, WoodDoor{Wood: OakWood(), Facing: cube.North, Right: true})
+		// A door of another type on the other side is never paired.
+		placeDoorTest(tx, other, WoodDoor{Wood: SpruceWood(), Facing: cube.North, Right: true})
+
+		tx.Block(left.Side(cube.FaceUp)).(WoodDoor).Activate(left.Side(cube.FaceUp), cube.FaceNorth, tx, nil, nil)
+		for _, pos := range []cube.Pos{left, right} {
+			for _, half := range []cube.Pos{pos, pos.Side(cube.FaceUp)} {
+				if !tx.Block(half).(WoodDoor).Open {
+					t.Errorf("door half at %v closed after opening its double door, want open", half)
+				}
+			}
+		}
+		if tx.Block(other).(WoodDoor).Open {
+			t.Errorf("door of another type next to double door opened, want closed")
+		}
+
+		tx.Block(right).(WoodDoor).Activate(right, cube.FaceNorth, tx, nil, nil)
+		for _, pos := range []cube.Pos{left, right} {
+			if tx.Block(pos).(WoodDoor).Open || tx.Block(pos.Side(cube.FaceUp)).(WoodDoor).Open {
+				t.Errorf("door at %v open after closing its double door, want closed", pos)
+			}
+		}
+	})
+}
+
+func TestIronDoorOpensOnlyWithRedstone(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	leverPos := pos.Side(cube.FaceEast)
+	runWorld(w, func(tx *world.Tx) {
+		placeDoorTest(tx, pos, IronDoor{Facing: cube.North})
+		tx.SetBlock(leverPos.Side(cube.FaceDown), Stone{}, nil)
+		tx.SetBlock(leverPos, Lever{Facing: cube.FaceUp}, nil)
+
+		if _, ok := tx.Block(pos).(Activatable); ok {
+			t.Errorf("iron door can be activated by hand, want only redstone to open it")
+		}
+	})
+	w.AdvanceTick()
+	assertIronDoorOpen(t, w, pos, false)
+
+	redstoneWireTestSetBlockAndWait(t, w, leverPos, Lever{Powered: true, Facing: cube.FaceUp})
+	assertIronDoorOpen(t, w, pos, true)
+
+	redstoneWireTestSetBlockAndWait(t, w, leverPos, Lever{Facing: cube.FaceUp})
+	assertIronDoorOpen(t, w, pos, false)
+}
+
+func TestDoorHalvesBreakTogether(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	bottom, top := cube.Pos{0, 64, 0}, cube.Pos{4, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		placeDoorTest(tx, bottom, WoodDoor{Wood: OakWood()})
+		placeDoorTest(tx, top, WoodDoor{Wood: OakWood()})
+		tx.SetBlock(bottom, nil, nil)
+		tx.SetBlock(top.Side(cube.FaceUp), nil, nil)
+	})
+	w.AdvanceTick()
+
+	runWorld(w, func(tx *world.Tx) {
+		if b := tx.Block(bottom.Side(cube.FaceUp)); b != (Air{}) {
+			t.Errorf("top half after breaking the bottom half = %v, want air", b)
+		}
+		if b := tx.Block(top); b != (Air{}) {
+			t.Errorf("bottom half after breaking the top half = %v, want air", b)
+		}
+	})
+}
+
+// placeDoorTest places both halves of the door passed at pos on top of a stone block.
+func placeDoorTest(tx *world.Tx, pos cube.Pos, d door) {
+	tx.SetBlock(pos.Side(cube.FaceDown), Stone{}, nil)
+	tx.SetBlock(pos, d, nil)
+	switch d := d.(type) {
+	case WoodDoor:
+		d.Top = true
+		tx.SetBlock(pos.Side(cube.FaceUp), d, nil)
+	case IronDoor:
+		d.Top = true
+		tx.SetBlock(pos.Side(cube.FaceUp), d, nil)
+	}
+}
+
+func assertIronDoorOpen(t *testing.T, w *world.World, pos cube.Pos, open bool) {
+	t.Helper()
+	runWorld(w, func(tx *world.Tx) {
+		for _, half := range []cube.Pos{pos, pos.Side(cube.FaceUp)} {
+			if d := tx.Block(half).(IronDoor); d.Open != open {
+				t.Errorf("iron door half at %v open = %v, want %v", half, d.Open, open)
+			}
+		}
+	})
+}
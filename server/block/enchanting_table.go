@@ -64,3 +64,8 @@ func (e EnchantingTable) EncodeNBT() map[string]any {
 func (e EnchantingTable) DecodeNBT(map[string]any) any {
 	return e
 }
+
+// PistonPushReaction ...
+func (EnchantingTable) PistonPushReaction() PistonPushReaction {
+	return PistonPushBlock
+}
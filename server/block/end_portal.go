@@ -63,3 +63,8 @@ func (EndPortal) EntityInside(_ cube.Pos, tx *world.Tx, e world.Entity) {
 func (EndPortal) EncodeBlock() (string, map[string]any) {
 	return "minecraft:end_portal", nil
 }
+
+// PistonPushReaction ...
+func (EndPortal) PistonPushReaction() PistonPushReaction {
+	return PistonPushBlock
+}
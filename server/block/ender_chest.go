@@ -135,3 +135,8 @@ func allEnderChests() (chests []world.Block) {
 	}
 	return
 }
+
+// PistonPushReaction ...
+func (EnderChest) PistonPushReaction() PistonPushReaction {
+	return PistonPushBlock
+}
@@ -42,8 +42,8 @@ type ExplosionConfig struct {
 // ExplodableEntity represents an entity that can be exploded.
 type ExplodableEntity interface {
 	// Explode is called when an explosion occurs. The entity can then react to the explosion using the configuration
-	// and impact provided.
-	Explode(explosionPos mgl64.Vec3, impact float64, c ExplosionConfig)
+	// and impact provided. The damage and knockback of the impact may have been changed by the world.Handler.
+	Explode(explosionPos mgl64.Vec3, impact world.ExplosionImpact, c ExplosionConfig)
 }
 
 // Explodable represents a block that can be exploded.
@@ -98,15 +98,17 @@ func (c ExplosionConfig) Explode(tx *world.Tx, explosionPos mgl64.Vec3) {
 		math.Ceil(explosionPos[2]+d+1),
 	)
 
-	affectedEntities := make([]world.Entity, 0, 32)
+	affectedEntities := make([]world.ExplosionImpact, 0, 32)
 	for e := range tx.EntitiesWithin(box.Grow(2)) {
 		pos := e.Position()
 		dist := pos.Sub(explosionPos).Len()
 		if dist > d || dist == 0 {
 			continue
 		}
-
-		affectedEntities = append(affectedEntities, e)
+		if _, ok := e.(ExplodableEntity); ok {
+			impact := (1 - dist/d) * exposure(tx, explosionPos, e)
+			affectedEntities = append(affectedEntities, c.impact(e, explosionPos, impact))
+		}
 	}
 
 	affectedBlocks := make([]cube.Pos, 0, 32)
@@ -140,9 +142,8 @@ func (c ExplosionConfig) Explode(tx *world.Tx, explosionPos mgl64.Vec3) {
 		return
 	}
 
-	for _, e := range affectedEntities {
-		if explodable, ok := e.(ExplodableEntity); ok {
-			impact := (1 - e.Position().Sub(explosionPos).Len()/d) * exposure(tx, explosionPos, e)
+	for _, impact := range affectedEntities {
+		if explodable, ok := impact.Entity.(ExplodableEntity); ok {
 			explodable.Explode(explosionPos, impact, c)
 		}
 	}
@@ -180,10 +181,24 @@ func (c ExplosionConfig) Explode(tx *world.Tx, explosionPos mgl64.Vec3) {
 	tx.PlaySound(explosionPos, c.Sound)
 }
 
+// impact computes the damage and knockback dealt to an entity by the explosion. The impact passed is a value
+// between 0 and 1, depending on the distance of the entity to the explosion and its exposure to it. Entities
+// that are not exposed to the explosion at all are neither damaged nor knocked back.
+func (c ExplosionConfig) impact(e world.Entity, explosionPos mgl64.Vec3, impact float64) world.ExplosionImpact {
+	if impact <= 0 {
+		return world.ExplosionImpact{Entity: e}
+	}
+	return world.ExplosionImpact{
+		Entity:    e,
+		Damage:    math.Floor((impact*impact+impact)*3.5*c.Size*2 + 1),
+		Knockback: e.Position().Sub(explosionPos).Normalize().Mul(impact),
+	}
+}
+
 // exposure returns the exposure of an explosion to an entity, used to calculate the impact of an explosion.
 func exposure(tx *world.Tx, origin mgl64.Vec3, e world.Entity) float64 {
 	pos := e.Position()
-	box := e.H().Type().BBox(e).Translate(pos)
+	box := world.EntityBBox(e).Translate(pos)
 
 	boxMin, boxMax := box.Min(), box.Max()
 	diff := boxMax.Sub(boxMin).Mul(2.0).Add(mgl64.Vec3{1, 1, 1})
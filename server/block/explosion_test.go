@@ -0,0 +1,113 @@
+package block
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestExplosionHandlerClearsBlocks(t *testing.T) {
+	w := world.Config{}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	w.Handle(&explosionTestHandler{f: func(_ []world.ExplosionImpact, blocks *[]cube.Pos) {
+		*blocks = nil
+	}})
+
+	origin := cube.Pos{0, 64, 0}
+	faces := []cube.Face{cube.FaceDown, cube.FaceWest, cube.FaceEast, cube.FaceNorth}
+	runWorld(w, func(tx *world.Tx) {
+		for _, face := range faces {
+			tx.SetBlock(origin.Side(face), Stone{}, nil)
+		}
+		data := &explosionTestData{}
+		tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 64.5, 2.5}}.New(explosionTestEntityType{}, data))
+		ExplosionConfig{RandSource: rand.NewPCG(1, 2)}.Explode(tx, origin.Vec3Centre())
+
+		for _, face := range faces {
+			if _, ok := tx.Block(origin.Side(face)).(Stone); !ok {
+				t.Errorf("block at %v was removed by explosion without affected blocks", origin.Side(face))
+			}
+		}
+		if !data.exploded || data.impact.Damage <= 0 {
+			t.Errorf("entity damage after clearing affected blocks = %v, want above 0", data.impact.Damage)
+		}
+	})
+}
+
+func TestExplosionHandlerModifiesDamage(t *testing.T) {
+	w := world.Config{}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	var computed float64
+	w.Handle(&explosionTestHandler{f: func(entities []world.ExplosionImpact, _ *[]cube.Pos) {
+		computed = entities[0].Damage
+		entities[0].Damage *= 2
+		entities[0].Knockback = mgl64.Vec3{}
+	}})
+
+	runWorld(w, func(tx *world.Tx) {
+		data := &explosionTestData{}
+		tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 64, 2.5}}.New(explosionTestEntityType{}, data))
+		ExplosionConfig{RandSource: rand.NewPCG(1, 2)}.Explode(tx, mgl64.Vec3{0.5, 64, 0.5})
+
+		if computed <= 0 {
+			t.Fatalf("computed explosion damage = %v, want above 0", computed)
+		}
+		if data.impact.Damage != computed*2 {
+			t.Errorf("entity explosion damage = %v, want %v", data.impact.Damage, computed*2)
+		}
+		if data.impact.Knockback != (mgl64.Vec3{}) {
+			t.Errorf("entity explosion knockback = %v, want none", data.impact.Knockback)
+		}
+	})
+}
+
+type explosionTestHandler struct {
+	world.NopHandler
+	f func(entities []world.ExplosionImpact, blocks *[]cube.Pos)
+}
+
+func (h *explosionTestHandler) HandleExplosion(_ *world.Context, _ mgl64.Vec3, entities *[]world.ExplosionImpact, blocks *[]cube.Pos, _ *float64, _ *bool) {
+	h.f(*entities, blocks)
+}
+
+type explosionTestData struct {
+	exploded bool
+	impact   world.ExplosionImpact
+}
+
+func (d *explosionTestData) Apply(data *world.EntityData) {
+	data.Data = d
+}
+
+// explosionTestEntity is an ExplodableEntity that records the impact of the last explosion it was affected by.
+type explosionTestEntity struct {
+	handle *world.EntityHandle
+	data   *world.EntityData
+}
+
+func (e *explosionTestEntity) Explode(_ mgl64.Vec3, impact world.ExplosionImpact, _ ExplosionConfig) {
+	d := e.data.Data.(*explosionTestData)
+	d.exploded, d.impact = true, impact
+}
+
+func (e *explosionTestEntity) H() *world.EntityHandle  { return e.handle }
+func (e *explosionTestEntity) Position() mgl64.Vec3    { return e.data.Pos }
+func (e *explosionTestEntity) Rotation() cube.Rotation { return e.data.Rot }
+func (e *explosionTestEntity) Close() error            { return nil }
+
+type explosionTestEntityType struct{}
+
+func (explosionTestEntityType) Open(_ *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &explosionTestEntity{handle: handle, data: data}
+}
+
+func (explosionTestEntityType) EncodeEntity() string { return "minecraft:explosion_test_entity" }
+func (explosionTestEntityType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.8, 0.3)
+}
+func (explosionTestEntityType) DecodeNBT(map[string]any, *world.EntityData) {}
+func (explosionTestEntityType) EncodeNBT(*world.EntityData) map[string]any  { return nil }
@@ -21,7 +21,7 @@ type Farmland struct {
 // SoilFor ...
 func (f Farmland) SoilFor(block world.Block) bool {
 	switch block.(type) {
-	case ShortGrass, Fern, DoubleTallGrass, Flower, DoubleFlower, NetherSprouts, PinkPetals, DeadBush:
+	case ShortGrass, Fern, DoubleTallGrass, Flower, DoubleFlower, NetherSprouts, PinkPetals, DeadBush, Sapling:
 		return true
 	}
 	return false
@@ -52,8 +52,12 @@ func (f Farmland) RandomTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
 	}
 }
 
-// hydrated checks for water within 4 blocks in each direction from the farmland.
+// hydrated checks for water within 4 blocks horizontally from the farmland, at the same level or one block above
+// it, or if it is raining on the farmland.
 func (f Farmland) hydrated(pos cube.Pos, tx *world.Tx) bool {
+	if tx.RainingAt(pos.Side(cube.FaceUp)) {
+		return true
+	}
 	posX, posY, posZ := pos.X(), pos.Y(), pos.Z()
 	for y := 0; y <= 1; y++ {
 		for x := -4; x <= 4; x++ {
@@ -69,15 +73,24 @@ func (f Farmland) hydrated(pos cube.Pos, tx *world.Tx) bool {
 	return false
 }
 
-// EntityLand ...
-func (f Farmland) EntityLand(pos cube.Pos, tx *world.Tx, e world.Entity, _ *float64) {
-	if living, ok := e.(livingEntity); ok {
-		if fall, ok := living.(fallDistanceEntity); ok && rand.Float64() < fall.FallDistance()-0.5 {
-			ctx := tx.Event()
-			if tx.World().Handler().HandleCropTrample(ctx, pos); !ctx.Cancelled() {
-				tx.SetBlock(pos, Dirt{}, nil)
-			}
-		}
+// EntityLand tramples the farmland if a living entity lands on it after falling, turning it back into dirt and
+// destroying any crop planted on it. The further the entity fell, the more likely it is to trample the farmland.
+// Entities other than players only trample farmland if the world has mob griefing enabled.
+func (f Farmland) EntityLand(pos cube.Pos, tx *world.Tx, e world.Entity, distance *float64) {
+	if _, ok := e.(livingEntity); !ok || rand.Float64() >= *distance-0.5 {
+		return
+	}
+	if e.H().Type().EncodeEntity() != "minecraft:player" && !tx.World().MobGriefing() {
+		return
+	}
+	ctx := tx.Event()
+	if tx.World().Handler().HandleCropTrample(ctx, pos); ctx.Cancelled() {
+		return
+	}
+	tx.SetBlock(pos, Dirt{}, nil)
+	above := pos.Side(cube.FaceUp)
+	if _, ok := tx.Block(above).(Crop); ok {
+		breakBlock(tx.Block(above), above, tx)
 	}
 }
 
@@ -0,0 +1,110 @@
+package block
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestFarmlandHydrationDistance(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+	w.StopRaining()
+
+	tests := []struct {
+		water cube.Pos
+		want  int
+	}{
+		{water: cube.Pos{4, 0, 4}, want: 7},
+		{water: cube.Pos{-4, 1, 0}, want: 7},
+		// Water further than 4 blocks away or more than a block above the
+		// farmland does not hydrate it, so it slowly dries out.
+		{water: cube.Pos{5, 0, 0}, want: 2},
+		{water: cube.Pos{0, 2, 0}, want: 2},
+		{water: cube.Pos{0, -1, 0}, want: 2},
+	}
+	r := rand.New(rand.NewPCG(0, 0))
+	for i, test := range tests {
+		pos := cube.Pos{i * 32, 64, 0}
+		runWorld(w, func(tx *world.Tx) {
+			tx.SetBlock(pos.Add(test.water), Water{Still: true, Depth: 8}, nil)
+			tx.SetBlock(pos, Farmland{Hydration: 3}, nil)
+
+			tx.Block(pos).(Farmland).RandomTick(pos, tx, r)
+			if f, ok := tx.Block(pos).(Farmland); !ok || f.Hydration != test.want {
+				t.Errorf("farmland with water at offset %v after random tick = %#v, want hydration %v", test.water, tx.Block(pos), test.want)
+			}
+		})
+	}
+
+	runWorld(w, func(tx *world.Tx) {
+		// Dry farmland turns into dirt, unless a crop is planted on it.
+		pos := cube.Pos{0, 64, 64}
+		tx.SetBlock(pos, Farmland{}, nil)
+		tx.SetBlock(pos.Add(cube.Pos{1, 0, 0}), Farmland{}, nil)
+		tx.SetBlock(pos.Add(cube.Pos{1, 1, 0}), WheatSeeds{}, nil)
+		Farmland{}.RandomTick(pos, tx, r)
+		Farmland{}.RandomTick(pos.Add(cube.Pos{1, 0, 0}), tx, r)
+		if _, ok := tx.Block(pos).(Dirt); !ok {
+			t.Errorf("dry farmland after random tick = %#v, want dirt", tx.Block(pos))
+		}
+		if _, ok := tx.Block(pos.Add(cube.Pos{1, 0, 0})).(Farmland); !ok {
+			t.Errorf("dry farmland with a crop after random tick = %#v, want farmland", tx.Block(pos.Add(cube.Pos{1, 0, 0})))
+		}
+	})
+
+	// Farmland exposed to rain is hydrated without water nearby.
+	w.StartRaining(time.Minute)
+	runWorld(w, func(tx *world.Tx) {
+		pos := cube.Pos{0, 64, 128}
+		tx.SetBlock(pos, Farmland{}, nil)
+		Farmland{}.RandomTick(pos, tx, r)
+		if f, ok := tx.Block(pos).(Farmland); !ok || f.Hydration != 7 {
+			t.Errorf("farmland in rain after random tick = %#v, want hydration 7", tx.Block(pos))
+		}
+	})
+}
+
+func TestFarmlandTrampling(t *testing.T) {
+	for _, mobGriefing := range []bool{true, false} {
+		w := world.Config{Synchronous: true, Entities: farmlandTestEntityRegistry()}.New()
+		w.SetMobGriefing(mobGriefing)
+
+		pos := cube.Pos{0, 64, 0}
+		runWorld(w, func(tx *world.Tx) {
+			tx.SetBlock(pos, Farmland{Hydration: 7}, nil)
+			tx.SetBlock(pos.Side(cube.FaceUp), WheatSeeds{crop: crop{Growth: 7}}, nil)
+			e := tx.AddEntity(world.EntitySpawnOpts{Position: pos.Side(cube.FaceUp).Vec3Middle()}.New(conduitTestEntityType{}, conduitTestEntityConfig{e: &conduitTestEntity{pos: pos.Side(cube.FaceUp).Vec3Middle()}}))
+
+			// Landing after barely falling never tramples farmland.
+			distance := 0.5
+			Farmland{Hydration: 7}.EntityLand(pos, tx, e, &distance)
+			if _, ok := tx.Block(pos).(Farmland); !ok {
+				t.Fatalf("farmland was trampled by an entity that did not fall")
+			}
+
+			distance = 3
+			Farmland{Hydration: 7}.EntityLand(pos, tx, e, &distance)
+			_, dirt := tx.Block(pos).(Dirt)
+			_, air := tx.Block(pos.Side(cube.FaceUp)).(Air)
+			if mobGriefing && (!dirt || !air) {
+				t.Errorf("after trampling, farmland = %#v and crop = %#v, want dirt without crop", tx.Block(pos), tx.Block(pos.Side(cube.FaceUp)))
+			}
+			if !mobGriefing && (dirt || air) {
+				t.Errorf("farmland was trampled by a mob with mob griefing disabled")
+			}
+		})
+		_ = w.Close()
+	}
+}
+
+func farmlandTestEntityRegistry() world.EntityRegistry {
+	return world.EntityRegistryConfig{
+		Item: func(opts world.EntitySpawnOpts, _ any) *world.EntityHandle {
+			return opts.New(redstoneTNTTestEntityType{}, redstoneTNTTestEntityConfig{})
+		},
+	}.New([]world.EntityType{redstoneTNTTestEntityType{}, conduitTestEntityType{}})
+}
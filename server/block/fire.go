@@ -45,8 +45,7 @@ func neighboursFlammable(pos cube.Pos, tx *world.Tx) bool {
 // infinitelyBurning returns true if fire can infinitely burn at the specified position.
 func infinitelyBurning(pos cube.Pos, tx *world.Tx) bool {
 	switch block := tx.Block(pos.Side(cube.FaceDown)).(type) {
-	// TODO: Magma Block
-	case Netherrack:
+	case Netherrack, Magma:
 		return true
 	case Bedrock:
 		return block.InfiniteBurning
@@ -83,7 +82,9 @@ func rainingAround(pos cube.Pos, tx *world.Tx) bool {
 
 // tick ...
 func (f Fire) tick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
-	if f.Type == SoulFire() {
+	if f.Type == SoulFire() || !tx.World().FireTick() {
+		// Soul fire never spreads, and no fire spreads or burns out if fire
+		// ticking is disabled in the world.
 		return
 	}
 	infinitelyBurns := infinitelyBurning(pos, tx)
@@ -0,0 +1,97 @@
+package block
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	_ "github.com/df-mc/dragonfly/server/world/biome"
+)
+
+func TestFireSpreadsToAdjacentWood(t *testing.T) {
+	tests := []struct {
+		name     string
+		fireTick bool
+	}{
+		{name: "fire tick", fireTick: true},
+		{name: "no fire tick"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := world.Config{Synchronous: true}.New()
+			defer w.Close()
+			w.StopWeatherCycle()
+			w.StopRaining()
+			w.SetFireTick(test.fireTick)
+
+			firePos := cube.Pos{0, 65, 0}
+			runWorld(w, func(tx *world.Tx) {
+				for x := -2; x <= 2; x++ {
+					tx.SetBlock(cube.Pos{x, 64, 0}, Planks{Wood: OakWood()}, nil)
+				}
+				tx.SetBlock(firePos, Fire{}, nil)
+
+				r := rand.New(rand.NewPCG(1, 2))
+				for range 100 {
+					if f, ok := tx.Block(firePos).(Fire); ok {
+						f.tick(firePos, tx, r)
+					}
+				}
+				if spread := fireSpread(tx, firePos); spread != test.fireTick {
+					t.Errorf("fire spread to adjacent wood: %t, want %t", spread, test.fireTick)
+				}
+			})
+		})
+	}
+}
+
+func TestFireOnNetherrackDoesNotDecay(t *testing.T) {
+	tests := []struct {
+		name  string
+		below world.Block
+		burns bool
+	}{
+		{name: "netherrack", below: Netherrack{}, burns: true},
+		{name: "magma", below: Magma{}, burns: true},
+		{name: "stone", below: Stone{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := world.Config{Synchronous: true}.New()
+			defer w.Close()
+
+			pos := cube.Pos{0, 65, 0}
+			runWorld(w, func(tx *world.Tx) {
+				tx.SetBlock(pos.Side(cube.FaceDown), test.below, nil)
+				tx.SetBlock(pos, Fire{}, nil)
+
+				r := rand.New(rand.NewPCG(1, 2))
+				for range 100 {
+					if f, ok := tx.Block(pos).(Fire); ok {
+						f.tick(pos, tx, r)
+					}
+				}
+				if _, burns := tx.Block(pos).(Fire); burns != test.burns {
+					t.Errorf("fire on %T still burning: %t, want %t", test.below, burns, test.burns)
+				}
+			})
+		})
+	}
+}
+
+// fireSpread checks if fire is found anywhere around the cube.Pos passed, other than at the position itself.
+func fireSpread(tx *world.Tx, pos cube.Pos) bool {
+	for x := -3; x <= 3; x++ {
+		for y := -1; y <= 4; y++ {
+			for z := -2; z <= 2; z++ {
+				if p := pos.Add(cube.Pos{x, y, z}); p != pos {
+					if _, ok := tx.Block(p).(Fire); ok {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
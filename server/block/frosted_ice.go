@@ -0,0 +1,117 @@
+package block
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// FrostedIce is a variant of ice created when an entity wearing boots with the Frost Walker enchantment walks
+// over still water. Frosted ice ages over time and melts back into water once it has aged fully.
+type FrostedIce struct {
+	solid
+	transparent
+
+	// Age is the age of the frosted ice, from 0-3. Frosted ice with an age of 3 melts into water the next time
+	// it ages.
+	Age int
+}
+
+// Freeze turns the block at pos into frosted ice if it is still water with air above it. Freeze returns true if
+// the water was frozen. The frosted ice starts melting after a while.
+func (FrostedIce) Freeze(pos cube.Pos, tx *world.Tx) bool {
+	if w, ok := tx.Block(pos).(Water); !ok || !w.Still || w.Depth != 8 {
+		return false
+	}
+	if _, ok := tx.Block(pos.Side(cube.FaceUp)).(Air); !ok {
+		return false
+	}
+	tx.SetBlock(pos, FrostedIce{}, nil)
+	tx.ScheduleBlockUpdate(pos, FrostedIce{}, time.Duration(60+rand.IntN(60))*time.Second/20)
+	return true
+}
+
+// Instrument ...
+func (FrostedIce) Instrument() sound.Instrument {
+	return sound.Chimes()
+}
+
+// BreakInfo ...
+func (f FrostedIce) BreakInfo() BreakInfo {
+	return newBreakInfo(0.5, alwaysHarvestable, pickaxeEffective, simpleDrops()).withBreakHandler(func(pos cube.Pos, tx *world.Tx, u item.User) {
+		if gm, ok := u.(interface{ GameMode() world.GameMode }); ok && gm.GameMode().CreativeInventory() {
+			return
+		}
+		if _, air := tx.Block(pos.Side(cube.FaceDown)).(Air); !air {
+			Ice{}.melt(pos, tx)
+		}
+	})
+}
+
+// Friction ...
+func (FrostedIce) Friction() float64 {
+	return 0.98
+}
+
+// LightDiffusionLevel ...
+func (FrostedIce) LightDiffusionLevel() uint8 {
+	return 2
+}
+
+// ScheduledTick ages the frosted ice if it is bright enough around it. Frosted ice with few neighbouring
+// frosted ice blocks always ages, so that frosted ice melts from the edges inwards.
+func (f FrostedIce) ScheduledTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
+	if (r.IntN(3) == 0 || f.neighbours(pos, tx) < 4) && int(tx.Light(pos)) > 11-f.Age-int(f.LightDiffusionLevel()) {
+		f.age(pos, tx, r, true)
+		return
+	}
+	tx.ScheduleBlockUpdate(pos, f, time.Duration(20+r.IntN(20))*time.Second/20)
+}
+
+// age increases the age of the frosted ice, melting it into water if it was fully aged. If melt is true,
+// neighbouring frosted ice that has fewer than 2 frosted ice neighbours itself also ages after melting.
+func (f FrostedIce) age(pos cube.Pos, tx *world.Tx, r *rand.Rand, melt bool) {
+	if f.Age < 3 {
+		f.Age++
+		tx.SetBlock(pos, f, nil)
+		tx.ScheduleBlockUpdate(pos, f, time.Duration(20+r.IntN(20))*time.Second/20)
+		return
+	}
+	Ice{}.melt(pos, tx)
+	if !melt {
+		return
+	}
+	for _, face := range cube.Faces() {
+		side := pos.Side(face)
+		if n, ok := tx.Block(side).(FrostedIce); ok && n.neighbours(side, tx) < 2 {
+			n.age(side, tx, r, false)
+		}
+	}
+}
+
+// neighbours returns the number of frosted ice blocks directly next to pos.
+func (FrostedIce) neighbours(pos cube.Pos, tx *world.Tx) (n int) {
+	for _, face := range cube.Faces() {
+		if _, ok := tx.Block(pos.Side(face)).(FrostedIce); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// EncodeBlock ...
+func (f FrostedIce) EncodeBlock() (string, map[string]any) {
+	return "minecraft:frosted_ice", map[string]any{"age": int32(f.Age)}
+}
+
+// allFrostedIce ...
+func allFrostedIce() (ice []world.Block) {
+	for i := 0; i < 4; i++ {
+		ice = append(ice, FrostedIce{Age: i})
+	}
+	return
+}
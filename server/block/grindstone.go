@@ -1,6 +1,8 @@
 package block
 
 import (
+	"math"
+
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/block/model"
 	"github.com/df-mc/dragonfly/server/item"
@@ -33,6 +35,55 @@ func (g Grindstone) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.Use
 	return false
 }
 
+// GrindstoneResult returns the result of grinding the stacks passed in a
+// Grindstone and the range of experience gained by doing so. Either of the
+// stacks may be empty. If both are non-empty, they must be durable items of
+// the same type, which are repaired into one item with the durability of both
+// items combined and a bonus of 5% of the maximum durability. All enchantments
+// other than curses are removed from the result, and enchanted books without
+// curses are turned into normal books. False is returned if the stacks cannot
+// be ground.
+func GrindstoneResult(first, second item.Stack) (item.Stack, XPDropRange, bool) {
+	if (first.Empty() && second.Empty()) || first.Count() > 1 || second.Count() > 1 {
+		return item.Stack{}, XPDropRange{}, false
+	}
+	result := first
+	if first.Empty() {
+		result = second
+	}
+	if !first.Empty() && !second.Empty() {
+		name, meta := first.Item().EncodeItem()
+		name2, meta2 := second.Item().EncodeItem()
+		if _, ok := first.Item().(item.Durable); !ok || name != name2 || meta != meta2 {
+			return item.Stack{}, XPDropRange{}, false
+		}
+		// The enchantments of the second item are added to calculate the
+		// experience gained and to keep its curses. Other enchantments are
+		// removed below.
+		result = first.WithEnchantments(second.Enchantments()...).
+			WithDurability(first.Durability() + second.Durability() + first.MaxDurability()*5/100)
+	}
+
+	var cost int
+	for _, e := range result.Enchantments() {
+		if curse, ok := e.Type().(interface{ Curse() bool }); ok && curse.Curse() {
+			continue
+		}
+		c, _ := e.Type().Cost(e.Level())
+		cost += c
+		result = result.WithoutEnchantments(e.Type())
+	}
+	if _, ok := result.Item().(item.EnchantedBook); ok && len(result.Enchantments()) == 0 {
+		result = result.WithItem(item.Book{})
+	}
+	var xp XPDropRange
+	if cost > 0 {
+		minXP := int(math.Ceil(float64(cost) / 2))
+		xp = XPDropRange{minXP, minXP*2 - 1}
+	}
+	return result.WithAnvilCost(0), xp, true
+}
+
 // UseOnBlock ...
 func (g Grindstone) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) (used bool) {
 	pos, face, used = firstReplaceable(tx, pos, face, g)
@@ -0,0 +1,71 @@
+package block
+
+import (
+	"math"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+)
+
+func TestGrindstoneResultRemovesEnchantments(t *testing.T) {
+	sword := item.NewStack(item.Sword{Tier: item.ToolTierDiamond}, 1).WithEnchantments(
+		item.NewEnchantment(enchantment.Sharpness, 3),
+		item.NewEnchantment(enchantment.CurseOfVanishing, 1),
+	).WithAnvilCost(5)
+
+	result, xp, ok := GrindstoneResult(sword, item.Stack{})
+	if !ok {
+		t.Fatal("expected enchanted sword to be ground")
+	}
+	if _, ok := result.Enchantment(enchantment.Sharpness); ok {
+		t.Error("expected sharpness to be removed from the result")
+	}
+	if _, ok := result.Enchantment(enchantment.CurseOfVanishing); !ok {
+		t.Error("expected curse of vanishing to be kept on the result")
+	}
+	if result.AnvilCost() != 0 {
+		t.Errorf("result anvil cost = %v, want 0", result.AnvilCost())
+	}
+	cost, _ := enchantment.Sharpness.Cost(3)
+	minXP := int(math.Ceil(float64(cost) / 2))
+	if want := (XPDropRange{minXP, minXP*2 - 1}); xp != want {
+		t.Errorf("experience range = %v, want %v", xp, want)
+	}
+
+	book := item.NewStack(item.EnchantedBook{}, 1).WithEnchantments(item.NewEnchantment(enchantment.Sharpness, 1))
+	if result, _, _ := GrindstoneResult(item.Stack{}, book); !result.Comparable(item.NewStack(item.Book{}, 1)) {
+		t.Errorf("ground enchanted book = %v, want book", result)
+	}
+}
+
+func TestGrindstoneResultRepairsItems(t *testing.T) {
+	sword := item.NewStack(item.Sword{Tier: item.ToolTierDiamond}, 1)
+	maxDurability := sword.MaxDurability()
+	first := sword.Damage(1000).WithEnchantments(item.NewEnchantment(enchantment.CurseOfVanishing, 1))
+	second := sword.Damage(800).WithEnchantments(item.NewEnchantment(enchantment.Sharpness, 2))
+
+	result, _, ok := GrindstoneResult(first, second)
+	if !ok {
+		t.Fatal("expected two swords to be repaired")
+	}
+	if want := first.Durability() + second.Durability() + maxDurability*5/100; result.Durability() != want {
+		t.Errorf("repaired durability = %v, want %v", result.Durability(), want)
+	}
+	if _, ok := result.Enchantment(enchantment.CurseOfVanishing); !ok {
+		t.Error("expected curse of the first item to be kept")
+	}
+	if len(result.Enchantments()) != 1 {
+		t.Errorf("repaired item enchantments = %v, want only curse of vanishing", result.Enchantments())
+	}
+
+	if result, _, _ := GrindstoneResult(sword.Damage(10), sword.Damage(10)); result.Durability() != maxDurability {
+		t.Errorf("repaired durability = %v, want capped at %v", result.Durability(), maxDurability)
+	}
+	if _, _, ok := GrindstoneResult(sword, item.NewStack(item.Pickaxe{Tier: item.ToolTierDiamond}, 1)); ok {
+		t.Error("expected items of different types not to be ground together")
+	}
+	if _, _, ok := GrindstoneResult(item.NewStack(item.Apple{}, 2), item.Stack{}); ok {
+		t.Error("expected stacks of more than one item not to be ground")
+	}
+}
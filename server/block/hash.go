@@ -36,6 +36,8 @@ const (
 	hashCandle
 	hashCarpet
 	hashCarrot
+	hashCartographyTable
+	hashCauldron
 	hashChest
 	hashChiseledQuartz
 	hashClay
@@ -44,9 +46,11 @@ const (
 	hashCobblestone
 	hashCobweb
 	hashCocoaBean
+	hashCommandBlock
 	hashComposter
 	hashConcrete
 	hashConcretePowder
+	hashConduit
 	hashCopper
 	hashCopperBars
 	hashCopperChain
@@ -90,6 +94,7 @@ const (
 	hashFletchingTable
 	hashFlower
 	hashFroglight
+	hashFrostedIce
 	hashFurnace
 	hashGlass
 	hashGlassPane
@@ -102,8 +107,10 @@ const (
 	hashGravel
 	hashGrindstone
 	hashHayBale
+	hashHoneyBlock
 	hashHoneycomb
 	hashHopper
+	hashIce
 	hashInfestedCobblestone
 	hashInfestedDeepslate
 	hashInfestedStone
@@ -112,7 +119,9 @@ const (
 	hashIron
 	hashIronBars
 	hashIronChain
+	hashIronDoor
 	hashIronOre
+	hashIronTrapdoor
 	hashItemFrame
 	hashJukebox
 	hashKelp
@@ -132,7 +141,9 @@ const (
 	hashMagma
 	hashMelon
 	hashMelonSeeds
+	hashMonsterSpawner
 	hashMossCarpet
+	hashMoving
 	hashMud
 	hashMudBricks
 	hashMuddyMangroveRoots
@@ -146,16 +157,20 @@ const (
 	hashNetherite
 	hashNetherrack
 	hashNote
+	hashObserver
 	hashObsidian
 	hashPackedIce
 	hashPackedMud
 	hashPinkPetals
+	hashPiston
+	hashPistonArmCollision
 	hashPlanks
 	hashPodzol
 	hashPolishedBlackstoneBrick
 	hashPolishedTuff
 	hashPortal
 	hashPotato
+	hashPowderSnow
 	hashPrismarine
 	hashPumpkin
 	hashPumpkinSeeds
@@ -174,8 +189,11 @@ const (
 	hashReinforcedDeepslate
 	hashResin
 	hashResinBricks
+	hashRespawnAnchor
 	hashSand
 	hashSandstone
+	hashSapling
+	hashSculkSensor
 	hashSeaLantern
 	hashSeaPickle
 	hashShortGrass
@@ -189,6 +207,7 @@ const (
 	hashSmoker
 	hashSmoothBasalt
 	hashSnow
+	hashSnowLayer
 	hashSoulSand
 	hashSoulSoil
 	hashSponge
@@ -353,6 +372,14 @@ func (c Carrot) Hash() (uint64, uint64) {
 	return hashCarrot, uint64(c.Growth)
 }
 
+func (CartographyTable) Hash() (uint64, uint64) {
+	return hashCartographyTable, 0
+}
+
+func (c Cauldron) Hash() (uint64, uint64) {
+	return hashCauldron, uint64(c.Level)
+}
+
 func (c Chest) Hash() (uint64, uint64) {
 	return hashChest, uint64(c.Facing)
 }
@@ -385,6 +412,10 @@ func (c CocoaBean) Hash() (uint64, uint64) {
 	return hashCocoaBean, uint64(c.Facing) | uint64(c.Age)<<2
 }
 
+func (c CommandBlock) Hash() (uint64, uint64) {
+	return hashCommandBlock, uint64(c.Mode.Uint8()) | uint64(c.Facing)<<2 | uint64(boolByte(c.Conditional))<<5
+}
+
 func (c Composter) Hash() (uint64, uint64) {
 	return hashComposter, uint64(c.Level)
 }
@@ -397,6 +428,10 @@ func (c ConcretePowder) Hash() (uint64, uint64) {
 	return hashConcretePowder, uint64(c.Colour.Uint8())
 }
 
+func (Conduit) Hash() (uint64, uint64) {
+	return hashConduit, 0
+}
+
 func (c Copper) Hash() (uint64, uint64) {
 	return hashCopper, uint64(c.Type.Uint8()) | uint64(c.Oxidation.Uint8())<<2 | uint64(boolByte(c.Waxed))<<4
 }
@@ -569,6 +604,10 @@ func (f Froglight) Hash() (uint64, uint64) {
 	return hashFroglight, uint64(f.Type.Uint8()) | uint64(f.Axis)<<2
 }
 
+func (f FrostedIce) Hash() (uint64, uint64) {
+	return hashFrostedIce, uint64(f.Age)
+}
+
 func (f Furnace) Hash() (uint64, uint64) {
 	return hashFurnace, uint64(f.Facing) | uint64(boolByte(f.Lit))<<2
 }
@@ -617,6 +656,10 @@ func (h HayBale) Hash() (uint64, uint64) {
 	return hashHayBale, uint64(h.Axis)
 }
 
+func (HoneyBlock) Hash() (uint64, uint64) {
+	return hashHoneyBlock, 0
+}
+
 func (Honeycomb) Hash() (uint64, uint64) {
 	return hashHoneycomb, 0
 }
@@ -625,6 +668,10 @@ func (h Hopper) Hash() (uint64, uint64) {
 	return hashHopper, uint64(h.Facing) | uint64(boolByte(h.Powered))<<3
 }
 
+func (Ice) Hash() (uint64, uint64) {
+	return hashIce, 0
+}
+
 func (InfestedCobblestone) Hash() (uint64, uint64) {
 	return hashInfestedCobblestone, 0
 }
@@ -657,10 +704,18 @@ func (c IronChain) Hash() (uint64, uint64) {
 	return hashIronChain, uint64(c.Axis)
 }
 
+func (d IronDoor) Hash() (uint64, uint64) {
+	return hashIronDoor, uint64(d.Facing) | uint64(boolByte(d.Open))<<2 | uint64(boolByte(d.Top))<<3 | uint64(boolByte(d.Right))<<4
+}
+
 func (i IronOre) Hash() (uint64, uint64) {
 	return hashIronOre, uint64(i.Type.Uint8())
 }
 
+func (t IronTrapdoor) Hash() (uint64, uint64) {
+	return hashIronTrapdoor, uint64(t.Facing) | uint64(boolByte(t.Open))<<2 | uint64(boolByte(t.Top))<<3
+}
+
 func (i ItemFrame) Hash() (uint64, uint64) {
 	return hashItemFrame, uint64(i.Facing) | uint64(boolByte(i.Glowing))<<3
 }
@@ -737,10 +792,18 @@ func (m MelonSeeds) Hash() (uint64, uint64) {
 	return hashMelonSeeds, uint64(m.Growth) | uint64(m.Direction)<<8
 }
 
+func (MonsterSpawner) Hash() (uint64, uint64) {
+	return hashMonsterSpawner, 0
+}
+
 func (MossCarpet) Hash() (uint64, uint64) {
 	return hashMossCarpet, 0
 }
 
+func (Moving) Hash() (uint64, uint64) {
+	return hashMoving, 0
+}
+
 func (Mud) Hash() (uint64, uint64) {
 	return hashMud, 0
 }
@@ -793,6 +856,10 @@ func (Note) Hash() (uint64, uint64) {
 	return hashNote, 0
 }
 
+func (o Observer) Hash() (uint64, uint64) {
+	return hashObserver, uint64(o.Facing) | uint64(boolByte(o.Powered))<<3
+}
+
 func (o Obsidian) Hash() (uint64, uint64) {
 	return hashObsidian, uint64(boolByte(o.Crying))
 }
@@ -809,6 +876,14 @@ func (p PinkPetals) Hash() (uint64, uint64) {
 	return hashPinkPetals, uint64(p.AdditionalCount) | uint64(p.Facing)<<8
 }
 
+func (p Piston) Hash() (uint64, uint64) {
+	return hashPiston, uint64(p.Facing) | uint64(boolByte(p.Sticky))<<3
+}
+
+func (c PistonArmCollision) Hash() (uint64, uint64) {
+	return hashPistonArmCollision, uint64(c.Facing) | uint64(boolByte(c.Sticky))<<3
+}
+
 func (p Planks) Hash() (uint64, uint64) {
 	return hashPlanks, uint64(p.Wood.Uint8())
 }
@@ -833,6 +908,10 @@ func (p Potato) Hash() (uint64, uint64) {
 	return hashPotato, uint64(p.Growth)
 }
 
+func (PowderSnow) Hash() (uint64, uint64) {
+	return hashPowderSnow, 0
+}
+
 func (p Prismarine) Hash() (uint64, uint64) {
 	return hashPrismarine, uint64(p.Type.Uint8())
 }
@@ -905,6 +984,10 @@ func (r ResinBricks) Hash() (uint64, uint64) {
 	return hashResinBricks, uint64(boolByte(r.Chiseled))
 }
 
+func (r RespawnAnchor) Hash() (uint64, uint64) {
+	return hashRespawnAnchor, uint64(r.Charge)
+}
+
 func (s Sand) Hash() (uint64, uint64) {
 	return hashSand, uint64(boolByte(s.Red))
 }
@@ -913,6 +996,14 @@ func (s Sandstone) Hash() (uint64, uint64) {
 	return hashSandstone, uint64(s.Type.Uint8()) | uint64(boolByte(s.Red))<<2
 }
 
+func (s Sapling) Hash() (uint64, uint64) {
+	return hashSapling, uint64(s.Wood.Uint8()) | uint64(boolByte(s.Ready))<<4
+}
+
+func (s SculkSensor) Hash() (uint64, uint64) {
+	return hashSculkSensor, uint64(s.Phase)
+}
+
 func (SeaLantern) Hash() (uint64, uint64) {
 	return hashSeaLantern, 0
 }
@@ -965,6 +1056,10 @@ func (Snow) Hash() (uint64, uint64) {
 	return hashSnow, 0
 }
 
+func (s SnowLayer) Hash() (uint64, uint64) {
+	return hashSnowLayer, uint64(s.Height) | uint64(boolByte(s.Covered))<<8
+}
+
 func (SoulSand) Hash() (uint64, uint64) {
 	return hashSoulSand, 0
 }
@@ -0,0 +1,64 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// HoneyBlock is a sticky block crafted from honey bottles. Entities on top of a honey block move slowly and
+// can barely jump, entities falling onto it take less fall damage and entities touching its sides slide down
+// it slowly. Like slime, honey blocks drag the blocks next to them along when moved by a piston.
+type HoneyBlock struct {
+	transparent
+}
+
+// Model ...
+func (HoneyBlock) Model() world.BlockModel {
+	return model.Honey{}
+}
+
+// EntityLand reduces the fall damage taken by entities landing on the honey block to a fifth.
+func (HoneyBlock) EntityLand(_ cube.Pos, _ *world.Tx, e world.Entity, distance *float64) {
+	if _, ok := e.(fallDistanceEntity); ok && *distance > 3 {
+		*distance = 3 + (*distance-3)*0.2
+	}
+}
+
+// SpeedFactor returns the factor that the horizontal velocity of entities on the honey block is multiplied
+// with.
+func (HoneyBlock) SpeedFactor() float64 {
+	return 0.4
+}
+
+// JumpFactor returns the factor that the jump velocity of entities jumping off the honey block is multiplied
+// with.
+func (HoneyBlock) JumpFactor() float64 {
+	return 0.5
+}
+
+// SlideVelocity returns the maximum downward velocity of entities sliding down the sides of the honey block.
+func (HoneyBlock) SlideVelocity() float64 {
+	return 0.05
+}
+
+// PistonSticksTo ...
+func (HoneyBlock) PistonSticksTo(b world.Block) bool {
+	_, slime := b.(Slime)
+	return !slime && pistonSticky(b)
+}
+
+// BreakInfo ...
+func (h HoneyBlock) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(h))
+}
+
+// EncodeItem ...
+func (HoneyBlock) EncodeItem() (name string, meta int16) {
+	return "minecraft:honey_block", 0
+}
+
+// EncodeBlock ...
+func (HoneyBlock) EncodeBlock() (string, map[string]any) {
+	return "minecraft:honey_block", nil
+}
@@ -0,0 +1,88 @@
+package block
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// Ice is a translucent solid block found in cold biomes. Ice melts into water when a bright enough light
+// source is placed near it, or when it is exposed to the sky in a warm biome.
+type Ice struct {
+	solid
+	transparent
+}
+
+// Instrument ...
+func (Ice) Instrument() sound.Instrument {
+	return sound.Chimes()
+}
+
+// BreakInfo ...
+func (i Ice) BreakInfo() BreakInfo {
+	return newBreakInfo(0.5, alwaysHarvestable, pickaxeEffective, silkTouchOnlyDrop(i)).withBreakHandler(func(pos cube.Pos, tx *world.Tx, u item.User) {
+		// Ice broken without silk touch leaves water behind if it was resting on a block that could hold
+		// it.
+		if gm, ok := u.(interface{ GameMode() world.GameMode }); ok && gm.GameMode().CreativeInventory() {
+			return
+		}
+		if held, _ := u.HeldItems(); hasSilkTouch(held.Enchantments()) {
+			return
+		}
+		below := pos.Side(cube.FaceDown)
+		if _, air := tx.Block(below).(Air); !air {
+			i.melt(pos, tx)
+		}
+	})
+}
+
+// Friction ...
+func (Ice) Friction() float64 {
+	return 0.98
+}
+
+// LightDiffusionLevel ...
+func (Ice) LightDiffusionLevel() uint8 {
+	return 2
+}
+
+// RandomTick melts the ice if a neighbouring block has a block light level higher than 11 or if the ice is
+// directly exposed to the sky in a warm biome.
+func (i Ice) RandomTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if iceMelts(pos, tx) {
+		i.melt(pos, tx)
+	}
+}
+
+// melt turns the ice at pos into water, or into air if water evaporates in the dimension of the world.
+func (Ice) melt(pos cube.Pos, tx *world.Tx) {
+	if tx.World().Dimension().WaterEvaporates() {
+		tx.SetBlock(pos, nil, nil)
+		return
+	}
+	tx.SetBlock(pos, Water{Depth: 8, Still: true}, nil)
+}
+
+// iceMelts checks if ice-like blocks at pos melt, either because a neighbouring block has a block light level
+// higher than 11 or because pos is directly exposed to the sky in a biome with a temperature higher than 1.
+func iceMelts(pos cube.Pos, tx *world.Tx) bool {
+	for _, face := range cube.Faces() {
+		if tx.BlockLight(pos.Side(face)) > 11 {
+			return true
+		}
+	}
+	return tx.Temperature(pos) > 1 && tx.HighestBlock(pos[0], pos[2]) <= pos[1]
+}
+
+// EncodeItem ...
+func (Ice) EncodeItem() (name string, meta int16) {
+	return "minecraft:ice", 0
+}
+
+// EncodeBlock ...
+func (Ice) EncodeBlock() (string, map[string]any) {
+	return "minecraft:ice", nil
+}
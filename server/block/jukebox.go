@@ -30,11 +30,9 @@ func (j Jukebox) InsertItem(h Hopper, pos cube.Pos, tx *world.Tx) bool {
 			continue
 		}
 
-		if m, ok := sourceStack.Item().(item.MusicDisc); ok {
-			j.Item = sourceStack
-			tx.SetBlock(pos, j, nil)
+		if _, ok := sourceStack.Item().(item.MusicDisc); ok {
 			_ = h.inventory.SetItem(sourceSlot, sourceStack.Grow(-1))
-			tx.PlaySound(pos.Vec3Centre(), sound.MusicDiscPlay{DiscType: m.DiscType})
+			j.play(pos, tx, sourceStack.Grow(1-sourceStack.Count()))
 			return true
 		}
 	}
@@ -43,9 +41,16 @@ func (j Jukebox) InsertItem(h Hopper, pos cube.Pos, tx *world.Tx) bool {
 }
 
 // ExtractItem ...
-func (j Jukebox) ExtractItem(_ Hopper, _ cube.Pos, _ *world.Tx) bool {
-	// TODO: This functionality requires redstone to be implemented.
-	return false
+func (j Jukebox) ExtractItem(h Hopper, pos cube.Pos, tx *world.Tx) bool {
+	if _, hasDisc := j.Disc(); !hasDisc {
+		return false
+	}
+	if _, err := h.inventory.AddItem(j.Item); err != nil {
+		// The hopper is full.
+		return false
+	}
+	j.stop(pos, tx)
+	return true
 }
 
 // FuelInfo ...
@@ -74,19 +79,12 @@ type jukeboxUser interface {
 func (j Jukebox) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, ctx *item.UseContext) bool {
 	if _, hasDisc := j.Disc(); hasDisc {
 		dropItem(tx, j.Item, pos.Side(cube.FaceUp).Vec3Middle())
-
-		j.Item = item.Stack{}
-		tx.SetBlock(pos, j, nil)
-		tx.PlaySound(pos.Vec3Centre(), sound.MusicDiscEnd{})
+		j.stop(pos, tx)
 	} else if held, _ := u.HeldItems(); !held.Empty() {
 		if m, ok := held.Item().(item.MusicDisc); ok {
-			j.Item = held
-
-			tx.SetBlock(pos, j, nil)
-			tx.PlaySound(pos.Vec3Centre(), sound.MusicDiscEnd{})
+			j.play(pos, tx, held.Grow(1-held.Count()))
 			ctx.SubtractFromCount(1)
 
-			tx.PlaySound(pos.Vec3Centre(), sound.MusicDiscPlay{DiscType: m.DiscType})
 			if u, ok := u.(jukeboxUser); ok {
 				u.SendJukeboxPopup(fmt.Sprintf("Now playing: %v - %v", m.DiscType.Author(), m.DiscType.DisplayName()))
 			}
@@ -95,6 +93,25 @@ func (j Jukebox) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User,
 	return true
 }
 
+// play inserts the music disc passed into the jukebox at pos and starts
+// playing it to viewers nearby.
+func (j Jukebox) play(pos cube.Pos, tx *world.Tx, disc item.Stack) {
+	j.Item = disc
+	tx.SetBlock(pos, j, nil)
+	if d, ok := j.Disc(); ok {
+		tx.PlaySound(pos.Vec3Centre(), sound.MusicDiscEnd{})
+		tx.PlaySound(pos.Vec3Centre(), sound.MusicDiscPlay{DiscType: d})
+	}
+}
+
+// stop removes the music disc from the jukebox at pos and stops playing it
+// to viewers nearby.
+func (j Jukebox) stop(pos cube.Pos, tx *world.Tx) {
+	j.Item = item.Stack{}
+	tx.SetBlock(pos, j, nil)
+	tx.PlaySound(pos.Vec3Centre(), sound.MusicDiscEnd{})
+}
+
 // Disc returns the currently playing music disc
 func (j Jukebox) Disc() (sound.DiscType, bool) {
 	if !j.Item.Empty() {
@@ -0,0 +1,82 @@
+package block
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+func TestJukeboxPlayEject(t *testing.T) {
+	w := world.Config{Entities: redstoneBreakDropTestEntityRegistry(), Synchronous: true}.New()
+	defer w.Close()
+
+	handler := &soundTestHandler{}
+	w.Handle(handler)
+
+	pos := cube.Pos{0, 64, 0}
+	disc := item.NewStack(item.MusicDisc{DiscType: sound.DiscCat()}, 1)
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pos, Jukebox{}, nil)
+		ctx := &item.UseContext{}
+		tx.Block(pos).(Jukebox).Activate(pos, cube.FaceUp, tx, jukeboxTestUser{held: disc}, ctx)
+
+		j := tx.Block(pos).(Jukebox)
+		if d, ok := j.Disc(); !ok || d != sound.DiscCat() {
+			t.Fatalf("jukebox disc after inserting cat = %v, %t", d, ok)
+		}
+		if ctx.CountSub != 1 {
+			t.Fatalf("inserting a disc subtracted %d items, want 1", ctx.CountSub)
+		}
+		if s, ok := handler.last().(sound.MusicDiscPlay); !ok || s.DiscType != sound.DiscCat() {
+			t.Fatalf("jukebox played %#v, want cat", handler.last())
+		}
+
+		j.Activate(pos, cube.FaceUp, tx, jukeboxTestUser{held: item.NewStack(item.MusicDisc{DiscType: sound.DiscWard()}, 1)}, &item.UseContext{})
+		if _, ok := tx.Block(pos).(Jukebox).Disc(); ok {
+			t.Fatal("jukebox still held a disc after being activated")
+		}
+		if _, ok := handler.last().(sound.MusicDiscEnd); !ok {
+			t.Fatalf("jukebox played %#v after ejecting its disc, want the music to stop", handler.last())
+		}
+		if n := len(slices.Collect(tx.Entities())); n != 1 {
+			t.Fatalf("jukebox dropped %d entities when ejecting its disc, want 1", n)
+		}
+	})
+}
+
+func TestJukeboxHopperExtract(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 65, 0}
+	disc := item.NewStack(item.MusicDisc{DiscType: sound.DiscCat()}, 1)
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pos, Jukebox{Item: disc}, nil)
+		h := NewHopper()
+		tx.SetBlock(pos.Side(cube.FaceDown), h, nil)
+
+		if !tx.Block(pos).(Jukebox).ExtractItem(h, pos, tx) {
+			t.Fatal("hopper could not extract disc from jukebox")
+		}
+		if _, ok := tx.Block(pos).(Jukebox).Disc(); ok {
+			t.Fatal("jukebox still held a disc after hopper extracted it")
+		}
+		if got, _ := h.inventory.Item(0); !got.Equal(disc) {
+			t.Fatalf("hopper item after extracting from jukebox = %v, want %v", got, disc)
+		}
+	})
+}
+
+// jukeboxTestUser is an item.User holding a single item stack in its main hand.
+type jukeboxTestUser struct {
+	item.User
+	held item.Stack
+}
+
+func (u jukeboxTestUser) HeldItems() (item.Stack, item.Stack) {
+	return u.held, item.Stack{}
+}
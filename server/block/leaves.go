@@ -81,6 +81,11 @@ func (l Leaves) RandomTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
 	}
 }
 
+// WantsRandomTick ...
+func (l Leaves) WantsRandomTick() bool {
+	return !l.Persistent && l.ShouldUpdate
+}
+
 // NeighbourUpdateTick ...
 func (l Leaves) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
 	if !l.Persistent && !l.ShouldUpdate {
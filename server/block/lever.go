@@ -42,22 +42,21 @@ func (l Lever) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
 }
 
 func (l Lever) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
-	supportPos := pos.Side(l.Facing.Opposite())
-	if !tx.Block(supportPos).Model().FaceSolid(supportPos, l.Facing, tx) {
+	if !l.SupportedAt(pos, tx) {
 		breakBlock(l, pos, tx)
 	}
 }
 
+// SupportedAt ...
+func (l Lever) SupportedAt(pos cube.Pos, tx *world.Tx) bool {
+	return faceSupports(pos, l.Facing.Opposite(), tx)
+}
+
 func (l Lever) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
 	pos, face, used := firstReplaceable(tx, pos, face, l)
 	if !used {
 		return false
 	}
-	supportPos := pos.Side(face.Opposite())
-	if !tx.Block(supportPos).Model().FaceSolid(supportPos, face, tx) {
-		return false
-	}
-
 	l.Powered = false
 	l.Facing = face
 	l.Direction = cube.North
@@ -32,6 +32,22 @@ func (s flowingWaterDisplacer) CanDisplace(b world.Liquid) bool {
 	return ok
 }
 
+// Waterlogged checks if the block at the position passed is waterlogged. A block is waterlogged if it is a
+// world.LiquidDisplacer, such as stairs or a fence, with water in the same position. Water is added to a
+// block in this way when a water bucket is used on it or when water flows into it, if the block is able to
+// displace that water, and is released once the block is broken.
+func Waterlogged(pos cube.Pos, tx *world.Tx) bool {
+	if _, ok := tx.Block(pos).(world.LiquidDisplacer); !ok {
+		return false
+	}
+	l, ok := tx.Liquid(pos)
+	if !ok {
+		return false
+	}
+	_, water := l.(Water)
+	return water
+}
+
 // tickLiquid ticks the liquid block passed at a specific position in the world. Depending on the surroundings
 // and the liquid block, the liquid will either spread or decrease in depth. Additionally, the liquid might
 // be turned into a solid block if a different liquid is next to it.
@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
+	"slices"
 	"time"
 )
 
@@ -58,6 +59,36 @@ func (l Loom) EncodeBlock() (name string, properties map[string]interface{}) {
 	return "minecraft:loom", map[string]interface{}{"direction": int32(horizontalDirection(l.Facing))}
 }
 
+// loomMaxPatterns is the maximum amount of pattern layers that a banner may
+// have for a Loom to add another pattern to it.
+const loomMaxPatterns = 6
+
+// LoomResult returns the result of applying the BannerPatternType passed to the
+// banner stack in a Loom, using the dye and pattern item stacks passed. The
+// pattern is added as a new layer on top of the existing patterns of the
+// banner, coloured in the colour of the dye. Patterns with an equivalent
+// item.BannerPattern, such as the creeper pattern, require that item to be
+// passed as pattern item. False is returned if the banner is an illager banner
+// or already has the maximum amount of patterns, if the dye is not an
+// item.Dye or if the pattern item required is missing.
+func LoomResult(banner, dye, patternItem item.Stack, pattern BannerPatternType) (item.Stack, bool) {
+	b, ok := banner.Item().(Banner)
+	if !ok || banner.Empty() || b.Illager || len(b.Patterns) >= loomMaxPatterns {
+		return item.Stack{}, false
+	}
+	d, ok := dye.Item().(item.Dye)
+	if !ok || dye.Empty() {
+		return item.Stack{}, false
+	}
+	if expected, ok := pattern.Item(); ok {
+		if p, ok := patternItem.Item().(item.BannerPattern); !ok || patternItem.Empty() || p.Type != expected {
+			return item.Stack{}, false
+		}
+	}
+	b.Patterns = append(slices.Clone(b.Patterns), BannerPatternLayer{Type: pattern, Colour: d.Colour})
+	return banner.WithItem(b).Grow(1 - banner.Count()), true
+}
+
 // allLooms ...
 func allLooms() (looms []world.Block) {
 	for _, d := range cube.Directions() {
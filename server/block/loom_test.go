@@ -0,0 +1,81 @@
+package block
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/item"
+)
+
+func TestLoomResultAppliesPattern(t *testing.T) {
+	banner := item.NewStack(Banner{Colour: item.ColourWhite(), Patterns: []BannerPatternLayer{
+		{Type: BorderBannerPattern(), Colour: item.ColourBlack()},
+	}}, 3)
+	dye := item.NewStack(item.Dye{Colour: item.ColourRed()}, 1)
+
+	result, ok := LoomResult(banner, dye, item.Stack{}, CrossBannerPattern())
+	if !ok {
+		t.Fatal("expected cross pattern to be applied to banner")
+	}
+	b, ok := result.Item().(Banner)
+	if !ok {
+		t.Fatalf("result = %v, want banner", result.Item())
+	}
+	want := []BannerPatternLayer{
+		{Type: BorderBannerPattern(), Colour: item.ColourBlack()},
+		{Type: CrossBannerPattern(), Colour: item.ColourRed()},
+	}
+	if !slices.Equal(b.Patterns, want) || b.Colour != item.ColourWhite() {
+		t.Errorf("banner patterns = %v, want %v", b.Patterns, want)
+	}
+	if result.Count() != 1 {
+		t.Errorf("result count = %v, want 1", result.Count())
+	}
+	if original := banner.Item().(Banner); len(original.Patterns) != 1 {
+		t.Errorf("input banner patterns changed to %v", original.Patterns)
+	}
+}
+
+func TestLoomResultRequiresPatternItem(t *testing.T) {
+	banner := item.NewStack(Banner{Colour: item.ColourWhite()}, 1)
+	dye := item.NewStack(item.Dye{Colour: item.ColourGreen()}, 1)
+
+	if _, ok := LoomResult(banner, dye, item.Stack{}, CreeperBannerPattern()); ok {
+		t.Error("expected creeper pattern without pattern item to fail")
+	}
+	if _, ok := LoomResult(banner, dye, item.NewStack(item.BannerPattern{Type: item.FlowerBannerPattern()}, 1), CreeperBannerPattern()); ok {
+		t.Error("expected creeper pattern with flower pattern item to fail")
+	}
+	result, ok := LoomResult(banner, dye, item.NewStack(item.BannerPattern{Type: item.CreeperBannerPattern()}, 1), CreeperBannerPattern())
+	if !ok {
+		t.Fatal("expected creeper pattern with creeper pattern item to be applied")
+	}
+	if b := result.Item().(Banner); len(b.Patterns) != 1 || b.Patterns[0].Type != CreeperBannerPattern() {
+		t.Errorf("banner patterns = %v, want a single creeper pattern", b.Patterns)
+	}
+}
+
+func TestLoomResultRejectsInvalidInputs(t *testing.T) {
+	dye := item.NewStack(item.Dye{Colour: item.ColourBlue()}, 1)
+	full := Banner{Colour: item.ColourWhite()}
+	for range loomMaxPatterns {
+		full.Patterns = append(full.Patterns, BannerPatternLayer{Type: StripeTopBannerPattern(), Colour: item.ColourBlack()})
+	}
+	tests := []struct {
+		name        string
+		banner, dye item.Stack
+	}{
+		{name: "illager banner", banner: item.NewStack(Banner{Illager: true}, 1), dye: dye},
+		{name: "maximum patterns", banner: item.NewStack(full, 1), dye: dye},
+		{name: "not a banner", banner: item.NewStack(Wool{}, 1), dye: dye},
+		{name: "not a dye", banner: item.NewStack(Banner{}, 1), dye: item.NewStack(item.Stick{}, 1)},
+		{name: "no dye", banner: item.NewStack(Banner{}, 1)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, ok := LoomResult(test.banner, test.dye, item.Stack{}, CrossBannerPattern()); ok {
+				t.Error("expected pattern application to fail")
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package block
+
+import (
+	"image/color"
+
+	"github.com/df-mc/dragonfly/server/item"
+)
+
+// Base colours of blocks shown on a world.Map, matching the colours used by
+// vanilla.
+var (
+	mapColourGrass     = mapRGB(0x7fb238)
+	mapColourSand      = mapRGB(0xf7e9a3)
+	mapColourFire      = mapRGB(0xff0000)
+	mapColourIce       = mapRGB(0xa0a0ff)
+	mapColourMetal     = mapRGB(0xa7a7a7)
+	mapColourPlant     = mapRGB(0x007c00)
+	mapColourSnow      = mapRGB(0xffffff)
+	mapColourClay      = mapRGB(0xa4a8b8)
+	mapColourDirt      = mapRGB(0x976d4d)
+	mapColourStone     = mapRGB(0x707070)
+	mapColourWater     = mapRGB(0x4040ff)
+	mapColourWood      = mapRGB(0x8f7748)
+	mapColourQuartz    = mapRGB(0xfffcf5)
+	mapColourGold      = mapRGB(0xfaee4d)
+	mapColourDiamond   = mapRGB(0x5cdbd5)
+	mapColourLapis     = mapRGB(0x4a80ff)
+	mapColourEmerald   = mapRGB(0x00d93a)
+	mapColourPodzol    = mapRGB(0x815631)
+	mapColourNether    = mapRGB(0x700200)
+	mapColourDeepslate = mapRGB(0x646464)
+)
+
+// dyeMapColours holds the map colours of blocks dyed in every item.Colour,
+// such as wool and concrete, indexed by item.Colour.Uint8.
+var dyeMapColours = [...]color.RGBA{
+	mapColourSnow, mapRGB(0xd87f33), mapRGB(0xb24cd8), mapRGB(0x6699d8),
+	mapRGB(0xe5e533), mapRGB(0x7fcc19), mapRGB(0xf27fa5), mapRGB(0x4c4c4c),
+	mapRGB(0x999999), mapRGB(0x4c7f99), mapRGB(0x7f3fb2), mapRGB(0x334cb2),
+	mapRGB(0x664c33), mapRGB(0x667f33), mapRGB(0x993333), mapRGB(0x191919),
+}
+
+// terracottaMapColours holds the map colours of stained terracotta in every
+// item.Colour, indexed by item.Colour.Uint8.
+var terracottaMapColours = [...]color.RGBA{
+	mapRGB(0xd1b1a1), mapRGB(0x9f5224), mapRGB(0x95576c), mapRGB(0x706c8a),
+	mapRGB(0xba8524), mapRGB(0x677535), mapRGB(0xa04d4e), mapRGB(0x392923),
+	mapRGB(0x876b62), mapRGB(0x575c5c), mapRGB(0x7a4958), mapRGB(0x4c3e5c),
+	mapRGB(0x4c3223), mapRGB(0x4c522a), mapRGB(0x8e3c2e), mapRGB(0x251610),
+}
+
+// mapRGB returns an opaque color.RGBA from the hexadecimal RGB value passed.
+func mapRGB(rgb uint32) color.RGBA {
+	return color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 0xff}
+}
+
+// dyeMapColour returns the map colour of a block dyed in the item.Colour
+// passed.
+func dyeMapColour(c item.Colour) color.RGBA {
+	return dyeMapColours[c.Uint8()]
+}
+
+// woodMapColour returns the map colour of planks and logs of the WoodType
+// passed.
+func woodMapColour(w WoodType) color.RGBA {
+	switch w {
+	case SpruceWood():
+		return mapColourPodzol
+	case BirchWood():
+		return mapColourSand
+	case JungleWood():
+		return mapColourDirt
+	case AcaciaWood():
+		return dyeMapColour(item.ColourOrange())
+	case DarkOakWood():
+		return dyeMapColour(item.ColourBrown())
+	case CrimsonWood():
+		return mapRGB(0x943f61)
+	case WarpedWood():
+		return mapRGB(0x3a8e8c)
+	case MangroveWood():
+		return dyeMapColour(item.ColourRed())
+	case CherryWood():
+		return terracottaMapColours[item.ColourWhite().Uint8()]
+	case PaleOakWood():
+		return mapColourQuartz
+	case BambooWood():
+		return dyeMapColour(item.ColourYellow())
+	}
+	return mapColourWood
+}
+
+// MapColour ...
+func (Grass) MapColour() color.RGBA { return mapColourGrass }
+
+// MapColour ...
+func (Dirt) MapColour() color.RGBA { return mapColourDirt }
+
+// MapColour ...
+func (Farmland) MapColour() color.RGBA { return mapColourDirt }
+
+// MapColour ...
+func (Podzol) MapColour() color.RGBA { return mapColourPodzol }
+
+// MapColour ...
+func (Mud) MapColour() color.RGBA { return dyeMapColour(item.ColourCyan()) }
+
+// MapColour ...
+func (s Sand) MapColour() color.RGBA {
+	if s.Red {
+		return dyeMapColour(item.ColourOrange())
+	}
+	return mapColourSand
+}
+
+// MapColour ...
+func (s Sandstone) MapColour() color.RGBA {
+	if s.Red {
+		return dyeMapColour(item.ColourOrange())
+	}
+	return mapColourSand
+}
+
+// MapColour ...
+func (Gravel) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (Clay) MapColour() color.RGBA { return mapColourClay }
+
+// MapColour ...
+func (Stone) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (Granite) MapColour() color.RGBA { return mapColourDirt }
+
+// MapColour ...
+func (Diorite) MapColour() color.RGBA { return mapColourQuartz }
+
+// MapColour ...
+func (Andesite) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (Cobblestone) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (StoneBricks) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (Bedrock) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (CoalOre) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (IronOre) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (GoldOre) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (DiamondOre) MapColour() color.RGBA { return mapColourStone }
+
+// MapColour ...
+func (Deepslate) MapColour() color.RGBA { return mapColourDeepslate }
+
+// MapColour ...
+func (Tuff) MapColour() color.RGBA { return terracottaMapColours[item.ColourGrey().Uint8()] }
+
+// MapColour ...
+func (Calcite) MapColour() color.RGBA { return terracottaMapColours[item.ColourWhite().Uint8()] }
+
+// MapColour ...
+func (Water) MapColour() color.RGBA { return mapColourWater }
+
+// MapColour ...
+func (Lava) MapColour() color.RGBA { return mapColourFire }
+
+// MapColour ...
+func (Leaves) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (ShortGrass) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (Flower) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (Cactus) MapColour() color.RGBA { return mapColourPlant }
+
+// MapColour ...
+func (Pumpkin) MapColour() color.RGBA { return dyeMapColour(item.ColourOrange()) }
+
+// MapColour ...
+func (Melon) MapColour() color.RGBA { return dyeMapColour(item.ColourLime()) }
+
+// MapColour ...
+func (l Log) MapColour() color.RGBA { return woodMapColour(l.Wood) }
+
+// MapColour ...
+func (w Wood) MapColour() color.RGBA { return woodMapColour(w.Wood) }
+
+// MapColour ...
+func (p Planks) MapColour() color.RGBA { return woodMapColour(p.Wood) }
+
+// MapColour ...
+func (Snow) MapColour() color.RGBA { return mapColourSnow }
+
+// MapColour ...
+func (PackedIce) MapColour() color.RGBA { return mapColourIce }
+
+// MapColour ...
+func (BlueIce) MapColour() color.RGBA { return mapColourIce }
+
+// MapColour ...
+func (w Wool) MapColour() color.RGBA { return dyeMapColour(w.Colour) }
+
+// MapColour ...
+func (c Concrete) MapColour() color.RGBA { return dyeMapColour(c.Colour) }
+
+// MapColour ...
+func (Terracotta) MapColour() color.RGBA { return dyeMapColour(item.ColourOrange()) }
+
+// MapColour ...
+func (t StainedTerracotta) MapColour() color.RGBA { return terracottaMapColours[t.Colour.Uint8()] }
+
+// MapColour ...
+func (Bricks) MapColour() color.RGBA { return dyeMapColour(item.ColourRed()) }
+
+// MapColour ...
+func (Obsidian) MapColour() color.RGBA { return dyeMapColour(item.ColourBlack()) }
+
+// MapColour ...
+func (Basalt) MapColour() color.RGBA { return dyeMapColour(item.ColourBlack()) }
+
+// MapColour ...
+func (Blackstone) MapColour() color.RGBA { return dyeMapColour(item.ColourBlack()) }
+
+// MapColour ...
+func (Netherrack) MapColour() color.RGBA { return mapColourNether }
+
+// MapColour ...
+func (NetherBricks) MapColour() color.RGBA { return mapColourNether }
+
+// MapColour ...
+func (SoulSand) MapColour() color.RGBA { return dyeMapColour(item.ColourBrown()) }
+
+// MapColour ...
+func (Glowstone) MapColour() color.RGBA { return mapColourSand }
+
+// MapColour ...
+func (EndStone) MapColour() color.RGBA { return mapColourSand }
+
+// MapColour ...
+func (Iron) MapColour() color.RGBA { return mapColourMetal }
+
+// MapColour ...
+func (Gold) MapColour() color.RGBA { return mapColourGold }
+
+// MapColour ...
+func (Diamond) MapColour() color.RGBA { return mapColourDiamond }
+
+// MapColour ...
+func (Emerald) MapColour() color.RGBA { return mapColourEmerald }
+
+// MapColour ...
+func (Lapis) MapColour() color.RGBA { return mapColourLapis }
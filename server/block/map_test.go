@@ -0,0 +1,79 @@
+package block
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestMapExploreSamplesTopBlocks(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	runWorld(w, func(tx *world.Tx) {
+		for x := -8; x < 8; x++ {
+			for z := -8; z < 8; z++ {
+				tx.SetBlock(cube.Pos{x, 64, z}, Grass{}, nil)
+			}
+		}
+		// A raised block is drawn brighter, while the block south of it is
+		// drawn darker because the block north of it is higher.
+		tx.SetBlock(cube.Pos{4, 65, 0}, Stone{}, nil)
+		// Blocks without a map colour are not drawn, so the grass below the
+		// glass is shown instead.
+		tx.SetBlock(cube.Pos{-2, 70, -2}, Glass{}, nil)
+		// Water is shaded by its depth rather than its height.
+		tx.SetBlock(cube.Pos{2, 64, 2}, Water{Still: true, Depth: 8}, nil)
+		tx.SetBlock(cube.Pos{2, 63, 2}, Water{Still: true, Depth: 8}, nil)
+
+		m := w.NewMap(cube.Pos{5, 64, -3}, 0)
+		if c := m.Centre(); c != (cube.Pos{0, 0, 0}) {
+			t.Fatalf("map centre = %v, want the origin", c)
+		}
+		initial := m.Version()
+		m.Explore(tx, mgl64.Vec3{0.5, 65, 0.5})
+		if m.Version() == initial {
+			t.Fatal("expected exploring the map to draw pixels")
+		}
+		for range 15 {
+			m.Explore(tx, mgl64.Vec3{0.5, 65, 0.5})
+		}
+
+		// The block at x and z is drawn at pixel x+64, z+64.
+		pixel := func(x, z int) color.RGBA { return m.Pixel(x+world.MapSize/2, z+world.MapSize/2) }
+		flat := color.RGBA{R: 127 * 220 / 255, G: 178 * 220 / 255, B: 56 * 220 / 255, A: 0xff}
+		tests := []struct {
+			name string
+			x, z int
+			want color.RGBA
+		}{
+			{name: "flat grass", x: 0, z: 0, want: flat},
+			{name: "grass at edge of area", x: -8, z: 7, want: flat},
+			{name: "grass below glass", x: -2, z: -2, want: flat},
+			{name: "raised stone", x: 4, z: 0, want: color.RGBA{R: 112, G: 112, B: 112, A: 0xff}},
+			{name: "grass south of raised stone", x: 4, z: 1, want: color.RGBA{R: 127 * 180 / 255, G: 178 * 180 / 255, B: 56 * 180 / 255, A: 0xff}},
+			{name: "shallow water", x: 2, z: 2, want: color.RGBA{R: 64, G: 64, B: 255, A: 0xff}},
+			{name: "empty column", x: 12, z: 0},
+			{name: "unloaded chunk", x: 40, z: 0},
+		}
+		for _, test := range tests {
+			if c := pixel(test.x, test.z); c != test.want {
+				t.Errorf("%v: pixel at %v, %v = %v, want %v", test.name, test.x, test.z, c, test.want)
+			}
+		}
+
+		// A locked map is no longer drawn onto.
+		m.Lock(true)
+		tx.SetBlock(cube.Pos{0, 64, 0}, Sand{}, nil)
+		version := m.Version()
+		for range 16 {
+			m.Explore(tx, mgl64.Vec3{0.5, 65, 0.5})
+		}
+		if m.Version() != version || pixel(0, 0) != flat {
+			t.Errorf("locked map changed after exploring: pixel = %v", pixel(0, 0))
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Cauldron is a model used by cauldrons. It is solid on all sides apart from the top and has a hollow inside
+// area.
+type Cauldron struct{}
+
+// BBox ...
+func (Cauldron) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	return []cube.BBox{
+		cube.Box(0, 0, 0, 1, 1, 0.125),
+		cube.Box(0, 0, 0.875, 1, 1, 1),
+		cube.Box(0.875, 0, 0, 1, 1, 1),
+		cube.Box(0, 0, 0, 0.125, 1, 1),
+		cube.Box(0.125, 0, 0.125, 0.875, 0.25, 0.875),
+	}
+}
+
+// FaceSolid returns true for all faces other than the top.
+func (Cauldron) FaceSolid(_ cube.Pos, face cube.Face, _ world.BlockSource) bool {
+	return face != cube.FaceUp
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Honey is the model of a honey block. Its collision box is slightly smaller than a full block on all sides
+// but the bottom, so that entities touching its sides slide down it and entities on top of it sink in slightly.
+type Honey struct{}
+
+// BBox returns a physics.BBox that is 1/16th of a block smaller than a full block on all sides but the bottom.
+func (Honey) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	return []cube.BBox{cube.Box(0.0625, 0, 0.0625, 0.9375, 0.9375, 0.9375)}
+}
+
+// FaceSolid always returns true.
+func (Honey) FaceSolid(cube.Pos, cube.Face, world.BlockSource) bool {
+	return true
+}
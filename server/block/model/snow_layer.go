@@ -0,0 +1,28 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// SnowLayer is a model used by layers of snow. Its height depends on the number of layers of snow stacked
+// on top of each other.
+type SnowLayer struct {
+	// Height is the number of layers of snow on top of the first layer. A layer of snow can have up to 7
+	// layers stacked on top of it, in which case it is a full block.
+	Height int
+}
+
+// BBox returns a BBox that is one layer lower than the snow layer is high, so that entities sink into the
+// top layer of snow. A single layer of snow has no BBox.
+func (s SnowLayer) BBox(cube.Pos, world.BlockSource) []cube.BBox {
+	if s.Height == 0 {
+		return nil
+	}
+	return []cube.BBox{cube.Box(0, 0, 0, 1, float64(s.Height)/8, 1)}
+}
+
+// FaceSolid returns true for the bottom face and for all faces if the snow layer is a full block.
+func (s SnowLayer) FaceSolid(_ cube.Pos, face cube.Face, _ world.BlockSource) bool {
+	return face == cube.FaceDown || s.Height == 7
+}
@@ -0,0 +1,205 @@
+package block
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// MonsterSpawner is a block that spawns entities of a specific type around it in bursts while a player is
+// nearby. The entity spawned is displayed spinning inside the spawner. Using a spawn egg on a monster spawner
+// changes the type of entity that it spawns.
+type MonsterSpawner struct {
+	solid
+	transparent
+	sourceWaterDisplacer
+
+	// Entity is the name of the entity type that the spawner spawns, such as "minecraft:zombie". The entity
+	// type must be registered in the world.EntityRegistry of the world. If empty, the spawner does not spawn
+	// anything.
+	Entity string
+	// Delay is the amount of ticks that remain until the spawner next spawns entities. It only counts down
+	// while a player is within the RequiredPlayerRange of the spawner.
+	Delay int
+	// MinSpawnDelay and MaxSpawnDelay are the minimum and maximum amount of ticks that the Delay is reset to
+	// after the spawner spawned entities. If 0, they default to 200 and 800 respectively.
+	MinSpawnDelay, MaxSpawnDelay int
+	// SpawnCount is the amount of entities that the spawner attempts to spawn at once. If 0, it defaults to
+	// 4.
+	SpawnCount int
+	// MaxNearbyEntities is the maximum amount of entities of the Entity type within the SpawnRange of the
+	// spawner. The spawner stops spawning entities once this amount is reached. If 0, it defaults to 6.
+	MaxNearbyEntities int
+	// RequiredPlayerRange is the distance in blocks within which a player must be for the spawner to be
+	// active. If 0, it defaults to 16.
+	RequiredPlayerRange int
+	// SpawnRange is the horizontal distance in blocks from the spawner within which entities are spawned. If
+	// 0, it defaults to 4.
+	SpawnRange int
+}
+
+// NewMonsterSpawner returns a MonsterSpawner that spawns entities of the type with the name passed, using the
+// default spawn settings.
+func NewMonsterSpawner(entity string) MonsterSpawner {
+	return MonsterSpawner{Entity: entity, Delay: 20}
+}
+
+// BreakInfo ...
+func (m MonsterSpawner) BreakInfo() BreakInfo {
+	return newBreakInfo(5, pickaxeHarvestable, pickaxeEffective, simpleDrops()).withXPDropRange(15, 43)
+}
+
+// SideClosed ...
+func (MonsterSpawner) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
+	return false
+}
+
+// Activate changes the entity type spawned by the spawner if the user is holding a spawn egg.
+func (m MonsterSpawner) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, ctx *item.UseContext) bool {
+	held, _ := u.HeldItems()
+	egg, ok := held.Item().(item.SpawnEgg)
+	if !ok {
+		return false
+	}
+	m.Entity = egg.Entity
+	tx.SetBlock(pos, m, nil)
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// Tick counts down the Delay of the spawner while a player is in range and spawns entities once it reaches 0.
+func (m MonsterSpawner) Tick(_ int64, pos cube.Pos, tx *world.Tx) {
+	if m.Entity == "" || !m.playerInRange(pos, tx) {
+		return
+	}
+	if m.Delay > 0 {
+		m.Delay--
+		tx.SetBlock(pos, m, nil)
+		return
+	}
+	m.spawn(pos, tx)
+	minDelay, maxDelay := m.spawnDelay()
+	m.Delay = minDelay + rand.IntN(maxDelay-minDelay+1)
+	tx.SetBlock(pos, m, nil)
+}
+
+// playerInRange checks if a player is within the RequiredPlayerRange of the spawner.
+func (m MonsterSpawner) playerInRange(pos cube.Pos, tx *world.Tx) bool {
+	r, centre := float64(defaultInt(m.RequiredPlayerRange, 16)), pos.Vec3Centre()
+	for p := range tx.Players() {
+		if p.Position().Sub(centre).Len() <= r {
+			return true
+		}
+	}
+	return false
+}
+
+// spawn attempts to spawn SpawnCount entities around the spawner. Spawning stops once the amount of entities of
+// the Entity type around the spawner reaches MaxNearbyEntities. Entities are only spawned at positions where
+// both the block at their feet and the block above have no collision, and where their EntityType allows them
+// to spawn according to world.Tx.CanSpawnAt.
+func (m MonsterSpawner) spawn(pos cube.Pos, tx *world.Tx) {
+	r := float64(defaultInt(m.SpawnRange, 4))
+	area := cube.Box(-r, -r, -r, r+1, r+1, r+1).Translate(pos.Vec3())
+	for range defaultInt(m.SpawnCount, 4) {
+		if m.nearbyEntities(area, tx) >= defaultInt(m.MaxNearbyEntities, 6) {
+			return
+		}
+		spawnPos := mgl64.Vec3{
+			float64(pos[0]) + (rand.Float64()-rand.Float64())*r + 0.5,
+			float64(pos[1] + rand.IntN(3) - 1),
+			float64(pos[2]) + (rand.Float64()-rand.Float64())*r + 0.5,
+		}
+		if !monsterSpawnerSpace(cube.PosFromVec3(spawnPos), tx) {
+			continue
+		}
+		opts := world.SpawnOptions{Rotation: cube.Rotation{rand.Float64() * 360}, CheckConditions: true}
+		if _, ok := tx.SpawnEntity(m.Entity, spawnPos, opts); ok {
+			tx.BroadcastLevelEvent(spawnPos, world.LevelEventMobSpawn, 0)
+		}
+	}
+}
+
+// nearbyEntities returns the amount of entities of the Entity type of the spawner within the area passed.
+func (m MonsterSpawner) nearbyEntities(area cube.BBox, tx *world.Tx) int {
+	var n int
+	for e := range tx.EntitiesWithin(area) {
+		if e.H().Type().EncodeEntity() == m.Entity {
+			n++
+		}
+	}
+	return n
+}
+
+// monsterSpawnerSpace checks if an entity may be spawned by a monster spawner at the position passed.
+func monsterSpawnerSpace(pos cube.Pos, tx *world.Tx) bool {
+	if pos.OutOfBounds(tx.Range()) {
+		return false
+	}
+	for _, p := range []cube.Pos{pos, pos.Side(cube.FaceUp)} {
+		if len(tx.Block(p).Model().BBox(p, tx)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// spawnDelay returns the minimum and maximum delay of the spawner after spawning entities.
+func (m MonsterSpawner) spawnDelay() (minDelay, maxDelay int) {
+	minDelay, maxDelay = defaultInt(m.MinSpawnDelay, 200), defaultInt(m.MaxSpawnDelay, 800)
+	return minDelay, max(minDelay, maxDelay)
+}
+
+// defaultInt returns v if it is not 0, or def otherwise.
+func defaultInt(v, def int) int {
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+// EncodeItem ...
+func (MonsterSpawner) EncodeItem() (name string, meta int16) {
+	return "minecraft:mob_spawner", 0
+}
+
+// EncodeBlock ...
+func (MonsterSpawner) EncodeBlock() (string, map[string]any) {
+	return "minecraft:mob_spawner", nil
+}
+
+// DecodeNBT ...
+func (m MonsterSpawner) DecodeNBT(data map[string]any) any {
+	m.Entity = nbtconv.String(data, "EntityIdentifier")
+	m.Delay = int(nbtconv.Int16(data, "Delay"))
+	m.MinSpawnDelay = int(nbtconv.Int16(data, "MinSpawnDelay"))
+	m.MaxSpawnDelay = int(nbtconv.Int16(data, "MaxSpawnDelay"))
+	m.SpawnCount = int(nbtconv.Int16(data, "SpawnCount"))
+	m.MaxNearbyEntities = int(nbtconv.Int16(data, "MaxNearbyEntities"))
+	m.RequiredPlayerRange = int(nbtconv.Int16(data, "RequiredPlayerRange"))
+	m.SpawnRange = int(nbtconv.Int16(data, "SpawnRange"))
+	return m
+}
+
+// EncodeNBT ...
+func (m MonsterSpawner) EncodeNBT() map[string]any {
+	minDelay, maxDelay := m.spawnDelay()
+	return map[string]any{
+		"id":                  "MobSpawner",
+		"EntityIdentifier":    m.Entity,
+		"Delay":               int16(m.Delay),
+		"MinSpawnDelay":       int16(minDelay),
+		"MaxSpawnDelay":       int16(maxDelay),
+		"SpawnCount":          int16(defaultInt(m.SpawnCount, 4)),
+		"MaxNearbyEntities":   int16(defaultInt(m.MaxNearbyEntities, 6)),
+		"RequiredPlayerRange": int16(defaultInt(m.RequiredPlayerRange, 16)),
+		"SpawnRange":          int16(defaultInt(m.SpawnRange, 4)),
+		"DisplayEntityWidth":  float32(1),
+		"DisplayEntityHeight": float32(1),
+		"DisplayEntityScale":  float32(1),
+	}
+}
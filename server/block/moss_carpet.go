@@ -24,9 +24,14 @@ func (MossCarpet) HasLiquidDrops() bool {
 	return true
 }
 
+// SupportedAt ...
+func (m MossCarpet) SupportedAt(pos cube.Pos, tx *world.Tx) bool {
+	return groundSupports(pos, tx)
+}
+
 // NeighbourUpdateTick ...
 func (m MossCarpet) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
-	if _, ok := tx.Block(pos.Side(cube.FaceDown)).(Air); ok {
+	if !m.SupportedAt(pos, tx) {
 		breakBlock(m, pos, tx)
 	}
 }
@@ -37,9 +42,6 @@ func (m MossCarpet) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *w
 	if !used {
 		return
 	}
-	if _, ok := tx.Block(pos.Side(cube.FaceDown)).(Air); ok {
-		return
-	}
 
 	place(tx, pos, m, user, ctx)
 	return placed(ctx)
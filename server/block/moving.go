@@ -0,0 +1,80 @@
+package block
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Moving is a block that is being moved by a piston. It is shown as the block it holds, moving along with
+// the arm of the piston, and is replaced by that block once the piston finished moving.
+type Moving struct {
+	empty
+	transparent
+
+	// Moving is the block that is being moved.
+	Moving world.Block
+	// Extra is an additional block that is moved along with Moving, such as a liquid that Moving was
+	// waterlogged with.
+	Extra world.Block
+	// Piston is the position of the piston moving the block.
+	Piston cube.Pos
+	// Expanding specifies if the block is pushed by an extending piston, as opposed to pulled by a retracting
+	// sticky piston.
+	Expanding bool
+}
+
+// ScheduledTick places the block that was moved once the piston finished moving it.
+func (m Moving) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	tx.SetBlock(pos, m.Moving, nil)
+}
+
+// PistonPushReaction ...
+func (Moving) PistonPushReaction() PistonPushReaction {
+	return PistonPushBlock
+}
+
+// EncodeBlock ...
+func (Moving) EncodeBlock() (string, map[string]any) {
+	return "minecraft:moving_block", nil
+}
+
+// DecodeNBT ...
+func (m Moving) DecodeNBT(data map[string]any) any {
+	m.Moving = nbtconv.Block(data, "movingBlock")
+	m.Extra = nbtconv.Block(data, "movingBlockExtra")
+	if nbter, ok := m.Moving.(world.NBTer); ok {
+		if entity, ok := data["movingEntity"].(map[string]any); ok {
+			m.Moving = nbter.DecodeNBT(entity).(world.Block)
+		}
+	}
+	m.Piston = cube.Pos{int(nbtconv.Int32(data, "pistonPosX")), int(nbtconv.Int32(data, "pistonPosY")), int(nbtconv.Int32(data, "pistonPosZ"))}
+	m.Expanding = nbtconv.Bool(data, "expanding")
+	return m
+}
+
+// EncodeNBT ...
+func (m Moving) EncodeNBT() map[string]any {
+	moving, extra := m.Moving, m.Extra
+	if moving == nil {
+		moving = Air{}
+	}
+	if extra == nil {
+		extra = Air{}
+	}
+	data := map[string]any{
+		"id":               "MovingBlock",
+		"movingBlock":      nbtconv.WriteBlock(moving),
+		"movingBlockExtra": nbtconv.WriteBlock(extra),
+		"pistonPosX":       int32(m.Piston[0]),
+		"pistonPosY":       int32(m.Piston[1]),
+		"pistonPosZ":       int32(m.Piston[2]),
+		"expanding":        m.Expanding,
+	}
+	if nbter, ok := moving.(world.NBTer); ok {
+		data["movingEntity"] = nbter.EncodeNBT()
+	}
+	return data
+}
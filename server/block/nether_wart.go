@@ -31,6 +31,11 @@ func (n NetherWart) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
 	}
 }
 
+// WantsRandomTick ...
+func (n NetherWart) WantsRandomTick() bool {
+	return n.Age < 3
+}
+
 // UseOnBlock ...
 func (n NetherWart) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
 	pos, _, used := firstReplaceable(tx, pos, face, n)
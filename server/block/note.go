@@ -24,8 +24,9 @@ type Note struct {
 
 // playNote emits the configured note sound and particle at pos.
 func (n Note) playNote(pos cube.Pos, tx *world.Tx) {
-	tx.PlaySound(pos.Vec3(), sound.Note{Instrument: n.instrument(pos, tx), Pitch: n.Pitch})
-	tx.AddParticle(pos.Vec3(), particle.Note{Instrument: n.Instrument(), Pitch: n.Pitch})
+	instrument := n.instrument(pos, tx)
+	tx.PlaySound(pos.Vec3(), sound.Note{Instrument: instrument, Pitch: n.Pitch})
+	tx.AddParticle(pos.Vec3(), particle.Note{Instrument: instrument, Pitch: n.Pitch})
 }
 
 // instrument returns the note block instrument selected by the block below it.
@@ -0,0 +1,94 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestNotePitchCycles(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	handler := &soundTestHandler{}
+	w.Handle(handler)
+
+	pos := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pos, Note{Pitch: 23}, nil)
+		for _, want := range []int{24, 0, 1} {
+			if !tx.Block(pos).(Note).Activate(pos, cube.FaceUp, tx, nil, nil) {
+				t.Fatal("note block could not be activated")
+			}
+			if got := tx.Block(pos).(Note).Pitch; got != want {
+				t.Fatalf("note block pitch after activation = %d, want %d", got, want)
+			}
+			if s, ok := handler.last().(sound.Note); !ok || s.Pitch != want {
+				t.Fatalf("note block played %#v, want a note with pitch %d", handler.last(), want)
+			}
+		}
+
+		tx.SetBlock(pos.Side(cube.FaceUp), Stone{}, nil)
+		if tx.Block(pos).(Note).Activate(pos, cube.FaceUp, tx, nil, nil) {
+			t.Fatal("note block with a block above it was activated")
+		}
+		if got := tx.Block(pos).(Note).Pitch; got != 1 {
+			t.Fatalf("pitch of note block with a block above it changed to %d", got)
+		}
+	})
+}
+
+func TestNoteInstrumentByBlockBelow(t *testing.T) {
+	tests := []struct {
+		name  string
+		below world.Block
+		want  sound.Instrument
+	}{
+		{name: "air", below: Air{}, want: sound.Piano()},
+		{name: "stone", below: Stone{}, want: sound.BassDrum()},
+		{name: "sand", below: Sand{}, want: sound.Snare()},
+		{name: "gold", below: Gold{}, want: sound.Bell()},
+		{name: "planks", below: Planks{Wood: OakWood()}, want: sound.Bass()},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := world.Config{Synchronous: true}.New()
+			defer w.Close()
+
+			handler := &soundTestHandler{}
+			w.Handle(handler)
+
+			pos := cube.Pos{0, 64, 0}
+			runWorld(w, func(tx *world.Tx) {
+				tx.SetBlock(pos.Side(cube.FaceDown), test.below, nil)
+				tx.SetBlock(pos, Note{}, nil)
+				Note{}.Activate(pos, cube.FaceUp, tx, nil, nil)
+			})
+			if s, ok := handler.last().(sound.Note); !ok || s.Instrument != test.want {
+				t.Fatalf("note block above %v played %#v, want instrument %v", test.below, handler.last(), test.want)
+			}
+		})
+	}
+}
+
+// soundTestHandler is a world.Handler that records the sounds played in a world.
+type soundTestHandler struct {
+	world.NopHandler
+
+	sounds []world.Sound
+}
+
+func (h *soundTestHandler) HandleSound(_ *world.Context, s world.Sound, _ mgl64.Vec3) {
+	h.sounds = append(h.sounds, s)
+}
+
+// last returns the last sound played, or nil if no sounds were played.
+func (h *soundTestHandler) last() world.Sound {
+	if len(h.sounds) == 0 {
+		return nil
+	}
+	return h.sounds[len(h.sounds)-1]
+}
@@ -0,0 +1,94 @@
+package block
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Observer is a block that emits a short redstone pulse from its back when the block in front of it changes.
+type Observer struct {
+	solid
+
+	// Facing is the direction the observer is observing. The redstone pulse is emitted from the opposite
+	// face.
+	Facing cube.Face
+	// Powered specifies if the observer is currently emitting a redstone pulse.
+	Powered bool
+}
+
+// observerDelay is the delay between the observer detecting a change and the start and end of its pulse.
+const observerDelay = time.Second / 10
+
+// UseOnBlock ...
+func (o Observer) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, o)
+	if !used {
+		return false
+	}
+	o.Facing = calculateFace(user, pos).Opposite()
+	o.Powered = false
+
+	place(tx, pos, o, user, ctx)
+	return placed(ctx)
+}
+
+// NeighbourUpdateTick schedules a redstone pulse if the block in front of the observer changed.
+func (o Observer) NeighbourUpdateTick(pos, changedNeighbour cube.Pos, tx *world.Tx) {
+	if changedNeighbour == pos.Side(o.Facing) && !o.Powered {
+		tx.ScheduleBlockUpdate(pos, o, observerDelay)
+	}
+}
+
+// ScheduledTick starts or ends the redstone pulse of the observer.
+func (o Observer) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	o.Powered = !o.Powered
+	tx.SetBlock(pos, o, nil)
+	if o.Powered {
+		tx.ScheduleBlockUpdate(pos, o, observerDelay)
+	}
+}
+
+// RedstonePower returns full power from the back of the observer while it emits a pulse.
+func (o Observer) RedstonePower(_ cube.Pos, _ *world.Tx, face cube.Face) int {
+	if o.Powered && face == o.Facing.Opposite() {
+		return 15
+	}
+	return 0
+}
+
+// RedstoneStrongPower strongly powers the block behind the observer while it emits a pulse.
+func (o Observer) RedstoneStrongPower(pos cube.Pos, tx *world.Tx, face cube.Face) int {
+	return o.RedstonePower(pos, tx, face)
+}
+
+// RedstoneNonConductive ...
+func (Observer) RedstoneNonConductive() {}
+
+// BreakInfo ...
+func (o Observer) BreakInfo() BreakInfo {
+	return newBreakInfo(3, pickaxeHarvestable, pickaxeEffective, oneOf(Observer{}))
+}
+
+// EncodeItem ...
+func (Observer) EncodeItem() (name string, meta int16) {
+	return "minecraft:observer", 0
+}
+
+// EncodeBlock ...
+func (o Observer) EncodeBlock() (string, map[string]any) {
+	return "minecraft:observer", map[string]any{"minecraft:facing_direction": o.Facing.String(), "powered_bit": o.Powered}
+}
+
+// allObservers ...
+func allObservers() (b []world.Block) {
+	for _, f := range cube.Faces() {
+		b = append(b, Observer{Facing: f})
+		b = append(b, Observer{Facing: f, Powered: true})
+	}
+	return
+}
@@ -50,3 +50,8 @@ func (o Obsidian) BreakInfo() BreakInfo {
 		return t.ToolType() == item.TypePickaxe && t.HarvestLevel() >= item.ToolTierDiamond.HarvestLevel
 	}, pickaxeEffective, oneOf(o)).withBlastResistance(6000)
 }
+
+// PistonPushReaction ...
+func (Obsidian) PistonPushReaction() PistonPushReaction {
+	return PistonPushBlock
+}
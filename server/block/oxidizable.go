@@ -18,11 +18,17 @@ type Oxidisable interface {
 	WithOxidationLevel(OxidationType) Oxidisable
 }
 
+// canOxidise checks if the Oxidisable passed may oxidise any further, meaning
+// it is not waxed and not yet fully oxidised.
+func canOxidise(o Oxidisable) bool {
+	return o.CanOxidate() && o.OxidationLevel() != OxidisedOxidation()
+}
+
 // attemptOxidation attempts to oxidise the block at the position passed. The details for this logic is
 // described on the Minecraft Wiki: https://minecraft.wiki/w/Oxidation.
 func attemptOxidation(pos cube.Pos, tx *world.Tx, r *rand.Rand, o Oxidisable) {
 	level := o.OxidationLevel()
-	if level == OxidisedOxidation() || !o.CanOxidate() {
+	if !canOxidise(o) {
 		return
 	} else if r.Float64() > 64.0/1125.0 {
 		return
@@ -0,0 +1,278 @@
+package block
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Piston is a block capable of pushing blocks when powered by redstone. Sticky pistons additionally pull the
+// block in front of their arm back when they retract.
+type Piston struct {
+	solid
+	transparent
+	sourceWaterDisplacer
+
+	// Facing is the direction the arm of the piston extends in.
+	Facing cube.Face
+	// Sticky specifies if the piston is a sticky piston, which pulls blocks back when it retracts.
+	Sticky bool
+	// Powered is whether the piston was powered during its last redstone update.
+	Powered bool
+
+	// State is the state of the arm of the piston. It is one of PistonRetracted, PistonExtending,
+	// PistonExtended and PistonRetracting.
+	State int
+	// Progress is the progress of the arm of the piston. It is 0 if the arm is retracted and 1 if it is
+	// extended.
+	Progress float64
+	// LastProgress is the progress of the arm of the piston during the previous tick.
+	LastProgress float64
+	// AttachedBlocks holds the original positions of the blocks currently being moved by the piston.
+	AttachedBlocks []cube.Pos
+	// BreakBlocks holds the positions of the blocks broken by the current movement of the piston.
+	BreakBlocks []cube.Pos
+}
+
+const (
+	// PistonRetracted is the State of a piston with its arm fully retracted.
+	PistonRetracted = iota
+	// PistonExtending is the State of a piston with its arm moving outwards.
+	PistonExtending
+	// PistonExtended is the State of a piston with its arm fully extended.
+	PistonExtended
+	// PistonRetracting is the State of a piston with its arm moving inwards.
+	PistonRetracting
+)
+
+// pistonPushLimit is the maximum number of blocks that a piston can move at once.
+const pistonPushLimit = 12
+
+// pistonTick is the time between two steps of the movement of a piston arm.
+const pistonTick = time.Second / 20
+
+// UseOnBlock ...
+func (p Piston) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, p)
+	if !used {
+		return false
+	}
+	p.Facing = calculateFace(user, pos)
+
+	place(tx, pos, p, user, ctx)
+	return placed(ctx)
+}
+
+// RedstoneNonConductive ...
+func (Piston) RedstoneNonConductive() {}
+
+// RedstonePowerUpdate records power changes. Power reaching the piston through the face of its arm is
+// ignored. Movement is scheduled in RedstonePowerPostUpdate so that cancellation of the update also
+// suppresses it.
+func (p Piston) RedstonePowerUpdate(pos cube.Pos, tx *world.Tx, _ int) (world.Block, bool) {
+	powered := p.receivesPower(pos, tx)
+	if powered == p.Powered {
+		return p, false
+	}
+	p.Powered = powered
+	return p, true
+}
+
+// RedstonePowerPostUpdate schedules the movement of the arm of the piston after an uncancelled change of
+// power.
+func (p Piston) RedstonePowerPostUpdate(pos cube.Pos, tx *world.Tx, _, after world.Block, _, _ int) {
+	tx.ScheduleBlockUpdate(pos, after, pistonTick)
+}
+
+// receivesPower checks if the piston at pos receives redstone power from any side other than the side of
+// its arm.
+func (p Piston) receivesPower(pos cube.Pos, tx *world.Tx) bool {
+	for _, face := range cube.Faces() {
+		if face != p.Facing && tx.RedstonePowerFrom(pos, face) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ScheduledTick moves the arm of the piston one step further. A retracted piston that is powered starts
+// extending, while an extended piston that is no longer powered starts retracting.
+func (p Piston) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	p.LastProgress = p.Progress
+	switch p.State {
+	case PistonRetracted:
+		if !p.Powered || !p.extend(pos, tx) {
+			return
+		}
+	case PistonExtending:
+		if p.Progress = min(p.Progress+0.5, 1); p.Progress == 1 {
+			p.State, p.AttachedBlocks, p.BreakBlocks = PistonExtended, nil, nil
+		}
+	case PistonExtended:
+		if p.Powered {
+			return
+		}
+		p.retract(pos, tx)
+	case PistonRetracting:
+		if p.Progress = max(p.Progress-0.5, 0); p.Progress == 0 {
+			p.State, p.AttachedBlocks, p.BreakBlocks = PistonRetracted, nil, nil
+		}
+	}
+	tx.SetBlock(pos, p, nil)
+	if p.moving() || p.Powered != (p.State == PistonExtended) {
+		tx.ScheduleBlockUpdate(pos, p, pistonTick)
+	}
+}
+
+// extend pushes the blocks in front of the piston and places the arm of the piston. False is returned if the
+// blocks in front of the piston could not be pushed.
+func (p *Piston) extend(pos cube.Pos, tx *world.Tx) bool {
+	r := newPistonResolver(tx, pos, p.Facing)
+	if !r.resolve(pos.Side(p.Facing)) {
+		return false
+	}
+	p.AttachedBlocks, p.BreakBlocks = r.moved, r.broken
+	r.move(pos, true)
+	tx.SetBlock(pos.Side(p.Facing), PistonArmCollision{Facing: p.Facing, Sticky: p.Sticky}, nil)
+	tx.PlaySound(pos.Vec3Centre(), sound.PistonExtend{})
+
+	p.State = PistonExtending
+	return true
+}
+
+// retract removes the arm of the piston. A sticky piston also pulls back the blocks attached to its arm, if
+// they can be moved.
+func (p *Piston) retract(pos cube.Pos, tx *world.Tx) {
+	armPos := pos.Side(p.Facing)
+	if _, ok := tx.Block(armPos).(PistonArmCollision); ok {
+		tx.SetBlock(armPos, nil, nil)
+	}
+	if p.Sticky {
+		r := newPistonResolver(tx, pos, p.Facing.Opposite())
+		if r.resolveAttached(armPos.Side(p.Facing)) {
+			p.AttachedBlocks, p.BreakBlocks = r.moved, r.broken
+			r.move(pos, false)
+		}
+	}
+	tx.PlaySound(pos.Vec3Centre(), sound.PistonRetract{})
+
+	p.State = PistonRetracting
+}
+
+// moving checks if the arm of the piston is currently moving.
+func (p Piston) moving() bool {
+	return p.State == PistonExtending || p.State == PistonRetracting
+}
+
+// PistonPushReaction returns PistonPushBlock if the arm of the piston is not fully retracted.
+func (p Piston) PistonPushReaction() PistonPushReaction {
+	if p.State != PistonRetracted {
+		return PistonPushBlock
+	}
+	return PistonPushNormal
+}
+
+// BreakInfo ...
+func (p Piston) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, alwaysHarvestable, pickaxeEffective, oneOf(Piston{Sticky: p.Sticky})).withBreakHandler(func(pos cube.Pos, tx *world.Tx, _ item.User) {
+		armPos := pos.Side(p.Facing)
+		if arm, ok := tx.Block(armPos).(PistonArmCollision); ok && arm.Facing == p.Facing {
+			tx.SetBlock(armPos, nil, nil)
+		}
+	})
+}
+
+// EncodeItem ...
+func (p Piston) EncodeItem() (name string, meta int16) {
+	if p.Sticky {
+		return "minecraft:sticky_piston", 0
+	}
+	return "minecraft:piston", 0
+}
+
+// EncodeBlock ...
+func (p Piston) EncodeBlock() (string, map[string]any) {
+	if p.Sticky {
+		return "minecraft:sticky_piston", map[string]any{"facing_direction": pistonFacing(p.Facing)}
+	}
+	return "minecraft:piston", map[string]any{"facing_direction": pistonFacing(p.Facing)}
+}
+
+// DecodeNBT ...
+func (p Piston) DecodeNBT(data map[string]any) any {
+	p.State = int(nbtconv.Uint8(data, "State"))
+	p.Progress = float64(nbtconv.Float32(data, "Progress"))
+	p.LastProgress = float64(nbtconv.Float32(data, "LastProgress"))
+	p.Powered = nbtconv.Bool(data, "powered")
+	p.AttachedBlocks = pistonPositions(data, "AttachedBlocks")
+	p.BreakBlocks = pistonPositions(data, "BreakBlocks")
+	return p
+}
+
+// EncodeNBT ...
+func (p Piston) EncodeNBT() map[string]any {
+	return map[string]any{
+		"id":             "PistonArm",
+		"State":          uint8(p.State),
+		"NewState":       uint8(p.State),
+		"Progress":       float32(p.Progress),
+		"LastProgress":   float32(p.LastProgress),
+		"Sticky":         boolByte(p.Sticky),
+		"powered":        boolByte(p.Powered),
+		"AttachedBlocks": pistonPositionsToInt32Slice(p.AttachedBlocks),
+		"BreakBlocks":    pistonPositionsToInt32Slice(p.BreakBlocks),
+	}
+}
+
+// pistonFacing converts the face of a piston arm to the facing direction of the piston block state, in which
+// horizontal faces are flipped.
+func pistonFacing(face cube.Face) int32 {
+	if face.Axis() == cube.Y {
+		return int32(face)
+	}
+	return int32(face.Opposite())
+}
+
+// pistonPositions reads a list of positions stored as consecutive X, Y and Z values from the key k.
+func pistonPositions(data map[string]any, k string) []cube.Pos {
+	var values []int32
+	switch v := data[k].(type) {
+	case []int32:
+		values = v
+	case []any:
+		for _, x := range v {
+			if i, ok := x.(int32); ok {
+				values = append(values, i)
+			}
+		}
+	}
+	positions := make([]cube.Pos, 0, len(values)/3)
+	for i := 0; i+2 < len(values); i += 3 {
+		positions = append(positions, cube.Pos{int(values[i]), int(values[i+1]), int(values[i+2])})
+	}
+	return positions
+}
+
+// pistonPositionsToInt32Slice writes a list of positions as consecutive X, Y and Z values.
+func pistonPositionsToInt32Slice(positions []cube.Pos) []int32 {
+	values := make([]int32, 0, len(positions)*3)
+	for _, pos := range positions {
+		values = append(values, int32(pos[0]), int32(pos[1]), int32(pos[2]))
+	}
+	return values
+}
+
+// allPistons ...
+func allPistons() (b []world.Block) {
+	for _, f := range cube.Faces() {
+		b = append(b, Piston{Facing: f})
+		b = append(b, Piston{Facing: f, Sticky: true})
+	}
+	return
+}
@@ -0,0 +1,58 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// PistonArmCollision is the arm of an extended piston. It is placed in front of a piston when it extends and
+// removed when it retracts.
+type PistonArmCollision struct {
+	solid
+	transparent
+
+	// Facing is the direction the arm extends in, matching the Facing of its piston.
+	Facing cube.Face
+	// Sticky specifies if the arm belongs to a sticky piston.
+	Sticky bool
+}
+
+// NeighbourUpdateTick removes the arm if the piston it belongs to was removed.
+func (c PistonArmCollision) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if piston, ok := tx.Block(pos.Side(c.Facing.Opposite())).(Piston); !ok || piston.Facing != c.Facing {
+		tx.SetBlock(pos, nil, nil)
+	}
+}
+
+// PistonPushReaction ...
+func (PistonArmCollision) PistonPushReaction() PistonPushReaction {
+	return PistonPushBlock
+}
+
+// BreakInfo ...
+func (c PistonArmCollision) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, alwaysHarvestable, pickaxeEffective, simpleDrops()).withBreakHandler(func(pos cube.Pos, tx *world.Tx, _ item.User) {
+		pistonPos := pos.Side(c.Facing.Opposite())
+		if piston, ok := tx.Block(pistonPos).(Piston); ok && piston.Facing == c.Facing {
+			breakBlock(piston, pistonPos, tx)
+		}
+	})
+}
+
+// EncodeBlock ...
+func (c PistonArmCollision) EncodeBlock() (string, map[string]any) {
+	if c.Sticky {
+		return "minecraft:sticky_piston_arm_collision", map[string]any{"facing_direction": pistonFacing(c.Facing)}
+	}
+	return "minecraft:piston_arm_collision", map[string]any{"facing_direction": pistonFacing(c.Facing)}
+}
+
+// allPistonArmCollisions ...
+func allPistonArmCollisions() (b []world.Block) {
+	for _, f := range cube.Faces() {
+		b = append(b, PistonArmCollision{Facing: f})
+		b = append(b, PistonArmCollision{Facing: f, Sticky: true})
+	}
+	return
+}
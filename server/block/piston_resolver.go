@@ -0,0 +1,179 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// PistonPushReaction specifies how a block reacts to being pushed or pulled by a piston.
+type PistonPushReaction uint8
+
+const (
+	// PistonPushDefault derives the reaction of a block from its properties. Blocks without a collision box,
+	// such as flowers and torches, are destroyed, blocks that cannot be broken, such as bedrock, cannot be
+	// moved and all other blocks are moved.
+	PistonPushDefault PistonPushReaction = iota
+	// PistonPushNormal makes a block move along with the piston. Any block entity data of the block, such as
+	// the contents of a chest, moves along with it.
+	PistonPushNormal
+	// PistonPushBlock makes a block immovable. An immovable block in the path of a piston stops it from
+	// extending, and sticky pistons leave it behind when retracting.
+	PistonPushBlock
+	// PistonPushDestroy makes a block break and drop its items when a piston pushes a block into it. Sticky
+	// pistons do not pull blocks with this reaction.
+	PistonPushDestroy
+)
+
+// PistonPushable represents a block that specifies how it reacts to pistons.
+type PistonPushable interface {
+	// PistonPushReaction returns the PistonPushReaction of the block.
+	PistonPushReaction() PistonPushReaction
+}
+
+// PistonSticky represents a block that drags the blocks next to it along when it is moved by a piston, such as
+// slime and honey blocks.
+type PistonSticky interface {
+	// PistonSticksTo checks if the block drags the block passed along when it is moved by a piston.
+	PistonSticksTo(b world.Block) bool
+}
+
+// pistonSticky checks if sticky blocks such as slime may drag the block passed along. Glazed terracotta is
+// never dragged along, although it may be pushed and pulled by pistons.
+func pistonSticky(b world.Block) bool {
+	_, terracotta := b.(GlazedTerracotta)
+	return !terracotta
+}
+
+// pistonPushReactions holds the PistonPushReactions registered using RegisterPistonPushReaction, keyed by
+// the name of the block.
+var pistonPushReactions = map[string]PistonPushReaction{}
+
+// RegisterPistonPushReaction registers the PistonPushReaction passed for all states of the block with the
+// name passed, such as "minecraft:chest". A registered reaction takes precedence over the reaction
+// returned by the block itself. Registering PistonPushDefault removes the reaction registered for the
+// block. RegisterPistonPushReaction must be called before any world is ticked.
+func RegisterPistonPushReaction(name string, r PistonPushReaction) {
+	if r == PistonPushDefault {
+		delete(pistonPushReactions, name)
+		return
+	}
+	pistonPushReactions[name] = r
+}
+
+// pistonPushReaction returns the PistonPushReaction of the block b at pos. If the block has no
+// registered reaction and does not implement PistonPushable, its reaction is derived from its properties.
+func pistonPushReaction(pos cube.Pos, b world.Block, tx *world.Tx) PistonPushReaction {
+	name, _ := b.EncodeBlock()
+	if r, ok := pistonPushReactions[name]; ok {
+		return r
+	}
+	if pushable, ok := b.(PistonPushable); ok {
+		if r := pushable.PistonPushReaction(); r != PistonPushDefault {
+			return r
+		}
+	}
+	if len(b.Model().BBox(pos, tx)) == 0 {
+		return PistonPushDestroy
+	}
+	if breakable, ok := b.(Breakable); !ok || breakable.BreakInfo().Hardness < 0 {
+		return PistonPushBlock
+	}
+	return PistonPushNormal
+}
+
+// pistonResolver resolves the blocks moved by a piston. Blocks are moved in the direction of face and
+// PistonSticky blocks such as slime drag the blocks adjacent to them along.
+type pistonResolver struct {
+	tx     *world.Tx
+	piston cube.Pos
+	face   cube.Face
+
+	moved   []cube.Pos
+	broken  []cube.Pos
+	visited map[cube.Pos]struct{}
+}
+
+// newPistonResolver returns a pistonResolver for the piston at pos, moving blocks in the direction of face.
+func newPistonResolver(tx *world.Tx, pos cube.Pos, face cube.Face) *pistonResolver {
+	return &pistonResolver{tx: tx, piston: pos, face: face, visited: make(map[cube.Pos]struct{})}
+}
+
+// resolve resolves the blocks moved when the block at start is pushed. False is returned if the movement is
+// blocked by an immovable block or if more than 12 blocks would be moved.
+func (r *pistonResolver) resolve(start cube.Pos) bool {
+	return r.add(start, true)
+}
+
+// resolveAttached resolves the blocks moved when the block at start is pulled, such as by a sticky piston.
+// Unlike resolve, an immovable block at start does not block the movement, but simply is not moved.
+func (r *pistonResolver) resolveAttached(start cube.Pos) bool {
+	return r.add(start, false)
+}
+
+// add adds the block at pos to the blocks moved. If required is true, the block is in the path of another
+// moving block and must make way for it, either by moving or being broken.
+func (r *pistonResolver) add(pos cube.Pos, required bool) bool {
+	if _, ok := r.visited[pos]; ok {
+		return true
+	}
+	if pos == r.piston || pos.OutOfBounds(r.tx.Range()) {
+		return !required
+	}
+	b := r.tx.Block(pos)
+	if _, ok := b.(Air); ok {
+		return true
+	}
+	if _, ok := b.(world.Liquid); ok {
+		// Liquids are simply replaced by the blocks moved into them.
+		return true
+	}
+	switch pistonPushReaction(pos, b, r.tx) {
+	case PistonPushBlock:
+		return !required
+	case PistonPushDestroy:
+		if required {
+			r.visited[pos] = struct{}{}
+			r.broken = append(r.broken, pos)
+		}
+		return true
+	}
+	r.visited[pos] = struct{}{}
+	r.moved = append(r.moved, pos)
+	if len(r.moved) > pistonPushLimit {
+		return false
+	}
+	if !r.add(pos.Side(r.face), true) {
+		return false
+	}
+	if sticky, ok := b.(PistonSticky); ok {
+		for _, face := range cube.Faces() {
+			side := pos.Side(face)
+			if face != r.face && sticky.PistonSticksTo(r.tx.Block(side)) && !r.add(side, false) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// move breaks and moves the blocks resolved. Moved blocks are replaced by Moving blocks at their new
+// positions, which turn back into the blocks once the piston at pos finished moving. All blocks are removed
+// before any of them is placed again, so that moved blocks never overwrite each other.
+func (r *pistonResolver) move(pos cube.Pos, extending bool) {
+	for _, breakPos := range r.broken {
+		breakBlock(r.tx.Block(breakPos), breakPos, r.tx)
+	}
+	blocks := make([]world.Block, len(r.moved))
+	for i, movedPos := range r.moved {
+		blocks[i] = r.tx.Block(movedPos)
+	}
+	for _, movedPos := range r.moved {
+		r.tx.SetBlock(movedPos, nil, nil)
+	}
+	for i, movedPos := range r.moved {
+		newPos := movedPos.Side(r.face)
+		m := Moving{Moving: blocks[i], Piston: pos, Expanding: extending}
+		r.tx.SetBlock(newPos, m, nil)
+		r.tx.ScheduleBlockUpdate(newPos, m, pistonTick*2)
+	}
+}
@@ -0,0 +1,214 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestPistonPushLimit(t *testing.T) {
+	tests := []struct {
+		name   string
+		blocks []world.Block
+		moves  bool
+	}{
+		{name: "push limit", blocks: pistonTestRow(Stone{}, 12), moves: true},
+		{name: "over push limit", blocks: pistonTestRow(Stone{}, 13)},
+		{name: "immovable", blocks: append(pistonTestRow(Stone{}, 2), Obsidian{})},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := world.Config{Synchronous: true}.New()
+			defer w.Close()
+
+			pistonPos := cube.Pos{0, 64, 0}
+			runWorld(w, func(tx *world.Tx) {
+				tx.SetBlock(pistonPos, Piston{Facing: cube.FaceEast}, nil)
+				for i, b := range test.blocks {
+					tx.SetBlock(pistonPos.Add(cube.Pos{i + 1}), b, nil)
+				}
+				tx.SetBlock(pistonPos.Side(cube.FaceWest), RedstoneBlock{}, nil)
+			})
+			pistonTestAdvance(w)
+
+			runWorld(w, func(tx *world.Tx) {
+				offset := 1
+				if test.moves {
+					offset = 2
+					if _, ok := tx.Block(pistonPos.Side(cube.FaceEast)).(PistonArmCollision); !ok {
+						t.Errorf("block in front of extended piston = %T, want PistonArmCollision", tx.Block(pistonPos.Side(cube.FaceEast)))
+					}
+				}
+				for i, b := range test.blocks {
+					if got := tx.Block(pistonPos.Add(cube.Pos{i + offset})); got != b {
+						t.Errorf("block %d after pushing = %T, want %T", i, got, b)
+					}
+				}
+				if p := tx.Block(pistonPos).(Piston); (p.State == PistonExtended) != test.moves {
+					t.Errorf("piston state = %d, extended: %t", p.State, test.moves)
+				}
+			})
+		})
+	}
+}
+
+func TestStickyPistonPullsBlockBack(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pistonPos, powerPos := cube.Pos{0, 64, 0}, cube.Pos{-1, 64, 0}
+	blockPos := pistonPos.Side(cube.FaceEast)
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pistonPos, Piston{Facing: cube.FaceEast, Sticky: true}, nil)
+		tx.SetBlock(blockPos, Stone{}, nil)
+		tx.SetBlock(powerPos, RedstoneBlock{}, nil)
+	})
+	pistonTestAdvance(w)
+
+	runWorld(w, func(tx *world.Tx) {
+		if got := tx.Block(blockPos.Side(cube.FaceEast)); got != (Stone{}) {
+			t.Fatalf("block pushed by sticky piston = %T, want Stone", got)
+		}
+		tx.SetBlock(powerPos, nil, nil)
+	})
+	pistonTestAdvance(w)
+
+	runWorld(w, func(tx *world.Tx) {
+		if got := tx.Block(blockPos); got != (Stone{}) {
+			t.Errorf("block pulled back by sticky piston = %T, want Stone", got)
+		}
+		if got := tx.Block(blockPos.Side(cube.FaceEast)); got != (Air{}) {
+			t.Errorf("block left behind by sticky piston = %T, want Air", got)
+		}
+		if p := tx.Block(pistonPos).(Piston); p.State != PistonRetracted {
+			t.Errorf("sticky piston state = %d, want retracted", p.State)
+		}
+	})
+}
+
+func TestPistonStickyBlocks(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pistonPos := cube.Pos{0, 64, 0}
+	honeyPos := pistonPos.Side(cube.FaceEast)
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pistonPos, Piston{Facing: cube.FaceEast}, nil)
+		tx.SetBlock(honeyPos, HoneyBlock{}, nil)
+		tx.SetBlock(honeyPos.Side(cube.FaceUp), Stone{}, nil)
+		tx.SetBlock(honeyPos.Side(cube.FaceDown), Slime{}, nil)
+		tx.SetBlock(honeyPos.Side(cube.FaceSouth), GlazedTerracotta{Colour: item.ColourRed()}, nil)
+		tx.SetBlock(pistonPos.Side(cube.FaceWest), RedstoneBlock{}, nil)
+	})
+	pistonTestAdvance(w)
+
+	runWorld(w, func(tx *world.Tx) {
+		moved := honeyPos.Side(cube.FaceEast)
+		if got := tx.Block(moved); got != (HoneyBlock{}) {
+			t.Fatalf("block pushed by piston = %T, want HoneyBlock", got)
+		}
+		if got := tx.Block(moved.Side(cube.FaceUp)); got != (Stone{}) {
+			t.Errorf("block on top of pushed honey block = %T, want Stone dragged along", got)
+		}
+		// Honey blocks do not stick to slime blocks or glazed terracotta.
+		if got := tx.Block(honeyPos.Side(cube.FaceDown)); got != (Slime{}) {
+			t.Errorf("slime block below pushed honey block = %T, want Slime left behind", got)
+		}
+		if _, ok := tx.Block(honeyPos.Side(cube.FaceSouth)).(GlazedTerracotta); !ok {
+			t.Errorf("glazed terracotta next to pushed honey block = %T, want GlazedTerracotta left behind", tx.Block(honeyPos.Side(cube.FaceSouth)))
+		}
+	})
+}
+
+func TestPistonPushReactions(t *testing.T) {
+	t.Run("registered immovable", func(t *testing.T) {
+		RegisterPistonPushReaction("minecraft:dirt", PistonPushBlock)
+		t.Cleanup(func() { RegisterPistonPushReaction("minecraft:dirt", PistonPushDefault) })
+
+		w := world.Config{Synchronous: true}.New()
+		defer w.Close()
+		pistonPos := cube.Pos{0, 64, 0}
+		runWorld(w, func(tx *world.Tx) {
+			tx.SetBlock(pistonPos, Piston{Facing: cube.FaceEast}, nil)
+			tx.SetBlock(pistonPos.Side(cube.FaceEast), Stone{}, nil)
+			tx.SetBlock(pistonPos.Add(cube.Pos{2}), Dirt{}, nil)
+			tx.SetBlock(pistonPos.Side(cube.FaceWest), RedstoneBlock{}, nil)
+		})
+		pistonTestAdvance(w)
+		runWorld(w, func(tx *world.Tx) {
+			if p := tx.Block(pistonPos).(Piston); p.State != PistonRetracted {
+				t.Errorf("piston state with a registered immovable block in the way = %d, want retracted", p.State)
+			}
+			if got := tx.Block(pistonPos.Add(cube.Pos{2})); got != (Dirt{}) {
+				t.Errorf("registered immovable block after pushing = %T, want Dirt", got)
+			}
+		})
+	})
+	t.Run("fragile block drops", func(t *testing.T) {
+		w := world.Config{Synchronous: true, Entities: redstoneBreakDropTestEntityRegistry()}.New()
+		defer w.Close()
+		pistonPos := cube.Pos{0, 64, 0}
+		torchPos := pistonPos.Add(cube.Pos{2})
+		runWorld(w, func(tx *world.Tx) {
+			tx.SetBlock(torchPos.Side(cube.FaceDown), Stone{}, nil)
+			tx.SetBlock(torchPos, Torch{Facing: cube.FaceDown}, nil)
+			tx.SetBlock(pistonPos, Piston{Facing: cube.FaceEast}, nil)
+			tx.SetBlock(pistonPos.Side(cube.FaceEast), Stone{}, nil)
+			tx.SetBlock(pistonPos.Side(cube.FaceWest), RedstoneBlock{}, nil)
+		})
+		pistonTestAdvance(w)
+		runWorld(w, func(tx *world.Tx) {
+			if got := tx.Block(torchPos); got != (Stone{}) {
+				t.Errorf("block pushed into a torch = %T, want Stone", got)
+			}
+			var drops int
+			for range tx.Entities() {
+				drops++
+			}
+			if drops != 1 {
+				t.Errorf("%d items dropped by a torch broken by a piston, want 1", drops)
+			}
+		})
+	})
+	t.Run("block entity moves with data", func(t *testing.T) {
+		w := world.Config{Synchronous: true}.New()
+		defer w.Close()
+		pistonPos := cube.Pos{0, 64, 0}
+		chestPos := pistonPos.Side(cube.FaceEast)
+		runWorld(w, func(tx *world.Tx) {
+			chest := NewChest()
+			_ = chest.Inventory(tx, chestPos).SetItem(3, item.NewStack(Stone{}, 17))
+			tx.SetBlock(chestPos, chest, nil)
+			tx.SetBlock(pistonPos, Piston{Facing: cube.FaceEast}, nil)
+			tx.SetBlock(pistonPos.Side(cube.FaceWest), RedstoneBlock{}, nil)
+		})
+		pistonTestAdvance(w)
+		runWorld(w, func(tx *world.Tx) {
+			chest, ok := tx.Block(chestPos.Side(cube.FaceEast)).(Chest)
+			if !ok {
+				t.Fatalf("block pushed by piston = %T, want Chest", tx.Block(chestPos.Side(cube.FaceEast)))
+			}
+			if it, _ := chest.Inventory(tx, chestPos.Side(cube.FaceEast)).Item(3); it.Count() != 17 {
+				t.Errorf("item in pushed chest = %v, want 17 stone", it)
+			}
+		})
+	})
+}
+
+// pistonTestRow returns a row of n blocks b.
+func pistonTestRow(b world.Block, n int) []world.Block {
+	row := make([]world.Block, n)
+	for i := range row {
+		row[i] = b
+	}
+	return row
+}
+
+// pistonTestAdvance advances the world by enough ticks for a piston to finish moving.
+func pistonTestAdvance(w *world.World) {
+	for range 8 {
+		w.AdvanceTick()
+	}
+}
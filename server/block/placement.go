@@ -0,0 +1,50 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Supported represents a block that must be supported by one of its
+// neighbours to exist, such as a torch attached to a wall or a carpet lying
+// on the ground. Supported blocks cannot be placed where they would float and
+// break when the block supporting them is removed.
+type Supported interface {
+	// SupportedAt checks if the block is supported by its neighbours when
+	// placed at the position passed.
+	SupportedAt(pos cube.Pos, tx *world.Tx) bool
+}
+
+// PlaceableAt checks if the block passed may be placed at the position passed.
+// False is returned if the position is out of bounds or if b implements
+// Supported and would not be supported at the position. If ctx is non-nil and
+// has IgnoreSupport set, the support of the block is not checked. PlaceableAt
+// does not check if the block currently at the position may be replaced.
+func PlaceableAt(tx *world.Tx, pos cube.Pos, b world.Block, ctx *item.UseContext) bool {
+	if pos.OutOfBounds(tx.Range()) {
+		return false
+	}
+	if ctx != nil && ctx.IgnoreSupport {
+		return true
+	}
+	if s, ok := b.(Supported); ok {
+		return s.SupportedAt(pos, tx)
+	}
+	return true
+}
+
+// faceSupports checks if the block at pos has a solid face facing towards the
+// face of pos passed, allowing a block at pos.Side(face.Opposite()) to be
+// attached to it.
+func faceSupports(pos cube.Pos, face cube.Face, tx *world.Tx) bool {
+	support := pos.Side(face)
+	return tx.Block(support).Model().FaceSolid(support, face.Opposite(), tx)
+}
+
+// groundSupports checks if the block below pos is not air, so that blocks
+// lying on the ground, such as carpets, may rest on it.
+func groundSupports(pos cube.Pos, tx *world.Tx) bool {
+	_, air := tx.Block(pos.Side(cube.FaceDown)).(Air)
+	return !air
+}
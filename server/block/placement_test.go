@@ -0,0 +1,143 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestTorchCannotBePlacedInMidAir(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		user := placementTestPlacer{tx: tx}
+		torch := Torch{Type: NormalFire()}
+		if torch.UseOnBlock(pos, cube.FaceUp, mgl64.Vec3{}, tx, user, &item.UseContext{}) {
+			t.Fatal("torch was placed in mid-air")
+		}
+		if b := tx.Block(pos); b != (Air{}) {
+			t.Fatalf("block after placing torch in mid-air = %v, want air", b)
+		}
+
+		// Even with a facing set explicitly, the torch should not be placed
+		// without a block supporting it, unless support is ignored.
+		torch.Facing = cube.FaceDown
+		place(tx, pos, torch, user, &item.UseContext{})
+		if b := tx.Block(pos); b != (Air{}) {
+			t.Fatalf("block after placing unsupported torch = %v, want air", b)
+		}
+		place(tx, pos, torch, user, &item.UseContext{IgnoreSupport: true})
+		if b := tx.Block(pos); b != torch {
+			t.Fatalf("block after placing torch ignoring support = %v, want %v", b, torch)
+		}
+
+		tx.SetBlock(pos, nil, nil)
+		tx.SetBlock(pos.Side(cube.FaceDown), Stone{}, nil)
+		if !(Torch{Type: NormalFire()}).UseOnBlock(pos.Side(cube.FaceDown), cube.FaceUp, mgl64.Vec3{}, tx, user, &item.UseContext{}) {
+			t.Fatal("torch could not be placed on top of stone")
+		}
+		if b := tx.Block(pos); b != torch {
+			t.Fatalf("block after placing torch on stone = %v, want %v", b, torch)
+		}
+	})
+}
+
+func TestCarpetCannotBePlacedInMidAir(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pos, Stone{}, nil)
+		user := placementTestPlacer{tx: tx}
+		if (Carpet{}).UseOnBlock(pos, cube.FaceEast, mgl64.Vec3{}, tx, user, &item.UseContext{}) {
+			t.Fatal("carpet was placed against the side of a block without ground below it")
+		}
+		if !(Carpet{}).UseOnBlock(pos, cube.FaceUp, mgl64.Vec3{}, tx, user, &item.UseContext{}) {
+			t.Fatal("carpet could not be placed on top of stone")
+		}
+	})
+}
+
+func TestStairsOrientByClickPosition(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	tests := []struct {
+		name       string
+		face       cube.Face
+		clickPos   mgl64.Vec3
+		rot        cube.Rotation
+		upsideDown bool
+		facing     cube.Direction
+	}{
+		{name: "top face", face: cube.FaceUp, clickPos: mgl64.Vec3{0.5, 1, 0.5}, rot: cube.Rotation{0, 0}, facing: cube.South},
+		{name: "bottom face", face: cube.FaceDown, clickPos: mgl64.Vec3{0.5, 0, 0.5}, rot: cube.Rotation{90, 0}, upsideDown: true, facing: cube.West},
+		{name: "lower half of side", face: cube.FaceEast, clickPos: mgl64.Vec3{1, 0.25, 0.5}, rot: cube.Rotation{180, 0}, facing: cube.North},
+		{name: "upper half of side", face: cube.FaceEast, clickPos: mgl64.Vec3{1, 0.75, 0.5}, rot: cube.Rotation{-90, 0}, upsideDown: true, facing: cube.East},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runWorld(w, func(tx *world.Tx) {
+				tx.SetBlock(pos, Stone{}, nil)
+				placed := pos.Side(test.face)
+				defer tx.SetBlock(placed, nil, nil)
+
+				user := placementTestPlacer{tx: tx, rot: test.rot}
+				if !(Stairs{Block: Planks{}}).UseOnBlock(pos, test.face, test.clickPos, tx, user, &item.UseContext{}) {
+					t.Fatal("stairs could not be placed")
+				}
+				s, ok := tx.Block(placed).(Stairs)
+				if !ok {
+					t.Fatalf("block placed = %v, want stairs", tx.Block(placed))
+				}
+				if s.UpsideDown != test.upsideDown || s.Facing != test.facing {
+					t.Errorf("stairs placed facing %v (upside down: %v), want facing %v (upside down: %v)", s.Facing, s.UpsideDown, test.facing, test.upsideDown)
+				}
+			})
+		})
+	}
+}
+
+func TestPlacingIntoWaterReplacesWater(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pos.Side(cube.FaceDown), Stone{}, nil)
+		tx.SetBlock(pos, Water{Still: true, Depth: 8}, nil)
+
+		user := placementTestPlacer{tx: tx}
+		if !(Log{Wood: OakWood()}).UseOnBlock(pos.Side(cube.FaceDown), cube.FaceUp, mgl64.Vec3{}, tx, user, &item.UseContext{}) {
+			t.Fatal("log could not be placed into water")
+		}
+		if b, want := tx.Block(pos), (Log{Wood: OakWood(), Axis: cube.Y}); b != want {
+			t.Fatalf("block placed into water = %v, want %v", b, want)
+		}
+		if _, ok := tx.Liquid(pos); ok {
+			t.Fatal("water remained at the position of the placed log")
+		}
+	})
+}
+
+// placementTestPlacer is a Placer that places blocks directly in the world and
+// faces the rotation set.
+type placementTestPlacer struct {
+	item.User
+	tx  *world.Tx
+	rot cube.Rotation
+}
+
+func (p placementTestPlacer) Rotation() cube.Rotation { return p.rot }
+
+func (p placementTestPlacer) PlaceBlock(pos cube.Pos, b world.Block, ctx *item.UseContext) {
+	p.tx.SetBlock(pos, b, nil)
+	ctx.CountSub = 1
+}
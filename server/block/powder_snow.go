@@ -0,0 +1,95 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// PowderSnow is a non-solid block that entities sink into. Entities inside powder snow freeze over time,
+// unless they are wearing leather armour. Entities wearing leather boots can climb powder snow. Powder snow
+// is obtained and placed using a powder snow bucket.
+type PowderSnow struct {
+	empty
+	transparent
+}
+
+// PowderSnow is implemented because the item package needs to identify this block but cannot implement the
+// block package.
+func (PowderSnow) PowderSnow() {}
+
+// EntityInside slows down entities inside the powder snow, resets their fall distance and freezes them.
+// Entities wearing leather boots are not slowed down and can climb the powder snow instead. Burning entities are
+// extinguished, melting the powder snow.
+func (p PowderSnow) EntityInside(pos cube.Pos, tx *world.Tx, e world.Entity) {
+	if fallEntity, ok := e.(fallDistanceEntity); ok {
+		fallEntity.ResetFallDistance()
+	}
+	if flammable, ok := e.(flammableEntity); ok && flammable.OnFireDuration() > 0 {
+		flammable.Extinguish()
+		breakBlockNoDrops(p, pos, tx)
+		return
+	}
+	if f, ok := e.(powderSnowFreezer); ok {
+		f.EnterPowderSnow()
+	}
+	if v, ok := e.(velocityEntity); ok {
+		// Only update the velocity if it changes, because setting the velocity of a player sends it to the
+		// client every time.
+		if vel := powderSnowVelocity(v.Velocity(), wearsLeatherBoots(e)); !vel.ApproxEqualThreshold(v.Velocity(), 1e-4) {
+			v.SetVelocity(vel)
+		}
+	}
+}
+
+// powderSnowVelocity returns the velocity of an entity inside powder snow. Entities wearing leather boots
+// climb the powder snow: They keep their velocity, but fall no faster than a climbing entity. Other entities
+// cannot move up and slowly sink into the powder snow.
+func powderSnowVelocity(vel mgl64.Vec3, leatherBoots bool) mgl64.Vec3 {
+	if leatherBoots {
+		vel[1] = max(vel[1], -0.15)
+		return vel
+	}
+	vel[0] *= 0.9
+	vel[1] = max(min(vel[1], 0), -0.12)
+	vel[2] *= 0.9
+	return vel
+}
+
+// wearsLeatherBoots checks if the entity passed is wearing leather boots.
+func wearsLeatherBoots(e world.Entity) bool {
+	a, ok := e.(armouredEntity)
+	if !ok {
+		return false
+	}
+	boots, ok := a.Armour().Boots().Item().(item.Boots)
+	if !ok {
+		return false
+	}
+	_, leather := boots.Tier.(item.ArmourTierLeather)
+	return leather
+}
+
+// BreakInfo ...
+func (p PowderSnow) BreakInfo() BreakInfo {
+	return newBreakInfo(0.25, alwaysHarvestable, shovelEffective, simpleDrops())
+}
+
+// EncodeBlock ...
+func (PowderSnow) EncodeBlock() (string, map[string]any) {
+	return "minecraft:powder_snow", nil
+}
+
+// powderSnowFreezer represents an entity that freezes while inside powder snow.
+type powderSnowFreezer interface {
+	// EnterPowderSnow marks the entity as being inside powder snow.
+	EnterPowderSnow()
+}
+
+// armouredEntity represents an entity that wears armour.
+type armouredEntity interface {
+	// Armour returns the armour inventory of the entity.
+	Armour() *inventory.Armour
+}
@@ -52,6 +52,11 @@ func (r RedstoneOre) RandomTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
 	r.fade(pos, tx)
 }
 
+// WantsRandomTick ...
+func (r RedstoneOre) WantsRandomTick() bool {
+	return r.Lit
+}
+
 // light emits particles and switches the ore to its lit state if it is not already lit.
 func (r RedstoneOre) light(pos cube.Pos, tx *world.Tx) {
 	r.emitParticles(pos, tx)
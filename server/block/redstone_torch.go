@@ -77,10 +77,14 @@ func (t RedstoneTorch) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx
 	return ok
 }
 
+// SupportedAt ...
+func (t RedstoneTorch) SupportedAt(pos cube.Pos, tx *world.Tx) bool {
+	return faceSupports(pos, t.facing(), tx)
+}
+
 // NeighbourUpdateTick breaks unsupported torches and otherwise schedules inverse-state refreshes.
 func (t RedstoneTorch) NeighbourUpdateTick(pos, changed cube.Pos, tx *world.Tx) {
-	facing := t.facing()
-	if !tx.Block(pos.Side(facing)).Model().FaceSolid(pos.Side(facing), facing.Opposite(), tx) {
+	if !t.SupportedAt(pos, tx) {
 		tx.Redstone().Torch(pos).ClearBurnout()
 		breakBlock(t, pos, tx)
 		return
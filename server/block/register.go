@@ -1,6 +1,8 @@
 package block
 
 import (
+	"slices"
+
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
@@ -30,6 +32,8 @@ func init() {
 	world.RegisterBlock(Cobblestone{Mossy: true})
 	world.RegisterBlock(Cobblestone{})
 	world.RegisterBlock(Cobweb{})
+	world.RegisterBlock(Conduit{})
+	world.RegisterBlock(CartographyTable{})
 	world.RegisterBlock(CraftingTable{})
 	world.RegisterBlock(DeadBush{})
 	world.RegisterBlock(DeepslateBricks{Cracked: true})
@@ -62,7 +66,9 @@ func init() {
 	world.RegisterBlock(Granite{})
 	world.RegisterBlock(Grass{})
 	world.RegisterBlock(Gravel{})
+	world.RegisterBlock(HoneyBlock{})
 	world.RegisterBlock(Honeycomb{})
+	world.RegisterBlock(Ice{})
 	world.RegisterBlock(InfestedStone{})
 	world.RegisterBlock(InfestedCobblestone{})
 	for _, b := range allInfestedStoneBricks() {
@@ -79,6 +85,7 @@ func init() {
 	world.RegisterBlock(LilyPad{})
 	world.RegisterBlock(Magma{})
 	world.RegisterBlock(Melon{})
+	world.RegisterBlock(MonsterSpawner{})
 	world.RegisterBlock(MossCarpet{})
 	world.RegisterBlock(MudBricks{})
 	world.RegisterBlock(Mud{})
@@ -100,6 +107,7 @@ func init() {
 	world.RegisterBlock(PolishedBlackstoneBrick{})
 	world.RegisterBlock(Portal{Axis: cube.X})
 	world.RegisterBlock(Portal{Axis: cube.Z})
+	world.RegisterBlock(PowderSnow{})
 	world.RegisterBlock(QuartzBricks{})
 	world.RegisterBlock(RawCopper{})
 	world.RegisterBlock(RawGold{})
@@ -114,6 +122,7 @@ func init() {
 	world.RegisterBlock(SeaLantern{})
 	world.RegisterBlock(Shroomlight{})
 	world.RegisterBlock(Slime{})
+	world.RegisterBlock(Moving{})
 	world.RegisterBlock(SmithingTable{})
 	world.RegisterBlock(SmoothBasalt{})
 	world.RegisterBlock(Snow{})
@@ -165,9 +174,13 @@ func init() {
 	registerAll(allCandles())
 	registerAll(allCarpet())
 	registerAll(allCarrots())
+	registerAll(allCauldrons())
 	registerAll(allIronChains())
+	registerAll(allIronDoors())
+	registerAll(allIronTrapdoors())
 	registerAll(allChests())
 	registerAll(allCocoaBeans())
+	registerAll(allCommandBlocks())
 	registerAll(allComposters())
 	registerAll(allConcrete())
 	registerAll(allConcretePowder())
@@ -185,6 +198,7 @@ func init() {
 	registerAll(allFire())
 	registerAll(allFlowers())
 	registerAll(allFroglight())
+	registerAll(allFrostedIce())
 	registerAll(allFurnaces())
 	registerAll(allGlazedTerracotta())
 	registerAll(allGrindstones())
@@ -206,7 +220,11 @@ func init() {
 	registerAll(allMuddyMangroveRoots())
 	registerAll(allNetherBricks())
 	registerAll(allNetherWart())
+	registerAll(allObservers())
+	registerAll(allSculkSensors())
 	registerAll(allPinkPetals())
+	registerAll(allPistonArmCollisions())
+	registerAll(allPistons())
 	registerAll(allPlanks())
 	registerAll(allPotato())
 	registerAll(allPrismarine())
@@ -216,12 +234,15 @@ func init() {
 	registerAll(allQuartz())
 	registerAll(allRedstoneTorches())
 	registerAll(allRedstoneWires())
+	registerAll(allRespawnAnchors())
 	registerAll(allSandstones())
+	registerAll(allSaplings())
 	registerAll(allSeaPickles())
 	registerAll(allSigns())
 	registerAll(allSkulls())
 	registerAll(allSlabs())
 	registerAll(allSmokers())
+	registerAll(allSnowLayers())
 	registerAll(allStainedGlass())
 	registerAll(allStainedGlassPane())
 	registerAll(allStainedTerracotta())
@@ -276,6 +297,7 @@ func init() {
 	world.RegisterItem(Bricks{})
 	world.RegisterItem(Cactus{})
 	world.RegisterItem(Cake{})
+	world.RegisterItem(Cauldron{})
 	world.RegisterItem(Calcite{})
 	world.RegisterItem(Carrot{})
 	world.RegisterItem(IronChain{})
@@ -288,7 +310,9 @@ func init() {
 	world.RegisterItem(Cobweb{})
 	world.RegisterItem(CocoaBean{})
 	world.RegisterItem(Composter{})
+	world.RegisterItem(Conduit{})
 	world.RegisterItem(CopperTorch{})
+	world.RegisterItem(CartographyTable{})
 	world.RegisterItem(CraftingTable{})
 	world.RegisterItem(DeadBush{})
 	world.RegisterItem(DeepslateBricks{Cracked: true})
@@ -324,6 +348,7 @@ func init() {
 	world.RegisterItem(Gravel{})
 	world.RegisterItem(Grindstone{})
 	world.RegisterItem(HayBale{})
+	world.RegisterItem(HoneyBlock{})
 	world.RegisterItem(Honeycomb{})
 	world.RegisterItem(Hopper{})
 	world.RegisterItem(InfestedStone{})
@@ -333,7 +358,10 @@ func init() {
 	}
 	world.RegisterItem(InfestedDeepslate{})
 	world.RegisterItem(InvisibleBedrock{})
+	world.RegisterItem(Ice{})
 	world.RegisterItem(IronBars{})
+	world.RegisterItem(IronDoor{})
+	world.RegisterItem(IronTrapdoor{})
 	world.RegisterItem(Iron{})
 	world.RegisterItem(ItemFrame{Glowing: true})
 	world.RegisterItem(ItemFrame{})
@@ -349,6 +377,7 @@ func init() {
 	world.RegisterItem(Loom{})
 	world.RegisterItem(MelonSeeds{})
 	world.RegisterItem(Melon{})
+	world.RegisterItem(MonsterSpawner{})
 	world.RegisterItem(MossCarpet{})
 	world.RegisterItem(MudBricks{})
 	world.RegisterItem(MuddyMangroveRoots{})
@@ -365,9 +394,13 @@ func init() {
 	world.RegisterItem(Note{Pitch: 24})
 	world.RegisterItem(Obsidian{Crying: true})
 	world.RegisterItem(Obsidian{})
+	world.RegisterItem(Observer{})
+	world.RegisterItem(SculkSensor{})
 	world.RegisterItem(PackedIce{})
 	world.RegisterItem(PackedMud{})
 	world.RegisterItem(PinkPetals{})
+	world.RegisterItem(Piston{Sticky: true})
+	world.RegisterItem(Piston{})
 	world.RegisterItem(Podzol{})
 	world.RegisterItem(PolishedBlackstoneBrick{Cracked: true})
 	world.RegisterItem(PolishedBlackstoneBrick{})
@@ -391,6 +424,7 @@ func init() {
 	world.RegisterItem(ResinBricks{Chiseled: true})
 	world.RegisterItem(ResinBricks{})
 	world.RegisterItem(Resin{})
+	world.RegisterItem(RespawnAnchor{})
 	world.RegisterItem(Sand{Red: true})
 	world.RegisterItem(Sand{})
 	world.RegisterItem(SeaLantern{})
@@ -400,6 +434,7 @@ func init() {
 	world.RegisterItem(SmithingTable{})
 	world.RegisterItem(Smoker{})
 	world.RegisterItem(SmoothBasalt{})
+	world.RegisterItem(SnowLayer{})
 	world.RegisterItem(Snow{})
 	world.RegisterItem(SoulSand{})
 	world.RegisterItem(SoulSoil{})
@@ -426,6 +461,7 @@ func init() {
 	world.RegisterItem(item.Bucket{Content: item.LiquidBucketContent(Lava{})})
 	world.RegisterItem(item.Bucket{Content: item.LiquidBucketContent(Water{})})
 	world.RegisterItem(item.Bucket{Content: item.MilkBucketContent()})
+	world.RegisterItem(item.Bucket{Content: item.PowderSnowBucketContent()})
 
 	for _, b := range allLight() {
 		world.RegisterItem(b.(world.Item))
@@ -446,6 +482,9 @@ func init() {
 	for _, t := range AnvilTypes() {
 		world.RegisterItem(Anvil{Type: t})
 	}
+	for _, m := range CommandBlockModes() {
+		world.RegisterItem(CommandBlock{Mode: m})
+	}
 	for _, c := range item.Colours() {
 		world.RegisterItem(Banner{Colour: c})
 		world.RegisterItem(Bed{Colour: c})
@@ -469,6 +508,9 @@ func init() {
 			world.RegisterItem(Wood{Wood: w})
 		}
 		world.RegisterItem(Planks{Wood: w})
+		if saplingWood(w) {
+			world.RegisterItem(Sapling{Wood: w})
+		}
 		world.RegisterItem(Sign{Wood: w})
 		world.RegisterItem(WoodDoor{Wood: w})
 		world.RegisterItem(WoodFenceGate{Wood: w})
@@ -558,8 +600,26 @@ func init() {
 	}
 }
 
+// init registers the block tags of the blocks implemented by Dragonfly.
+func init() {
+	registerTag("minecraft:leaves", allLeaves())
+	registerTag("minecraft:logs", allLogs(), allWood())
+	registerTag("minecraft:planks", allPlanks())
+	registerTag("minecraft:wool", allWool())
+}
+
 func registerAll(blocks []world.Block) {
 	for _, b := range blocks {
 		world.RegisterBlock(b)
 	}
 }
+
+// registerTag registers a block tag with the name passed of which all blocks passed are members.
+func registerTag(name string, blocks ...[]world.Block) {
+	var names []string
+	for _, b := range slices.Concat(blocks...) {
+		n, _ := b.EncodeBlock()
+		names = append(names, n)
+	}
+	world.RegisterBlockTag(world.NewTag(name, names...))
+}
@@ -20,3 +20,8 @@ func (ReinforcedDeepslate) EncodeItem() (name string, meta int16) {
 func (ReinforcedDeepslate) EncodeBlock() (string, map[string]interface{}) {
 	return "minecraft:reinforced_deepslate", nil
 }
+
+// PistonPushReaction ...
+func (ReinforcedDeepslate) PistonPushReaction() PistonPushReaction {
+	return PistonPushBlock
+}
@@ -0,0 +1,141 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/google/uuid"
+)
+
+// RespawnAnchor is a block that allows players to set their spawn point in dimensions where respawn anchors work,
+// such as the nether. It must be charged with glowstone to be used, and loses a charge every time a player
+// respawns at it. A charged respawn anchor explodes when used in a dimension where respawn anchors do not work.
+type RespawnAnchor struct {
+	solid
+	bassDrum
+
+	// Charge is the amount of charges the respawn anchor has. It can be 0-4.
+	Charge int
+}
+
+// respawnAnchorUser represents a user that can set its spawn point using a respawn anchor.
+type respawnAnchorUser interface {
+	item.User
+	UUID() uuid.UUID
+	Messaget(t chat.Translation, a ...any)
+}
+
+// LightEmissionLevel ...
+func (r RespawnAnchor) LightEmissionLevel() uint8 {
+	return [...]uint8{0, 3, 7, 11, 15}[r.Charge]
+}
+
+// Activate ...
+func (r RespawnAnchor) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, ctx *item.UseContext) bool {
+	held, _ := u.HeldItems()
+	if _, ok := held.Item().(Glowstone); ok && r.Charge < 4 {
+		r.Charge++
+		tx.SetBlock(pos, r, nil)
+		tx.PlaySound(pos.Vec3Centre(), sound.RespawnAnchorCharge{})
+		ctx.SubtractFromCount(1)
+		return true
+	}
+	if r.Charge == 0 {
+		return false
+	}
+
+	w := tx.World()
+	if !w.Dimension().RespawnAnchorsWork() {
+		tx.SetBlock(pos, nil, nil)
+		ExplosionConfig{
+			Size:      5,
+			SpawnFire: true,
+		}.Explode(tx, pos.Vec3Centre())
+		return true
+	}
+	s, ok := u.(respawnAnchorUser)
+	if !ok {
+		return false
+	}
+	if w.PlayerSpawn(s.UUID()) != pos {
+		w.SetPlayerSpawn(s.UUID(), pos)
+		tx.PlaySound(pos.Vec3Centre(), sound.RespawnAnchorSetSpawn{})
+		s.Messaget(chat.MessageRespawnAnchorPointSet)
+	}
+	return true
+}
+
+// CanRespawnOn ...
+func (r RespawnAnchor) CanRespawnOn() bool {
+	return r.Charge > 0
+}
+
+// respawnAnchorOffsets are the offsets around a respawn anchor that are checked for a safe spawn position, in
+// order of preference.
+var respawnAnchorOffsets = func() (offsets []cube.Pos) {
+	for _, y := range []int{0, -1, 1} {
+		offsets = append(offsets, cube.Pos{-1, y, -1}, cube.Pos{0, y, -1}, cube.Pos{1, y, -1}, cube.Pos{1, y, 0}, cube.Pos{1, y, 1}, cube.Pos{0, y, 1}, cube.Pos{-1, y, 1}, cube.Pos{-1, y, 0})
+	}
+	return append(offsets, cube.Pos{0, 1, 0})
+}()
+
+// SafeSpawn returns a position around the respawn anchor with room for a player to stand on a solid block. False
+// is returned if there is no such position or if respawn anchors do not work in the dimension of the world.
+func (r RespawnAnchor) SafeSpawn(pos cube.Pos, tx *world.Tx) (cube.Pos, bool) {
+	if !tx.World().Dimension().RespawnAnchorsWork() {
+		return cube.Pos{}, false
+	}
+	for _, offset := range respawnAnchorOffsets {
+		spawnPos := pos.Add(offset)
+		_, feet := tx.Block(spawnPos).Model().(model.Empty)
+		_, head := tx.Block(spawnPos.Side(cube.FaceUp)).Model().(model.Empty)
+		below := spawnPos.Side(cube.FaceDown)
+		if feet && head && tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+			return spawnPos, true
+		}
+	}
+	return cube.Pos{}, false
+}
+
+// RespawnOn uses up one of the charges of the respawn anchor.
+func (r RespawnAnchor) RespawnOn(pos cube.Pos, tx *world.Tx) {
+	if r.Charge == 0 {
+		return
+	}
+	r.Charge--
+	tx.SetBlock(pos, r, nil)
+	tx.PlaySound(pos.Vec3Centre(), sound.RespawnAnchorDeplete{})
+}
+
+// BreakInfo ...
+func (r RespawnAnchor) BreakInfo() BreakInfo {
+	return newBreakInfo(50, func(t item.Tool) bool {
+		return t.ToolType() == item.TypePickaxe && t.HarvestLevel() >= item.ToolTierDiamond.HarvestLevel
+	}, pickaxeEffective, oneOf(RespawnAnchor{})).withBlastResistance(6000)
+}
+
+// PistonPushReaction ...
+func (RespawnAnchor) PistonPushReaction() PistonPushReaction {
+	return PistonPushBlock
+}
+
+// EncodeItem ...
+func (RespawnAnchor) EncodeItem() (name string, meta int16) {
+	return "minecraft:respawn_anchor", 0
+}
+
+// EncodeBlock ...
+func (r RespawnAnchor) EncodeBlock() (string, map[string]any) {
+	return "minecraft:respawn_anchor", map[string]any{"respawn_anchor_charge": int32(r.Charge)}
+}
+
+// allRespawnAnchors returns all possible respawn anchor states.
+func allRespawnAnchors() (anchors []world.Block) {
+	for charge := range 5 {
+		anchors = append(anchors, RespawnAnchor{Charge: charge})
+	}
+	return
+}
@@ -0,0 +1,182 @@
+package block
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Sapling is a non-solid plant that grows into a tree over time. Bone meal may be used on a sapling to speed up
+// its growth. A sapling only grows into a tree if there is enough room above it.
+type Sapling struct {
+	empty
+	transparent
+
+	// Wood is the type of wood of the tree that the sapling grows into. Saplings exist for every WoodType with
+	// leaves, other than mangrove wood.
+	Wood WoodType
+	// Ready specifies if the sapling is ready to grow into a tree the next time it grows.
+	Ready bool
+}
+
+var _ item.BoneMealAffected = Sapling{}
+
+// BoneMeal has a 45% chance to make the sapling grow. A sapling that is ready to grow into a tree, but that does
+// not have enough room to do so, is not affected by bone meal.
+func (s Sapling) BoneMeal(pos cube.Pos, tx *world.Tx) item.BoneMealResult {
+	if s.Ready && !s.treeFits(pos, s.treeHeight(), tx) {
+		return item.BoneMealResultNone
+	}
+	if rand.Float64() < 0.45 {
+		s.grow(pos, tx, rand.IntN(3))
+	}
+	return item.BoneMealResultSmall
+}
+
+// RandomTick ...
+func (s Sapling) RandomTick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
+	if tx.Light(pos) >= 9 && r.IntN(7) == 0 {
+		s.grow(pos, tx, r.IntN(3))
+	}
+}
+
+// grow makes the sapling ready to grow if it is not yet ready, or grows it into a tree with a trunk that is
+// extra blocks higher than the minimum height of the tree otherwise.
+func (s Sapling) grow(pos cube.Pos, tx *world.Tx, extra int) {
+	if !s.Ready {
+		s.Ready = true
+		tx.SetBlock(pos, s, nil)
+		return
+	}
+	height := s.treeHeight() + extra
+	if !s.treeFits(pos, height, tx) {
+		return
+	}
+	leaves, _ := s.Wood.Leaves()
+	top := pos.Add(cube.Pos{0, height})
+	for y := -3; y <= 0; y++ {
+		// The leaves form two layers with a radius of 2 below two layers with a radius of 1 at the top of the
+		// trunk. The corners of all layers but the bottom are left out randomly to round the crown.
+		r := 1 - y/2
+		if y <= -2 {
+			r = 2
+		}
+		for x := -r; x <= r; x++ {
+			for z := -r; z <= r; z++ {
+				if abs(x) == r && abs(z) == r && (y == 0 || (y > -3 && rand.IntN(2) == 0)) {
+					continue
+				}
+				leavesPos := top.Add(cube.Pos{x, y, z})
+				if _, ok := tx.Block(leavesPos).(Air); ok {
+					tx.SetBlock(leavesPos, Leaves{Type: leaves}, nil)
+				}
+			}
+		}
+	}
+	for y := range height {
+		tx.SetBlock(pos.Add(cube.Pos{0, y}), Log{Wood: s.Wood}, nil)
+	}
+}
+
+// treeHeight returns the minimum height of the trunk of the tree that the sapling grows into.
+func (s Sapling) treeHeight() int {
+	if s.Wood == BirchWood() {
+		return 5
+	}
+	return 4
+}
+
+// treeFits checks if a tree with a trunk of the height passed fits at the position of the sapling. This is the
+// case if all blocks of its trunk and crown are either air or leaves and if the top of the tree is within the
+// world.
+func (s Sapling) treeFits(pos cube.Pos, height int, tx *world.Tx) bool {
+	if pos[1]+height >= tx.Range()[1] {
+		return false
+	}
+	for y := 1; y <= height; y++ {
+		r := 0
+		if y >= height-1 {
+			r = 1
+		} else if y >= height-3 {
+			r = 2
+		}
+		for x := -r; x <= r; x++ {
+			for z := -r; z <= r; z++ {
+				switch tx.Block(pos.Add(cube.Pos{x, y, z})).(type) {
+				case Air, Leaves:
+				default:
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// NeighbourUpdateTick ...
+func (s Sapling) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !supportsVegetation(s, tx.Block(pos.Side(cube.FaceDown))) {
+		breakBlock(s, pos, tx)
+	}
+}
+
+// UseOnBlock ...
+func (s Sapling) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, s)
+	if !used || !supportsVegetation(s, tx.Block(pos.Side(cube.FaceDown))) {
+		return false
+	}
+
+	place(tx, pos, s, user, ctx)
+	return placed(ctx)
+}
+
+// HasLiquidDrops ...
+func (Sapling) HasLiquidDrops() bool {
+	return true
+}
+
+// BreakInfo ...
+func (s Sapling) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(Sapling{Wood: s.Wood}))
+}
+
+// FuelInfo ...
+func (Sapling) FuelInfo() item.FuelInfo {
+	return newFuelInfo(time.Second * 5)
+}
+
+// CompostChance ...
+func (Sapling) CompostChance() float64 {
+	return 0.3
+}
+
+// EncodeItem ...
+func (s Sapling) EncodeItem() (name string, meta int16) {
+	return "minecraft:" + s.Wood.String() + "_sapling", 0
+}
+
+// EncodeBlock ...
+func (s Sapling) EncodeBlock() (string, map[string]any) {
+	return "minecraft:" + s.Wood.String() + "_sapling", map[string]any{"age_bit": boolByte(s.Ready)}
+}
+
+// saplingWood checks if a Sapling exists for the WoodType passed.
+func saplingWood(w WoodType) bool {
+	_, ok := w.Leaves()
+	return ok && w != MangroveWood()
+}
+
+// allSaplings returns a list of all possible sapling states.
+func allSaplings() (saplings []world.Block) {
+	for _, w := range WoodTypes() {
+		if saplingWood(w) {
+			saplings = append(saplings, Sapling{Wood: w}, Sapling{Wood: w, Ready: true})
+		}
+	}
+	return
+}
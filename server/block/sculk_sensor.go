@@ -0,0 +1,175 @@
+package block
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// SculkSensor is a block that detects vibrations emitted nearby, such as those of entities stepping on blocks or
+// blocks being placed, and converts them into a redstone pulse. Vibrations do not pass through wool.
+type SculkSensor struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Phase is the phase of the sculk sensor. It is one of SculkSensorInactive, SculkSensorActive and
+	// SculkSensorCooldown.
+	Phase int
+	// Power is the redstone power emitted by the sculk sensor while it is active. The closer the vibration
+	// detected, the higher the power.
+	Power int
+	// LastFrequency is the frequency of the last vibration detected by the sculk sensor.
+	LastFrequency int
+}
+
+const (
+	// SculkSensorInactive is the Phase of a sculk sensor that is listening to vibrations.
+	SculkSensorInactive = iota
+	// SculkSensorActive is the Phase of a sculk sensor that detected a vibration and is emitting redstone power.
+	SculkSensorActive
+	// SculkSensorCooldown is the Phase of a sculk sensor that stopped emitting redstone power, but does not yet
+	// listen to vibrations again.
+	SculkSensorCooldown
+)
+
+const (
+	// sculkSensorRange is the radius in blocks around a sculk sensor within which it detects vibrations.
+	sculkSensorRange = 8
+	// sculkSensorActiveTime is the time that a sculk sensor emits redstone power for after detecting a vibration.
+	sculkSensorActiveTime = time.Second * 3 / 2
+	// sculkSensorCooldownTime is the time that a sculk sensor does not listen to vibrations for after it stopped
+	// emitting redstone power.
+	sculkSensorCooldownTime = time.Second / 2
+)
+
+// Model ...
+func (SculkSensor) Model() world.BlockModel {
+	return model.Slab{}
+}
+
+// UseOnBlock ...
+func (s SculkSensor) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, s)
+	if !used {
+		return false
+	}
+	place(tx, pos, SculkSensor{}, user, ctx)
+	return placed(ctx)
+}
+
+// VibrationRange ...
+func (SculkSensor) VibrationRange() float64 {
+	return sculkSensorRange
+}
+
+// ListenVibration activates the sculk sensor if it is inactive and the vibration passed is not occluded by wool
+// between the sculk sensor and the position of the vibration.
+func (s SculkSensor) ListenVibration(pos cube.Pos, tx *world.Tx, v world.Vibration) bool {
+	if s.Phase != SculkSensorInactive || cube.PosFromVec3(v.Pos) == pos || vibrationOccluded(v.Pos, pos, tx) {
+		return false
+	}
+	dist := pos.Vec3Centre().Sub(v.Pos).Len()
+	s.Phase, s.LastFrequency = SculkSensorActive, v.Frequency
+	s.Power = max(1, 15-int(math.Floor(dist*15/sculkSensorRange)))
+	tx.SetBlock(pos, s, nil)
+	tx.ScheduleBlockUpdate(pos, s, sculkSensorActiveTime)
+	tx.PlaySound(pos.Vec3Centre(), sound.SculkSensorPowerOn{})
+	return true
+}
+
+// vibrationOccluded checks if wool between the position the vibration was emitted at and the position of the
+// listener blocks the vibration.
+func vibrationOccluded(from mgl64.Vec3, listener cube.Pos, tx *world.Tx) bool {
+	to := listener.Vec3Centre()
+	if mgl64.FloatEqual(to.Sub(from).LenSqr(), 0) {
+		return false
+	}
+	occluded := false
+	trace.TraverseBlocks(from, to, func(pos cube.Pos) bool {
+		if pos == listener {
+			return true
+		}
+		_, occluded = tx.Block(pos).(Wool)
+		return !occluded
+	})
+	return occluded
+}
+
+// ScheduledTick ends the redstone pulse of an active sculk sensor or ends the cooldown of a sculk sensor after
+// its pulse.
+func (s SculkSensor) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	switch s.Phase {
+	case SculkSensorActive:
+		s.Phase, s.Power = SculkSensorCooldown, 0
+		tx.SetBlock(pos, s, nil)
+		tx.ScheduleBlockUpdate(pos, s, sculkSensorCooldownTime)
+		tx.PlaySound(pos.Vec3Centre(), sound.SculkSensorPowerOff{})
+	case SculkSensorCooldown:
+		s.Phase = SculkSensorInactive
+		tx.SetBlock(pos, s, nil)
+	}
+}
+
+// RedstonePower returns the power of the sculk sensor to all sides while it is active.
+func (s SculkSensor) RedstonePower(cube.Pos, *world.Tx, cube.Face) int {
+	if s.Phase != SculkSensorActive {
+		return 0
+	}
+	return s.Power
+}
+
+// RedstoneStrongPower strongly powers the block below the sculk sensor while it is active.
+func (s SculkSensor) RedstoneStrongPower(pos cube.Pos, tx *world.Tx, face cube.Face) int {
+	if face != cube.FaceDown {
+		return 0
+	}
+	return s.RedstonePower(pos, tx, face)
+}
+
+// BreakInfo ...
+func (s SculkSensor) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, alwaysHarvestable, hoeEffective, oneOf(SculkSensor{})).withXPDropRange(5, 5)
+}
+
+// EncodeItem ...
+func (SculkSensor) EncodeItem() (name string, meta int16) {
+	return "minecraft:sculk_sensor", 0
+}
+
+// EncodeBlock ...
+func (s SculkSensor) EncodeBlock() (string, map[string]any) {
+	return "minecraft:sculk_sensor", map[string]any{"sculk_sensor_phase": int32(s.Phase)}
+}
+
+// DecodeNBT ...
+func (s SculkSensor) DecodeNBT(data map[string]any) any {
+	s.Power = int(nbtconv.Int32(data, "Power"))
+	s.LastFrequency = int(nbtconv.Int32(data, "LastFrequency"))
+	return s
+}
+
+// EncodeNBT ...
+func (s SculkSensor) EncodeNBT() map[string]any {
+	return map[string]any{
+		"id":            "SculkSensor",
+		"Power":         int32(s.Power),
+		"LastFrequency": int32(s.LastFrequency),
+	}
+}
+
+// allSculkSensors ...
+func allSculkSensors() (b []world.Block) {
+	for phase := SculkSensorInactive; phase <= SculkSensorCooldown; phase++ {
+		b = append(b, SculkSensor{Phase: phase})
+	}
+	return
+}
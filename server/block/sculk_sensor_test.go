@@ -0,0 +1,113 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestSculkSensorDetectsVibrations(t *testing.T) {
+	sensorPos := cube.Pos{0, 64, 0}
+	tests := []struct {
+		name     string
+		pos      mgl64.Vec3
+		occluder bool
+		detected bool
+	}{
+		{name: "nearby", pos: mgl64.Vec3{3.5, 64.5, 0.5}, detected: true},
+		{name: "out of range", pos: mgl64.Vec3{9.5, 64.5, 0.5}},
+		{name: "occluded by wool", pos: mgl64.Vec3{3.5, 64.5, 0.5}, occluder: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := world.Config{Synchronous: true}.New()
+			defer w.Close()
+
+			runWorld(w, func(tx *world.Tx) {
+				tx.SetBlock(sensorPos, SculkSensor{}, nil)
+				if test.occluder {
+					tx.SetBlock(cube.Pos{2, 64, 0}, Wool{}, nil)
+				}
+				tx.EmitVibration(world.Vibration{Pos: test.pos, Frequency: world.VibrationBlockPlace})
+
+				s := tx.Block(sensorPos).(SculkSensor)
+				if detected := s.Phase == SculkSensorActive; detected != test.detected {
+					t.Fatalf("sculk sensor detected vibration: %t, want %t", detected, test.detected)
+				}
+				if !test.detected {
+					return
+				}
+				if s.LastFrequency != world.VibrationBlockPlace {
+					t.Errorf("sculk sensor frequency = %d, want %d", s.LastFrequency, world.VibrationBlockPlace)
+				}
+				if s.Power != 10 {
+					t.Errorf("sculk sensor power = %d, want 10", s.Power)
+				}
+			})
+		})
+	}
+}
+
+func TestSculkSensorNearestDetectsVibration(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	near, far := cube.Pos{2, 64, 0}, cube.Pos{-4, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(near, SculkSensor{}, nil)
+		tx.SetBlock(far, SculkSensor{}, nil)
+		tx.EmitVibration(world.Vibration{Pos: mgl64.Vec3{0.5, 64.5, 0.5}, Frequency: world.VibrationStep})
+
+		if s := tx.Block(near).(SculkSensor); s.Phase != SculkSensorActive {
+			t.Errorf("nearest sculk sensor phase = %d, want active", s.Phase)
+		}
+		if s := tx.Block(far).(SculkSensor); s.Phase != SculkSensorInactive {
+			t.Errorf("farther sculk sensor phase = %d, want inactive", s.Phase)
+		}
+	})
+}
+
+func TestSculkSensorCooldown(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	vibrate := func(tx *world.Tx, frequency int) {
+		tx.EmitVibration(world.Vibration{Pos: mgl64.Vec3{2.5, 64.5, 0.5}, Frequency: frequency})
+	}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pos, SculkSensor{}, nil)
+		vibrate(tx, world.VibrationBlockPlace)
+		vibrate(tx, world.VibrationBlockBreak)
+		if s := tx.Block(pos).(SculkSensor); s.LastFrequency != world.VibrationBlockPlace {
+			t.Errorf("active sculk sensor frequency = %d, want %d", s.LastFrequency, world.VibrationBlockPlace)
+		}
+	})
+	for range 30 {
+		w.AdvanceTick()
+	}
+	runWorld(w, func(tx *world.Tx) {
+		s := tx.Block(pos).(SculkSensor)
+		if s.Phase != SculkSensorCooldown || s.RedstonePower(pos, tx, cube.FaceUp) != 0 {
+			t.Errorf("sculk sensor phase = %d, power = %d, want cooldown without power", s.Phase, s.RedstonePower(pos, tx, cube.FaceUp))
+		}
+		vibrate(tx, world.VibrationBlockBreak)
+		if s := tx.Block(pos).(SculkSensor); s.Phase != SculkSensorCooldown {
+			t.Errorf("sculk sensor on cooldown phase = %d after vibration, want cooldown", s.Phase)
+		}
+	})
+	for range 10 {
+		w.AdvanceTick()
+	}
+	runWorld(w, func(tx *world.Tx) {
+		if s := tx.Block(pos).(SculkSensor); s.Phase != SculkSensorInactive {
+			t.Fatalf("sculk sensor phase = %d after cooldown, want inactive", s.Phase)
+		}
+		vibrate(tx, world.VibrationBlockBreak)
+		if s := tx.Block(pos).(SculkSensor); s.Phase != SculkSensorActive || s.LastFrequency != world.VibrationBlockBreak {
+			t.Errorf("sculk sensor phase = %d, frequency = %d after cooldown, want active with frequency %d", s.Phase, s.LastFrequency, world.VibrationBlockBreak)
+		}
+	})
+}
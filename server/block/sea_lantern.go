@@ -22,6 +22,11 @@ func (s SeaLantern) BreakInfo() BreakInfo {
 	return newBreakInfo(0.3, alwaysHarvestable, nothingEffective, silkTouchDrop(item.NewStack(item.PrismarineCrystals{}, rand.IntN(2)+2), item.NewStack(s, 1)))
 }
 
+// ConduitFrame ...
+func (SeaLantern) ConduitFrame() bool {
+	return true
+}
+
 // EncodeItem ...
 func (SeaLantern) EncodeItem() (name string, meta int16) {
 	return "minecraft:sea_lantern", 0
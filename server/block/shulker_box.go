@@ -219,7 +219,7 @@ func (s ShulkerBox) push(pos cube.Pos, tx *world.Tx, e world.Entity) {
 		return
 	}
 	shulkerBBox := shulkerBBoxes[0].Translate(pos.Vec3())
-	entityBBox := e.H().Type().BBox(e).Translate(e.Position())
+	entityBBox := world.EntityBBox(e).Translate(e.Position())
 	if !shulkerBBox.IntersectsWith(entityBBox) {
 		return
 	}
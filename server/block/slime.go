@@ -3,6 +3,7 @@ package block
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
 )
 
 // Slime is a storage block equivalent to nine slimeballs. It has both sticky and bouncy properties,
@@ -21,14 +22,25 @@ func (Slime) EntityLand(_ cube.Pos, _ *world.Tx, e world.Entity, distance *float
 		return
 	}
 	if v, ok := e.(velocityEntity); ok {
-		vel := v.Velocity()
-		if vel[1] < 0 {
-			vel[1] = -vel[1]
-			v.SetVelocity(vel)
-		}
+		v.SetVelocity(Slime{}.Bounce(v.Velocity()))
 	}
 }
 
+// Bounce returns the velocity of an entity that lands on the slime block with the velocity passed. Downward
+// velocity is reflected, so that the entity bounces back up.
+func (Slime) Bounce(vel mgl64.Vec3) mgl64.Vec3 {
+	if vel[1] < 0 {
+		vel[1] = -vel[1]
+	}
+	return vel
+}
+
+// PistonSticksTo ...
+func (Slime) PistonSticksTo(b world.Block) bool {
+	_, honey := b.(HoneyBlock)
+	return !honey && pistonSticky(b)
+}
+
 // Friction ...
 func (Slime) Friction() float64 {
 	return 0.8
@@ -4,6 +4,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/item/recipe"
 	"github.com/df-mc/dragonfly/server/world"
 	"math"
 	"math/rand/v2"
@@ -174,8 +175,9 @@ func (s *smelter) setDurations(remaining, max, cook time.Duration) {
 }
 
 // tickSmelting ticks the smelter, ensuring the necessary items exist in the furnace, and then processing all inputted
-// items for the necessary duration.
-func (s *smelter) tickSmelting(requirement, decrement time.Duration, lit bool, supported func(item.SmeltInfo) bool) bool {
+// items for the necessary duration. Smelting recipes registered for the block passed are preferred over the smelt
+// info of the input item.
+func (s *smelter) tickSmelting(block string, requirement, decrement time.Duration, lit bool, supported func(item.SmeltInfo) bool) bool {
 	s.mu.Lock()
 
 	// First keep track of our past durations, since if any of them change, we need to be able to tell they did and then
@@ -191,7 +193,9 @@ func (s *smelter) tickSmelting(requirement, decrement time.Duration, lit bool, s
 
 	// Initialise some default smelt info, and update it if we can smelt the item.
 	var inputInfo item.SmeltInfo
-	if i, ok := input.Item().(item.Smeltable); ok && supported(i.SmeltInfo()) {
+	if r, ok := smeltingRecipe(block, input); ok {
+		inputInfo = item.SmeltInfo{Product: r.Output()[0], Experience: r.Experience()}
+	} else if i, ok := input.Item().(item.Smeltable); ok && supported(i.SmeltInfo()) {
 		inputInfo = i.SmeltInfo()
 	}
 
@@ -270,3 +274,16 @@ func (s *smelter) tickSmelting(requirement, decrement time.Duration, lit bool, s
 	s.mu.Unlock()
 	return lit
 }
+
+// smeltingRecipe looks up the recipe.Smelting registered for the block passed with the input passed.
+func smeltingRecipe(block string, input item.Stack) (recipe.Smelting, bool) {
+	if input.Empty() {
+		return recipe.Smelting{}, false
+	}
+	r, ok := recipe.Lookup(block, input.Item())
+	if !ok {
+		return recipe.Smelting{}, false
+	}
+	smelting, ok := r.(recipe.Smelting)
+	return smelting, ok
+}
@@ -0,0 +1,66 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/recipe"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestFurnaceUsesCustomSmeltingRecipe(t *testing.T) {
+	recipe.Register(recipe.NewSmelting(item.NewStack(Dirt{}, 1), item.NewStack(item.Diamond{}, 2), 1, "furnace"))
+	// A recipe for the blast furnace does not affect furnaces.
+	recipe.Register(recipe.NewSmelting(item.NewStack(Sand{}, 1), item.NewStack(item.Emerald{}, 1), 1, "blast_furnace"))
+	// Iron ore normally smelts into an iron ingot, but a recipe overrides it.
+	recipe.Register(recipe.NewSmelting(item.NewStack(IronOre{}, 1), item.NewStack(item.GoldIngot{}, 1), 0, "furnace"))
+
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	for i, tc := range []struct {
+		input, want item.Stack
+	}{
+		{input: item.NewStack(Dirt{}, 1), want: item.NewStack(item.Diamond{}, 2)},
+		{input: item.NewStack(IronOre{}, 1), want: item.NewStack(item.GoldIngot{}, 1)},
+		{input: item.NewStack(Sand{}, 1), want: item.NewStack(Glass{}, 1)},
+	} {
+		pos := cube.Pos{i, 64, 0}
+		runWorld(w, func(tx *world.Tx) {
+			f := NewFurnace(cube.North)
+			tx.SetBlock(pos, f, nil)
+			inv := f.Inventory(tx, pos)
+			_ = inv.SetItem(0, tc.input)
+			_ = inv.SetItem(1, item.NewStack(item.Coal{}, 1))
+
+			// A furnace takes 10 seconds, or 200 ticks, to smelt an item.
+			for tick := range int64(201) {
+				f, _ := tx.Block(pos).(Furnace)
+				f.Tick(tick, pos, tx)
+			}
+			if product, _ := inv.Item(2); !product.Comparable(tc.want) || product.Count() != tc.want.Count() {
+				t.Errorf("furnace product of %v = %v, want %v", tc.input, product, tc.want)
+			}
+		})
+	}
+}
+
+func TestSmeltingRecipeLookupByInput(t *testing.T) {
+	items := world.Items()
+	recipe.Register(recipe.NewSmelting(item.NewStack(items[0], 1), item.NewStack(item.Diamond{}, 1), 0, "smelter_test_small"))
+	for _, it := range items {
+		recipe.Register(recipe.NewSmelting(item.NewStack(it, 1), item.NewStack(item.Diamond{}, 1), 0, "smelter_test_large"))
+	}
+	if r, ok := recipe.Lookup("smelter_test_large", items[len(items)-1]); !ok || !r.Input()[0].(item.Stack).Comparable(item.NewStack(items[len(items)-1], 1)) {
+		t.Fatalf("lookup of last registered smelting recipe = %v, %v, want recipe for %v", r, ok, items[len(items)-1])
+	}
+
+	// A lookup is a single map access on the input of the recipe, so it costs the same regardless of the
+	// amount of recipes registered for a block.
+	small := testing.AllocsPerRun(100, func() { recipe.Lookup("smelter_test_small", items[0]) })
+	large := testing.AllocsPerRun(100, func() { recipe.Lookup("smelter_test_large", items[0]) })
+	if large > small {
+		t.Errorf("allocations of lookup with %v recipes = %v, want at most %v as with 1 recipe", len(items), large, small)
+	}
+}
@@ -3,6 +3,7 @@ package block
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/recipe"
 	"github.com/df-mc/dragonfly/server/world"
 )
 
@@ -36,3 +37,57 @@ func (SmithingTable) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.Us
 	}
 	return false
 }
+
+// SmithingResult returns the result of applying the smithing recipe passed to
+// the input, material and template stacks in a SmithingTable. Each of the
+// stacks must match the corresponding input of the recipe. A
+// recipe.SmithingTransform, such as a netherite upgrade, turns the input into
+// the output item of the recipe while keeping its enchantments, damage and
+// other data. A recipe.SmithingTrim applies the armour trim of the template
+// and material to the input. False is returned if the stacks do not match the
+// recipe or if it is not a smithing recipe.
+func SmithingResult(r recipe.Recipe, input, material, template item.Stack) (item.Stack, bool) {
+	expected := r.Input()
+	if len(expected) != 3 || !stationInputMatches(input, expected[0]) || !stationInputMatches(material, expected[1]) || !stationInputMatches(template, expected[2]) {
+		return item.Stack{}, false
+	}
+	switch r.(type) {
+	case recipe.SmithingTransform:
+		return input.WithItem(r.Output()[0].Item()).Grow(1 - input.Count()), true
+	case recipe.SmithingTrim:
+		t, ok := template.Item().(item.SmithingTemplate)
+		if !ok {
+			return item.Stack{}, false
+		}
+		mat, ok := material.Item().(item.ArmourTrimMaterial)
+		if !ok {
+			return item.Stack{}, false
+		}
+		trimmable, ok := input.Item().(item.Trimmable)
+		if !ok {
+			return item.Stack{}, false
+		}
+		return input.WithItem(trimmable.WithTrim(item.ArmourTrim{Template: t.Template, Material: mat})).Grow(1 - input.Count()), true
+	}
+	return item.Stack{}, false
+}
+
+// stationInputMatches checks if the stack passed matches the recipe input
+// expected of a SmithingTable or Stonecutter recipe. Unlike crafting, the
+// enchantments, damage and other data of the stack are not compared, so that
+// enchanted or damaged gear can be upgraded.
+func stationInputMatches(s item.Stack, expected recipe.Item) bool {
+	if s.Empty() {
+		return false
+	}
+	name, meta := s.Item().EncodeItem()
+	switch expected := expected.(type) {
+	case recipe.ItemTag:
+		return expected.Contains(name)
+	case item.Stack:
+		expectedName, expectedMeta := expected.Item().EncodeItem()
+		_, variants := expected.Value("variants")
+		return name == expectedName && (variants || meta == expectedMeta)
+	}
+	return false
+}
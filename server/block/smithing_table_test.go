@@ -0,0 +1,82 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/item/recipe"
+)
+
+func TestSmithingResultNetheriteUpgrade(t *testing.T) {
+	template := item.NewStack(item.SmithingTemplate{Template: item.TemplateNetheriteUpgrade()}, 1)
+	upgrade := recipe.NewSmithingTransform(
+		item.NewStack(item.Sword{Tier: item.ToolTierDiamond}, 1),
+		item.NewStack(item.NetheriteIngot{}, 1),
+		template,
+		item.NewStack(item.Sword{Tier: item.ToolTierNetherite}, 1),
+		"smithing_table",
+	)
+	sword := item.NewStack(item.Sword{Tier: item.ToolTierDiamond}, 1).
+		Damage(100).
+		WithCustomName("Blade").
+		WithEnchantments(item.NewEnchantment(enchantment.Sharpness, 4))
+
+	result, ok := SmithingResult(upgrade, sword, item.NewStack(item.NetheriteIngot{}, 1), template)
+	if !ok {
+		t.Fatal("expected diamond sword to be upgraded")
+	}
+	if s, ok := result.Item().(item.Sword); !ok || s.Tier != item.ToolTierNetherite {
+		t.Fatalf("upgraded item = %v, want netherite sword", result.Item())
+	}
+	if result.CustomName() != "Blade" || result.MaxDurability()-result.Durability() != 100 {
+		t.Errorf("upgraded sword lost its name or damage: %v", result)
+	}
+	if e, ok := result.Enchantment(enchantment.Sharpness); !ok || e.Level() != 4 {
+		t.Error("expected enchantments to be kept on the upgraded sword")
+	}
+
+	if _, ok := SmithingResult(upgrade, sword, item.NewStack(item.IronIngot{}, 1), template); ok {
+		t.Error("expected upgrade with the wrong ingredient to fail")
+	}
+	wrongTemplate := item.NewStack(item.SmithingTemplate{Template: item.TemplateVex()}, 1)
+	if _, ok := SmithingResult(upgrade, sword, item.NewStack(item.NetheriteIngot{}, 1), wrongTemplate); ok {
+		t.Error("expected upgrade with the wrong template to fail")
+	}
+	if _, ok := SmithingResult(upgrade, sword, item.NewStack(item.NetheriteIngot{}, 1), item.Stack{}); ok {
+		t.Error("expected upgrade without a template to fail")
+	}
+}
+
+func TestSmithingResultTrimRoundTrip(t *testing.T) {
+	template := item.NewStack(item.SmithingTemplate{Template: item.TemplateCoast()}, 1)
+	trim := recipe.NewSmithingTrim(
+		item.NewStack(item.Leggings{Tier: item.ArmourTierLeather{}}, 1),
+		item.NewStack(item.Emerald{}, 1),
+		template,
+		"smithing_table",
+	)
+	leggings := item.NewStack(item.Leggings{Tier: item.ArmourTierLeather{Colour: item.ColourCyan().RGBA()}}, 1)
+
+	result, ok := SmithingResult(trim, leggings, item.NewStack(item.Emerald{}, 1), template)
+	if !ok {
+		t.Fatal("expected leather leggings to be trimmed")
+	}
+	want := item.ArmourTrim{Template: item.TemplateCoast(), Material: item.Emerald{}}
+	if l := result.Item().(item.Leggings); l.Trim != want {
+		t.Fatalf("trim of trimmed leggings = %v, want %v", l.Trim, want)
+	}
+
+	data := nbtconv.WriteItem(result, false)
+	if tr, _ := data["Trim"].(map[string]any); tr["Pattern"] != "coast" || tr["Material"] != "emerald" {
+		t.Errorf("trim NBT of trimmed leggings = %v, want pattern coast and material emerald", data["Trim"])
+	}
+	decoded := nbtconv.Item(nbtconv.WriteItem(result, true), nil).Item().(item.Leggings)
+	if decoded.Trim != want {
+		t.Errorf("trim after decoding = %v, want %v", decoded.Trim, want)
+	}
+	if c, _ := decoded.DyeColour(); c != item.ColourCyan().RGBA() {
+		t.Errorf("colour after decoding trimmed leggings = %v, want the dye to be kept", c)
+	}
+}
@@ -0,0 +1,119 @@
+package block
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// SnowLayer is a thin layer of snow that forms on top of blocks while it is snowing. Layers of snow may be
+// stacked on top of each other until they form a full block.
+type SnowLayer struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Height is the number of layers of snow stacked on top of the first layer, from 0-7. A snow layer with
+	// a height of 7 is a full block of snow.
+	Height int
+	// Covered specifies if the snow layer covers another block, such as short grass, that is shown through
+	// the snow.
+	Covered bool
+}
+
+// Model ...
+func (s SnowLayer) Model() world.BlockModel {
+	return model.SnowLayer{Height: s.Height}
+}
+
+// ReplaceableBy returns true if the snow layer consists of only a single layer and the block replacing it is
+// not another snow layer, which is instead stacked on top.
+func (s SnowLayer) ReplaceableBy(b world.Block) bool {
+	_, snow := b.(SnowLayer)
+	return s.Height == 0 && !snow
+}
+
+// BreakInfo ...
+func (s SnowLayer) BreakInfo() BreakInfo {
+	return newBreakInfo(0.1, shovelEffective, shovelEffective, silkTouchDrop(item.NewStack(item.Snowball{}, s.Height+1), item.NewStack(SnowLayer{}, s.Height+1)))
+}
+
+// UseOnBlock places a snow layer or, if the block clicked is a snow layer that is not yet a full block, adds
+// a layer of snow on top of it.
+func (s SnowLayer) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	if existing, ok := tx.Block(pos).(SnowLayer); ok && existing.Height < 7 {
+		existing.Height++
+		place(tx, pos, existing, user, ctx)
+		return placed(ctx)
+	}
+	pos, _, used := firstReplaceable(tx, pos, face, s)
+	if !used {
+		return false
+	}
+	if existing, ok := tx.Block(pos).(SnowLayer); ok && existing.Height < 7 {
+		existing.Height++
+		place(tx, pos, existing, user, ctx)
+		return placed(ctx)
+	}
+	if !s.SupportedAt(pos, tx) {
+		return false
+	}
+	place(tx, pos, SnowLayer{}, user, ctx)
+	return placed(ctx)
+}
+
+// SupportedAt checks if a snow layer can rest on the block below pos. Snow layers need a block below with a
+// solid top face, or a full snow layer.
+func (SnowLayer) SupportedAt(pos cube.Pos, tx *world.Tx) bool {
+	below := pos.Side(cube.FaceDown)
+	return tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx)
+}
+
+// NeighbourUpdateTick breaks the snow layer if the block below it no longer supports it.
+func (s SnowLayer) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
+	if !s.SupportedAt(pos, tx) {
+		breakBlock(s, pos, tx)
+	}
+}
+
+// RandomTick melts the snow layer if a neighbouring block has a block light level higher than 11. Unlike
+// ice, a snow layer does not melt in warm biomes during the day.
+func (s SnowLayer) RandomTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	for _, face := range cube.Faces() {
+		if tx.BlockLight(pos.Side(face)) > 11 {
+			breakBlockNoDrops(s, pos, tx)
+			return
+		}
+	}
+}
+
+// ReceivePrecipitation adds a layer of snow to the snow layer while it is snowing, until it reaches the
+// snow accumulation height of the world.
+func (s SnowLayer) ReceivePrecipitation(pos cube.Pos, tx *world.Tx, _ *rand.Rand, snow bool) {
+	if !snow || s.Height+1 >= tx.World().SnowAccumulationHeight() || s.Height >= 7 {
+		return
+	}
+	s.Height++
+	tx.SetBlock(pos, s, nil)
+}
+
+// EncodeItem ...
+func (SnowLayer) EncodeItem() (name string, meta int16) {
+	return "minecraft:snow_layer", 0
+}
+
+// EncodeBlock ...
+func (s SnowLayer) EncodeBlock() (string, map[string]any) {
+	return "minecraft:snow_layer", map[string]any{"height": int32(s.Height), "covered_bit": s.Covered}
+}
+
+// allSnowLayers returns all possible states of snow layers.
+func allSnowLayers() (b []world.Block) {
+	for h := range 8 {
+		b = append(b, SnowLayer{Height: h}, SnowLayer{Height: h, Covered: true})
+	}
+	return
+}
@@ -62,21 +62,30 @@ func (s Sponge) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world
 	}
 
 	place(tx, pos, s, user, ctx)
-	if particles && placed(ctx) {
+	if !placed(ctx) {
+		return false
+	}
+	if particles {
 		tx.AddParticle(pos.Side(cube.FaceUp).Vec3(), particle.Evaporate{})
 	}
-	return placed(ctx)
+	// Neighbour updates are only sent to the blocks around the sponge, so water next to it must be absorbed
+	// right after placing it.
+	s.absorb(pos, tx)
+	return true
 }
 
 // NeighbourUpdateTick checks for nearby water flow. If water could be found and the sponge is dry, it will absorb the
 // water and be flagged as wet.
 func (s Sponge) NeighbourUpdateTick(pos, _ cube.Pos, tx *world.Tx) {
-	// The sponge is dry, so it can absorb nearby water.
-	if !s.Wet {
-		if s.absorbWater(pos, tx) > 0 {
-			// Water has been absorbed, so we flag the sponge as wet.
-			s.setWet(pos, tx)
-		}
+	s.absorb(pos, tx)
+}
+
+// absorb absorbs the water around the sponge at pos if it is dry and flags it as wet if any water was
+// absorbed.
+func (s Sponge) absorb(pos cube.Pos, tx *world.Tx) {
+	// Only a dry sponge can absorb nearby water.
+	if !s.Wet && s.absorbWater(pos, tx) > 0 {
+		s.setWet(pos, tx)
 	}
 }
 
@@ -89,7 +98,7 @@ func (s Sponge) setWet(pos cube.Pos, tx *world.Tx) {
 }
 
 // absorbWater replaces water blocks near the sponge by air out to a taxicab geometry of 7 in all directions.
-// The maximum for absorbed blocks is 65.
+// The maximum for absorbed blocks is 65. Both source and flowing water blocks are absorbed.
 // The returned int specifies the amount of replaced water blocks.
 func (s Sponge) absorbWater(pos cube.Pos, tx *world.Tx) int {
 	// distanceToSponge binds a world.Position to its distance from the sponge's position.
@@ -111,16 +120,21 @@ func (s Sponge) absorbWater(pos cube.Pos, tx *world.Tx) int {
 		// Pop the next distanceToSponge entry from the queue.
 		next := queue[0]
 		queue = queue[1:]
+		if next.distance >= 7 {
+			// Water further than 7 blocks away from the sponge is not absorbed.
+			continue
+		}
 
 		next.block.Neighbours(func(neighbour cube.Pos) {
+			if replaced >= 65 {
+				return
+			}
 			liquid, found := tx.Liquid(neighbour)
 			if found {
 				if _, isWater := liquid.(Water); isWater {
 					tx.SetLiquid(neighbour, nil)
 					replaced++
-					if next.distance < 7 {
-						queue = append(queue, distanceToSponge{neighbour, next.distance + 1})
-					}
+					queue = append(queue, distanceToSponge{neighbour, next.distance + 1})
 				}
 			}
 		}, tx.Range())
@@ -0,0 +1,79 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestSpongeAbsorbWater(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		for p := range cube.Range3D(pos.Sub(cube.Pos{8, 8, 8}), pos.Add(cube.Pos{8, 8, 8})) {
+			tx.SetBlock(p, Water{Still: true, Depth: 8}, nil)
+		}
+		tx.SetBlock(pos, Sponge{}, nil)
+		if n := (Sponge{}).absorbWater(pos, tx); n != 65 {
+			t.Fatalf("sponge surrounded by water absorbed %v water blocks, want 65", n)
+		}
+	})
+
+	runWorld(w, func(tx *world.Tx) {
+		for p := range cube.Range3D(pos.Sub(cube.Pos{8, 8, 8}), pos.Add(cube.Pos{20, 8, 8})) {
+			tx.SetBlock(p, Stone{}, nil)
+		}
+		// A line of flowing water only has water blocks up to 7 blocks away absorbed.
+		for x := 1; x <= 10; x++ {
+			tx.SetBlock(pos.Add(cube.Pos{x, 0, 0}), Water{Depth: 8 - x%8}, nil)
+		}
+		tx.SetBlock(pos, Sponge{}, nil)
+		if n := (Sponge{}).absorbWater(pos, tx); n != 7 {
+			t.Fatalf("sponge next to a line of water absorbed %v water blocks, want 7", n)
+		}
+		if _, ok := tx.Liquid(pos.Add(cube.Pos{8, 0, 0})); !ok {
+			t.Fatal("sponge absorbed water 8 blocks away")
+		}
+	})
+}
+
+func TestSpongeDrying(t *testing.T) {
+	smelt := Sponge{Wet: true}.SmeltInfo()
+	if smelt.Product.Item() != (Sponge{}) {
+		t.Fatalf("smelting a wet sponge produced %v, want a dry sponge", smelt.Product.Item())
+	}
+	if (Sponge{}).SmeltInfo().Product.Count() != 0 {
+		t.Fatal("dry sponge must not be smeltable")
+	}
+
+	w := world.Config{Dim: world.Nether, Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pos.Side(cube.FaceDown), Stone{}, nil)
+		ctx := &item.UseContext{}
+		if !(Sponge{Wet: true}).UseOnBlock(pos.Side(cube.FaceDown), cube.FaceUp, mgl64.Vec3{}, tx, spongeTestPlacer{tx: tx}, ctx) {
+			t.Fatal("wet sponge could not be placed in the nether")
+		}
+		if b := tx.Block(pos); b != (Sponge{}) {
+			t.Fatalf("wet sponge placed in the nether became %v, want a dry sponge", b)
+		}
+	})
+}
+
+// spongeTestPlacer is a Placer that places blocks directly in the world.
+type spongeTestPlacer struct {
+	item.User
+	tx *world.Tx
+}
+
+func (p spongeTestPlacer) PlaceBlock(pos cube.Pos, b world.Block, ctx *item.UseContext) {
+	p.tx.SetBlock(pos, b, nil)
+	ctx.CountSub = 1
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/block/model"
 	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/recipe"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
 )
@@ -57,6 +58,38 @@ func (s Stonecutter) EncodeBlock() (name string, properties map[string]interface
 	return "minecraft:stonecutter_block", map[string]any{"minecraft:cardinal_direction": s.Facing.String()}
 }
 
+// StonecutterRecipes returns all registered stonecutter recipes that the input
+// stack passed may be cut with, in the order they were registered. These are
+// the outputs that a player can select from in the stonecutter. Recipes for
+// stone, for example, produce stone bricks, stone slabs and stone stairs.
+func StonecutterRecipes(input item.Stack) []recipe.Recipe {
+	var recipes []recipe.Recipe
+	for _, r := range recipe.Recipes() {
+		if _, ok := StonecutterResult(r, input, 1); ok {
+			recipes = append(recipes, r)
+		}
+	}
+	return recipes
+}
+
+// StonecutterResult returns the result of cutting the input stack passed times
+// times in a Stonecutter using the recipe selected. The count of the stack
+// returned is the count of the recipe output multiplied by times, so cutting
+// stone into slabs twice results in 4 slabs. The stack may hold more items
+// than its maximum count. False is returned if the recipe is not a stonecutter
+// recipe, if the input does not match the recipe or if the input holds fewer
+// than times items.
+func StonecutterResult(r recipe.Recipe, input item.Stack, times int) (item.Stack, bool) {
+	if _, ok := r.(recipe.Shapeless); !ok || r.Block() != "stonecutter" {
+		return item.Stack{}, false
+	}
+	expected, output := r.Input(), r.Output()
+	if times < 1 || input.Count() < times || len(expected) != 1 || len(output) != 1 || !stationInputMatches(input, expected[0]) {
+		return item.Stack{}, false
+	}
+	return output[0].Grow(output[0].Count() * (times - 1)), true
+}
+
 // allStonecutters ...
 func allStonecutters() (stonecutters []world.Block) {
 	for _, d := range cube.Directions() {
@@ -0,0 +1,63 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/recipe"
+)
+
+func TestStonecutterResult(t *testing.T) {
+	bricks := recipe.NewShapeless([]recipe.Item{item.NewStack(Stone{}, 1)}, item.NewStack(StoneBricks{}, 1), "stonecutter")
+	slabs := recipe.NewShapeless([]recipe.Item{item.NewStack(Stone{}, 1)}, item.NewStack(Slab{Block: Stone{}}, 2), "stonecutter")
+	tests := []struct {
+		name   string
+		recipe recipe.Recipe
+		input  item.Stack
+		times  int
+		want   item.Stack
+		ok     bool
+	}{
+		{name: "single craft", recipe: bricks, input: item.NewStack(Stone{}, 1), times: 1, want: item.NewStack(StoneBricks{}, 1), ok: true},
+		{name: "multiple crafts", recipe: bricks, input: item.NewStack(Stone{}, 64), times: 10, want: item.NewStack(StoneBricks{}, 10), ok: true},
+		{name: "multiplied output", recipe: slabs, input: item.NewStack(Stone{}, 5), times: 3, want: item.NewStack(Slab{Block: Stone{}}, 6), ok: true},
+		{name: "too few inputs", recipe: slabs, input: item.NewStack(Stone{}, 2), times: 3},
+		{name: "no crafts", recipe: bricks, input: item.NewStack(Stone{}, 1), times: 0},
+		{name: "wrong input", recipe: bricks, input: item.NewStack(Cobblestone{}, 1), times: 1},
+		{name: "crafting recipe", recipe: recipe.NewShapeless([]recipe.Item{item.NewStack(Stone{}, 1)}, item.NewStack(StoneBricks{}, 1), "crafting_table"), input: item.NewStack(Stone{}, 1), times: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, ok := StonecutterResult(test.recipe, test.input, test.times)
+			if ok != test.ok {
+				t.Fatalf("StonecutterResult() ok = %v, want %v", ok, test.ok)
+			}
+			if ok && (!result.Comparable(test.want) || result.Count() != test.want.Count()) {
+				t.Errorf("StonecutterResult() = %v, want %v", result, test.want)
+			}
+		})
+	}
+}
+
+func TestStonecutterRecipesSelectsOutputsForInput(t *testing.T) {
+	bricks := recipe.NewShapeless([]recipe.Item{item.NewStack(Stone{}, 1)}, item.NewStack(StoneBricks{}, 1), "stonecutter")
+	slabs := recipe.NewShapeless([]recipe.Item{item.NewStack(Stone{}, 1)}, item.NewStack(Slab{Block: Stone{}}, 2), "stonecutter")
+	cobbleSlabs := recipe.NewShapeless([]recipe.Item{item.NewStack(Cobblestone{}, 1)}, item.NewStack(Slab{Block: Cobblestone{}}, 2), "stonecutter")
+	recipe.Register(bricks)
+	recipe.Register(slabs)
+	recipe.Register(cobbleSlabs)
+
+	var outputs []item.Stack
+	for _, r := range StonecutterRecipes(item.NewStack(Stone{}, 1)) {
+		outputs = append(outputs, r.Output()...)
+	}
+	if len(outputs) != 2 || !outputs[0].Comparable(item.NewStack(StoneBricks{}, 1)) || !outputs[1].Comparable(item.NewStack(Slab{Block: Stone{}}, 2)) {
+		t.Fatalf("outputs for stone = %v, want stone bricks and stone slabs", outputs)
+	}
+	if outputs[1].Count() != 2 {
+		t.Errorf("output count of stone slabs = %v, want 2", outputs[1].Count())
+	}
+	if r := StonecutterRecipes(item.NewStack(StoneBricks{}, 1)); len(r) != 0 {
+		t.Errorf("outputs for stone bricks = %v, want none", r)
+	}
+}
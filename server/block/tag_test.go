@@ -0,0 +1,38 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestDefaultBlockTags(t *testing.T) {
+	tests := []struct {
+		tag     string
+		members []world.Block
+		others  []world.Block
+	}{
+		{tag: "minecraft:logs", members: []world.Block{Log{Wood: OakWood()}, Log{Wood: CrimsonWood(), Stripped: true}, Wood{Wood: BirchWood()}}, others: []world.Block{Planks{Wood: OakWood()}, Stone{}}},
+		{tag: "minecraft:planks", members: []world.Block{Planks{Wood: OakWood()}, Planks{Wood: BambooWood()}}, others: []world.Block{Log{Wood: OakWood()}}},
+		{tag: "minecraft:wool", members: []world.Block{Wool{Colour: item.ColourWhite()}, Wool{Colour: item.ColourBlack()}}, others: []world.Block{Carpet{Colour: item.ColourWhite()}}},
+		{tag: "minecraft:leaves", members: []world.Block{Leaves{Type: OakLeaves()}, Leaves{Type: CherryLeaves()}}, others: []world.Block{Log{Wood: OakWood()}}},
+	}
+	for _, test := range tests {
+		tag, ok := world.BlockTag(test.tag)
+		if !ok {
+			t.Errorf("block tag %v is not registered", test.tag)
+			continue
+		}
+		for _, b := range test.members {
+			if !tag.ContainsBlock(b) {
+				t.Errorf("block tag %v does not contain %#v", test.tag, b)
+			}
+		}
+		for _, b := range test.others {
+			if tag.ContainsBlock(b) {
+				t.Errorf("block tag %v contains %#v", test.tag, b)
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestWaterBucketWaterlogsBlock(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	stairs := Stairs{Block: Stone{}}
+	runWorld(w, func(tx *world.Tx) {
+		for p := range cube.Range3D(cube.Pos{-8, 63, -8}, cube.Pos{8, 63, 8}) {
+			tx.SetBlock(p, Stone{}, nil)
+		}
+		tx.SetBlock(pos, stairs, nil)
+
+		bucket := item.Bucket{Content: item.LiquidBucketContent(Water{Still: true, Depth: 8})}
+		if !bucket.UseOnBlock(pos, cube.FaceUp, mgl64.Vec3{}, tx, nil, &item.UseContext{}) {
+			t.Fatalf("water bucket could not be used on stairs")
+		}
+		if !Waterlogged(pos, tx) {
+			t.Fatalf("stairs were not waterlogged after using a water bucket on them")
+		}
+		if b := tx.Block(pos); b != stairs {
+			t.Fatalf("block after waterlogging = %#v, want %#v", b, stairs)
+		}
+	})
+	for range 20 {
+		w.AdvanceTick()
+	}
+	runWorld(w, func(tx *world.Tx) {
+		// Water flows out of the gaps of the waterlogged block.
+		if l, ok := tx.Liquid(pos.Side(cube.FaceEast)); !ok || l.LiquidDepth() != 7 {
+			t.Errorf("liquid next to waterlogged stairs = %v (%v), want water with a depth of 7", l, ok)
+		}
+
+		breakBlockNoDrops(tx.Block(pos), pos, tx)
+		if Waterlogged(pos, tx) {
+			t.Errorf("air was considered waterlogged")
+		}
+		if b, ok := tx.Block(pos).(Water); !ok || b.Depth != 8 || b.Falling {
+			t.Fatalf("block after breaking waterlogged stairs = %#v, want a water source", tx.Block(pos))
+		}
+	})
+}
+
+func TestWaterloggedBlockReplaced(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pos, Slab{Block: Stone{}}, nil)
+		tx.SetLiquid(pos, Water{Still: true, Depth: 8})
+		if !Waterlogged(pos, tx) {
+			t.Fatalf("slab was not waterlogged after setting water in it")
+		}
+
+		tx.SetBlock(pos, Stairs{Block: Stone{}}, nil)
+		if !Waterlogged(pos, tx) {
+			t.Errorf("stairs replacing a waterlogged slab were not waterlogged")
+		}
+		// A double slab cannot hold water, so the water is removed when it
+		// replaces a waterlogged block.
+		tx.SetBlock(pos, Slab{Block: Stone{}, Double: true}, nil)
+		if _, ok := tx.Liquid(pos); ok {
+			t.Errorf("double slab replacing waterlogged stairs still held water")
+		}
+	})
+}
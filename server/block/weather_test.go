@@ -0,0 +1,129 @@
+package block
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// TestSnowAccumulatesDuringSnowfall verifies that snow layers form on solid
+// blocks while it snows in a cold biome, and that they do not stack higher
+// than the snow accumulation height of the World.
+func TestSnowAccumulatesDuringSnowfall(t *testing.T) {
+	w := world.Config{Synchronous: true, SnowAccumulationHeight: 3}.New()
+	defer w.Close()
+
+	runWorld(w, func(tx *world.Tx) {
+		for x := 0; x < 16; x++ {
+			for z := 0; z < 16; z++ {
+				tx.SetBlock(cube.Pos{x, 0, z}, Stone{}, nil)
+				for y := 1; y < 4; y++ {
+					tx.SetBiome(cube.Pos{x, y, z}, biome.SnowyPlains{})
+				}
+			}
+		}
+	})
+	w.StartRaining(time.Hour)
+	for range 4000 {
+		w.AdvanceTick()
+	}
+
+	runWorld(w, func(tx *world.Tx) {
+		layers, stacked := 0, 0
+		for x := 0; x < 16; x++ {
+			for z := 0; z < 16; z++ {
+				s, ok := tx.Block(cube.Pos{x, 1, z}).(SnowLayer)
+				if !ok {
+					continue
+				}
+				layers++
+				if s.Height > 0 {
+					stacked++
+				}
+				if s.Height > 2 {
+					t.Errorf("snow layer at %v has height %v, want at most 2 with an accumulation height of 3", cube.Pos{x, 1, z}, s.Height)
+				}
+			}
+		}
+		if layers == 0 {
+			t.Fatalf("no snow layers formed during snowfall in a cold biome")
+		}
+		if stacked == 0 {
+			t.Errorf("no snow layers stacked during snowfall, want some layers with a height above 0")
+		}
+	})
+}
+
+// TestSnowLayerStacksUpToAccumulationHeight verifies that a snow layer only
+// grows from snowfall, and stops growing at the snow accumulation height.
+func TestSnowLayerStacksUpToAccumulationHeight(t *testing.T) {
+	w := world.Config{Synchronous: true, SnowAccumulationHeight: 3}.New()
+	defer w.Close()
+
+	pos, r := cube.Pos{0, 1, 0}, rand.New(rand.NewPCG(1, 2))
+	runWorld(w, func(tx *world.Tx) {
+		tx.SetBlock(pos.Side(cube.FaceDown), Stone{}, nil)
+		tx.SetBlock(pos, SnowLayer{}, nil)
+
+		tx.Block(pos).(world.PrecipitationReceiver).ReceivePrecipitation(pos, tx, r, false)
+		if s := tx.Block(pos).(SnowLayer); s.Height != 0 {
+			t.Fatalf("snow layer grew to height %v from rain, want 0", s.Height)
+		}
+		for range 5 {
+			tx.Block(pos).(world.PrecipitationReceiver).ReceivePrecipitation(pos, tx, r, true)
+		}
+		if s := tx.Block(pos).(SnowLayer); s.Height != 2 {
+			t.Fatalf("snow layer grew to height %v from snowfall, want 2", s.Height)
+		}
+	})
+}
+
+// TestIceMeltsUnderBrightLight verifies that ice melts into water when a
+// neighbouring block is lit brightly enough, but stays frozen in the dark.
+// The blocks are generated with the chunk, so that its light is calculated
+// when it is loaded.
+func TestIceMeltsUnderBrightLight(t *testing.T) {
+	dark, lit := cube.Pos{0, 1, 0}, cube.Pos{8, 1, 0}
+	w := world.Config{Synchronous: true, Generator: iceTestGenerator{dark, lit}}.New()
+	defer w.Close()
+
+	r := rand.New(rand.NewPCG(1, 2))
+	runWorld(w, func(tx *world.Tx) {
+		Ice{}.RandomTick(dark, tx, r)
+		if _, ok := tx.Block(dark).(Ice); !ok {
+			t.Errorf("ice without light melted into %v, want ice", tx.Block(dark))
+		}
+		Ice{}.RandomTick(lit, tx, r)
+		if b := tx.Block(lit); b != (Water{Depth: 8, Still: true}) {
+			t.Errorf("ice next to glowstone melted into %v, want still water", b)
+		}
+	})
+}
+
+// iceTestGenerator generates ice enclosed by stone at the dark and lit
+// positions, with glowstone next to the ice at the lit position.
+type iceTestGenerator struct {
+	dark, lit cube.Pos
+}
+
+func (g iceTestGenerator) GenerateChunk(pos world.ChunkPos, c *chunk.Chunk) {
+	if pos != (world.ChunkPos{}) {
+		return
+	}
+	set := func(pos cube.Pos, b world.Block) {
+		c.SetBlock(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0, world.BlockRuntimeID(b))
+	}
+	for _, pos := range []cube.Pos{g.dark, g.lit} {
+		set(pos.Side(cube.FaceDown), Stone{})
+		set(pos.Side(cube.FaceUp), Stone{})
+		set(pos, Ice{})
+	}
+	set(g.lit.Side(cube.FaceEast), Glowstone{})
+}
+
+func (iceTestGenerator) DefaultSpawn(world.Dimension) cube.Pos { return cube.Pos{} }
@@ -5,7 +5,6 @@ import (
 	"github.com/df-mc/dragonfly/server/block/model"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
-	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
 	"time"
 )
@@ -100,25 +99,28 @@ func (d WoodDoor) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *wor
 	return placed(ctx)
 }
 
-// Activate ...
+// Activate opens or closes the door, along with a door forming a double door with it.
 func (d WoodDoor) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.User, _ *item.UseContext) bool {
-	d.Open = !d.Open
-	tx.SetBlock(pos, d, nil)
-
-	otherPos := pos.Side(cube.Face(boolByte(!d.Top)))
-	other := tx.Block(otherPos)
-	if door, ok := other.(WoodDoor); ok {
-		door.Open = d.Open
-		tx.SetBlock(otherPos, door, nil)
-	}
-	if d.Open {
-		tx.PlaySound(pos.Vec3Centre(), sound.DoorOpen{Block: d})
-		return true
-	}
-	tx.PlaySound(pos.Vec3Centre(), sound.DoorClose{Block: d})
+	setDoorOpen(pos, tx, d, !d.Open, true)
 	return true
 }
 
+// RedstonePowerAction opens the door when it starts receiving redstone power and closes it when it stops.
+func (d WoodDoor) RedstonePowerAction(pos cube.Pos, tx *world.Tx, oldPower, newPower int) {
+	updateDoorPower(pos, tx, d, oldPower, newPower)
+}
+
+// doorState ...
+func (d WoodDoor) doorState() (facing cube.Direction, open, top, right bool) {
+	return d.Facing, d.Open, d.Top, d.Right
+}
+
+// withOpen ...
+func (d WoodDoor) withOpen(open bool) door {
+	d.Open = open
+	return d
+}
+
 // BreakInfo ...
 func (d WoodDoor) BreakInfo() BreakInfo {
 	return newBreakInfo(3, alwaysHarvestable, axeEffective, oneOf(d))
@@ -92,6 +92,21 @@ func (f WoodFenceGate) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.
 	return true
 }
 
+// RedstonePowerAction opens the fence gate when it starts receiving redstone power and closes it when it stops.
+func (f WoodFenceGate) RedstonePowerAction(pos cube.Pos, tx *world.Tx, oldPower, newPower int) {
+	open, changed := redstoneOpen(f.Open, oldPower, newPower)
+	if !changed {
+		return
+	}
+	f.Open = open
+	tx.SetBlock(pos, f, nil)
+	if f.Open {
+		tx.PlaySound(pos.Vec3Centre(), sound.FenceGateOpen{Block: f})
+		return
+	}
+	tx.PlaySound(pos.Vec3Centre(), sound.FenceGateClose{Block: f})
+}
+
 // SideClosed ...
 func (f WoodFenceGate) SideClosed(cube.Pos, cube.Pos, *world.Tx) bool {
 	return false
@@ -67,6 +67,21 @@ func (t WoodTrapdoor) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, _ item.U
 	return true
 }
 
+// RedstonePowerAction opens the trapdoor when it starts receiving redstone power and closes it when it stops.
+func (t WoodTrapdoor) RedstonePowerAction(pos cube.Pos, tx *world.Tx, oldPower, newPower int) {
+	open, changed := redstoneOpen(t.Open, oldPower, newPower)
+	if !changed {
+		return
+	}
+	t.Open = open
+	tx.SetBlock(pos, t, nil)
+	if t.Open {
+		tx.PlaySound(pos.Vec3Centre(), sound.TrapdoorOpen{Block: t})
+		return
+	}
+	tx.PlaySound(pos.Vec3Centre(), sound.TrapdoorClose{Block: t})
+}
+
 // BreakInfo ...
 func (t WoodTrapdoor) BreakInfo() BreakInfo {
 	return newBreakInfo(3, alwaysHarvestable, axeEffective, oneOf(t))
@@ -2,13 +2,10 @@ package cmd
 
 import (
 	"fmt"
-	"github.com/df-mc/dragonfly/server/internal/sliceutil"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
-	"math/rand/v2"
 	"reflect"
 	"slices"
-	"sort"
 	"strconv"
 	"strings"
 )
@@ -116,7 +113,7 @@ func (p parser) parseArgument(line *Line, v reflect.Value, optional bool, name s
 	case bool:
 		err = p.bool(line, v)
 	case mgl64.Vec3:
-		err = p.vec3(line, v)
+		err = p.vec3(line, v, source)
 	case Varargs:
 		err = p.varargs(line, v)
 	case []Target:
@@ -236,17 +233,41 @@ func (p parser) sub(line *Line, name string) error {
 	return MessageParameterInvalid.F(arg)
 }
 
-// vec3 ...
-func (p parser) vec3(line *Line, v reflect.Value) error {
-	if err := p.float(line, v.Index(0)); err != nil {
-		return err
+// vec3 parses three coordinates into a mgl64.Vec3. Coordinates prefixed with a '~' are relative to the
+// position of the Source, so that '~ ~1 ~' resolves to one block above it.
+func (p parser) vec3(line *Line, v reflect.Value, source Source) error {
+	pos := source.Position()
+	for i := range 3 {
+		if i > 0 {
+			line.RemoveNext()
+		}
+		if err := p.coordinate(line, v.Index(i), pos[i]); err != nil {
+			return err
+		}
 	}
-	line.RemoveNext()
-	if err := p.float(line, v.Index(1)); err != nil {
-		return err
+	return nil
+}
+
+// coordinate parses a single, possibly relative, coordinate. Relative coordinates are added to base.
+func (p parser) coordinate(line *Line, v reflect.Value, base float64) error {
+	arg, ok := line.Next()
+	if !ok {
+		return line.UsageError()
+	}
+	num, relative := strings.CutPrefix(arg, "~")
+	if relative && num == "" {
+		v.SetFloat(base)
+		return nil
+	}
+	value, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return MessageNumberInvalid.F(arg)
+	}
+	if relative {
+		value += base
 	}
-	line.RemoveNext()
-	return p.float(line, v.Index(2))
+	v.SetFloat(value)
+	return nil
 }
 
 // varargs ...
@@ -268,48 +289,31 @@ func (p parser) targets(line *Line, v reflect.Value, tx *world.Tx) error {
 	return nil
 }
 
-// parseTargets parses one or more Targets from the Line passed.
+// parseTargets parses one or more Targets from the Line passed. Arguments starting with an '@' are parsed
+// as target selectors, such as '@e[type=zombie,r=10]'. Other arguments are parsed as the name of a player.
 func (p parser) parseTargets(line *Line, tx *world.Tx) ([]Target, error) {
-	entities, players := targets(tx)
 	first, ok := line.Next()
 	if !ok {
 		return nil, line.UsageError()
 	}
-	if strings.HasPrefix(first, "@") && tx == nil {
-		return nil, MessageNoTargets.F()
-	}
-	switch first[:min(len(first), 2)] {
-	case "@p":
-		pos := line.src.Position()
-		playerDistances := make([]float64, len(players))
-		for i, p := range players {
-			playerDistances[i] = p.Position().Sub(pos).Len()
-		}
-		sort.Slice(players, func(i, j int) bool {
-			return playerDistances[i] < playerDistances[j]
-		})
-		if len(players) == 0 {
-			return nil, nil
-		}
-		return sliceutil.Convert[Target](players[0:1]), nil
-	case "@e":
-		return entities, nil
-	case "@a":
-		return sliceutil.Convert[Target](players), nil
-	case "@s":
-		return []Target{line.src}, nil
-	case "@r":
-		if len(players) == 0 {
-			return nil, nil
-		}
-		return []Target{players[rand.IntN(len(players))]}, nil
-	default:
-		target, err := p.parsePlayer(first, players)
+	if !strings.HasPrefix(first, "@") {
+		target, err := p.parsePlayer(first, players(tx))
 		if err != nil {
 			return nil, err
 		}
 		return []Target{target}, nil
 	}
+	if tx == nil {
+		return nil, MessageNoTargets.F()
+	}
+	expr, n := selectorExpression(line)
+	s, err := parseSelector(expr, line.src)
+	if err != nil {
+		return nil, err
+	}
+	// The last argument of the selector is removed by parseArgument.
+	line.RemoveN(n - 1)
+	return s.resolve(line.src, tx), nil
 }
 
 // parsePlayer attempts to find a target whose name matches the name passed.
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// AuditEntry is an entry in the audit log of executed commands, holding who
+// executed a command, where it was executed and what its result was.
+type AuditEntry struct {
+	// Time is the time at which the command was executed.
+	Time time.Time
+	// Source is the name of the Source that executed the command. It is empty
+	// if the Source does not implement NamedTarget.
+	Source string
+	// Command is the name of the command executed.
+	Command string
+	// Args holds the arguments that the command was executed with.
+	Args string
+	// World is the name of the world that the command was executed in. It is
+	// empty if the command was not executed in a world.
+	World string
+	// Position is the position of the Source at the time of execution.
+	Position mgl64.Vec3
+	// Success specifies if the command was executed successfully, meaning its
+	// output holds no errors.
+	Success bool
+	// Messages and Errors hold the (success) messages and the errors of the
+	// output of the command.
+	Messages, Errors []string
+	// Redacted specifies if the arguments and the output of the command were
+	// removed from the entry.
+	Redacted bool
+}
+
+// Redact returns a copy of the AuditEntry with its arguments and output
+// removed, such as for commands that are passed a password.
+func (e AuditEntry) Redact() AuditEntry {
+	e.Args, e.Messages, e.Errors, e.Redacted = "", nil, nil, true
+	return e
+}
+
+// Auditor passes an AuditEntry for every command executed to its Sink. An
+// Auditor is enabled using SetAuditor.
+type Auditor struct {
+	// Sink is called with an AuditEntry for every command executed, after its
+	// output was sent to its Source. Sink may be called concurrently for
+	// commands executed in different worlds.
+	Sink func(e AuditEntry)
+	// Filter is called with every AuditEntry before it is passed to the Sink.
+	// It may change the entry, for example by calling AuditEntry.Redact, or
+	// return false to leave it out of the audit log completely. If nil, all
+	// entries are passed to the Sink unchanged.
+	Filter func(e AuditEntry) (AuditEntry, bool)
+}
+
+// auditor holds the Auditor set using SetAuditor.
+var auditor atomic.Pointer[Auditor]
+
+// SetAuditor sets the Auditor that all commands executed are passed to.
+// Passing nil disables auditing.
+func SetAuditor(a *Auditor) {
+	auditor.Store(a)
+}
+
+// RedactCommands returns a filter for Auditor.Filter that redacts the entries
+// of the commands with the names passed.
+func RedactCommands(names ...string) func(e AuditEntry) (AuditEntry, bool) {
+	return func(e AuditEntry) (AuditEntry, bool) {
+		if slices.Contains(names, e.Command) {
+			return e.Redact(), true
+		}
+		return e, true
+	}
+}
+
+// AuditWriter returns a sink for Auditor.Sink that writes every AuditEntry
+// to w as a line of JSON, such as to a log file. Errors writing to w are
+// ignored.
+func AuditWriter(w io.Writer) func(e AuditEntry) {
+	var mu sync.Mutex
+	return func(e AuditEntry) {
+		b, err := json.Marshal(e)
+		if err != nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = w.Write(append(b, '\n'))
+	}
+}
+
+// audit passes an AuditEntry for the execution of the Command passed to the
+// Auditor set, if any.
+func audit(cmd Command, args string, source Source, o *Output, tx *world.Tx) {
+	a := auditor.Load()
+	if a == nil || a.Sink == nil {
+		return
+	}
+	e := AuditEntry{
+		Time:     time.Now(),
+		Command:  cmd.name,
+		Args:     args,
+		Position: source.Position(),
+		Success:  o.ErrorCount() == 0,
+	}
+	if named, ok := source.(NamedTarget); ok {
+		e.Source = named.Name()
+	}
+	if tx != nil {
+		e.World = tx.World().Name()
+	}
+	for _, m := range o.Messages() {
+		e.Messages = append(e.Messages, m.String())
+	}
+	for _, err := range o.Errors() {
+		e.Errors = append(e.Errors, err.Error())
+	}
+	if a.Filter != nil {
+		var ok bool
+		if e, ok = a.Filter(e); !ok {
+			return
+		}
+	}
+	a.Sink(e)
+}
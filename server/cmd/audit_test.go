@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// auditTestEcho prints the message passed to it, or fails if it is empty.
+type auditTestEcho struct {
+	Message Varargs `cmd:"message"`
+}
+
+func (e auditTestEcho) Run(_ Source, o *Output, _ *world.Tx) {
+	if e.Message == "fail" {
+		o.Errorf("echo failed")
+		return
+	}
+	o.Printf("echo: %v", e.Message)
+}
+
+func TestCommandAudit(t *testing.T) {
+	Register(New("audittest", "", nil, auditTestEcho{}))
+	Register(New("auditsecret", "", nil, auditTestEcho{}))
+
+	var entries []AuditEntry
+	SetAuditor(&Auditor{
+		Sink: func(e AuditEntry) { entries = append(entries, e) },
+		Filter: func(e AuditEntry) (AuditEntry, bool) {
+			if e.Args == "ignored" {
+				return e, false
+			}
+			return RedactCommands("auditsecret")(e)
+		},
+	})
+	t.Cleanup(func() { SetAuditor(nil) })
+
+	w := world.Config{Synchronous: true}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	pos := mgl64.Vec3{1, 64, -2.5}
+	src := NewVirtualSource("auditor", pos, w, PermissionLevelOwner)
+	if err := w.Do(func(tx *world.Tx) {
+		ExecuteLine("/audittest hello world", src, tx)
+		ExecuteLine("/audittest fail", src, tx)
+		ExecuteLine("/auditsecret hunter2", src, tx)
+		ExecuteLine("/audittest ignored", src, tx)
+	}).Wait(context.Background()); err != nil {
+		t.Fatalf("world task failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("%v audit entries for 4 commands with one filtered out, want 3", len(entries))
+	}
+
+	e := entries[0]
+	if e.Source != "auditor" || e.Command != "audittest" || e.Args != "hello world" || e.World != w.Name() || e.Position != pos {
+		t.Errorf("audit entry = %+v, want source auditor, command audittest, args \"hello world\", world %q and position %v", e, w.Name(), pos)
+	}
+	if !e.Success || !slices.Equal(e.Messages, []string{"echo: hello world"}) || len(e.Errors) != 0 || e.Redacted || e.Time.IsZero() {
+		t.Errorf("audit entry result = %+v, want a successful, unredacted entry with output \"echo: hello world\"", e)
+	}
+	if e := entries[1]; e.Success || !slices.Equal(e.Errors, []string{"echo failed"}) {
+		t.Errorf("audit entry of a failed command = %+v, want an unsuccessful entry with error \"echo failed\"", e)
+	}
+
+	e = entries[2]
+	if e.Command != "auditsecret" || !e.Redacted || e.Args != "" || len(e.Messages) != 0 || len(e.Errors) != 0 {
+		t.Errorf("audit entry of a redacted command = %+v, want its arguments and output removed", e)
+	}
+	if e.Source != "auditor" || e.World != w.Name() || !e.Success {
+		t.Errorf("audit entry of a redacted command = %+v, want its source, world and result kept", e)
+	}
+
+	var buf bytes.Buffer
+	AuditWriter(&buf)(e)
+	var decoded AuditEntry
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || decoded.Command != "auditsecret" || !decoded.Redacted || bytes.Contains(buf.Bytes(), []byte("hunter2")) {
+		t.Errorf("audit writer wrote %q, want a redacted JSON entry of auditsecret", buf.String())
+	}
+}
@@ -132,6 +132,7 @@ func (cmd Command) Execute(args string, source Source, tx *world.Tx) {
 		panic("execute: invalid command source: source must not be nil")
 	}
 	output := &Output{}
+	defer audit(cmd, args, source, output, tx)
 	defer source.SendCommandOutput(output)
 
 	var leastErroneous error
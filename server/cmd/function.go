@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Function is a list of command lines, typically loaded from an .mcfunction file, that may be run as a
+// whole by a Source. Functions may be registered using RegisterFunction, after which they may be run using
+// the command returned by FunctionCommand.
+type Function struct {
+	name  string
+	lines []string
+}
+
+// NewFunction creates a Function with the name and command lines passed. Empty lines and lines starting
+// with a '#' are ignored.
+func NewFunction(name string, lines []string) Function {
+	f := Function{name: strings.ToLower(name)}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		f.lines = append(f.lines, line)
+	}
+	return f
+}
+
+// ReadFunction reads a Function with the name passed from r. Each line read from r is a command line, with
+// or without a leading slash.
+func ReadFunction(name string, r io.Reader) (Function, error) {
+	var lines []string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		lines = append(lines, s.Text())
+	}
+	if err := s.Err(); err != nil {
+		return Function{}, fmt.Errorf("read function %v: %w", name, err)
+	}
+	return NewFunction(name, lines), nil
+}
+
+// LoadFunctions loads all .mcfunction files found in the directory passed and its subdirectories and
+// registers them using RegisterFunction. The name of each function is its path relative to dir without the
+// file extension, with forward slashes as separators, such as 'lobby/reset'.
+func LoadFunctions(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".mcfunction" {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("load functions: %w", err)
+		}
+		defer f.Close()
+
+		fn, err := ReadFunction(filepath.ToSlash(strings.TrimSuffix(rel, ".mcfunction")), f)
+		if err != nil {
+			return err
+		}
+		RegisterFunction(fn)
+		return nil
+	})
+}
+
+// Name returns the name of the Function. The name is guaranteed to be lowercase.
+func (f Function) Name() string {
+	return f.name
+}
+
+// Lines returns the command lines of the Function in the order that they are executed.
+func (f Function) Lines() []string {
+	return slices.Clone(f.lines)
+}
+
+// MaxFunctionDepth is the maximum depth of functions run by other functions. Functions nested deeper, such
+// as those of a function that runs itself, are not run.
+const MaxFunctionDepth = 64
+
+// Run runs all command lines of the Function as the Source passed, in order. No more than limit command
+// lines are run, including lines of functions run by the Function itself. If limit is 0 or lower, no limit
+// is applied. Functions run by the Function are nested no deeper than MaxFunctionDepth. The number of
+// command lines run is returned.
+func (f Function) Run(src Source, tx *world.Tx, limit int) int {
+	if limit <= 0 {
+		limit = -1
+	}
+	return f.run(src, tx, &limit, 0)
+}
+
+// run runs the command lines of the Function, decrementing the remaining limit for every line executed.
+// depth is the number of functions that the Function was nested in.
+func (f Function) run(src Source, tx *world.Tx, remaining *int, depth int) (n int) {
+	for _, line := range f.lines {
+		if *remaining == 0 {
+			break
+		}
+		*remaining--
+		n++
+
+		if name, ok := nestedFunction(line); ok {
+			// Nested functions share the command limit of the function they were run from, so they are run
+			// directly instead of through the function command.
+			if nested, ok := FunctionByName(name); ok {
+				if depth < MaxFunctionDepth {
+					n += nested.run(src, tx, remaining, depth+1)
+				}
+				continue
+			}
+		}
+		ExecuteLine(line, src, tx)
+	}
+	return n
+}
+
+// nestedFunction checks if the command line passed runs a function and returns its name if so.
+func nestedFunction(line string) (string, bool) {
+	args := strings.Fields(strings.TrimPrefix(line, "/"))
+	if len(args) != 2 || strings.ToLower(args[0]) != "function" {
+		return "", false
+	}
+	return strings.ToLower(args[1]), true
+}
+
+// ExecuteLine parses a full command line, such as '/say hello', and executes the command it refers to as
+// the Source passed. The leading slash is optional. If no command could be found with the name in the
+// command line, an error is sent to the Source and false is returned.
+func ExecuteLine(commandLine string, src Source, tx *world.Tx) bool {
+	name, args, _ := strings.Cut(strings.TrimPrefix(strings.TrimSpace(commandLine), "/"), " ")
+	command, ok := ByAlias(strings.ToLower(name))
+	if !ok {
+		o := &Output{}
+		o.Errort(MessageUnknown, name)
+		src.SendCommandOutput(o)
+		return false
+	}
+	command.Execute(args, src, tx)
+	return true
+}
+
+var (
+	// functions holds all functions registered using RegisterFunction, indexed by their name.
+	functions   = map[string]Function{}
+	functionsMu sync.RWMutex
+)
+
+// RegisterFunction registers a Function so that it may be run using the command returned by
+// FunctionCommand. A function with the same name that was registered previously is overwritten.
+func RegisterFunction(f Function) {
+	functionsMu.Lock()
+	defer functionsMu.Unlock()
+	functions[f.name] = f
+}
+
+// FunctionByName looks up a Function registered using RegisterFunction by its name.
+func FunctionByName(name string) (Function, bool) {
+	functionsMu.RLock()
+	defer functionsMu.RUnlock()
+	f, ok := functions[strings.ToLower(name)]
+	return f, ok
+}
+
+// Functions returns all functions registered using RegisterFunction.
+func Functions() []Function {
+	functionsMu.RLock()
+	defer functionsMu.RUnlock()
+	return slices.Collect(maps.Values(functions))
+}
+
+// FunctionCommand returns a Command named 'function' that runs a Function registered using
+// RegisterFunction. The command is not registered automatically: It may be registered using Register.
+func FunctionCommand() Command {
+	return New("function", "Runs commands found in the corresponding function file.", nil, functionCommand{})
+}
+
+// functionCommand implements the Runnable of the command returned by FunctionCommand.
+type functionCommand struct {
+	Name functionName `cmd:"name"`
+}
+
+// Run ...
+func (f functionCommand) Run(src Source, o *Output, tx *world.Tx) {
+	fn, ok := FunctionByName(string(f.Name))
+	if !ok {
+		o.Errorf("Unknown function: %v", f.Name)
+		return
+	}
+	limit := 0
+	if tx != nil {
+		limit = tx.World().FunctionCommandLimit()
+	}
+	o.Printf("Successfully executed %v function entries.", fn.Run(src, tx, limit))
+}
+
+// functionName is an Enum with the names of all registered functions as options.
+type functionName string
+
+// Type ...
+func (functionName) Type() string {
+	return "FunctionName"
+}
+
+// Options ...
+func (functionName) Options(Source) []string {
+	fns := Functions()
+	names := make([]string, 0, len(fns))
+	for _, f := range fns {
+		names = append(names, f.name)
+	}
+	slices.Sort(names)
+	return names
+}
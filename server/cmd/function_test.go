@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestFunctionRunLimitsDepth(t *testing.T) {
+	RegisterFunction(NewFunction("function_test/recurse", []string{"function function_test/recurse"}))
+	fn, _ := FunctionByName("function_test/recurse")
+
+	src := NewVirtualSource("test", mgl64.Vec3{}, nil, PermissionLevelAdmin)
+	if n := fn.Run(src, nil, 0); n != MaxFunctionDepth+1 {
+		t.Errorf("function running itself without limit ran %v lines, want %v", n, MaxFunctionDepth+1)
+	}
+	if n := fn.Run(src, nil, 10); n != 10 {
+		t.Errorf("function running itself with a limit of 10 ran %v lines, want 10", n)
+	}
+}
@@ -0,0 +1,413 @@
+package cmd
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// TaggedTarget is a Target that has tags, which may be filtered on by target selectors using the 'tag'
+// argument.
+type TaggedTarget interface {
+	Target
+	// Tags returns the tags of the Target.
+	Tags() []string
+}
+
+// ScoredTarget is a Target that has scores for scoreboard objectives, which may be filtered on by target
+// selectors using the 'scores' argument.
+type ScoredTarget interface {
+	Target
+	// Score returns the score of the Target for the objective with the name passed. False is returned if
+	// the Target has no score for the objective.
+	Score(objective string) (int, bool)
+}
+
+// selectorSort is the order in which the targets matched by a selector are selected.
+type selectorSort int
+
+const (
+	sortArbitrary selectorSort = iota
+	sortNearest
+	sortFurthest
+	sortRandom
+)
+
+// selectorFilter is a, possibly negated, value of a selector argument such as 'type=!zombie'.
+type selectorFilter struct {
+	value  string
+	negate bool
+}
+
+// scoreFilter is a filter of the 'scores' selector argument, such as 'points=1..5'.
+type scoreFilter struct {
+	objective string
+	min, max  int
+	negate    bool
+}
+
+// selector is a target selector, such as '@e[type=zombie,r=10,c=2]', parsed from a command line. Targets
+// are selected by variable: '@s' selects the Source itself, '@a' and '@p' select players, '@r' selects a
+// random player and '@e' selects all entities. The arguments between the brackets narrow down the targets
+// selected.
+type selector struct {
+	variable byte
+
+	base             mgl64.Vec3
+	minDist, maxDist float64
+	volume           *mgl64.Vec3
+
+	limit int
+	sort  selectorSort
+
+	types, names, tags []selectorFilter
+	scores             []scoreFilter
+}
+
+// selectorExpression reads a full selector, such as '@e[type=zombie, r=10]', from the Line passed. Because
+// arguments are split by spaces, a selector with spaces between its arguments spans multiple arguments of
+// the Line. The full selector and the number of arguments it spans are returned.
+func selectorExpression(line *Line) (string, int) {
+	args, _ := line.NextN(line.Len())
+	depth := 0
+	for i, arg := range args {
+		depth += strings.Count(arg, "[") - strings.Count(arg, "]")
+		if depth <= 0 {
+			return strings.Join(args[:i+1], " "), i + 1
+		}
+	}
+	return strings.Join(args, " "), len(args)
+}
+
+// parseSelector parses a selector from the expression passed. Relative coordinates in the selector are
+// resolved against the position of the Source passed.
+func parseSelector(expr string, src Source) (selector, error) {
+	if len(expr) < 2 || !strings.ContainsRune("spare", rune(expr[1])) {
+		return selector{}, MessageParameterInvalid.F(expr)
+	}
+	s := selector{variable: expr[1], base: src.Position(), maxDist: -1}
+	switch s.variable {
+	case 'p':
+		s.sort, s.limit = sortNearest, 1
+	case 'r':
+		s.sort, s.limit = sortRandom, 1
+	}
+
+	rest := strings.TrimSpace(expr[2:])
+	if rest == "" {
+		return s, nil
+	}
+	if !strings.HasPrefix(rest, "[") || !strings.HasSuffix(rest, "]") {
+		return selector{}, MessageParameterInvalid.F(expr)
+	}
+	sorted, limit := false, 0
+	for _, arg := range splitSelectorArguments(rest[1 : len(rest)-1]) {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return selector{}, MessageParameterInvalid.F(arg)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		var err error
+		switch key {
+		case "x", "y", "z":
+			i := int(key[0] - 'x')
+			s.base[i], err = selectorCoordinate(value, src.Position()[i])
+		case "r":
+			s.maxDist, err = selectorFloat(value)
+		case "rm":
+			s.minDist, err = selectorFloat(value)
+		case "dx", "dy", "dz":
+			if s.volume == nil {
+				s.volume = &mgl64.Vec3{}
+			}
+			s.volume[key[1]-'x'], err = selectorFloat(value)
+		case "c":
+			limit, err = strconv.Atoi(value)
+			if err != nil || limit == 0 {
+				err = MessageNumberInvalid.F(value)
+			}
+		case "sort":
+			sorted = true
+			switch value {
+			case "nearest":
+				s.sort = sortNearest
+			case "furthest":
+				s.sort = sortFurthest
+			case "random":
+				s.sort = sortRandom
+			case "arbitrary":
+				s.sort = sortArbitrary
+			default:
+				err = MessageParameterInvalid.F(value)
+			}
+		case "type":
+			s.types = append(s.types, newSelectorFilter(value))
+		case "name":
+			s.names = append(s.names, newSelectorFilter(strings.Trim(value, `"`)))
+		case "tag":
+			s.tags = append(s.tags, newSelectorFilter(value))
+		case "scores":
+			s.scores, err = parseScoreFilters(value)
+		default:
+			err = MessageParameterInvalid.F(arg)
+		}
+		if err != nil {
+			return selector{}, err
+		}
+	}
+	if limit != 0 {
+		// A positive limit selects the targets nearest to the base position and a negative limit the
+		// targets furthest away, unless the order was set explicitly.
+		s.limit = max(limit, -limit)
+		if !sorted && s.sort != sortRandom {
+			s.sort = sortNearest
+			if limit < 0 {
+				s.sort = sortFurthest
+			}
+		}
+	}
+	return s, nil
+}
+
+// splitSelectorArguments splits the arguments of a selector by commas, ignoring commas nested in braces,
+// such as those separating the objectives of the 'scores' argument.
+func splitSelectorArguments(s string) []string {
+	var args []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if strings.TrimSpace(s[start:]) != "" {
+		args = append(args, s[start:])
+	}
+	return args
+}
+
+// newSelectorFilter returns a selectorFilter for the argument value passed, which is negated if prefixed
+// with a '!'.
+func newSelectorFilter(value string) selectorFilter {
+	v, negate := strings.CutPrefix(value, "!")
+	return selectorFilter{value: strings.TrimSpace(v), negate: negate}
+}
+
+// parseScoreFilters parses the value of a 'scores' argument, such as '{points=1..5,deaths=!0}'.
+func parseScoreFilters(value string) ([]scoreFilter, error) {
+	if !strings.HasPrefix(value, "{") || !strings.HasSuffix(value, "}") {
+		return nil, MessageParameterInvalid.F(value)
+	}
+	var filters []scoreFilter
+	for _, arg := range splitSelectorArguments(value[1 : len(value)-1]) {
+		objective, r, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, MessageParameterInvalid.F(arg)
+		}
+		r, negate := strings.CutPrefix(strings.TrimSpace(r), "!")
+		f := scoreFilter{objective: strings.TrimSpace(objective), negate: negate}
+		var err error
+		if f.min, f.max, err = parseRange(r); err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+// parseRange parses an inclusive integer range such as '5', '1..5', '1..' or '..5'.
+func parseRange(s string) (lo, hi int, err error) {
+	lower, upper, isRange := strings.Cut(s, "..")
+	if !isRange {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, 0, MessageNumberInvalid.F(s)
+		}
+		return v, v, nil
+	}
+	lo, hi = math.MinInt, math.MaxInt
+	if lower != "" {
+		if lo, err = strconv.Atoi(lower); err != nil {
+			return 0, 0, MessageNumberInvalid.F(lower)
+		}
+	}
+	if upper != "" {
+		if hi, err = strconv.Atoi(upper); err != nil {
+			return 0, 0, MessageNumberInvalid.F(upper)
+		}
+	}
+	return lo, hi, nil
+}
+
+// selectorFloat parses a float value of a selector argument.
+func selectorFloat(value string) (float64, error) {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, MessageNumberInvalid.F(value)
+	}
+	return v, nil
+}
+
+// selectorCoordinate parses a, possibly relative, coordinate of a selector argument.
+func selectorCoordinate(value string, base float64) (float64, error) {
+	num, relative := strings.CutPrefix(value, "~")
+	if relative && num == "" {
+		return base, nil
+	}
+	v, err := selectorFloat(num)
+	if relative {
+		v += base
+	}
+	return v, err
+}
+
+// resolve returns all Targets selected by the selector when executed by the Source passed.
+func (s selector) resolve(src Source, tx *world.Tx) []Target {
+	var candidates []Target
+	switch {
+	case s.variable == 's':
+		candidates = []Target{src}
+	case s.variable == 'e' || (s.variable == 'r' && slices.ContainsFunc(s.types, func(f selectorFilter) bool { return !f.negate })):
+		// Random selectors only select entities other than players if a type is specified.
+		candidates = s.entities(tx)
+	default:
+		for p := range tx.Players() {
+			candidates = append(candidates, p)
+		}
+	}
+	candidates = slices.DeleteFunc(candidates, func(t Target) bool {
+		return !s.matches(t)
+	})
+
+	switch s.sort {
+	case sortNearest, sortFurthest:
+		slices.SortStableFunc(candidates, func(a, b Target) int {
+			da, db := a.Position().Sub(s.base).Len(), b.Position().Sub(s.base).Len()
+			if s.sort == sortFurthest {
+				da, db = db, da
+			}
+			switch {
+			case da < db:
+				return -1
+			case da > db:
+				return 1
+			}
+			return 0
+		})
+	case sortRandom:
+		rand.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+	}
+	if s.limit > 0 && len(candidates) > s.limit {
+		candidates = candidates[:s.limit]
+	}
+	return candidates
+}
+
+// entities returns all entities that may be selected by the selector. If the selector has a maximum
+// distance, only entities in the chunks within that distance are looked up.
+func (s selector) entities(tx *world.Tx) []Target {
+	it := tx.Entities()
+	if s.maxDist >= 0 && s.volume == nil {
+		it = tx.EntitiesWithin(cube.Box(s.base[0], s.base[1], s.base[2], s.base[0], s.base[1], s.base[2]).Grow(s.maxDist))
+	}
+	var entities []Target
+	for e := range it {
+		entities = append(entities, e)
+	}
+	return entities
+}
+
+// matches checks if the Target passed matches all arguments of the selector.
+func (s selector) matches(t Target) bool {
+	pos := t.Position()
+	if dist := pos.Sub(s.base).Len(); (s.maxDist >= 0 && dist > s.maxDist) || dist < s.minDist {
+		return false
+	}
+	if s.volume != nil {
+		for i := range 3 {
+			lo, hi := s.base[i], s.base[i]+s.volume[i]
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			// The volume spans full blocks, so a target standing in the block at the far edge of the volume
+			// is still selected.
+			if pos[i] < lo || pos[i] >= hi+1 {
+				return false
+			}
+		}
+	}
+	for _, f := range s.types {
+		if matchesType(t, f.value) == f.negate {
+			return false
+		}
+	}
+	for _, f := range s.names {
+		if (targetName(t) == f.value) == f.negate {
+			return false
+		}
+	}
+	for _, f := range s.tags {
+		var tags []string
+		if tagged, ok := t.(TaggedTarget); ok {
+			tags = tagged.Tags()
+		}
+		// 'tag=' selects targets without tags and 'tag=!' targets with at least one tag.
+		has := len(tags) == 0
+		if f.value != "" {
+			has = slices.Contains(tags, f.value)
+		}
+		if has == f.negate {
+			return false
+		}
+	}
+	for _, f := range s.scores {
+		scored, ok := t.(ScoredTarget)
+		if !ok {
+			return false
+		}
+		score, ok := scored.Score(f.objective)
+		if !ok || (score >= f.min && score <= f.max) == f.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesType checks if the Target passed is an entity of the type passed, such as 'zombie' or
+// 'minecraft:zombie'.
+func matchesType(t Target, typ string) bool {
+	e, ok := t.(world.Entity)
+	if !ok {
+		return false
+	}
+	if !strings.Contains(typ, ":") {
+		typ = "minecraft:" + typ
+	}
+	return e.H().Type().EncodeEntity() == typ
+}
+
+// targetName returns the name of the Target passed, or its name tag if it has no name.
+func targetName(t Target) string {
+	if named, ok := t.(NamedTarget); ok {
+		return named.Name()
+	}
+	if tagged, ok := t.(interface{ NameTag() string }); ok {
+		return tagged.NameTag()
+	}
+	return ""
+}
@@ -0,0 +1,141 @@
+package cmd_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// doTx runs f in a transaction of the world passed and waits for it to finish.
+func doTx(t *testing.T, w *world.World, f func(tx *world.Tx)) {
+	t.Helper()
+	if err := w.Do(f).Wait(context.Background()); err != nil {
+		t.Fatalf("world task failed: %v", err)
+	}
+}
+
+// selectorTestRecord records the targets passed to it.
+type selectorTestRecord struct {
+	Targets []cmd.Target `cmd:"targets"`
+	targets *[]cmd.Target
+}
+
+func (r selectorTestRecord) Run(_ cmd.Source, _ *cmd.Output, _ *world.Tx) {
+	*r.targets = r.Targets
+}
+
+func TestTargetSelectorResolution(t *testing.T) {
+	var selected []cmd.Target
+	cmd.Register(cmd.New("selectortest", "", nil, selectorTestRecord{targets: &selected}))
+
+	w := world.Config{Synchronous: true}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	src := cmd.NewVirtualSource("test", mgl64.Vec3{0, 64, 0.5}, w, cmd.PermissionLevelOwner)
+	doTx(t, w, func(tx *world.Tx) {
+		names := map[*world.EntityHandle]string{}
+		for i, x := range []float64{1, 2, 3, 5, 20} {
+			name := string(rune('a' + i))
+			opts := world.EntitySpawnOpts{Position: mgl64.Vec3{x, 64, 0.5}, NameTag: name}
+			names[tx.AddEntity(entity.NewItem(opts, item.NewStack(item.Stick{}, 1))).H()] = name
+		}
+		names[tx.AddEntity(entity.NewText("sign", mgl64.Vec3{4, 64, 0.5})).H()] = "sign"
+
+		cases := []struct {
+			selector string
+			want     []string
+			ordered  bool
+		}{
+			{selector: "@e[type=item,r=10,c=2]", want: []string{"a", "b"}, ordered: true},
+			{selector: "@e[type=minecraft:item,c=-1]", want: []string{"e"}, ordered: true},
+			{selector: "@e[r=10,rm=1.5,sort=furthest,c=2]", want: []string{"d", "sign"}, ordered: true},
+			{selector: "@e[sort=nearest,c=3,x=21]", want: []string{"e", "d", "sign"}, ordered: true},
+			{selector: "@e[type=!item]", want: []string{"sign"}},
+			{selector: "@e[name=b]", want: []string{"b"}},
+			{selector: "@e[type=item, name=!a, r=4]", want: []string{"b", "c"}},
+			{selector: "@e[x=~2,dx=1,dy=0,dz=0]", want: []string{"b", "c"}},
+			{selector: "@e[type=dragonfly:text,name=!sign]", want: nil},
+		}
+		for _, c := range cases {
+			selected = nil
+			cmd.ExecuteLine("/selectortest "+c.selector, src, tx)
+			var got []string
+			for _, target := range selected {
+				got = append(got, names[target.(world.Entity).H()])
+			}
+			if !c.ordered {
+				slices.Sort(got)
+			}
+			if !slices.Equal(got, c.want) {
+				t.Errorf("%v selected %v, want %v", c.selector, got, c.want)
+			}
+		}
+
+		selected = nil
+		cmd.ExecuteLine("/selectortest @s", src, tx)
+		if len(selected) != 1 || selected[0] != cmd.Target(src) {
+			t.Errorf("@s selected %v, want the source", selected)
+		}
+	})
+}
+
+func TestTargetSelectorTagsAndScores(t *testing.T) {
+	var selected []cmd.Target
+	cmd.Register(cmd.New("selectortagtest", "", nil, selectorTestRecord{targets: &selected}))
+
+	objectives := world.NewObjectives()
+	points, _ := objectives.Create("points", "Points", world.ObjectiveCriteriaDummy)
+	w := world.Config{Synchronous: true, Objectives: objectives}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	src := cmd.NewVirtualSource("test", mgl64.Vec3{}, w, cmd.PermissionLevelOwner)
+	doTx(t, w, func(tx *world.Tx) {
+		names := map[*world.EntityHandle]string{}
+		spawn := func(name string, score int, tags ...string) {
+			e := tx.AddEntity(entity.NewText(name, mgl64.Vec3{})).(*entity.Ent)
+			for _, tag := range tags {
+				e.AddTag(tag)
+			}
+			if score >= 0 {
+				points.SetScore(e, score)
+			}
+			names[e.H()] = name
+		}
+		spawn("a", 1, "red")
+		spawn("b", 5, "red", "leader")
+		spawn("c", 10, "blue")
+		spawn("d", -1)
+
+		cases := []struct {
+			selector string
+			want     []string
+		}{
+			{selector: "@e[tag=red]", want: []string{"a", "b"}},
+			{selector: "@e[tag=red,tag=!leader]", want: []string{"a"}},
+			{selector: "@e[tag=]", want: []string{"d"}},
+			{selector: "@e[tag=!]", want: []string{"a", "b", "c"}},
+			{selector: "@e[scores={points=2..}]", want: []string{"b", "c"}},
+			{selector: "@e[scores={points=..5}]", want: []string{"a", "b"}},
+			{selector: "@e[scores={points=!1..5}]", want: []string{"c"}},
+			{selector: "@e[tag=red,scores={points=5}]", want: []string{"b"}},
+		}
+		for _, c := range cases {
+			selected = nil
+			cmd.ExecuteLine("/selectortagtest "+c.selector, src, tx)
+			var got []string
+			for _, target := range selected {
+				got = append(got, names[target.(world.Entity).H()])
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, c.want) {
+				t.Errorf("%v selected %v, want %v", c.selector, got, c.want)
+			}
+		}
+	})
+}
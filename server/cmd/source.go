@@ -1,5 +1,13 @@
 package cmd
 
+import (
+	"slices"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
 // Source represents a source of a command execution. Commands may limit the sources that can run them by
 // implementing the Allower interface.
 // Source implements Target. A Source must always be able to target itself.
@@ -10,3 +18,117 @@ type Source interface {
 	// SendCommandOutput is called by a Command automatically after being run.
 	SendCommandOutput(o *Output)
 }
+
+// PermissionLevel is the level of permission that a Source has to execute commands. Commands may check the
+// permission level of a Source in their Allow method using SourcePermissionLevel.
+type PermissionLevel int
+
+const (
+	// PermissionLevelAny is the permission level of sources that may only execute commands anyone may run.
+	PermissionLevelAny PermissionLevel = iota
+	// PermissionLevelGameDirectors is the permission level of sources such as command blocks, which may run
+	// commands that change the game, but not commands that manage the server.
+	PermissionLevelGameDirectors
+	// PermissionLevelAdmin is the permission level of sources that may run commands that manage players.
+	PermissionLevelAdmin
+	// PermissionLevelHost is the permission level of sources that may run commands that manage the server.
+	PermissionLevelHost
+	// PermissionLevelOwner is the permission level of sources that may run any command, such as the console.
+	PermissionLevelOwner
+)
+
+// SourcePermissionLevel returns the PermissionLevel of the Source passed. If the Source does not implement a
+// PermissionLevel method, PermissionLevelAny is returned.
+func SourcePermissionLevel(src Source) PermissionLevel {
+	if p, ok := src.(interface{ PermissionLevel() PermissionLevel }); ok {
+		return p.PermissionLevel()
+	}
+	return PermissionLevelAny
+}
+
+// VirtualSource is a Source that is not backed by a player or other entity, such as a command block, a
+// function run by the server or a remote console. A VirtualSource has a fixed name, position, world and
+// PermissionLevel. Relative coordinates in commands it executes, such as '~ ~1 ~', are resolved against its
+// position. The output of the commands executed is captured by the VirtualSource and, if set, passed to its
+// output sink, so that it is never broadcast to players.
+// A VirtualSource is safe for concurrent use.
+type VirtualSource struct {
+	name  string
+	pos   mgl64.Vec3
+	w     *world.World
+	level PermissionLevel
+	sink  func(o *Output)
+
+	mu      sync.Mutex
+	outputs []*Output
+}
+
+// NewVirtualSource creates a VirtualSource with the name, position, world and PermissionLevel passed. w may
+// be nil for sources that are not attached to a world.
+func NewVirtualSource(name string, pos mgl64.Vec3, w *world.World, level PermissionLevel) *VirtualSource {
+	return &VirtualSource{name: name, pos: pos, w: w, level: level}
+}
+
+// WithSink returns a copy of the VirtualSource that passes the output of every command it executes to sink,
+// such as a function writing the output to a remote console connection. The copy does not share the output
+// captured by the original VirtualSource.
+func (s *VirtualSource) WithSink(sink func(o *Output)) *VirtualSource {
+	return &VirtualSource{name: s.name, pos: s.pos, w: s.w, level: s.level, sink: sink}
+}
+
+// WithPosition returns a copy of the VirtualSource positioned at pos, as done when executing a command
+// relative to another position. The copy shares the output sink of the original VirtualSource, but not the
+// output it captured.
+func (s *VirtualSource) WithPosition(pos mgl64.Vec3) *VirtualSource {
+	return &VirtualSource{name: s.name, pos: pos, w: s.w, level: s.level, sink: s.sink}
+}
+
+// Name returns the name of the VirtualSource.
+func (s *VirtualSource) Name() string {
+	return s.name
+}
+
+// Position returns the position of the VirtualSource.
+func (s *VirtualSource) Position() mgl64.Vec3 {
+	return s.pos
+}
+
+// World returns the world of the VirtualSource, or nil if it is not attached to a world.
+func (s *VirtualSource) World() *world.World {
+	return s.w
+}
+
+// PermissionLevel returns the PermissionLevel of the VirtualSource.
+func (s *VirtualSource) PermissionLevel() PermissionLevel {
+	return s.level
+}
+
+// SendCommandOutput captures the output of a command executed by the VirtualSource and passes it to its
+// output sink, if it has one.
+func (s *VirtualSource) SendCommandOutput(o *Output) {
+	s.mu.Lock()
+	s.outputs = append(s.outputs, o)
+	s.mu.Unlock()
+	if s.sink != nil {
+		s.sink(o)
+	}
+}
+
+// Outputs returns the outputs of all commands executed by the VirtualSource, in the order that they were
+// executed.
+func (s *VirtualSource) Outputs() []*Output {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.outputs)
+}
+
+// LastOutput returns the output of the last command executed by the VirtualSource. False is returned if the
+// VirtualSource did not execute any commands yet.
+func (s *VirtualSource) LastOutput() (*Output, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.outputs) == 0 {
+		return nil, false
+	}
+	return s.outputs[len(s.outputs)-1], true
+}
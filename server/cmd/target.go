@@ -21,12 +21,10 @@ type NamedTarget interface {
 	Name() string
 }
 
-// targets returns all Targets selectable by the Source passed.
-func targets(tx *world.Tx) (entities []Target, players []NamedTarget) {
+// players returns all players in the world of the transaction passed as NamedTargets.
+func players(tx *world.Tx) []NamedTarget {
 	if tx == nil {
-		return nil, nil
+		return nil
 	}
-	ent := sliceutil.Convert[Target](slices.Collect(tx.Entities()))
-	pl := sliceutil.Convert[NamedTarget](slices.Collect(tx.Players()))
-	return ent, pl
+	return sliceutil.Convert[NamedTarget](slices.Collect(tx.Players()))
 }
@@ -10,6 +10,7 @@ import (
 	_ "unsafe"
 
 	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/cmd"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/internal/packbuilder"
 	"github.com/df-mc/dragonfly/server/player"
@@ -21,6 +22,7 @@ import (
 	"github.com/df-mc/dragonfly/server/world/mcdb"
 	"github.com/google/uuid"
 	"github.com/sandertv/gophertunnel/minecraft"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 	"github.com/sandertv/gophertunnel/minecraft/resource"
 )
@@ -53,7 +55,8 @@ type Config struct {
 	DisableResourceBuilding bool
 	// Allower may be used to specify what players can join the server and what
 	// players cannot. By returning false in the Allow method, for example if
-	// the player has been banned, will prevent the player from joining.
+	// the player has been banned, will prevent the player from joining. An
+	// AccessList may be used to keep an allowlist and bans of players.
 	Allower Allower
 	// AuthDisabled specifies if XBOX Live authentication should be disabled.
 	// Note that this should generally only be done for testing purposes or for
@@ -62,12 +65,54 @@ type Config struct {
 	AuthDisabled bool
 	// MuteEmoteChat specifies if the player emote chat should be muted or not.
 	MuteEmoteChat bool
+	// CommandAuditor, if not nil, is passed an entry for every command that is
+	// executed on the server, for example to keep an audit log of commands
+	// run by players. See cmd.Auditor for more information.
+	CommandAuditor *cmd.Auditor
+	// RecipeUnlocking specifies if the recipe book of players only shows the
+	// recipes they unlocked using player.Player.UnlockRecipes. If false, all
+	// recipes are shown.
+	RecipeUnlocking bool
+	// CraftingAutoRefill specifies if the crafting grid of players is refilled
+	// from their inventory after crafting, so that the same recipe may be
+	// crafted repeatedly.
+	CraftingAutoRefill bool
+	// DisableInventoryValidation disables verifying that inventory actions of
+	// players that do not craft items conserve the items moved around. With
+	// validation enabled, actions that would duplicate items are rejected.
+	DisableInventoryValidation bool
+	// MovementPolicy is the player.MovementPolicy that the movement and
+	// interactions of players are validated against when they join. The
+	// zero value only limits the reach of players to the default distances.
+	MovementPolicy player.MovementPolicy
 	// MaxPlayers is the maximum amount of players allowed to join the server at
 	// once.
 	MaxPlayers int
+	// ReservedSlots is the amount of additional slots above MaxPlayers that may
+	// only be used by players for which Reserved returns true. ReservedSlots has
+	// no effect if MaxPlayers is 0.
+	ReservedSlots int
+	// Reserved returns true if the player with the identity data passed may
+	// join the server using one of its ReservedSlots when it is full. Reserved
+	// players also skip the queue. If nil, no players may use reserved slots.
+	Reserved func(d login.IdentityData) bool
+	// QueueWhenFull specifies if players trying to join the server while it is
+	// full should be queued until a slot frees up, instead of being
+	// disconnected immediately. Queued players are kept on the loading screen
+	// and are sent QueueMessage with their position in the queue regularly.
+	QueueWhenFull bool
+	// QueueMessage is the message sent to players waiting in the queue. It must
+	// have exactly 1 formatting verb, which is replaced with the position of the
+	// player in the queue. QueueMessage is set to a default message if empty.
+	QueueMessage string
 	// MaxChunkRadius is the maximum view distance that each player may have,
 	// measured in chunks. A chunk radius generally leads to more memory usage.
 	MaxChunkRadius int
+	// ChunksPerTick is the maximum number of chunks sent to each player every
+	// tick. Chunks closest to the player are sent first. Lower values spread
+	// the bandwidth used when a player joins or teleports over more ticks.
+	// ChunksPerTick is 4 if left empty.
+	ChunksPerTick int
 	// JoinMessage, QuitMessage and ShutdownMessage are the messages to send for
 	// when a player joins or quits the server and when the server shuts down,
 	// kicking all online players. If set, JoinMessage and QuitMessage must have
@@ -100,12 +145,21 @@ type Config struct {
 	// of the dimensions (with netherrack and end stone for nether/end
 	// respectively).
 	Generator func(dim world.Dimension) world.Generator
+	// BiomeSource may return a world.BiomeSource for a world.Dimension that
+	// overrides the biomes generated by the Generator of that dimension. If
+	// nil, or if it returns nil, the biomes of the Generator are used.
+	BiomeSource func(dim world.Dimension) world.BiomeSource
 	// RandomTickSpeed specifies the rate at which blocks should be ticked in
 	// the default worlds. Setting this value to -1 or lower will stop random
 	// ticking altogether, while setting it higher results in faster ticking. If
 	// left as 0, the RandomTickSpeed will default to a speed of 3 blocks per
 	// sub chunk per tick (normal ticking speed).
 	RandomTickSpeed int
+	// ScheduledTickBudget is the maximum number of scheduled block updates
+	// processed per tick in the default worlds. Updates exceeding the budget
+	// are spread round-robin across chunks and deferred to the next tick. If
+	// set to 0 or lower, all updates due are processed every tick.
+	ScheduledTickBudget int
 	// SaveInterval specifies how often a World should be automatically saved to
 	// disk. This includes chunks, entities and level.dat data. If ReadOnlyWorld
 	// is set to true, changing SaveInterval will have no effect.
@@ -117,6 +171,27 @@ type Config struct {
 	// ChunkUnloadInterval should not be used to prevent chunks from unloading
 	// altogether. This should be done using a Loader with a custom Viewer.
 	ChunkUnloadInterval time.Duration
+	// ChunkEntityLimit is the maximum number of entities counted by
+	// LimitedEntity that a single chunk of the default worlds may hold. If set
+	// to 0 or lower, chunks may hold any number of entities.
+	ChunkEntityLimit int
+	// LimitedEntity is called to check if entities of a world.EntityType count
+	// towards the ChunkEntityLimit. If nil, all entities other than players
+	// count towards the limit.
+	LimitedEntity func(t world.EntityType) bool
+	// RemoveOldestEntities specifies if the oldest entities in a chunk are
+	// removed when a new entity is added to a chunk that reached the
+	// ChunkEntityLimit. If false, the new entity is not added instead.
+	RemoveOldestEntities bool
+	// DamageListener is called every time an entity in one of the default
+	// worlds is hurt successfully, with the attacker, the victim and the
+	// damage dealt after reductions. If nil, no function is called.
+	DamageListener func(tx *world.Tx, d world.Damage)
+	// Teams holds the teams that entities in the default worlds of the Server
+	// may be members of. The same Teams is used for all default worlds, so
+	// that players keep their team when changing dimensions. If nil, a new
+	// world.Teams is created.
+	Teams *world.Teams
 	// Entities is a world.EntityRegistry with all entity types registered that
 	// may be added to the Server's worlds. If no entity types are registered,
 	// Entities will be set to entity.DefaultRegistry.
@@ -141,7 +216,10 @@ func (conf Config) New() *Server {
 		conf.Name = "Dragonfly Server"
 	}
 	if conf.StatusProvider == nil {
-		conf.StatusProvider = statusProvider{name: conf.Name}
+		conf.StatusProvider = statusProvider{name: conf.Name, maxPlayers: conf.MaxPlayers}
+	}
+	if conf.QueueMessage == "" {
+		conf.QueueMessage = "The server is full. You are in position %v of the queue."
 	}
 	if conf.PlayerProvider == nil {
 		conf.PlayerProvider = player.NopProvider{}
@@ -158,6 +236,12 @@ func (conf Config) New() *Server {
 	if conf.MaxChunkRadius == 0 {
 		conf.MaxChunkRadius = 12
 	}
+	if conf.ChunksPerTick <= 0 {
+		conf.ChunksPerTick = 4
+	}
+	if conf.CommandAuditor != nil {
+		cmd.SetAuditor(conf.CommandAuditor)
+	}
 	if conf.ShutdownMessage.Zero() {
 		conf.ShutdownMessage = chat.MessageServerDisconnect
 	}
@@ -167,6 +251,9 @@ func (conf Config) New() *Server {
 	if conf.Blocks == nil {
 		conf.Blocks = world.DefaultBlockRegistry
 	}
+	if conf.Teams == nil {
+		conf.Teams = world.NewTeams()
+	}
 
 	// Initialize the passed block registry and also initialize the default block registry which
 	// is used in some vanilla paths.
@@ -185,8 +272,12 @@ func (conf Config) New() *Server {
 		conf:     conf,
 		incoming: make(chan incoming),
 		p:        make(map[uuid.UUID]*onlinePlayer),
+		slots:    newPlayerSlots(conf.MaxPlayers, conf.ReservedSlots),
 		world:    &world.World{}, nether: &world.World{}, end: &world.World{},
 	}
+	if l, ok := conf.Allower.(*AccessList); ok {
+		l.attach(srv)
+	}
 	for _, lf := range conf.Listeners {
 		l, err := lf(conf)
 		if err != nil {
@@ -227,6 +318,10 @@ type UserConfig struct {
 		DisableJoinQuitMessages bool
 		// MuteEmoteChat specifies if the player emote chat should be muted or not.
 		MuteEmoteChat bool
+		// CommandAuditFile is the file that every command executed on the
+		// server is logged to, along with its source, arguments and result. If
+		// empty, commands are not logged.
+		CommandAuditFile string
 	}
 	World struct {
 		// SaveData controls whether a world's data will be saved and loaded.
@@ -237,16 +332,47 @@ type UserConfig struct {
 		SaveData bool
 		// Folder is the folder that the data of the world resides in.
 		Folder string
+		// ChunkEntityLimit is the maximum number of entities other than
+		// players that a single chunk may hold. If set to 0, chunks may hold
+		// any number of entities.
+		ChunkEntityLimit int
+		// RemoveOldestEntities specifies if the oldest entities in a chunk are
+		// removed to make space for new ones when the ChunkEntityLimit is
+		// reached, instead of preventing new entities from spawning.
+		RemoveOldestEntities bool
+		// ScheduledTickBudget is the maximum number of scheduled block
+		// updates, such as those of flowing liquids, processed per tick. If
+		// set to 0, all updates due are processed every tick.
+		ScheduledTickBudget int
 	}
 	Players struct {
 		// MaxCount is the maximum amount of players allowed to join the server
 		// at the same time. If set to 0, the amount of maximum players will
 		// grow every time a player joins.
 		MaxCount int
+		// ReservedSlots is the amount of additional slots above MaxCount that
+		// only players listed in Reserved may use to join a full server.
+		ReservedSlots int
+		// Reserved is a list of UUIDs of players that may use the reserved slots
+		// of the server and skip the queue.
+		Reserved []string
+		// QueueWhenFull specifies if players trying to join a full server should
+		// be queued until a slot frees up instead of being disconnected.
+		QueueWhenFull bool
 		// MaximumChunkRadius is the maximum chunk radius that players may set
 		// in their settings. If they try to set it above this number, it will
 		// be capped and set to the max.
 		MaximumChunkRadius int
+		// RecipeUnlocking specifies if the recipe book of players only shows
+		// the recipes they unlocked.
+		RecipeUnlocking bool
+		// CraftingAutoRefill specifies if the crafting grid of players is
+		// refilled from their inventory after crafting.
+		CraftingAutoRefill bool
+		// InventoryValidation specifies if inventory actions of players are
+		// verified to conserve the items moved around, rejecting actions that
+		// would duplicate items.
+		InventoryValidation bool
 		// SaveData controls whether a player's data will be saved and loaded.
 		// If true, the server will use the default LevelDB data provider and if
 		// false, an empty provider will be used. To use your own provider, turn
@@ -256,6 +382,12 @@ type UserConfig struct {
 		// Folder controls where the player data will be stored by the default
 		// LevelDB player provider if it is enabled.
 		Folder string
+		// AccessListFile is the JSON file that the allowlist and bans of
+		// players are stored in. If empty, no allowlist or bans are used.
+		AccessListFile string
+		// AllowlistEnabled specifies if only players on the allowlist may join
+		// the server when the AccessListFile is first created.
+		AllowlistEnabled bool
 	}
 	Resources struct {
 		// AutoBuildPack is if the server should automatically generate a
@@ -276,18 +408,48 @@ type UserConfig struct {
 func (uc UserConfig) Config(log *slog.Logger) (Config, error) {
 	var err error
 	conf := Config{
-		Log:                     log,
-		Name:                    uc.Server.Name,
-		ResourcesRequired:       uc.Resources.Required,
-		AuthDisabled:            !uc.Server.AuthEnabled,
-		MuteEmoteChat:           uc.Server.MuteEmoteChat,
-		MaxPlayers:              uc.Players.MaxCount,
-		MaxChunkRadius:          uc.Players.MaximumChunkRadius,
-		DisableResourceBuilding: !uc.Resources.AutoBuildPack,
+		Log:                        log,
+		Name:                       uc.Server.Name,
+		ResourcesRequired:          uc.Resources.Required,
+		AuthDisabled:               !uc.Server.AuthEnabled,
+		MuteEmoteChat:              uc.Server.MuteEmoteChat,
+		MaxPlayers:                 uc.Players.MaxCount,
+		ReservedSlots:              uc.Players.ReservedSlots,
+		QueueWhenFull:              uc.Players.QueueWhenFull,
+		MaxChunkRadius:             uc.Players.MaximumChunkRadius,
+		RecipeUnlocking:            uc.Players.RecipeUnlocking,
+		CraftingAutoRefill:         uc.Players.CraftingAutoRefill,
+		DisableInventoryValidation: !uc.Players.InventoryValidation,
+		DisableResourceBuilding:    !uc.Resources.AutoBuildPack,
+		ChunkEntityLimit:           uc.World.ChunkEntityLimit,
+		RemoveOldestEntities:       uc.World.RemoveOldestEntities,
+		ScheduledTickBudget:        uc.World.ScheduledTickBudget,
+	}
+	if len(uc.Players.Reserved) > 0 {
+		reserved := make(map[uuid.UUID]struct{}, len(uc.Players.Reserved))
+		for _, s := range uc.Players.Reserved {
+			id, err := uuid.Parse(s)
+			if err != nil {
+				return conf, fmt.Errorf("parse reserved player UUID %q: %w", s, err)
+			}
+			reserved[id] = struct{}{}
+		}
+		conf.Reserved = func(d login.IdentityData) bool {
+			id, err := uuid.Parse(d.Identity)
+			_, ok := reserved[id]
+			return err == nil && ok
+		}
 	}
 	if !uc.Server.DisableJoinQuitMessages {
 		conf.JoinMessage, conf.QuitMessage = chat.MessageJoin, chat.MessageQuit
 	}
+	if uc.Server.CommandAuditFile != "" {
+		f, err := os.OpenFile(uc.Server.CommandAuditFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return conf, fmt.Errorf("open command audit file: %w", err)
+		}
+		conf.CommandAuditor = &cmd.Auditor{Sink: cmd.AuditWriter(f)}
+	}
 	if uc.World.SaveData {
 		conf.WorldProvider, err = mcdb.Config{Log: log}.Open(uc.World.Folder)
 		if err != nil {
@@ -304,6 +466,12 @@ func (uc UserConfig) Config(log *slog.Logger) (Config, error) {
 			return conf, fmt.Errorf("create player provider: %w", err)
 		}
 	}
+	if uc.Players.AccessListFile != "" {
+		conf.Allower, err = AccessListConfig{File: uc.Players.AccessListFile, AllowlistEnabled: uc.Players.AllowlistEnabled}.New()
+		if err != nil {
+			return conf, fmt.Errorf("create access list: %w", err)
+		}
+	}
 	conf.Listeners = append(conf.Listeners, uc.listenerFunc)
 	return conf, nil
 }
@@ -351,6 +519,7 @@ func DefaultConfig() UserConfig {
 	c.World.Folder = "world"
 	c.Players.MaximumChunkRadius = 32
 	c.Players.SaveData = true
+	c.Players.InventoryValidation = true
 	c.Players.Folder = "players"
 	c.Resources.AutoBuildPack = true
 	c.Resources.Folder = "resources"
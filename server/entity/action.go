@@ -59,6 +59,10 @@ type FireworkExplosionAction struct{ action }
 // TotemUseAction is a world.EntityAction that displays the totem use particles and animation.
 type TotemUseAction struct{ action }
 
+// FishingHookBiteAction is a world.EntityAction that makes a fishing hook get pulled under water by a fish biting
+// it.
+type FishingHookBiteAction struct{ action }
+
 // action implements the Action interface. Structures in this package may embed it to gets its functionality
 // out of the box.
 type action struct{}
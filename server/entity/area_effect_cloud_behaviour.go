@@ -4,6 +4,7 @@ import (
 	"iter"
 	"time"
 
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/item/potion"
 	"github.com/df-mc/dragonfly/server/world"
@@ -93,12 +94,10 @@ func (a *AreaEffectCloudBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
 
 	pos := e.Position()
 	if a.subtractTickRadius() {
-		for _, v := range tx.Viewers(pos) {
-			v.ViewEntityState(e)
-		}
+		tx.UpdateEntityState(e)
 	}
 
-	if int16(e.Age()/(time.Second*20))%10 != 0 {
+	if (e.Age()/(time.Second/20))%10 != 0 {
 		// Area effect clouds only trigger updates every ten ticks.
 		return nil
 	}
@@ -108,10 +107,11 @@ func (a *AreaEffectCloudBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
 			delete(a.targets, target)
 		}
 	}
-	if a.applyEffects(pos, e, a.filter(tx.EntitiesWithin(e.H().Type().BBox(e).Translate(pos)))) {
-		for _, v := range tx.Viewers(pos) {
-			v.ViewEntityState(e)
-		}
+	// The box is extended downwards slightly so that entities standing on the
+	// same ground as the cloud are also found.
+	box := world.EntityBBox(e).Translate(pos).ExtendTowards(cube.FaceDown, 0.5)
+	if a.applyEffects(pos, e, a.filter(tx.EntitiesWithin(box))) {
+		tx.UpdateEntityState(e)
 	}
 	return nil
 }
@@ -186,3 +186,8 @@ func (a *AreaEffectCloudBehaviour) subtractUseRadius() {
 		a.stationary.close = true
 	}
 }
+
+// base returns the BaseBehaviour of the underlying StationaryBehaviour.
+func (a *AreaEffectCloudBehaviour) base() *BaseBehaviour {
+	return a.stationary.base()
+}
@@ -4,6 +4,8 @@ package entity
 // to inherit common functionality, or forward methods to another instance.
 type BaseBehaviour struct {
 	portalTravel *PortalTravelComputer
+
+	noClip, noEntityCollision, glowing bool
 }
 
 // NewBaseBehaviour returns a BaseBehaviour initialised with the default Ent runtime behaviour.
@@ -18,3 +20,9 @@ func (b *BaseBehaviour) PortalTravelComputer() *PortalTravelComputer {
 	}
 	return b.portalTravel
 }
+
+// base returns the BaseBehaviour itself. It allows Ent to access the state
+// held by the BaseBehaviour of behaviours that embed it or forward it.
+func (b *BaseBehaviour) base() *BaseBehaviour {
+	return b
+}
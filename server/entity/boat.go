@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewBoat creates a new boat entity made of the wood type passed. Boats may be
+// ridden by up to two entities.
+func NewBoat(opts world.EntitySpawnOpts, wood block.WoodType) *world.EntityHandle {
+	conf := boatConf
+	conf.Wood = wood
+	return opts.New(BoatType, conf)
+}
+
+var boatConf = BoatBehaviourConfig{
+	Gravity: 0.04,
+	Drag:    0.1,
+}
+
+// BoatType is a world.EntityType implementation for Boat.
+var BoatType boatType
+
+type boatType struct{}
+
+func (t boatType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (boatType) EncodeEntity() string   { return "minecraft:boat" }
+func (boatType) NetworkOffset() float64 { return 0.375 }
+func (boatType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.7, 0, -0.7, 0.7, 0.455, 0.7)
+}
+
+func (boatType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := boatConf
+	conf.Wood = boatWood(nbtconv.Int32(m, "Variant"))
+	b := conf.New()
+	b.ride.DecodeNBT(m)
+	b.leash.DecodeNBT(m)
+	data.Data = b
+}
+
+func (boatType) EncodeNBT(data *world.EntityData) map[string]any {
+	b := data.Data.(*BoatBehaviour)
+	m := map[string]any{"Variant": b.Variant()}
+	b.ride.EncodeNBT(m)
+	b.leash.EncodeNBT(m)
+	return m
+}
+
+// boatVariants holds the wood types of boats, indexed by their variant.
+var boatVariants = [...]block.WoodType{
+	block.OakWood(), block.SpruceWood(), block.BirchWood(), block.JungleWood(), block.AcaciaWood(),
+	block.DarkOakWood(), block.MangroveWood(), block.BambooWood(), block.CherryWood(), block.PaleOakWood(),
+}
+
+// boatWood returns the wood type of the boat variant passed.
+func boatWood(variant int32) block.WoodType {
+	if variant < 0 || int(variant) >= len(boatVariants) {
+		return block.OakWood()
+	}
+	return boatVariants[variant]
+}
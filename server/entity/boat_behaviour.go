@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// BoatBehaviourConfig holds optional parameters for a BoatBehaviour.
+type BoatBehaviourConfig struct {
+	// Wood is the type of wood that the boat is made of.
+	Wood block.WoodType
+	// Gravity is the amount of Y velocity subtracted every tick.
+	Gravity float64
+	// Drag is used to reduce all axes of the velocity every tick. Velocity is
+	// multiplied with (1-Drag) every tick.
+	Drag float64
+}
+
+func (conf BoatBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a BoatBehaviour using the parameters in conf.
+func (conf BoatBehaviourConfig) New() *BoatBehaviour {
+	b := &BoatBehaviour{
+		BaseBehaviour: NewBaseBehaviour(),
+		conf:          conf,
+		mc: &MovementComputer{
+			Gravity:           conf.Gravity,
+			Drag:              conf.Drag,
+			DragBeforeGravity: true,
+			Buoyancy:          Buoyancy{Force: boatBuoyancy, MaxRise: 0.1},
+		},
+		ride:  &RideComputer{Seats: []mgl64.Vec3{{0, -0.2, 0.2}, {0, -0.2, -0.6}}},
+		leash: &LeashComputer{},
+	}
+	b.brk = &BreakComputer{MaxDamage: 40, Drop: item.NewStack(item.Boat{Type: item.BoatTypes()[b.Variant()]}, 1)}
+	return b
+}
+
+// BoatBehaviour implements the behaviour of boats. Boats float on water and
+// are driven by the movement input of the first entity riding them.
+type BoatBehaviour struct {
+	BaseBehaviour
+
+	conf  BoatBehaviourConfig
+	mc    *MovementComputer
+	ride  *RideComputer
+	leash *LeashComputer
+	brk   *BreakComputer
+}
+
+const (
+	// boatAcceleration is the velocity added every tick while the rider of a
+	// boat moves forward.
+	boatAcceleration = 0.04
+	// boatReverseAcceleration is the velocity added every tick while the rider
+	// of a boat moves backward.
+	boatReverseAcceleration = 0.005
+	// boatTurnSpeed is the yaw in degrees that a boat turns every tick while
+	// its rider moves sideways.
+	boatTurnSpeed = 1.0
+	// boatBuoyancy is the upward velocity added every tick while a boat is in
+	// water.
+	boatBuoyancy = 0.06
+)
+
+// Wood returns the type of wood that the boat is made of.
+func (b *BoatBehaviour) Wood() block.WoodType {
+	return b.conf.Wood
+}
+
+// Variant returns the network variant of the boat, which depends on its wood
+// type.
+func (b *BoatBehaviour) Variant() int32 {
+	return int32(max(slices.Index(boatVariants[:], b.conf.Wood), 0))
+}
+
+// RideComputer returns the state of the entities riding the boat.
+func (b *BoatBehaviour) RideComputer() *RideComputer {
+	return b.ride
+}
+
+// LeashComputer returns the state of the lead that the boat may be tied to.
+func (b *BoatBehaviour) LeashComputer() *LeashComputer {
+	return b.leash
+}
+
+// BreakComputer returns the damage taken by the boat, which breaks it once
+// it is hit enough times.
+func (b *BoatBehaviour) BreakComputer() *BreakComputer {
+	return b.brk
+}
+
+// Tick moves the boat using the input of its rider and makes it float on
+// water.
+func (b *BoatBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	b.ride.Tick(e, tx)
+	b.leash.Tick(e, tx)
+	b.brk.Tick()
+
+	rot, vel := e.data.Rot, e.data.Vel
+	input := b.ride.Input()
+	rot[0] -= input.Strafe * boatTurnSpeed
+
+	acceleration := input.Forward * boatAcceleration
+	if input.Forward < 0 {
+		acceleration = input.Forward * boatReverseAcceleration
+	}
+	vel = vel.Add(cube.Rotation{rot.Yaw(), 0}.Vec3().Mul(acceleration))
+
+	m := b.mc.TickMovement(e, e.data.Pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
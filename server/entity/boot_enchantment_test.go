@@ -0,0 +1,88 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestFrostWalkerFreezesWater(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	handle := spawnTestPlayer(t, w, player.Config{GameMode: world.GameModeSurvival})
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		for x := 1; x <= 5; x++ {
+			tx.SetBlock(cube.Pos{x, 63, 0}, block.Water{Still: true, Depth: 8}, nil)
+		}
+		tx.SetBlock(cube.Pos{2, 64, 0}, block.Stone{}, nil)
+
+		// Without Frost Walker, walking next to water does not freeze it.
+		p.Move(mgl64.Vec3{0.1}, 0, 0)
+		if _, ok := tx.Block(cube.Pos{1, 63, 0}).(block.Water); !ok {
+			t.Fatalf("water next to player without frost walker = %#v, want water", tx.Block(cube.Pos{1, 63, 0}))
+		}
+
+		p.Armour().SetBoots(item.NewStack(item.Boots{Tier: item.ArmourTierDiamond{}}, 1).WithEnchantments(item.NewEnchantment(enchantment.FrostWalker, 1)))
+		p.Move(mgl64.Vec3{0.5}, 0, 0)
+		for x, want := range map[int]bool{1: true, 3: true, 4: true, 2: false, 5: false} {
+			_, frozen := tx.Block(cube.Pos{x, 63, 0}).(block.FrostedIce)
+			if frozen != want {
+				t.Errorf("water at x=%v frozen by player with frost walker = %v, want %v", x, frozen, want)
+			}
+		}
+	})
+
+	// The frosted ice melts back into water after a while.
+	advanceTicks(w, 1200)
+	withPlayer(t, w, handle, func(tx *world.Tx, _ *player.Player) {
+		if _, ok := tx.Block(cube.Pos{1, 63, 0}).(block.FrostedIce); ok {
+			t.Errorf("frosted ice did not melt after 1200 ticks")
+		}
+	})
+}
+
+func TestDepthStriderReducesWaterDrag(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	velocity := func(level int) float64 {
+		handle := spawnTestPlayer(t, w, player.Config{GameMode: world.GameModeSurvival})
+		withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+			for x := 0; x <= 2; x++ {
+				tx.SetBlock(cube.Pos{x, 63, 0}, block.Stone{}, nil)
+				tx.SetBlock(cube.Pos{x, 64, 0}, block.Water{Still: true, Depth: 8}, nil)
+				tx.SetBlock(cube.Pos{x, 65, 0}, block.Water{Still: true, Depth: 8}, nil)
+			}
+			boots := item.NewStack(item.Boots{Tier: item.ArmourTierDiamond{}}, 1)
+			if level > 0 {
+				boots = boots.WithEnchantments(item.NewEnchantment(enchantment.DepthStrider, level))
+			}
+			p.Armour().SetBoots(boots)
+			p.SetVelocity(mgl64.Vec3{0.5})
+		})
+		advanceTicks(w, 1)
+
+		var vel float64
+		withPlayer(t, w, handle, func(_ *world.Tx, p *player.Player) {
+			vel = p.Velocity()[0]
+			_ = p.Close()
+		})
+		return vel
+	}
+	without := velocity(0)
+	for level := 1; level <= 3; level++ {
+		if with := velocity(level); with <= without {
+			t.Errorf("velocity in water with depth strider %v = %v, want more than the %v without depth strider", level, with, without)
+		} else {
+			without = with
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Breakable is a world.Entity that is not Living, but that breaks after being
+// hit enough times in quick succession, such as a boat or a minecart.
+type Breakable interface {
+	world.Entity
+	// Hit hits the entity with the damage passed, which is typically the
+	// attack damage of the item used to hit it. Hit returns false if the
+	// entity cannot be hit.
+	Hit(damage float64, src world.DamageSource) bool
+}
+
+// BreakComputer keeps track of the damage dealt to an entity that breaks once
+// it has taken too much damage, such as a boat. The damage wears off over
+// time, so that the entity must be hit in quick succession to break it.
+type BreakComputer struct {
+	// MaxDamage is the damage that the entity may take before it breaks.
+	// Every hit deals ten times the damage passed to Hit, and one damage wears
+	// off every tick.
+	MaxDamage float64
+	// Drop is the item dropped when the entity breaks, unless it is broken by
+	// an entity that has a creative inventory.
+	Drop item.Stack
+
+	damage float64
+}
+
+// Hit shows the hurt animation of e and adds the damage passed to its damage
+// so far, breaking e if it exceeds the MaxDamage. Entities hit by an attacker
+// that has a creative inventory, such as a player in creative mode, break
+// immediately without dropping anything.
+func (c *BreakComputer) Hit(e world.Entity, damage float64, src world.DamageSource, tx *world.Tx) {
+	for _, v := range tx.EntityViewers(e) {
+		v.ViewEntityAction(e, HurtAction{})
+	}
+	if s, ok := src.(AttackDamageSource); ok {
+		if g, ok := s.Attacker.(interface{ GameMode() world.GameMode }); ok && g.GameMode().CreativeInventory() {
+			_ = e.Close()
+			return
+		}
+	}
+	if c.damage += damage * 10; c.damage <= c.MaxDamage {
+		return
+	}
+	if !c.Drop.Empty() {
+		tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: e.Position().Add(mgl64.Vec3{0, 0.5})}, c.Drop))
+	}
+	_ = e.Close()
+}
+
+// Tick makes one damage of the entity wear off.
+func (c *BreakComputer) Tick() {
+	c.damage = max(c.damage-1, 0)
+}
@@ -0,0 +1,167 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Breedable is a Living entity, such as an animal, that grows up from a baby
+// into an adult and that may breed with other entities of the same type. The
+// age and love mode of a Breedable are typically kept track of using an
+// AgeComputer.
+type Breedable interface {
+	Living
+	// Baby checks if the entity is a baby.
+	Baby() bool
+	// InLove checks if the entity is in love mode, meaning it breeds with a
+	// nearby entity of the same type that is also in love mode.
+	InLove() bool
+	// BreedingItem checks if the item passed may be fed to the entity to make
+	// it enter love mode or to make it grow up faster.
+	BreedingItem(it world.Item) bool
+	// FinishBreeding ends the love mode of the entity after it bred with
+	// another entity and starts its breeding cooldown.
+	FinishBreeding(tx *world.Tx)
+}
+
+// AgeComputer is used to keep track of the age of a Breedable entity and of
+// its love mode. A baby grows up into an adult over time. An adult enters
+// love mode when it is fed a breeding item, after which it breeds with a
+// nearby adult of the same type that is also in love mode. After breeding,
+// an adult cannot be fed to enter love mode again until its breeding cooldown
+// has passed.
+type AgeComputer struct {
+	// GrowDuration is the time it takes for a baby to grow up into an adult.
+	// If 0, it defaults to 20 minutes.
+	GrowDuration time.Duration
+	// LoveDuration is the time that an entity stays in love mode if it does
+	// not breed. If 0, it defaults to 30 seconds.
+	LoveDuration time.Duration
+	// BreedCooldown is the time after breeding until an entity may enter
+	// love mode again. If 0, it defaults to 5 minutes.
+	BreedCooldown time.Duration
+
+	// age is the age of the entity in ticks. A negative age is the amount of
+	// ticks left until a baby grows up. A positive age is the amount of ticks
+	// left in the breeding cooldown of an adult.
+	age int64
+	// love is the amount of ticks left in love mode.
+	love int64
+}
+
+// breedRadius is the maximum distance between two entities in love mode for
+// them to breed.
+const breedRadius = 3
+
+// Baby checks if the entity is a baby.
+func (c *AgeComputer) Baby() bool {
+	return c.age < 0
+}
+
+// InLove checks if the entity is currently in love mode.
+func (c *AgeComputer) InLove() bool {
+	return c.love > 0
+}
+
+// SetBaby makes the entity e a baby that takes the GrowDuration to grow up if
+// baby is true, or turns it into an adult right away if false.
+func (c *AgeComputer) SetBaby(e world.Entity, baby bool, tx *world.Tx) {
+	if baby == c.Baby() {
+		return
+	}
+	c.age, c.love = 0, 0
+	if baby {
+		c.age = -durationTicks(c.GrowDuration, time.Minute*20)
+	}
+	tx.UpdateEntityState(e)
+}
+
+// Feed feeds the item passed to the Breedable entity e. If e is a baby, it
+// grows up 10% of its remaining time faster. If e is an adult that is not in
+// its breeding cooldown, it enters love mode. Feed returns false if the item
+// is not a breeding item of e or if e could not be fed.
+func (c *AgeComputer) Feed(e Breedable, it item.Stack, tx *world.Tx) bool {
+	if it.Empty() || !e.BreedingItem(it.Item()) {
+		return false
+	}
+	switch {
+	case c.Baby():
+		c.age -= c.age / 10
+		if c.age == 0 {
+			tx.UpdateEntityState(e)
+		}
+		return true
+	case c.age == 0 && !c.InLove():
+		c.love = durationTicks(c.LoveDuration, time.Second*30)
+		tx.UpdateEntityState(e)
+		return true
+	}
+	return false
+}
+
+// FinishBreeding ends the love mode of e and starts its BreedCooldown.
+func (c *AgeComputer) FinishBreeding(e world.Entity, tx *world.Tx) {
+	c.love, c.age = 0, durationTicks(c.BreedCooldown, time.Minute*5)
+	tx.UpdateEntityState(e)
+}
+
+// Tick ages the Breedable entity e by a single tick. While e is in love mode,
+// Tick looks for an adult of the same type within 3 blocks that is also in
+// love mode and breeds with it, spawning a baby of their type between them.
+// Tick should be called every tick.
+func (c *AgeComputer) Tick(e Breedable, tx *world.Tx) {
+	switch {
+	case c.age < 0:
+		if c.age++; c.age == 0 {
+			tx.UpdateEntityState(e)
+		}
+	case c.age > 0:
+		c.age--
+	}
+	if c.love <= 0 {
+		return
+	}
+	if c.love--; c.love == 0 {
+		tx.UpdateEntityState(e)
+		return
+	}
+	partner, ok := NearestEntity(e, tx, breedRadius, func(other world.Entity) bool {
+		b, ok := other.(Breedable)
+		return ok && other.H().Type() == e.H().Type() && b.InLove() && !b.Baby()
+	})
+	if !ok {
+		return
+	}
+	pos := e.Position().Add(partner.Position()).Mul(0.5)
+	tx.SpawnEntity(e.H().Type().EncodeEntity(), pos, world.SpawnOptions{Rotation: e.Rotation(), NBT: map[string]any{"IsBaby": uint8(1)}})
+	partner.(Breedable).FinishBreeding(tx)
+	c.FinishBreeding(e, tx)
+}
+
+// EncodeNBT writes the age and love mode of the entity to the map passed.
+func (c *AgeComputer) EncodeNBT(m map[string]any) {
+	m["Age"] = int32(c.age)
+	m["InLove"] = int32(c.love)
+	m["IsBaby"] = boolByte(c.Baby())
+}
+
+// DecodeNBT reads the age and love mode of the entity written using EncodeNBT
+// from the map passed. An entity with only the IsBaby tag set, as written by
+// spawn eggs and when breeding, is decoded as a newborn baby.
+func (c *AgeComputer) DecodeNBT(m map[string]any) {
+	c.age, c.love = int64(nbtconv.Int32(m, "Age")), int64(nbtconv.Int32(m, "InLove"))
+	if _, ok := m["Age"]; !ok && nbtconv.Bool(m, "IsBaby") {
+		c.age = -durationTicks(c.GrowDuration, time.Minute*20)
+	}
+}
+
+// durationTicks returns d in ticks, or def in ticks if d is 0.
+func durationTicks(d, def time.Duration) int64 {
+	if d == 0 {
+		d = def
+	}
+	return d.Milliseconds() / 50
+}
@@ -0,0 +1,183 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestAgeComputerFeedingEntersLoveMode(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: breedTestRegistry()}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		a := breedTestSpawn(tx, mgl64.Vec3{0.5, 64, 0.5}, false)
+		if a.ac.Feed(a, item.NewStack(item.Apple{}, 1), tx) || a.InLove() {
+			t.Fatalf("animal entered love mode after being fed an item that is not its breeding item")
+		}
+		if !a.ac.Feed(a, item.NewStack(item.Wheat{}, 1), tx) || !a.InLove() {
+			t.Fatalf("animal did not enter love mode after being fed its breeding item")
+		}
+		if a.ac.Feed(a, item.NewStack(item.Wheat{}, 1), tx) {
+			t.Errorf("animal already in love mode was fed again")
+		}
+
+		// Love mode ends after its duration if the animal does not find a partner.
+		for range 600 {
+			a.ac.Tick(a, tx)
+		}
+		if a.InLove() {
+			t.Errorf("animal is still in love mode after 30 seconds without a partner")
+		}
+
+		baby := breedTestSpawn(tx, mgl64.Vec3{20.5, 64, 0.5}, true)
+		if !baby.Baby() || !baby.ac.Feed(baby, item.NewStack(item.Wheat{}, 1), tx) || baby.InLove() {
+			t.Fatalf("baby fed its breeding item entered love mode, want it to grow instead")
+		}
+		// A baby takes 24000 ticks to grow up, 10% of which is skipped by feeding it.
+		for range 21599 {
+			baby.ac.Tick(baby, tx)
+		}
+		if !baby.Baby() {
+			t.Fatalf("baby grew up too early after being fed")
+		}
+		baby.ac.Tick(baby, tx)
+		if baby.Baby() {
+			t.Errorf("baby did not grow up after 21600 ticks after being fed")
+		}
+	})
+}
+
+func TestAgeComputerBreedsAdjacentAnimals(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: breedTestRegistry()}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		a := breedTestSpawn(tx, mgl64.Vec3{0.5, 64, 0.5}, false)
+		b := breedTestSpawn(tx, mgl64.Vec3{1.5, 64, 0.5}, false)
+		far := breedTestSpawn(tx, mgl64.Vec3{10.5, 64, 0.5}, false)
+		for _, e := range []*breedTestAnimal{a, far} {
+			e.ac.Feed(e, item.NewStack(item.Wheat{}, 1), tx)
+		}
+		a.ac.Tick(a, tx)
+		far.ac.Tick(far, tx)
+		if n := breedTestBabies(tx); n != 0 {
+			t.Fatalf("babies spawned with one animal in love mode nearby = %v, want 0", n)
+		}
+
+		b.ac.Feed(b, item.NewStack(item.Wheat{}, 1), tx)
+		for _, e := range []*breedTestAnimal{a, b, far} {
+			e.ac.Tick(e, tx)
+		}
+		if n := breedTestBabies(tx); n != 1 {
+			t.Fatalf("babies spawned by two adjacent animals in love mode = %v, want 1", n)
+		}
+		if a.InLove() || b.InLove() || !far.InLove() {
+			t.Errorf("animals in love mode after breeding = %v, %v, %v, want only the animal far away", a.InLove(), b.InLove(), far.InLove())
+		}
+	})
+}
+
+func TestAgeComputerBreedCooldown(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: breedTestRegistry()}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		a := breedTestSpawn(tx, mgl64.Vec3{0.5, 64, 0.5}, false)
+		b := breedTestSpawn(tx, mgl64.Vec3{1.5, 64, 0.5}, false)
+		tick := func(n int) {
+			for range n {
+				a.ac.Tick(a, tx)
+				b.ac.Tick(b, tx)
+			}
+		}
+		breed := func() bool {
+			before := breedTestBabies(tx)
+			a.ac.Feed(a, item.NewStack(item.Wheat{}, 1), tx)
+			b.ac.Feed(b, item.NewStack(item.Wheat{}, 1), tx)
+			tick(1)
+			return breedTestBabies(tx) > before
+		}
+		if !breed() {
+			t.Fatalf("animals did not breed after being fed")
+		}
+		if breed() {
+			t.Fatalf("animals bred again right after breeding, want them to be in their breeding cooldown")
+		}
+		// The breeding cooldown of the animals is set to 10 seconds, or 200 ticks.
+		tick(190)
+		if breed() {
+			t.Fatalf("animals bred again before their breeding cooldown passed")
+		}
+		tick(20)
+		if !breed() {
+			t.Errorf("animals did not breed again after their breeding cooldown passed")
+		}
+	})
+}
+
+func breedTestRegistry() world.EntityRegistry {
+	return world.EntityRegistryConfig{}.New([]world.EntityType{breedTestAnimalType{}})
+}
+
+// breedTestSpawn spawns a breedTestAnimal at the position passed.
+func breedTestSpawn(tx *world.Tx, pos mgl64.Vec3, baby bool) *breedTestAnimal {
+	nbt := map[string]any{}
+	if baby {
+		nbt["IsBaby"] = uint8(1)
+	}
+	e, _ := tx.SpawnEntity("dragonfly:breed_test_animal", pos, world.SpawnOptions{NBT: nbt})
+	return e.(*breedTestAnimal)
+}
+
+// breedTestBabies returns the amount of baby breedTestAnimals in the world.
+func breedTestBabies(tx *world.Tx) (n int) {
+	for e := range tx.Entities() {
+		if a, ok := e.(*breedTestAnimal); ok && a.Baby() {
+			n++
+		}
+	}
+	return n
+}
+
+// breedTestAnimal is a Breedable that breeds when fed wheat. Methods not implemented by it panic when called.
+type breedTestAnimal struct {
+	Living
+	handle *world.EntityHandle
+	d      *world.EntityData
+	ac     *AgeComputer
+}
+
+func (a *breedTestAnimal) H() *world.EntityHandle          { return a.handle }
+func (a *breedTestAnimal) Position() mgl64.Vec3            { return a.d.Pos }
+func (a *breedTestAnimal) Rotation() cube.Rotation         { return a.d.Rot }
+func (a *breedTestAnimal) Close() error                    { return nil }
+func (a *breedTestAnimal) Baby() bool                      { return a.ac.Baby() }
+func (a *breedTestAnimal) InLove() bool                    { return a.ac.InLove() }
+func (a *breedTestAnimal) FinishBreeding(tx *world.Tx)     { a.ac.FinishBreeding(a, tx) }
+func (a *breedTestAnimal) BreedingItem(it world.Item) bool { _, ok := it.(item.Wheat); return ok }
+
+type breedTestAnimalType struct{}
+
+func (breedTestAnimalType) Open(_ *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &breedTestAnimal{handle: handle, d: data, ac: data.Data.(*AgeComputer)}
+}
+
+func (breedTestAnimalType) EncodeEntity() string { return "dragonfly:breed_test_animal" }
+func (breedTestAnimalType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.45, 0, -0.45, 0.45, 0.9, 0.45)
+}
+func (breedTestAnimalType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	ac := &AgeComputer{BreedCooldown: time.Second * 10}
+	ac.DecodeNBT(m)
+	data.Data = ac
+}
+func (breedTestAnimalType) EncodeNBT(data *world.EntityData) map[string]any {
+	m := map[string]any{}
+	data.Data.(*AgeComputer).EncodeNBT(m)
+	return m
+}
@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestItemFloatsInWater(t *testing.T) {
+	w := buoyancyTestWorld(t)
+	var handle *world.EntityHandle
+	mustDo(t, w, func(tx *world.Tx) {
+		handle = tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 60.1, 0.5}}, item.NewStack(item.Apple{}, 1))).H()
+	})
+
+	surface := 63 + 8.0/9
+	lowest, highest := 64.0, 0.0
+	for i := range 300 {
+		w.AdvanceTick()
+		mustDo(t, w, func(tx *world.Tx) {
+			e, ok := handle.Entity(tx)
+			if !ok {
+				t.Fatalf("item entity was removed from the world")
+			}
+			if y := e.Position()[1]; i >= 240 {
+				lowest, highest = min(lowest, y), max(highest, y)
+			}
+		})
+	}
+	if lowest < surface-0.5 || highest > surface+0.3 {
+		t.Errorf("item in water moved between y=%v and y=%v after rising, want it floating at the surface at y=%v", lowest, highest, surface)
+	}
+}
+
+func TestNonSwimmerSinksSlowlyInWater(t *testing.T) {
+	w := buoyancyTestWorld(t)
+	// A non-swimmer is pushed up by the water, but not enough to counteract
+	// its gravity.
+	buoyancy := Buoyancy{Force: 0.06, Drag: 0.2}
+
+	var inWater, inAir *world.EntityHandle
+	mustDo(t, w, func(tx *world.Tx) {
+		inWater = tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 63, 0.5}}.New(testMovingEntType{}, buoyancyTestConfig{b: buoyancy})).H()
+		inAir = tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{4.5, 63, 4.5}}.New(testMovingEntType{}, buoyancyTestConfig{b: buoyancy})).H()
+	})
+	for range 10 {
+		w.AdvanceTick()
+	}
+	mustDo(t, w, func(tx *world.Tx) {
+		water, _ := inWater.Entity(tx)
+		air, _ := inAir.Entity(tx)
+		sunk, fell := 63-water.Position()[1], 63-air.Position()[1]
+		if sunk <= 0 {
+			t.Fatalf("non-swimmer in water did not sink")
+		}
+		if sunk > fell/2 {
+			t.Errorf("non-swimmer sank %v blocks in water and fell %v blocks in air, want water drag to slow it down", sunk, fell)
+		}
+		if v := water.(*Ent).Velocity()[1]; v < -0.15 {
+			t.Errorf("velocity of a non-swimmer sinking in water = %v, want it limited by water drag", v)
+		}
+	})
+}
+
+// buoyancyTestWorld returns a world with a column of still water from y=60 to
+// y=63 at x=0, z=0, enclosed by stone.
+func buoyancyTestWorld(t *testing.T) *world.World {
+	w := world.Config{Synchronous: true}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	mustDo(t, w, func(tx *world.Tx) {
+		for pos := range cube.Range3D(cube.Pos{-1, 59, -1}, cube.Pos{1, 63, 1}) {
+			tx.SetBlock(pos, block.Stone{}, nil)
+		}
+		for y := 60; y <= 63; y++ {
+			tx.SetBlock(cube.Pos{0, y, 0}, block.Water{Still: true, Depth: 8}, nil)
+		}
+	})
+	return w
+}
+
+type buoyancyTestConfig struct {
+	b Buoyancy
+}
+
+func (c buoyancyTestConfig) Apply(data *world.EntityData) {
+	data.Data = &buoyancyTestBehaviour{
+		BaseBehaviour: NewBaseBehaviour(),
+		mc:            &MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true, Buoyancy: c.b},
+	}
+}
+
+// buoyancyTestBehaviour is the behaviour of an entity that falls and moves in
+// liquids according to its Buoyancy.
+type buoyancyTestBehaviour struct {
+	BaseBehaviour
+	mc *MovementComputer
+}
+
+func (b *buoyancyTestBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	m := b.mc.TickMovement(e, e.data.Pos, e.data.Vel, e.data.Rot, tx)
+	e.data.Pos, e.data.Vel = m.pos, m.vel
+	return m
+}
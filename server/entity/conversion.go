@@ -0,0 +1,165 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Conversion describes the conversion of an entity into an entity of a
+// different type, such as a zombie converting into a drowned after being
+// underwater for too long, or a zombie villager that is cured.
+type Conversion struct {
+	// Target is the name of the entity type that the entity converts into, as
+	// registered in the world.EntityRegistry, such as 'minecraft:drowned'.
+	Target string
+	// Condition returns true if the entity currently meets the condition of
+	// the conversion. The entity converts once the condition has been met for
+	// the Delay without interruption. If nil, the conversion only starts once
+	// ConversionComputer.Start is called, after which the entity converts
+	// after the Delay regardless of its surroundings.
+	Condition func(e world.Entity, tx *world.Tx) bool
+	// Delay is the time it takes for the entity to convert. If 0, the entity
+	// converts right away.
+	Delay time.Duration
+	// Transform is called with the NBT of the converting entity before the
+	// target entity is decoded from it. It may be used to change or remove
+	// data that should not be preserved. If nil, all data that the target
+	// entity type is able to decode is preserved, such as the name tag,
+	// health or trades of the entity.
+	Transform func(e world.Entity, m map[string]any)
+	// Sound is the sound played when the entity converts. If nil, no sound is
+	// played.
+	Sound world.Sound
+}
+
+// ConversionComputer is used to convert an entity into an entity of a
+// different type once one of its Conversions finishes. The zero value of a
+// ConversionComputer has no Conversions and never converts the entity.
+type ConversionComputer struct {
+	// Conversions are the conversions that the entity may undergo. If
+	// multiple conversions finish in the same tick, the first of them is
+	// used.
+	Conversions []Conversion
+
+	// progress holds the number of ticks that each of the Conversions has
+	// been in progress for, keyed by their Target. Conversions not in
+	// progress are not present.
+	progress map[string]int64
+}
+
+// Start starts the Conversion with the Target passed that has no Condition,
+// such as the curing of a zombie villager after being fed a golden apple.
+// Start returns false if the entity has no such Conversion or if it is
+// already in progress.
+func (c *ConversionComputer) Start(target string) bool {
+	for _, conv := range c.Conversions {
+		if conv.Target != target || conv.Condition != nil {
+			continue
+		}
+		if _, ok := c.progress[target]; ok {
+			return false
+		}
+		if c.progress == nil {
+			c.progress = map[string]int64{}
+		}
+		c.progress[target] = 0
+		return true
+	}
+	return false
+}
+
+// Converting checks if the Conversion with the Target passed is currently in
+// progress.
+func (c *ConversionComputer) Converting(target string) bool {
+	_, ok := c.progress[target]
+	return ok
+}
+
+// Tick progresses the Conversions of the entity e by a single tick. Once a
+// Conversion finishes, e is converted using Convert and the new entity is
+// returned along with true. e must not be used after this happens. Tick
+// should be called every tick.
+func (c *ConversionComputer) Tick(e world.Entity, tx *world.Tx) (world.Entity, bool) {
+	for _, conv := range c.Conversions {
+		if conv.Condition != nil {
+			if !conv.Condition(e, tx) {
+				delete(c.progress, conv.Target)
+				continue
+			}
+			if c.progress == nil {
+				c.progress = map[string]int64{}
+			}
+		} else if _, ok := c.progress[conv.Target]; !ok {
+			continue
+		}
+		if c.progress[conv.Target]++; c.progress[conv.Target] < conv.Delay.Milliseconds()/50 {
+			continue
+		}
+		if converted, ok := Convert(e, conv, tx); ok {
+			return converted, true
+		}
+		// The target entity type is not registered: Try again later.
+		delete(c.progress, conv.Target)
+	}
+	return nil, false
+}
+
+// EncodeNBT writes the progress of the Conversions of the entity to the map
+// passed.
+func (c *ConversionComputer) EncodeNBT(m map[string]any) {
+	if len(c.progress) == 0 {
+		return
+	}
+	progress := make(map[string]any, len(c.progress))
+	for target, ticks := range c.progress {
+		progress[target] = int32(ticks)
+	}
+	m["ConversionTicks"] = progress
+}
+
+// DecodeNBT reads the progress of the Conversions of the entity written
+// using EncodeNBT from the map passed.
+func (c *ConversionComputer) DecodeNBT(m map[string]any) {
+	progress, _ := m["ConversionTicks"].(map[string]any)
+	for target := range progress {
+		if c.progress == nil {
+			c.progress = map[string]int64{}
+		}
+		c.progress[target] = int64(nbtconv.Int32(progress, target))
+	}
+}
+
+// Convert converts the entity e into a new entity of the Target type of the
+// Conversion passed, which is decoded from the NBT of e so that data such as
+// its name tag, health or effects are preserved. The new entity is spawned at
+// the position of e with the same rotation and velocity, after which e is
+// closed so that viewers see it being replaced. Convert returns false and
+// leaves e untouched if the Target type is not registered in the world.
+func Convert(e world.Entity, conv Conversion, tx *world.Tx) (world.Entity, bool) {
+	m := e.H().EncodeNBT()
+	delete(m, "identifier")
+	// The progress of conversions must not carry over to the target entity,
+	// which would otherwise resume them.
+	delete(m, "ConversionTicks")
+	if conv.Transform != nil {
+		conv.Transform(e, m)
+	}
+	opts := world.SpawnOptions{
+		Rotation: e.Rotation(),
+		Velocity: nbtconv.Vec3(m, "Motion"),
+		NameTag:  nbtconv.String(m, "NameTag"),
+		NBT:      m,
+	}
+	pos := e.Position()
+	converted, ok := tx.SpawnEntity(conv.Target, pos, opts)
+	if !ok {
+		return nil, false
+	}
+	_ = e.Close()
+	if conv.Sound != nil {
+		tx.PlaySound(pos, conv.Sound)
+	}
+	return converted, true
+}
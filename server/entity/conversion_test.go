@@ -0,0 +1,173 @@
+package entity
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestZombieConvertsToDrownedUnderwater(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: conversionTestRegistry()}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		for y := 64; y <= 66; y++ {
+			tx.SetBlock(cube.Pos{0, y, 0}, block.Water{Still: true, Depth: 8}, nil)
+		}
+		zombie := conversionTestSpawn(tx, "dragonfly:conversion_test_zombie", mgl64.Vec3{0.5, 64, 0.5}, nil)
+		tick := func(n int) world.Entity {
+			for range n {
+				if converted, ok := zombie.conv.Tick(zombie, tx); ok {
+					return converted
+				}
+			}
+			return nil
+		}
+		// The conversion is reset when the zombie leaves the water.
+		tick(30)
+		zombie.d.Pos = mgl64.Vec3{5.5, 64, 5.5}
+		tick(1)
+		zombie.d.Pos = mgl64.Vec3{0.5, 64, 0.5}
+		if converted := tick(39); converted != nil {
+			t.Fatalf("zombie converted after 39 ticks underwater, want it to take 40 ticks")
+		}
+		converted := tick(1)
+		if converted == nil {
+			t.Fatalf("zombie did not convert after 40 ticks underwater")
+		}
+		if name := converted.H().Type().EncodeEntity(); name != "dragonfly:conversion_test_drowned" {
+			t.Errorf("zombie converted into %v, want dragonfly:conversion_test_drowned", name)
+		}
+		if pos := converted.Position(); pos != (mgl64.Vec3{0.5, 64, 0.5}) {
+			t.Errorf("drowned spawned at %v, want the position of the zombie", pos)
+		}
+		if _, ok := zombie.H().Entity(tx); ok {
+			t.Errorf("zombie was not removed after converting")
+		}
+	})
+}
+
+func TestCuredZombieVillagerKeepsTrades(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: conversionTestRegistry()}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	offers := []string{"emerald:bread", "wheat:emerald"}
+	mustDo(t, w, func(tx *world.Tx) {
+		zombie := conversionTestSpawn(tx, "dragonfly:conversion_test_zombie_villager", mgl64.Vec3{0.5, 64, 0.5}, offers)
+		zombie.d.Name = "Steve"
+		if _, ok := zombie.conv.Tick(zombie, tx); ok {
+			t.Fatalf("zombie villager converted before being cured")
+		}
+		if !zombie.conv.Start("dragonfly:conversion_test_villager") {
+			t.Fatalf("curing of zombie villager could not be started")
+		}
+		if zombie.conv.Start("dragonfly:conversion_test_villager") {
+			t.Errorf("curing of zombie villager was started twice")
+		}
+
+		var converted world.Entity
+		for i := 0; converted == nil && i < 200; i++ {
+			converted, _ = zombie.conv.Tick(zombie, tx)
+		}
+		villager, ok := converted.(*conversionTestMob)
+		if !ok || villager.H().Type().EncodeEntity() != "dragonfly:conversion_test_villager" {
+			t.Fatalf("cured zombie villager converted into %v, want dragonfly:conversion_test_villager", converted)
+		}
+		if !slices.Equal(villager.offers, offers) {
+			t.Errorf("trades of cured villager = %v, want %v", villager.offers, offers)
+		}
+		if villager.d.Name != "Steve" {
+			t.Errorf("name tag of cured villager = %q, want %q", villager.d.Name, "Steve")
+		}
+		if len(villager.conv.progress) != 0 {
+			t.Errorf("conversion of zombie villager carried over to the cured villager")
+		}
+	})
+}
+
+func conversionTestRegistry() world.EntityRegistry {
+	return world.EntityRegistryConfig{}.New([]world.EntityType{
+		conversionTestMobType{name: "dragonfly:conversion_test_zombie"},
+		conversionTestMobType{name: "dragonfly:conversion_test_drowned"},
+		conversionTestMobType{name: "dragonfly:conversion_test_zombie_villager"},
+		conversionTestMobType{name: "dragonfly:conversion_test_villager"},
+	})
+}
+
+// conversionTestSpawn spawns a conversionTestMob of the type passed with the offers passed.
+func conversionTestSpawn(tx *world.Tx, name string, pos mgl64.Vec3, offers []string) *conversionTestMob {
+	nbt := map[string]any{}
+	if offers != nil {
+		nbt["Offers"] = slices.Clone(offers)
+	}
+	e, _ := tx.SpawnEntity(name, pos, world.SpawnOptions{NBT: nbt})
+	return e.(*conversionTestMob)
+}
+
+type conversionTestData struct {
+	conv   *ConversionComputer
+	offers []string
+}
+
+// conversionTestMob is an entity that converts using a ConversionComputer. Methods not implemented by it panic
+// when called.
+type conversionTestMob struct {
+	Living
+	tx     *world.Tx
+	handle *world.EntityHandle
+	d      *world.EntityData
+	conv   *ConversionComputer
+	offers []string
+}
+
+func (m *conversionTestMob) H() *world.EntityHandle  { return m.handle }
+func (m *conversionTestMob) Position() mgl64.Vec3    { return m.d.Pos }
+func (m *conversionTestMob) Rotation() cube.Rotation { return m.d.Rot }
+func (m *conversionTestMob) Close() error {
+	m.tx.RemoveEntity(m)
+	return m.handle.Close()
+}
+
+type conversionTestMobType struct {
+	name string
+}
+
+func (t conversionTestMobType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	d := data.Data.(*conversionTestData)
+	return &conversionTestMob{tx: tx, handle: handle, d: data, conv: d.conv, offers: d.offers}
+}
+
+func (t conversionTestMobType) EncodeEntity() string { return t.name }
+func (conversionTestMobType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.95, 0.3)
+}
+func (t conversionTestMobType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conv := &ConversionComputer{}
+	switch t.name {
+	case "dragonfly:conversion_test_zombie":
+		conv.Conversions = []Conversion{{
+			Target: "dragonfly:conversion_test_drowned",
+			Condition: func(e world.Entity, tx *world.Tx) bool {
+				_, ok := tx.Liquid(cube.PosFromVec3(e.Position().Add(mgl64.Vec3{0, 1.74})))
+				return ok
+			},
+			Delay: time.Second * 2,
+		}}
+	case "dragonfly:conversion_test_zombie_villager":
+		conv.Conversions = []Conversion{{Target: "dragonfly:conversion_test_villager", Delay: time.Second * 5}}
+	}
+	conv.DecodeNBT(m)
+	offers, _ := m["Offers"].([]string)
+	data.Data = &conversionTestData{conv: conv, offers: offers}
+}
+func (conversionTestMobType) EncodeNBT(data *world.EntityData) map[string]any {
+	d := data.Data.(*conversionTestData)
+	m := map[string]any{"Offers": slices.Clone(d.offers)}
+	d.conv.EncodeNBT(m)
+	return m
+}
@@ -46,6 +46,10 @@ type (
 
 	// ExplosionDamageSource is used for damage caused by an explosion.
 	ExplosionDamageSource struct{}
+
+	// FreezingDamageSource is used for damage caused by an entity being fully
+	// frozen in powder snow.
+	FreezingDamageSource struct{}
 )
 
 func (FallDamageSource) ReducedByArmour() bool     { return false }
@@ -92,4 +96,8 @@ func (ExplosionDamageSource) Fire() bool                { return false }
 func (ExplosionDamageSource) AffectedByEnchantment(e item.EnchantmentType) bool {
 	return e == enchantment.BlastProtection
 }
-func (ExplosionDamageSource) IgnoreTotem() bool { return false }
+func (ExplosionDamageSource) IgnoreTotem() bool        { return false }
+func (FreezingDamageSource) ReducedByResistance() bool { return true }
+func (FreezingDamageSource) ReducedByArmour() bool     { return false }
+func (FreezingDamageSource) Fire() bool                { return false }
+func (FreezingDamageSource) IgnoreTotem() bool         { return false }
@@ -0,0 +1,85 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestDamageListener(t *testing.T) {
+	var received []world.Damage
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry, DamageListener: func(_ *world.Tx, d world.Damage) {
+		received = append(received, d)
+	}}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	attackerHandle := spawnTestPlayer(t, w, player.Config{GameMode: world.GameModeSurvival})
+	for _, tc := range []struct {
+		name     string
+		src      func(attacker, projectile world.Entity) world.DamageSource
+		attacker bool
+		reduced  bool
+	}{
+		{
+			name: "attack",
+			src: func(attacker, _ world.Entity) world.DamageSource {
+				return entity.AttackDamageSource{Attacker: attacker}
+			},
+			attacker: true,
+			reduced:  true,
+		},
+		{
+			name: "projectile",
+			src: func(attacker, projectile world.Entity) world.DamageSource {
+				return entity.ProjectileDamageSource{Projectile: projectile, Owner: attacker}
+			},
+			attacker: true,
+			reduced:  true,
+		},
+		{
+			name: "fall",
+			src:  func(world.Entity, world.Entity) world.DamageSource { return entity.FallDamageSource{} },
+		},
+	} {
+		received = nil
+		victimHandle := spawnTestPlayer(t, w, player.Config{GameMode: world.GameModeSurvival})
+		withPlayer(t, w, victimHandle, func(tx *world.Tx, victim *player.Player) {
+			attacker, _ := attackerHandle.Entity(tx)
+			projectile := tx.AddEntity(entity.NewText("", mgl64.Vec3{0.5, 65, 0.5}))
+			victim.Armour().SetChestplate(item.NewStack(item.Chestplate{Tier: item.ArmourTierDiamond{}}, 1))
+
+			src := tc.src(attacker, projectile)
+			before := victim.Health()
+			victim.Hurt(10, src)
+			if len(received) != 1 {
+				t.Fatalf("%v: damage listener called %v times, want once", tc.name, len(received))
+			}
+			d := received[0]
+			if d.Victim != victim || d.Source != src || d.Damage != 10 {
+				t.Errorf("%v: damage received = %+v, want victim %v hurt by %v for 10 damage", tc.name, d, victim.Name(), src)
+			}
+			if tc.attacker && d.Attacker != attacker {
+				t.Errorf("%v: attacker = %v, want %v", tc.name, d.Attacker, attacker)
+			} else if !tc.attacker && d.Attacker != nil {
+				t.Errorf("%v: attacker = %v, want no attacker", tc.name, d.Attacker)
+			}
+			if taken := before - victim.Health(); !mgl64.FloatEqual(d.FinalDamage, taken) {
+				t.Errorf("%v: final damage = %v, want the %v health taken from the victim", tc.name, d.FinalDamage, taken)
+			}
+			if tc.reduced && d.FinalDamage >= d.Damage {
+				t.Errorf("%v: final damage %v was not reduced by armour", tc.name, d.FinalDamage)
+			}
+
+			// Hits that deal no damage do not reach the damage listener.
+			received = nil
+			victim.Hurt(1, src)
+			if len(received) != 0 {
+				t.Errorf("%v: damage listener called for a hit absorbed by attack immunity", tc.name)
+			}
+		})
+	}
+}
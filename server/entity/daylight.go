@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// DaylightBurner is a Living entity, such as an undead mob, that catches fire when it is exposed to
+// sunlight. A DaylightBurner may wear armour to protect it from burning.
+type DaylightBurner interface {
+	Living
+	Flammable
+	// Armour returns the armour worn by the entity. A helmet worn prevents the entity from burning.
+	Armour() *inventory.Armour
+}
+
+// daylightBurnDuration is the duration that a DaylightBurner is set on fire for when exposed to sunlight.
+const daylightBurnDuration = time.Second * 8
+
+// BurnInDaylight sets the DaylightBurner passed on fire if it is exposed to sunlight, as checked using
+// SunlightExposed. If the entity wears a helmet, the helmet takes damage instead, until it breaks.
+// BurnInDaylight should be called every tick and returns true if the entity was set on fire.
+func BurnInDaylight(e DaylightBurner, tx *world.Tx, r *rand.Rand) bool {
+	if e.OnFireDuration() > 0 || !SunlightExposed(e, tx) {
+		return false
+	}
+	if helmet := e.Armour().Helmet(); !helmet.Empty() {
+		if _, ok := helmet.Item().(item.Durable); ok {
+			e.Armour().SetHelmet(helmet.Damage(r.IntN(2)))
+		}
+		return false
+	}
+	e.SetOnFire(daylightBurnDuration)
+	return true
+}
+
+// SunlightExposed checks if the entity passed is exposed to sunlight. This is the case if it is day in the
+// world, if it is not raining at the position of the entity and if the entity is not in water and has no
+// blocks above its head that block the light of the sky.
+func SunlightExposed(e world.Entity, tx *world.Tx) bool {
+	if tx.World().Dimension() != world.Overworld || !daytime(tx.World().Time()) {
+		return false
+	}
+	pos := cube.PosFromVec3(e.Position())
+	if _, ok := tx.Liquid(pos); ok {
+		return false
+	}
+	eyes := cube.PosFromVec3(e.Position().Add(mgl64.Vec3{0, eyeHeight(e)}))
+	if tx.RainingAt(eyes) {
+		return false
+	}
+	return eyes[1] > tx.HighestLightBlocker(eyes[0], eyes[2])
+}
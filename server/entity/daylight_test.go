@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	_ "github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestBurnInDaylight(t *testing.T) {
+	pos := cube.Pos{0, 70, 0}
+	tests := []struct {
+		name   string
+		time   int
+		setup  func(tx *world.Tx)
+		rain   bool
+		helmet bool
+		burns  bool
+	}{
+		{name: "exposed", time: 1000, burns: true},
+		{name: "night", time: 18000},
+		{name: "shade", time: 1000, setup: func(tx *world.Tx) {
+			tx.SetBlock(pos.Add(cube.Pos{0, 3}), block.Stone{}, nil)
+		}},
+		{name: "water", time: 1000, setup: func(tx *world.Tx) {
+			tx.SetLiquid(pos, block.Water{Still: true, Depth: 8})
+		}},
+		{name: "rain", time: 1000, rain: true},
+		{name: "helmet", time: 1000, helmet: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := world.Config{}.New()
+			t.Cleanup(func() { _ = w.Close() })
+			w.StopWeatherCycle()
+			w.StopRaining()
+			if test.rain {
+				w.StartRaining(time.Hour)
+			}
+			w.SetTime(test.time)
+
+			b := &testDaylightBurner{pos: pos.Vec3Middle(), armour: inventory.NewArmour(nil)}
+			helmet := item.NewStack(item.Helmet{Tier: item.ArmourTierIron{}}, 1)
+			if test.helmet {
+				b.armour.SetHelmet(helmet)
+			}
+			mustDo(t, w, func(tx *world.Tx) {
+				if test.setup != nil {
+					test.setup(tx)
+				}
+				r := rand.New(rand.NewPCG(1, 2))
+				burnt := false
+				for range 20 {
+					burnt = BurnInDaylight(b, tx, r) || burnt
+				}
+				if burnt != test.burns || (b.fire > 0) != test.burns {
+					t.Errorf("entity burnt in daylight: %t, want %t", burnt, test.burns)
+				}
+				if test.helmet && b.armour.Helmet().Durability() >= helmet.Durability() {
+					t.Errorf("helmet durability = %d, want less than %d", b.armour.Helmet().Durability(), helmet.Durability())
+				}
+			})
+		})
+	}
+}
+
+// testDaylightBurner is a DaylightBurner used for testing. Methods not implemented by it panic when called.
+type testDaylightBurner struct {
+	Living
+	pos    mgl64.Vec3
+	fire   time.Duration
+	armour *inventory.Armour
+}
+
+func (b *testDaylightBurner) Position() mgl64.Vec3             { return b.pos }
+func (b *testDaylightBurner) EyeHeight() float64               { return 1.74 }
+func (b *testDaylightBurner) OnFireDuration() time.Duration    { return b.fire }
+func (b *testDaylightBurner) SetOnFire(duration time.Duration) { b.fire = duration }
+func (b *testDaylightBurner) Extinguish()                      { b.fire = 0 }
+func (b *testDaylightBurner) Armour() *inventory.Armour        { return b.armour }
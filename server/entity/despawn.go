@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// DespawnComputer is used to despawn naturally spawned mobs that are far away
+// from players. A mob without any player within its FarRadius is despawned
+// right away, while a mob without any player within its NearRadius has a
+// random chance to be despawned every tick. Entities that are persistent,
+// because they have a name tag, are leashed or were marked persistent using
+// SetPersistent, are never despawned.
+// An Ent whose Behaviour has a DespawnComputer() DespawnComputer method is
+// despawned automatically when it is ticked.
+// The zero value of a DespawnComputer is ready to use.
+type DespawnComputer struct {
+	// FarRadius is the distance to the nearest player beyond which the
+	// entity is despawned. If 0, it defaults to 128.
+	FarRadius float64
+	// NearRadius is the distance to the nearest player beyond which the
+	// entity has a random chance of being despawned every tick. If 0, it
+	// defaults to 32.
+	NearRadius float64
+	// Chance is the chance, between 0 and 1, that the entity is despawned on
+	// a tick while it is between the NearRadius and FarRadius of the nearest
+	// player. If 0, it defaults to 1/800.
+	Chance float64
+}
+
+// Despawns checks if the entity e should be despawned on the current tick,
+// based on the distance to the nearest player in its world. Despawns always
+// returns false if e is persistent.
+func (c DespawnComputer) Despawns(e world.Entity, tx *world.Tx) bool {
+	if persistent(e) {
+		return false
+	}
+	far, near := defaultValue(c.FarRadius, 128), defaultValue(c.NearRadius, 32)
+	dist := nearestPlayerDistance(e, tx, far)
+	switch {
+	case dist > far:
+		return true
+	case dist > near:
+		return rand.Float64() < defaultValue(c.Chance, 1.0/800)
+	}
+	return false
+}
+
+// Tick closes the entity e if it should be despawned according to Despawns.
+// True is returned if e was despawned. Tick should be called every tick.
+func (c DespawnComputer) Tick(e world.Entity, tx *world.Tx) bool {
+	if !c.Despawns(e, tx) {
+		return false
+	}
+	_ = e.Close()
+	return true
+}
+
+// persistent checks if the entity e is persistent, meaning it should never be
+// despawned.
+func persistent(e world.Entity) bool {
+	if p, ok := e.(interface{ Persistent() bool }); ok && p.Persistent() {
+		return true
+	}
+	l, ok := e.(Leashable)
+	return ok && l.LeashHolder() != nil
+}
+
+// nearestPlayerDistance returns the distance from the entity e to the nearest
+// player that is not a spectator, only checking the chunks within radius
+// of e. If no such player is found, math.Inf(1) is returned.
+func nearestPlayerDistance(e world.Entity, tx *world.Tx, radius float64) float64 {
+	pos := e.Position()
+	dist := math.Inf(1)
+	for other := range tx.EntitiesWithin(cube.Box(-radius, -radius, -radius, radius, radius, radius).Translate(pos)) {
+		if other.H().Type().EncodeEntity() != "minecraft:player" {
+			continue
+		}
+		if g, ok := other.(interface{ GameMode() world.GameMode }); ok && !g.GameMode().HasCollision() {
+			continue
+		}
+		dist = min(dist, other.Position().Sub(pos).Len())
+	}
+	return dist
+}
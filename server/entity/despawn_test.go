@@ -0,0 +1,93 @@
+package entity_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// despawnTestWorld returns a world with a player at the origin that may hold mobs of testMobType.
+func despawnTestWorld(t *testing.T) *world.World {
+	w := newTestWorld(t)
+	doTx(t, w, func(tx *world.Tx) {
+		tx.AddEntity(world.EntitySpawnOpts{}.New(player.Type, player.Config{Name: "player", Position: mgl64.Vec3{0.5, 64, 0.5}}))
+	})
+	return w
+}
+
+// despawnTestSpawn spawns a mob of testMobType at the position passed.
+func despawnTestSpawn(tx *world.Tx, pos mgl64.Vec3, opts world.SpawnOptions) *entity.Ent {
+	e, _ := tx.SpawnEntity(testMobName, pos, opts)
+	return e.(*entity.Ent)
+}
+
+func TestFarMobDespawns(t *testing.T) {
+	w := despawnTestWorld(t)
+	doTx(t, w, func(tx *world.Tx) {
+		far := despawnTestSpawn(tx, mgl64.Vec3{200.5, 64, 0.5}, world.SpawnOptions{})
+		near := despawnTestSpawn(tx, mgl64.Vec3{10.5, 64, 0.5}, world.SpawnOptions{})
+		for range 100 {
+			near.Tick(tx, 0)
+		}
+		far.Tick(tx, 0)
+		if _, ok := near.H().Entity(tx); !ok {
+			t.Errorf("mob 10 blocks away from a player despawned, want it to remain")
+		}
+		if _, ok := far.H().Entity(tx); ok {
+			t.Errorf("mob 200 blocks away from a player did not despawn")
+		}
+	})
+}
+
+func TestPersistentMobDoesNotDespawn(t *testing.T) {
+	w := despawnTestWorld(t)
+	doTx(t, w, func(tx *world.Tx) {
+		named := despawnTestSpawn(tx, mgl64.Vec3{200.5, 64, 0.5}, world.SpawnOptions{NameTag: "Named"})
+		flagged := despawnTestSpawn(tx, mgl64.Vec3{200.5, 64, 10.5}, world.SpawnOptions{Persistent: true})
+		marked := despawnTestSpawn(tx, mgl64.Vec3{200.5, 64, 20.5}, world.SpawnOptions{})
+		marked.SetPersistent(true)
+		for name, e := range map[string]*entity.Ent{"named": named, "spawned persistent": flagged, "marked persistent": marked} {
+			e.Tick(tx, 0)
+			if _, ok := e.H().Entity(tx); !ok {
+				t.Errorf("%v mob far away from a player despawned, want it to persist", name)
+			}
+		}
+
+		// Persistence is read from the NBT of an entity.
+		decoded := despawnTestSpawn(tx, mgl64.Vec3{200.5, 64, 30.5}, world.SpawnOptions{NBT: map[string]any{"Persistent": uint8(1)}})
+		if !decoded.Persistent() {
+			t.Errorf("mob decoded with persistent NBT is not persistent")
+		}
+	})
+}
+
+func TestMidRangeDespawnChance(t *testing.T) {
+	w := despawnTestWorld(t)
+	doTx(t, w, func(tx *world.Tx) {
+		mid := despawnTestSpawn(tx, mgl64.Vec3{64.5, 64, 0.5}, world.SpawnOptions{})
+		near := despawnTestSpawn(tx, mgl64.Vec3{16.5, 64, 0.5}, world.SpawnOptions{})
+		for _, chance := range []float64{0.25, 0.05} {
+			const samples = 20000
+			c := entity.DespawnComputer{Chance: chance}
+			var n int
+			for range samples {
+				if c.Despawns(mid, tx) {
+					n++
+				}
+				if c.Despawns(near, tx) {
+					t.Fatalf("mob within the near radius of a player despawned")
+				}
+			}
+			if got := float64(n) / samples; math.Abs(got-chance) > 0.015 {
+				t.Errorf("share of ticks that a mob 64 blocks away despawns with chance %v = %.3f", chance, got)
+			}
+		}
+		if (entity.DespawnComputer{NearRadius: 80}).Despawns(mid, tx) {
+			t.Errorf("mob within a configured near radius of 80 blocks despawned")
+		}
+	})
+}
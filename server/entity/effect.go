@@ -111,9 +111,7 @@ func (m *EffectManager) Tick(entity Living, tx *world.Tx) {
 	}
 
 	if update {
-		for _, v := range tx.Viewers(entity.Position()) {
-			v.ViewEntityState(entity)
-		}
+		tx.UpdateEntityState(entity)
 	}
 }
 
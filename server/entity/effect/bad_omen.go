@@ -0,0 +1,19 @@
+package effect
+
+import (
+	"image/color"
+)
+
+// BadOmen is a lasting effect that causes a raid to start when the affected
+// player enters a village. The level of the effect increases the number of
+// waves and raiders of the raid.
+var BadOmen badOmen
+
+type badOmen struct {
+	nopLasting
+}
+
+// RGBA ...
+func (badOmen) RGBA() color.RGBA {
+	return color.RGBA{R: 0x0b, G: 0x61, B: 0x38, A: 0xff}
+}
@@ -134,6 +134,21 @@ func (e Effect) Tick() int {
 	return e.tick
 }
 
+// Harmful checks if the Type passed has a negative effect on the entity that it
+// is applied to, such as Poison or Slowness. Types other than those in this
+// package may implement a Harmful() bool method to report this themselves.
+func Harmful(t Type) bool {
+	if h, ok := t.(interface{ Harmful() bool }); ok {
+		return h.Harmful()
+	}
+	switch t {
+	case Slowness, MiningFatigue, InstantDamage, Nausea, Blindness, Hunger, Weakness, Poison, Wither, Levitation,
+		FatalPoison, BadOmen, Darkness:
+		return true
+	}
+	return false
+}
+
 // nopLasting is a lasting effect with no (server-side) behaviour. It does not implement the RGBA method.
 type nopLasting struct{}
 
@@ -0,0 +1,18 @@
+package effect
+
+import (
+	"image/color"
+)
+
+// HeroOfTheVillage is a lasting effect granted to players that defended a
+// village from a raid.
+var HeroOfTheVillage heroOfTheVillage
+
+type heroOfTheVillage struct {
+	nopLasting
+}
+
+// RGBA ...
+func (heroOfTheVillage) RGBA() color.RGBA {
+	return color.RGBA{R: 0x44, G: 0xff, B: 0x44, A: 0xff}
+}
@@ -3,6 +3,7 @@ package entity
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/particle"
 	"github.com/df-mc/dragonfly/server/world/sound"
@@ -25,27 +26,40 @@ var enderPearlConf = ProjectileBehaviourConfig{
 	Hit:      teleport,
 }
 
-// teleporter represents a living entity that can teleport.
-type teleporter interface {
-	// Teleport teleports the entity to the position given.
-	Teleport(pos mgl64.Vec3)
-	Living
-}
-
-// teleport teleports the owner of an Ent to a trace.Result's position.
+// teleport teleports the owner of an Ent to a trace.Result's position and
+// deals fall damage to it.
 func teleport(e *Ent, tx *world.Tx, target trace.Result) {
 	behaviour := e.Behaviour().(*ProjectileBehaviour)
 	if behaviour.PortalTravel() {
 		return
 	}
 	owner, _ := behaviour.Owner().Entity(tx)
-	if user, ok := owner.(teleporter); ok {
-		tx.PlaySound(user.Position(), sound.Teleport{})
-		user.Teleport(target.Position())
+	if user, ok := owner.(Living); ok && item.TeleportEntity(tx, user, enderPearlLanding(user, target)) {
 		user.Hurt(5, FallDamageSource{})
 	}
 }
 
+// enderPearlLanding returns the position that the entity passed lands at
+// when an ender pearl it threw hits the target passed. If the pearl hit the
+// bottom or the side of a block, the position is moved away from the block so
+// that the entity does not end up inside it.
+func enderPearlLanding(e world.Entity, target trace.Result) mgl64.Vec3 {
+	pos := target.Position()
+	r, ok := target.(trace.BlockResult)
+	if !ok {
+		return pos
+	}
+	box := world.EntityBBox(e)
+	switch face := r.Face(); face {
+	case cube.FaceUp:
+	case cube.FaceDown:
+		pos[1] -= box.Height()
+	default:
+		pos = pos.Add(cube.Pos{}.Side(face).Vec3().Mul(box.Width() / 2))
+	}
+	return pos
+}
+
 // EnderPearlType is a world.EntityType implementation for EnderPearl.
 var EnderPearlType enderPearlType
 
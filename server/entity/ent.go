@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"slices"
 	"sync"
 	"time"
 
@@ -43,9 +44,9 @@ func (e *Ent) Behaviour() Behaviour {
 }
 
 // Explode propagates the explosion behaviour of the underlying Behaviour.
-func (e *Ent) Explode(src mgl64.Vec3, impact float64, conf block.ExplosionConfig) {
+func (e *Ent) Explode(src mgl64.Vec3, impact world.ExplosionImpact, conf block.ExplosionConfig) {
 	if expl, ok := e.Behaviour().(interface {
-		Explode(e *Ent, src mgl64.Vec3, impact float64, conf block.ExplosionConfig)
+		Explode(e *Ent, src mgl64.Vec3, impact world.ExplosionImpact, conf block.ExplosionConfig)
 	}); ok {
 		expl.Explode(e, src, impact, conf)
 	}
@@ -70,7 +71,7 @@ func (e *Ent) SetVelocity(v mgl64.Vec3) {
 
 // Teleport teleports the entity to the position given.
 func (e *Ent) Teleport(pos mgl64.Vec3) {
-	viewers := e.tx.Viewers(e.data.Pos)
+	viewers := e.tx.EntityViewers(e)
 	e.data.Pos = pos
 	for _, v := range viewers {
 		v.ViewEntityTeleport(e, pos)
@@ -100,9 +101,7 @@ func (e *Ent) SetOnFire(duration time.Duration) {
 
 	e.data.FireDuration = duration
 	if stateChanged {
-		for _, v := range e.tx.Viewers(e.data.Pos) {
-			v.ViewEntityState(e)
-		}
+		e.tx.UpdateEntityState(e)
 	}
 }
 
@@ -121,9 +120,74 @@ func (e *Ent) NameTag() string {
 // empty string is passed.
 func (e *Ent) SetNameTag(s string) {
 	e.data.Name = s
-	for _, v := range e.tx.Viewers(e.Position()) {
-		v.ViewEntityState(e)
+	e.tx.UpdateEntityState(e)
+}
+
+// Scale returns the scale of the entity, by which its hitbox is multiplied.
+// The default scale is 1.
+func (e *Ent) Scale() float64 {
+	if e.data.Scale == 0 {
+		return 1
+	}
+	return e.data.Scale
+}
+
+// SetScale changes the scale of the entity, changing both the size at which
+// it is shown to viewers and its hitbox. Passing 0 resets the scale to the
+// default of 1.
+func (e *Ent) SetScale(s float64) {
+	e.data.Scale = max(s, 0)
+	e.tx.UpdateEntityState(e)
+}
+
+// Persistent checks if the entity is persistent, meaning it is never
+// despawned for being far away from players. Entities with a name tag are
+// always persistent.
+func (e *Ent) Persistent() bool {
+	return e.data.Persistent || e.data.Name != ""
+}
+
+// SetPersistent changes if the entity is persistent. Entities are typically
+// made persistent when they pick up an item or when spawned by something that
+// should keep them around.
+func (e *Ent) SetPersistent(v bool) {
+	e.data.Persistent = v
+}
+
+// Tags returns the tags of the entity, as added using AddTag.
+func (e *Ent) Tags() []string {
+	return slices.Clone(e.data.Tags)
+}
+
+// HasTag checks if the entity has the tag passed.
+func (e *Ent) HasTag(tag string) bool {
+	return slices.Contains(e.data.Tags, tag)
+}
+
+// AddTag adds a tag to the entity. Tags are saved with the entity and may be
+// used to select it in commands. False is returned if the entity already had
+// the tag.
+func (e *Ent) AddTag(tag string) bool {
+	if e.HasTag(tag) {
+		return false
 	}
+	e.data.Tags = append(e.data.Tags, tag)
+	return true
+}
+
+// RemoveTag removes a tag from the entity. False is returned if the entity
+// did not have the tag.
+func (e *Ent) RemoveTag(tag string) bool {
+	n := len(e.data.Tags)
+	e.data.Tags = slices.DeleteFunc(e.data.Tags, func(t string) bool { return t == tag })
+	return len(e.data.Tags) != n
+}
+
+// Score returns the score of the entity for the objective with the name
+// passed in the world.Objectives of its world. False is returned if the
+// entity has no score for the objective.
+func (e *Ent) Score(objective string) (int, bool) {
+	return e.tx.World().Objectives().Score(objective, e.H().UUID())
 }
 
 // Tick ticks Ent, progressing its lifetime and closing the entity if it is
@@ -140,7 +204,14 @@ func (e *Ent) Tick(tx *world.Tx, current int64) {
 		return
 	}
 	e.SetOnFire(e.OnFireDuration() - time.Second/20)
+	if dc, ok := e.Behaviour().(interface{ DespawnComputer() DespawnComputer }); ok && dc.DespawnComputer().Tick(e, tx) {
+		return
+	}
 
+	rotated := false
+	if lc := e.lookComputer(); lc != nil {
+		e.data.Rot, rotated = lc.Tick(e, e.data.Rot)
+	}
 	m := e.Behaviour().Tick(e, tx)
 	if e.finishPendingPortalTravel(tx) {
 		return
@@ -148,6 +219,14 @@ func (e *Ent) Tick(tx *world.Tx, current int64) {
 	if m != nil {
 		m.Send()
 	}
+	if rotated && (m == nil || m.dpos.ApproxEqualThreshold(zeroVec3, epsilon)) {
+		// The entity only turned its head, so the rotation was not sent along
+		// with its movement.
+		onGround := m == nil || m.onGround
+		for _, v := range tx.EntityViewers(e) {
+			v.ViewEntityMovement(e, e.data.Pos, e.data.Rot, onGround)
+		}
+	}
 	if e.checkPortalInsiders() && e.finishPendingPortalTravel(tx) {
 		return
 	}
@@ -158,6 +237,9 @@ func (e *Ent) Tick(tx *world.Tx, current int64) {
 // Close closes the Ent and removes the associated entity from the world.
 func (e *Ent) Close() error {
 	e.once.Do(func() {
+		if rc := e.rideComputer(); rc != nil {
+			rc.DismountAll(e, e.tx)
+		}
 		e.tx.RemoveEntity(e)
 		_ = e.handle.Close()
 	})
@@ -168,7 +250,7 @@ func (e *Ent) Close() error {
 func (e *Ent) TravelThroughPortal(tx *world.Tx, target world.Dimension) {
 	if tc := e.portalTravelComputer(); tc != nil {
 		if e.deferPortalTravel {
-			tc.queuePortalTravel(tx, target)
+			tc.queuePortalTravel(e, tx, target)
 			return
 		}
 		tc.EnterPortal(e, tx, target)
@@ -206,6 +288,246 @@ func (e *Ent) finishPendingPortalTravel(tx *world.Tx) bool {
 	return false
 }
 
+// baseBehaviour returns the BaseBehaviour of the Behaviour of the Ent, or
+// nil if its Behaviour does not embed or forward a BaseBehaviour.
+func (e *Ent) baseBehaviour() *BaseBehaviour {
+	if b, ok := e.Behaviour().(interface{ base() *BaseBehaviour }); ok {
+		return b.base()
+	}
+	return nil
+}
+
+// NoClip returns true if the entity passes through blocks instead of
+// colliding with them.
+func (e *Ent) NoClip() bool {
+	b := e.baseBehaviour()
+	return b != nil && b.noClip
+}
+
+// SetNoClip sets if the entity passes through blocks instead of colliding
+// with them. If the Behaviour of the entity does not have a BaseBehaviour,
+// SetNoClip has no effect. When collision with blocks is enabled again, the
+// entity is moved out of any blocks it is inside of.
+func (e *Ent) SetNoClip(v bool) {
+	b := e.baseBehaviour()
+	if b == nil || b.noClip == v {
+		return
+	}
+	b.noClip = v
+	if !v {
+		if pos := EscapeBlocks(e.tx, e, e.data.Pos); pos != e.data.Pos {
+			e.Teleport(pos)
+		}
+	}
+}
+
+// NoEntityCollision returns true if the entity does not collide with other
+// entities, such as projectiles.
+func (e *Ent) NoEntityCollision() bool {
+	b := e.baseBehaviour()
+	return b != nil && b.noEntityCollision
+}
+
+// SetNoEntityCollision sets if the entity does not collide with other
+// entities, such as projectiles. If the Behaviour of the entity does not have
+// a BaseBehaviour, SetNoEntityCollision has no effect.
+func (e *Ent) SetNoEntityCollision(v bool) {
+	if b := e.baseBehaviour(); b != nil {
+		b.noEntityCollision = v
+	}
+}
+
+// Glowing returns true if the entity is glowing, which highlights it to
+// viewers in the glow colour of its world.Team.
+func (e *Ent) Glowing() bool {
+	b := e.baseBehaviour()
+	return b != nil && b.glowing
+}
+
+// SetGlowing sets if the entity is glowing. A glowing entity is highlighted
+// to viewers in the colour returned by world.Teams.GlowColour. Because Bedrock
+// clients do not draw outlines around entities, the name tag of a glowing
+// entity is shown in that colour instead. If the Behaviour of the entity does
+// not have a BaseBehaviour, SetGlowing has no effect.
+func (e *Ent) SetGlowing(v bool) {
+	b := e.baseBehaviour()
+	if b == nil || b.glowing == v {
+		return
+	}
+	b.glowing = v
+	e.tx.UpdateEntityState(e)
+}
+
+// rideComputer returns the behaviour's ride state, if any.
+func (e *Ent) rideComputer() *RideComputer {
+	if b, ok := e.Behaviour().(interface{ RideComputer() *RideComputer }); ok {
+		return b.RideComputer()
+	}
+	return nil
+}
+
+// Riders returns the handles of the entities riding the entity. Nil is
+// returned if the entity cannot be ridden.
+func (e *Ent) Riders() []*world.EntityHandle {
+	if rc := e.rideComputer(); rc != nil {
+		return rc.Riders()
+	}
+	return nil
+}
+
+// AddRider makes the entity passed start riding the entity. False is returned
+// if the entity cannot be ridden or if all of its seats are taken.
+func (e *Ent) AddRider(rider world.Entity) bool {
+	if rc := e.rideComputer(); rc != nil {
+		return rc.Mount(e, rider, e.tx)
+	}
+	return false
+}
+
+// RemoveRider makes the entity passed stop riding the entity.
+func (e *Ent) RemoveRider(rider world.Entity) {
+	if rc := e.rideComputer(); rc != nil {
+		rc.Dismount(e, rider, e.tx)
+	}
+}
+
+// SeatPosition returns the position of the seat taken by the rider passed.
+func (e *Ent) SeatPosition(rider world.Entity) (mgl64.Vec3, bool) {
+	if rc := e.rideComputer(); rc != nil {
+		return rc.SeatPosition(e, rider)
+	}
+	return mgl64.Vec3{}, false
+}
+
+// DismountPosition returns the position that riders are moved to when they
+// stop riding the entity.
+func (e *Ent) DismountPosition() mgl64.Vec3 {
+	if rc := e.rideComputer(); rc != nil {
+		return rc.DismountPosition(e, e.tx)
+	}
+	return e.Position()
+}
+
+// Steer passes the movement input of a rider to the entity.
+func (e *Ent) Steer(rider world.Entity, input RideInput) {
+	if rc := e.rideComputer(); rc != nil {
+		rc.Steer(rider, input)
+	}
+}
+
+// breakComputer returns the behaviour's break state, if any.
+func (e *Ent) breakComputer() *BreakComputer {
+	if b, ok := e.Behaviour().(interface{ BreakComputer() *BreakComputer }); ok {
+		return b.BreakComputer()
+	}
+	return nil
+}
+
+// Hit hits the entity, breaking it if it was hit enough times in quick
+// succession. False is returned if the entity cannot be broken.
+func (e *Ent) Hit(damage float64, src world.DamageSource) bool {
+	if bc := e.breakComputer(); bc != nil {
+		bc.Hit(e, damage, src, e.tx)
+		return true
+	}
+	return false
+}
+
+// Saddled checks if the entity wears a saddle.
+func (e *Ent) Saddled() bool {
+	if b, ok := e.Behaviour().(interface{ Saddled() bool }); ok {
+		return b.Saddled()
+	}
+	return false
+}
+
+// Saddle puts a saddle on the entity. False is returned if the entity cannot
+// wear a saddle or if it already wears one.
+func (e *Ent) Saddle() bool {
+	if b, ok := e.Behaviour().(interface{ Saddle() bool }); ok && b.Saddle() {
+		e.tx.UpdateEntityState(e)
+		return true
+	}
+	return false
+}
+
+// lookComputer returns the behaviour's look state, if any.
+func (e *Ent) lookComputer() *LookComputer {
+	if b, ok := e.Behaviour().(interface{ LookComputer() *LookComputer }); ok {
+		return b.LookComputer()
+	}
+	return nil
+}
+
+// LookAt makes the entity turn its head towards the position passed. Nothing
+// happens if the entity cannot look around.
+func (e *Ent) LookAt(pos mgl64.Vec3) {
+	if lc := e.lookComputer(); lc != nil {
+		lc.LookAt(pos)
+	}
+}
+
+// HeadYaw returns the yaw of the head of the entity. If the entity cannot
+// look around, the yaw of its body is returned.
+func (e *Ent) HeadYaw() float64 {
+	if lc := e.lookComputer(); lc != nil {
+		return lc.HeadYaw()
+	}
+	return e.data.Rot.Yaw()
+}
+
+// leashComputer returns the behaviour's leash state, if any.
+func (e *Ent) leashComputer() *LeashComputer {
+	if b, ok := e.Behaviour().(interface{ LeashComputer() *LeashComputer }); ok {
+		return b.LeashComputer()
+	}
+	return nil
+}
+
+// LeashHolder returns the handle of the entity holding the lead of the entity.
+// Nil is returned if the entity is not leashed or cannot be leashed.
+func (e *Ent) LeashHolder() *world.EntityHandle {
+	if lc := e.leashComputer(); lc != nil {
+		return lc.Holder()
+	}
+	return nil
+}
+
+// Leash ties the entity to the holder passed. False is returned if the entity
+// cannot be leashed or if the holder is too far away.
+func (e *Ent) Leash(holder world.Entity) bool {
+	if lc := e.leashComputer(); lc != nil {
+		return lc.Leash(e, holder, e.tx)
+	}
+	return false
+}
+
+// Unleash removes the lead from the entity.
+func (e *Ent) Unleash() {
+	if lc := e.leashComputer(); lc != nil {
+		lc.Unleash(e, e.tx)
+	}
+}
+
+// Angler returns the handle of the entity that cast the entity if it is a
+// fishing hook. Nil is returned otherwise.
+func (e *Ent) Angler() *world.EntityHandle {
+	if f, ok := e.Behaviour().(*FishingHookBehaviour); ok {
+		return f.Owner()
+	}
+	return nil
+}
+
+// Reel reels in the entity if it is a fishing hook and returns the damage
+// dealt to the fishing rod that cast it. 0 is returned if the entity is not a
+// fishing hook.
+func (e *Ent) Reel() int {
+	if f, ok := e.Behaviour().(*FishingHookBehaviour); ok {
+		return f.Reel(e, e.tx)
+	}
+	return 0
+}
+
 type portalBlock interface {
 	Portal() world.Dimension
 }
@@ -213,7 +535,7 @@ type portalBlock interface {
 // checkPortalInsiders checks whether the entity is inside portal blocks.
 // Other EntityInsider blocks are intentionally left to entity physics.
 func (e *Ent) checkPortalInsiders() bool {
-	box := e.H().Type().BBox(e).Translate(e.Position()).Grow(-0.0001)
+	box := world.EntityBBox(e).Translate(e.Position()).Grow(-0.0001)
 	low, high := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
 
 	for blockPos := range cube.Range3D(low, high) {
@@ -0,0 +1,101 @@
+package entity_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// newTestWorld returns a synchronous world that may hold the entities of
+// entity.DefaultRegistry and mobs of testMobType. The world is closed when the
+// test finishes.
+func newTestWorld(t *testing.T) *world.World {
+	t.Helper()
+	reg := entity.DefaultRegistry
+	w := world.Config{Synchronous: true, Entities: reg.Config().New(append(reg.Types(), testMobType{}))}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	return w
+}
+
+// doTx runs f in a transaction of the world passed and waits for it to finish.
+func doTx(t *testing.T, w *world.World, f func(tx *world.Tx)) {
+	t.Helper()
+	if err := w.Do(f).Wait(context.Background()); err != nil {
+		t.Fatalf("world task failed: %v", err)
+	}
+}
+
+// spawnTestPlayer spawns a player standing in the middle of a single stone
+// block at y=63 and returns its handle. The name and position of conf are
+// filled out if empty.
+func spawnTestPlayer(t *testing.T, w *world.World, conf player.Config) *world.EntityHandle {
+	t.Helper()
+	if conf.Name == "" {
+		conf.Name = "player"
+	}
+	if conf.Position == (mgl64.Vec3{}) {
+		conf.Position = mgl64.Vec3{0.5, 64, 0.5}
+	}
+	var handle *world.EntityHandle
+	doTx(t, w, func(tx *world.Tx) {
+		tx.SetBlock(cube.PosFromVec3(conf.Position).Side(cube.FaceDown), block.Stone{}, nil)
+		handle = tx.AddEntity(world.EntitySpawnOpts{}.New(player.Type, conf)).H()
+	})
+	// Let the player settle on the block.
+	advanceTicks(w, 2)
+	return handle
+}
+
+// withPlayer runs f in a transaction of the world passed with the player with
+// the handle passed.
+func withPlayer(t *testing.T, w *world.World, handle *world.EntityHandle, f func(tx *world.Tx, p *player.Player)) {
+	t.Helper()
+	doTx(t, w, func(tx *world.Tx) {
+		e, ok := handle.Entity(tx)
+		if !ok {
+			t.Fatalf("player is no longer in the world")
+		}
+		f(tx, e.(*player.Player))
+	})
+}
+
+// advanceTicks advances the synchronous world passed by n ticks.
+func advanceTicks(w *world.World, n int) {
+	for range n {
+		w.AdvanceTick()
+	}
+}
+
+// testMob is the behaviour of mobs of testMobType. They despawn using the zero
+// value of a DespawnComputer.
+type testMob struct {
+	*entity.PassiveBehaviour
+}
+
+func (testMob) DespawnComputer() entity.DespawnComputer { return entity.DespawnComputer{} }
+
+// testMobType is the type of a plain mob with the size of a zombie, which may
+// be spawned by its name, testMobName.
+type testMobType struct{}
+
+// testMobName is the name that mobs of testMobType are spawned by.
+const testMobName = "dragonfly:test_mob"
+
+func (testMobType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return entity.Open(tx, handle, data)
+}
+
+func (testMobType) EncodeEntity() string { return testMobName }
+func (testMobType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.8, 0.3)
+}
+func (testMobType) DecodeNBT(_ map[string]any, data *world.EntityData) {
+	data.Data = testMob{PassiveBehaviour: entity.PassiveBehaviourConfig{}.New()}
+}
+func (testMobType) EncodeNBT(*world.EntityData) map[string]any { return nil }
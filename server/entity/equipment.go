@@ -0,0 +1,245 @@
+package entity
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// EquipmentSlot is a slot of Equipment.
+type EquipmentSlot int
+
+const (
+	// EquipmentMainHand is the slot of the item held in the main hand.
+	EquipmentMainHand EquipmentSlot = iota
+	// EquipmentOffHand is the slot of the item held in the off hand.
+	EquipmentOffHand
+	// EquipmentHelmet is the slot of the helmet worn.
+	EquipmentHelmet
+	// EquipmentChestplate is the slot of the chestplate worn.
+	EquipmentChestplate
+	// EquipmentLeggings is the slot of the leggings worn.
+	EquipmentLeggings
+	// EquipmentBoots is the slot of the boots worn.
+	EquipmentBoots
+)
+
+// DefaultEquipmentDropChance is the chance that an item of Equipment is
+// dropped when the entity wearing it dies, unless changed using
+// Equipment.SetDropChance.
+const DefaultEquipmentDropChance = 0.085
+
+// Equipment holds the items held and the armour worn by a Living entity, such
+// as a mob, together with the chance that each of them is dropped when the
+// entity dies. Entities that expose their Equipment through the HeldItems and
+// Armour methods, such as a Zombie, have it shown to viewers.
+// Equipment must only be used within the transaction of the entity that
+// holds it.
+type Equipment struct {
+	held    *inventory.Inventory
+	armour  *inventory.Armour
+	chances [6]float64
+	changed bool
+}
+
+// NewEquipment returns empty Equipment with a drop chance of
+// DefaultEquipmentDropChance for every slot.
+func NewEquipment() *Equipment {
+	eq := &Equipment{}
+	eq.held = inventory.New(2, func(int, item.Stack, item.Stack) { eq.changed = true })
+	eq.armour = inventory.NewArmour(func(int, item.Stack, item.Stack) { eq.changed = true })
+	for i := range eq.chances {
+		eq.chances[i] = DefaultEquipmentDropChance
+	}
+	return eq
+}
+
+// Item returns the item in the EquipmentSlot passed.
+func (eq *Equipment) Item(slot EquipmentSlot) item.Stack {
+	if slot <= EquipmentOffHand {
+		it, _ := eq.held.Item(int(slot))
+		return it
+	}
+	it, _ := eq.armour.Inventory().Item(int(slot - EquipmentHelmet))
+	return it
+}
+
+// SetItem sets the item in the EquipmentSlot passed. An error is returned if
+// the item cannot be worn in an armour slot.
+func (eq *Equipment) SetItem(slot EquipmentSlot, it item.Stack) error {
+	if slot <= EquipmentOffHand {
+		return eq.held.SetItem(int(slot), it)
+	}
+	return eq.armour.Inventory().SetItem(int(slot-EquipmentHelmet), it)
+}
+
+// HeldItems returns the items held in the main hand and off hand.
+func (eq *Equipment) HeldItems() (mainHand, offHand item.Stack) {
+	return eq.Item(EquipmentMainHand), eq.Item(EquipmentOffHand)
+}
+
+// SetHeldItems sets the items held in the main hand and off hand.
+func (eq *Equipment) SetHeldItems(mainHand, offHand item.Stack) {
+	_ = eq.held.SetItem(0, mainHand)
+	_ = eq.held.SetItem(1, offHand)
+}
+
+// Armour returns the armour worn.
+func (eq *Equipment) Armour() *inventory.Armour {
+	return eq.armour
+}
+
+// DropChance returns the chance from 0 to 1 that the item in the
+// EquipmentSlot passed is dropped when the entity dies.
+func (eq *Equipment) DropChance(slot EquipmentSlot) float64 {
+	return eq.chances[slot]
+}
+
+// SetDropChance sets the chance that the item in the EquipmentSlot passed is
+// dropped when the entity dies. A chance of 0 or lower never drops the item.
+// A chance of 1 or higher always drops the item without damaging it, which is
+// typically used for items that the entity picked up.
+func (eq *Equipment) SetDropChance(slot EquipmentSlot, chance float64) {
+	eq.chances[slot] = chance
+}
+
+// AttackDamage returns the damage dealt by an attack with the item held in the
+// main hand, taking into account its Sharpness enchantment. base is the
+// damage dealt by the entity without holding any item.
+func (eq *Equipment) AttackDamage(base float64) float64 {
+	held := eq.Item(EquipmentMainHand)
+	dmg := base + held.AttackDamage() - 1
+	if s, ok := held.Enchantment(enchantment.Sharpness); ok {
+		dmg += enchantment.Sharpness.Addend(s.Level())
+	}
+	return dmg
+}
+
+// ReduceDamage returns the damage that remains of the damage passed after the
+// reduction by the armour worn. If the source is reduced by armour, the armour
+// worn also takes damage.
+func (eq *Equipment) ReduceDamage(dmg float64, src world.DamageSource) float64 {
+	dmg -= eq.armour.DamageReduction(dmg, src)
+	if src.ReducedByArmour() {
+		eq.armour.Damage(dmg, func(s item.Stack, d int) item.Stack {
+			return s.Damage(d)
+		})
+	}
+	return max(dmg, 0)
+}
+
+// Drops returns the items that are dropped when the entity holding the
+// Equipment dies and clears the slots of the items dropped. The chance of an
+// item dropping is increased by 1% per looting level. Items with a drop
+// chance below 1 also lose a random amount of durability.
+func (eq *Equipment) Drops(r *rand.Rand, looting int) []item.Stack {
+	var drops []item.Stack
+	for slot := EquipmentMainHand; slot <= EquipmentBoots; slot++ {
+		it, chance := eq.Item(slot), eq.chances[slot]
+		if it.Empty() || chance <= 0 || r.Float64() >= chance+float64(looting)*0.01 {
+			continue
+		}
+		if d := it.Durability(); chance < 1 && d > 1 {
+			it = it.Damage(r.IntN(d))
+		}
+		drops = append(drops, it)
+		_ = eq.SetItem(slot, item.Stack{})
+	}
+	return drops
+}
+
+// ShowChanges shows the Equipment of the entity passed to its viewers if it
+// changed since the last call to ShowChanges. It should be called every tick
+// by the entity holding the Equipment.
+func (eq *Equipment) ShowChanges(e world.Entity, tx *world.Tx) {
+	if !eq.changed {
+		return
+	}
+	eq.changed = false
+	for _, v := range tx.Viewers(e.Position()) {
+		v.ViewEntityItems(e)
+		v.ViewEntityArmour(e)
+	}
+}
+
+// EncodeNBT writes the items and drop chances of the Equipment to the map
+// passed, so that they are restored when the entity is loaded using
+// DecodeNBT.
+func (eq *Equipment) EncodeNBT(m map[string]any) {
+	chances := make([]float32, len(eq.chances))
+	for i, chance := range eq.chances {
+		chances[i] = float32(chance)
+	}
+	m["Equipment"] = nbtconv.InvToNBT(eq.held)
+	m["Armor"] = nbtconv.InvToNBT(eq.armour.Inventory())
+	m["DropChances"] = chances
+}
+
+// DecodeNBT reads the items and drop chances written using EncodeNBT from the
+// map passed. Drop chances missing from the map are left unchanged.
+func (eq *Equipment) DecodeNBT(m map[string]any) {
+	nbtconv.InvFromNBT(eq.held, nbtconv.Slice(m, "Equipment"))
+	nbtconv.InvFromNBT(eq.armour.Inventory(), nbtconv.Slice(m, "Armor"))
+	switch chances := m["DropChances"].(type) {
+	case []float32:
+		for i := range min(len(chances), len(eq.chances)) {
+			eq.chances[i] = float64(chances[i])
+		}
+	case []any:
+		for i := range min(len(chances), len(eq.chances)) {
+			if chance, ok := chances[i].(float32); ok {
+				eq.chances[i] = float64(chance)
+			}
+		}
+	}
+}
+
+// EquipRandomly fills the empty armour slots of the Equipment with random
+// armour, like mobs such as zombies and skeletons are equipped when they
+// spawn naturally. The chance of wearing armour depends on the difficulty:
+// Mobs never wear armour on peaceful and easy difficulty and are most likely
+// to wear armour on hard difficulty, where they are also more likely to wear
+// a full set. Better armour tiers are rarer than worse ones.
+func (eq *Equipment) EquipRandomly(r *rand.Rand, d world.Difficulty) {
+	var chance, stopChance float64
+	switch d {
+	case world.DifficultyNormal:
+		chance, stopChance = 0.05, 0.25
+	case world.DifficultyHard:
+		chance, stopChance = 0.15, 0.1
+	default:
+		return
+	}
+	if r.Float64() >= chance {
+		return
+	}
+	tier := 0
+	for range 3 {
+		if r.Float64() < 0.095 {
+			tier++
+		}
+	}
+	armourTier := []item.ArmourTier{item.ArmourTierLeather{}, item.ArmourTierGold{}, item.ArmourTierChain{}, item.ArmourTierIron{}}[tier]
+
+	pieces := []struct {
+		slot EquipmentSlot
+		it   world.Item
+	}{
+		{EquipmentBoots, item.Boots{Tier: armourTier}},
+		{EquipmentLeggings, item.Leggings{Tier: armourTier}},
+		{EquipmentChestplate, item.Chestplate{Tier: armourTier}},
+		{EquipmentHelmet, item.Helmet{Tier: armourTier}},
+	}
+	for i, piece := range pieces {
+		if i > 0 && r.Float64() < stopChance {
+			break
+		}
+		if eq.Item(piece.slot).Empty() {
+			_ = eq.SetItem(piece.slot, item.NewStack(piece.it, 1))
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestEquipmentReduceDamage(t *testing.T) {
+	eq := NewEquipment()
+	if got := eq.ReduceDamage(10, AttackDamageSource{}); got != 10 {
+		t.Fatalf("damage without armour = %v, want 10", got)
+	}
+	helmet := item.NewStack(item.Helmet{Tier: item.ArmourTierIron{}}, 1)
+	eq.Armour().Set(helmet, item.NewStack(item.Chestplate{Tier: item.ArmourTierIron{}}, 1), item.Stack{}, item.Stack{})
+
+	if got := eq.ReduceDamage(10, AttackDamageSource{}); got >= 10 {
+		t.Errorf("damage with armour = %v, want below 10", got)
+	}
+	if eq.Armour().Helmet().Durability() >= helmet.Durability() {
+		t.Errorf("helmet durability = %d, want below %d", eq.Armour().Helmet().Durability(), helmet.Durability())
+	}
+	if got := eq.ReduceDamage(10, FallDamageSource{}); got != 10 {
+		t.Errorf("fall damage with armour = %v, want 10", got)
+	}
+}
+
+func TestEquipmentDrops(t *testing.T) {
+	const n, chance = 10000, 0.25
+	r := rand.New(rand.NewPCG(1, 2))
+	sword := item.NewStack(item.Sword{Tier: item.ToolTierIron}, 1)
+
+	dropped := 0
+	for range n {
+		eq := NewEquipment()
+		eq.SetHeldItems(sword, item.Stack{})
+		eq.SetDropChance(EquipmentMainHand, chance)
+		if drops := eq.Drops(r, 0); len(drops) == 1 {
+			dropped++
+			if _, ok := drops[0].Item().(item.Sword); !ok {
+				t.Fatalf("dropped %v, want sword", drops[0])
+			}
+			if held, _ := eq.HeldItems(); !held.Empty() {
+				t.Fatalf("held item after dropping = %v, want empty", held)
+			}
+		}
+	}
+	if rate := float64(dropped) / n; math.Abs(rate-chance) > 0.02 {
+		t.Errorf("drop rate = %v, want about %v", rate, chance)
+	}
+
+	eq := NewEquipment()
+	eq.SetHeldItems(sword, item.Stack{})
+	eq.SetDropChance(EquipmentMainHand, 1)
+	if drops := eq.Drops(r, 0); len(drops) != 1 || drops[0].Durability() != sword.Durability() {
+		t.Errorf("drops with guaranteed chance = %v, want undamaged sword", drops)
+	}
+	eq.SetHeldItems(sword, item.Stack{})
+	eq.SetDropChance(EquipmentMainHand, 0)
+	if drops := eq.Drops(r, 3); len(drops) != 0 {
+		t.Errorf("drops with zero chance = %v, want none", drops)
+	}
+}
+
+func TestEquipmentEquipRandomly(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	armoured := 0
+	for range 1000 {
+		eq := NewEquipment()
+		eq.EquipRandomly(r, world.DifficultyEasy)
+		if len(eq.Armour().Items()) != 0 {
+			t.Fatal("expected no armour on easy difficulty")
+		}
+		eq.EquipRandomly(r, world.DifficultyHard)
+		if len(eq.Armour().Items()) != 0 {
+			armoured++
+		}
+	}
+	if armoured == 0 || armoured > 300 {
+		t.Errorf("armoured mobs on hard difficulty = %v/1000, want about 150", armoured)
+	}
+}
@@ -111,7 +111,7 @@ func (exp *ExperienceOrbBehaviour) moveToTarget(e *Ent, target experienceCollect
 		e.SetVelocity(e.Velocity().Add(diff.Normalize().Mul(0.2 * math.Pow(1-math.Sqrt(dist), 2))))
 	}
 
-	if e.H().Type().BBox(e).Translate(pos).IntersectsWith(target.H().Type().BBox(target).Translate(target.Position())) && target.CollectExperience(exp.conf.Experience) {
+	if world.EntityBBox(e).Translate(pos).IntersectsWith(world.EntityBBox(target).Translate(target.Position())) && target.CollectExperience(exp.conf.Experience) {
 		_ = e.Close()
 	}
 }
@@ -127,3 +127,8 @@ type experienceCollector interface {
 	// CanCollectExperience returns whether the player can collect experience or not.
 	CanCollectExperience() bool
 }
+
+// base returns the BaseBehaviour of the underlying PassiveBehaviour.
+func (exp *ExperienceOrbBehaviour) base() *BaseBehaviour {
+	return exp.passive.base()
+}
@@ -101,7 +101,7 @@ func (f *FallingBlockBehaviour) damageEntities(e *Ent, d damager, pos mgl64.Vec3
 	dmg := math.Min(math.Floor(dist*damagePerBlock), maxDamage)
 	src := block.DamageSource{Block: f.block}
 
-	for e := range filterLiving(tx.EntitiesWithin(e.H().Type().BBox(e).Translate(pos).Grow(0.05))) {
+	for e := range filterLiving(tx.EntitiesWithin(world.EntityBBox(e).Translate(pos).Grow(0.05))) {
 		e.(Living).Hurt(dmg, src)
 	}
 	if b, ok := f.block.(breakable); ok && dmg > 0.0 && rand.Float64() < (dist+1)*0.05 {
@@ -135,3 +135,8 @@ type breakable interface {
 type landable interface {
 	Landed(tx *world.Tx, pos cube.Pos)
 }
+
+// base returns the BaseBehaviour of the underlying PassiveBehaviour.
+func (f *FallingBlockBehaviour) base() *BaseBehaviour {
+	return f.passive.base()
+}
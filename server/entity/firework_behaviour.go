@@ -98,7 +98,7 @@ func (f *FireworkBehaviour) explode(e *Ent, tx *world.Tx) {
 	owner, _ := f.conf.Owner.Entity(tx)
 	pos, explosions := e.Position(), f.conf.Firework.Explosions
 
-	for _, v := range tx.Viewers(pos) {
+	for _, v := range tx.EntityViewers(e) {
 		v.ViewEntityAction(e, FireworkExplosionAction{})
 	}
 	for _, explosion := range explosions {
@@ -117,7 +117,7 @@ func (f *FireworkBehaviour) explode(e *Ent, tx *world.Tx) {
 	}
 
 	force := float64(len(explosions)*2) + 5.0
-	for victim := range filterLiving(tx.EntitiesWithin(e.H().Type().BBox(e).Translate(pos).Grow(5.25))) {
+	for victim := range filterLiving(tx.EntitiesWithin(world.EntityBBox(e).Translate(pos).Grow(5.25))) {
 		tpos := victim.Position()
 		dist := pos.Sub(tpos).Len()
 		if dist > 5.0 {
@@ -131,7 +131,7 @@ func (f *FireworkBehaviour) explode(e *Ent, tx *world.Tx) {
 			victim.(Living).Hurt(dmg, src)
 			continue
 		}
-		if _, ok := trace.Perform(pos, tpos, tx, victim.H().Type().BBox(victim).Grow(0.3), nil); ok {
+		if _, ok := trace.Perform(pos, tpos, tx, world.EntityBBox(victim).Grow(0.3), nil); ok {
 			victim.(Living).Hurt(dmg, src)
 		}
 	}
@@ -149,3 +149,8 @@ func filterLiving(seq iter.Seq[world.Entity]) iter.Seq[world.Entity] {
 		}
 	}
 }
+
+// base returns the BaseBehaviour of the underlying PassiveBehaviour.
+func (f *FireworkBehaviour) base() *BaseBehaviour {
+	return f.passive.base()
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewFishingHook creates a fishing hook entity cast by an owner entity. lure
+// and luckOfTheSea are the levels of the Lure and Luck of the Sea enchantments
+// of the fishing rod that cast the hook.
+func NewFishingHook(opts world.EntitySpawnOpts, owner world.Entity, lure, luckOfTheSea int) *world.EntityHandle {
+	conf := fishingHookConf
+	conf.Owner = owner.H()
+	conf.Lure, conf.LuckOfTheSea = lure, luckOfTheSea
+	return opts.New(FishingHookType, conf)
+}
+
+var fishingHookConf = FishingHookBehaviourConfig{
+	Gravity: 0.03,
+	Drag:    0.08,
+	Loot:    DefaultFishingLoot,
+}
+
+// FishingHookType is a world.EntityType implementation for fishing hooks.
+var FishingHookType fishingHookType
+
+type fishingHookType struct{}
+
+func (t fishingHookType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (fishingHookType) EncodeEntity() string { return "minecraft:fishing_hook" }
+func (fishingHookType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.125, 0, -0.125, 0.125, 0.25, 0.125)
+}
+
+// DecodeNBT creates a fishing hook without an owner. Fishing hooks are not
+// meant to persist, so the hook is removed the first time it is ticked.
+func (fishingHookType) DecodeNBT(_ map[string]any, data *world.EntityData) {
+	data.Data = fishingHookConf.New()
+}
+func (fishingHookType) EncodeNBT(*world.EntityData) map[string]any { return nil }
@@ -0,0 +1,293 @@
+package entity
+
+import (
+	"iter"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// FishingHookBehaviourConfig holds optional parameters for a
+// FishingHookBehaviour.
+type FishingHookBehaviourConfig struct {
+	// Owner is the entity that cast the fishing hook. The hook is removed if
+	// the owner is no longer holding a fishing rod.
+	Owner *world.EntityHandle
+	// Gravity is the amount of Y velocity subtracted every tick.
+	Gravity float64
+	// Drag is used to reduce all axes of the velocity every tick. Velocity is
+	// multiplied with (1-Drag) every tick.
+	Drag float64
+	// Lure is the level of the Lure enchantment of the fishing rod that cast
+	// the hook. Every level reduces the time until a fish bites.
+	Lure int
+	// LuckOfTheSea is the level of the Luck of the Sea enchantment of the
+	// fishing rod that cast the hook. Every level increases the chance of
+	// catching treasure.
+	LuckOfTheSea int
+	// Loot is the FishingLootTable that loot caught by the hook is selected
+	// from.
+	Loot FishingLootTable
+}
+
+func (conf FishingHookBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a FishingHookBehaviour using the parameters in conf.
+func (conf FishingHookBehaviourConfig) New() *FishingHookBehaviour {
+	return &FishingHookBehaviour{
+		BaseBehaviour: NewBaseBehaviour(),
+		conf:          conf,
+		mc:            &MovementComputer{Gravity: conf.Gravity, Drag: conf.Drag, DragBeforeGravity: true},
+	}
+}
+
+// FishingHookBehaviour implements the behaviour of a fishing hook. A fishing
+// hook floats on water until a fish bites, which may then be caught by
+// reeling the hook in. Entities hit by the hook are hooked and pulled towards
+// the owner when reeled in.
+type FishingHookBehaviour struct {
+	BaseBehaviour
+
+	conf FishingHookBehaviourConfig
+	mc   *MovementComputer
+
+	hooked *world.EntityHandle
+	// wait is the time left until a fish bites the hook. bite is the time left
+	// for the hook to be reeled in to catch the fish that bit it.
+	wait, bite time.Duration
+}
+
+// fishingHookMaxDistance is the distance in blocks that a fishing hook may be
+// away from its owner before it is removed.
+const fishingHookMaxDistance = 32
+
+// Owner returns the handle of the entity that cast the fishing hook.
+func (f *FishingHookBehaviour) Owner() *world.EntityHandle {
+	return f.conf.Owner
+}
+
+// HookedEntity returns the handle of the entity hooked by the fishing hook, or
+// nil if no entity is hooked.
+func (f *FishingHookBehaviour) HookedEntity() *world.EntityHandle {
+	return f.hooked
+}
+
+// Biting returns true if a fish is currently biting the hook, meaning it will
+// be caught if the hook is reeled in.
+func (f *FishingHookBehaviour) Biting() bool {
+	return f.bite > 0
+}
+
+// Tick moves the fishing hook, keeps it attached to the entity it hooked and
+// makes fish bite it while it floats on water.
+func (f *FishingHookBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	if !f.ownerInRange(e, tx) {
+		_ = e.Close()
+		return nil
+	}
+	if f.hooked != nil {
+		if hooked, ok := f.hooked.Entity(tx); ok {
+			box := world.EntityBBox(hooked)
+			pos := hooked.Position().Add(mgl64.Vec3{0, box.Height() * 0.8})
+			m := &Movement{v: tx.EntityViewers(e), e: e, pos: pos, dpos: pos.Sub(e.data.Pos), dvel: e.data.Vel.Mul(-1), rot: e.data.Rot}
+			e.data.Pos, e.data.Vel = pos, mgl64.Vec3{}
+			return m
+		}
+		f.hooked = nil
+		tx.UpdateEntityState(e)
+	}
+
+	vel := e.data.Vel
+	if fishingWater(tx, e.data.Pos) {
+		vel[0], vel[2] = vel[0]*0.9, vel[2]*0.9
+		vel[1] = min(vel[1]*0.8+fishingHookBuoyancy, 0.1)
+	}
+	// The hook bobs up and down on the surface of the water, so it is still
+	// considered to be floating if it is just above water.
+	floating := fishingWater(tx, e.data.Pos.Sub(mgl64.Vec3{0, 0.5}))
+	if !floating && f.hookEntity(e, tx, vel) {
+		return nil
+	}
+
+	m := f.mc.TickMovement(e, e.data.Pos, vel, e.data.Rot, tx)
+	e.data.Pos, e.data.Vel = m.pos, m.vel
+	if floating {
+		f.tickFishing(e, tx)
+	} else {
+		f.wait, f.bite = 0, 0
+	}
+	return m
+}
+
+// fishingWater checks if there is water at the position passed.
+func fishingWater(tx *world.Tx, pos mgl64.Vec3) bool {
+	l, ok := tx.Liquid(cube.PosFromVec3(pos))
+	return ok && l.LiquidType() == "water"
+}
+
+// fishingHookBuoyancy is the Y velocity added to a fishing hook every tick
+// that it is in water.
+const fishingHookBuoyancy = 0.06
+
+// ownerInRange checks if the owner of the fishing hook is still holding a
+// fishing rod and is close enough to the hook for it to remain.
+func (f *FishingHookBehaviour) ownerInRange(e *Ent, tx *world.Tx) bool {
+	if f.conf.Owner == nil {
+		return false
+	}
+	owner, ok := f.conf.Owner.Entity(tx)
+	if !ok || owner.Position().Sub(e.data.Pos).Len() > fishingHookMaxDistance {
+		return false
+	}
+	h, ok := owner.(interface {
+		HeldItems() (mainHand, offHand item.Stack)
+	})
+	if !ok {
+		return false
+	}
+	main, off := h.HeldItems()
+	_, mainRod := main.Item().(item.FishingRod)
+	_, offRod := off.Item().(item.FishingRod)
+	return mainRod || offRod
+}
+
+// hookEntity checks if the fishing hook collides with an entity when moving
+// with the velocity passed. If so, the entity is hooked and true is returned.
+func (f *FishingHookBehaviour) hookEntity(e *Ent, tx *world.Tx, vel mgl64.Vec3) bool {
+	pos := e.data.Pos
+	hit, ok := trace.Perform(pos, pos.Add(vel), tx, world.EntityBBox(e).Grow(0.3), f.ignores(e))
+	if !ok {
+		return false
+	}
+	r, ok := hit.(trace.EntityResult)
+	if !ok {
+		return false
+	}
+	f.hooked = r.Entity().H()
+	e.data.Pos, e.data.Vel = r.Position(), mgl64.Vec3{}
+	tx.UpdateEntityState(e)
+	return true
+}
+
+// ignores returns a function to ignore entities in trace.Perform that are not
+// living, the fishing hook itself, or its owner.
+func (f *FishingHookBehaviour) ignores(e *Ent) trace.EntityFilter {
+	return func(seq iter.Seq[world.Entity]) iter.Seq[world.Entity] {
+		return func(yield func(world.Entity) bool) {
+			for other := range seq {
+				g, ok := other.(interface{ GameMode() world.GameMode })
+				spectator := ok && !g.GameMode().HasCollision()
+				_, living := other.(Living)
+				if spectator || !living || other.H() == e.H() || other.H() == f.conf.Owner {
+					continue
+				}
+				if !yield(other) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// tickFishing progresses the time until a fish bites the hook and shows the
+// bite once it happens.
+func (f *FishingHookBehaviour) tickFishing(e *Ent, tx *world.Tx) {
+	if f.bite > 0 {
+		if f.bite -= time.Second / 20; f.bite <= 0 {
+			// The fish got away, so start waiting for another one.
+			f.wait = fishingWaitTime(f.conf.Lure)
+		}
+		return
+	}
+	if f.wait <= 0 {
+		f.wait = fishingWaitTime(f.conf.Lure)
+	}
+	if f.wait -= time.Second / 20; f.wait > 0 {
+		return
+	}
+	f.bite = fishingBiteTime()
+	e.data.Vel[1] -= 0.2
+	for _, v := range tx.EntityViewers(e) {
+		v.ViewEntityAction(e, FishingHookBiteAction{})
+	}
+	for range 6 {
+		tx.AddParticle(e.data.Pos.Add(mgl64.Vec3{rand.Float64() - 0.5, 0, rand.Float64() - 0.5}), particle.Bubble{})
+	}
+}
+
+const (
+	// fishingMinWaitTime and fishingMaxWaitTime are the bounds of the time
+	// until a fish bites a fishing hook without Lure.
+	fishingMinWaitTime, fishingMaxWaitTime = time.Second * 5, time.Second * 30
+	// fishingLureMinWaitTime is the lowest that fishingMinWaitTime may be
+	// lowered to by Lure.
+	fishingLureMinWaitTime = time.Second
+	// fishingMinBiteTime and fishingMaxBiteTime are the bounds of the time
+	// that a fish remains on the hook after biting it.
+	fishingMinBiteTime, fishingMaxBiteTime = time.Second, time.Second * 2
+)
+
+// fishingWaitTime returns a random time until a fish bites a fishing hook
+// cast by a fishing rod with the level of Lure passed.
+func fishingWaitTime(lure int) time.Duration {
+	reduction := enchantment.Lure.WaitReduction(lure)
+	lower := max(fishingMinWaitTime-reduction, fishingLureMinWaitTime)
+	upper := max(fishingMaxWaitTime-reduction, lower)
+	return lower + randomTicks(upper-lower)
+}
+
+// fishingBiteTime returns a random time that a fish remains on the hook after
+// biting it.
+func fishingBiteTime() time.Duration {
+	return fishingMinBiteTime + randomTicks(fishingMaxBiteTime-fishingMinBiteTime)
+}
+
+// randomTicks returns a random duration between 0 and d, inclusive, rounded to
+// whole ticks.
+func randomTicks(d time.Duration) time.Duration {
+	return time.Duration(rand.IntN(int(d/(time.Second/20))+1)) * (time.Second / 20)
+}
+
+// Reel reels in the fishing hook. If an entity was hooked, it is pulled
+// towards the owner. If a fish was biting, loot from the FishingLootTable is
+// thrown towards the owner along with experience. The hook is closed and the
+// durability that the fishing rod loses is returned.
+func (f *FishingHookBehaviour) Reel(e *Ent, tx *world.Tx) int {
+	defer e.Close()
+	owner, ok := f.conf.Owner.Entity(tx)
+	if !ok {
+		return 0
+	}
+	if f.hooked != nil {
+		hooked, ok := f.hooked.Entity(tx)
+		if v, moving := hooked.(interface{ SetVelocity(mgl64.Vec3) }); ok && moving {
+			v.SetVelocity(owner.Position().Sub(hooked.Position()).Mul(0.1))
+			return 5
+		}
+		return 0
+	}
+	if f.bite > 0 {
+		pos := e.data.Pos
+		diff := owner.Position().Sub(pos)
+		vel := diff.Mul(0.1).Add(mgl64.Vec3{0, math.Sqrt(diff.Len()) * 0.08})
+		if loot := f.conf.Loot.Roll(f.conf.LuckOfTheSea); !loot.Empty() {
+			tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos, Velocity: vel}, loot))
+		}
+		tx.AddEntity(NewExperienceOrb(world.EntitySpawnOpts{Position: owner.Position()}, rand.IntN(6)+1))
+		return 1
+	}
+	if f.mc.OnGround() {
+		return 2
+	}
+	return 0
+}
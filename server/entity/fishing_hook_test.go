@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFishingWaitTimeBounds(t *testing.T) {
+	for _, tc := range []struct {
+		lure         int
+		lower, upper time.Duration
+	}{
+		{lure: 0, lower: time.Second * 5, upper: time.Second * 30},
+		{lure: 1, lower: time.Second, upper: time.Second * 25},
+		{lure: 3, lower: time.Second, upper: time.Second * 15},
+	} {
+		lowest, highest := time.Duration(math.MaxInt64), time.Duration(0)
+		for range 10000 {
+			d := fishingWaitTime(tc.lure)
+			if d < tc.lower || d > tc.upper {
+				t.Fatalf("wait time with lure %v = %v, want between %v and %v", tc.lure, d, tc.lower, tc.upper)
+			}
+			if d%(time.Second/20) != 0 {
+				t.Fatalf("wait time with lure %v = %v, want whole ticks", tc.lure, d)
+			}
+			lowest, highest = min(lowest, d), max(highest, d)
+		}
+		// With 10000 samples of at most 601 possible values, both bounds are
+		// practically certain to be reached.
+		if lowest != tc.lower || highest != tc.upper {
+			t.Errorf("wait times with lure %v ranged from %v to %v, want %v to %v", tc.lure, lowest, highest, tc.lower, tc.upper)
+		}
+	}
+}
+
+func TestFishingBiteTimeBounds(t *testing.T) {
+	for range 1000 {
+		if d := fishingBiteTime(); d < time.Second || d > time.Second*2 {
+			t.Fatalf("bite time = %v, want between 1s and 2s", d)
+		}
+	}
+}
+
+func TestFishingLootCategoryDistribution(t *testing.T) {
+	const samples = 200000
+	for _, tc := range []struct {
+		luck                 int
+		fish, treasure, junk float64
+	}{
+		{luck: 0, fish: 0.85, treasure: 0.05, junk: 0.10},
+		{luck: 3, fish: 82.0 / 97, treasure: 11.0 / 97, junk: 4.0 / 97},
+	} {
+		var counts [3]int
+		for range samples {
+			counts[DefaultFishingLoot.category(tc.luck)]++
+		}
+		for c, want := range map[fishingCategory]float64{fishingCategoryFish: tc.fish, fishingCategoryTreasure: tc.treasure, fishingCategoryJunk: tc.junk} {
+			if got := float64(counts[c]) / samples; math.Abs(got-want) > 0.01 {
+				t.Errorf("share of category %v with luck of the sea %v = %.3f, want %.3f", c, tc.luck, got, want)
+			}
+		}
+	}
+
+	// Categories without entries are never selected.
+	table := FishingLootTable{Fish: DefaultFishingLoot.Fish}
+	for range 1000 {
+		if c := table.category(3); c != fishingCategoryFish {
+			t.Fatalf("category of table with only fish = %v, want fish", c)
+		}
+	}
+	if s := (FishingLootTable{}).Roll(0); !s.Empty() {
+		t.Errorf("loot of empty table = %v, want empty stack", s)
+	}
+}
@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/potion"
+)
+
+// FishingLootTable holds the loot that may be caught using a fishing rod. The
+// loot is divided into fish, treasure and junk. Every time loot is caught, one
+// of these categories is selected first, after which an entry of that
+// category is selected based on its weight.
+type FishingLootTable struct {
+	// Fish, Treasure and Junk are the entries of the loot table in each
+	// category. A category without entries is never selected.
+	Fish, Treasure, Junk []FishingLootEntry
+}
+
+// FishingLootEntry is an entry of a FishingLootTable.
+type FishingLootEntry struct {
+	// Item is the item.Stack that is caught if the entry is selected.
+	Item item.Stack
+	// Weight is the weight of the entry relative to the other entries in its
+	// category. Entries with a higher weight are more likely to be selected.
+	Weight int
+}
+
+// DefaultFishingLoot is the FishingLootTable used by fishing hooks by default.
+// It holds the vanilla fishing loot implemented by Dragonfly.
+var DefaultFishingLoot = FishingLootTable{
+	Fish: []FishingLootEntry{
+		{Item: item.NewStack(item.Cod{}, 1), Weight: 60},
+		{Item: item.NewStack(item.Salmon{}, 1), Weight: 25},
+		{Item: item.NewStack(item.TropicalFish{}, 1), Weight: 2},
+		{Item: item.NewStack(item.Pufferfish{}, 1), Weight: 13},
+	},
+	Treasure: []FishingLootEntry{
+		{Item: item.NewStack(item.Bow{}, 1), Weight: 1},
+		{Item: item.NewStack(item.EnchantedBook{}, 1), Weight: 1},
+		{Item: item.NewStack(item.FishingRod{}, 1), Weight: 1},
+		{Item: item.NewStack(item.NautilusShell{}, 1), Weight: 1},
+	},
+	Junk: []FishingLootEntry{
+		{Item: item.NewStack(block.LilyPad{}, 1), Weight: 17},
+		{Item: item.NewStack(item.Bowl{}, 1), Weight: 10},
+		{Item: item.NewStack(item.Leather{}, 1), Weight: 10},
+		{Item: item.NewStack(item.Boots{Tier: item.ArmourTierLeather{}}, 1), Weight: 10},
+		{Item: item.NewStack(item.RottenFlesh{}, 1), Weight: 10},
+		{Item: item.NewStack(item.Potion{Type: potion.Water()}, 1), Weight: 10},
+		{Item: item.NewStack(item.Bone{}, 1), Weight: 10},
+		{Item: item.NewStack(item.Stick{}, 1), Weight: 5},
+		{Item: item.NewStack(block.String{}, 1), Weight: 5},
+		{Item: item.NewStack(item.InkSac{}, 10), Weight: 1},
+	},
+}
+
+// fishingCategory is a category of loot in a FishingLootTable.
+type fishingCategory int
+
+const (
+	fishingCategoryFish fishingCategory = iota
+	fishingCategoryTreasure
+	fishingCategoryJunk
+)
+
+// Roll selects a random item.Stack from the FishingLootTable. luck is the
+// level of Luck of the Sea of the fishing rod used, which increases the
+// chance of treasure and decreases the chance of fish and junk. An empty
+// item.Stack is returned if the FishingLootTable has no entries.
+func (t FishingLootTable) Roll(luck int) item.Stack {
+	switch t.category(luck) {
+	case fishingCategoryTreasure:
+		return rollFishingEntries(t.Treasure)
+	case fishingCategoryJunk:
+		return rollFishingEntries(t.Junk)
+	}
+	return rollFishingEntries(t.Fish)
+}
+
+// category selects a random category of the FishingLootTable, weighted by the
+// luck passed.
+func (t FishingLootTable) category(luck int) fishingCategory {
+	weights := [...]int{
+		fishingCategoryFish:     max(85-luck, 0),
+		fishingCategoryTreasure: 5 + luck*2,
+		fishingCategoryJunk:     max(10-luck*2, 0),
+	}
+	entries := [...][]FishingLootEntry{t.Fish, t.Treasure, t.Junk}
+	total := 0
+	for i := range weights {
+		if len(entries[i]) == 0 {
+			weights[i] = 0
+		}
+		total += weights[i]
+	}
+	if total == 0 {
+		return fishingCategoryFish
+	}
+	n := rand.IntN(total)
+	for i, w := range weights {
+		if n < w {
+			return fishingCategory(i)
+		}
+		n -= w
+	}
+	return fishingCategoryFish
+}
+
+// rollFishingEntries selects a random item.Stack from the entries passed based
+// on their weight.
+func rollFishingEntries(entries []FishingLootEntry) item.Stack {
+	total := 0
+	for _, e := range entries {
+		total += max(e.Weight, 0)
+	}
+	if total == 0 {
+		return item.Stack{}
+	}
+	n := rand.IntN(total)
+	for _, e := range entries {
+		if n < max(e.Weight, 0) {
+			return e.Item
+		}
+		n -= max(e.Weight, 0)
+	}
+	return item.Stack{}
+}
@@ -0,0 +1,65 @@
+package entity
+
+// MaxFrozenTicks is the amount of ticks an entity must spend in powder snow before it is fully frozen.
+const MaxFrozenTicks = 140
+
+// FreezeComputer handles the freezing of an entity standing in powder snow. The entity gradually freezes
+// while inside powder snow and thaws when it leaves it. Fully frozen entities take freezing damage every two
+// seconds.
+type FreezeComputer struct {
+	ticks       int
+	damageTicks int
+	inside      bool
+}
+
+// EnterPowderSnow marks the entity as being inside powder snow for the current tick.
+func (c *FreezeComputer) EnterPowderSnow() {
+	c.inside = true
+}
+
+// InPowderSnow returns true if the entity was inside powder snow during the current tick.
+func (c *FreezeComputer) InPowderSnow() bool {
+	return c.inside
+}
+
+// Tick ticks the FreezeComputer. canFreeze specifies if the entity can currently freeze, which is not the case
+// if it is, for example, wearing leather armour. The frozen ticks increase by one while the entity is in powder
+// snow and can freeze, and decrease by two otherwise. Tick returns true if the entity should be hurt by
+// freezing damage.
+func (c *FreezeComputer) Tick(canFreeze bool) bool {
+	if c.inside && canFreeze {
+		c.ticks = min(c.ticks+1, MaxFrozenTicks)
+	} else {
+		c.ticks = max(c.ticks-2, 0)
+	}
+	c.inside = false
+
+	if !c.FullyFrozen() {
+		c.damageTicks = 0
+		return false
+	}
+	c.damageTicks++
+	return c.damageTicks%40 == 0
+}
+
+// FrozenTicks returns the amount of ticks the entity has been freezing for.
+func (c *FreezeComputer) FrozenTicks() int {
+	return c.ticks
+}
+
+// SetFrozenTicks sets the amount of ticks the entity has been freezing for. The value is clamped between 0
+// and MaxFrozenTicks.
+func (c *FreezeComputer) SetFrozenTicks(ticks int) {
+	c.ticks = max(min(ticks, MaxFrozenTicks), 0)
+}
+
+// FullyFrozen returns true if the entity has been freezing for MaxFrozenTicks.
+func (c *FreezeComputer) FullyFrozen() bool {
+	return c.ticks >= MaxFrozenTicks
+}
+
+// FreezingStrength returns a value between 0 and 1 that indicates how frozen the entity is. It is used to
+// display the freezing overlay on the screen of players.
+func (c *FreezeComputer) FreezingStrength() float64 {
+	return float64(c.ticks) / MaxFrozenTicks
+}
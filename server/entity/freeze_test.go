@@ -0,0 +1,42 @@
+package entity
+
+import "testing"
+
+func TestFreezeComputerFrozenTicks(t *testing.T) {
+	c := &FreezeComputer{}
+	for range 10 {
+		c.EnterPowderSnow()
+		c.Tick(true)
+	}
+	if got := c.FrozenTicks(); got != 10 {
+		t.Fatalf("frozen ticks after 10 ticks in powder snow = %v, want 10", got)
+	}
+
+	c.Tick(true)
+	if got := c.FrozenTicks(); got != 8 {
+		t.Fatalf("frozen ticks after leaving powder snow = %v, want 8", got)
+	}
+
+	c.EnterPowderSnow()
+	c.Tick(false)
+	if got := c.FrozenTicks(); got != 6 {
+		t.Fatalf("frozen ticks in powder snow while unable to freeze = %v, want 6", got)
+	}
+
+	damaged := 0
+	for range MaxFrozenTicks + 80 {
+		c.EnterPowderSnow()
+		if c.Tick(true) {
+			damaged++
+		}
+	}
+	if !c.FullyFrozen() || c.FrozenTicks() != MaxFrozenTicks {
+		t.Fatalf("frozen ticks = %v, want fully frozen at %v", c.FrozenTicks(), MaxFrozenTicks)
+	}
+	if damaged == 0 {
+		t.Fatal("fully frozen entity was never damaged")
+	}
+	if c.FreezingStrength() != 1 {
+		t.Fatalf("freezing strength of fully frozen entity = %v, want 1", c.FreezingStrength())
+	}
+}
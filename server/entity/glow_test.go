@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+)
+
+func TestEntSetGlowing(t *testing.T) {
+	teams := world.NewTeams()
+	w := world.Config{Entities: DefaultRegistry, Teams: teams}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	v := &glowTestViewer{}
+	l := world.NewLoader(2, w, v)
+	pos := mgl64.Vec3{0.5, 64, 0.5}
+	mustDo(t, w, func(tx *world.Tx) {
+		l.Move(tx, pos)
+		l.Load(tx, 16)
+		e := tx.AddEntity(NewText("quest target", pos)).(*Ent)
+		if e.Glowing() {
+			t.Fatal("expected new entity not to be glowing")
+		}
+
+		e.SetGlowing(true)
+		if !e.Glowing() || len(v.states) != 1 || !v.states[0] {
+			t.Fatalf("glowing after SetGlowing(true) = %v, viewed states %v, want one glowing state", e.Glowing(), v.states)
+		}
+		// Setting the same value again does not update viewers.
+		e.SetGlowing(true)
+		if len(v.states) != 1 {
+			t.Errorf("viewed states = %v, want no update when glow did not change", v.states)
+		}
+
+		if c := teams.GlowColour(e.H().UUID()); c != text.White {
+			t.Errorf("glow colour without team = %q, want white", c)
+		}
+		gold, _ := teams.Create("gold")
+		gold.SetColour(text.Gold)
+		gold.Add(e)
+		if c := teams.GlowColour(e.H().UUID()); c != text.Gold {
+			t.Errorf("glow colour in team = %q, want the team colour", c)
+		}
+
+		e.SetGlowing(false)
+		if e.Glowing() || v.states[len(v.states)-1] {
+			t.Fatalf("glowing after SetGlowing(false) = %v, last viewed state glowing = %v, want false", e.Glowing(), v.states[len(v.states)-1])
+		}
+	})
+}
+
+// glowTestViewer records if entities were glowing when their state was
+// viewed.
+type glowTestViewer struct {
+	world.NopViewer
+	states []bool
+}
+
+func (v *glowTestViewer) ViewEntityState(e world.Entity) {
+	v.states = append(v.states, e.(*Ent).Glowing())
+}
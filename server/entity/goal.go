@@ -0,0 +1,201 @@
+package entity
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Mob is a Living entity whose behaviour is controlled by the goals of a
+// GoalSelector.
+type Mob interface {
+	Living
+	// Target returns the handle of the entity that the mob is targeting, or
+	// nil if it has no target.
+	Target() *world.EntityHandle
+	// SetTarget changes the entity that the mob is targeting. Passing nil
+	// clears the target.
+	SetTarget(target *world.EntityHandle)
+}
+
+// GoalFlag is a flag of a control of a Mob that a Goal uses. Two goals that
+// share a GoalFlag never run at the same time, so that, for example, two
+// goals that move the mob never move it in different directions at once.
+type GoalFlag uint8
+
+const (
+	// GoalFlagMove is held by goals that move the mob.
+	GoalFlagMove GoalFlag = 1 << iota
+	// GoalFlagLook is held by goals that change the rotation of the mob.
+	GoalFlagLook
+	// GoalFlagJump is held by goals that make the mob jump.
+	GoalFlagJump
+	// GoalFlagTarget is held by goals that change the target of the mob.
+	GoalFlagTarget
+)
+
+// Goal is a single behaviour of a Mob, such as wandering around or attacking
+// its target. Goals are added to a GoalSelector with a priority, which
+// decides which goals run when multiple goals need the same controls of the
+// mob.
+type Goal interface {
+	// Flags returns the GoalFlags of the controls of the mob that the goal
+	// uses while running.
+	Flags() GoalFlag
+	// CanStart checks if the goal is able to start running. CanStart is
+	// called every tick while the goal is not running.
+	CanStart(m Mob, tx *world.Tx) bool
+	// CanContinue checks if the goal is able to keep running. The goal is
+	// stopped once CanContinue returns false.
+	CanContinue(m Mob, tx *world.Tx) bool
+	// Start is called when the goal starts running.
+	Start(m Mob, tx *world.Tx)
+	// Stop is called when the goal stops running, either because CanContinue
+	// returned false or because a goal with a higher priority took over one of
+	// its controls.
+	Stop(m Mob, tx *world.Tx)
+	// Tick is called every tick while the goal is running.
+	Tick(m Mob, tx *world.Tx)
+}
+
+// GoalSelector selects the goals of a Mob that run every tick. Goals with a
+// lower priority value take precedence: A goal that is able to start takes
+// over the GoalFlags held by running goals with a higher priority value,
+// stopping those goals. A goal never starts if one of its GoalFlags is held by
+// a running goal with the same or a lower priority value.
+// Combining goals, a zombie could, for example, be composed of a
+// NearestTargetGoal, a MeleeAttackGoal and a WanderGoal:
+//
+//	s := entity.NewGoalSelector()
+//	s.Add(1, &entity.NearestTargetGoal{Radius: 35, Filter: isPlayer})
+//	s.Add(2, &entity.MeleeAttackGoal{Damage: 3})
+//	s.Add(7, &entity.WanderGoal{})
+//
+// A GoalSelector must only be used within the transaction of its Mob.
+type GoalSelector struct {
+	goals []*selectedGoal
+	flags map[GoalFlag]*selectedGoal
+}
+
+// selectedGoal is a Goal added to a GoalSelector with a priority.
+type selectedGoal struct {
+	Goal
+	priority int
+	running  bool
+}
+
+// NewGoalSelector returns a GoalSelector without any goals.
+func NewGoalSelector() *GoalSelector {
+	return &GoalSelector{flags: make(map[GoalFlag]*selectedGoal)}
+}
+
+// Add adds a Goal to the GoalSelector with the priority passed. Goals with a
+// lower priority value take precedence over goals with a higher one. Goals
+// with the same priority are considered in the order they were added.
+func (s *GoalSelector) Add(priority int, g Goal) {
+	i := slices.IndexFunc(s.goals, func(goal *selectedGoal) bool {
+		return goal.priority > priority
+	})
+	if i == -1 {
+		i = len(s.goals)
+	}
+	s.goals = slices.Insert(s.goals, i, &selectedGoal{Goal: g, priority: priority})
+}
+
+// Remove removes a Goal from the GoalSelector, stopping it if it was
+// running.
+func (s *GoalSelector) Remove(g Goal, m Mob, tx *world.Tx) {
+	s.goals = slices.DeleteFunc(s.goals, func(goal *selectedGoal) bool {
+		if goal.Goal != g {
+			return false
+		}
+		s.stop(goal, m, tx)
+		return true
+	})
+}
+
+// Running returns all goals of the GoalSelector that are currently running,
+// ordered by their priority.
+func (s *GoalSelector) Running() []Goal {
+	var running []Goal
+	for _, goal := range s.goals {
+		if goal.running {
+			running = append(running, goal.Goal)
+		}
+	}
+	return running
+}
+
+// Stop stops all goals that are currently running.
+func (s *GoalSelector) Stop(m Mob, tx *world.Tx) {
+	for _, goal := range s.goals {
+		s.stop(goal, m, tx)
+	}
+}
+
+// Tick ticks the GoalSelector. Running goals that are no longer able to
+// continue are stopped first, after which goals that are able to start are
+// started if their GoalFlags are free or held by goals with a higher priority
+// value. Finally, all running goals are ticked.
+func (s *GoalSelector) Tick(m Mob, tx *world.Tx) {
+	for _, goal := range s.goals {
+		if goal.running && !goal.CanContinue(m, tx) {
+			s.stop(goal, m, tx)
+		}
+	}
+	for _, goal := range s.goals {
+		if goal.running || !s.available(goal) || !goal.CanStart(m, tx) {
+			continue
+		}
+		for flag := range goalFlags(goal.Flags()) {
+			if holder, ok := s.flags[flag]; ok {
+				s.stop(holder, m, tx)
+			}
+			s.flags[flag] = goal
+		}
+		goal.running = true
+		goal.Start(m, tx)
+	}
+	for _, goal := range s.goals {
+		if goal.running {
+			goal.Tick(m, tx)
+		}
+	}
+}
+
+// available checks if all GoalFlags of the goal passed are either free or
+// held by a goal with a higher priority value.
+func (s *GoalSelector) available(goal *selectedGoal) bool {
+	for flag := range goalFlags(goal.Flags()) {
+		if holder, ok := s.flags[flag]; ok && holder.priority <= goal.priority {
+			return false
+		}
+	}
+	return true
+}
+
+// stop stops the goal passed if it is running and releases its GoalFlags.
+func (s *GoalSelector) stop(goal *selectedGoal, m Mob, tx *world.Tx) {
+	if !goal.running {
+		return
+	}
+	goal.running = false
+	for flag := range goalFlags(goal.Flags()) {
+		if s.flags[flag] == goal {
+			delete(s.flags, flag)
+		}
+	}
+	goal.Stop(m, tx)
+}
+
+// goalFlags returns an iterator over the individual GoalFlags set in f.
+func goalFlags(f GoalFlag) iter.Seq[GoalFlag] {
+	return func(yield func(GoalFlag) bool) {
+		for flag := GoalFlagMove; flag <= GoalFlagTarget; flag <<= 1 {
+			if f&flag != 0 && !yield(flag) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package entity
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestGoalSelectorPreemptsLowerPriorityGoals(t *testing.T) {
+	s := NewGoalSelector()
+	wander := &goalTestGoal{flags: GoalFlagMove, start: true}
+	attack := &goalTestGoal{flags: GoalFlagMove | GoalFlagLook}
+	s.Add(5, wander)
+	s.Add(1, attack)
+
+	s.Tick(nil, nil)
+	if running := s.Running(); !slices.Equal(running, []Goal{wander}) {
+		t.Fatalf("running goals = %v, want only the lower priority goal", running)
+	}
+
+	attack.start = true
+	s.Tick(nil, nil)
+	if running := s.Running(); !slices.Equal(running, []Goal{attack}) {
+		t.Fatalf("running goals = %v, want only the higher priority goal", running)
+	}
+	if wander.stops != 1 {
+		t.Errorf("lower priority goal stopped %v times, want 1", wander.stops)
+	}
+
+	// Once the higher priority goal can no longer continue, the lower
+	// priority goal may run again.
+	attack.start, attack.stop = false, true
+	s.Tick(nil, nil)
+	if running := s.Running(); !slices.Equal(running, []Goal{wander}) {
+		t.Fatalf("running goals after higher priority goal stopped = %v, want only the lower priority goal", running)
+	}
+	if wander.starts != 2 || attack.starts != 1 || attack.stops != 1 {
+		t.Errorf("goals started %v and %v times, want 2 and 1", wander.starts, attack.starts)
+	}
+}
+
+func TestGoalSelectorFlagsExcludeConflictingGoals(t *testing.T) {
+	s := NewGoalSelector()
+	walk := &goalTestGoal{flags: GoalFlagMove, start: true}
+	walkSamePriority := &goalTestGoal{flags: GoalFlagMove, start: true}
+	walkAndLook := &goalTestGoal{flags: GoalFlagMove | GoalFlagLook, start: true}
+	look := &goalTestGoal{flags: GoalFlagLook, start: true}
+	s.Add(1, walk)
+	s.Add(1, walkSamePriority)
+	s.Add(2, walkAndLook)
+	s.Add(3, look)
+
+	for range 5 {
+		s.Tick(nil, nil)
+	}
+	if running := s.Running(); !slices.Equal(running, []Goal{walk, look}) {
+		t.Fatalf("running goals = %v, want the goals that use different flags", running)
+	}
+	if walkSamePriority.starts != 0 || walkAndLook.starts != 0 {
+		t.Errorf("conflicting goals started %v and %v times, want 0", walkSamePriority.starts, walkAndLook.starts)
+	}
+	if walk.ticks != 5 || look.ticks != 5 {
+		t.Errorf("running goals ticked %v and %v times, want 5", walk.ticks, look.ticks)
+	}
+
+	s.Remove(walk, nil, nil)
+	s.Tick(nil, nil)
+	if running := s.Running(); !slices.Equal(running, []Goal{walkSamePriority, look}) {
+		t.Fatalf("running goals after removing goal = %v, want the next goal using its flags", running)
+	}
+	if walk.stops != 1 {
+		t.Errorf("removed goal stopped %v times, want 1", walk.stops)
+	}
+}
+
+func TestNearestTargetAndMeleeAttackGoals(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		spawn := func(pos mgl64.Vec3, target bool) *goalTestMob {
+			return tx.AddEntity(world.EntitySpawnOpts{Position: pos}.New(goalTestMobType{}, goalTestMobConfig{target: target})).(*goalTestMob)
+		}
+		zombie := spawn(mgl64.Vec3{0.5, 64, 0.5}, false)
+		near := spawn(mgl64.Vec3{4.5, 64, 0.5}, true)
+		far := spawn(mgl64.Vec3{-8.5, 64, 0.5}, true)
+		spawn(mgl64.Vec3{1.5, 64, 0.5}, false)
+		spawn(mgl64.Vec3{20.5, 64, 0.5}, true)
+
+		s := NewGoalSelector()
+		s.Add(1, &NearestTargetGoal{Radius: 16, Filter: func(e world.Entity) bool {
+			m, ok := e.(*goalTestMob)
+			return ok && m.data().target
+		}})
+		s.Add(2, &MeleeAttackGoal{Damage: 3, Reach: 2})
+		s.Add(7, &WanderGoal{Chance: 1})
+
+		s.Tick(zombie, tx)
+		if zombie.Target() != near.H() {
+			t.Fatalf("target = %v, want the nearest target", zombie.Target())
+		}
+		if vel := zombie.Velocity(); vel[0] <= 0 || vel[2] != 0 {
+			t.Fatalf("velocity while chasing target = %v, want movement towards the target", vel)
+		}
+		if len(s.Running()) != 2 {
+			t.Fatalf("running goals = %v, want the target and attack goals", s.Running())
+		}
+
+		// Move the zombie within reach of its target so that it attacks.
+		zombie.d.Pos = mgl64.Vec3{3.5, 64, 0.5}
+		for range 25 {
+			s.Tick(zombie, tx)
+		}
+		if dmg := near.data().damage; !slices.Equal(dmg, []float64{3, 3}) {
+			t.Errorf("damage dealt to target = %v, want two attacks of 3", dmg)
+		}
+		if len(far.data().damage) != 0 {
+			t.Errorf("damage dealt to target further away = %v, want none", far.data().damage)
+		}
+
+		// Once the target dies, the zombie switches to the other target.
+		near.data().dead = true
+		s.Tick(zombie, tx)
+		if zombie.Target() != far.H() {
+			t.Fatalf("target after previous target died = %v, want the remaining target", zombie.Target())
+		}
+	})
+}
+
+// goalTestGoal is a Goal that starts and stops when its start and stop fields
+// are set and counts the calls to its methods.
+type goalTestGoal struct {
+	flags                GoalFlag
+	start, stop          bool
+	starts, stops, ticks int
+}
+
+func (g *goalTestGoal) Flags() GoalFlag                 { return g.flags }
+func (g *goalTestGoal) CanStart(Mob, *world.Tx) bool    { return g.start }
+func (g *goalTestGoal) CanContinue(Mob, *world.Tx) bool { return !g.stop }
+func (g *goalTestGoal) Start(Mob, *world.Tx)            { g.starts++ }
+func (g *goalTestGoal) Stop(Mob, *world.Tx)             { g.stops++ }
+func (g *goalTestGoal) Tick(Mob, *world.Tx)             { g.ticks++ }
+
+type goalTestMobConfig struct {
+	target bool
+}
+
+func (conf goalTestMobConfig) Apply(data *world.EntityData) {
+	data.Data = &goalTestMobData{target: conf.target}
+}
+
+type goalTestMobData struct {
+	target bool
+	dead   bool
+	vel    mgl64.Vec3
+	aim    *world.EntityHandle
+	damage []float64
+}
+
+// goalTestMob is a Mob that records the damage dealt to it. Methods not
+// implemented by it panic when called.
+type goalTestMob struct {
+	Living
+	handle *world.EntityHandle
+	d      *world.EntityData
+}
+
+func (m *goalTestMob) data() *goalTestMobData                 { return m.d.Data.(*goalTestMobData) }
+func (m *goalTestMob) H() *world.EntityHandle                 { return m.handle }
+func (m *goalTestMob) Position() mgl64.Vec3                   { return m.d.Pos }
+func (m *goalTestMob) Rotation() cube.Rotation                { return m.d.Rot }
+func (m *goalTestMob) Speed() float64                         { return 0.1 }
+func (m *goalTestMob) Velocity() mgl64.Vec3                   { return m.data().vel }
+func (m *goalTestMob) SetVelocity(vel mgl64.Vec3)             { m.data().vel = vel }
+func (m *goalTestMob) Dead() bool                             { return m.data().dead }
+func (m *goalTestMob) Target() *world.EntityHandle            { return m.data().aim }
+func (m *goalTestMob) SetTarget(target *world.EntityHandle)   { m.data().aim = target }
+func (m *goalTestMob) LookAt(mgl64.Vec3)                      {}
+func (m *goalTestMob) KnockBack(mgl64.Vec3, float64, float64) {}
+func (m *goalTestMob) Tick(*world.Tx, int64)                  {}
+func (m *goalTestMob) Close() error                           { return nil }
+
+func (m *goalTestMob) Hurt(dmg float64, _ world.DamageSource) (float64, bool) {
+	m.data().damage = append(m.data().damage, dmg)
+	return dmg, true
+}
+
+type goalTestMobType struct{}
+
+func (goalTestMobType) Open(_ *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &goalTestMob{handle: handle, d: data}
+}
+
+func (goalTestMobType) EncodeEntity() string { return "minecraft:goal_test_mob" }
+func (goalTestMobType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.95, 0.3)
+}
+func (goalTestMobType) DecodeNBT(map[string]any, *world.EntityData) {}
+func (goalTestMobType) EncodeNBT(*world.EntityData) map[string]any  { return nil }
@@ -0,0 +1,432 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// WanderGoal is a Goal that makes a Mob walk to random positions around it
+// every now and then.
+type WanderGoal struct {
+	// Speed is the multiplier of the speed of the mob while wandering. If 0,
+	// it defaults to 1.
+	Speed float64
+	// Chance is the chance per tick that the mob starts wandering while idle.
+	// If 0, it defaults to 1/120.
+	Chance float64
+	// Radius is the maximum horizontal distance of the positions that the mob
+	// wanders to. If 0, it defaults to 10.
+	Radius float64
+
+	dest  mgl64.Vec3
+	ticks int
+}
+
+// Flags ...
+func (g *WanderGoal) Flags() GoalFlag { return GoalFlagMove }
+
+// CanStart ...
+func (g *WanderGoal) CanStart(m Mob, _ *world.Tx) bool {
+	if rand.Float64() >= defaultValue(g.Chance, 1.0/120) {
+		return false
+	}
+	g.dest = randomHorizontalPos(m.Position(), defaultValue(g.Radius, 10))
+	return true
+}
+
+// CanContinue ...
+func (g *WanderGoal) CanContinue(m Mob, _ *world.Tx) bool {
+	return g.ticks < goalMoveTimeout && horizontalDistance(m.Position(), g.dest) > goalArriveDistance
+}
+
+// Start ...
+func (g *WanderGoal) Start(Mob, *world.Tx) { g.ticks = 0 }
+
+// Stop ...
+func (g *WanderGoal) Stop(m Mob, _ *world.Tx) { stopMoving(m) }
+
+// Tick ...
+func (g *WanderGoal) Tick(m Mob, _ *world.Tx) {
+	g.ticks++
+	moveTowards(m, g.dest, defaultValue(g.Speed, 1))
+}
+
+// LookAtGoal is a Goal that makes a Mob look at a nearby entity, such as a
+// player, for a short while.
+type LookAtGoal struct {
+	// Radius is the maximum distance of the entities that the mob looks at.
+	// If 0, it defaults to 8.
+	Radius float64
+	// Chance is the chance per tick that the mob starts looking at a nearby
+	// entity while idle. If 0, it defaults to 0.02.
+	Chance float64
+	// Filter returns true for entities that the mob may look at. If nil, the
+	// mob looks at players.
+	Filter func(e world.Entity) bool
+
+	target *world.EntityHandle
+	ticks  int
+}
+
+// Flags ...
+func (g *LookAtGoal) Flags() GoalFlag { return GoalFlagLook }
+
+// CanStart ...
+func (g *LookAtGoal) CanStart(m Mob, tx *world.Tx) bool {
+	if rand.Float64() >= defaultValue(g.Chance, 0.02) {
+		return false
+	}
+	filter := g.Filter
+	if filter == nil {
+		filter = isPlayer
+	}
+	e, ok := NearestEntity(m, tx, defaultValue(g.Radius, 8), filter)
+	if ok {
+		g.target = e.H()
+	}
+	return ok
+}
+
+// CanContinue ...
+func (g *LookAtGoal) CanContinue(m Mob, tx *world.Tx) bool {
+	e, ok := g.target.Entity(tx)
+	return ok && g.ticks > 0 && e.Position().Sub(m.Position()).Len() <= defaultValue(g.Radius, 8)
+}
+
+// Start ...
+func (g *LookAtGoal) Start(Mob, *world.Tx) { g.ticks = 40 + rand.IntN(40) }
+
+// Stop ...
+func (g *LookAtGoal) Stop(Mob, *world.Tx) { g.target = nil }
+
+// Tick ...
+func (g *LookAtGoal) Tick(m Mob, tx *world.Tx) {
+	g.ticks--
+	if e, ok := g.target.Entity(tx); ok {
+		m.LookAt(e.Position().Add(mgl64.Vec3{0, eyeHeight(e)}))
+	}
+}
+
+// NearestTargetGoal is a Goal that makes a Mob target the nearest entity
+// matching a filter, such as the nearest player for a zombie. The target
+// is kept until it dies, leaves the world or moves out of range.
+type NearestTargetGoal struct {
+	// Radius is the maximum distance of the entities that the mob targets.
+	// If 0, it defaults to 16.
+	Radius float64
+	// Filter returns true for entities that the mob may target. If nil, the
+	// mob targets players.
+	Filter func(e world.Entity) bool
+}
+
+// Flags ...
+func (g *NearestTargetGoal) Flags() GoalFlag { return GoalFlagTarget }
+
+// CanStart ...
+func (g *NearestTargetGoal) CanStart(m Mob, tx *world.Tx) bool {
+	if _, ok := validTarget(m, tx, math.MaxFloat64); ok {
+		// The target was set by something else, such as the mob being
+		// attacked.
+		return false
+	}
+	filter := g.Filter
+	if filter == nil {
+		filter = isPlayer
+	}
+	e, ok := NearestEntity(m, tx, defaultValue(g.Radius, 16), func(e world.Entity) bool {
+		l, living := e.(Living)
+		return living && !l.Dead() && filter(e)
+	})
+	if ok {
+		m.SetTarget(e.H())
+	}
+	return ok
+}
+
+// CanContinue ...
+func (g *NearestTargetGoal) CanContinue(m Mob, tx *world.Tx) bool {
+	_, ok := validTarget(m, tx, defaultValue(g.Radius, 16))
+	return ok
+}
+
+// Start ...
+func (g *NearestTargetGoal) Start(Mob, *world.Tx) {}
+
+// Stop ...
+func (g *NearestTargetGoal) Stop(m Mob, _ *world.Tx) { m.SetTarget(nil) }
+
+// Tick ...
+func (g *NearestTargetGoal) Tick(Mob, *world.Tx) {}
+
+// MeleeAttackGoal is a Goal that makes a Mob walk towards its target and
+// attack it once it is in reach.
+type MeleeAttackGoal struct {
+	// Speed is the multiplier of the speed of the mob while chasing its
+	// target. If 0, it defaults to 1.
+	Speed float64
+	// Damage is the damage dealt to the target every attack. If 0, it
+	// defaults to 2.
+	Damage float64
+	// Reach is the maximum distance between the mob and its target for the
+	// mob to be able to attack it. If 0, it defaults to 2.
+	Reach float64
+	// Cooldown is the number of ticks between two attacks. If 0, it defaults
+	// to 20.
+	Cooldown int
+
+	cooldown int
+}
+
+// Flags ...
+func (g *MeleeAttackGoal) Flags() GoalFlag { return GoalFlagMove | GoalFlagLook }
+
+// CanStart ...
+func (g *MeleeAttackGoal) CanStart(m Mob, tx *world.Tx) bool {
+	_, ok := validTarget(m, tx, math.MaxFloat64)
+	return ok
+}
+
+// CanContinue ...
+func (g *MeleeAttackGoal) CanContinue(m Mob, tx *world.Tx) bool {
+	return g.CanStart(m, tx)
+}
+
+// Start ...
+func (g *MeleeAttackGoal) Start(Mob, *world.Tx) { g.cooldown = 0 }
+
+// Stop ...
+func (g *MeleeAttackGoal) Stop(m Mob, _ *world.Tx) { stopMoving(m) }
+
+// Tick ...
+func (g *MeleeAttackGoal) Tick(m Mob, tx *world.Tx) {
+	target, ok := validTarget(m, tx, math.MaxFloat64)
+	if !ok {
+		return
+	}
+	m.LookAt(target.Position().Add(mgl64.Vec3{0, eyeHeight(target)}))
+	if g.cooldown > 0 {
+		g.cooldown--
+	}
+	if target.Position().Sub(m.Position()).Len() > defaultValue(g.Reach, 2) {
+		moveTowards(m, target.Position(), defaultValue(g.Speed, 1))
+		return
+	}
+	stopMoving(m)
+	if g.cooldown == 0 {
+		g.cooldown = int(defaultValue(float64(g.Cooldown), 20))
+		if _, vulnerable := target.Hurt(defaultValue(g.Damage, 2), AttackDamageSource{Attacker: m}); vulnerable {
+			target.KnockBack(m.Position(), 0.4, 0.4)
+		}
+	}
+}
+
+// FleeGoal is a Goal that makes a Mob run away from nearby entities matching
+// a filter.
+type FleeGoal struct {
+	// Radius is the distance within which the mob flees from entities. If 0,
+	// it defaults to 6.
+	Radius float64
+	// Speed is the multiplier of the speed of the mob while fleeing. If 0, it
+	// defaults to 1.5.
+	Speed float64
+	// Filter returns true for entities that the mob flees from. Filter must
+	// be non-nil.
+	Filter func(e world.Entity) bool
+
+	from *world.EntityHandle
+}
+
+// Flags ...
+func (g *FleeGoal) Flags() GoalFlag { return GoalFlagMove }
+
+// CanStart ...
+func (g *FleeGoal) CanStart(m Mob, tx *world.Tx) bool {
+	e, ok := NearestEntity(m, tx, defaultValue(g.Radius, 6), g.Filter)
+	if ok {
+		g.from = e.H()
+	}
+	return ok
+}
+
+// CanContinue ...
+func (g *FleeGoal) CanContinue(m Mob, tx *world.Tx) bool {
+	e, ok := g.from.Entity(tx)
+	return ok && e.Position().Sub(m.Position()).Len() <= defaultValue(g.Radius, 6)*1.5
+}
+
+// Start ...
+func (g *FleeGoal) Start(Mob, *world.Tx) {}
+
+// Stop ...
+func (g *FleeGoal) Stop(m Mob, _ *world.Tx) {
+	g.from = nil
+	stopMoving(m)
+}
+
+// Tick ...
+func (g *FleeGoal) Tick(m Mob, tx *world.Tx) {
+	if e, ok := g.from.Entity(tx); ok {
+		pos := m.Position()
+		moveTowards(m, pos.Add(pos.Sub(e.Position())), defaultValue(g.Speed, 1.5))
+	}
+}
+
+// PanicGoal is a Goal that makes a Mob run around randomly for a while after
+// it is hurt or while it is on fire. Mobs using a PanicGoal should call
+// Trigger when they are hurt.
+type PanicGoal struct {
+	// Speed is the multiplier of the speed of the mob while panicking. If 0,
+	// it defaults to 1.25.
+	Speed float64
+	// Duration is the number of ticks that the mob panics for after it is
+	// hurt. If 0, it defaults to 60.
+	Duration int
+
+	triggered bool
+	ticks     int
+	dest      mgl64.Vec3
+}
+
+// Trigger makes the PanicGoal start running on the next tick of the
+// GoalSelector it is in, or restarts its duration if it is already running.
+func (g *PanicGoal) Trigger() {
+	g.triggered = true
+}
+
+// Flags ...
+func (g *PanicGoal) Flags() GoalFlag { return GoalFlagMove }
+
+// CanStart ...
+func (g *PanicGoal) CanStart(m Mob, _ *world.Tx) bool {
+	return g.triggered || onFire(m)
+}
+
+// CanContinue ...
+func (g *PanicGoal) CanContinue(m Mob, _ *world.Tx) bool {
+	return g.triggered || g.ticks > 0 || onFire(m)
+}
+
+// Start ...
+func (g *PanicGoal) Start(m Mob, _ *world.Tx) {
+	g.dest = randomHorizontalPos(m.Position(), 5)
+}
+
+// Stop ...
+func (g *PanicGoal) Stop(m Mob, _ *world.Tx) { stopMoving(m) }
+
+// Tick ...
+func (g *PanicGoal) Tick(m Mob, _ *world.Tx) {
+	if g.triggered {
+		g.triggered, g.ticks = false, int(defaultValue(float64(g.Duration), 60))
+	}
+	g.ticks--
+	if horizontalDistance(m.Position(), g.dest) <= goalArriveDistance {
+		g.dest = randomHorizontalPos(m.Position(), 5)
+	}
+	moveTowards(m, g.dest, defaultValue(g.Speed, 1.25))
+}
+
+const (
+	// goalMoveTimeout is the maximum number of ticks that a goal moves the
+	// mob towards a single position.
+	goalMoveTimeout = 200
+	// goalArriveDistance is the horizontal distance from a position within
+	// which a mob is considered to have arrived at it.
+	goalArriveDistance = 0.5
+)
+
+// NearestEntity returns the entity nearest to the entity passed within the
+// radius passed for which filter returns true. The entity itself is never
+// returned. Only the chunks within the radius are searched.
+func NearestEntity(m world.Entity, tx *world.Tx, radius float64, filter func(e world.Entity) bool) (world.Entity, bool) {
+	pos := m.Position()
+	box := cube.Box(-radius, -radius, -radius, radius, radius, radius).Translate(pos)
+
+	var nearest world.Entity
+	dist := radius * radius
+	for e := range tx.EntitiesWithin(box) {
+		if e.H() == m.H() || !filter(e) {
+			continue
+		}
+		if d := e.Position().Sub(pos).LenSqr(); d <= dist {
+			nearest, dist = e, d
+		}
+	}
+	return nearest, nearest != nil
+}
+
+// validTarget returns the target of the Mob passed if it is a Living entity
+// that is alive and within the distance passed.
+func validTarget(m Mob, tx *world.Tx, dist float64) (Living, bool) {
+	h := m.Target()
+	if h == nil {
+		return nil, false
+	}
+	e, ok := h.Entity(tx)
+	if !ok {
+		return nil, false
+	}
+	l, ok := e.(Living)
+	if !ok || l.Dead() || e.Position().Sub(m.Position()).Len() > dist {
+		return nil, false
+	}
+	return l, true
+}
+
+// moveTowards sets the horizontal velocity of the Mob passed so that it
+// moves towards pos with its speed multiplied by speed.
+func moveTowards(m Mob, pos mgl64.Vec3, speed float64) {
+	delta := pos.Sub(m.Position())
+	delta[1] = 0
+	if delta.Len() < epsilon {
+		stopMoving(m)
+		return
+	}
+	vel := delta.Normalize().Mul(m.Speed() * speed)
+	vel[1] = m.Velocity()[1]
+	m.SetVelocity(vel)
+	m.LookAt(pos.Add(mgl64.Vec3{0, eyeHeight(m)}))
+}
+
+// stopMoving removes the horizontal velocity of the Living entity passed.
+func stopMoving(m Living) {
+	m.SetVelocity(mgl64.Vec3{0, m.Velocity()[1]})
+}
+
+// randomHorizontalPos returns a random position at the height of pos with a
+// horizontal distance of at most radius from pos.
+func randomHorizontalPos(pos mgl64.Vec3, radius float64) mgl64.Vec3 {
+	angle, dist := rand.Float64()*math.Pi*2, rand.Float64()*radius
+	return pos.Add(mgl64.Vec3{math.Cos(angle) * dist, 0, math.Sin(angle) * dist})
+}
+
+// horizontalDistance returns the distance between a and b on the X and Z
+// axes.
+func horizontalDistance(a, b mgl64.Vec3) float64 {
+	delta := a.Sub(b)
+	delta[1] = 0
+	return delta.Len()
+}
+
+// onFire checks if the entity passed is Flammable and currently on fire.
+func onFire(e world.Entity) bool {
+	f, ok := e.(Flammable)
+	return ok && f.OnFireDuration() > 0
+}
+
+// isPlayer checks if the entity passed is a player.
+func isPlayer(e world.Entity) bool {
+	return e.H().Type().EncodeEntity() == "minecraft:player"
+}
+
+// defaultValue returns v if it is non-zero, or def otherwise.
+func defaultValue(v, def float64) float64 {
+	if v == 0 {
+		return def
+	}
+	return v
+}
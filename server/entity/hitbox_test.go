@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestScaledEntityHitbox(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		pos := mgl64.Vec3{0.5, 64, 0.5}
+		e := tx.AddEntity(world.EntitySpawnOpts{Position: pos}.New(testMovingEntType{}, testMoveConfig{})).(*Ent)
+		if box := world.EntityBBox(e); box != e.H().Type().BBox(e) {
+			t.Fatalf("hitbox of an unscaled entity = %v, want the box of its type %v", box, e.H().Type().BBox(e))
+		}
+
+		// The ray passes the entity 0.5 blocks from its centre, which is just
+		// outside its unscaled hitbox grown by trace.EntityIntercept, but
+		// inside its hitbox when scaled by 2.
+		start, end := pos.Add(mgl64.Vec3{-3, 0.1, 0.5}), pos.Add(mgl64.Vec3{3, 0.1, 0.5})
+		hit := func() bool {
+			res, ok := trace.Perform(start, end, tx, cube.Box(-0.5, -0.5, -0.5, 0.5, 0.5, 0.5).Grow(1), nil)
+			if !ok {
+				return false
+			}
+			r, ok := res.(trace.EntityResult)
+			return ok && r.Entity().H() == e.H()
+		}
+		if hit() {
+			t.Fatalf("projectile trace hit an unscaled entity that it passed")
+		}
+
+		e.SetScale(2)
+		if box, want := world.EntityBBox(e), cube.Box(-0.25, 0, -0.25, 0.25, 0.5, 0.25); box != want {
+			t.Fatalf("hitbox of an entity scaled by 2 = %v, want %v", box, want)
+		}
+		if !hit() {
+			t.Fatalf("projectile trace did not hit an entity scaled by 2 within its doubled hitbox")
+		}
+
+		e.SetScale(0)
+		if e.Scale() != 1 || world.EntityBBox(e) != e.H().Type().BBox(e) {
+			t.Errorf("scale after resetting it = %v, want 1 with the box of the type as hitbox", e.Scale())
+		}
+	})
+}
+
+func TestBabyEntityHitbox(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: breedTestRegistry()}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		adult := breedTestSpawn(tx, mgl64.Vec3{0.5, 64, 0.5}, false)
+		if box, want := world.EntityBBox(adult), cube.Box(-0.45, 0, -0.45, 0.45, 0.9, 0.45); box != want {
+			t.Errorf("hitbox of an adult = %v, want %v", box, want)
+		}
+		baby := breedTestSpawn(tx, mgl64.Vec3{4.5, 64, 0.5}, true)
+		if box, want := world.EntityBBox(baby), cube.Box(-0.225, 0, -0.225, 0.225, 0.45, 0.225); box != want {
+			t.Errorf("hitbox of a baby = %v, want the halved box %v", box, want)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewHorse creates a new horse entity. Horses may be ridden by a single
+// entity, which controls the horse once it wears a saddle.
+func NewHorse(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(HorseType, horseConf)
+}
+
+var horseConf = HorseBehaviourConfig{
+	Gravity:      0.08,
+	Drag:         0.02,
+	Speed:        0.7,
+	JumpVelocity: 0.6,
+}
+
+// HorseType is a world.EntityType implementation for Horse.
+var HorseType horseType
+
+type horseType struct{}
+
+func (t horseType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (horseType) EncodeEntity() string   { return "minecraft:horse" }
+func (horseType) NetworkOffset() float64 { return 0 }
+func (horseType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.6982, 0, -0.6982, 0.6982, 1.6, 0.6982)
+}
+
+func (horseType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	conf := horseConf
+	conf.Saddled = nbtconv.Bool(m, "Saddled")
+	h := conf.New()
+	h.ride.DecodeNBT(m)
+	h.leash.DecodeNBT(m)
+	data.Data = h
+}
+
+func (horseType) EncodeNBT(data *world.EntityData) map[string]any {
+	h := data.Data.(*HorseBehaviour)
+	m := map[string]any{"Saddled": boolByte(h.Saddled())}
+	h.ride.EncodeNBT(m)
+	h.leash.EncodeNBT(m)
+	return m
+}
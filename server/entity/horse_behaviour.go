@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// HorseBehaviourConfig holds optional parameters for a HorseBehaviour.
+type HorseBehaviourConfig struct {
+	// Gravity is the amount of Y velocity subtracted every tick.
+	Gravity float64
+	// Drag is used to reduce all axes of the velocity every tick. Velocity is
+	// multiplied with (1-Drag) every tick.
+	Drag float64
+	// Speed is the horizontal velocity that the horse walks with while its
+	// rider moves, before the friction of the ground is applied.
+	Speed float64
+	// JumpVelocity is the vertical velocity that the horse jumps with when
+	// its rider jumps.
+	JumpVelocity float64
+	// Saddled specifies if the horse wears a saddle.
+	Saddled bool
+}
+
+func (conf HorseBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a HorseBehaviour using the parameters in conf.
+func (conf HorseBehaviourConfig) New() *HorseBehaviour {
+	return &HorseBehaviour{
+		BaseBehaviour: NewBaseBehaviour(),
+		conf:          conf,
+		saddled:       conf.Saddled,
+		mc: &MovementComputer{
+			Gravity:           conf.Gravity,
+			Drag:              conf.Drag,
+			DragBeforeGravity: true,
+		},
+		ride:  &RideComputer{Seats: []mgl64.Vec3{{0, 0.85, -0.2}}},
+		leash: &LeashComputer{},
+	}
+}
+
+// HorseBehaviour implements the behaviour of horses. Horses are always tame
+// and may be ridden by a single entity. The rider controls the horse once it
+// wears a saddle: The horse then turns to face the direction the rider looks
+// in and walks and jumps according to the movement input of the rider.
+type HorseBehaviour struct {
+	BaseBehaviour
+
+	conf    HorseBehaviourConfig
+	saddled bool
+	mc      *MovementComputer
+	ride    *RideComputer
+	leash   *LeashComputer
+}
+
+// Saddled checks if the horse wears a saddle.
+func (h *HorseBehaviour) Saddled() bool {
+	return h.saddled
+}
+
+// Saddle puts a saddle on the horse. False is returned if the horse already
+// wore a saddle.
+func (h *HorseBehaviour) Saddle() bool {
+	if h.saddled {
+		return false
+	}
+	h.saddled = true
+	return true
+}
+
+// Tamed always returns true: Horses may be ridden without taming them first.
+func (h *HorseBehaviour) Tamed() bool {
+	return true
+}
+
+// RideComputer returns the state of the entity riding the horse.
+func (h *HorseBehaviour) RideComputer() *RideComputer {
+	return h.ride
+}
+
+// LeashComputer returns the state of the lead that the horse may be tied to.
+func (h *HorseBehaviour) LeashComputer() *LeashComputer {
+	return h.leash
+}
+
+// Tick moves the horse using the input of its rider if it wears a saddle.
+func (h *HorseBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	h.ride.Tick(e, tx)
+	h.leash.Tick(e, tx)
+
+	rot, vel := e.data.Rot, e.data.Vel
+	input, riders := h.ride.Input(), h.ride.Riders()
+	if h.saddled && len(riders) > 0 {
+		if rider, ok := riders[0].Entity(tx); ok {
+			rot = cube.Rotation{rider.Rotation().Yaw(), 0}
+		}
+		if input.Forward != 0 || input.Strafe != 0 {
+			forward := cube.Rotation{rot.Yaw(), 0}.Vec3()
+			left := mgl64.Vec3{forward[2], 0, -forward[0]}
+			move := forward.Mul(input.Forward).Add(left.Mul(input.Strafe))
+			if move.Len() > 1 {
+				move = move.Normalize()
+			}
+			move = move.Mul(h.conf.Speed)
+			vel[0], vel[2] = move[0], move[2]
+		}
+		if input.Jumping && h.mc.OnGround() {
+			vel[1] = h.conf.JumpVelocity
+		}
+	}
+
+	m := h.mc.TickMovement(e, e.data.Pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+	return m
+}
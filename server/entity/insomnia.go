@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// InsomniaThreshold is the time since an Insomniac last rested after which phantoms may start spawning around
+// it. It is equal to three in-game days.
+const InsomniaThreshold = time.Second * 3 * world.TimeFull / 20
+
+// Insomniac represents an entity, typically a player, that keeps track of the time since it last rested in a
+// bed.
+type Insomniac interface {
+	world.Entity
+	// TimeSinceRest returns the time since the entity last slept in a bed or died.
+	TimeSinceRest() time.Duration
+}
+
+// PhantomSpawn checks if phantoms should spawn around the Insomniac passed and returns the position and the
+// number of phantoms to spawn. Phantoms only spawn in the overworld if the world has insomnia enabled, if it
+// is night or thundering and if the Insomniac has the sky above it, above sea level. The longer the time since
+// the Insomniac last rested exceeds the InsomniaThreshold, the more likely phantoms are to spawn. The bool
+// returned is false if no phantoms should spawn.
+func PhantomSpawn(e Insomniac, tx *world.Tx, r *rand.Rand) (cube.Pos, int, bool) {
+	w := tx.World()
+	if !w.Insomnia() || w.Dimension() != world.Overworld || w.Difficulty() == world.DifficultyPeaceful {
+		return cube.Pos{}, 0, false
+	}
+	if daytime(w.Time()) && !tx.Thundering() {
+		return cube.Pos{}, 0, false
+	}
+	pos := cube.PosFromVec3(e.Position())
+	if pos[1] < 63 || pos[1] <= tx.HighestLightBlocker(pos[0], pos[2]) {
+		return cube.Pos{}, 0, false
+	}
+	rest, threshold := e.TimeSinceRest()/(time.Second/20), InsomniaThreshold/(time.Second/20)
+	if rest < threshold || r.Int64N(int64(rest)) < int64(threshold) {
+		return cube.Pos{}, 0, false
+	}
+	spawnPos := pos.Add(cube.Pos{r.IntN(21) - 10, 20 + r.IntN(15), r.IntN(21) - 10})
+	if _, ok := tx.Block(spawnPos).(block.Air); !ok {
+		return cube.Pos{}, 0, false
+	}
+	diff, _ := world.DifficultyID(w.Difficulty())
+	return spawnPos, 1 + r.IntN(diff+1), true
+}
+
+// daytime checks if the world time passed is during the day.
+func daytime(t int) bool {
+	t = (t%world.TimeFull + world.TimeFull) % world.TimeFull
+	return t < world.TimeSleep || t >= world.TimeWake
+}
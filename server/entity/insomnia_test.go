@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestPhantomSpawnInsomniaThreshold(t *testing.T) {
+	tests := []struct {
+		name     string
+		rest     time.Duration
+		time     int
+		insomnia bool
+		spawns   bool
+	}{
+		{name: "rested", rest: InsomniaThreshold - time.Second, time: 18000, insomnia: true},
+		{name: "insomniac", rest: InsomniaThreshold * 10, time: 18000, insomnia: true, spawns: true},
+		{name: "insomniac during day", rest: InsomniaThreshold * 10, time: 1000, insomnia: true},
+		{name: "insomnia disabled", rest: InsomniaThreshold * 10, time: 18000},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := world.Config{}.New()
+			t.Cleanup(func() { _ = w.Close() })
+			w.StopWeatherCycle()
+			w.StopRaining()
+			w.SetTime(test.time)
+			w.SetInsomnia(test.insomnia)
+
+			e := testInsomniac{pos: mgl64.Vec3{0.5, 70, 0.5}, rest: test.rest}
+			mustDo(t, w, func(tx *world.Tx) {
+				r := rand.New(rand.NewPCG(1, 2))
+				spawned := false
+				for range 100 {
+					pos, count, ok := PhantomSpawn(e, tx, r)
+					if !ok {
+						continue
+					}
+					spawned = true
+					if count < 1 || count > 3 {
+						t.Errorf("phantom spawn count = %d, want between 1 and 3", count)
+					}
+					if pos[1] < 90 {
+						t.Errorf("phantom spawn position %v, want at least 20 blocks above entity", pos)
+					}
+				}
+				if spawned != test.spawns {
+					t.Errorf("phantoms spawned: %t, want %t", spawned, test.spawns)
+				}
+			})
+		})
+	}
+}
+
+// testInsomniac is an Insomniac used for testing. Methods not implemented by it panic when called.
+type testInsomniac struct {
+	world.Entity
+	pos  mgl64.Vec3
+	rest time.Duration
+}
+
+func (e testInsomniac) Position() mgl64.Vec3         { return e.pos }
+func (e testInsomniac) TimeSinceRest() time.Duration { return e.rest }
@@ -0,0 +1,60 @@
+package entity_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestInvulnerablePlayerDamage(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	err := w.Do(func(tx *world.Tx) {
+		spawn := func(pos mgl64.Vec3) *player.Player {
+			return tx.AddEntity(world.EntitySpawnOpts{}.New(player.Type, player.Config{Name: "player", Position: pos})).(*player.Player)
+		}
+		attacker, target := spawn(mgl64.Vec3{0, 64, 0}), spawn(mgl64.Vec3{1, 64, 0})
+		target.SetInvulnerable(true)
+
+		attacker.AttackEntity(target)
+		if target.Health() != target.MaxHealth() {
+			t.Errorf("health of invulnerable player after attack = %v, want %v", target.Health(), target.MaxHealth())
+		}
+		if target.Velocity() != (mgl64.Vec3{}) {
+			t.Errorf("velocity of invulnerable player after attack = %v, want no knock back", target.Velocity())
+		}
+
+		target.Explode(target.Position(), world.ExplosionImpact{Entity: target, Damage: 4, Knockback: mgl64.Vec3{1, 0.5, 0}}, block.ExplosionConfig{})
+		if target.Health() != target.MaxHealth() {
+			t.Errorf("health of invulnerable player after explosion = %v, want %v", target.Health(), target.MaxHealth())
+		}
+		if target.Velocity() != (mgl64.Vec3{}) {
+			t.Errorf("velocity of invulnerable player after explosion = %v, want no knock back", target.Velocity())
+		}
+
+		// Void damage bypasses invulnerability by default.
+		if n, vulnerable := target.Hurt(4, entity.VoidDamageSource{}); !vulnerable || n != 4 {
+			t.Errorf("void damage dealt to invulnerable player = %v, %v, want 4, true", n, vulnerable)
+		}
+
+		target.SetInvulnerabilityBypass(func(world.DamageSource) bool { return false })
+		if _, vulnerable := target.Hurt(4, entity.VoidDamageSource{}); vulnerable {
+			t.Errorf("void damage dealt to invulnerable player without bypass, want no damage")
+		}
+
+		// The damage must exceed the void damage dealt before, as the player is still immune to it.
+		target.SetInvulnerable(false)
+		if _, vulnerable := target.Hurt(6, entity.AttackDamageSource{Attacker: attacker}); !vulnerable {
+			t.Errorf("attack damage dealt to player no longer invulnerable, want damage")
+		}
+	}).Wait(context.Background())
+	if err != nil {
+		t.Fatalf("world task failed: %v", err)
+	}
+}
@@ -29,8 +29,9 @@ func NewItemPickupDelay(opts world.EntitySpawnOpts, i item.Stack, delay time.Dur
 }
 
 var itemConf = ItemBehaviourConfig{
-	Gravity: 0.04,
-	Drag:    0.02,
+	Gravity:  0.04,
+	Drag:     0.02,
+	Buoyancy: Buoyancy{Force: 0.045, MaxRise: 0.06, Submersion: 0.1, Drag: 0.01},
 }
 
 // ItemType is a world.EntityType implementation for Item.
@@ -1,7 +1,9 @@
 package entity
 
 import (
+	"cmp"
 	"math"
+	"slices"
 	"time"
 
 	"github.com/df-mc/dragonfly/server/block"
@@ -20,6 +22,9 @@ type ItemBehaviourConfig struct {
 	// Drag is used to reduce all axes of the velocity every tick. Velocity is
 	// multiplied with (1-Drag) every tick.
 	Drag float64
+	// Buoyancy controls the movement of the item while it is in a liquid,
+	// such as to make it float to the surface of water.
+	Buoyancy Buoyancy
 	// ExistenceDuration specifies how long the item stack should last. The
 	// default is time.Minute * 5.
 	ExistenceDuration time.Duration
@@ -51,6 +56,7 @@ func (conf ItemBehaviourConfig) New() *ItemBehaviour {
 	b.passive = PassiveBehaviourConfig{
 		Gravity:           conf.Gravity,
 		Drag:              conf.Drag,
+		Buoyancy:          conf.Buoyancy,
 		ExistenceDuration: conf.ExistenceDuration,
 		Tick:              b.tick,
 	}.New()
@@ -105,8 +111,8 @@ func (i *ItemBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
 
 // Explode reacts to explosions. The item entity is destroyed, unless the item
 // type is blast proof.
-func (i *ItemBehaviour) Explode(e *Ent, src mgl64.Vec3, impact float64, conf block.ExplosionConfig) {
-	if impact > 0 {
+func (i *ItemBehaviour) Explode(e *Ent, _ mgl64.Vec3, impact world.ExplosionImpact, _ block.ExplosionConfig) {
+	if impact.Damage > 0 {
 		if expl, ok := i.Item().Item().(interface{ BlastProof() bool }); ok && expl.BlastProof() {
 			return
 		}
@@ -124,29 +130,66 @@ func (i *ItemBehaviour) tick(e *Ent, tx *world.Tx) {
 }
 
 // checkNearby checks the nearby entities for item collectors and other item
-// stacks. If a collector is found in range, the item will be picked up. If
-// another item stack with the same item type is found in range, the item
-// stacks will merge.
+// stacks. If a collector is found in range, the item will be picked up by the
+// nearest collector that is able to collect it. If no collector picks up the
+// item and another item stack with the same item type is found in range, the
+// item stacks will merge.
 func (i *ItemBehaviour) checkNearby(e *Ent, tx *world.Tx) {
 	pos := e.Position()
-	bbox := e.H().Type().BBox(e)
-	grown := bbox.GrowVec3(mgl64.Vec3{1, 0.5, 1}).Translate(pos)
+	bbox := world.EntityBBox(e).Translate(pos)
+	grown := bbox.GrowVec3(mgl64.Vec3{1, 0.5, 1})
 
-	for other := range tx.EntitiesWithin(bbox.Translate(pos).Grow(2)) {
-		if e.H() == other.H() || !other.H().Type().BBox(other).Translate(other.Position()).IntersectsWith(grown) {
+	var collectors []Collector
+	var items []*Ent
+	for other := range tx.EntitiesWithin(bbox.Grow(MaxPickupRadius + 1)) {
+		if e.H() == other.H() {
 			continue
 		}
+		otherBBox := world.EntityBBox(other).Translate(other.Position())
 		if collector, ok := other.(Collector); ok {
-			// A collector was within range to pick up the entity.
-			i.collect(e, collector, tx)
-			return
-		} else if other.H().Type() == ItemType {
-			// Another item entity was in range to merge with.
-			if i.merge(e, other.(*Ent), tx) {
-				return
+			if otherBBox.IntersectsWith(bbox.GrowVec3(pickupRange(collector))) && canPickup(collector, i.i) {
+				collectors = append(collectors, collector)
 			}
+		} else if other.H().Type() == ItemType && otherBBox.IntersectsWith(grown) {
+			items = append(items, other.(*Ent))
+		}
+	}
+	slices.SortFunc(collectors, func(a, b Collector) int {
+		return cmp.Compare(a.Position().Sub(pos).Len(), b.Position().Sub(pos).Len())
+	})
+	for _, collector := range collectors {
+		// A collector was within range to pick up the entity. If it could not
+		// collect any of the items, for example because its inventory is full,
+		// the next nearest collector is tried.
+		if i.collect(e, collector, tx) {
+			return
 		}
 	}
+	for _, other := range items {
+		// Another item entity was in range to merge with.
+		if i.merge(e, other, tx) {
+			return
+		}
+	}
+}
+
+// pickupRange returns the amount that the bounding box of an item entity is
+// grown by to check if the Collector passed is in range to collect it.
+func pickupRange(c Collector) mgl64.Vec3 {
+	r := DefaultPickupRadius
+	if ranged, ok := c.(RangedCollector); ok {
+		r = max(0, min(ranged.PickupRadius(), MaxPickupRadius))
+	}
+	return mgl64.Vec3{r, r / 2, r}
+}
+
+// canPickup checks if the Collector passed wants to collect the item.Stack
+// passed.
+func canPickup(c Collector, s item.Stack) bool {
+	if ranged, ok := c.(RangedCollector); ok {
+		return ranged.CanPickup(s)
+	}
+	return true
 }
 
 // merge merges the item entity with another item entity.
@@ -169,26 +212,28 @@ func (i *ItemBehaviour) merge(e *Ent, other *Ent, tx *world.Tx) bool {
 	return true
 }
 
-// collect makes a collector collect the item (or at least part of it).
-func (i *ItemBehaviour) collect(e *Ent, collector Collector, tx *world.Tx) {
+// collect makes a collector collect the item (or at least part of it). False
+// is returned if the collector did not collect any of the items.
+func (i *ItemBehaviour) collect(e *Ent, collector Collector, tx *world.Tx) bool {
 	pos := e.Position()
 	n, _ := collector.Collect(i.i)
 	if n == 0 {
-		return
+		return false
 	}
-	for _, viewer := range tx.Viewers(pos) {
+	for _, viewer := range tx.EntityViewers(e) {
 		viewer.ViewEntityAction(e, PickedUpAction{Collector: collector})
 	}
 
 	if n == i.i.Count() {
 		// The collector picked up the entire stack.
 		_ = e.Close()
-		return
+		return true
 	}
 	// Create a new item entity and shrink it by the amount of items that the
 	// collector collected.
 	tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos}, i.i.Grow(-n)))
 	_ = e.Close()
+	return true
 }
 
 // Collector represents an entity in the world that is able to collect an item, typically an entity such as
@@ -202,3 +247,30 @@ type Collector interface {
 	// collect any items in the first place.
 	Collect(stack item.Stack) (n int, ok bool)
 }
+
+const (
+	// DefaultPickupRadius is the horizontal distance from an item entity
+	// within which a Collector picks it up. The vertical distance is half of
+	// the horizontal distance.
+	DefaultPickupRadius = 1.0
+	// MaxPickupRadius is the maximum pickup radius of a RangedCollector.
+	// Larger pickup radii are reduced to MaxPickupRadius.
+	MaxPickupRadius = 8.0
+)
+
+// RangedCollector is a Collector that may collect items from a distance other
+// than the DefaultPickupRadius and that may choose which items it collects.
+type RangedCollector interface {
+	Collector
+	// PickupRadius returns the horizontal distance from an item entity within
+	// which the RangedCollector picks it up.
+	PickupRadius() float64
+	// CanPickup checks if the RangedCollector collects the item.Stack passed
+	// when it is in range of the item entity holding it.
+	CanPickup(stack item.Stack) bool
+}
+
+// base returns the BaseBehaviour of the underlying PassiveBehaviour.
+func (i *ItemBehaviour) base() *BaseBehaviour {
+	return i.passive.base()
+}
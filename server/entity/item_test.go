@@ -0,0 +1,146 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestItemPickupRadius(t *testing.T) {
+	tests := []struct {
+		name      string
+		radius    float64
+		distance  float64
+		collected bool
+	}{
+		{name: "default", radius: DefaultPickupRadius, distance: 4},
+		{name: "expanded", radius: 5, distance: 4, collected: true},
+		{name: "limited", radius: MaxPickupRadius * 2, distance: MaxPickupRadius + 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := world.Config{}.New()
+			t.Cleanup(func() { _ = w.Close() })
+
+			pos := mgl64.Vec3{0.5, 64, 0.5}
+			mustDo(t, w, func(tx *world.Tx) {
+				tx.SetBlock(cube.PosFromVec3(pos).Side(cube.FaceDown), block.Stone{}, nil)
+				c := addTestCollector(tx, pos.Add(mgl64.Vec3{test.distance}), testCollectorConfig{radius: test.radius, space: 64})
+				tickItem(tx, tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos}, item.NewStack(item.Apple{}, 3))).(*Ent))
+
+				if got := c.behaviour().collected > 0; got != test.collected {
+					t.Errorf("item collected from distance %v with radius %v: %t, want %t", test.distance, test.radius, got, test.collected)
+				}
+			})
+		})
+	}
+}
+
+func TestItemPickupNearestEligible(t *testing.T) {
+	w := world.Config{}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	pos := mgl64.Vec3{0.5, 64, 0.5}
+	mustDo(t, w, func(tx *world.Tx) {
+		tx.SetBlock(cube.PosFromVec3(pos).Side(cube.FaceDown), block.Stone{}, nil)
+		filtered := addTestCollector(tx, pos.Add(mgl64.Vec3{0.5}), testCollectorConfig{radius: 4, space: 64, filter: func(s item.Stack) bool {
+			_, ok := s.Item().(item.Apple)
+			return !ok
+		}})
+		full := addTestCollector(tx, pos.Add(mgl64.Vec3{1}), testCollectorConfig{radius: 4})
+		far := addTestCollector(tx, pos.Add(mgl64.Vec3{3}), testCollectorConfig{radius: 4, space: 64})
+		nearest := addTestCollector(tx, pos.Add(mgl64.Vec3{2}), testCollectorConfig{radius: 4, space: 64})
+
+		e := tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos}, item.NewStack(item.Apple{}, 3))).(*Ent)
+		e.Tick(tx, 1)
+		if n := nearest.behaviour().collected; n != 0 {
+			t.Fatalf("item collected %d items before its pickup delay expired", n)
+		}
+		tickItem(tx, e)
+
+		if n := filtered.behaviour().collected; n != 0 {
+			t.Errorf("filter rejecting apples collected %d apples", n)
+		}
+		if n := full.behaviour().collected; n != 0 {
+			t.Errorf("collector with full inventory collected %d apples", n)
+		}
+		if n := far.behaviour().collected; n != 0 {
+			t.Errorf("collector further away collected %d apples", n)
+		}
+		if n := nearest.behaviour().collected; n != 3 {
+			t.Errorf("nearest eligible collector collected %d apples, want 3", n)
+		}
+	})
+}
+
+// tickItem ticks the item entity passed until its default pickup delay has expired or until it is removed.
+func tickItem(tx *world.Tx, e *Ent) {
+	for i := range int64(12) {
+		if _, ok := e.H().Entity(tx); !ok {
+			return
+		}
+		e.Tick(tx, i+1)
+	}
+}
+
+// addTestCollector adds a testCollector to the world at the position passed.
+func addTestCollector(tx *world.Tx, pos mgl64.Vec3, conf testCollectorConfig) *testCollector {
+	return tx.AddEntity(world.EntitySpawnOpts{Position: pos}.New(testCollectorType{}, conf)).(*testCollector)
+}
+
+type testCollectorConfig struct {
+	radius float64
+	space  int
+	filter func(s item.Stack) bool
+}
+
+func (c testCollectorConfig) Apply(data *world.EntityData) {
+	data.Data = &testCollectorBehaviour{BaseBehaviour: NewBaseBehaviour(), conf: c}
+}
+
+type testCollectorBehaviour struct {
+	BaseBehaviour
+
+	conf      testCollectorConfig
+	collected int
+}
+
+func (b *testCollectorBehaviour) Tick(*Ent, *world.Tx) *Movement { return nil }
+
+// testCollector is a RangedCollector that collects items until it has collected as many items as its space.
+type testCollector struct {
+	*Ent
+}
+
+func (c *testCollector) behaviour() *testCollectorBehaviour {
+	return c.data.Data.(*testCollectorBehaviour)
+}
+
+func (c *testCollector) Collect(s item.Stack) (int, bool) {
+	b := c.behaviour()
+	n := min(s.Count(), b.conf.space-b.collected)
+	b.collected += n
+	return n, true
+}
+
+func (c *testCollector) PickupRadius() float64 { return c.behaviour().conf.radius }
+func (c *testCollector) CanPickup(s item.Stack) bool {
+	return c.behaviour().conf.filter == nil || c.behaviour().conf.filter(s)
+}
+
+type testCollectorType struct{}
+
+func (testCollectorType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &testCollector{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (testCollectorType) EncodeEntity() string { return "minecraft:test_collector" }
+func (testCollectorType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.8, 0.3)
+}
+func (testCollectorType) DecodeNBT(map[string]any, *world.EntityData) {}
+func (testCollectorType) EncodeNBT(*world.EntityData) map[string]any  { return nil }
@@ -0,0 +1,118 @@
+package entity_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// itemUseTestPlayer spawns a player holding the stack passed and returns its handle.
+func itemUseTestPlayer(t *testing.T, w *world.World, held item.Stack) (handle *world.EntityHandle) {
+	t.Helper()
+	doTx(t, w, func(tx *world.Tx) {
+		p := tx.AddEntity(world.EntitySpawnOpts{}.New(player.Type, player.Config{Name: "player", Position: mgl64.Vec3{0, 64, 0}})).(*player.Player)
+		p.SetFood(10)
+		p.SetHeldItems(held, item.Stack{})
+		handle = p.H()
+	})
+	return handle
+}
+
+func TestFoodConsumedAfterDuration(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	handle := itemUseTestPlayer(t, w, item.NewStack(item.Apple{}, 2))
+	withPlayer(t, w, handle, func(_ *world.Tx, p *player.Player) {
+		p.UseItem()
+		if !p.UsingItem() {
+			t.Fatalf("player is not using food after starting to eat it")
+		}
+	})
+
+	// An apple takes 1.61 seconds, or just over 32 ticks, to eat.
+	advanceTicks(w, 20)
+	withPlayer(t, w, handle, func(_ *world.Tx, p *player.Player) {
+		if held, _ := p.HeldItems(); held.Count() != 2 || p.Food() != 10 {
+			t.Errorf("apples held and food after 20 ticks of eating = %v, %v, want 2, 10", held.Count(), p.Food())
+		}
+	})
+	advanceTicks(w, 20)
+	withPlayer(t, w, handle, func(_ *world.Tx, p *player.Player) {
+		if held, _ := p.HeldItems(); held.Count() != 1 || p.Food() != 14 {
+			t.Errorf("apples held and food after 40 ticks of eating = %v, %v, want 1, 14", held.Count(), p.Food())
+		}
+		if !p.UsingItem() {
+			t.Errorf("player stopped using food after eating one of the stack, want it to keep eating")
+		}
+	})
+}
+
+func TestFoodConsumptionInterruptedByDamage(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	handle := itemUseTestPlayer(t, w, item.NewStack(item.Apple{}, 2))
+	withPlayer(t, w, handle, func(_ *world.Tx, p *player.Player) {
+		p.UseItem()
+	})
+	advanceTicks(w, 20)
+	withPlayer(t, w, handle, func(_ *world.Tx, p *player.Player) {
+		p.Hurt(1, entity.VoidDamageSource{})
+		if p.UsingItem() {
+			t.Errorf("player is still using food after being hurt, want eating to be interrupted")
+		}
+	})
+	advanceTicks(w, 40)
+	withPlayer(t, w, handle, func(_ *world.Tx, p *player.Player) {
+		if held, _ := p.HeldItems(); held.Count() != 2 {
+			t.Errorf("apples held after interrupted eating = %v, want 2", held.Count())
+		}
+	})
+}
+
+func TestBowForceScalesWithDrawDuration(t *testing.T) {
+	for _, tc := range []struct {
+		ticks int
+		speed float64
+	}{
+		// After 5 ticks, the bow has been drawn for 6 ticks, including the tick it started being drawn in.
+		{ticks: 5, speed: item.DrawForce(item.Bow{}, time.Millisecond*300) * 5},
+		{ticks: 12, speed: item.DrawForce(item.Bow{}, time.Millisecond*650) * 5},
+		{ticks: 40, speed: 5},
+	} {
+		w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+		handle := itemUseTestPlayer(t, w, item.NewStack(item.Bow{}, 1))
+		withPlayer(t, w, handle, func(_ *world.Tx, p *player.Player) {
+			_, _ = p.Inventory().AddItem(item.NewStack(item.Arrow{}, 1))
+			p.UseItem()
+		})
+		advanceTicks(w, tc.ticks)
+		withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+			p.ReleaseItem()
+			var arrows int
+			for e := range tx.Entities() {
+				if e.H().Type() != entity.ArrowType {
+					continue
+				}
+				arrows++
+				if speed := e.(*entity.Ent).Velocity().Len(); math.Abs(speed-tc.speed) > 1e-9 {
+					t.Errorf("arrow speed after drawing bow for %v ticks = %v, want %v", tc.ticks, speed, tc.speed)
+				}
+			}
+			if arrows != 1 {
+				t.Errorf("arrows shot after drawing bow for %v ticks = %v, want 1", tc.ticks, arrows)
+			}
+		})
+		_ = w.Close()
+	}
+	if f := item.DrawForce(item.Bow{}, time.Millisecond*300); f >= item.DrawForce(item.Bow{}, time.Millisecond*650) || f >= 1 {
+		t.Errorf("bow drawn for 6 ticks has force %v, want less than when drawn longer", f)
+	}
+}
@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+)
+
+// Leashable is a world.Entity that may be tied to another entity with a lead,
+// such as a boat.
+type Leashable interface {
+	world.Entity
+	// LeashHolder returns the handle of the entity holding the lead of the
+	// Leashable, or nil if it is not leashed.
+	LeashHolder() *world.EntityHandle
+	// Leash ties the Leashable to the entity passed. False is returned if the
+	// entity is too far away or if the Leashable cannot be leashed.
+	Leash(holder world.Entity) bool
+	// Unleash removes the lead from the Leashable.
+	Unleash()
+}
+
+// maxLeashDistance is the maximum distance between a leashed entity and the
+// holder of its lead. The lead breaks if the entities move further apart.
+const maxLeashDistance = 10
+
+// LeashComputer is used to keep track of the entity holding the lead of an
+// entity.
+type LeashComputer struct {
+	holder *world.EntityHandle
+	// pending is the UUID of the holder read using DecodeNBT while the holder
+	// is not yet loaded.
+	pending uuid.UUID
+}
+
+// Holder returns the handle of the entity holding the lead, or nil if the
+// entity is not leashed.
+func (c *LeashComputer) Holder() *world.EntityHandle {
+	return c.holder
+}
+
+// Leash ties e to holder and shows the lead to viewers of e. False is returned
+// if holder is e itself or further away than the maximum length of a lead.
+func (c *LeashComputer) Leash(e, holder world.Entity, tx *world.Tx) bool {
+	if holder.H() == e.H() || holder.Position().Sub(e.Position()).Len() > maxLeashDistance {
+		return false
+	}
+	c.holder, c.pending = holder.H(), uuid.Nil
+	c.updateState(e, tx)
+	return true
+}
+
+// Unleash removes the lead from e and updates it for viewers of e.
+func (c *LeashComputer) Unleash(e world.Entity, tx *world.Tx) {
+	if c.holder == nil && c.pending == uuid.Nil {
+		return
+	}
+	c.holder, c.pending = nil, uuid.Nil
+	c.updateState(e, tx)
+}
+
+// Tick ties e to the holder read using DecodeNBT once the holder is loaded and
+// breaks the lead if the holder moved too far away from e. The holder of a
+// lead that is no longer in the world of e, for example because its chunk was
+// unloaded, is waited for until it is loaded again.
+func (c *LeashComputer) Tick(e world.Entity, tx *world.Tx) {
+	if c.holder != nil {
+		holder, ok := c.holder.Entity(tx)
+		if !ok {
+			c.holder, c.pending = nil, c.holder.UUID()
+			return
+		}
+		if holder.Position().Sub(e.Position()).Len() > maxLeashDistance {
+			c.Unleash(e, tx)
+		}
+		return
+	}
+	if c.pending == uuid.Nil {
+		return
+	}
+	if holder, ok := tx.EntityByUUID(c.pending); ok {
+		c.holder, c.pending = holder.H(), uuid.Nil
+		c.updateState(e, tx)
+	}
+}
+
+// EncodeNBT writes the holder of the lead to the map passed, so that the lead
+// is tied again after the entity is loaded using DecodeNBT. Players are not
+// saved with the world, so leads held by players are not written.
+func (c *LeashComputer) EncodeNBT(m map[string]any) {
+	switch {
+	case c.holder != nil && c.holder.Type().EncodeEntity() != "minecraft:player":
+		m["LeasherID"] = uniqueID(c.holder.UUID())
+	case c.pending != uuid.Nil:
+		m["LeasherID"] = uniqueID(c.pending)
+	}
+}
+
+// DecodeNBT reads the holder of the lead written using EncodeNBT from the map
+// passed. The lead is tied by Tick as soon as the holder is loaded.
+func (c *LeashComputer) DecodeNBT(m map[string]any) {
+	if _, ok := m["LeasherID"]; ok {
+		c.pending = entityUUID(nbtconv.Int64(m, "LeasherID"))
+	}
+}
+
+// updateState shows the current state of e to its viewers.
+func (c *LeashComputer) updateState(e world.Entity, tx *world.Tx) {
+	tx.UpdateEntityState(e)
+}
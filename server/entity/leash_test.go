@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestLeashSurvivesReload(t *testing.T) {
+	dir := t.TempDir()
+	// The holder is in a chunk next to that of the boat, which is loaded only after the boat's chunk.
+	pos, holderPos := mgl64.Vec3{14.5, 64, 0.5}, mgl64.Vec3{17.5, 64, 0.5}
+	boat := NewBoat(world.EntitySpawnOpts{Position: pos}, block.OakWood())
+	holder := NewText("holder", holderPos)
+
+	w := openPersistentWorld(t, dir)
+	mustDo(t, w, func(tx *world.Tx) {
+		if !tx.AddEntity(boat).(Leashable).Leash(tx.AddEntity(holder)) {
+			t.Fatal("expected boat to be leashed to holder")
+		}
+	})
+	_ = w.Close()
+
+	w = openPersistentWorld(t, dir)
+	t.Cleanup(func() { _ = w.Close() })
+	mustDo(t, w, func(tx *world.Tx) {
+		tx.Block(cube.PosFromVec3(pos))
+		e, ok := tx.EntityByUUID(boat.UUID())
+		if !ok {
+			t.Fatal("boat was not loaded after reload")
+		}
+		leashed := e.(*Ent)
+		leashed.Tick(tx, 1)
+		if h := leashed.LeashHolder(); h != nil {
+			t.Fatalf("boat leashed to %v before its holder was loaded", h.UUID())
+		}
+
+		tx.Block(cube.PosFromVec3(holderPos))
+		leashed.Tick(tx, 2)
+		if h := leashed.LeashHolder(); h == nil || h.UUID() != holder.UUID() {
+			t.Fatalf("boat leash holder after reload = %v, want %v", h, holder.UUID())
+		}
+	})
+}
+
+func TestLeashBreaksWhenTooFar(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	pos := mgl64.Vec3{0.5, 64, 0.5}
+	mustDo(t, w, func(tx *world.Tx) {
+		tx.SetBlock(cube.PosFromVec3(pos).Side(cube.FaceDown), block.Stone{}, nil)
+		e := tx.AddEntity(NewBoat(world.EntitySpawnOpts{Position: pos}, block.OakWood())).(*Ent)
+		holder := tx.AddEntity(NewText("holder", pos.Add(mgl64.Vec3{3})))
+		if e.Leash(tx.AddEntity(NewText("far", pos.Add(mgl64.Vec3{maxLeashDistance + 1})))) {
+			t.Fatal("boat leashed to holder further away than the maximum leash distance")
+		}
+		if !e.Leash(holder) {
+			t.Fatal("expected boat to be leashed to nearby holder")
+		}
+		e.Tick(tx, 1)
+		if e.LeashHolder() != holder.H() {
+			t.Fatalf("boat leash holder = %v, want %v", e.LeashHolder(), holder.H())
+		}
+		holder.(*Ent).data.Pos = pos.Add(mgl64.Vec3{maxLeashDistance + 1})
+		e.Tick(tx, 2)
+		if e.LeashHolder() != nil {
+			t.Fatal("leash did not break after holder moved too far away")
+		}
+	})
+}
@@ -20,6 +20,12 @@ func NewLightning(opts world.EntitySpawnOpts) *world.EntityHandle {
 // NewLightningWithDamage creates a new lightning entities using the damage and
 // fire properties passed.
 func NewLightningWithDamage(opts world.EntitySpawnOpts, dmg float64, blockFire bool, entityFireDuration time.Duration) *world.EntityHandle {
+	return opts.New(LightningType, newLightningConf(dmg, blockFire, entityFireDuration))
+}
+
+// newLightningConf returns the StationaryBehaviourConfig of a lightning
+// entity with the damage and fire properties passed.
+func newLightningConf(dmg float64, blockFire bool, entityFireDuration time.Duration) StationaryBehaviourConfig {
 	conf := lightningConf
 	conf.Tick = (&lightningState{
 		Damage:             dmg,
@@ -28,17 +34,34 @@ func NewLightningWithDamage(opts world.EntitySpawnOpts, dmg float64, blockFire b
 		state:              2,
 		lifetime:           rand.IntN(4) + 1,
 	}).tick
-	return opts.New(LightningType, conf)
+	return conf
 }
 
 var lightningConf = StationaryBehaviourConfig{SpawnSounds: []world.Sound{sound.Explosion{}, sound.Thunder{}}, ExistenceDuration: time.Second}
 
+// LightningStruck represents an entity that is affected by lightning in a way
+// other than being hurt and set on fire. Creepers struck by lightning, for
+// example, become charged, while pigs and villagers are converted into
+// zombified piglins and witches, which may be done using ConvertEntity.
+type LightningStruck interface {
+	world.Entity
+	// StruckByLightning is called once for every lightning strike that
+	// strikes the entity, before the entity is hurt by it. StruckByLightning
+	// returns false if the entity should not be hurt or set on fire by the
+	// strike, for example because it was converted into another entity.
+	StruckByLightning(tx *world.Tx, lightning world.Entity) bool
+}
+
 // lightningState holds the state of a lightning entity.
 type lightningState struct {
 	Damage             float64
 	EntityFireDuration time.Duration
 	BlockFire          bool
 	state, lifetime    int
+	// struck holds the LightningStruck entities already struck by the
+	// lightning and whether they should be hurt by it, so that they are only
+	// struck once.
+	struck map[*world.EntityHandle]bool
 }
 
 // tick carries out lightning logic, dealing damage and setting blocks/entities
@@ -65,10 +88,13 @@ func (s *lightningState) tick(e *Ent, tx *world.Tx) {
 
 // dealDamage deals damage to all entities around the lightning and sets them
 // on fire.
-func (s *lightningState) dealDamage(e *Ent, tx *world.Tx) {
-	pos := e.Position()
-	bb := e.H().Type().BBox(e).GrowVec3(mgl64.Vec3{3, 6, 3}).Translate(pos.Add(mgl64.Vec3{0, 3}))
+func (s *lightningState) dealDamage(lightning *Ent, tx *world.Tx) {
+	pos := lightning.Position()
+	bb := world.EntityBBox(lightning).GrowVec3(mgl64.Vec3{3, 6, 3}).Translate(pos.Add(mgl64.Vec3{0, 3}))
 	for e := range tx.EntitiesWithin(bb) {
+		if l, ok := e.(LightningStruck); ok && !s.strike(l, lightning, tx) {
+			continue
+		}
 		// Only damage entities that weren't already dead.
 		if l, ok := e.(Living); ok && l.Health() > 0 {
 			if s.Damage > 0 {
@@ -81,13 +107,50 @@ func (s *lightningState) dealDamage(e *Ent, tx *world.Tx) {
 	}
 }
 
+// strike calls StruckByLightning on the entity passed if it was not yet
+// struck by the lightning passed. It returns false if the entity should not be
+// hurt by the lightning.
+func (s *lightningState) strike(l LightningStruck, lightning *Ent, tx *world.Tx) bool {
+	if s.struck == nil {
+		s.struck = make(map[*world.EntityHandle]bool)
+	}
+	hurt, ok := s.struck[l.H()]
+	if !ok {
+		hurt = l.StruckByLightning(tx, lightning)
+		s.struck[l.H()] = hurt
+	}
+	return hurt
+}
+
+// ConvertEntity replaces the entity passed with a new entity of the
+// world.EntityType registered under the name passed, such as when a pig struck
+// by lightning turns into a zombified piglin. The new entity is decoded from
+// the NBT passed, if non-nil, and is added at the position and with the
+// rotation of the entity replaced, which is removed from the world. The name
+// tag of the entity is kept if both entities may have one. ConvertEntity
+// returns false if the new entity could not be added, in which case the
+// entity passed is not removed.
+func ConvertEntity(tx *world.Tx, e world.Entity, name string, nbt map[string]any) (world.Entity, bool) {
+	converted, ok := tx.SpawnEntity(name, e.Position(), world.SpawnOptions{Rotation: e.Rotation(), NBT: nbt})
+	if !ok {
+		return nil, false
+	}
+	if from, ok := e.(interface{ NameTag() string }); ok && from.NameTag() != "" {
+		if to, ok := converted.(interface{ SetNameTag(s string) }); ok {
+			to.SetNameTag(from.NameTag())
+		}
+	}
+	tx.RemoveEntity(e)
+	_ = e.Close()
+	return converted, true
+}
+
 // spreadFire attempts to place fire at the position of the lightning and does
 // 4 additional attempts to spread it around that position.
 func (s *lightningState) spreadFire(tx *world.Tx, pos cube.Pos) {
 	s.fire().Start(tx, pos)
 	for i := 0; i < 4; i++ {
-		pos.Add(cube.Pos{rand.IntN(3) - 1, rand.IntN(3) - 1, rand.IntN(3) - 1})
-		s.fire().Start(tx, pos)
+		s.fire().Start(tx, pos.Add(cube.Pos{rand.IntN(3) - 1, rand.IntN(3) - 1, rand.IntN(3) - 1}))
 	}
 }
 
@@ -118,7 +181,7 @@ func (t lightningType) Open(tx *world.Tx, handle *world.EntityHandle, data *worl
 	return &Ent{tx: tx, handle: handle, data: data}
 }
 func (t lightningType) DecodeNBT(_ map[string]any, data *world.EntityData) {
-	data.Data = lightningConf.New()
+	data.Data = newLightningConf(5, true, time.Second*8).New()
 }
 func (t lightningType) EncodeNBT(*world.EntityData) map[string]any { return nil }
 func (lightningType) EncodeEntity() string                         { return "minecraft:lightning_bolt" }
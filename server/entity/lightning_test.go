@@ -0,0 +1,187 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// lightningTestStrike strikes lightning at the position passed and ticks it until it is gone.
+func lightningTestStrike(tx *world.Tx, pos mgl64.Vec3) {
+	lightningTestTick(tx, tx.AddEntity(NewLightningWithDamage(world.EntitySpawnOpts{Position: pos}, 5, false, time.Second*8)).(*Ent))
+}
+
+// lightningTestTick ticks the lightning passed until it is gone.
+func lightningTestTick(tx *world.Tx, l *Ent) {
+	for i := range int64(40) {
+		if _, ok := l.H().Entity(tx); !ok {
+			return
+		}
+		l.Tick(tx, i)
+	}
+}
+
+func TestLightningDamagesAndIgnitesEntitiesInRange(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: lightningTestRegistry()}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		near := lightningTestSpawn(tx, mgl64.Vec3{2.5, 64, 0.5}, "mob")
+		above := lightningTestSpawn(tx, mgl64.Vec3{0.5, 70, 0.5}, "mob")
+		far := lightningTestSpawn(tx, mgl64.Vec3{4.5, 64, 0.5}, "mob")
+		lightningTestStrike(tx, mgl64.Vec3{0.5, 64, 0.5})
+
+		if near.damage == 0 || near.fire != time.Second*8 {
+			t.Errorf("entity 2 blocks from lightning took %v damage and burns for %v, want damage and 8s of fire", near.damage, near.fire)
+		}
+		if above.damage == 0 {
+			t.Errorf("entity 6 blocks above lightning took no damage")
+		}
+		if far.damage != 0 || far.fire != 0 {
+			t.Errorf("entity 4 blocks from lightning took %v damage and burns for %v, want none", far.damage, far.fire)
+		}
+	})
+}
+
+func TestLightningSpawnedByName(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: lightningTestRegistry()}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		near := lightningTestSpawn(tx, mgl64.Vec3{1.5, 64, 0.5}, "mob")
+		l, ok := tx.SpawnEntity("minecraft:lightning_bolt", mgl64.Vec3{0.5, 64, 0.5}, world.SpawnOptions{})
+		if !ok {
+			t.Fatalf("lightning could not be spawned by its name")
+		}
+		lightningTestTick(tx, l.(*Ent))
+		if near.damage == 0 || near.fire == 0 {
+			t.Errorf("entity next to lightning spawned by its name took %v damage and burns for %v, want damage and fire", near.damage, near.fire)
+		}
+	})
+}
+
+func TestLightningChargesCreeper(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: lightningTestRegistry()}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		creeper := lightningTestSpawn(tx, mgl64.Vec3{1.5, 64, 0.5}, "creeper")
+		lightningTestStrike(tx, mgl64.Vec3{0.5, 64, 0.5})
+		if !creeper.powered {
+			t.Fatalf("creeper struck by lightning is not charged")
+		}
+		if creeper.struck != 1 {
+			t.Errorf("creeper struck by lightning %v times by a single strike, want 1", creeper.struck)
+		}
+		if creeper.damage == 0 {
+			t.Errorf("charged creeper took no damage from lightning")
+		}
+	})
+}
+
+func TestLightningConvertsPig(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: lightningTestRegistry()}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		pos := mgl64.Vec3{1.5, 64, 0.5}
+		pig := lightningTestSpawn(tx, pos, "pig")
+		pig.nameTag = "Pig"
+		lightningTestStrike(tx, mgl64.Vec3{0.5, 64, 0.5})
+		if _, ok := pig.H().Entity(tx); ok {
+			t.Fatalf("pig struck by lightning is still in the world, want it converted")
+		}
+		if pig.damage != 0 {
+			t.Errorf("pig converted by lightning took %v damage, want 0", pig.damage)
+		}
+
+		var piglins []*lightningTestMob
+		for e := range tx.Entities() {
+			if m, ok := e.(*lightningTestMob); ok && m.kind == "piglin" {
+				piglins = append(piglins, m)
+			}
+		}
+		if len(piglins) != 1 {
+			t.Fatalf("piglins after striking pig with lightning = %v, want 1", len(piglins))
+		}
+		if p := piglins[0]; p.Position() != pos || p.nameTag != "Pig" {
+			t.Errorf("piglin position and name tag = %v, %q, want %v, %q", p.Position(), p.nameTag, pos, "Pig")
+		}
+	})
+}
+
+func lightningTestRegistry() world.EntityRegistry {
+	return world.EntityRegistryConfig{}.New([]world.EntityType{LightningType, lightningTestMobType{}})
+}
+
+// lightningTestSpawn spawns a lightningTestMob of the kind passed at the position passed.
+func lightningTestSpawn(tx *world.Tx, pos mgl64.Vec3, kind string) *lightningTestMob {
+	e, _ := tx.SpawnEntity("dragonfly:lightning_test_mob", pos, world.SpawnOptions{NBT: map[string]any{"Kind": kind}})
+	return e.(*lightningTestMob)
+}
+
+// lightningTestMob is a LightningStruck entity that records the damage and fire it receives. Creepers become
+// powered and pigs are converted into piglins when struck. Methods not implemented by it panic when called.
+type lightningTestMob struct {
+	Living
+	*lightningTestMobData
+	handle *world.EntityHandle
+	d      *world.EntityData
+}
+
+// lightningTestMobData holds the state of a lightningTestMob.
+type lightningTestMobData struct {
+	kind, nameTag string
+	powered       bool
+	struck        int
+	damage        float64
+	fire          time.Duration
+}
+
+func (m *lightningTestMob) H() *world.EntityHandle        { return m.handle }
+func (m *lightningTestMob) Position() mgl64.Vec3          { return m.d.Pos }
+func (m *lightningTestMob) Rotation() cube.Rotation       { return m.d.Rot }
+func (m *lightningTestMob) Close() error                  { return nil }
+func (m *lightningTestMob) Health() float64               { return 20 - m.damage }
+func (m *lightningTestMob) OnFireDuration() time.Duration { return m.fire }
+func (m *lightningTestMob) SetOnFire(d time.Duration)     { m.fire = d }
+func (m *lightningTestMob) Extinguish()                   { m.fire = 0 }
+func (m *lightningTestMob) NameTag() string               { return m.nameTag }
+func (m *lightningTestMob) SetNameTag(s string)           { m.nameTag = s }
+func (m *lightningTestMob) Hurt(dmg float64, _ world.DamageSource) (float64, bool) {
+	m.damage += dmg
+	return dmg, true
+}
+
+func (m *lightningTestMob) StruckByLightning(tx *world.Tx, _ world.Entity) bool {
+	m.struck++
+	switch m.kind {
+	case "creeper":
+		m.powered = true
+	case "pig":
+		_, ok := ConvertEntity(tx, m, "dragonfly:lightning_test_mob", map[string]any{"Kind": "piglin"})
+		return !ok
+	}
+	return true
+}
+
+type lightningTestMobType struct{}
+
+func (lightningTestMobType) Open(_ *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &lightningTestMob{lightningTestMobData: data.Data.(*lightningTestMobData), handle: handle, d: data}
+}
+
+func (lightningTestMobType) EncodeEntity() string { return "dragonfly:lightning_test_mob" }
+func (lightningTestMobType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.7, 0.3)
+}
+func (lightningTestMobType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	kind, _ := m["Kind"].(string)
+	data.Data = &lightningTestMobData{kind: kind}
+}
+func (lightningTestMobType) EncodeNBT(data *world.EntityData) map[string]any {
+	return map[string]any{"Kind": data.Data.(*lightningTestMobData).kind}
+}
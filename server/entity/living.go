@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
@@ -52,4 +54,29 @@ type Living interface {
 	Speed() float64
 	// SetSpeed sets the speed of an entity to a new value.
 	SetSpeed(float64)
+	// Invulnerable checks if the entity is invulnerable. An invulnerable entity is not hurt or knocked back
+	// by damage, other than by damage from sources that bypass its invulnerability.
+	Invulnerable() bool
+	// SetInvulnerable sets if the entity is invulnerable.
+	SetInvulnerable(v bool)
+	// LookAt rotates the entity so that it looks at the position passed.
+	LookAt(pos mgl64.Vec3)
+}
+
+// Land should be called when the entity e lands on the ground after falling
+// for the distance passed. Land calls block.EntityLander.EntityLand of the
+// block that e landed on, so that, for example, farmland is trampled or fall
+// damage is reduced by a hay bale. The fall distance as changed by the block
+// is returned.
+func Land(e world.Entity, distance float64, tx *world.Tx) float64 {
+	pos := cube.PosFromVec3(e.Position())
+	b := tx.Block(pos)
+	if len(b.Model().BBox(pos, tx)) == 0 {
+		pos = pos.Sub(cube.Pos{0, 1})
+		b = tx.Block(pos)
+	}
+	if h, ok := b.(block.EntityLander); ok {
+		h.EntityLand(pos, tx, e, &distance)
+	}
+	return distance
 }
@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"math"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// LookRotation returns the rotation of an entity with its eyes at from that
+// looks directly at to.
+func LookRotation(from, to mgl64.Vec3) cube.Rotation {
+	d := to.Sub(from)
+	horizontal := math.Hypot(d[0], d[2])
+	if horizontal < epsilon && math.Abs(d[1]) < epsilon {
+		return cube.Rotation{}
+	}
+	return cube.Rotation{
+		mgl64.RadToDeg(math.Atan2(-d[0], d[2])),
+		mgl64.RadToDeg(-math.Atan2(d[1], horizontal)),
+	}
+}
+
+// LookComputer is used to smoothly rotate the head of a Living entity towards
+// a position that it looks at. Like in vanilla, the head of the entity has a
+// yaw of its own, which may differ from the yaw of its body by at most
+// MaxHeadYaw degrees. If the head turns further than that, the body is turned
+// along with it. The yaw of a cube.Rotation of an entity using a
+// LookComputer is the yaw of its body, while the pitch is that of its head.
+// The zero value of a LookComputer is ready to use.
+type LookComputer struct {
+	// HeadSpeed is the maximum number of degrees that the yaw of the head
+	// changes every tick. If 0, it defaults to 10.
+	HeadSpeed float64
+	// PitchSpeed is the maximum number of degrees that the pitch of the head
+	// changes every tick. If 0, it defaults to 40.
+	PitchSpeed float64
+	// MaxHeadYaw is the maximum difference in degrees between the yaw of the
+	// head and the yaw of the body. If 0, it defaults to 75.
+	MaxHeadYaw float64
+
+	headYaw     float64
+	initialised bool
+	target      mgl64.Vec3
+	// looking is the number of ticks left that the entity keeps looking at
+	// the target.
+	looking int
+}
+
+// lookDuration is the number of ticks that an entity keeps looking at a
+// position after LookComputer.LookAt is called.
+const lookDuration = 2
+
+// LookAt makes the entity look at the position passed. LookAt must be
+// called every tick to keep looking at a position. Once it is no longer
+// called, the head of the entity turns back to face the same way as its body.
+func (c *LookComputer) LookAt(pos mgl64.Vec3) {
+	c.target, c.looking = pos, lookDuration
+}
+
+// HeadYaw returns the yaw of the head of the entity.
+func (c *LookComputer) HeadYaw() float64 {
+	return c.headYaw
+}
+
+// Tick rotates the head of the entity e, which currently has the rotation
+// rot, a single tick towards the position that it is looking at and returns
+// its new rotation. If the yaw of the head exceeds the MaxHeadYaw relative to
+// the body, the body is rotated along with the head. Tick returns false if
+// neither the rotation nor the yaw of the head changed.
+func (c *LookComputer) Tick(e world.Entity, rot cube.Rotation) (cube.Rotation, bool) {
+	if !c.initialised {
+		c.headYaw, c.initialised = rot.Yaw(), true
+	}
+	headYaw, yaw, pitch := c.headYaw, rot.Yaw(), rot.Pitch()
+	if c.looking > 0 {
+		c.looking--
+		want := LookRotation(e.Position().Add(mgl64.Vec3{0, eyeHeight(e)}), c.target)
+		headYaw = rotateTowards(headYaw, want.Yaw(), defaultValue(c.HeadSpeed, 10))
+		pitch = rotateTowards(pitch, want.Pitch(), defaultValue(c.PitchSpeed, 40))
+	} else {
+		headYaw = rotateTowards(headYaw, yaw, defaultValue(c.HeadSpeed, 10))
+	}
+	// Turn the body along with the head if the head turned too far.
+	maxHeadYaw := defaultValue(c.MaxHeadYaw, 75)
+	if diff := wrapDegrees(headYaw - yaw); diff > maxHeadYaw {
+		yaw = wrapDegrees(headYaw - maxHeadYaw)
+	} else if diff < -maxHeadYaw {
+		yaw = wrapDegrees(headYaw + maxHeadYaw)
+	}
+	changed := headYaw != c.headYaw || yaw != rot.Yaw() || pitch != rot.Pitch()
+	c.headYaw = headYaw
+	return cube.Rotation{yaw, pitch}, changed
+}
+
+// rotateTowards rotates the angle from towards the angle to, in degrees, by
+// at most the maximum number of degrees passed, taking the shortest way
+// around.
+func rotateTowards(from, to, maximum float64) float64 {
+	return wrapDegrees(from + max(-maximum, min(wrapDegrees(to-from), maximum)))
+}
+
+// wrapDegrees wraps the angle passed into the range [-180, 180).
+func wrapDegrees(v float64) float64 {
+	v = math.Mod(v+180, 360)
+	if v < 0 {
+		v += 360
+	}
+	return v - 180
+}
+
+// eyeHeight returns the height of the eyes of an entity above its position.
+func eyeHeight(e world.Entity) float64 {
+	if eyed, ok := e.(interface{ EyeHeight() float64 }); ok {
+		return eyed.EyeHeight()
+	}
+	return world.EntityBBox(e).Height() * 0.85
+}
@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"math"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestLookRotation(t *testing.T) {
+	tests := []struct {
+		to         mgl64.Vec3
+		yaw, pitch float64
+	}{
+		{to: mgl64.Vec3{0, 0, 1}, yaw: 0, pitch: 0},
+		{to: mgl64.Vec3{-1, 0, 0}, yaw: 90, pitch: 0},
+		{to: mgl64.Vec3{1, 0, 0}, yaw: -90, pitch: 0},
+		{to: mgl64.Vec3{0, 1, 0}, yaw: 0, pitch: -90},
+		{to: mgl64.Vec3{0, -1, 1}, yaw: 0, pitch: 45},
+		{to: mgl64.Vec3{-1, 0, 1}, yaw: 45, pitch: 0},
+	}
+	for _, test := range tests {
+		rot := LookRotation(mgl64.Vec3{}, test.to)
+		if !mgl64.FloatEqualThreshold(rot.Yaw(), test.yaw, 1e-9) || !mgl64.FloatEqualThreshold(rot.Pitch(), test.pitch, 1e-9) {
+			t.Errorf("LookRotation(%v) = %v, want yaw %v and pitch %v", test.to, rot, test.yaw, test.pitch)
+		}
+	}
+	if rot := LookRotation(mgl64.Vec3{}, mgl64.Vec3{0, 0, -1}); math.Abs(rot.Yaw()) != 180 {
+		t.Errorf("LookRotation(0, 0, -1) yaw = %v, want 180", rot.Yaw())
+	}
+
+	from, to := mgl64.Vec3{3, 64, -2}, mgl64.Vec3{-7, 70.5, 11}
+	if dir := LookRotation(from, to).Vec3(); !dir.ApproxEqualThreshold(to.Sub(from).Normalize(), 1e-9) {
+		t.Errorf("direction of LookRotation = %v, want %v", dir, to.Sub(from).Normalize())
+	}
+}
+
+func TestLookComputerTurnsHeadSmoothly(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		e, _ := lookTestEnt(tx, cube.Rotation{0, 0})
+		eye := e.Position().Add(mgl64.Vec3{0, eyeHeight(e)})
+		// The target is to the west of the entity, which is at a head yaw of
+		// 90 degrees, and 45 degrees below its eyes.
+		target := eye.Add(mgl64.Vec3{-4, -4, 0})
+
+		e.LookAt(target)
+		e.Tick(tx, 0)
+		if e.HeadYaw() != 10 || e.Rotation().Yaw() != 0 || e.Rotation().Pitch() != 40 {
+			t.Fatalf("head yaw %v and rotation %v after a tick, want head yaw 10, body yaw 0 and pitch 40", e.HeadYaw(), e.Rotation())
+		}
+		for i := 0; i < 20; i++ {
+			e.LookAt(target)
+			e.Tick(tx, int64(i+1))
+		}
+		if !mgl64.FloatEqual(e.HeadYaw(), 90) || !mgl64.FloatEqual(e.Rotation().Pitch(), 45) {
+			t.Errorf("head yaw %v and pitch %v while looking at the target, want 90 and 45", e.HeadYaw(), e.Rotation().Pitch())
+		}
+		// The head turned 90 degrees, so the body must have turned 15 degrees
+		// along with it.
+		if !mgl64.FloatEqual(e.Rotation().Yaw(), 15) {
+			t.Errorf("body yaw with the head turned 90 degrees = %v, want 15", e.Rotation().Yaw())
+		}
+
+		// Once the entity stops looking at the target, its head turns back to
+		// face the same way as its body.
+		for i := 0; i < 20; i++ {
+			e.Tick(tx, int64(i+21))
+		}
+		if !mgl64.FloatEqual(e.HeadYaw(), e.Rotation().Yaw()) {
+			t.Errorf("head yaw %v after no longer looking at the target, want body yaw %v", e.HeadYaw(), e.Rotation().Yaw())
+		}
+	})
+}
+
+func TestLookComputerHeadYawClamp(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		e, lc := lookTestEnt(tx, cube.Rotation{170, 0})
+		lc.HeadSpeed, lc.MaxHeadYaw = 30, 40
+		eye := e.Position().Add(mgl64.Vec3{0, eyeHeight(e)})
+		// Walk the target around the entity in both directions so that the
+		// head keeps turning past the clamp and across the -180/180 boundary.
+		for i := 0; i < 200; i++ {
+			angle := mgl64.DegToRad(float64(i) * 23)
+			if i >= 100 {
+				angle = -angle
+			}
+			e.LookAt(eye.Add(mgl64.Vec3{math.Sin(angle), 0, math.Cos(angle)}))
+			e.Tick(tx, int64(i))
+			if diff := wrapDegrees(e.HeadYaw() - e.Rotation().Yaw()); math.Abs(diff) > 40+1e-9 {
+				t.Fatalf("head yaw %v is %v degrees from body yaw %v, want at most 40", e.HeadYaw(), diff, e.Rotation().Yaw())
+			}
+			if yaw := e.Rotation().Yaw(); yaw < -180 || yaw >= 180 {
+				t.Fatalf("body yaw %v is not wrapped to [-180, 180)", yaw)
+			}
+		}
+	})
+}
+
+// lookTestEnt adds an entity with a LookComputer and the rotation passed to
+// the world of the transaction passed.
+func lookTestEnt(tx *world.Tx, rot cube.Rotation) (*Ent, *LookComputer) {
+	lc := &LookComputer{}
+	handle := world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 64, 0.5}, Rotation: rot}.New(testMovingEntType{}, lookTestConfig{lc: lc})
+	return tx.AddEntity(handle).(*Ent), lc
+}
+
+type lookTestConfig struct {
+	lc *LookComputer
+}
+
+func (c lookTestConfig) Apply(data *world.EntityData) {
+	data.Data = &lookTestBehaviour{testMoveBehaviour: testMoveBehaviour{BaseBehaviour: NewBaseBehaviour()}, lc: c.lc}
+}
+
+// lookTestBehaviour is the behaviour of an entity that can look around.
+type lookTestBehaviour struct {
+	testMoveBehaviour
+	lc *LookComputer
+}
+
+func (b *lookTestBehaviour) LookComputer() *LookComputer { return b.lc }
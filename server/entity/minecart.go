@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// NewMinecart creates a new minecart entity. Minecarts may be ridden by a
+// single entity.
+func NewMinecart(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(MinecartType, minecartConf)
+}
+
+var minecartConf = MinecartBehaviourConfig{
+	Gravity: 0.04,
+	Drag:    0.05,
+}
+
+// MinecartType is a world.EntityType implementation for Minecart.
+var MinecartType minecartType
+
+type minecartType struct{}
+
+func (t minecartType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Ent{tx: tx, handle: handle, data: data}
+}
+
+func (minecartType) EncodeEntity() string   { return "minecraft:minecart" }
+func (minecartType) NetworkOffset() float64 { return 0.35 }
+func (minecartType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.49, 0, -0.49, 0.49, 0.7, 0.49)
+}
+
+func (minecartType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	c := minecartConf.New()
+	c.ride.DecodeNBT(m)
+	data.Data = c
+}
+
+func (minecartType) EncodeNBT(data *world.EntityData) map[string]any {
+	m := map[string]any{}
+	data.Data.(*MinecartBehaviour).ride.EncodeNBT(m)
+	return m
+}
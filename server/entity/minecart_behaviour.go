@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// MinecartBehaviourConfig holds optional parameters for a MinecartBehaviour.
+type MinecartBehaviourConfig struct {
+	// Gravity is the amount of Y velocity subtracted every tick.
+	Gravity float64
+	// Drag is used to reduce all axes of the velocity every tick. Velocity is
+	// multiplied with (1-Drag) every tick.
+	Drag float64
+}
+
+func (conf MinecartBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a MinecartBehaviour using the parameters in conf.
+func (conf MinecartBehaviourConfig) New() *MinecartBehaviour {
+	return &MinecartBehaviour{
+		BaseBehaviour: NewBaseBehaviour(),
+		mc: &MovementComputer{
+			Gravity:           conf.Gravity,
+			Drag:              conf.Drag,
+			DragBeforeGravity: true,
+		},
+		ride: &RideComputer{Seats: []mgl64.Vec3{{0, 0.1, 0}}},
+		brk:  &BreakComputer{MaxDamage: 40, Drop: item.NewStack(item.Minecart{}, 1)},
+	}
+}
+
+// MinecartBehaviour implements the behaviour of minecarts. Rails are not yet
+// implemented, so minecarts roll over the ground, pushed in the direction
+// that their rider looks in while it moves forward.
+type MinecartBehaviour struct {
+	BaseBehaviour
+
+	mc   *MovementComputer
+	ride *RideComputer
+	brk  *BreakComputer
+}
+
+// minecartAcceleration is the velocity added every tick while the rider of a
+// minecart moves forward.
+const minecartAcceleration = 0.02
+
+// RideComputer returns the state of the entity riding the minecart.
+func (m *MinecartBehaviour) RideComputer() *RideComputer {
+	return m.ride
+}
+
+// BreakComputer returns the damage taken by the minecart, which breaks it
+// once it is hit enough times.
+func (m *MinecartBehaviour) BreakComputer() *BreakComputer {
+	return m.brk
+}
+
+// Tick moves the minecart using the input of its rider.
+func (m *MinecartBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	m.ride.Tick(e, tx)
+	m.brk.Tick()
+
+	vel := e.data.Vel
+	if input, riders := m.ride.Input(), m.ride.Riders(); input.Forward > 0 && len(riders) > 0 {
+		if rider, ok := riders[0].Entity(tx); ok {
+			vel = vel.Add(cube.Rotation{rider.Rotation().Yaw(), 0}.Vec3().Mul(input.Forward * minecartAcceleration))
+		}
+	}
+	mv := m.mc.TickMovement(e, e.data.Pos, vel, e.data.Rot, tx)
+	e.data.Pos, e.data.Vel = mv.pos, mv.vel
+	return mv
+}
@@ -0,0 +1,127 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+var monsterSpawnerTestPos = cube.Pos{0, 64, 0}
+
+// monsterSpawnerTestTick ticks the monster spawner at monsterSpawnerTestPos and returns the amount of text
+// entities in the world afterwards.
+func monsterSpawnerTestTick(tx *world.Tx) int {
+	tx.Block(monsterSpawnerTestPos).(block.MonsterSpawner).Tick(0, monsterSpawnerTestPos, tx)
+	var n int
+	for e := range tx.Entities() {
+		if e.H().Type() == entity.TextType {
+			n++
+		}
+	}
+	return n
+}
+
+func TestMonsterSpawnerPlayerRange(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	doTx(t, w, func(tx *world.Tx) {
+		tx.SetBlock(monsterSpawnerTestPos, block.MonsterSpawner{Entity: "dragonfly:text"}, nil)
+		if n := monsterSpawnerTestTick(tx); n != 0 {
+			t.Fatalf("spawner without players nearby spawned %v entities, want 0", n)
+		}
+		p := tx.AddEntity(world.EntitySpawnOpts{}.New(player.Type, player.Config{Name: "player", Position: mgl64.Vec3{20.5, 64, 0.5}})).(*player.Player)
+		if n := monsterSpawnerTestTick(tx); n != 0 {
+			t.Fatalf("spawner with player 20 blocks away spawned %v entities, want 0", n)
+		}
+		p.Teleport(mgl64.Vec3{10.5, 64, 0.5})
+		if n := monsterSpawnerTestTick(tx); n == 0 {
+			t.Fatalf("spawner with player 10 blocks away spawned no entities")
+		}
+	})
+}
+
+func TestMonsterSpawnerSpawnCountAndCap(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	doTx(t, w, func(tx *world.Tx) {
+		tx.AddEntity(world.EntitySpawnOpts{}.New(player.Type, player.Config{Name: "player", Position: mgl64.Vec3{0.5, 64, 5.5}}))
+		tx.SetBlock(monsterSpawnerTestPos, block.MonsterSpawner{Entity: "dragonfly:text", MinSpawnDelay: 1, MaxSpawnDelay: 1, SpawnCount: 4, MaxNearbyEntities: 6}, nil)
+
+		// Positions overlapping with the spawner itself are skipped, so a burst may spawn fewer entities than
+		// the SpawnCount.
+		if n := monsterSpawnerTestTick(tx); n < 1 || n > 4 {
+			t.Fatalf("entities spawned in a single burst = %v, want 1-4", n)
+		}
+		for range 100 {
+			if n := monsterSpawnerTestTick(tx); n > 6 {
+				t.Fatalf("entities spawned = %v, want at most the cap of 6", n)
+			}
+		}
+		if n := monsterSpawnerTestTick(tx); n != 6 {
+			t.Errorf("entities spawned after many bursts = %v, want the cap of 6", n)
+		}
+	})
+}
+
+func TestMonsterSpawnerDelay(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	doTx(t, w, func(tx *world.Tx) {
+		tx.AddEntity(world.EntitySpawnOpts{}.New(player.Type, player.Config{Name: "player", Position: mgl64.Vec3{0.5, 64, 5.5}}))
+		tx.SetBlock(monsterSpawnerTestPos, block.MonsterSpawner{Entity: "dragonfly:text", Delay: 3, MinSpawnDelay: 10, MaxSpawnDelay: 10, MaxNearbyEntities: 100}, nil)
+
+		for i := range 3 {
+			if n := monsterSpawnerTestTick(tx); n != 0 {
+				t.Fatalf("entities spawned after %v ticks with a delay of 3 = %v, want 0", i+1, n)
+			}
+		}
+		first := monsterSpawnerTestTick(tx)
+		if first == 0 {
+			t.Fatalf("spawner spawned no entities once its delay passed")
+		}
+		if d := tx.Block(monsterSpawnerTestPos).(block.MonsterSpawner).Delay; d != 10 {
+			t.Errorf("delay after spawning = %v, want 10", d)
+		}
+		for range 10 {
+			if n := monsterSpawnerTestTick(tx); n != first {
+				t.Fatalf("entities spawned during delay = %v, want %v", n, first)
+			}
+		}
+		if n := monsterSpawnerTestTick(tx); n <= first {
+			t.Errorf("entities spawned after delay of 10 ticks = %v, want more than %v", n, first)
+		}
+
+		tx.SetBlock(monsterSpawnerTestPos, block.MonsterSpawner{Entity: "dragonfly:text"}, nil)
+		monsterSpawnerTestTick(tx)
+		if d := tx.Block(monsterSpawnerTestPos).(block.MonsterSpawner).Delay; d < 200 || d > 800 {
+			t.Errorf("delay after spawning with default settings = %v, want 200-800", d)
+		}
+	})
+}
+
+func TestMonsterSpawnerNBT(t *testing.T) {
+	s := block.MonsterSpawner{
+		Entity:              "minecraft:zombie",
+		Delay:               50,
+		MinSpawnDelay:       100,
+		MaxSpawnDelay:       300,
+		SpawnCount:          2,
+		MaxNearbyEntities:   3,
+		RequiredPlayerRange: 8,
+		SpawnRange:          5,
+	}
+	if decoded := (block.MonsterSpawner{}).DecodeNBT(s.EncodeNBT()); decoded != s {
+		t.Errorf("decoded spawner = %+v, want %+v", decoded, s)
+	}
+	if got := (block.MonsterSpawner{}).DecodeNBT(block.NewMonsterSpawner("minecraft:skeleton").EncodeNBT()).(block.MonsterSpawner); got.Entity != "minecraft:skeleton" || got.SpawnCount != 4 || got.MaxNearbyEntities != 6 {
+		t.Errorf("decoded default spawner = %+v, want skeleton spawner with default settings", got)
+	}
+}
@@ -12,10 +12,36 @@ import (
 type MovementComputer struct {
 	Gravity, Drag     float64
 	DragBeforeGravity bool
+	// Buoyancy controls the movement of the entity while it is in a liquid.
+	// The zero value leaves the movement of the entity in liquids unchanged.
+	Buoyancy Buoyancy
 
 	onGround bool
 }
 
+// Buoyancy controls the movement of an entity while its bounding box
+// intersects with a liquid, such as to make an entity float in water.
+type Buoyancy struct {
+	// Force is the upward velocity added every tick while the entity is
+	// submerged in the liquid, before gravity is subtracted. If Force exceeds
+	// the Gravity of the MovementComputer, the entity rises to the surface of
+	// the liquid, where it bobs up and down.
+	Force float64
+	// MaxRise is the maximum upward velocity that Force accelerates the entity
+	// to. If 0, the upward velocity is not limited.
+	MaxRise float64
+	// Submersion is the depth in blocks that the bounding box of the entity
+	// must be submerged for Force to be applied, which is roughly the depth
+	// that a buoyant entity floats at.
+	Submersion float64
+	// Drag is used to reduce all axes of the velocity every tick that the
+	// entity is in the liquid, in addition to the Drag of the
+	// MovementComputer. Velocity is multiplied with (1-Drag) every tick.
+	Drag float64
+	// Lava specifies if the Buoyancy applies in lava instead of in water.
+	Lava bool
+}
+
 // Movement represents the movement of a world.Entity as a result of a call to MovementComputer.TickMovement. The
 // resulting position and velocity can be obtained by calling Position and Velocity. These can be sent to viewers by
 // calling Send.
@@ -63,11 +89,18 @@ func (m *Movement) Rotation() cube.Rotation {
 // The new position of the entity after movement is returned.
 // The resulting Movement can be sent to viewers by calling Movement.Send.
 func (c *MovementComputer) TickMovement(e world.Entity, pos, vel mgl64.Vec3, rot cube.Rotation, tx *world.Tx) *Movement {
-	viewers := tx.Viewers(pos)
+	viewers := tx.EntityViewers(e)
 
 	velBefore := vel
+	vel = c.applyBuoyancy(tx, e, pos, vel)
 	vel = c.applyHorizontalForces(tx, pos, c.applyVerticalForces(vel))
+	fall := vel[1]
 	dPos, vel := c.CheckCollision(tx, e, pos, vel)
+	if c.onGround && fall < 0 {
+		vel = c.bounce(tx, e, pos.Add(dPos), vel, fall)
+	} else if !c.onGround && vel[1] < 0 {
+		vel = c.slide(tx, e, pos.Add(dPos), vel)
+	}
 
 	return &Movement{v: viewers, e: e,
 		pos: pos.Add(dPos), vel: vel, dpos: dPos, dvel: vel.Sub(velBefore),
@@ -98,32 +131,141 @@ func (c *MovementComputer) applyVerticalForces(vel mgl64.Vec3) mgl64.Vec3 {
 	return vel
 }
 
+// applyBuoyancy applies the drag and the upward force of the Buoyancy set if
+// the entity is in the liquid of the Buoyancy.
+func (c *MovementComputer) applyBuoyancy(tx *world.Tx, e world.Entity, pos, vel mgl64.Vec3) mgl64.Vec3 {
+	b := c.Buoyancy
+	if b == (Buoyancy{}) {
+		return vel
+	}
+	liquid := "water"
+	if b.Lava {
+		liquid = "lava"
+	}
+	depth, ok := submersion(tx, world.EntityBBox(e).Translate(pos), liquid)
+	if !ok {
+		return vel
+	}
+	vel = vel.Mul(1 - b.Drag)
+	if depth >= b.Submersion && b.Force > 0 && (b.MaxRise == 0 || vel[1] < b.MaxRise) {
+		vel[1] += b.Force
+		if b.MaxRise > 0 {
+			vel[1] = min(vel[1], b.MaxRise)
+		}
+	}
+	return vel
+}
+
+// submersion returns the depth in blocks that the bounding box passed is
+// submerged in the liquid with the type passed. False is returned if the box
+// does not intersect with the liquid at all.
+func submersion(tx *world.Tx, box cube.BBox, liquid string) (float64, bool) {
+	minY, depth, found := box.Min()[1], 0.0, false
+	low, high := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
+	for pos := range cube.Range3D(low, high) {
+		l, ok := tx.Liquid(pos)
+		if !ok || l.LiquidType() != liquid {
+			continue
+		}
+		// Liquids that are not falling fill 1/9th of a block per level of
+		// depth, so that even source blocks leave a gap at the top.
+		surface := float64(pos[1]) + 1
+		if !l.LiquidFalling() {
+			surface = float64(pos[1]) + float64(l.LiquidDepth())/9
+		}
+		if surface > minY {
+			depth, found = max(depth, min(surface, box.Max()[1])-minY), true
+		}
+	}
+	return depth, found
+}
+
 // applyHorizontalForces applies friction to the velocity based on the Drag value, reducing it on the X and Z axes.
+// Entities on the ground are further slowed by the friction of the block below them and by blocks that have a
+// speed factor, such as honey blocks.
 func (c *MovementComputer) applyHorizontalForces(tx *world.Tx, pos, vel mgl64.Vec3) mgl64.Vec3 {
 	friction := 1 - c.Drag
 	if c.onGround {
-		if f, ok := tx.Block(cube.PosFromVec3(pos).Side(cube.FaceDown)).(interface {
+		b := tx.Block(groundPos(pos))
+		if f, ok := b.(interface {
 			Friction() float64
 		}); ok {
 			friction *= f.Friction()
 		} else {
 			friction *= 0.6
 		}
+		if f, ok := b.(interface {
+			SpeedFactor() float64
+		}); ok {
+			friction *= f.SpeedFactor()
+		}
 	}
 	vel[0] *= friction
 	vel[2] *= friction
 	return vel
 }
 
+// bounce makes an entity that landed on a bouncy block, such as slime, bounce off it with the downward
+// velocity passed that it had before landing. Entities that are sneaking do not bounce.
+func (c *MovementComputer) bounce(tx *world.Tx, e world.Entity, pos, vel mgl64.Vec3, fall float64) mgl64.Vec3 {
+	if s, ok := e.(interface{ Sneaking() bool }); ok && s.Sneaking() {
+		return vel
+	}
+	if b, ok := tx.Block(groundPos(pos)).(interface {
+		Bounce(vel mgl64.Vec3) mgl64.Vec3
+	}); ok {
+		if vel = b.Bounce(mgl64.Vec3{vel[0], fall, vel[2]}); vel[1] > 0 {
+			c.onGround = false
+		}
+	}
+	return vel
+}
+
+// slide limits the downward velocity of an entity that is falling along the side of a block that entities
+// slide down, such as a honey block.
+func (c *MovementComputer) slide(tx *world.Tx, e world.Entity, pos, vel mgl64.Vec3) mgl64.Vec3 {
+	box := world.EntityBBox(e).Translate(pos)
+	side := box.GrowVec3(mgl64.Vec3{0.01, 0, 0.01})
+	for p := range cube.Range3D(cube.PosFromVec3(side.Min()), cube.PosFromVec3(side.Max())) {
+		b, ok := tx.Block(p).(interface {
+			SlideVelocity() float64
+		})
+		if !ok {
+			continue
+		}
+		for _, bb := range tx.Block(p).Model().BBox(p, tx) {
+			bb = bb.Translate(p.Vec3())
+			if bb.IntersectsWith(side) && box.Min()[1] < bb.Max()[1] {
+				vel[1] = max(vel[1], -b.SlideVelocity())
+				return vel
+			}
+		}
+	}
+	return vel
+}
+
+// groundPos returns the position of the block that affects the movement of an entity standing at pos, such as
+// through its friction. This is usually the block directly below the entity, but it is the block at pos itself
+// for blocks lower than a full block.
+func groundPos(pos mgl64.Vec3) cube.Pos {
+	return cube.PosFromVec3(pos.Sub(mgl64.Vec3{0, 0.5000001}))
+}
+
 // CheckCollision handles the collision of the entity with blocks, adapting the velocity of the entity if it
 // happens to collide with a block.
 // The final velocity and the Vec3 that the entity should move is returned.
 func (c *MovementComputer) CheckCollision(tx *world.Tx, e world.Entity, pos, vel mgl64.Vec3) (mgl64.Vec3, mgl64.Vec3) {
 	// TODO: Implement collision with other entities.
+	if n, ok := e.(interface{ NoClip() bool }); ok && n.NoClip() {
+		// Entities with no-clip enabled pass through blocks, so they can never
+		// be on the ground either.
+		c.onGround = false
+		return vel, vel
+	}
 	deltaX, deltaY, deltaZ := vel[0], vel[1], vel[2]
 
 	// Entities only ever have a single bounding box.
-	entityBBox := e.H().Type().BBox(e).Translate(pos)
+	entityBBox := world.EntityBBox(e).Translate(pos)
 	blocks := blockBBoxsAround(tx, entityBBox.Extend(vel))
 
 	if !mgl64.FloatEqualThreshold(deltaY, 0, epsilon) {
@@ -168,6 +310,30 @@ func (c *MovementComputer) CheckCollision(tx *world.Tx, e world.Entity, pos, vel
 	return mgl64.Vec3{deltaX, deltaY, deltaZ}, vel
 }
 
+// EscapeBlocks returns the lowest position at or above pos at which the
+// bounding box of the entity passed does not intersect with any blocks. It is
+// used to move entities out of blocks when their collision with blocks is
+// enabled again. If no such position exists below the top of the world, pos is
+// returned.
+func EscapeBlocks(tx *world.Tx, e world.Entity, pos mgl64.Vec3) mgl64.Vec3 {
+	box := world.EntityBBox(e)
+	for y := pos[1]; y < float64(tx.Range()[1]); y = math.Floor(y) + 1 {
+		candidate := mgl64.Vec3{pos[0], y, pos[2]}
+		translated := box.Translate(candidate)
+		free := true
+		for _, blockBBox := range blockBBoxsAround(tx, translated) {
+			if blockBBox.IntersectsWith(translated) {
+				free = false
+				break
+			}
+		}
+		if free {
+			return candidate
+		}
+	}
+	return pos
+}
+
 // blockBBoxsAround returns all blocks around the entity passed, using the BBox passed to make a prediction of
 // what blocks need to have their BBox returned.
 func blockBBoxsAround(tx *world.Tx, box cube.BBox) []cube.BBox {
@@ -0,0 +1,106 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestSneakingPlayerDoesNotWalkOffEdge(t *testing.T) {
+	for _, tc := range []struct {
+		sneaking, protected bool
+		states              player.MovementStates
+	}{
+		{sneaking: true, protected: true},
+		{sneaking: false, protected: false},
+		{sneaking: true, protected: false, states: player.MovementStates{DisableEdgeProtection: true}},
+	} {
+		w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+		handle := spawnTestPlayer(t, w, player.Config{MovementStates: tc.states})
+		withPlayer(t, w, handle, func(_ *world.Tx, p *player.Player) {
+			if tc.sneaking {
+				p.StartSneaking()
+			}
+		})
+		for range 20 {
+			withPlayer(t, w, handle, func(_ *world.Tx, p *player.Player) {
+				p.SetVelocity(mgl64.Vec3{0.2, p.Velocity()[1], 0.1})
+			})
+			advanceTicks(w, 1)
+		}
+		withPlayer(t, w, handle, func(_ *world.Tx, p *player.Player) {
+			pos := p.Position()
+			onBlock := pos[1] == 64 && pos[0] <= 1.3+1e-9 && pos[2] <= 1.3+1e-9
+			if tc.protected && !onBlock {
+				t.Errorf("sneaking player walked off edge to %v, want it to remain on the block", pos)
+			} else if tc.protected && (pos[0] < 1.2 || pos[2] < 1.2) {
+				t.Errorf("sneaking player stopped at %v, want it to walk up to the edge", pos)
+			} else if !tc.protected && onBlock {
+				t.Errorf("player (sneaking: %v, states: %+v) stayed on the block at %v, want it to walk off", tc.sneaking, tc.states, pos)
+			}
+		})
+		_ = w.Close()
+	}
+}
+
+func TestSprintingSpeedAndHunger(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	defer w.Close()
+	w.SetDifficulty(world.DifficultyNormal)
+
+	handle := spawnTestPlayer(t, w, player.Config{})
+	withPlayer(t, w, handle, func(_ *world.Tx, p *player.Player) {
+		p.StartSprinting()
+		if !p.Sprinting() || !mgl64.FloatEqual(p.Speed(), 0.13) {
+			t.Fatalf("sprinting and speed after starting to sprint = %v, %v, want true, 0.13", p.Sprinting(), p.Speed())
+		}
+		p.StartSneaking()
+		if p.Sprinting() || !mgl64.FloatEqual(p.Speed(), 0.1) {
+			t.Errorf("sprinting and speed after starting to sneak = %v, %v, want false, 0.1", p.Sprinting(), p.Speed())
+		}
+		p.StopSneaking()
+
+		p.SetMovementStates(player.MovementStates{SprintSpeed: 2})
+		p.StartSprinting()
+		if !mgl64.FloatEqual(p.Speed(), 0.2) {
+			t.Errorf("speed after sprinting with sprint speed 2 = %v, want 0.2", p.Speed())
+		}
+		p.SetMovementStates(player.MovementStates{})
+		if !mgl64.FloatEqual(p.Speed(), 0.13) {
+			t.Errorf("speed of sprinting player after resetting sprint speed = %v, want 0.13", p.Speed())
+		}
+
+		// A sprint jump boosts the player forward in the direction it is facing and exhausts it by 0.2.
+		exhaustion := p.Data().Exhaustion
+		p.Jump()
+		if vel := p.Velocity(); vel[1] <= 0 || vel[2] <= 0 {
+			t.Errorf("velocity after sprint jump = %v, want upwards and forwards", vel)
+		}
+		if d := p.Data().Exhaustion - exhaustion; !mgl64.FloatEqual(d, 0.2) {
+			t.Errorf("exhaustion of sprint jump = %v, want 0.2", d)
+		}
+
+		// Sprinting exhausts the player by 0.1 for every block moved.
+		exhaustion = p.Data().Exhaustion
+		p.Move(mgl64.Vec3{0, 0, 2}, 0, 0)
+		if d := p.Data().Exhaustion - exhaustion; !mgl64.FloatEqual(d, 0.2) {
+			t.Errorf("exhaustion of sprinting 2 blocks = %v, want 0.2", d)
+		}
+		p.StopSprinting()
+		exhaustion = p.Data().Exhaustion
+		p.Move(mgl64.Vec3{0, 0, 2}, 0, 0)
+		if d := p.Data().Exhaustion - exhaustion; d != 0 {
+			t.Errorf("exhaustion of walking 2 blocks = %v, want 0", d)
+		}
+
+		// A player without enough food cannot sprint.
+		p.SetFood(6)
+		p.StartSprinting()
+		if p.Sprinting() {
+			t.Errorf("player with 6 food started sprinting, want it unable to sprint")
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestMovementComputerNoClip(t *testing.T) {
+	tests := []struct {
+		name   string
+		noClip bool
+	}{
+		{name: "collision"},
+		{name: "no-clip", noClip: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := world.Config{Entities: DefaultRegistry}.New()
+			t.Cleanup(func() { _ = w.Close() })
+
+			mustDo(t, w, func(tx *world.Tx) {
+				for y := 64; y < 67; y++ {
+					tx.SetBlock(cube.Pos{1, y, 0}, block.Stone{}, nil)
+				}
+				pos := mgl64.Vec3{0.5, 64, 0.5}
+				e := tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos}, item.NewStack(item.Stick{}, 1))).(*Ent)
+				e.SetNoClip(test.noClip)
+
+				mc := &MovementComputer{}
+				m := mc.TickMovement(e, pos, mgl64.Vec3{2}, cube.Rotation{}, tx)
+				if passed := m.Position()[0] > 2; passed != test.noClip {
+					t.Errorf("entity moved to %v, passed through wall: %t, want %t", m.Position(), passed, test.noClip)
+				}
+			})
+		})
+	}
+}
+
+func TestEntSetNoClipEscapesBlocks(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		tx.SetBlock(cube.Pos{0, 64, 0}, block.Stone{}, nil)
+		pos := mgl64.Vec3{0.5, 64.5, 0.5}
+		e := tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos}, item.NewStack(item.Stick{}, 1))).(*Ent)
+		e.SetNoClip(true)
+		if e.Position() != pos {
+			t.Errorf("entity with no-clip enabled moved to %v, want %v", e.Position(), pos)
+		}
+		e.SetNoClip(false)
+		if want := (mgl64.Vec3{0.5, 65, 0.5}); e.Position() != want {
+			t.Errorf("entity with no-clip disabled at %v, want %v", e.Position(), want)
+		}
+	})
+}
+
+func TestMovementComputerSlimeBounce(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		for x, b := range []world.Block{block.Stone{}, block.Slime{}} {
+			tx.SetBlock(cube.Pos{x, 63, 0}, b, nil)
+			pos := mgl64.Vec3{float64(x) + 0.5, 64.05, 0.5}
+			e := tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos}, item.NewStack(item.Stick{}, 1))).(*Ent)
+
+			mc := &MovementComputer{Gravity: 0.04, Drag: 0.02}
+			m := mc.TickMovement(e, pos, mgl64.Vec3{0, -0.5}, cube.Rotation{}, tx)
+			_, slime := b.(block.Slime)
+			if bounced := m.Velocity()[1] > 0; bounced != slime {
+				t.Errorf("entity landing on %T has velocity %v, bounced: %t, want %t", b, m.Velocity(), bounced, slime)
+			}
+			if mc.OnGround() == slime {
+				t.Errorf("entity landing on %T on ground: %t, want %t", b, mc.OnGround(), !slime)
+			}
+		}
+	})
+}
+
+func TestMovementComputerHoneyBlock(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		tx.SetBlock(cube.Pos{0, 63, 0}, block.Stone{}, nil)
+		tx.SetBlock(cube.Pos{0, 63, 2}, block.HoneyBlock{}, nil)
+
+		velocity := func(pos mgl64.Vec3) float64 {
+			e := tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos}, item.NewStack(item.Stick{}, 1))).(*Ent)
+			mc := &MovementComputer{Gravity: 0.04, Drag: 0.02}
+			m := mc.TickMovement(e, pos, mgl64.Vec3{}, cube.Rotation{}, tx)
+			m = mc.TickMovement(e, m.Position(), mgl64.Vec3{0.5}, cube.Rotation{}, tx)
+			return m.Velocity()[0]
+		}
+		stone, honey := velocity(mgl64.Vec3{0.2, 64, 0.5}), velocity(mgl64.Vec3{0.2, 63.9375, 2.5})
+		if want := stone * 0.4; !mgl64.FloatEqual(honey, want) {
+			t.Errorf("velocity of entity on honey block = %v, want %v", honey, want)
+		}
+
+		// Entities falling along the side of a honey block slide down it slowly.
+		pos := mgl64.Vec3{0.5, 63.5, 1.1}
+		e := tx.AddEntity(NewItem(world.EntitySpawnOpts{Position: pos}, item.NewStack(item.Stick{}, 1))).(*Ent)
+		mc := &MovementComputer{Gravity: 0.04, Drag: 0.02}
+		m := mc.TickMovement(e, pos, mgl64.Vec3{0, -0.5}, cube.Rotation{}, tx)
+		if m.Velocity()[1] != -0.5*0.98-0.04*0.98 {
+			t.Errorf("velocity of entity falling without honey block next to it = %v", m.Velocity())
+		}
+		pos = mgl64.Vec3{0.5, 63.5, 1.93}
+		m = mc.TickMovement(e, pos, mgl64.Vec3{0, -0.5}, cube.Rotation{}, tx)
+		if want := -0.05; m.Velocity()[1] != want {
+			t.Errorf("velocity of entity sliding down honey block = %v, want %v", m.Velocity()[1], want)
+		}
+	})
+}
@@ -15,6 +15,8 @@ type PassiveBehaviourConfig struct {
 	// Drag is used to reduce all axes of the velocity every tick. Velocity is
 	// multiplied with (1-Drag) every tick.
 	Drag float64
+	// Buoyancy controls the movement of the entity while it is in a liquid.
+	Buoyancy Buoyancy
 	// ExistenceDuration is the duration that an entity with this behaviour
 	// should last. Once this time expires, the entity is closed. If
 	// ExistenceDuration is 0, the entity will never expire automatically.
@@ -44,6 +46,7 @@ func (conf PassiveBehaviourConfig) New() *PassiveBehaviour {
 			Gravity:           conf.Gravity,
 			Drag:              conf.Drag,
 			DragBeforeGravity: true,
+			Buoyancy:          conf.Buoyancy,
 		},
 	}
 }
@@ -64,8 +67,8 @@ type PassiveBehaviour struct {
 
 // Explode adds velocity to a passive entity to blast it away from the
 // explosion's source.
-func (p *PassiveBehaviour) Explode(e *Ent, src mgl64.Vec3, impact float64, _ block.ExplosionConfig) {
-	e.data.Vel = e.data.Vel.Add(e.data.Pos.Sub(src).Normalize().Mul(impact))
+func (p *PassiveBehaviour) Explode(e *Ent, _ mgl64.Vec3, impact world.ExplosionImpact, _ block.ExplosionConfig) {
+	e.data.Vel = e.data.Vel.Add(impact.Knockback)
 }
 
 // Fuse returns the leftover time until PassiveBehaviourConfig.Expire is called,
@@ -96,7 +99,7 @@ func (p *PassiveBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
 	}
 
 	if p.Fuse()%(time.Second/4) == 0 {
-		for _, v := range tx.Viewers(m.pos) {
+		for _, v := range tx.EntityViewers(e) {
 			v.ViewEntityState(e)
 		}
 	}
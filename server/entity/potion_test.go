@@ -0,0 +1,168 @@
+package entity
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestPotionSplashRadius(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	impact := mgl64.Vec3{0.5, 64, 0.5}
+	full := potion.Swiftness().Effects()[0].Duration()
+	tests := []struct {
+		name   string
+		offset float64
+		direct bool
+		want   time.Duration
+	}{
+		{name: "direct hit", offset: 2, direct: true, want: full},
+		{name: "near", offset: 1, want: full * 3 / 4},
+		{name: "far", offset: 3, want: full / 4},
+		{name: "out of range", offset: 5},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mustDo(t, w, func(tx *world.Tx) {
+				target := tx.AddEntity(world.EntitySpawnOpts{Position: impact.Add(mgl64.Vec3{test.offset})}.New(testPotionTargetType{}, testPotionTargetConfig{}))
+				defer target.(*testPotionTarget).Close()
+				pot := tx.AddEntity(NewSplashPotion(world.EntitySpawnOpts{Position: impact}, potion.Swiftness(), target)).(*Ent)
+				defer pot.Close()
+
+				var res trace.Result = trace.BlockResult{}
+				if test.direct {
+					res, _ = trace.EntityIntercept(target, impact.Add(mgl64.Vec3{test.offset, 5}), impact.Add(mgl64.Vec3{test.offset, -1}))
+				}
+				potionSplash(1, potion.Swiftness(), false)(pot, tx, res)
+
+				effects := target.(*testPotionTarget).effects()
+				if test.want == 0 {
+					if len(effects) != 0 {
+						t.Fatalf("effects out of splash radius = %v, want none", effects)
+					}
+					return
+				}
+				if len(effects) != 1 {
+					t.Fatalf("effects applied = %v, want 1", len(effects))
+				}
+				if got := effects[0].Duration(); time.Duration(got-test.want).Abs() > time.Millisecond {
+					t.Errorf("effect duration = %v, want %v", got, test.want)
+				}
+			})
+		})
+	}
+}
+
+func TestLingeringPotionSpawnsCloud(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	impact := mgl64.Vec3{0.5, 64, 0.5}
+	mustDo(t, w, func(tx *world.Tx) {
+		target := tx.AddEntity(world.EntitySpawnOpts{Position: impact}.New(testPotionTargetType{}, testPotionTargetConfig{})).(*testPotionTarget)
+		pot := tx.AddEntity(NewLingeringPotion(world.EntitySpawnOpts{Position: impact}, potion.Swiftness(), target)).(*Ent)
+		potionSplash(0.25, potion.Swiftness(), true)(pot, tx, trace.BlockResult{})
+
+		if n := len(target.effects()); n != 0 {
+			t.Errorf("effects applied on impact of lingering potion = %v, want 0", n)
+		}
+		var clouds int
+		for e := range tx.Entities() {
+			if _, ok := e.H().Type().(areaEffectCloudType); ok {
+				clouds++
+			}
+		}
+		if clouds != 1 {
+			t.Errorf("area effect clouds spawned = %v, want 1", clouds)
+		}
+	})
+}
+
+func TestAreaEffectCloudReapplication(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	pos := mgl64.Vec3{0.5, 64, 0.5}
+	l := world.NewLoader(1, w, world.NopViewer{})
+	var target *testPotionTarget
+	mustDo(t, w, func(tx *world.Tx) {
+		l.Move(tx, pos)
+		l.Load(tx, 4)
+		target = tx.AddEntity(world.EntitySpawnOpts{Position: pos}.New(testPotionTargetType{}, testPotionTargetConfig{})).(*testPotionTarget)
+		tx.AddEntity(NewAreaEffectCloud(world.EntitySpawnOpts{Position: pos}, potion.Swiftness()))
+	})
+
+	// The cloud applies its effects once it is half a second old and then
+	// after every reapplication delay of two seconds, but only checks for
+	// entities every ten ticks.
+	var applied []int
+	for age := range 100 {
+		w.AdvanceTick()
+		mustDo(t, w, func(tx *world.Tx) {
+			if len(target.effects()) > len(applied) {
+				applied = append(applied, age)
+			}
+		})
+	}
+	if want := []int{10, 50, 90}; !slices.Equal(applied, want) {
+		t.Fatalf("effects applied at cloud age %v ticks, want %v", applied, want)
+	}
+	mustDo(t, w, func(tx *world.Tx) {
+		l.Close(tx)
+		if dur, full := target.effects()[0].Duration(), potion.Swiftness().Effects()[0].Duration(); dur != full/4 {
+			t.Errorf("cloud effect duration = %v, want %v", dur, full/4)
+		}
+	})
+}
+
+type testPotionTargetConfig struct{}
+
+func (testPotionTargetConfig) Apply(data *world.EntityData) {
+	data.Data = &testPotionTargetData{}
+}
+
+type testPotionTargetData struct {
+	effects []effect.Effect
+}
+
+// testPotionTarget is a Living entity that records the effects added to it.
+// Methods not implemented by it panic when called.
+type testPotionTarget struct {
+	Living
+	handle *world.EntityHandle
+	d      *world.EntityData
+}
+
+func (e *testPotionTarget) data() *testPotionTargetData { return e.d.Data.(*testPotionTargetData) }
+func (e *testPotionTarget) effects() []effect.Effect    { return e.data().effects }
+
+func (e *testPotionTarget) AddEffect(eff effect.Effect) {
+	e.data().effects = append(e.data().effects, eff)
+}
+func (e *testPotionTarget) Tick(*world.Tx, int64)   {}
+func (e *testPotionTarget) H() *world.EntityHandle  { return e.handle }
+func (e *testPotionTarget) Position() mgl64.Vec3    { return e.d.Pos }
+func (e *testPotionTarget) Rotation() cube.Rotation { return e.d.Rot }
+func (e *testPotionTarget) Close() error            { return nil }
+
+type testPotionTargetType struct{}
+
+func (testPotionTargetType) Open(_ *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &testPotionTarget{handle: handle, d: data}
+}
+
+func (testPotionTargetType) EncodeEntity() string { return "minecraft:test_potion_target" }
+func (testPotionTargetType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.8, 0.3)
+}
+func (testPotionTargetType) DecodeNBT(map[string]any, *world.EntityData) {}
+func (testPotionTargetType) EncodeNBT(*world.EntityData) map[string]any  { return nil }
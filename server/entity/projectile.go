@@ -135,8 +135,8 @@ func (lt *ProjectileBehaviour) Owner() *world.EntityHandle {
 
 // Explode adds velocity to a projectile to blast it away from the explosion's
 // source.
-func (lt *ProjectileBehaviour) Explode(e *Ent, src mgl64.Vec3, impact float64, _ block.ExplosionConfig) {
-	e.data.Vel = e.Velocity().Add(e.Position().Sub(src).Normalize().Mul(impact))
+func (lt *ProjectileBehaviour) Explode(e *Ent, _ mgl64.Vec3, impact world.ExplosionImpact, _ block.ExplosionConfig) {
+	e.data.Vel = e.Velocity().Add(impact.Knockback)
 }
 
 // Potion returns the potion.Potion that is applied to an entity if hit by the
@@ -206,6 +206,7 @@ func (lt *ProjectileBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
 		if h, ok := tx.Block(bpos).(block.ProjectileHitter); ok {
 			h.ProjectileHit(bpos, tx, e, r.Face())
 		}
+		tx.EmitVibration(world.Vibration{Pos: r.Position(), Frequency: world.VibrationProjectileLand, Source: e})
 		if lt.conf.SurviveBlockCollision {
 			lt.hitBlockSurviving(e, r, m, tx)
 			return m
@@ -226,7 +227,7 @@ func (lt *ProjectileBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
 // projectile is still attached to a block and if it can be picked up.
 func (lt *ProjectileBehaviour) tickAttached(e *Ent, tx *world.Tx) bool {
 	boxes := tx.Block(lt.collisionPos).Model().BBox(lt.collisionPos, tx)
-	box := e.H().Type().BBox(e).Translate(e.Position())
+	box := world.EntityBBox(e).Translate(e.Position())
 
 	for _, bb := range boxes {
 		if box.IntersectsWith(bb.Translate(lt.collisionPos.Vec3()).Grow(0.05)) {
@@ -243,10 +244,10 @@ func (lt *ProjectileBehaviour) tickAttached(e *Ent, tx *world.Tx) bool {
 // tryPickup checks for nearby projectile collectors and closes the entity if
 // one was found.
 func (lt *ProjectileBehaviour) tryPickup(e *Ent, tx *world.Tx) {
-	translated := e.H().Type().BBox(e).Translate(e.Position())
+	translated := world.EntityBBox(e).Translate(e.Position())
 	grown := translated.GrowVec3(mgl64.Vec3{1, 0.5, 1})
 	for other := range tx.EntitiesWithin(translated.Grow(2)) {
-		if !other.H().Type().BBox(other).Translate(other.Position()).IntersectsWith(grown) {
+		if !world.EntityBBox(other).Translate(other.Position()).IntersectsWith(grown) {
 			continue
 		}
 		collector, ok := other.(Collector)
@@ -259,7 +260,7 @@ func (lt *ProjectileBehaviour) tryPickup(e *Ent, tx *world.Tx) {
 
 		// A collector was within range and able to pick up the entity.
 		lt.close = true
-		for _, viewer := range tx.Viewers(e.Position()) {
+		for _, viewer := range tx.EntityViewers(e) {
 			viewer.ViewEntityAction(e, PickedUpAction{Collector: collector})
 		}
 	}
@@ -279,7 +280,7 @@ func (lt *ProjectileBehaviour) hitBlockSurviving(e *Ent, r trace.BlockResult, m
 		e.SetVelocity(mgl64.Vec3{})
 		lt.collisionPos, lt.collided = r.BlockPosition(), true
 
-		for _, v := range tx.Viewers(m.pos) {
+		for _, v := range tx.EntityViewers(e) {
 			v.ViewEntityTeleport(e, m.pos)
 			v.ViewEntityAction(e, ArrowShakeAction{Duration: time.Millisecond * 350})
 			v.ViewEntityState(e)
@@ -320,7 +321,7 @@ func (lt *ProjectileBehaviour) hitEntity(l Living, e *Ent, vel mgl64.Vec3) {
 // based on gravity and drag.
 func (lt *ProjectileBehaviour) tickMovement(e *Ent, tx *world.Tx) (*Movement, trace.Result) {
 	pos, vel := e.Position(), e.Velocity()
-	viewers := tx.Viewers(pos)
+	viewers := tx.EntityViewers(e)
 
 	velBefore := vel
 	vel = lt.mc.applyHorizontalForces(tx, pos, lt.mc.applyVerticalForces(vel))
@@ -335,7 +336,7 @@ func (lt *ProjectileBehaviour) tickMovement(e *Ent, tx *world.Tx) (*Movement, tr
 		ok  bool
 	)
 	if !mgl64.FloatEqual(end.Sub(pos).LenSqr(), 0) {
-		if hit, ok = trace.Perform(pos, end, tx, e.H().Type().BBox(e).Grow(1.0), lt.ignores(e)); ok {
+		if hit, ok = trace.Perform(pos, end, tx, world.EntityBBox(e).Grow(1.0), lt.ignores(e)); ok {
 			if _, ok := hit.(trace.BlockResult); ok {
 				// Undo the gravity because the velocity as a result of gravity
 				// at the point of collision should be 0.
@@ -357,7 +358,7 @@ func (lt *ProjectileBehaviour) tickMovement(e *Ent, tx *world.Tx) (*Movement, tr
 }
 
 // ignores returns a function to ignore entities in trace.Perform that are
-// either a spectator, not living, the entity itself, its owner in the first
+// either a spectator, not colliding with entities, not living, the entity itself, its owner in the first
 // 5 ticks, or an entity it already collided with.
 func (lt *ProjectileBehaviour) ignores(e *Ent) trace.EntityFilter {
 	return func(seq iter.Seq[world.Entity]) iter.Seq[world.Entity] {
@@ -365,11 +366,13 @@ func (lt *ProjectileBehaviour) ignores(e *Ent) trace.EntityFilter {
 			for other := range seq {
 				g, ok := other.(interface{ GameMode() world.GameMode })
 				spectator := ok && !g.GameMode().HasCollision()
+				n, ok := other.(interface{ NoEntityCollision() bool })
+				noCollision := ok && n.NoEntityCollision()
 				itself := e.H() == other.H()
 				_, living := other.(Living)
 				owner := e.data.Age < time.Second/4 && lt.conf.Owner == other.H()
 				collidedEntity := slices.Contains(lt.collidedEntities, other.H())
-				if spectator || itself || !living || owner || collidedEntity {
+				if spectator || noCollision || itself || !living || owner || collidedEntity {
 					continue
 				}
 				if !yield(other) {
@@ -0,0 +1,452 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/player/bossbar"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// RaidConfig holds the settings of the raids started by Raids. A raid starts
+// when a player with the effect.BadOmen effect enters a village. Waves of
+// raiders then spawn around the village one after another. Once all waves
+// are defeated, the players nearby are granted the effect.HeroOfTheVillage
+// effect.
+type RaidConfig struct {
+	// Raiders holds the names of the entity types spawned as raiders, as
+	// registered in the world.EntityRegistry of the world. Every raider
+	// spawned is picked at random from Raiders. Raids do not start if Raiders
+	// is empty.
+	Raiders []string
+	// VillageRadius is the radius in blocks around a player that is searched
+	// for a village. Players within the VillageRadius of the centre of a raid
+	// are granted the effect.HeroOfTheVillage effect if the raid is won. If 0,
+	// it defaults to 32.
+	VillageRadius int
+	// Village returns the centre of the village that the position passed is
+	// in, or false if the position is not in a village. A raid is lost if its
+	// village can no longer be found. If nil, a village is any group of beds
+	// within the VillageRadius, with the average position of the beds as its
+	// centre.
+	Village func(tx *world.Tx, pos mgl64.Vec3, radius int) (mgl64.Vec3, bool)
+	// SpawnDistance is the distance in blocks from the centre of the village
+	// that raiders spawn at. If 0, it defaults to 24.
+	SpawnDistance float64
+	// WaveDelay is the time between the defeat of a wave and the spawning of
+	// the next wave. If 0, it defaults to 15 seconds.
+	WaveDelay time.Duration
+	// Timeout is the time after which a raid that was not won is lost. If 0, it
+	// defaults to 40 minutes.
+	Timeout time.Duration
+	// HeroDuration is the duration of the effect.HeroOfTheVillage effect
+	// granted to players after winning a raid. If 0, it defaults to 40
+	// minutes.
+	HeroDuration time.Duration
+}
+
+// maxOmenLevel is the highest level of Bad Omen that a raid may have.
+const maxOmenLevel = 5
+
+// raidViewDistance is the distance in blocks from the centre of a raid within
+// which players are shown the boss bar of the raid.
+const raidViewDistance = 96
+
+// Waves returns the number of waves of a raid with the Bad Omen level passed
+// in a world with the difficulty passed. Raids have 3 waves on easy, 5 on
+// normal and 7 on hard difficulty, with a bonus wave for a Bad Omen level
+// above 1. No raids happen on peaceful difficulty.
+func (conf RaidConfig) Waves(diff world.Difficulty, omen int) int {
+	var waves int
+	switch diff {
+	case world.DifficultyEasy:
+		waves = 3
+	case world.DifficultyNormal:
+		waves = 5
+	case world.DifficultyHard:
+		waves = 7
+	default:
+		return 0
+	}
+	if omen > 1 {
+		waves++
+	}
+	return waves
+}
+
+// WaveSize returns the number of raiders spawned in a wave of a raid with the
+// Bad Omen level passed. The first wave has 4 raiders, growing by one with
+// every following wave and with every level of Bad Omen above 1.
+func (conf RaidConfig) WaveSize(wave, omen int) int {
+	return 3 + wave + min(max(omen, 1), maxOmenLevel) - 1
+}
+
+// New creates Raids using the RaidConfig. Raids must be started using
+// Raids.Start.
+func (conf RaidConfig) New() *Raids {
+	if conf.VillageRadius <= 0 {
+		conf.VillageRadius = 32
+	}
+	if conf.Village == nil {
+		conf.Village = bedVillage
+	}
+	conf.SpawnDistance = defaultValue(conf.SpawnDistance, 24)
+	if conf.WaveDelay <= 0 {
+		conf.WaveDelay = time.Second * 15
+	}
+	if conf.Timeout <= 0 {
+		conf.Timeout = time.Minute * 40
+	}
+	if conf.HeroDuration <= 0 {
+		conf.HeroDuration = time.Minute * 40
+	}
+	return &Raids{conf: conf}
+}
+
+// Raids starts and runs raids in a world when players with the
+// effect.BadOmen effect enter a village. Raids may only be used in the world
+// that it was started in.
+type Raids struct {
+	conf  RaidConfig
+	raids []*Raid
+
+	running bool
+	// run is incremented every time Raids is started, so that ticks scheduled
+	// by an earlier run are ignored after restarting it.
+	run  int
+	tick int64
+}
+
+// Start starts looking for players with Bad Omen entering villages in the
+// world of the world.Tx passed and runs the raids that they start. Nothing
+// happens if Raids is already running.
+func (r *Raids) Start(tx *world.Tx) {
+	if r.running {
+		return
+	}
+	r.running = true
+	r.run++
+	r.schedule(tx)
+}
+
+// Stop stops all raids currently running, removing their boss bars, and
+// stops starting new raids.
+func (r *Raids) Stop(tx *world.Tx) {
+	if !r.running {
+		return
+	}
+	r.running = false
+	for _, raid := range r.raids {
+		raid.removeBar(tx)
+	}
+	r.raids = nil
+}
+
+// Raids returns the raids that are currently running.
+func (r *Raids) Raids() []*Raid {
+	return slices.Clone(r.raids)
+}
+
+// StartRaid starts a raid at the village with the centre passed with the Bad
+// Omen level passed. False is returned if a raid is already running within
+// the VillageRadius of the centre or if the raid would have no waves, such as
+// on peaceful difficulty.
+func (r *Raids) StartRaid(tx *world.Tx, centre mgl64.Vec3, omen int) (*Raid, bool) {
+	if !r.running || len(r.conf.Raiders) == 0 || r.raidAt(centre) != nil {
+		return nil, false
+	}
+	omen = min(max(omen, 1), maxOmenLevel)
+	waves := r.conf.Waves(tx.World().Difficulty(), omen)
+	if waves <= 0 {
+		return nil, false
+	}
+	raid := &Raid{conf: &r.conf, centre: centre, omen: omen, waves: waves, shown: map[*world.EntityHandle]struct{}{}}
+	raid.delay = raid.ticks(r.conf.WaveDelay)
+	raid.updateBar(tx)
+	r.raids = append(r.raids, raid)
+	return raid, true
+}
+
+// raidAt returns the raid running within the VillageRadius of the position
+// passed, or nil if there is none.
+func (r *Raids) raidAt(pos mgl64.Vec3) *Raid {
+	for _, raid := range r.raids {
+		if raid.centre.Sub(pos).Len() <= float64(r.conf.VillageRadius) {
+			return raid
+		}
+	}
+	return nil
+}
+
+// schedule schedules the next tick of Raids.
+func (r *Raids) schedule(tx *world.Tx) {
+	run := r.run
+	tx.ScheduleFunc(time.Second/20, func(tx *world.Tx) {
+		if r.run == run && r.running {
+			r.doTick(tx)
+			r.schedule(tx)
+		}
+	})
+}
+
+// doTick ticks all raids currently running and starts raids for players with
+// Bad Omen that entered a village.
+func (r *Raids) doTick(tx *world.Tx) {
+	r.tick++
+	r.raids = slices.DeleteFunc(r.raids, func(raid *Raid) bool {
+		return !raid.doTick(tx, r.tick)
+	})
+	if r.tick%20 != 0 {
+		return
+	}
+	for p := range tx.Players() {
+		l, ok := p.(Living)
+		if !ok || l.Dead() {
+			continue
+		}
+		omen, ok := badOmenLevel(l)
+		if !ok || r.raidAt(p.Position()) != nil {
+			continue
+		}
+		if centre, ok := r.conf.Village(tx, p.Position(), r.conf.VillageRadius); ok {
+			if _, ok := r.StartRaid(tx, centre, omen); ok {
+				l.RemoveEffect(effect.BadOmen)
+			}
+		}
+	}
+}
+
+// badOmenLevel returns the level of the Bad Omen effect of the entity passed,
+// if any.
+func badOmenLevel(l Living) (int, bool) {
+	for _, e := range l.Effects() {
+		if e.Type() == effect.BadOmen {
+			return e.Level(), true
+		}
+	}
+	return 0, false
+}
+
+// RaidState is the state of a Raid.
+type RaidState uint8
+
+const (
+	// RaidOngoing is the state of a Raid that was neither won nor lost yet.
+	RaidOngoing RaidState = iota
+	// RaidWon is the state of a Raid of which all waves were defeated.
+	RaidWon
+	// RaidLost is the state of a Raid that timed out or of which the village
+	// could no longer be found.
+	RaidLost
+)
+
+// Raid is a raid on a village started by Raids.
+type Raid struct {
+	conf   *RaidConfig
+	centre mgl64.Vec3
+	omen   int
+
+	waves, wave int
+	raiders     []*world.EntityHandle
+	waveSize    int
+	// delay is the number of ticks left until the next wave spawns.
+	delay int64
+	age   int64
+	state RaidState
+
+	bar bossbar.BossBar
+	// shown holds the viewers that the boss bar is currently shown to.
+	shown map[*world.EntityHandle]struct{}
+}
+
+// Centre returns the centre of the village that the Raid takes place in.
+func (r *Raid) Centre() mgl64.Vec3 {
+	return r.centre
+}
+
+// OmenLevel returns the level of Bad Omen that started the Raid.
+func (r *Raid) OmenLevel() int {
+	return r.omen
+}
+
+// Waves returns the total number of waves of the Raid.
+func (r *Raid) Waves() int {
+	return r.waves
+}
+
+// Wave returns the number of waves that have spawned so far.
+func (r *Raid) Wave() int {
+	return r.wave
+}
+
+// Raiders returns the handles of the raiders of the current wave that are
+// still alive.
+func (r *Raid) Raiders() []*world.EntityHandle {
+	return slices.Clone(r.raiders)
+}
+
+// State returns the RaidState of the Raid.
+func (r *Raid) State() RaidState {
+	return r.state
+}
+
+// BossBar returns the boss bar shown to players near the Raid.
+func (r *Raid) BossBar() bossbar.BossBar {
+	return r.bar
+}
+
+// ticks converts the duration passed to a number of ticks, which is at least
+// 1.
+func (r *Raid) ticks(d time.Duration) int64 {
+	return max(int64(d/(time.Second/20)), 1)
+}
+
+// doTick progresses the Raid by a single tick. False is returned if the Raid
+// ended.
+func (r *Raid) doTick(tx *world.Tx, current int64) bool {
+	r.age++
+	r.raiders = slices.DeleteFunc(r.raiders, func(h *world.EntityHandle) bool {
+		return !raiderAlive(tx, h)
+	})
+	if r.age >= r.ticks(r.conf.Timeout) {
+		r.end(tx, RaidLost)
+		return false
+	}
+	if current%20 == 0 {
+		if _, ok := r.conf.Village(tx, r.centre, r.conf.VillageRadius); !ok {
+			r.end(tx, RaidLost)
+			return false
+		}
+	}
+	if len(r.raiders) == 0 {
+		if r.wave >= r.waves {
+			r.end(tx, RaidWon)
+			return false
+		}
+		if r.delay--; r.delay <= 0 {
+			r.spawnWave(tx)
+		}
+	}
+	r.updateBar(tx)
+	return true
+}
+
+// raiderAlive checks if the raider with the handle passed is still alive.
+func raiderAlive(tx *world.Tx, h *world.EntityHandle) bool {
+	e, ok := h.Entity(tx)
+	if !ok {
+		return false
+	}
+	l, ok := e.(Living)
+	return !ok || !l.Dead()
+}
+
+// spawnWave spawns the next wave of raiders at a random position around the
+// village.
+func (r *Raid) spawnWave(tx *world.Tx) {
+	r.wave++
+	r.delay = r.ticks(r.conf.WaveDelay)
+	r.waveSize = r.conf.WaveSize(r.wave, r.omen)
+
+	angle := rand.Float64() * math.Pi * 2
+	x := int(math.Floor(r.centre[0] + math.Cos(angle)*r.conf.SpawnDistance))
+	z := int(math.Floor(r.centre[2] + math.Sin(angle)*r.conf.SpawnDistance))
+	for range r.waveSize {
+		pos := cube.Pos{x + rand.IntN(5) - 2, 0, z + rand.IntN(5) - 2}
+		pos[1] = tx.HighestBlock(pos[0], pos[2]) + 1
+		name := r.conf.Raiders[rand.IntN(len(r.conf.Raiders))]
+		if e, ok := tx.SpawnEntity(name, pos.Vec3Middle(), world.SpawnOptions{Persistent: true}); ok {
+			r.raiders = append(r.raiders, e.H())
+		}
+	}
+}
+
+// end ends the Raid with the RaidState passed. If the Raid was won, all
+// players within the VillageRadius of its centre are granted the Hero of the
+// Village effect.
+func (r *Raid) end(tx *world.Tx, state RaidState) {
+	r.state = state
+	r.removeBar(tx)
+	if state != RaidWon {
+		return
+	}
+	for p := range tx.Players() {
+		if l, ok := p.(Living); ok && !l.Dead() && p.Position().Sub(r.centre).Len() <= float64(r.conf.VillageRadius) {
+			l.AddEffect(effect.New(effect.HeroOfTheVillage, r.omen, r.conf.HeroDuration))
+		}
+	}
+}
+
+// updateBar updates the boss bar of the Raid and shows it to all players
+// near the Raid. Before a wave spawns, the bar fills up. Once a wave spawned,
+// the bar empties as its raiders are defeated.
+func (r *Raid) updateBar(tx *world.Tx) {
+	text, health := "Raid", 1-float64(r.delay)/float64(r.ticks(r.conf.WaveDelay))
+	if len(r.raiders) > 0 {
+		health = float64(len(r.raiders)) / float64(r.waveSize)
+		if len(r.raiders) <= 2 {
+			text = fmt.Sprintf("Raid - Raiders Remaining: %d", len(r.raiders))
+		}
+	}
+	r.bar = bossbar.New(text).WithHealthPercentage(min(max(health, 0), 1)).WithColour(bossbar.Red())
+	for p := range tx.Players() {
+		v, ok := p.(bossbar.Viewer)
+		if !ok {
+			continue
+		}
+		if p.Position().Sub(r.centre).Len() <= raidViewDistance {
+			v.SendBossBar(r.bar)
+			r.shown[p.H()] = struct{}{}
+		} else if _, ok := r.shown[p.H()]; ok {
+			v.RemoveBossBar()
+			delete(r.shown, p.H())
+		}
+	}
+}
+
+// removeBar removes the boss bar from all players that it was shown to and
+// that are still in the world.
+func (r *Raid) removeBar(tx *world.Tx) {
+	for h := range r.shown {
+		if e, ok := h.Entity(tx); ok {
+			if v, ok := e.(bossbar.Viewer); ok {
+				v.RemoveBossBar()
+			}
+		}
+	}
+	clear(r.shown)
+}
+
+// bedVillage finds a village within the radius passed around pos by looking
+// for beds. The centre of the village is the average position of the heads of
+// the beds found.
+func bedVillage(tx *world.Tx, pos mgl64.Vec3, radius int) (mgl64.Vec3, bool) {
+	centre, n := mgl64.Vec3{}, 0
+	origin := cube.PosFromVec3(pos)
+	for bedPos := range tx.BlocksWithin(origin, radius, villageBeds...) {
+		if math.Abs(float64(bedPos[1]-origin[1])) > float64(radius) {
+			continue
+		}
+		centre, n = centre.Add(bedPos.Vec3Centre()), n+1
+	}
+	if n == 0 {
+		return mgl64.Vec3{}, false
+	}
+	return centre.Mul(1 / float64(n)), true
+}
+
+// villageBeds holds the block states of the heads of beds, which are used to
+// find villages.
+var villageBeds = func() (beds []world.Block) {
+	for _, d := range cube.Directions() {
+		beds = append(beds, block.Bed{Facing: d, Head: true})
+	}
+	return beds
+}()
@@ -0,0 +1,169 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestRaidWaveScaling(t *testing.T) {
+	conf := entity.RaidConfig{}
+	tests := []struct {
+		diff        world.Difficulty
+		omen, waves int
+	}{
+		{diff: world.DifficultyPeaceful, omen: 5, waves: 0},
+		{diff: world.DifficultyEasy, omen: 1, waves: 3},
+		{diff: world.DifficultyEasy, omen: 2, waves: 4},
+		{diff: world.DifficultyNormal, omen: 1, waves: 5},
+		{diff: world.DifficultyNormal, omen: 5, waves: 6},
+		{diff: world.DifficultyHard, omen: 1, waves: 7},
+		{diff: world.DifficultyHard, omen: 3, waves: 8},
+	}
+	for _, test := range tests {
+		if waves := conf.Waves(test.diff, test.omen); waves != test.waves {
+			t.Errorf("waves with difficulty %T and Bad Omen %v = %v, want %v", test.diff, test.omen, waves, test.waves)
+		}
+	}
+	for _, test := range []struct{ wave, omen, size int }{{1, 1, 4}, {3, 1, 6}, {1, 3, 6}, {2, 9, 9}} {
+		if size := conf.WaveSize(test.wave, test.omen); size != test.size {
+			t.Errorf("size of wave %v with Bad Omen %v = %v, want %v", test.wave, test.omen, size, test.size)
+		}
+	}
+}
+
+func TestRaidWon(t *testing.T) {
+	w, handle, raids := raidTestWorld(t, entity.RaidConfig{Village: raidTestVillage})
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.AddEffect(effect.New(effect.BadOmen, 2, time.Minute))
+	})
+	advanceTicks(w, 20)
+	raid := raidTestStarted(t, w, handle, raids)
+	if raid.OmenLevel() != 2 || raid.Waves() != 6 {
+		t.Fatalf("raid started with Bad Omen %v and %v waves, want Bad Omen 2 and 6 waves", raid.OmenLevel(), raid.Waves())
+	}
+
+	for i := 0; i < 200 && raid.State() == entity.RaidOngoing; i++ {
+		advanceTicks(w, 1)
+		doTx(t, w, func(tx *world.Tx) {
+			raiders := raid.Raiders()
+			if len(raiders) == 0 {
+				return
+			}
+			if want := raid.Waves(); raid.Wave() > want {
+				t.Fatalf("raid spawned wave %v, but only has %v waves", raid.Wave(), want)
+			}
+			if size := (entity.RaidConfig{}).WaveSize(raid.Wave(), 2); len(raiders) != size {
+				t.Fatalf("wave %v spawned %v raiders, want %v", raid.Wave(), len(raiders), size)
+			}
+			// Defeat the whole wave.
+			for _, h := range raiders {
+				if e, ok := h.Entity(tx); ok {
+					_ = e.(*entity.Ent).Close()
+				}
+			}
+		})
+	}
+	if raid.State() != entity.RaidWon || raid.Wave() != 6 {
+		t.Fatalf("raid state %v after wave %v, want the raid won after 6 waves", raid.State(), raid.Wave())
+	}
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		if e, ok := p.Effect(effect.HeroOfTheVillage); !ok || e.Level() != 2 {
+			t.Errorf("player effects after winning a raid = %v, want Hero of the Village II", p.Effects())
+		}
+	})
+	if len(raids.Raids()) != 0 {
+		t.Errorf("raid still running after it was won")
+	}
+}
+
+func TestRaidLost(t *testing.T) {
+	t.Run("Timeout", func(t *testing.T) {
+		w, handle, raids := raidTestWorld(t, entity.RaidConfig{Village: raidTestVillage, Timeout: time.Second * 5})
+		withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+			p.AddEffect(effect.New(effect.BadOmen, 1, time.Minute))
+		})
+		advanceTicks(w, 20)
+		raid := raidTestStarted(t, w, handle, raids)
+		// The raiders are never defeated, so the raid times out.
+		advanceTicks(w, 100)
+		if raid.State() != entity.RaidLost {
+			t.Fatalf("raid state after timing out = %v, want lost", raid.State())
+		}
+		withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+			if _, ok := p.Effect(effect.HeroOfTheVillage); ok {
+				t.Errorf("player was granted Hero of the Village after losing a raid")
+			}
+		})
+	})
+	t.Run("VillageDestroyed", func(t *testing.T) {
+		// Without a Village function, the village is made up of the beds near
+		// the player.
+		w, handle, raids := raidTestWorld(t, entity.RaidConfig{})
+		bed := cube.Pos{3, 64, 3}
+		doTx(t, w, func(tx *world.Tx) {
+			tx.SetBlock(bed.Side(cube.FaceSouth), block.Bed{Facing: cube.North}, nil)
+			tx.SetBlock(bed, block.Bed{Facing: cube.North, Head: true}, nil)
+		})
+		withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+			p.AddEffect(effect.New(effect.BadOmen, 1, time.Minute))
+		})
+		advanceTicks(w, 20)
+		raid := raidTestStarted(t, w, handle, raids)
+		if c := raid.Centre(); !c.ApproxEqual(bed.Vec3Centre()) {
+			t.Errorf("raid centre = %v, want the bed of the village at %v", c, bed.Vec3Centre())
+		}
+		doTx(t, w, func(tx *world.Tx) {
+			tx.SetBlock(bed, nil, nil)
+			tx.SetBlock(bed.Side(cube.FaceSouth), nil, nil)
+		})
+		advanceTicks(w, 20)
+		if raid.State() != entity.RaidLost {
+			t.Fatalf("raid state after the village was destroyed = %v, want lost", raid.State())
+		}
+	})
+}
+
+// raidTestVillage is a village that is always found with its centre at the position of the player.
+func raidTestVillage(*world.Tx, mgl64.Vec3, int) (mgl64.Vec3, bool) {
+	return mgl64.Vec3{0.5, 64, 0.5}, true
+}
+
+// raidTestWorld returns a world with a stone floor, a player standing in the middle of it and Raids started using
+// the config passed. Waves of raiders spawn close to the village, right after the previous wave was defeated.
+func raidTestWorld(t *testing.T, conf entity.RaidConfig) (*world.World, *world.EntityHandle, *entity.Raids) {
+	w := newTestWorld(t)
+	doTx(t, w, func(tx *world.Tx) {
+		for pos := range cube.Range3D(cube.Pos{-16, 63, -16}, cube.Pos{16, 63, 16}) {
+			tx.SetBlock(pos, block.Stone{}, nil)
+		}
+	})
+	handle := spawnTestPlayer(t, w, player.Config{})
+
+	conf.Raiders = []string{testMobName}
+	conf.SpawnDistance, conf.WaveDelay = 8, time.Second/20
+	raids := conf.New()
+	doTx(t, w, raids.Start)
+	return w, handle, raids
+}
+
+// raidTestStarted returns the raid that was started by the player passed, failing the test if there is none.
+func raidTestStarted(t *testing.T, w *world.World, handle *world.EntityHandle, raids *entity.Raids) *entity.Raid {
+	t.Helper()
+	if len(raids.Raids()) != 1 {
+		t.Fatalf("%v raids started by a player with Bad Omen in a village, want 1", len(raids.Raids()))
+	}
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		if _, ok := p.Effect(effect.BadOmen); ok {
+			t.Errorf("player still has Bad Omen after starting a raid")
+		}
+	})
+	return raids.Raids()[0]
+}
@@ -0,0 +1,51 @@
+package entity_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestPlayerAttackReach(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	policy := player.MovementPolicy{AttackReach: 3, CreativeAttackReach: 5, ReachTolerance: 0.5}
+	tests := []struct {
+		name     string
+		mode     world.GameMode
+		distance float64
+		want     bool
+	}{
+		{name: "survival within reach", mode: world.GameModeSurvival, distance: 3, want: true},
+		{name: "survival within tolerance", mode: world.GameModeSurvival, distance: 3.4, want: true},
+		{name: "survival beyond reach", mode: world.GameModeSurvival, distance: 4.5, want: false},
+		{name: "creative within reach", mode: world.GameModeCreative, distance: 5, want: true},
+		{name: "creative beyond reach", mode: world.GameModeCreative, distance: 6.5, want: false},
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := w.Do(func(tx *world.Tx) {
+				// Place every attacker and target far enough apart not to interfere with each other.
+				pos := mgl64.Vec3{float64(i) * 32, 64, 0}
+				attacker := tx.AddEntity(world.EntitySpawnOpts{}.New(player.Type, player.Config{Name: "attacker", Position: pos, GameMode: test.mode, MovementPolicy: policy})).(*player.Player)
+				// The target is placed along the X axis, so that the distance is measured from the eyes of the
+				// attacker to the side of the bounding box of the target that faces it, 0.3 blocks from its
+				// position.
+				targetPos := pos.Add(mgl64.Vec3{test.distance + 0.3, attacker.EyeHeight() - 1})
+				target := tx.AddEntity(world.EntitySpawnOpts{}.New(player.Type, player.Config{Name: "target", Position: targetPos})).(*player.Player)
+
+				if got := attacker.AttackEntity(target); got != test.want {
+					t.Errorf("attack at a distance of %v blocks = %v, want %v", test.distance, got, test.want)
+				}
+			}).Wait(context.Background())
+			if err != nil {
+				t.Fatalf("world task failed: %v", err)
+			}
+		})
+	}
+}
@@ -12,19 +12,24 @@ import (
 var DefaultRegistry = conf.New([]world.EntityType{
 	AreaEffectCloudType,
 	ArrowType,
+	BoatType,
 	BottleOfEnchantingType,
 	EggType,
 	EnderPearlType,
 	ExperienceOrbType,
 	FallingBlockType,
 	FireworkType,
+	FishingHookType,
+	HorseType,
 	ItemType,
 	LightningType,
 	LingeringPotionType,
+	MinecartType,
 	SnowballType,
 	SplashPotionType,
 	TNTType,
 	TextType,
+	ZombieType,
 })
 
 var conf = world.EntityRegistryConfig{
@@ -35,6 +40,7 @@ var conf = world.EntityRegistryConfig{
 	EnderPearl:         NewEnderPearl,
 	FallingBlock:       NewFallingBlock,
 	Lightning:          NewLightning,
+	FishingHook:        NewFishingHook,
 	Firework: func(opts world.EntitySpawnOpts, firework world.Item, owner world.Entity, sidewaysVelocityMultiplier, upwardsAcceleration float64, attached bool) *world.EntityHandle {
 		return newFirework(opts, firework.(item.Firework), owner, sidewaysVelocityMultiplier, upwardsAcceleration, attached)
 	},
@@ -0,0 +1,148 @@
+package entity_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+)
+
+// respawnTestProvider is a world.Provider that keeps player spawn positions in memory.
+type respawnTestProvider struct {
+	world.NopProvider
+	spawns map[uuid.UUID]cube.Pos
+}
+
+func (p *respawnTestProvider) LoadPlayerSpawnPosition(id uuid.UUID) (cube.Pos, bool, error) {
+	pos, ok := p.spawns[id]
+	return pos, ok, nil
+}
+
+func (p *respawnTestProvider) SavePlayerSpawnPosition(id uuid.UUID, pos cube.Pos) error {
+	p.spawns[id] = pos
+	return nil
+}
+
+// respawnTestWorld returns a new world with the dimension passed that keeps player spawn positions.
+func respawnTestWorld(dim world.Dimension) *world.World {
+	return world.Config{Synchronous: true, Dim: dim, Entities: entity.DefaultRegistry, Provider: &respawnTestProvider{spawns: map[uuid.UUID]cube.Pos{}}}.New()
+}
+
+// respawnTestPlayer spawns a player next to pos on a floor of stone and runs f with it.
+func respawnTestPlayer(t *testing.T, w *world.World, pos cube.Pos, f func(tx *world.Tx, p *player.Player)) {
+	t.Helper()
+	if err := w.Do(func(tx *world.Tx) {
+		for x := -4; x <= 4; x++ {
+			for z := -4; z <= 4; z++ {
+				tx.SetBlock(pos.Add(cube.Pos{x, -1, z}), block.Stone{}, nil)
+			}
+		}
+		p := tx.AddEntity(world.EntitySpawnOpts{}.New(player.Type, player.Config{Name: "player", Position: pos.Add(cube.Pos{2}).Vec3Middle()})).(*player.Player)
+		f(tx, p)
+	}).Wait(context.Background()); err != nil {
+		t.Fatalf("world task failed: %v", err)
+	}
+}
+
+func TestBedExplodesWhereBedsDoNotWork(t *testing.T) {
+	for _, dim := range []world.Dimension{world.Overworld, world.Nether, world.End} {
+		w := respawnTestWorld(dim)
+		pos := cube.Pos{0, 64, 0}
+		respawnTestPlayer(t, w, pos, func(tx *world.Tx, p *player.Player) {
+			tx.SetBlock(pos, block.Bed{Facing: cube.North}, nil)
+			tx.SetBlock(pos.Side(cube.FaceNorth), block.Bed{Facing: cube.North, Head: true}, nil)
+			p.UseItemOnBlock(pos, cube.FaceUp, mgl64.Vec3{})
+
+			_, bed := tx.Block(pos).(block.Bed)
+			_, floor := tx.Block(pos.Side(cube.FaceDown)).(block.Stone)
+			if dim.BedsWork() {
+				if !bed || !floor {
+					t.Errorf("bed used in %v exploded, want it to remain", dim)
+				}
+				return
+			}
+			if bed || floor {
+				t.Errorf("bed used in %v did not explode, want bed and floor destroyed", dim)
+			}
+			if _, sleeping := p.Sleeping(); sleeping {
+				t.Errorf("player is sleeping in bed used in %v, want no sleep", dim)
+			}
+			if p.Health() >= p.MaxHealth() {
+				t.Errorf("player next to exploding bed in %v has full health, want explosion damage", dim)
+			}
+		})
+		_ = w.Close()
+	}
+}
+
+func TestRespawnAnchorChargeAndSpawn(t *testing.T) {
+	w := respawnTestWorld(world.Nether)
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	respawnTestPlayer(t, w, pos, func(tx *world.Tx, p *player.Player) {
+		tx.SetBlock(pos, block.RespawnAnchor{}, nil)
+		p.SetHeldItems(item.NewStack(block.Glowstone{}, 2), item.Stack{})
+		p.UseItemOnBlock(pos, cube.FaceUp, mgl64.Vec3{})
+		p.UseItemOnBlock(pos, cube.FaceUp, mgl64.Vec3{})
+		if a := tx.Block(pos).(block.RespawnAnchor); a.Charge != 2 {
+			t.Fatalf("respawn anchor charge after using 2 glowstone = %v, want 2", a.Charge)
+		}
+		if held, _ := p.HeldItems(); !held.Empty() {
+			t.Errorf("held item after charging respawn anchor = %v, want glowstone used up", held)
+		}
+		if spawn := w.PlayerSpawn(p.UUID()); spawn == pos {
+			t.Errorf("player spawn set to respawn anchor while charging it, want it unchanged")
+		}
+
+		p.UseItemOnBlock(pos, cube.FaceUp, mgl64.Vec3{})
+		if spawn := w.PlayerSpawn(p.UUID()); spawn != pos {
+			t.Fatalf("player spawn after using charged respawn anchor = %v, want %v", spawn, pos)
+		}
+
+		// Every respawn at the anchor uses up one of its charges, until it can no longer be respawned on.
+		for charge := 1; charge >= 0; charge-- {
+			b := tx.Block(pos).(block.RespawnBlock)
+			if !b.CanRespawnOn() {
+				t.Fatalf("respawn anchor with %v charges cannot be respawned on", charge+1)
+			}
+			if spawn, ok := b.SafeSpawn(pos, tx); !ok || spawn.Sub(pos)[1] != 0 {
+				t.Fatalf("safe spawn of respawn anchor = %v, %v, want position next to it", spawn, ok)
+			}
+			b.RespawnOn(pos, tx)
+			if a := tx.Block(pos).(block.RespawnAnchor); a.Charge != charge {
+				t.Fatalf("respawn anchor charge after respawning = %v, want %v", a.Charge, charge)
+			}
+		}
+		if tx.Block(pos).(block.RespawnBlock).CanRespawnOn() {
+			t.Errorf("respawn anchor without charges can be respawned on")
+		}
+	})
+}
+
+func TestRespawnAnchorExplodesOutsideNether(t *testing.T) {
+	w := respawnTestWorld(world.Overworld)
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	respawnTestPlayer(t, w, pos, func(tx *world.Tx, p *player.Player) {
+		tx.SetBlock(pos, block.RespawnAnchor{Charge: 1}, nil)
+		if _, ok := (block.RespawnAnchor{Charge: 1}).SafeSpawn(pos, tx); ok {
+			t.Errorf("respawn anchor in overworld has a safe spawn, want none")
+		}
+		p.UseItemOnBlock(pos, cube.FaceUp, mgl64.Vec3{})
+		if _, ok := tx.Block(pos).(block.RespawnAnchor); ok {
+			t.Errorf("charged respawn anchor used in overworld did not explode")
+		}
+		if spawn := w.PlayerSpawn(p.UUID()); spawn == pos {
+			t.Errorf("player spawn set to respawn anchor in overworld, want it unchanged")
+		}
+	})
+}
@@ -0,0 +1,250 @@
+package entity
+
+import (
+	"encoding/binary"
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+)
+
+// Rideable is a world.Entity that may be ridden by other entities, such as a
+// boat. The first rider of a Rideable controls its movement.
+type Rideable interface {
+	world.Entity
+	// Riders returns the handles of the entities currently riding the
+	// Rideable, ordered by seat. The first rider controls the Rideable.
+	Riders() []*world.EntityHandle
+	// AddRider makes the entity passed start riding the Rideable. False is
+	// returned if no seat was available.
+	AddRider(rider world.Entity) bool
+	// RemoveRider makes the entity passed stop riding the Rideable.
+	RemoveRider(rider world.Entity)
+	// SeatPosition returns the position of the seat taken by the rider passed.
+	// False is returned if the entity was not riding the Rideable.
+	SeatPosition(rider world.Entity) (mgl64.Vec3, bool)
+	// DismountPosition returns the position that riders of the Rideable are
+	// moved to when they stop riding it.
+	DismountPosition() mgl64.Vec3
+	// Steer passes the movement input of a rider to the Rideable. Input of
+	// riders that do not control the Rideable is ignored.
+	Steer(rider world.Entity, input RideInput)
+}
+
+// RideInput is the movement input of the rider controlling a Rideable. It is
+// passed to the Rideable every tick, so that it may be moved with physics of
+// its own, such as those of a boat or a custom vehicle.
+type RideInput struct {
+	// Forward is the forward movement input, in the range -1 to 1. Positive
+	// values move forward and negative values move backward.
+	Forward float64
+	// Strafe is the sideways movement input, in the range -1 to 1. Positive
+	// values move to the left and negative values move to the right.
+	Strafe float64
+	// Jumping is true if the rider is holding the jump button.
+	Jumping bool
+	// Sneaking is true if the rider is holding the sneak button.
+	Sneaking bool
+}
+
+// RideComputer is used to keep track of the entities riding an entity and the
+// movement input passed by the rider controlling it.
+type RideComputer struct {
+	// Seats holds the offsets of the seats of the entity relative to its
+	// position, where the Z axis points in the direction the entity is facing.
+	// The number of seats is the maximum number of riders.
+	Seats []mgl64.Vec3
+
+	riders []*world.EntityHandle
+	input  RideInput
+	// pending holds the UUIDs of riders read using DecodeNBT that have not
+	// yet been mounted, because they were not yet loaded.
+	pending []uuid.UUID
+}
+
+// Riders returns the handles of the entities currently riding, ordered by
+// seat.
+func (c *RideComputer) Riders() []*world.EntityHandle {
+	return slices.Clone(c.riders)
+}
+
+// Mount makes rider start riding vehicle and shows the link to viewers of the
+// vehicle. False is returned if all seats were taken or if rider was already
+// riding the vehicle.
+func (c *RideComputer) Mount(vehicle, rider world.Entity, tx *world.Tx) bool {
+	if len(c.riders) >= len(c.Seats) || rider.H() == vehicle.H() || slices.Contains(c.riders, rider.H()) {
+		return false
+	}
+	c.riders = append(c.riders, rider.H())
+	for _, v := range tx.EntityViewers(vehicle) {
+		v.ViewEntityMount(rider, vehicle, len(c.riders) == 1)
+	}
+	return true
+}
+
+// Dismount makes rider stop riding vehicle and removes the link for viewers of
+// the vehicle. Riders in later seats move up a seat.
+func (c *RideComputer) Dismount(vehicle, rider world.Entity, tx *world.Tx) {
+	i := slices.Index(c.riders, rider.H())
+	if i == -1 {
+		return
+	}
+	for _, v := range tx.EntityViewers(vehicle) {
+		v.ViewEntityDismount(rider, vehicle)
+	}
+	c.remove(vehicle, i, tx)
+}
+
+// DismountAll makes all riders stop riding vehicle, for example because the
+// vehicle is removed.
+func (c *RideComputer) DismountAll(vehicle world.Entity, tx *world.Tx) {
+	viewers := tx.EntityViewers(vehicle)
+	for _, h := range c.riders {
+		if rider, ok := h.Entity(tx); ok {
+			for _, v := range viewers {
+				v.ViewEntityDismount(rider, vehicle)
+			}
+		}
+	}
+	c.riders, c.input = nil, RideInput{}
+}
+
+// SeatPosition returns the position of the seat taken by rider, rotated with
+// the yaw of vehicle. False is returned if rider was not riding vehicle.
+func (c *RideComputer) SeatPosition(vehicle, rider world.Entity) (mgl64.Vec3, bool) {
+	i := slices.Index(c.riders, rider.H())
+	if i == -1 {
+		return mgl64.Vec3{}, false
+	}
+	seat := c.Seats[i]
+	forward := cube.Rotation{vehicle.Rotation().Yaw(), 0}.Vec3()
+	right := mgl64.Vec3{-forward[2], 0, forward[0]}
+	return vehicle.Position().Add(forward.Mul(seat[2])).Add(right.Mul(seat[0])).Add(mgl64.Vec3{0, seat[1]}), true
+}
+
+// DismountPosition returns a position next to vehicle that a rider can stand
+// in. If no such position exists, the position on top of vehicle is returned.
+func (c *RideComputer) DismountPosition(vehicle world.Entity, tx *world.Tx) mgl64.Vec3 {
+	pos, box := vehicle.Position(), world.EntityBBox(vehicle)
+	forward := cube.Rotation{vehicle.Rotation().Yaw(), 0}.Vec3()
+	right := mgl64.Vec3{-forward[2], 0, forward[0]}
+	dist := box.Width()/2 + 0.5
+	for _, dir := range [...]mgl64.Vec3{right.Mul(-1), right, forward, forward.Mul(-1)} {
+		candidate := pos.Add(dir.Mul(dist))
+		feet := cube.PosFromVec3(candidate)
+		if passable(tx, feet) && passable(tx, feet.Side(cube.FaceUp)) {
+			return candidate
+		}
+	}
+	return pos.Add(mgl64.Vec3{0, box.Height()})
+}
+
+// Steer stores the movement input of rider if it controls vehicle. The input
+// is consumed by the next call to Input.
+func (c *RideComputer) Steer(rider world.Entity, input RideInput) {
+	if len(c.riders) == 0 || c.riders[0] != rider.H() {
+		return
+	}
+	input.Forward, input.Strafe = mgl64.Clamp(input.Forward, -1, 1), mgl64.Clamp(input.Strafe, -1, 1)
+	c.input = input
+}
+
+// Input returns and resets the movement input of the rider controlling the
+// entity. Behaviours of rideable entities should call Input every tick to
+// move the entity according to the input of its rider.
+func (c *RideComputer) Input() RideInput {
+	input := c.input
+	c.input = RideInput{}
+	return input
+}
+
+// Tick mounts riders read using DecodeNBT once they are loaded and removes
+// riders that are no longer in the same world as vehicle, for example because
+// they left the server.
+func (c *RideComputer) Tick(vehicle world.Entity, tx *world.Tx) {
+	for i := 0; i < len(c.pending); {
+		// Riders may be in a chunk that is loaded later than that of the
+		// vehicle, so they stay pending until they are found.
+		if rider, ok := tx.EntityByUUID(c.pending[i]); ok {
+			c.pending = slices.Delete(c.pending, i, i+1)
+			c.Mount(vehicle, rider, tx)
+			continue
+		}
+		i++
+	}
+	for i := len(c.riders) - 1; i >= 0; i-- {
+		if _, ok := c.riders[i].Entity(tx); !ok {
+			c.remove(vehicle, i, tx)
+		}
+	}
+}
+
+// EncodeNBT writes the riders of the entity to the map passed, so that they
+// are mounted again after the entity is loaded using DecodeNBT. Players are
+// not saved with the world and are therefore not written.
+func (c *RideComputer) EncodeNBT(m map[string]any) {
+	ids := make([]uuid.UUID, 0, len(c.riders)+len(c.pending))
+	for _, h := range c.riders {
+		if h.Type().EncodeEntity() != "minecraft:player" {
+			ids = append(ids, h.UUID())
+		}
+	}
+	ids = append(ids, c.pending...)
+	if len(ids) == 0 {
+		return
+	}
+	links := make([]any, len(ids))
+	for i, id := range ids {
+		links[i] = map[string]any{"entityID": uniqueID(id), "LinkID": int32(i)}
+	}
+	m["LinksTag"] = links
+}
+
+// DecodeNBT reads the riders written using EncodeNBT from the map passed. The
+// riders are mounted by Tick as soon as they are loaded.
+func (c *RideComputer) DecodeNBT(m map[string]any) {
+	links, _ := m["LinksTag"].([]any)
+	for _, l := range links {
+		if link, ok := l.(map[string]any); ok {
+			c.pending = append(c.pending, entityUUID(nbtconv.Int64(link, "entityID")))
+		}
+	}
+}
+
+// uniqueID returns the unique ID of an entity with the UUID passed, as saved
+// in NBT.
+func uniqueID(id uuid.UUID) int64 {
+	return int64(binary.LittleEndian.Uint64(id[8:]))
+}
+
+// entityUUID returns the UUID of an entity with the unique ID passed, as
+// saved in NBT.
+func entityUUID(id int64) (u uuid.UUID) {
+	binary.LittleEndian.PutUint64(u[8:], uint64(id))
+	return u
+}
+
+// remove removes the rider in seat i. Riders in later seats move up a seat and
+// are shown in their new seat to viewers of vehicle.
+func (c *RideComputer) remove(vehicle world.Entity, i int, tx *world.Tx) {
+	c.riders = slices.Delete(c.riders, i, i+1)
+	if i == 0 {
+		c.input = RideInput{}
+	}
+	viewers := tx.EntityViewers(vehicle)
+	for j, h := range c.riders[i:] {
+		if rider, ok := h.Entity(tx); ok {
+			for _, v := range viewers {
+				v.ViewEntityMount(rider, vehicle, i+j == 0)
+			}
+		}
+	}
+}
+
+// passable checks if an entity can stand in the block at the position passed.
+func passable(tx *world.Tx, pos cube.Pos) bool {
+	return len(tx.Block(pos).Model().BBox(pos, tx)) == 0
+}
@@ -0,0 +1,84 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestPlayerSteersCustomVehicle(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	handle := spawnTestPlayer(t, w, player.Config{})
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		b := &rideInputTestBehaviour{ride: &entity.RideComputer{Seats: []mgl64.Vec3{{0, 1, 0}}}}
+		vehicle := tx.AddEntity(world.EntitySpawnOpts{Position: p.Position()}.New(rideInputTestVehicleType{}, b)).(*entity.Ent)
+		if !p.Mount(vehicle) {
+			t.Fatalf("player could not mount custom vehicle")
+		}
+
+		for _, test := range []struct {
+			name       string
+			input, got entity.RideInput
+		}{
+			{name: "forward and right", input: entity.RideInput{Forward: 1, Strafe: -1}, got: entity.RideInput{Forward: 1, Strafe: -1}},
+			{name: "backward and left", input: entity.RideInput{Forward: -0.5, Strafe: 0.5}, got: entity.RideInput{Forward: -0.5, Strafe: 0.5}},
+			{name: "jumping", input: entity.RideInput{Forward: 1, Jumping: true}, got: entity.RideInput{Forward: 1, Jumping: true}},
+			{name: "sneaking", input: entity.RideInput{Sneaking: true}, got: entity.RideInput{Sneaking: true}},
+			{name: "out of range", input: entity.RideInput{Forward: 3, Strafe: -2}, got: entity.RideInput{Forward: 1, Strafe: -1}},
+		} {
+			p.SteerVehicle(test.input)
+			vehicle.Tick(tx, 1)
+			if b.input != test.got {
+				t.Errorf("vehicle input after steering %v = %+v, want %+v", test.name, b.input, test.got)
+			}
+		}
+
+		// Input is only delivered to the vehicle for the tick it was passed in.
+		vehicle.Tick(tx, 2)
+		if b.input != (entity.RideInput{}) {
+			t.Errorf("vehicle input without steering = %+v, want no input", b.input)
+		}
+
+		p.Dismount()
+		p.SteerVehicle(entity.RideInput{Forward: 1})
+		vehicle.Tick(tx, 3)
+		if b.input != (entity.RideInput{}) {
+			t.Errorf("vehicle input after player dismounted = %+v, want no input", b.input)
+		}
+	})
+}
+
+// rideInputTestBehaviour is the entity.Behaviour of a custom vehicle that
+// records the input of its rider every tick.
+type rideInputTestBehaviour struct {
+	ride  *entity.RideComputer
+	input entity.RideInput
+}
+
+func (b *rideInputTestBehaviour) Apply(data *world.EntityData) { data.Data = b }
+
+func (b *rideInputTestBehaviour) RideComputer() *entity.RideComputer { return b.ride }
+
+func (b *rideInputTestBehaviour) Tick(e *entity.Ent, tx *world.Tx) *entity.Movement {
+	b.ride.Tick(e, tx)
+	b.input = b.ride.Input()
+	return nil
+}
+
+type rideInputTestVehicleType struct{}
+
+func (rideInputTestVehicleType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return entity.Open(tx, handle, data)
+}
+func (rideInputTestVehicleType) EncodeEntity() string { return "dragonfly:ride_input_test_vehicle" }
+func (rideInputTestVehicleType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.5, 0, -0.5, 0.5, 1, 0.5)
+}
+func (rideInputTestVehicleType) DecodeNBT(map[string]any, *world.EntityData) {}
+func (rideInputTestVehicleType) EncodeNBT(*world.EntityData) map[string]any  { return nil }
@@ -0,0 +1,150 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/mcdb"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestBoatMountDismountLinks(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	v := &rideTestViewer{}
+	l := world.NewLoader(2, w, v)
+	pos := mgl64.Vec3{0.5, 64, 0.5}
+	boat := NewBoat(world.EntitySpawnOpts{Position: pos}, block.OakWood())
+	riders := []*world.EntityHandle{NewText("a", pos), NewText("b", pos), NewText("c", pos)}
+
+	mustDo(t, w, func(tx *world.Tx) {
+		l.Move(tx, pos)
+		l.Load(tx, 16)
+		vehicle := tx.AddEntity(boat).(Rideable)
+		rs := make([]world.Entity, len(riders))
+		for i, h := range riders {
+			rs[i] = tx.AddEntity(h)
+		}
+
+		if !vehicle.AddRider(rs[0]) || !vehicle.AddRider(rs[1]) {
+			t.Fatal("expected boat to seat two riders")
+		}
+		if vehicle.AddRider(rs[2]) {
+			t.Fatal("expected boat with two riders to reject a third rider")
+		}
+		if len(v.mounts) != 2 || v.mounts[0] != (rideTestLink{rs[0].H(), boat, true}) || v.mounts[1] != (rideTestLink{rs[1].H(), boat, false}) {
+			t.Fatalf("unexpected mount links %v, expected driver and passenger", v.mounts)
+		}
+
+		v.mounts = nil
+		vehicle.RemoveRider(rs[0])
+		if len(v.dismounts) != 1 || v.dismounts[0] != (rideTestLink{rs[0].H(), boat, false}) {
+			t.Fatalf("unexpected dismount links %v after driver dismounted", v.dismounts)
+		}
+		if len(v.mounts) != 1 || v.mounts[0] != (rideTestLink{rs[1].H(), boat, true}) {
+			t.Fatalf("unexpected mount links %v, expected passenger to become driver", v.mounts)
+		}
+
+		_ = vehicle.Close()
+		if len(v.dismounts) != 2 || v.dismounts[1] != (rideTestLink{rs[1].H(), boat, false}) {
+			t.Fatalf("unexpected dismount links %v after closing the boat", v.dismounts)
+		}
+	})
+}
+
+func TestBoatSteerVelocity(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	pos := mgl64.Vec3{0.5, 64, 0.5}
+	boat := NewBoat(world.EntitySpawnOpts{Position: pos}, block.OakWood())
+	mustDo(t, w, func(tx *world.Tx) {
+		tx.SetBlock(cube.PosFromVec3(pos).Side(cube.FaceDown), block.Stone{}, nil)
+		e := tx.AddEntity(boat).(*Ent)
+		driver, passenger := tx.AddEntity(NewText("a", pos)), tx.AddEntity(NewText("b", pos))
+		e.AddRider(driver)
+		e.AddRider(passenger)
+
+		e.Steer(passenger, RideInput{Forward: 1})
+		e.Tick(tx, 1)
+		if vel := e.Velocity(); vel[2] > epsilon {
+			t.Fatalf("boat moved forward with velocity %v from input of a passenger", vel)
+		}
+
+		e.Steer(driver, RideInput{Forward: 1})
+		e.Tick(tx, 2)
+		if vel := e.Velocity(); vel[2] <= 0 || !mgl64.FloatEqual(vel[0], 0) {
+			t.Fatalf("boat steered forward by its driver had velocity %v, expected positive Z velocity", vel)
+		}
+
+		yaw := e.Rotation().Yaw()
+		e.Steer(driver, RideInput{Strafe: 1})
+		e.Tick(tx, 3)
+		if got := e.Rotation().Yaw(); got >= yaw {
+			t.Fatalf("boat steered left had yaw %v, expected less than %v", got, yaw)
+		}
+	})
+}
+
+func TestBoatRidersSurviveReload(t *testing.T) {
+	dir := t.TempDir()
+	pos := mgl64.Vec3{0.5, 64, 0.5}
+	boat := NewBoat(world.EntitySpawnOpts{Position: pos}, block.OakWood())
+	rider := NewText("rider", pos)
+
+	w := openPersistentWorld(t, dir)
+	mustDo(t, w, func(tx *world.Tx) {
+		if !tx.AddEntity(boat).(Rideable).AddRider(tx.AddEntity(rider)) {
+			t.Fatal("expected boat to seat rider")
+		}
+	})
+	_ = w.Close()
+
+	w = openPersistentWorld(t, dir)
+	t.Cleanup(func() { _ = w.Close() })
+	mustDo(t, w, func(tx *world.Tx) {
+		tx.Block(cube.PosFromVec3(pos))
+		e, ok := tx.EntityByUUID(boat.UUID())
+		if !ok {
+			t.Fatal("boat was not loaded after reload")
+		}
+		vehicle := e.(*Ent)
+		vehicle.Tick(tx, 1)
+		if riders := vehicle.Riders(); len(riders) != 1 || riders[0].UUID() != rider.UUID() {
+			t.Fatalf("boat riders after reload = %v, want rider %v", riders, rider.UUID())
+		}
+	})
+}
+
+// openPersistentWorld opens a world saved in the directory passed, which stores its chunks and entities on
+// disk when closed.
+func openPersistentWorld(t *testing.T, dir string) *world.World {
+	t.Helper()
+	db, err := mcdb.Config{}.Open(dir)
+	if err != nil {
+		t.Fatalf("open world database: %v", err)
+	}
+	return world.Config{Provider: db, Entities: DefaultRegistry}.New()
+}
+
+type rideTestLink struct {
+	rider, vehicle *world.EntityHandle
+	driver         bool
+}
+
+// rideTestViewer records the mount and dismount links it views.
+type rideTestViewer struct {
+	world.NopViewer
+	mounts, dismounts []rideTestLink
+}
+
+func (v *rideTestViewer) ViewEntityMount(rider, vehicle world.Entity, driver bool) {
+	v.mounts = append(v.mounts, rideTestLink{rider.H(), vehicle.H(), driver})
+}
+
+func (v *rideTestViewer) ViewEntityDismount(rider, vehicle world.Entity) {
+	v.dismounts = append(v.dismounts, rideTestLink{rider.H(), vehicle.H(), false})
+}
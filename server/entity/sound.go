@@ -0,0 +1,192 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Sounds holds the sounds played by a Living entity through a SoundComputer.
+// Sounds left nil are never played.
+type Sounds struct {
+	// Ambient is the sound occasionally played by the entity.
+	Ambient world.Sound
+	// Hurt is the sound played when the entity is hurt.
+	Hurt world.Sound
+	// Death is the sound played when the entity dies.
+	Death world.Sound
+	// Step returns the sound played when the entity walks over the block
+	// passed. If nil, no step sounds are played.
+	Step func(b world.Block) world.Sound
+}
+
+// DefaultSounds returns the Sounds of the encoded entity type passed, such as
+// 'minecraft:zombie', which play the vanilla sounds of that entity type.
+func DefaultSounds(entityType string) Sounds {
+	return Sounds{
+		Ambient: sound.EntityAmbient{EntityType: entityType},
+		Hurt:    sound.EntityHurt{EntityType: entityType},
+		Death:   sound.EntityDeath{EntityType: entityType},
+		Step: func(b world.Block) world.Sound {
+			return sound.EntityStep{EntityType: entityType, Block: b}
+		},
+	}
+}
+
+var (
+	soundsMu     sync.RWMutex
+	entitySounds = map[string]Sounds{}
+)
+
+// RegisterSounds registers the Sounds played by entities of the encoded
+// entity type passed, overwriting the sounds that were previously registered
+// or the DefaultSounds of the type. This may be used to change the sounds of
+// custom entities.
+func RegisterSounds(entityType string, s Sounds) {
+	soundsMu.Lock()
+	defer soundsMu.Unlock()
+	entitySounds[entityType] = s
+}
+
+// SoundsOf returns the Sounds registered for the encoded entity type passed
+// using RegisterSounds. If none were registered, the DefaultSounds of the type
+// are returned.
+func SoundsOf(entityType string) Sounds {
+	soundsMu.RLock()
+	defer soundsMu.RUnlock()
+	if s, ok := entitySounds[entityType]; ok {
+		return s
+	}
+	return DefaultSounds(entityType)
+}
+
+// SoundComputer is used to play the ambient, hurt, death and step sounds of a
+// Living entity to viewers in range. Ambient sounds are played at a random
+// interval between MinAmbientInterval and MaxAmbientInterval, and step sounds
+// are played as the entity walks over blocks on the ground. The zero value of
+// a SoundComputer is ready to use.
+type SoundComputer struct {
+	// Sounds overrides the Sounds played by the entity. If nil, the Sounds
+	// registered for the type of the entity are used, as returned by SoundsOf.
+	Sounds *Sounds
+	// MinAmbientInterval is the minimum time between two ambient sounds. If 0,
+	// it defaults to 4 seconds.
+	MinAmbientInterval time.Duration
+	// MaxAmbientInterval is the maximum time between two ambient sounds. If 0,
+	// or if lower than MinAmbientInterval, it defaults to MinAmbientInterval
+	// plus 8 seconds.
+	MaxAmbientInterval time.Duration
+	// StepDistance is the distance in blocks that the entity walks on the
+	// ground between two step sounds. If 0, it defaults to 1.
+	StepDistance float64
+
+	// ambient is the number of ticks left until the next ambient sound. If 0,
+	// the next ambient sound is yet to be scheduled.
+	ambient int64
+	// walked is the distance walked on the ground since the last step sound.
+	walked      float64
+	last        mgl64.Vec3
+	initialised bool
+}
+
+// Tick plays the ambient and step sounds of the entity passed. Tick must be
+// called every tick, with onGround specifying if the entity is currently on
+// the ground. Living entities that are dead do not play any sounds.
+func (c *SoundComputer) Tick(e world.Entity, onGround bool, tx *world.Tx) {
+	if l, ok := e.(Living); ok && l.Dead() {
+		return
+	}
+	s := c.sounds(e)
+	if c.ambient <= 0 {
+		c.ambient = c.ambientInterval()
+	}
+	if c.ambient--; c.ambient == 0 {
+		c.play(e, s.Ambient, tx)
+	}
+
+	pos := e.Position()
+	if !c.initialised {
+		c.last, c.initialised = pos, true
+	}
+	moved := math.Hypot(pos[0]-c.last[0], pos[2]-c.last[2])
+	c.last = pos
+	if !onGround || s.Step == nil {
+		return
+	}
+	if c.walked += moved; c.walked < defaultValue(c.StepDistance, 1) {
+		return
+	}
+	c.walked = 0
+	// The block walked over is found slightly below the feet of the entity,
+	// so that blocks lower than a full block, such as slabs, are found too.
+	below := cube.PosFromVec3(pos.Sub(mgl64.Vec3{0, 0.2}))
+	b := tx.Block(below)
+	if _, ok := b.(block.Air); ok {
+		return
+	}
+	c.play(e, s.Step(b), tx)
+}
+
+// Hurt plays the hurt sound of the entity passed. Like in vanilla, the next
+// ambient sound is delayed, so that it does not play right after the entity
+// is hurt.
+func (c *SoundComputer) Hurt(e world.Entity, tx *world.Tx) {
+	c.play(e, c.sounds(e).Hurt, tx)
+	c.ambient = c.ambientInterval()
+}
+
+// Death plays the death sound of the entity passed.
+func (c *SoundComputer) Death(e world.Entity, tx *world.Tx) {
+	c.play(e, c.sounds(e).Death, tx)
+}
+
+// sounds returns the Sounds played by the entity passed.
+func (c *SoundComputer) sounds(e world.Entity) Sounds {
+	if c.Sounds != nil {
+		return *c.Sounds
+	}
+	return SoundsOf(e.H().Type().EncodeEntity())
+}
+
+// ambientInterval returns a random number of ticks between the minimum and
+// maximum ambient interval.
+func (c *SoundComputer) ambientInterval() int64 {
+	minInterval := c.MinAmbientInterval
+	if minInterval <= 0 {
+		minInterval = time.Second * 4
+	}
+	maxInterval := c.MaxAmbientInterval
+	if maxInterval <= 0 || maxInterval < minInterval {
+		maxInterval = minInterval + time.Second*8
+	}
+	lo, hi := minInterval.Milliseconds()/50, maxInterval.Milliseconds()/50
+	return max(lo+rand.Int64N(hi-lo+1), 1)
+}
+
+// play plays the sound passed at the position of the entity passed. If the
+// entity is a baby, sounds of the entity type are made higher pitched.
+func (c *SoundComputer) play(e world.Entity, s world.Sound, tx *world.Tx) {
+	if s == nil {
+		return
+	}
+	if b, ok := e.(interface{ Baby() bool }); ok && b.Baby() {
+		switch so := s.(type) {
+		case sound.EntityAmbient:
+			so.Baby, s = true, so
+		case sound.EntityHurt:
+			so.Baby, s = true, so
+		case sound.EntityDeath:
+			so.Baby, s = true, so
+		case sound.EntityStep:
+			so.Baby, s = true, so
+		}
+	}
+	tx.PlaySound(e.Position(), s)
+}
@@ -0,0 +1,147 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestSoundComputerHurtSound(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: soundTestRegistry()}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	near, far := &soundTestViewer{}, &soundTestViewer{}
+	nearLoader, farLoader := world.NewLoader(2, w, near), world.NewLoader(2, w, far)
+	mustDo(t, w, func(tx *world.Tx) {
+		farLoader.Move(tx, mgl64.Vec3{1000, 64, 1000})
+		nearLoader.Load(tx, 100)
+		farLoader.Load(tx, 100)
+
+		e, _ := tx.SpawnEntity("dragonfly:sound_test_mob", mgl64.Vec3{0.5, 64, 0.5}, world.SpawnOptions{})
+		e.(*soundTestMob).Hurt(1, AttackDamageSource{})
+	})
+
+	want := sound.EntityHurt{EntityType: "dragonfly:sound_test_mob"}
+	if got := near.count(want); got != 1 {
+		t.Errorf("hurt sounds received by viewer in range = %v, want 1", got)
+	}
+	if got := far.count(want); got != 0 {
+		t.Errorf("hurt sounds received by viewer out of range = %v, want 0", got)
+	}
+
+	// Custom mobs may override the sounds of their entity type.
+	custom := sound.Custom{Name: "mob.custom.hurt"}
+	RegisterSounds("dragonfly:sound_test_mob", Sounds{Hurt: custom})
+	t.Cleanup(func() {
+		soundsMu.Lock()
+		delete(entitySounds, "dragonfly:sound_test_mob")
+		soundsMu.Unlock()
+	})
+	mustDo(t, w, func(tx *world.Tx) {
+		e, _ := tx.SpawnEntity("dragonfly:sound_test_mob", mgl64.Vec3{0.5, 64, 0.5}, world.SpawnOptions{})
+		e.(*soundTestMob).Hurt(1, AttackDamageSource{})
+	})
+	if got := near.count(custom); got != 1 {
+		t.Errorf("registered hurt sounds received by viewer in range = %v, want 1", got)
+	}
+}
+
+func TestSoundComputerAmbientInterval(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: soundTestRegistry()}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	v := &soundTestViewer{}
+	l := world.NewLoader(2, w, v)
+	mustDo(t, w, func(tx *world.Tx) {
+		l.Load(tx, 100)
+		tx.SetBlock(cube.Pos{0, 63, 0}, block.Stone{}, nil)
+		e, _ := tx.SpawnEntity("dragonfly:sound_test_mob", mgl64.Vec3{0.5, 64, 0.5}, world.SpawnOptions{})
+		mob := e.(*soundTestMob)
+		mob.sc.MinAmbientInterval, mob.sc.MaxAmbientInterval = time.Second, time.Second*2
+
+		var ticks []int
+		for i := range 1000 {
+			before := v.count(sound.EntityAmbient{EntityType: "dragonfly:sound_test_mob"})
+			mob.sc.Tick(mob, true, tx)
+			if v.count(sound.EntityAmbient{EntityType: "dragonfly:sound_test_mob"}) > before {
+				ticks = append(ticks, i+1)
+			}
+		}
+		if len(ticks) < 1000/40 {
+			t.Fatalf("ambient sounds played in 1000 ticks = %v, want at least %v", len(ticks), 1000/40)
+		}
+		prev := 0
+		for _, tick := range ticks {
+			if gap := tick - prev; gap < 20 || gap > 40 {
+				t.Errorf("ticks between ambient sounds = %v, want between 20 and 40", gap)
+			}
+			prev = tick
+		}
+		if n := v.count(sound.EntityStep{EntityType: "dragonfly:sound_test_mob", Block: block.Stone{}}); n != 0 {
+			t.Errorf("step sounds played by an entity standing still = %v, want 0", n)
+		}
+	})
+}
+
+func soundTestRegistry() world.EntityRegistry {
+	return world.EntityRegistryConfig{}.New([]world.EntityType{soundTestMobType{}})
+}
+
+type soundTestViewer struct {
+	world.NopViewer
+	sounds []world.Sound
+}
+
+func (v *soundTestViewer) ViewSound(_ mgl64.Vec3, s world.Sound) {
+	v.sounds = append(v.sounds, s)
+}
+
+// count returns the number of times the sound passed was received.
+func (v *soundTestViewer) count(s world.Sound) (n int) {
+	for _, received := range v.sounds {
+		if received == s {
+			n++
+		}
+	}
+	return n
+}
+
+// soundTestMob is a Living entity that plays its sounds using a SoundComputer. Methods not implemented by it
+// panic when called.
+type soundTestMob struct {
+	Living
+	tx     *world.Tx
+	handle *world.EntityHandle
+	d      *world.EntityData
+	sc     *SoundComputer
+}
+
+func (m *soundTestMob) H() *world.EntityHandle  { return m.handle }
+func (m *soundTestMob) Position() mgl64.Vec3    { return m.d.Pos }
+func (m *soundTestMob) Rotation() cube.Rotation { return m.d.Rot }
+func (m *soundTestMob) Close() error            { return nil }
+func (m *soundTestMob) Dead() bool              { return false }
+func (m *soundTestMob) Hurt(dmg float64, _ world.DamageSource) (float64, bool) {
+	m.sc.Hurt(m, m.tx)
+	return dmg, true
+}
+
+type soundTestMobType struct{}
+
+func (soundTestMobType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &soundTestMob{tx: tx, handle: handle, d: data, sc: data.Data.(*SoundComputer)}
+}
+
+func (soundTestMobType) EncodeEntity() string { return "dragonfly:sound_test_mob" }
+func (soundTestMobType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.8, 0.3)
+}
+func (soundTestMobType) DecodeNBT(_ map[string]any, data *world.EntityData) {
+	data.Data = &SoundComputer{}
+}
+func (soundTestMobType) EncodeNBT(*world.EntityData) map[string]any { return nil }
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// SpawnConditions holds the conditions that a position must meet for an
+// entity to spawn there. It may be used to implement world.SpawnValidator for
+// an EntityType, so that entities spawned naturally, by spawners or by spawn
+// eggs are not placed in walls or liquids. The zero value of SpawnConditions
+// describes a land mob that spawns on any solid ground at any light level.
+type SpawnConditions struct {
+	// Aquatic specifies if the entity spawns in water rather than on land.
+	// Aquatic entities require their entire hitbox to be in water, while land
+	// entities require solid ground below them and may not spawn in any
+	// liquid.
+	Aquatic bool
+	// Light returns true if the entity may spawn at the light level passed.
+	// If nil, the entity spawns at any light level.
+	Light func(level uint8) bool
+	// Biomes are the biomes that the entity may spawn in. If empty, the
+	// entity spawns in any biome.
+	Biomes []world.Biome
+}
+
+// Check checks if an entity with the bounding box passed, relative to its
+// position, may spawn with its feet at pos. The bounding box may not intersect
+// with the collision box of any block.
+func (c SpawnConditions) Check(tx *world.Tx, pos mgl64.Vec3, box cube.BBox) bool {
+	feet := cube.PosFromVec3(pos)
+	if len(c.Biomes) > 0 {
+		id := tx.Biome(feet).EncodeBiome()
+		if !slices.ContainsFunc(c.Biomes, func(b world.Biome) bool { return b.EncodeBiome() == id }) {
+			return false
+		}
+	}
+	if c.Light != nil && !c.Light(tx.Light(feet)) {
+		return false
+	}
+	if !c.Aquatic {
+		below := feet.Side(cube.FaceDown)
+		if !tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+			return false
+		}
+	}
+	box = box.Translate(pos).Grow(-1e-4)
+	for p := range cube.Range3D(cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())) {
+		for _, b := range tx.Block(p).Model().BBox(p, tx) {
+			if b.Translate(p.Vec3()).IntersectsWith(box) {
+				return false
+			}
+		}
+		l, ok := tx.Liquid(p)
+		if c.Aquatic && (!ok || l.LiquidType() != "water") || !c.Aquatic && ok {
+			return false
+		}
+	}
+	return true
+}
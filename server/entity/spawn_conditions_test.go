@@ -0,0 +1,118 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestLandMobSpawnConditions(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: spawnConditionsTestRegistry()}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		for x := 0; x < 5; x++ {
+			tx.SetBlock(cube.Pos{x, 63, 0}, block.Stone{}, nil)
+		}
+		// A one block high gap, water and lava at x=1, 2 and 3, and a wall with
+		// solid ground below it at x=4.
+		tx.SetBlock(cube.Pos{1, 65, 0}, block.Stone{}, nil)
+		tx.SetBlock(cube.Pos{4, 62, 0}, block.Stone{}, nil)
+		tx.SetBlock(cube.Pos{2, 64, 0}, block.Water{Still: true, Depth: 8}, nil)
+		tx.SetBlock(cube.Pos{3, 64, 0}, block.Lava{Still: true, Depth: 8}, nil)
+
+		for _, test := range []struct {
+			name string
+			pos  mgl64.Vec3
+			want bool
+		}{
+			{name: "on solid ground", pos: mgl64.Vec3{0.5, 64, 0.5}, want: true},
+			{name: "in a one block gap", pos: mgl64.Vec3{1.5, 64, 0.5}},
+			{name: "in water", pos: mgl64.Vec3{2.5, 64, 0.5}},
+			{name: "in lava", pos: mgl64.Vec3{3.5, 64, 0.5}},
+			{name: "in a wall", pos: mgl64.Vec3{4.5, 63, 0.5}},
+			{name: "in the air", pos: mgl64.Vec3{0.5, 70, 0.5}},
+			{name: "below the world", pos: mgl64.Vec3{0.5, -100, 0.5}},
+		} {
+			_, spawned := tx.SpawnEntity("dragonfly:spawn_conditions_test_land", test.pos, world.SpawnOptions{CheckConditions: true})
+			if spawned != test.want {
+				t.Errorf("land mob spawned %v = %v, want %v", test.name, spawned, test.want)
+			}
+		}
+		// Conditions are only checked if requested, such as for spawn eggs.
+		if _, ok := tx.SpawnEntity("dragonfly:spawn_conditions_test_land", mgl64.Vec3{2.5, 64, 0.5}, world.SpawnOptions{}); !ok {
+			t.Errorf("land mob not spawned in water without checking conditions")
+		}
+		ctx := &item.UseContext{}
+		if (item.SpawnEgg{Entity: "dragonfly:spawn_conditions_test_land"}).UseOnBlock(cube.Pos{1, 63, 0}, cube.FaceUp, mgl64.Vec3{}, tx, nil, ctx) {
+			t.Errorf("spawn egg spawned land mob in a one block gap")
+		}
+	})
+}
+
+func TestAquaticMobSpawnConditions(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: spawnConditionsTestRegistry()}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		tx.SetBlock(cube.Pos{0, 63, 0}, block.Stone{}, nil)
+		tx.SetBlock(cube.Pos{1, 63, 0}, block.Water{Still: true, Depth: 8}, nil)
+		tx.SetBlock(cube.Pos{2, 63, 0}, block.Lava{Still: true, Depth: 8}, nil)
+
+		for _, test := range []struct {
+			name string
+			pos  mgl64.Vec3
+			want bool
+		}{
+			{name: "in water", pos: mgl64.Vec3{1.5, 63.2, 0.5}, want: true},
+			{name: "on solid ground", pos: mgl64.Vec3{0.5, 64, 0.5}},
+			{name: "in lava", pos: mgl64.Vec3{2.5, 63.2, 0.5}},
+			{name: "partially above water", pos: mgl64.Vec3{1.5, 63.8, 0.5}},
+		} {
+			_, spawned := tx.SpawnEntity("dragonfly:spawn_conditions_test_aquatic", test.pos, world.SpawnOptions{CheckConditions: true})
+			if spawned != test.want {
+				t.Errorf("aquatic mob spawned %v = %v, want %v", test.name, spawned, test.want)
+			}
+		}
+	})
+}
+
+func spawnConditionsTestRegistry() world.EntityRegistry {
+	return world.EntityRegistryConfig{}.New([]world.EntityType{
+		spawnConditionsTestType{name: "dragonfly:spawn_conditions_test_land", box: cube.Box(-0.3, 0, -0.3, 0.3, 1.9, 0.3)},
+		spawnConditionsTestType{name: "dragonfly:spawn_conditions_test_aquatic", box: cube.Box(-0.2, 0, -0.2, 0.2, 0.4, 0.2), conditions: SpawnConditions{Aquatic: true}},
+	})
+}
+
+// spawnConditionsTestType is an EntityType that only spawns at positions that meet its SpawnConditions.
+type spawnConditionsTestType struct {
+	name       string
+	box        cube.BBox
+	conditions SpawnConditions
+}
+
+func (t spawnConditionsTestType) CanSpawnAt(tx *world.Tx, pos mgl64.Vec3) bool {
+	return t.conditions.Check(tx, pos, t.box)
+}
+
+func (t spawnConditionsTestType) Open(_ *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &spawnConditionsTestEntity{handle: handle, d: data}
+}
+func (t spawnConditionsTestType) EncodeEntity() string                      { return t.name }
+func (t spawnConditionsTestType) BBox(world.Entity) cube.BBox               { return t.box }
+func (spawnConditionsTestType) DecodeNBT(map[string]any, *world.EntityData) {}
+func (spawnConditionsTestType) EncodeNBT(*world.EntityData) map[string]any  { return nil }
+
+type spawnConditionsTestEntity struct {
+	handle *world.EntityHandle
+	d      *world.EntityData
+}
+
+func (e *spawnConditionsTestEntity) H() *world.EntityHandle  { return e.handle }
+func (e *spawnConditionsTestEntity) Position() mgl64.Vec3    { return e.d.Pos }
+func (e *spawnConditionsTestEntity) Rotation() cube.Rotation { return e.d.Rot }
+func (e *spawnConditionsTestEntity) Close() error            { return nil }
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestSpawnEggPosition(t *testing.T) {
+	pos := cube.Pos{0, 63, 0}
+	tests := []struct {
+		name  string
+		block world.Block
+		face  cube.Face
+		want  mgl64.Vec3
+	}{
+		{name: "top", block: block.Stone{}, face: cube.FaceUp, want: mgl64.Vec3{0.5, 64, 0.5}},
+		{name: "side", block: block.Stone{}, face: cube.FaceEast, want: mgl64.Vec3{1.5, 63, 0.5}},
+		{name: "slab", block: block.Slab{Block: block.Stone{}}, face: cube.FaceUp, want: mgl64.Vec3{0.5, 63.5, 0.5}},
+		{name: "grass", block: block.ShortGrass{}, face: cube.FaceUp, want: mgl64.Vec3{0.5, 63, 0.5}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := world.Config{Entities: DefaultRegistry}.New()
+			t.Cleanup(func() { _ = w.Close() })
+
+			mustDo(t, w, func(tx *world.Tx) {
+				tx.SetBlock(pos, test.block, nil)
+				ctx := &item.UseContext{}
+				if !(item.SpawnEgg{Entity: "dragonfly:text"}).UseOnBlock(pos, test.face, mgl64.Vec3{}, tx, nil, ctx) {
+					t.Fatal("spawn egg could not be used on block")
+				}
+				if ctx.CountSub != 1 {
+					t.Errorf("using spawn egg subtracted %d items, want 1", ctx.CountSub)
+				}
+				entities := slices.Collect(tx.Entities())
+				if len(entities) != 1 {
+					t.Fatalf("spawn egg spawned %d entities, want 1", len(entities))
+				}
+				if e := entities[0]; e.H().Type() != TextType || e.Position() != test.want {
+					t.Errorf("spawn egg spawned %v at %v, want %v at %v", e.H().Type().EncodeEntity(), e.Position(), TextType.EncodeEntity(), test.want)
+				}
+			})
+		})
+	}
+}
+
+func TestSpawnEggUnknownEntity(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	pos := cube.Pos{0, 63, 0}
+	mustDo(t, w, func(tx *world.Tx) {
+		tx.SetBlock(pos, block.Stone{}, nil)
+		ctx := &item.UseContext{}
+		if (item.SpawnEgg{Entity: "minecraft:unknown"}).UseOnBlock(pos, cube.FaceUp, mgl64.Vec3{}, tx, nil, ctx) {
+			t.Error("spawn egg of unknown entity type was used")
+		}
+		if ctx.CountSub != 0 || len(slices.Collect(tx.Entities())) != 0 {
+			t.Error("spawn egg of unknown entity type spawned an entity")
+		}
+	})
+}
+
+func TestSpawnEggOnEntity(t *testing.T) {
+	w := world.Config{Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		text := tx.AddEntity(NewText("parent", mgl64.Vec3{0.5, 64, 0.5}))
+		if (item.SpawnEgg{Entity: "minecraft:boat"}).UseOnEntity(text, tx, nil, &item.UseContext{}) {
+			t.Error("spawn egg was used on entity of a different type")
+		}
+		if !(item.SpawnEgg{Entity: "dragonfly:text"}).UseOnEntity(text, tx, nil, &item.UseContext{}) {
+			t.Fatal("spawn egg could not be used on entity of the same type")
+		}
+		if n := len(slices.Collect(tx.Entities())); n != 2 {
+			t.Errorf("world holds %d entities after using spawn egg on entity, want 2", n)
+		}
+	})
+}
@@ -24,27 +24,35 @@ type SplashableEntity interface {
 	Splash(tx *world.Tx, pos mgl64.Vec3)
 }
 
+// potionSplashRadius is the maximum distance from the impact of a splash potion
+// at which entities are affected by its effects.
+const potionSplashRadius = 4.0
+
 // potionSplash returns a function that creates a potion splash with a specific
-// duration multiplier and potion type.
+// duration multiplier and potion type. Entities within potionSplashRadius of
+// the impact receive the effects of the potion, with their duration and
+// potency scaled down linearly with the distance to the impact. An entity hit
+// directly receives the full effects. Lingering potions do not affect entities
+// on impact, but leave an area effect cloud instead.
 func potionSplash(durMul float64, pot potion.Potion, linger bool) func(e *Ent, tx *world.Tx, res trace.Result) {
 	return func(e *Ent, tx *world.Tx, res trace.Result) {
 		pos := e.Position()
 		effects := pot.Effects()
-		box := e.H().Type().BBox(e).Translate(pos)
+		box := world.EntityBBox(e).Translate(pos)
 
-		if len(effects) > 0 {
+		if len(effects) > 0 && !linger {
 			for otherE := range filterLiving(tx.EntitiesWithin(box.GrowVec3(mgl64.Vec3{8.25, 4.25, 8.25}))) {
 				otherPos := otherE.Position()
-				if !otherE.H().Type().BBox(otherE).Translate(otherPos).IntersectsWith(box.GrowVec3(mgl64.Vec3{4.125, 2.125, 4.125})) {
+				if !world.EntityBBox(otherE).Translate(otherPos).IntersectsWith(box.GrowVec3(mgl64.Vec3{4.125, 2.125, 4.125})) {
 					continue
 				}
 
 				dist := otherPos.Sub(pos).Len()
-				if dist > 4 {
+				if dist > potionSplashRadius {
 					continue
 				}
 
-				f := 1 - dist/4
+				f := 1 - dist/potionSplashRadius
 				if entityResult, ok := res.(trace.EntityResult); ok && entityResult.Entity().H() == otherE.H() {
 					f = 1
 				}
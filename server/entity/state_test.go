@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestBatchedEntityState(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry, BatchEntityState: true}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	v := &stateTestViewer{}
+	l := world.NewLoader(2, w, v)
+	pos := mgl64.Vec3{0.5, 64, 0.5}
+	mustDo(t, w, func(tx *world.Tx) {
+		l.Move(tx, pos)
+		l.Load(tx, 16)
+		e := tx.AddEntity(NewText("", pos)).(*Ent)
+
+		e.SetOnFire(time.Second * 5)
+		e.SetNameTag("burning")
+		e.SetGlowing(true)
+		if len(v.states) != 0 {
+			t.Fatalf("viewed states before the end of the tick = %v, want none", v.states)
+		}
+	})
+	w.AdvanceTick()
+
+	want := stateTestState{name: "burning", onFire: true, glowing: true}
+	if len(v.states) != 1 || v.states[0] != want {
+		t.Fatalf("viewed states after tick = %v, want one state %v with all changes", v.states, want)
+	}
+
+	// A tick without changes does not update viewers again.
+	w.AdvanceTick()
+	if len(v.states) != 1 {
+		t.Errorf("viewed states after tick without changes = %v, want no new state", v.states)
+	}
+}
+
+func TestUnbatchedEntityState(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	v := &stateTestViewer{}
+	l := world.NewLoader(2, w, v)
+	pos := mgl64.Vec3{0.5, 64, 0.5}
+	mustDo(t, w, func(tx *world.Tx) {
+		l.Move(tx, pos)
+		l.Load(tx, 16)
+		e := tx.AddEntity(NewText("", pos)).(*Ent)
+
+		e.SetNameTag("named")
+		e.SetGlowing(true)
+	})
+	if len(v.states) != 2 {
+		t.Fatalf("viewed states = %v, want one state for every change", v.states)
+	}
+}
+
+// stateTestState holds the state of an Ent at the time it was viewed.
+type stateTestState struct {
+	name            string
+	onFire, glowing bool
+}
+
+// stateTestViewer records the state of entities every time it is viewed.
+type stateTestViewer struct {
+	world.NopViewer
+	states []stateTestState
+}
+
+func (v *stateTestViewer) ViewEntityState(e world.Entity) {
+	ent := e.(*Ent)
+	v.states = append(v.states, stateTestState{name: ent.NameTag(), onFire: ent.OnFireDuration() > 0, glowing: ent.Glowing()})
+}
@@ -0,0 +1,149 @@
+package entity_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// teleportTestWorld returns a world with a stone floor at y=63 between -16 and 16 on the X and Z axis and a
+// player standing on it at the origin.
+func teleportTestWorld(t *testing.T) (*world.World, *world.EntityHandle) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	doTx(t, w, func(tx *world.Tx) {
+		for pos := range cube.Range3D(cube.Pos{-16, 63, -16}, cube.Pos{16, 63, 16}) {
+			tx.SetBlock(pos, block.Stone{}, nil)
+		}
+	})
+	return w, spawnTestPlayer(t, w, player.Config{})
+}
+
+func TestChorusFruitTeleportSearchBounds(t *testing.T) {
+	w, handle := teleportTestWorld(t)
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		// Block off part of the floor so that some positions tried are inside blocks and must instead be moved
+		// up onto them.
+		for pos := range cube.Range3D(cube.Pos{-4, 64, -4}, cube.Pos{-1, 65, 4}) {
+			tx.SetBlock(pos, block.Stone{}, nil)
+		}
+		box := p.H().Type().BBox(p)
+		for range 500 {
+			pos, ok := item.RandomTeleportConfig{Radius: 4, Attempts: 64}.Position(tx, p.Position(), box)
+			if !ok {
+				t.Fatalf("no safe position found within 4 blocks of a player on a floor")
+			}
+			if d := pos.Sub(p.Position()); math.Abs(d[0]) > 4 || math.Abs(d[2]) > 4 {
+				t.Fatalf("random teleport position %v is more than 4 blocks away from %v", pos, p.Position())
+			}
+			if onBlocks := pos[0] < 0 && pos[0] > -4 && pos[1] == 66; (pos[1] != 64 && !onBlocks) || !item.SafeLanding(tx, pos, box) {
+				t.Fatalf("random teleport position %v is not a safe position on the floor or on top of the blocks", pos)
+			}
+		}
+
+		// The floor is 12 blocks below the player at y=76, which is out of the default radius of 8 blocks.
+		if pos, ok := (item.RandomTeleportConfig{}).Position(tx, mgl64.Vec3{0.5, 76, 0.5}, box); ok {
+			t.Errorf("random teleport position %v found with no ground within the radius", pos)
+		}
+		if _, ok := (item.RandomTeleportConfig{}).Position(tx, mgl64.Vec3{0.5, 72, 0.5}, box); !ok {
+			t.Errorf("no random teleport position found with the ground 8 blocks below")
+		}
+	})
+}
+
+func TestChorusFruitTeleportsConsumer(t *testing.T) {
+	w, handle := teleportTestWorld(t)
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		start := p.Position()
+		item.ChorusFruit{}.Consume(tx, p)
+		if d := p.Position().Sub(start); d.Len() == 0 || math.Abs(d[0]) > 8 || math.Abs(d[2]) > 8 {
+			t.Errorf("player moved %v after eating chorus fruit, want a teleport within 8 blocks", d)
+		}
+		if !p.HasCooldown(item.ChorusFruit{}) {
+			t.Errorf("chorus fruit has no cooldown after being eaten")
+		}
+	})
+}
+
+func TestEnderPearlImpactTeleport(t *testing.T) {
+	w, handle := teleportTestWorld(t)
+	var pearl *world.EntityHandle
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		// Prevent the player from regenerating the health lost from the teleport.
+		p.SetFood(10)
+		pearl = entity.NewEnderPearl(world.EntitySpawnOpts{Position: mgl64.Vec3{8.5, 66, 0.5}, Velocity: mgl64.Vec3{0, -0.5, 0}}, p)
+		tx.AddEntity(pearl)
+	})
+	advanceTicks(w, 20)
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		if _, ok := pearl.Entity(tx); ok {
+			t.Fatalf("ender pearl was not removed after hitting the floor")
+		}
+		if pos := p.Position(); !mgl64.FloatEqualThreshold(pos[0], 8.5, 0.01) || !mgl64.FloatEqualThreshold(pos[1], 64, 0.01) {
+			t.Errorf("player position after ender pearl impact = %v, want it on the floor at the impact", pos)
+		}
+		if p.Health() != 15 {
+			t.Errorf("player health after ender pearl teleport = %v, want 15", p.Health())
+		}
+	})
+}
+
+func TestLongTeleportReloadsViewers(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	handle := spawnTestPlayer(t, w, player.Config{TeleportReloadDistance: 32})
+
+	v := &teleportTestViewer{}
+	l := world.NewLoader(4, w, v)
+	doTx(t, w, func(tx *world.Tx) {
+		l.Move(tx, mgl64.Vec3{0.5, 64, 0.5})
+		l.Load(tx, 1000)
+	})
+	t.Cleanup(func() { doTx(t, w, l.Close) })
+	if !slices.Equal(v.events, []string{"view"}) {
+		t.Fatalf("viewer events after loading the player = %v, want [view]", v.events)
+	}
+
+	v.events = nil
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.Teleport(mgl64.Vec3{10.5, 64, 0.5})
+	})
+	if !slices.Equal(v.events, []string{"teleport"}) {
+		t.Errorf("viewer events after a short teleport = %v, want [teleport]", v.events)
+	}
+
+	v.events = nil
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.Teleport(mgl64.Vec3{45.5, 64, 0.5})
+	})
+	advanceTicks(w, 2)
+	if !slices.Equal(v.events, []string{"hide", "view"}) {
+		t.Errorf("viewer events after a long teleport = %v, want [hide view]", v.events)
+	}
+}
+
+// teleportTestViewer records how players are shown to it.
+type teleportTestViewer struct {
+	world.NopViewer
+	events []string
+}
+
+func (v *teleportTestViewer) ViewEntity(e world.Entity) { v.record(e, "view") }
+func (v *teleportTestViewer) HideEntity(e world.Entity) { v.record(e, "hide") }
+func (v *teleportTestViewer) ViewEntityTeleport(e world.Entity, _ mgl64.Vec3) {
+	v.record(e, "teleport")
+}
+
+func (v *teleportTestViewer) record(e world.Entity, event string) {
+	if _, ok := e.(*player.Player); ok {
+		v.events = append(v.events, event)
+	}
+}
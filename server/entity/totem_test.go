@@ -0,0 +1,83 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestTotemPreventsLethalDamage(t *testing.T) {
+	for _, offHand := range []bool{false, true} {
+		w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+		t.Cleanup(func() { _ = w.Close() })
+
+		handle := spawnTestPlayer(t, w, player.Config{GameMode: world.GameModeSurvival})
+		withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+			if offHand {
+				p.SetHeldItems(item.Stack{}, item.NewStack(item.Totem{}, 1))
+			} else {
+				p.SetHeldItems(item.NewStack(item.Totem{}, 1), item.Stack{})
+			}
+			p.AddEffect(effect.New(effect.Poison, 1, time.Minute))
+			p.AddEffect(effect.New(effect.Speed, 1, time.Minute))
+
+			if _, vulnerable := p.Hurt(100, entity.AttackDamageSource{}); vulnerable {
+				t.Errorf("lethal damage with a totem in the off hand (%v) was dealt", offHand)
+			}
+			if p.Dead() || p.Health() != 1 {
+				t.Fatalf("player saved by a totem in the off hand (%v): dead %v with health %v, want alive with health 1", offHand, p.Dead(), p.Health())
+			}
+			if main, off := p.HeldItems(); !main.Empty() || !off.Empty() {
+				t.Errorf("held items after using a totem in the off hand (%v) = %v, %v, want the totem consumed", offHand, main, off)
+			}
+			if _, ok := p.Effect(effect.Poison); ok {
+				t.Errorf("player still has Poison after using a totem")
+			}
+			if _, ok := p.Effect(effect.Speed); !ok {
+				t.Errorf("player lost Speed after using a totem, want only harmful effects cleared")
+			}
+			for _, want := range []effect.Effect{
+				effect.New(effect.Regeneration, 2, time.Second*40),
+				effect.New(effect.FireResistance, 1, time.Second*40),
+				effect.New(effect.Absorption, 2, time.Second*5),
+			} {
+				if e, ok := p.Effect(want.Type()); !ok || e.Level() != want.Level() || e.Duration() != want.Duration() {
+					t.Errorf("player effect %T after using a totem = %v, want level %v for %v", want.Type(), e, want.Level(), want.Duration())
+				}
+			}
+		})
+	}
+}
+
+func TestVoidDamageBypassesTotem(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	handle := spawnTestPlayer(t, w, player.Config{GameMode: world.GameModeSurvival})
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.SetHeldItems(item.NewStack(item.Totem{}, 1), item.NewStack(item.Totem{}, 1))
+		p.Hurt(100, entity.VoidDamageSource{})
+		if !p.Dead() {
+			t.Fatalf("player survived lethal void damage while holding totems")
+		}
+	})
+}
+
+func TestDisabledTotem(t *testing.T) {
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	handle := spawnTestPlayer(t, w, player.Config{GameMode: world.GameModeSurvival, Totem: player.TotemConfig{Disabled: true}})
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.SetHeldItems(item.Stack{}, item.NewStack(item.Totem{}, 1))
+		p.Hurt(100, entity.AttackDamageSource{})
+		if !p.Dead() {
+			t.Errorf("player survived lethal damage with totems disabled")
+		}
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/portal"
+	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
 )
 
@@ -29,6 +30,10 @@ type PortalTravelComputer struct {
 	// Cooldown is how long the entity must wait after a travel attempt before it may travel again. Non-player
 	// entities use 15 seconds (300 ticks).
 	Cooldown time.Duration
+	// Delay is how long the entity must stand in a portal before it travels
+	// through it, if travel is not instantaneous. If 0, a delay of 4 seconds
+	// is used.
+	Delay time.Duration
 
 	mu             sync.Mutex
 	start          time.Time
@@ -67,23 +72,38 @@ type portalTravelHandler interface {
 	HandlePortalTravel(source, destination world.Dimension)
 }
 
-// EnterPortal handles an entity touching a portal block. It teleports the entity to the other dimension after four
-// seconds or instantly if instantaneous is true.
+// EnterPortal handles an entity touching a portal block. It teleports the entity to the other dimension after the
+// Delay of the PortalTravelComputer or instantly if instantaneous is true.
 func (t *PortalTravelComputer) EnterPortal(e Traveller, tx *world.Tx, target world.Dimension) {
-	if destination := t.enterPortal(tx, target); destination != nil {
+	awaiting := t.awaiting()
+	destination := t.enterPortal(tx, target)
+	if destination != nil {
 		t.travelQueued(e, tx, destination)
+	} else if !awaiting && t.awaiting() {
+		tx.PlaySound(e.Position(), sound.PortalTrigger{})
 	}
 }
 
 // queuePortalTravel records portal travel to be completed by a terminal Ent tick step.
-func (t *PortalTravelComputer) queuePortalTravel(tx *world.Tx, target world.Dimension) {
-	if destination := t.enterPortal(tx, target); destination != nil {
+func (t *PortalTravelComputer) queuePortalTravel(e world.Entity, tx *world.Tx, target world.Dimension) {
+	awaiting := t.awaiting()
+	destination := t.enterPortal(tx, target)
+	if destination != nil {
 		t.mu.Lock()
 		t.pending = destination
 		t.mu.Unlock()
+	} else if !awaiting && t.awaiting() {
+		tx.PlaySound(e.Position(), sound.PortalTrigger{})
 	}
 }
 
+// awaiting reports whether the entity is currently waiting in a portal to travel through it.
+func (t *PortalTravelComputer) awaiting() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.awaitingTravel
+}
+
 // enterPortal updates portal contact state and returns the destination world if travel should start.
 func (t *PortalTravelComputer) enterPortal(tx *world.Tx, target world.Dimension) *world.World {
 	source := tx.World()
@@ -103,7 +123,7 @@ func (t *PortalTravelComputer) enterPortal(tx *world.Tx, target world.Dimension)
 		t.mu.Unlock()
 		return nil
 	}
-	travelNow := t.instantaneous(source.Dimension(), target) || (t.awaitingTravel && time.Since(t.start) >= time.Second*4)
+	travelNow := t.instantaneous(source.Dimension(), target) || (t.awaitingTravel && time.Since(t.start) >= t.delay())
 	if !travelNow && !t.awaitingTravel {
 		t.start, t.awaitingTravel = time.Now(), true
 	}
@@ -115,6 +135,14 @@ func (t *PortalTravelComputer) enterPortal(tx *world.Tx, target world.Dimension)
 	return nil
 }
 
+// delay returns how long the entity must stand in a portal before travelling through it.
+func (t *PortalTravelComputer) delay() time.Duration {
+	if t.Delay == 0 {
+		return time.Second * 4
+	}
+	return t.Delay
+}
+
 func (t *PortalTravelComputer) instantaneous(source, target world.Dimension) bool {
 	return t.Instantaneous != nil && t.Instantaneous(source, target)
 }
@@ -229,6 +257,7 @@ func (t *PortalTravelComputer) transfer(handle *world.EntityHandle, source, dest
 		if e, ok := tx.AddEntityAt(handle, spawn).(Traveller); ok {
 			t.finishTravel(e, spawn, sourceDim, destinationDim)
 		}
+		tx.PlaySound(spawn, sound.PortalTravel{})
 		return true, nil
 	})
 	if err != nil {
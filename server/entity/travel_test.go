@@ -301,6 +301,25 @@ func TestPortalTravelComputerDelayedTravel(t *testing.T) {
 	})
 }
 
+func TestPortalTravelComputerCustomDelay(t *testing.T) {
+	overworld, nether := portalWorlds(t)
+
+	tc := &PortalTravelComputer{Delay: time.Second}
+	mustDo(t, overworld, func(tx *world.Tx) {
+		if destination := tc.enterPortal(tx, world.Nether); destination != nil {
+			t.Fatal("enterPortal() started travel before the portal timer finished")
+		}
+		tc.start = time.Now().Add(-time.Second / 2)
+		if destination := tc.enterPortal(tx, world.Nether); destination != nil {
+			t.Fatal("enterPortal() started travel before the custom delay passed")
+		}
+		tc.start = time.Now().Add(-time.Second)
+		if destination := tc.enterPortal(tx, world.Nether); destination != nether {
+			t.Fatalf("enterPortal() destination after custom delay = %v, want the Nether", destination)
+		}
+	})
+}
+
 func TestPortalTravelComputerCooldown(t *testing.T) {
 	overworld, nether := portalWorlds(t)
 	_ = nether
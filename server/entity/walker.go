@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Walker moves a Living entity towards a position in a straight line, without
+// any pathfinding. This is sufficient for simple behaviour, such as following
+// or fleeing from another entity. A Walker jumps onto blocks that are at most
+// one block higher than the entity, stops in front of obstacles that are
+// higher and refuses to walk off ledges that the entity would fall down
+// further than its MaxDrop.
+// The zero value of a Walker is ready to use.
+type Walker struct {
+	// StopDistance is the horizontal distance from the target within which
+	// the entity stops moving. If 0, it defaults to 0.5.
+	StopDistance float64
+	// MaxDrop is the maximum height that the entity may drop down from a
+	// ledge. If 0, it defaults to 3.
+	MaxDrop float64
+	// JumpVelocity is the vertical velocity that the entity jumps with to get
+	// onto a block. It must be high enough for the entity to clear a full
+	// block with its gravity and drag. If 0, it defaults to 0.5, which
+	// suffices for a gravity of 0.08 and a drag of 0.02.
+	JumpVelocity float64
+}
+
+const (
+	// walkLookAhead is the horizontal distance in front of an entity at which
+	// a Walker checks for obstacles and ledges.
+	walkLookAhead = 0.5
+	// walkMaxStep is the maximum height of an obstacle relative to the feet
+	// of an entity that a Walker jumps onto.
+	walkMaxStep = 1
+)
+
+// MoveTowards moves the Living entity passed towards target using the zero
+// value of a Walker. See Walker.MoveTowards for more information.
+func MoveTowards(l Living, tx *world.Tx, target mgl64.Vec3, speed float64) bool {
+	return Walker{}.MoveTowards(l, tx, target, speed)
+}
+
+// MoveTowards sets the horizontal velocity of the Living entity passed so
+// that it moves towards target with its speed multiplied by speed. If a block
+// of at most one block high is in the way of the entity and there is room
+// above it, the entity jumps onto it, as long as it is on the ground.
+// MoveTowards stops the entity and returns false if it is within the
+// StopDistance of target, if it is stuck against an obstacle that it cannot
+// jump onto or if moving any further would make it walk off a ledge.
+// The velocity set is applied by the movement of the entity, typically
+// computed using a MovementComputer.
+func (w Walker) MoveTowards(l Living, tx *world.Tx, target mgl64.Vec3, speed float64) bool {
+	pos := l.Position()
+	delta := target.Sub(pos)
+	delta[1] = 0
+	if delta.Len() <= defaultValue(w.StopDistance, goalArriveDistance) {
+		stopMoving(l)
+		return false
+	}
+	dir, maxDrop := delta.Normalize(), defaultValue(w.MaxDrop, 3)
+
+	box := world.EntityBBox(l).Translate(pos)
+	ahead := box.Translate(dir.Mul(walkLookAhead))
+	blocks := blockBBoxsAround(tx, box.Extend(dir.Mul(walkLookAhead)).Extend(mgl64.Vec3{0, -maxDrop}).Extend(mgl64.Vec3{0, walkMaxStep}))
+
+	var height float64
+	for _, bb := range blocks {
+		if bb.IntersectsWith(ahead) {
+			height = max(height, bb.Max()[1]-pos[1])
+		}
+	}
+	vel := dir.Mul(l.Speed() * speed)
+	vel[1] = l.Velocity()[1]
+	if height > 0 {
+		if height > walkMaxStep+epsilon || !walkFree(blocks, ahead.Translate(mgl64.Vec3{0, height})) {
+			// The obstacle is either too high to jump onto or there is no room
+			// above it: The entity is stuck against a wall.
+			stopMoving(l)
+			return false
+		}
+		if vel[1] <= 0 && walkOnGround(blocks, box) {
+			vel[1] = defaultValue(w.JumpVelocity, 0.5)
+		}
+	} else if min := ahead.Min(); walkFree(blocks, cube.Box(min[0], pos[1]-maxDrop, min[2], ahead.Max()[0], pos[1], ahead.Max()[2])) {
+		// There is no ground in front of the entity within its MaxDrop, so it
+		// would walk off a ledge.
+		stopMoving(l)
+		return false
+	}
+	l.SetVelocity(vel)
+	return true
+}
+
+// walkFree checks if none of the block bounding boxes passed intersect with
+// box.
+func walkFree(blocks []cube.BBox, box cube.BBox) bool {
+	for _, bb := range blocks {
+		if bb.IntersectsWith(box) {
+			return false
+		}
+	}
+	return true
+}
+
+// walkOnGround checks if an entity with the bounding box passed is standing
+// on one of the block bounding boxes passed.
+func walkOnGround(blocks []cube.BBox, box cube.BBox) bool {
+	min, max := box.Min(), box.Max()
+	return !walkFree(blocks, cube.Box(min[0], min[1]-0.05, min[2], max[0], min[1], max[2]))
+}
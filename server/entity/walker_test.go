@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"math"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestWalkerStepsUpOntoLedge(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		for x := 0; x < 10; x++ {
+			tx.SetBlock(cube.Pos{x, 63, 0}, block.Stone{}, nil)
+			if x >= 5 {
+				tx.SetBlock(cube.Pos{x, 64, 0}, block.Stone{}, nil)
+			}
+		}
+		m := tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 64, 0.5}}.New(walkerTestMobType{}, walkerTestMobConfig{})).(*walkerTestMob)
+		target := mgl64.Vec3{8.5, 65, 0.5}
+		for range 200 {
+			Walker{}.MoveTowards(m, tx, target, 1)
+			m.move(tx)
+		}
+		if pos := m.Position(); pos[1] != 65 || horizontalDistance(pos, target) > 0.5 {
+			t.Errorf("position after walking towards ledge = %v, want on top of the ledge near %v", pos, target)
+		}
+		if (Walker{}).MoveTowards(m, tx, target, 1) {
+			t.Errorf("walker moved entity within stopping distance of its target")
+		}
+
+		// A wall of two blocks high cannot be jumped onto.
+		tx.SetBlock(cube.Pos{7, 66, 0}, block.Stone{}, nil)
+		tx.SetBlock(cube.Pos{7, 67, 0}, block.Stone{}, nil)
+		m.d.Pos, m.vel = mgl64.Vec3{5.5, 65, 0.5}, mgl64.Vec3{}
+		for range 100 {
+			Walker{}.MoveTowards(m, tx, target, 1)
+			m.move(tx)
+		}
+		if pos := m.Position(); pos[0] >= 6.7 || pos[1] != 65 {
+			t.Errorf("position after walking towards wall = %v, want in front of the wall", pos)
+		}
+	})
+}
+
+func TestWalkerStopsAtLedge(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	mustDo(t, w, func(tx *world.Tx) {
+		for x := 0; x < 5; x++ {
+			tx.SetBlock(cube.Pos{x, 63, 0}, block.Stone{}, nil)
+		}
+		// A drop of 2 blocks is within the maximum drop of 3 blocks and is
+		// walked off of.
+		tx.SetBlock(cube.Pos{-1, 61, 0}, block.Stone{}, nil)
+		tx.SetBlock(cube.Pos{-2, 61, 0}, block.Stone{}, nil)
+
+		m := tx.AddEntity(world.EntitySpawnOpts{Position: mgl64.Vec3{2.5, 64, 0.5}}.New(walkerTestMobType{}, walkerTestMobConfig{})).(*walkerTestMob)
+		for range 100 {
+			Walker{}.MoveTowards(m, tx, mgl64.Vec3{10.5, 64, 0.5}, 1)
+			m.move(tx)
+		}
+		if pos := m.Position(); pos[0] <= 4 || pos[0] >= 5.3 || pos[1] != 64 {
+			t.Errorf("position after walking towards ledge = %v, want at the edge of the ledge", pos)
+		}
+		if (Walker{}).MoveTowards(m, tx, mgl64.Vec3{10.5, 64, 0.5}, 1) || math.Abs(m.vel[0]) > epsilon {
+			t.Errorf("walker moved entity off a ledge with velocity %v, want it to stop", m.vel)
+		}
+
+		for range 100 {
+			Walker{}.MoveTowards(m, tx, mgl64.Vec3{-1.5, 62, 0.5}, 1)
+			m.move(tx)
+		}
+		if pos := m.Position(); pos[1] != 62 {
+			t.Errorf("position after walking towards small drop = %v, want at the bottom of the drop", pos)
+		}
+	})
+}
+
+type walkerTestMobConfig struct{}
+
+func (walkerTestMobConfig) Apply(*world.EntityData) {}
+
+// walkerTestMob is a Living entity that moves with the gravity and drag of
+// most mobs. Methods not implemented by it panic when called.
+type walkerTestMob struct {
+	Living
+	handle *world.EntityHandle
+	d      *world.EntityData
+	mc     MovementComputer
+	vel    mgl64.Vec3
+}
+
+func (m *walkerTestMob) H() *world.EntityHandle     { return m.handle }
+func (m *walkerTestMob) Position() mgl64.Vec3       { return m.d.Pos }
+func (m *walkerTestMob) Rotation() cube.Rotation    { return m.d.Rot }
+func (m *walkerTestMob) Speed() float64             { return 0.1 }
+func (m *walkerTestMob) Velocity() mgl64.Vec3       { return m.vel }
+func (m *walkerTestMob) SetVelocity(vel mgl64.Vec3) { m.vel = vel }
+func (m *walkerTestMob) Tick(*world.Tx, int64)      {}
+func (m *walkerTestMob) Close() error               { return nil }
+
+// move moves the mob by its velocity for a single tick.
+func (m *walkerTestMob) move(tx *world.Tx) {
+	movement := m.mc.TickMovement(m, m.d.Pos, m.vel, m.d.Rot, tx)
+	m.d.Pos, m.vel = movement.Position(), movement.Velocity()
+}
+
+type walkerTestMobType struct{}
+
+func (walkerTestMobType) Open(_ *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &walkerTestMob{handle: handle, d: data, mc: MovementComputer{Gravity: 0.08, Drag: 0.02}}
+}
+
+func (walkerTestMobType) EncodeEntity() string { return "minecraft:walker_test_mob" }
+func (walkerTestMobType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.95, 0.3)
+}
+func (walkerTestMobType) DecodeNBT(map[string]any, *world.EntityData) {}
+func (walkerTestMobType) EncodeNBT(*world.EntityData) map[string]any  { return nil }
@@ -0,0 +1,291 @@
+package entity
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NewZombie creates a new zombie entity. Zombies attack nearby players and
+// may be equipped with armour and items, which they drop by chance when they
+// die. Zombies spawned without any saved equipment are equipped with random
+// armour on their first tick, depending on the difficulty of the world.
+func NewZombie(opts world.EntitySpawnOpts) *world.EntityHandle {
+	return opts.New(ZombieType, zombieConf)
+}
+
+var zombieConf = ZombieBehaviourConfig{
+	Gravity: 0.08,
+	Drag:    0.02,
+	Speed:   0.1,
+}
+
+// ZombieType is a world.EntityType implementation for Zombie.
+var ZombieType zombieType
+
+type zombieType struct{}
+
+func (t zombieType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return &Zombie{Ent: &Ent{tx: tx, handle: handle, data: data}}
+}
+
+func (zombieType) EncodeEntity() string { return "minecraft:zombie" }
+func (zombieType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.95, 0.3)
+}
+
+// CanSpawnAt checks if a zombie may spawn at the position passed.
+func (t zombieType) CanSpawnAt(tx *world.Tx, pos mgl64.Vec3) bool {
+	return SpawnConditions{}.Check(tx, pos, t.BBox(nil))
+}
+
+func (zombieType) DecodeNBT(m map[string]any, data *world.EntityData) {
+	z := zombieConf.New()
+	if _, ok := m["Health"]; ok {
+		z.health.AddHealth(float64(nbtconv.Float32(m, "Health")) - z.health.Health())
+	}
+	z.equipment.DecodeNBT(m)
+	// Zombies saved before always have their armour written, so only
+	// zombies freshly spawned from NBT, such as by a spawn egg, are equipped
+	// randomly.
+	_, z.equipped = m["Armor"]
+	data.Data = z
+}
+
+func (zombieType) EncodeNBT(data *world.EntityData) map[string]any {
+	z := data.Data.(*ZombieBehaviour)
+	m := map[string]any{"Health": float32(z.health.Health())}
+	z.equipment.EncodeNBT(m)
+	return m
+}
+
+// Zombie is a Mob that attacks nearby players. A Zombie may hold items and
+// wear armour, which are shown to viewers, increase the damage of its attacks
+// and reduce the damage it takes.
+type Zombie struct {
+	*Ent
+}
+
+// zombie returns the ZombieBehaviour of the Zombie.
+func (z *Zombie) zombie() *ZombieBehaviour {
+	return z.data.Data.(*ZombieBehaviour)
+}
+
+// Equipment returns the items held and the armour worn by the zombie.
+func (z *Zombie) Equipment() *Equipment {
+	return z.zombie().equipment
+}
+
+// HeldItems returns the items held by the zombie in its main hand and off
+// hand.
+func (z *Zombie) HeldItems() (mainHand, offHand item.Stack) {
+	return z.zombie().equipment.HeldItems()
+}
+
+// SetHeldItems changes the items held by the zombie in its main hand and off
+// hand.
+func (z *Zombie) SetHeldItems(mainHand, offHand item.Stack) {
+	z.zombie().equipment.SetHeldItems(mainHand, offHand)
+}
+
+// Armour returns the armour worn by the zombie.
+func (z *Zombie) Armour() *inventory.Armour {
+	return z.zombie().equipment.Armour()
+}
+
+// Health returns the health of the zombie.
+func (z *Zombie) Health() float64 {
+	return z.zombie().health.Health()
+}
+
+// MaxHealth returns the maximum health of the zombie.
+func (z *Zombie) MaxHealth() float64 {
+	return z.zombie().health.MaxHealth()
+}
+
+// SetMaxHealth changes the maximum health of the zombie.
+func (z *Zombie) SetMaxHealth(v float64) {
+	z.zombie().health.SetMaxHealth(v)
+}
+
+// Dead checks if the zombie is dead.
+func (z *Zombie) Dead() bool {
+	return z.Health() <= mgl64.Epsilon
+}
+
+// Hurt hurts the zombie for the damage passed. The damage is reduced by the
+// armour worn and the Resistance effect. Like players, a zombie that was hurt
+// recently is only hurt by damage higher than the damage it was last hurt by.
+func (z *Zombie) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
+	b := z.zombie()
+	if _, ok := b.effects.Effect(effect.FireResistance); (ok && src.Fire()) || z.Dead() || dmg < 0 {
+		return 0, false
+	}
+	if _, void := src.(VoidDamageSource); b.invulnerable && !void {
+		return 0, false
+	}
+	attacker, hasAttacker := damageAttacker(src)
+	if hasAttacker && !z.tx.World().DamageAllowed(attacker, z) {
+		return 0, false
+	}
+	damageLeft := dmg
+	if b.immunity > 0 {
+		if damageLeft -= b.lastDamage; damageLeft <= 0 {
+			return 0, false
+		}
+	} else {
+		b.immunity = zombieImmunityTicks
+	}
+	b.lastDamage = dmg
+
+	damageLeft = b.equipment.ReduceDamage(damageLeft, src)
+	if res, ok := b.effects.Effect(effect.Resistance); ok {
+		damageLeft *= effect.Resistance.Multiplier(src, res.Level())
+	}
+	b.health.AddHealth(-damageLeft)
+	z.tx.World().ReportDamage(z.tx, world.Damage{Attacker: attacker, Victim: z, Damage: dmg, FinalDamage: damageLeft, Source: src})
+
+	for _, v := range z.tx.EntityViewers(z) {
+		v.ViewEntityAction(z, HurtAction{})
+	}
+	if z.Dead() {
+		z.kill(src)
+	} else {
+		b.sound.Hurt(z, z.tx)
+	}
+	return damageLeft, true
+}
+
+// zombieImmunityTicks is the number of ticks that a zombie is immune to
+// damage lower than the damage it was last hurt by.
+const zombieImmunityTicks = 10
+
+// kill makes the zombie die, dropping its loot and the equipment that it
+// drops according to the drop chances of its Equipment. The zombie is removed
+// once its death animation has played.
+func (z *Zombie) kill(src world.DamageSource) {
+	b := z.zombie()
+	for _, v := range z.tx.EntityViewers(z) {
+		v.ViewEntityAction(z, DeathAction{})
+	}
+	b.sound.Death(z, z.tx)
+	b.goals.Stop(z, z.tx)
+
+	pos := z.Position()
+	drops := b.equipment.Drops(b.rand, 0)
+	if n := rand.IntN(3); n > 0 {
+		drops = append(drops, item.NewStack(item.RottenFlesh{}, n))
+	}
+	for _, it := range drops {
+		opts := world.EntitySpawnOpts{Position: pos, Velocity: mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1}}
+		z.tx.AddEntity(NewItem(opts, it))
+	}
+	if attacker, ok := damageAttacker(src); ok && isPlayer(attacker) {
+		for _, orb := range NewExperienceOrbs(pos, 5) {
+			z.tx.AddEntity(orb)
+		}
+	}
+}
+
+// Heal heals the zombie for the health passed and returns the health that
+// was added.
+func (z *Zombie) Heal(health float64, _ world.HealingSource) float64 {
+	if z.Dead() || health < 0 {
+		return 0
+	}
+	before := z.Health()
+	z.zombie().health.AddHealth(health)
+	return z.Health() - before
+}
+
+// KnockBack knocks the zombie back from the source passed. The knockback
+// resistance of the armour worn reduces the velocity.
+func (z *Zombie) KnockBack(src mgl64.Vec3, force, height float64) {
+	if z.Dead() || z.zombie().invulnerable {
+		return
+	}
+	velocity := z.Position().Sub(src)
+	velocity[1] = 0
+	if velocity.Len() != 0 {
+		velocity = velocity.Normalize().Mul(force)
+	}
+	velocity[1] = height
+	z.SetVelocity(velocity.Mul(1 - z.Armour().KnockBackResistance()))
+}
+
+// Explode hurts the zombie and knocks it back from an explosion.
+func (z *Zombie) Explode(_ mgl64.Vec3, impact world.ExplosionImpact, _ block.ExplosionConfig) {
+	if impact.Damage > 0 {
+		z.Hurt(impact.Damage, ExplosionDamageSource{})
+	}
+	if impact.Knockback != (mgl64.Vec3{}) && !z.zombie().invulnerable {
+		z.SetVelocity(impact.Knockback.Mul(1 - z.Armour().KnockBackResistance()))
+	}
+}
+
+// AddEffect adds an effect to the zombie.
+func (z *Zombie) AddEffect(e effect.Effect) {
+	z.zombie().effects.Add(e, z)
+	z.tx.UpdateEntityState(z)
+}
+
+// RemoveEffect removes an effect from the zombie.
+func (z *Zombie) RemoveEffect(e effect.Type) {
+	z.zombie().effects.Remove(e, z)
+	z.tx.UpdateEntityState(z)
+}
+
+// Effects returns the effects currently active on the zombie.
+func (z *Zombie) Effects() []effect.Effect {
+	return z.zombie().effects.Effects()
+}
+
+// Speed returns the speed of the zombie.
+func (z *Zombie) Speed() float64 {
+	return z.zombie().speed
+}
+
+// SetSpeed changes the speed of the zombie.
+func (z *Zombie) SetSpeed(v float64) {
+	z.zombie().speed = v
+}
+
+// Invulnerable checks if the zombie is invulnerable.
+func (z *Zombie) Invulnerable() bool {
+	return z.zombie().invulnerable
+}
+
+// SetInvulnerable sets if the zombie is invulnerable. An invulnerable zombie
+// is only hurt by the void.
+func (z *Zombie) SetInvulnerable(v bool) {
+	z.zombie().invulnerable = v
+}
+
+// Target returns the handle of the entity that the zombie is attacking.
+func (z *Zombie) Target() *world.EntityHandle {
+	return z.zombie().target
+}
+
+// SetTarget changes the entity that the zombie is attacking.
+func (z *Zombie) SetTarget(target *world.EntityHandle) {
+	z.zombie().target = target
+}
+
+// damageAttacker returns the entity responsible for the damage source passed,
+// if any.
+func damageAttacker(src world.DamageSource) (world.Entity, bool) {
+	switch s := src.(type) {
+	case AttackDamageSource:
+		return s.Attacker, s.Attacker != nil
+	case ProjectileDamageSource:
+		return s.Owner, s.Owner != nil
+	}
+	return nil, false
+}
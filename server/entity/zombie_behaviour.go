@@ -0,0 +1,156 @@
+package entity
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// ZombieBehaviourConfig holds optional parameters for a ZombieBehaviour.
+type ZombieBehaviourConfig struct {
+	// Gravity is the amount of Y velocity subtracted every tick.
+	Gravity float64
+	// Drag is used to reduce all axes of the velocity every tick. Velocity is
+	// multiplied with (1-Drag) every tick.
+	Drag float64
+	// Speed is the horizontal velocity that the zombie walks with.
+	Speed float64
+}
+
+func (conf ZombieBehaviourConfig) Apply(data *world.EntityData) {
+	data.Data = conf.New()
+}
+
+// New creates a ZombieBehaviour using the parameters in conf.
+func (conf ZombieBehaviourConfig) New() *ZombieBehaviour {
+	z := &ZombieBehaviour{
+		BaseBehaviour: NewBaseBehaviour(),
+		conf:          conf,
+		speed:         conf.Speed,
+		rand:          rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+		health:        NewHealthManager(20, 20),
+		effects:       NewEffectManager(),
+		equipment:     NewEquipment(),
+		goals:         NewGoalSelector(),
+		melee:         &MeleeAttackGoal{},
+		mc: &MovementComputer{
+			Gravity:           conf.Gravity,
+			Drag:              conf.Drag,
+			DragBeforeGravity: true,
+		},
+		look: &LookComputer{},
+	}
+	z.goals.Add(1, &NearestTargetGoal{Radius: 35})
+	z.goals.Add(2, z.melee)
+	z.goals.Add(7, &WanderGoal{})
+	z.goals.Add(8, &LookAtGoal{})
+	return z
+}
+
+// ZombieBehaviour implements the behaviour of zombies. Zombies target the
+// nearest player within 35 blocks and walk towards it to attack it, dealing
+// more damage when holding a weapon. Zombies that are freshly spawned are
+// equipped with random armour on their first tick, with a higher chance on
+// hard difficulty. Like other undead, zombies burn in daylight, unless they
+// wear a helmet.
+type ZombieBehaviour struct {
+	BaseBehaviour
+
+	conf ZombieBehaviourConfig
+	rand *rand.Rand
+
+	speed        float64
+	invulnerable bool
+	target       *world.EntityHandle
+
+	health     *HealthManager
+	effects    *EffectManager
+	equipment  *Equipment
+	equipped   bool
+	immunity   int
+	lastDamage float64
+	deathTicks int
+
+	fallDistance float64
+
+	goals *GoalSelector
+	melee *MeleeAttackGoal
+	mc    *MovementComputer
+	look  *LookComputer
+	sound SoundComputer
+}
+
+// zombieDeathTicks is the number of ticks that the death animation of a
+// zombie takes until the zombie is removed.
+const zombieDeathTicks = 20
+
+// LookComputer returns the state of the head of the zombie.
+func (z *ZombieBehaviour) LookComputer() *LookComputer {
+	return z.look
+}
+
+// DespawnComputer despawns zombies that are far away from players.
+func (z *ZombieBehaviour) DespawnComputer() DespawnComputer {
+	return DespawnComputer{}
+}
+
+// Tick runs the goals of the zombie, sets it on fire if it is exposed to
+// sunlight, moves it and shows changes to its equipment to viewers. Dead
+// zombies are removed once their death animation has played.
+func (z *ZombieBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
+	zombie := &Zombie{Ent: e}
+	if zombie.Dead() {
+		if z.deathTicks++; z.deathTicks >= zombieDeathTicks {
+			_ = e.Close()
+		}
+		return nil
+	}
+	if !z.equipped {
+		z.equipped = true
+		z.equipment.EquipRandomly(z.rand, tx.World().Difficulty())
+	}
+	if z.immunity > 0 {
+		z.immunity--
+	}
+	z.effects.Tick(zombie, tx)
+	BurnInDaylight(zombie, tx, z.rand)
+	if fire := e.OnFireDuration(); fire > 0 {
+		pos := cube.PosFromVec3(e.data.Pos)
+		if l, ok := tx.Liquid(pos); (ok && l.LiquidType() == "water") || tx.RainingAt(pos) {
+			e.Extinguish()
+		} else if fire%time.Second == 0 {
+			zombie.Hurt(1, block.FireDamageSource{})
+		}
+	}
+	if zombie.Dead() {
+		return nil
+	}
+
+	z.melee.Damage = z.equipment.AttackDamage(3)
+	z.goals.Tick(zombie, tx)
+
+	rot, vel := e.data.Rot, e.data.Vel
+	if math.Hypot(vel[0], vel[2]) > epsilon {
+		// Zombies face the direction that they walk in.
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-vel[0], vel[2])), rot.Pitch()}
+	}
+	m := z.mc.TickMovement(zombie, e.data.Pos, vel, rot, tx)
+	e.data.Pos, e.data.Vel, e.data.Rot = m.pos, m.vel, m.rot
+
+	if !m.onGround {
+		z.fallDistance = math.Max(z.fallDistance-m.dpos[1], 0)
+	} else if z.fallDistance > 0 {
+		if dist := Land(zombie, z.fallDistance, tx); dist > 3 {
+			zombie.Hurt(math.Ceil(dist-3), FallDamageSource{})
+		}
+		z.fallDistance = 0
+	}
+	z.sound.Tick(zombie, m.onGround, tx)
+	z.equipment.ShowChanges(zombie, tx)
+	return m
+}
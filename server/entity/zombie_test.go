@@ -0,0 +1,125 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// zombieTestSpawn adds a zombie to the world at the position passed.
+func zombieTestSpawn(tx *world.Tx, pos mgl64.Vec3) *entity.Zombie {
+	return tx.AddEntity(entity.NewZombie(world.EntitySpawnOpts{Position: pos})).(*entity.Zombie)
+}
+
+func TestZombieArmourReducesDamage(t *testing.T) {
+	w := newTestWorld(t)
+	doTx(t, w, func(tx *world.Tx) {
+		bare, armoured := zombieTestSpawn(tx, mgl64.Vec3{0.5, 64, 0.5}), zombieTestSpawn(tx, mgl64.Vec3{4.5, 64, 0.5})
+		helmet := item.NewStack(item.Helmet{Tier: item.ArmourTierIron{}}, 1)
+		armoured.Armour().Set(helmet, item.NewStack(item.Chestplate{Tier: item.ArmourTierIron{}}, 1), item.NewStack(item.Leggings{Tier: item.ArmourTierIron{}}, 1), item.NewStack(item.Boots{Tier: item.ArmourTierIron{}}, 1))
+
+		bareDmg, _ := bare.Hurt(8, entity.AttackDamageSource{})
+		armouredDmg, _ := armoured.Hurt(8, entity.AttackDamageSource{})
+		if bareDmg != 8 || bare.Health() != 12 {
+			t.Errorf("zombie without armour took %v damage with %v health left, want 8 damage with 12 health left", bareDmg, bare.Health())
+		}
+		if armouredDmg >= bareDmg || armoured.Health() <= bare.Health() {
+			t.Errorf("zombie with armour took %v damage, want less than %v", armouredDmg, bareDmg)
+		}
+		if armoured.Armour().Helmet().Durability() >= helmet.Durability() {
+			t.Errorf("helmet durability = %d, want less than %d", armoured.Armour().Helmet().Durability(), helmet.Durability())
+		}
+	})
+}
+
+func TestZombieEquipmentDrops(t *testing.T) {
+	w := newTestWorld(t)
+	sword := item.NewStack(item.Sword{Tier: item.ToolTierIron}, 1)
+	for _, chance := range []float64{0, 1} {
+		doTx(t, w, func(tx *world.Tx) {
+			z := zombieTestSpawn(tx, mgl64.Vec3{0.5, 64, 0.5})
+			z.SetHeldItems(sword, item.Stack{})
+			z.Equipment().SetDropChance(entity.EquipmentMainHand, chance)
+			if _, vulnerable := z.Hurt(100, entity.AttackDamageSource{}); !vulnerable || !z.Dead() {
+				t.Fatalf("zombie not killed")
+			}
+
+			var dropped []item.Stack
+			for e := range tx.Entities() {
+				if e.H().Type() != entity.ItemType {
+					continue
+				}
+				if it := e.(*entity.Ent).Behaviour().(*entity.ItemBehaviour).Item(); it.Comparable(sword) {
+					dropped = append(dropped, it)
+				}
+				_ = e.Close()
+			}
+			if want := int(chance); len(dropped) != want {
+				t.Errorf("swords dropped with a drop chance of %v = %v, want %v", chance, len(dropped), want)
+			}
+			if chance == 1 && len(dropped) == 1 && dropped[0].Durability() != sword.Durability() {
+				t.Errorf("dropped sword durability = %d, want %d", dropped[0].Durability(), sword.Durability())
+			}
+			if held, _ := z.HeldItems(); chance == 1 && !held.Empty() {
+				t.Errorf("held item after dropping = %v, want empty", held)
+			}
+		})
+	}
+}
+
+func TestZombieEquippedOnHardDifficulty(t *testing.T) {
+	for _, d := range []world.Difficulty{world.DifficultyEasy, world.DifficultyHard} {
+		w := newTestWorld(t)
+		w.SetDifficulty(d)
+		doTx(t, w, func(tx *world.Tx) {
+			armoured := 0
+			for i := range 200 {
+				z := zombieTestSpawn(tx, mgl64.Vec3{float64(i%20) * 2, 64, float64(i/20) * 2})
+				z.SetPersistent(true)
+				z.Tick(tx, 0)
+				if len(z.Armour().Items()) != 0 {
+					armoured++
+				}
+			}
+			if d == world.DifficultyEasy && armoured != 0 {
+				t.Errorf("armoured zombies on easy difficulty = %v/200, want none", armoured)
+			}
+			if d == world.DifficultyHard && (armoured == 0 || armoured > 60) {
+				t.Errorf("armoured zombies on hard difficulty = %v/200, want about 30", armoured)
+			}
+		})
+	}
+}
+
+func TestZombieBurnsInDaylight(t *testing.T) {
+	w := newTestWorld(t)
+	// Zombies are never equipped with random armour on easy difficulty, so
+	// the zombie without a helmet is guaranteed to burn.
+	w.SetDifficulty(world.DifficultyEasy)
+	w.StopWeatherCycle()
+	w.StopRaining()
+	w.SetTime(1000)
+	doTx(t, w, func(tx *world.Tx) {
+		bare, helmeted := zombieTestSpawn(tx, mgl64.Vec3{0.5, 64, 0.5}), zombieTestSpawn(tx, mgl64.Vec3{4.5, 64, 0.5})
+		helmet := item.NewStack(item.Helmet{Tier: item.ArmourTierIron{}}, 1)
+		helmeted.Armour().SetHelmet(helmet)
+		for _, z := range []*entity.Zombie{bare, helmeted} {
+			z.SetPersistent(true)
+			for range 40 {
+				z.Tick(tx, 0)
+			}
+		}
+		if bare.OnFireDuration() <= 0 || bare.Health() >= bare.MaxHealth() {
+			t.Errorf("zombie without helmet: fire = %v, health = %v, want it burning and hurt", bare.OnFireDuration(), bare.Health())
+		}
+		if helmeted.OnFireDuration() > 0 || helmeted.Health() != helmeted.MaxHealth() {
+			t.Errorf("zombie with helmet: fire = %v, health = %v, want it not burning", helmeted.OnFireDuration(), helmeted.Health())
+		}
+		if helmeted.Armour().Helmet().Durability() >= helmet.Durability() {
+			t.Errorf("helmet durability = %d, want less than %d", helmeted.Armour().Helmet().Durability(), helmet.Durability())
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package blockinternal
 
 import (
+	"maps"
+	"slices"
+
 	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/block/customblock"
@@ -37,8 +40,11 @@ func Components(identifier string, b world.CustomBlock, blockID int32) map[strin
 		})
 	}
 	if permutable, ok := b.(block.Permutable); ok {
-		for name, values := range permutable.States() {
-			builder.AddProperty(name, values)
+		// Properties are added in a fixed order, so that clients enumerate the states of the block in the same
+		// order every time.
+		states := permutable.States()
+		for _, name := range slices.Sorted(maps.Keys(states)) {
+			builder.AddProperty(name, states[name])
 		}
 		for _, permutation := range permutable.Permutations() {
 			builder.AddPermutation(permutation.Condition, componentsFromProperties(permutation.Properties))
@@ -3,9 +3,29 @@ package iteminternal
 import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"strings"
 )
 
+// Entry returns the entry of the given custom item as sent to clients in the ItemRegistry packet. Custom items
+// that are not custom blocks are component based, with their components as returned by Components.
+func Entry(it world.CustomItem) protocol.ItemEntry {
+	name, _ := it.EncodeItem()
+	rid, _, _ := world.ItemRuntimeID(it)
+	_, isCustomBlock := it.(world.CustomBlock)
+	var entryVersion int32 = protocol.ItemEntryVersionDataDriven
+	if isCustomBlock {
+		entryVersion = protocol.ItemEntryVersionNone
+	}
+	return protocol.ItemEntry{
+		Name:           name,
+		ComponentBased: !isCustomBlock,
+		RuntimeID:      int16(rid),
+		Version:        entryVersion,
+		Data:           Components(it),
+	}
+}
+
 // Components returns all the components of the given custom item. If the item has no components, a nil map and false
 // are returned.
 func Components(it world.CustomItem) map[string]any {
@@ -47,7 +67,11 @@ func Components(it world.CustomItem) map[string]any {
 			builder.AddProperty("use_animation", int32(1))
 		}
 	}
-	if x, ok := it.(item.Cooldown); ok {
+	if x, ok := it.(item.Drawable); ok {
+		builder.AddProperty("use_duration", int32(x.DrawDuration().Seconds()*20))
+		builder.AddProperty("use_animation", int32(4))
+	}
+	if x, ok := it.(item.Cooldown); ok && x.Cooldown() > 0 {
 		builder.AddComponent("minecraft:cooldown", map[string]any{
 			"category": name,
 			"duration": float32(x.Cooldown().Seconds()),
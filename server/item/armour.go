@@ -1,6 +1,10 @@
 package item
 
-import "image/color"
+import (
+	"image/color"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
 
 type (
 	// Armour represents an item that may be worn as armour. Generally, these items provide armour points, which
@@ -53,8 +57,65 @@ type (
 		Armour
 		Boots() bool
 	}
+	// Dyeable represents an item, generally leather Armour, that may be dyed
+	// in a crafting table or a cauldron.
+	Dyeable interface {
+		// DyeColour returns the colour that the item is dyed. The colour is
+		// zero if the item is not dyed. False is returned if the item cannot be
+		// dyed at all.
+		DyeColour() (color.RGBA, bool)
+		// WithDyeColour returns the item dyed the colour passed. Passing a zero
+		// colour removes the dye from the item. Items that cannot be dyed are
+		// returned unchanged.
+		WithDyeColour(c color.RGBA) world.Item
+	}
 )
 
+// MixDyeColours mixes the colours of the dyes passed into the colour cur, the
+// way leather armour is dyed in a crafting table. cur is the current colour of
+// the item dyed and is left out of the mix if it is zero.
+func MixDyeColours(cur color.RGBA, dyes ...Colour) color.RGBA {
+	var r, g, b, brightness, n float64
+	add := func(c color.RGBA) {
+		r, g, b = r+float64(c.R), g+float64(c.G), b+float64(c.B)
+		brightness += float64(max(c.R, c.G, c.B))
+		n++
+	}
+	if cur != (color.RGBA{}) {
+		add(cur)
+	}
+	for _, d := range dyes {
+		add(d.RGBA())
+	}
+	if n == 0 {
+		return cur
+	}
+	r, g, b = r/n, g/n, b/n
+	// The mixed colour is scaled back up to the average brightness of the
+	// colours mixed, so that mixing does not darken the colour.
+	if m := max(r, g, b); m > 0 {
+		gain := brightness / n / m
+		r, g, b = r*gain, g*gain, b*gain
+	}
+	return color.RGBA{R: uint8(r), G: uint8(g), B: uint8(b), A: 0xff}
+}
+
+// leatherColour returns the colour of the ArmourTier passed if it is
+// ArmourTierLeather.
+func leatherColour(tier ArmourTier) (color.RGBA, bool) {
+	t, ok := tier.(ArmourTierLeather)
+	return t.Colour, ok
+}
+
+// withLeatherColour returns the ArmourTier passed with its colour changed to c
+// if it is ArmourTierLeather.
+func withLeatherColour(tier ArmourTier, c color.RGBA) ArmourTier {
+	if _, ok := tier.(ArmourTierLeather); ok {
+		return ArmourTierLeather{Colour: c}
+	}
+	return tier
+}
+
 // ArmourTierLeather is the ArmourTier of leather armour
 type ArmourTierLeather struct {
 	// Colour is the dyed colour of the armour.
@@ -0,0 +1,45 @@
+package item
+
+import (
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Boat is an item used to place a boat, which can be ridden by up to two entities and is mostly used to
+// travel over water.
+type Boat struct {
+	// Type is the type of wood that the boat is made of.
+	Type BoatType
+}
+
+// MaxCount ...
+func (Boat) MaxCount() int {
+	return 1
+}
+
+// FuelInfo ...
+func (Boat) FuelInfo() FuelInfo {
+	return newFuelInfo(time.Second * 60)
+}
+
+// UseOnBlock places a boat on the face of the block clicked, facing in the same direction as the user. If a
+// liquid, such as water, was clicked, the boat is placed in it.
+func (b Boat) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user User, ctx *UseContext) bool {
+	opts := world.SpawnOptions{Rotation: cube.Rotation{user.Rotation().Yaw()}, Persistent: true, NBT: map[string]any{"Variant": int32(b.Type.Uint8())}}
+	if _, ok := tx.SpawnEntity("minecraft:boat", spawnEggPosition(pos, face, tx), opts); !ok {
+		return false
+	}
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// EncodeItem ...
+func (b Boat) EncodeItem() (name string, meta int16) {
+	if b.Type == BoatTypeBamboo() {
+		return "minecraft:bamboo_raft", 0
+	}
+	return "minecraft:" + b.Type.String() + "_boat", 0
+}
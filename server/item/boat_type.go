@@ -0,0 +1,99 @@
+package item
+
+// BoatType represents the type of wood that a Boat is made of.
+type BoatType struct {
+	boatType
+}
+
+// BoatTypeOak returns the oak boat type.
+func BoatTypeOak() BoatType {
+	return BoatType{0}
+}
+
+// BoatTypeSpruce returns the spruce boat type.
+func BoatTypeSpruce() BoatType {
+	return BoatType{1}
+}
+
+// BoatTypeBirch returns the birch boat type.
+func BoatTypeBirch() BoatType {
+	return BoatType{2}
+}
+
+// BoatTypeJungle returns the jungle boat type.
+func BoatTypeJungle() BoatType {
+	return BoatType{3}
+}
+
+// BoatTypeAcacia returns the acacia boat type.
+func BoatTypeAcacia() BoatType {
+	return BoatType{4}
+}
+
+// BoatTypeDarkOak returns the dark oak boat type.
+func BoatTypeDarkOak() BoatType {
+	return BoatType{5}
+}
+
+// BoatTypeMangrove returns the mangrove boat type.
+func BoatTypeMangrove() BoatType {
+	return BoatType{6}
+}
+
+// BoatTypeBamboo returns the bamboo boat type, which is known as a raft.
+func BoatTypeBamboo() BoatType {
+	return BoatType{7}
+}
+
+// BoatTypeCherry returns the cherry boat type.
+func BoatTypeCherry() BoatType {
+	return BoatType{8}
+}
+
+// BoatTypePaleOak returns the pale oak boat type.
+func BoatTypePaleOak() BoatType {
+	return BoatType{9}
+}
+
+// BoatTypes returns a list of all existing boat types, ordered by their variant.
+func BoatTypes() []BoatType {
+	return []BoatType{
+		BoatTypeOak(), BoatTypeSpruce(), BoatTypeBirch(), BoatTypeJungle(), BoatTypeAcacia(), BoatTypeDarkOak(),
+		BoatTypeMangrove(), BoatTypeBamboo(), BoatTypeCherry(), BoatTypePaleOak(),
+	}
+}
+
+// boatType is the underlying value of a BoatType struct.
+type boatType uint8
+
+// String ...
+func (b boatType) String() string {
+	switch b {
+	case 0:
+		return "oak"
+	case 1:
+		return "spruce"
+	case 2:
+		return "birch"
+	case 3:
+		return "jungle"
+	case 4:
+		return "acacia"
+	case 5:
+		return "dark_oak"
+	case 6:
+		return "mangrove"
+	case 7:
+		return "bamboo"
+	case 8:
+		return "cherry"
+	case 9:
+		return "pale_oak"
+	}
+	panic("unknown boat type")
+}
+
+// Uint8 returns the boat type as a uint8, which is equal to the variant of the boat entity.
+func (b boatType) Uint8() uint8 {
+	return uint8(b)
+}
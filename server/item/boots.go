@@ -90,6 +90,17 @@ func (b Boots) WithTrim(trim ArmourTrim) world.Item {
 	return b
 }
 
+// DyeColour returns the colour that the boots are dyed if they are made of leather.
+func (b Boots) DyeColour() (color.RGBA, bool) {
+	return leatherColour(b.Tier)
+}
+
+// WithDyeColour ...
+func (b Boots) WithDyeColour(c color.RGBA) world.Item {
+	b.Tier = withLeatherColour(b.Tier, c)
+	return b
+}
+
 // EncodeItem ...
 func (b Boots) EncodeItem() (name string, meta int16) {
 	return "minecraft:" + b.Tier.Name() + "_boots", 0
@@ -4,7 +4,6 @@ import (
 	"github.com/df-mc/dragonfly/server/item/potion"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
-	"math"
 	"time"
 )
 
@@ -38,8 +37,7 @@ func (Bow) Release(releaser Releaser, tx *world.Tx, ctx *UseContext, duration ti
 		return
 	}
 
-	d := float64(ticks) / 20
-	force := math.Min((d*d+d*2)/3, 1)
+	force := DrawForce(Bow{}, duration)
 	if force < 0.1 {
 		// The force must be at least 0.1.
 		return
@@ -103,6 +101,11 @@ func (Bow) Release(releaser Releaser, tx *world.Tx, ctx *UseContext, duration ti
 	tx.PlaySound(releaser.Position(), sound.BowShoot{})
 }
 
+// DrawDuration returns the duration that a bow must be drawn for to shoot an arrow with full force.
+func (Bow) DrawDuration() time.Duration {
+	return time.Second
+}
+
 // EnchantmentValue ...
 func (Bow) EnchantmentValue() int {
 	return 1
@@ -10,8 +10,9 @@ import (
 
 // BucketContent is the content of a bucket.
 type BucketContent struct {
-	liquid world.Liquid
-	milk   bool
+	liquid     world.Liquid
+	milk       bool
+	powderSnow bool
 }
 
 // LiquidBucketContent returns a new BucketContent with the liquid passed in.
@@ -24,6 +25,11 @@ func MilkBucketContent() BucketContent {
 	return BucketContent{milk: true}
 }
 
+// PowderSnowBucketContent returns a new BucketContent with the powder snow flag set.
+func PowderSnowBucketContent() BucketContent {
+	return BucketContent{powderSnow: true}
+}
+
 // Liquid returns the world.Liquid that a Bucket with this BucketContent places.
 // If this BucketContent does not place a liquid block, false is returned.
 func (b BucketContent) Liquid() (world.Liquid, bool) {
@@ -34,6 +40,8 @@ func (b BucketContent) Liquid() (world.Liquid, bool) {
 func (b BucketContent) String() string {
 	if b.milk {
 		return "milk"
+	} else if b.powderSnow {
+		return "powder_snow"
 	} else if b.liquid != nil {
 		return b.liquid.LiquidType()
 	}
@@ -87,7 +95,7 @@ func (b Bucket) Consume(_ *world.Tx, c Consumer) Stack {
 
 // Empty returns true if the bucket is empty.
 func (b Bucket) Empty() bool {
-	return b.Content.liquid == nil && !b.Content.milk
+	return b.Content.liquid == nil && !b.Content.milk && !b.Content.powderSnow
 }
 
 // FuelInfo ...
@@ -106,6 +114,9 @@ func (b Bucket) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world
 	if b.Empty() {
 		return b.fillFrom(pos, tx, ctx)
 	}
+	if b.Content.powderSnow {
+		return b.placePowderSnow(pos, face, tx, ctx)
+	}
 	liq := b.Content.liquid.WithDepth(8, false)
 	if bl := tx.Block(pos); canDisplace(bl, liq) || replaceableWith(bl, liq) {
 		tx.SetLiquid(pos, liq)
@@ -125,6 +136,16 @@ func (b Bucket) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world
 // fillFrom fills a bucket from the liquid at the position passed in the world. If there is no liquid or if
 // the liquid is no source, fillFrom returns false.
 func (b Bucket) fillFrom(pos cube.Pos, tx *world.Tx, ctx *UseContext) bool {
+	if _, ok := tx.Block(pos).(interface{ PowderSnow() }); ok {
+		air, _ := world.BlockByName("minecraft:air", nil)
+		tx.SetBlock(pos, air, nil)
+		tx.PlaySound(pos.Vec3Centre(), sound.BucketFill{PowderSnow: true})
+
+		ctx.NewItem = NewStack(Bucket{Content: PowderSnowBucketContent()}, 1)
+		ctx.NewItemSurvivalOnly = true
+		ctx.SubtractFromCount(1)
+		return true
+	}
 	liquid, ok := tx.Liquid(pos)
 	if !ok {
 		return false
@@ -142,6 +163,31 @@ func (b Bucket) fillFrom(pos cube.Pos, tx *world.Tx, ctx *UseContext) bool {
 	return true
 }
 
+// placePowderSnow places the powder snow of a powder snow bucket at the position passed, or at the side of it if
+// the block at the position cannot be replaced.
+func (b Bucket) placePowderSnow(pos cube.Pos, face cube.Face, tx *world.Tx, ctx *UseContext) bool {
+	snow, ok := world.BlockByName("minecraft:powder_snow", nil)
+	if !ok {
+		return false
+	}
+	if !replaceableWith(tx.Block(pos), snow) {
+		if pos = pos.Side(face); !replaceableWith(tx.Block(pos), snow) {
+			return false
+		}
+	}
+	if _, ok := tx.Liquid(pos); ok {
+		// Powder snow cannot be placed in liquids.
+		return false
+	}
+	tx.SetBlock(pos, snow, nil)
+	tx.PlaySound(pos.Vec3Centre(), sound.BucketEmpty{PowderSnow: true})
+
+	ctx.NewItem = NewStack(Bucket{}, 1)
+	ctx.NewItemSurvivalOnly = true
+	ctx.SubtractFromCount(1)
+	return true
+}
+
 // EncodeItem ...
 func (b Bucket) EncodeItem() (name string, meta int16) {
 	if !b.Empty() {
@@ -94,6 +94,17 @@ func (c Chestplate) WithTrim(trim ArmourTrim) world.Item {
 	return c
 }
 
+// DyeColour returns the colour that the chestplate is dyed if it is made of leather.
+func (c Chestplate) DyeColour() (color.RGBA, bool) {
+	return leatherColour(c.Tier)
+}
+
+// WithDyeColour ...
+func (c Chestplate) WithDyeColour(col color.RGBA) world.Item {
+	c.Tier = withLeatherColour(c.Tier, col)
+	return c
+}
+
 // EncodeItem ...
 func (c Chestplate) EncodeItem() (name string, meta int16) {
 	return "minecraft:" + c.Tier.Name() + "_chestplate", 0
@@ -0,0 +1,45 @@
+package item
+
+import (
+	"time"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// ChorusFruit is a food item that teleports the consumer to a random safe
+// position nearby when eaten.
+type ChorusFruit struct{}
+
+// AlwaysConsumable ...
+func (ChorusFruit) AlwaysConsumable() bool {
+	return true
+}
+
+// ConsumeDuration ...
+func (ChorusFruit) ConsumeDuration() time.Duration {
+	return DefaultConsumeDuration
+}
+
+// Consume ...
+func (ChorusFruit) Consume(tx *world.Tx, c Consumer) Stack {
+	c.Saturate(4, 2.4)
+	if pos, ok := (RandomTeleportConfig{}).Position(tx, c.Position(), world.EntityBBox(c)); ok {
+		TeleportEntity(tx, c, pos)
+	}
+	if cd, ok := c.(interface {
+		SetCooldown(item world.Item, cooldown time.Duration)
+	}); ok {
+		cd.SetCooldown(ChorusFruit{}, time.Second)
+	}
+	return Stack{}
+}
+
+// CompostChance ...
+func (ChorusFruit) CompostChance() float64 {
+	return 0.65
+}
+
+// EncodeItem ...
+func (ChorusFruit) EncodeItem() (name string, meta int16) {
+	return "minecraft:chorus_fruit", 0
+}
@@ -3,6 +3,7 @@ package creative
 import (
 	_ "embed"
 	"fmt"
+	"slices"
 
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
 	// The following four imports are essential for this package: They make sure this package is loaded after
@@ -120,6 +121,45 @@ func registerCreativeItems() {
 		}
 		RegisterItem(Item{st, creativeGroups[data.GroupIndex].Name})
 	}
+	registerCustomItems()
+}
+
+// registerCustomItems registers all custom items registered using world.RegisterItem to the creative
+// inventory. Items are added to the group returned by their category. If no group with that name exists yet,
+// a new one is registered with the first item of the group as its icon. Custom items without a group are
+// added to an anonymous group of their category, so that they are listed separately like vanilla items
+// without a group. Custom items with a zero category are not added to the creative inventory.
+func registerCustomItems() {
+	for _, it := range world.CustomItems() {
+		c := it.Category()
+		if c.Uint8() == 0 {
+			continue
+		}
+		st := item.NewStack(it, 1)
+		name := c.Group()
+		if name == "" {
+			name = anonymousGroup(Category{category(c.Uint8())})
+		}
+		if name == "" {
+			// The category had no anonymous group to add the item to, so a group of its own is registered.
+			name = "itemGroup.name.custom." + c.String()
+		}
+		if !slices.ContainsFunc(creativeGroups, func(group Group) bool { return group.Name == name }) {
+			RegisterGroup(Group{Category: Category{category(c.Uint8())}, Name: name, Icon: st})
+		}
+		RegisterItem(Item{Stack: st, Group: name})
+	}
+}
+
+// anonymousGroup returns the name of the last anonymous group registered with the category passed. An empty
+// string is returned if no such group was registered.
+func anonymousGroup(c Category) string {
+	for _, group := range slices.Backward(creativeGroups) {
+		if group.Category == c && group.Icon.Empty() {
+			return group.Name
+		}
+	}
+	return ""
 }
 
 func itemStackFromEntry(data creativeItemEntry) (item.Stack, bool) {
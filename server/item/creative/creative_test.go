@@ -0,0 +1,60 @@
+package creative
+
+import (
+	"image"
+	"testing"
+
+	itemcategory "github.com/df-mc/dragonfly/server/item/category"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestRegisterCustomItems(t *testing.T) {
+	grouped := creativeTestItem{name: "dragonfly:creative_test_grouped", category: itemcategory.Equipment().WithGroup("creative_test")}
+	ungrouped := creativeTestItem{name: "dragonfly:creative_test_ungrouped", category: itemcategory.Nature()}
+	world.RegisterItem(grouped)
+	world.RegisterItem(ungrouped)
+	world.RegisterItem(creativeTestItem{name: "dragonfly:creative_test_hidden"})
+	world.DefaultBlockRegistry.Finalize()
+	registerCreativeItems()
+
+	groups := map[string]Group{}
+	for _, group := range Groups() {
+		groups[group.Name] = group
+	}
+	items := map[string]string{}
+	for _, it := range Items() {
+		name, _ := it.Stack.Item().EncodeItem()
+		items[name] = it.Group
+	}
+
+	group, ok := groups[items[grouped.name]]
+	if !ok || group.Name != "itemGroup.name.creative_test" {
+		t.Fatalf("grouped custom item registered in group %q, want itemGroup.name.creative_test", items[grouped.name])
+	}
+	if group.Category != EquipmentCategory() || group.Icon.Empty() {
+		t.Errorf("group of custom item = %+v, want equipment group with an icon", group)
+	}
+
+	group, ok = groups[items[ungrouped.name]]
+	if !ok || group.Category != NatureCategory() || !group.Icon.Empty() {
+		t.Errorf("ungrouped custom item registered in group %+v, want anonymous nature group", group)
+	}
+	if group.Name != anonymousGroup(NatureCategory()) {
+		t.Errorf("ungrouped custom item registered in new group %q, want existing anonymous group %q", group.Name, anonymousGroup(NatureCategory()))
+	}
+
+	if g, ok := items["dragonfly:creative_test_hidden"]; ok {
+		t.Errorf("custom item without category registered in group %q, want not registered", g)
+	}
+}
+
+// creativeTestItem is a world.CustomItem with the name and category passed.
+type creativeTestItem struct {
+	name     string
+	category itemcategory.Category
+}
+
+func (i creativeTestItem) EncodeItem() (string, int16)     { return i.name, 0 }
+func (i creativeTestItem) Name() string                    { return i.name }
+func (i creativeTestItem) Texture() image.Image            { return image.NewRGBA(image.Rect(0, 0, 16, 16)) }
+func (i creativeTestItem) Category() itemcategory.Category { return i.category }
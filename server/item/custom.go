@@ -0,0 +1,159 @@
+package item
+
+import (
+	"image"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/item/category"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// CustomConfig holds the components of a custom item. Custom items are built from a CustomConfig using
+// CustomConfig.New, after which they may be registered using world.RegisterItem like any other world.CustomItem.
+// Items built from a CustomConfig behave like vanilla items with the same components, both server-side and on
+// the client.
+type CustomConfig struct {
+	// Identifier is the namespaced identifier of the item, such as 'example:teleport_wand'. It must be unique
+	// among all registered items.
+	Identifier string
+	// Name is the name displayed on the item to clients.
+	Name string
+	// Texture is the texture of the item, which is included in the resource pack sent to clients.
+	Texture image.Image
+	// Category is the category the item is listed under in the creative inventory.
+	Category category.Category
+	// MaxCount is the maximum amount of items that a stack of the item may hold. If 0, MaxCount defaults to 64
+	// for items without durability and to 1 for items with durability.
+	MaxCount int
+	// Durability is the amount of times the item can be used before it breaks. If 0, the item has no durability.
+	// Durability has no effect on items with Food.
+	Durability int
+	// Cooldown is the duration that a player must wait after using the item before it can be used again. If 0,
+	// the item has no cooldown.
+	Cooldown time.Duration
+	// Food, if non-nil, makes the item edible. When eaten, the player is saturated by the amount of food and
+	// saturation points of Food.
+	Food *CustomFood
+	// UseDuration is the duration that a player must use an item with Food for it to be eaten. If 0, UseDuration
+	// defaults to DefaultConsumeDuration.
+	UseDuration time.Duration
+	// Use is called when a user uses the item in the air, such as when a player right-clicks while holding it.
+	// The UseContext passed may be used to damage the item or subtract from its count. Use returns true if the
+	// item was used successfully. Use is not called for items with Food, which are eaten instead.
+	Use func(tx *world.Tx, user User, ctx *UseContext) bool
+}
+
+// CustomFood holds the food component of a custom item built from a CustomConfig.
+type CustomFood struct {
+	// Food is the amount of food points restored by eating the item.
+	Food int
+	// Saturation is the amount of saturation points restored by eating the item.
+	Saturation float64
+	// AlwaysEdible specifies if the item may be eaten when the food bar of the player is full.
+	AlwaysEdible bool
+}
+
+// New builds a custom item from the CustomConfig. The world.CustomItem returned must be registered using
+// world.RegisterItem before it can be used.
+func (conf CustomConfig) New() world.CustomItem {
+	if conf.MaxCount <= 0 {
+		conf.MaxCount = 64
+		if conf.Durability > 0 {
+			conf.MaxCount = 1
+		}
+	}
+	if conf.UseDuration <= 0 {
+		conf.UseDuration = DefaultConsumeDuration
+	}
+	c := customItem{conf: &conf}
+	switch {
+	case conf.Food != nil:
+		return customFood{c}
+	case conf.Durability > 0:
+		return customDurable{customUsable{c}}
+	}
+	return customUsable{c}
+}
+
+// customItem implements the components shared by all custom items built from a CustomConfig. conf is shared by
+// all instances of the item, so that they compare equal.
+type customItem struct {
+	conf *CustomConfig
+}
+
+// Name ...
+func (c customItem) Name() string {
+	return c.conf.Name
+}
+
+// Texture ...
+func (c customItem) Texture() image.Image {
+	return c.conf.Texture
+}
+
+// Category ...
+func (c customItem) Category() category.Category {
+	return c.conf.Category
+}
+
+// MaxCount ...
+func (c customItem) MaxCount() int {
+	return c.conf.MaxCount
+}
+
+// Cooldown ...
+func (c customItem) Cooldown() time.Duration {
+	return c.conf.Cooldown
+}
+
+// EncodeItem ...
+func (c customItem) EncodeItem() (name string, meta int16) {
+	return c.conf.Identifier, 0
+}
+
+// customUsable is a custom item that calls CustomConfig.Use when used in the air.
+type customUsable struct {
+	customItem
+}
+
+// Use ...
+func (c customUsable) Use(tx *world.Tx, user User, ctx *UseContext) bool {
+	if c.conf.Use == nil {
+		return false
+	}
+	return c.conf.Use(tx, user, ctx)
+}
+
+// customDurable is a custom usable item with durability.
+type customDurable struct {
+	customUsable
+}
+
+// DurabilityInfo ...
+func (c customDurable) DurabilityInfo() DurabilityInfo {
+	return DurabilityInfo{
+		MaxDurability: c.conf.Durability,
+		BrokenItem:    simpleItem(Stack{}),
+	}
+}
+
+// customFood is a custom item that may be eaten.
+type customFood struct {
+	customItem
+}
+
+// AlwaysConsumable ...
+func (c customFood) AlwaysConsumable() bool {
+	return c.conf.Food.AlwaysEdible
+}
+
+// ConsumeDuration ...
+func (c customFood) ConsumeDuration() time.Duration {
+	return c.conf.UseDuration
+}
+
+// Consume ...
+func (c customFood) Consume(_ *world.Tx, consumer Consumer) Stack {
+	consumer.Saturate(c.conf.Food.Food, c.conf.Food.Saturation)
+	return Stack{}
+}
@@ -0,0 +1,156 @@
+package item_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/internal/iteminternal"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/category"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// customItemTestPlayer adds a player with half of its food and the item held
+// passed to the world and returns its handle.
+func customItemTestPlayer(t *testing.T, w *world.World, held item.Stack) (handle *world.EntityHandle) {
+	t.Helper()
+	doTx(t, w, func(tx *world.Tx) {
+		p := tx.AddEntity(world.EntitySpawnOpts{}.New(player.Type, player.Config{Name: "player", Position: mgl64.Vec3{0, 64, 0}})).(*player.Player)
+		p.SetFood(10)
+		p.SetHeldItems(held, item.Stack{})
+		handle = p.H()
+	})
+	return handle
+}
+
+// customItemTestUse holds the arguments that the Use function of customItemTestWand was last called with.
+var customItemTestUse struct {
+	calls int
+	tx    *world.Tx
+	user  item.User
+}
+
+// customItemTestWand is a custom item that teleports its user 8 blocks up when used.
+var customItemTestWand = item.CustomConfig{
+	Identifier: "dragonfly:test_teleport_wand",
+	Name:       "Teleport Wand",
+	Category:   category.Equipment(),
+	Durability: 10,
+	Cooldown:   time.Second * 2,
+	Use: func(tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+		customItemTestUse.calls++
+		customItemTestUse.tx, customItemTestUse.user = tx, user
+		user.(*player.Player).Teleport(user.Position().Add(mgl64.Vec3{0, 8}))
+		ctx.DamageItem(1)
+		return true
+	},
+}.New()
+
+// customItemTestFood is a custom item that may be eaten.
+var customItemTestFood = item.CustomConfig{
+	Identifier:  "dragonfly:test_food",
+	Name:        "Food",
+	Category:    category.Nature(),
+	MaxCount:    16,
+	Food:        &item.CustomFood{Food: 6, Saturation: 2},
+	UseDuration: time.Second,
+}.New()
+
+func init() {
+	world.RegisterItem(customItemTestWand)
+	world.RegisterItem(customItemTestFood)
+}
+
+func TestCustomItemComponentsInItemRegistry(t *testing.T) {
+	pk := &packet.ItemRegistry{Items: []protocol.ItemEntry{iteminternal.Entry(customItemTestWand), iteminternal.Entry(customItemTestFood)}}
+	buf := bytes.NewBuffer(nil)
+	pk.Marshal(protocol.NewWriter(buf, 0))
+	decoded := &packet.ItemRegistry{}
+	decoded.Marshal(protocol.NewReader(buf, 0, false))
+	if len(decoded.Items) != 2 {
+		t.Fatalf("items in decoded item registry = %v, want 2", len(decoded.Items))
+	}
+
+	wand, food := decoded.Items[0], decoded.Items[1]
+	if rid, _, _ := world.ItemRuntimeID(customItemTestWand); wand.Name != "dragonfly:test_teleport_wand" || !wand.ComponentBased || wand.RuntimeID != int16(rid) {
+		t.Errorf("wand entry = %v, %v, %v, want dragonfly:test_teleport_wand, component based, runtime ID %v", wand.Name, wand.ComponentBased, wand.RuntimeID, rid)
+	}
+	components := wand.Data["components"].(map[string]any)
+	properties := components["item_properties"].(map[string]any)
+	if d := components["minecraft:durability"].(map[string]any)["max_durability"]; d != int32(10) {
+		t.Errorf("wand max durability = %v, want 10", d)
+	}
+	if d := components["minecraft:cooldown"].(map[string]any)["duration"]; d != float32(2) {
+		t.Errorf("wand cooldown = %v, want 2", d)
+	}
+	if n := components["minecraft:display_name"].(map[string]any)["value"]; n != "Teleport Wand" {
+		t.Errorf("wand display name = %v, want Teleport Wand", n)
+	}
+	if n := properties["max_stack_size"]; n != int32(1) {
+		t.Errorf("wand max stack size = %v, want 1", n)
+	}
+	if _, ok := components["minecraft:food"]; ok {
+		t.Errorf("wand has food component, want none")
+	}
+
+	components = food.Data["components"].(map[string]any)
+	properties = components["item_properties"].(map[string]any)
+	if _, ok := components["minecraft:food"]; !ok {
+		t.Errorf("food has no food component")
+	}
+	if _, ok := components["minecraft:cooldown"]; ok {
+		t.Errorf("food without cooldown has cooldown component, want none")
+	}
+	if d, n := properties["use_duration"], properties["max_stack_size"]; d != int32(20) || n != int32(16) {
+		t.Errorf("food use duration and max stack size = %v, %v, want 20, 16", d, n)
+	}
+}
+
+func TestCustomItemUseCallback(t *testing.T) {
+	w := newTestWorld(t)
+
+	customItemTestUse.calls = 0
+	handle := customItemTestPlayer(t, w, item.NewStack(customItemTestWand, 1))
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.UseItem()
+		if customItemTestUse.calls != 1 || customItemTestUse.tx != tx || customItemTestUse.user != p {
+			t.Fatalf("use callback calls, tx and user = %v, %p, %v, want 1, %p, %v", customItemTestUse.calls, customItemTestUse.tx, customItemTestUse.user, tx, p)
+		}
+		if pos := p.Position(); pos != (mgl64.Vec3{0, 72, 0}) {
+			t.Errorf("player position after using wand = %v, want (0, 72, 0)", pos)
+		}
+		if held, _ := p.HeldItems(); held.Durability() != 9 {
+			t.Errorf("wand durability after use = %v, want 9", held.Durability())
+		}
+		if !p.HasCooldown(customItemTestWand) {
+			t.Errorf("wand has no cooldown after use")
+		}
+
+		// The wand cannot be used again while it has a cooldown.
+		p.UseItem()
+		if customItemTestUse.calls != 1 {
+			t.Errorf("use callback calls after using wand during cooldown = %v, want 1", customItemTestUse.calls)
+		}
+	})
+}
+
+func TestCustomItemFoodEaten(t *testing.T) {
+	w := newTestWorld(t)
+
+	handle := customItemTestPlayer(t, w, item.NewStack(customItemTestFood, 2))
+	withPlayer(t, w, handle, func(_ *world.Tx, p *player.Player) {
+		p.UseItem()
+	})
+	// The food takes 1 second, or 20 ticks, to eat.
+	advanceTicks(w, 21)
+	withPlayer(t, w, handle, func(_ *world.Tx, p *player.Player) {
+		if held, _ := p.HeldItems(); held.Count() != 1 || p.Food() != 16 {
+			t.Errorf("food held and food level after eating = %v, %v, want 1, 16", held.Count(), p.Food())
+		}
+	})
+}
@@ -31,10 +31,20 @@ func (depthStrider) Rarity() item.EnchantmentRarity {
 	return item.EnchantmentRarityRare
 }
 
+// Drag returns the drag applied to the velocity of the wearer in water,
+// reduced from the drag passed for the level of the enchantment. The drag is
+// reduced only half as much if the wearer is not on the ground.
+func (depthStrider) Drag(drag float64, level int, onGround bool) float64 {
+	f := float64(min(level, 3)) / 3
+	if !onGround {
+		f *= 0.5
+	}
+	return drag * (1 - f)
+}
+
 // CompatibleWithEnchantment ...
-func (depthStrider) CompatibleWithEnchantment(item.EnchantmentType) bool {
-	// TODO: Frost Walker
-	return true
+func (depthStrider) CompatibleWithEnchantment(t item.EnchantmentType) bool {
+	return t != FrostWalker
 }
 
 // CompatibleWithItem ...
@@ -0,0 +1,55 @@
+package enchantment
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// FrostWalker is a boot enchantment that freezes the still water around the
+// wearer into frosted ice while it walks on the ground.
+var FrostWalker frostWalker
+
+type frostWalker struct{}
+
+// Name ...
+func (frostWalker) Name() string {
+	return "Frost Walker"
+}
+
+// MaxLevel ...
+func (frostWalker) MaxLevel() int {
+	return 2
+}
+
+// Cost ...
+func (frostWalker) Cost(level int) (int, int) {
+	minCost := level * 10
+	return minCost, minCost + 15
+}
+
+// Rarity ...
+func (frostWalker) Rarity() item.EnchantmentRarity {
+	return item.EnchantmentRarityRare
+}
+
+// Treasure ...
+func (frostWalker) Treasure() bool {
+	return true
+}
+
+// Radius returns the radius in blocks around the wearer within which water is
+// frozen for the level passed.
+func (frostWalker) Radius(level int) int {
+	return min(16, 2+level)
+}
+
+// CompatibleWithEnchantment ...
+func (frostWalker) CompatibleWithEnchantment(t item.EnchantmentType) bool {
+	return t != DepthStrider
+}
+
+// CompatibleWithItem ...
+func (frostWalker) CompatibleWithItem(i world.Item) bool {
+	b, ok := i.(item.BootsType)
+	return ok && b.Boots()
+}
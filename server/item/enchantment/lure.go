@@ -0,0 +1,52 @@
+package enchantment
+
+import (
+	"time"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Lure is an enchantment to fishing rods that decreases the time it takes for
+// a fish to bite the hook.
+var Lure lure
+
+type lure struct{}
+
+// Name ...
+func (lure) Name() string {
+	return "Lure"
+}
+
+// MaxLevel ...
+func (lure) MaxLevel() int {
+	return 3
+}
+
+// Cost ...
+func (lure) Cost(level int) (int, int) {
+	minCost := 15 + (level-1)*9
+	return minCost, minCost + 50
+}
+
+// Rarity ...
+func (lure) Rarity() item.EnchantmentRarity {
+	return item.EnchantmentRarityRare
+}
+
+// WaitReduction returns the duration that the time until a fish bites is
+// reduced by for a level of lure.
+func (lure) WaitReduction(level int) time.Duration {
+	return time.Second * 5 * time.Duration(level)
+}
+
+// CompatibleWithEnchantment ...
+func (lure) CompatibleWithEnchantment(item.EnchantmentType) bool {
+	return true
+}
+
+// CompatibleWithItem ...
+func (lure) CompatibleWithItem(i world.Item) bool {
+	_, ok := i.(item.FishingRod)
+	return ok
+}
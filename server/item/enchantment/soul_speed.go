@@ -37,6 +37,15 @@ func (soulSpeed) Treasure() bool {
 	return true
 }
 
+// SpeedMultiplier returns the multiplier of the movement speed of the wearer
+// while on soul sand or soul soil for the level passed.
+func (soulSpeed) SpeedMultiplier(level int) float64 {
+	if level <= 0 {
+		return 1
+	}
+	return 1.3 + 0.105*float64(level)
+}
+
 // CompatibleWithEnchantment ...
 func (soulSpeed) CompatibleWithEnchantment(item.EnchantmentType) bool {
 	return true
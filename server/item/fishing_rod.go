@@ -0,0 +1,89 @@
+package item
+
+import (
+	"time"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// FishingRod is a tool used to catch fish, treasure and junk from water. It may
+// also be used to hook entities and pull them towards the user.
+type FishingRod struct{}
+
+// fishingHook represents a fishing hook entity cast by a FishingRod.
+type fishingHook interface {
+	world.Entity
+	// Angler returns the handle of the entity that cast the fishing hook, or
+	// nil if the entity is not a fishing hook.
+	Angler() *world.EntityHandle
+	// Reel reels in the fishing hook, dropping any loot caught towards the
+	// angler or pulling the entity hooked. The damage dealt to the fishing rod
+	// is returned.
+	Reel() int
+}
+
+// MaxCount ...
+func (FishingRod) MaxCount() int {
+	return 1
+}
+
+// DurabilityInfo ...
+func (FishingRod) DurabilityInfo() DurabilityInfo {
+	return DurabilityInfo{
+		MaxDurability: 384,
+		BrokenItem:    simpleItem(Stack{}),
+	}
+}
+
+// FuelInfo ...
+func (FishingRod) FuelInfo() FuelInfo {
+	return newFuelInfo(time.Second * 15)
+}
+
+// Use casts a fishing hook if the user has none, or reels in the one it cast
+// before.
+func (FishingRod) Use(tx *world.Tx, user User, ctx *UseContext) bool {
+	area := world.EntityBBox(user).Translate(user.Position()).Grow(fishingHookMaxDistance)
+	for e := range tx.EntitiesWithin(area) {
+		if hook, ok := e.(fishingHook); ok && hook.Angler() == user.H() {
+			ctx.DamageItem(hook.Reel())
+			return true
+		}
+	}
+
+	held, _ := user.HeldItems()
+	lure, luck := 0, 0
+	for _, enchant := range held.Enchantments() {
+		if _, ok := enchant.Type().(interface{ WaitReduction(int) time.Duration }); ok {
+			lure = enchant.Level()
+		}
+		if _, ok := enchant.Type().(interface{ FishingLuck(int) int }); ok {
+			luck = enchant.Level()
+		}
+	}
+
+	create := tx.World().EntityRegistry().Config().FishingHook
+	opts := world.EntitySpawnOpts{
+		Position: eyePosition(user),
+		Velocity: user.Rotation().Vec3().Mul(0.6).Add(mgl64.Vec3{0, 0.2}),
+	}
+	tx.AddEntity(create(opts, user, lure, luck))
+	tx.PlaySound(user.Position(), sound.ItemThrow{})
+	return true
+}
+
+// fishingHookMaxDistance is the distance in blocks that a fishing hook may be
+// away from its angler before it is removed.
+const fishingHookMaxDistance = 32
+
+// EnchantmentValue ...
+func (FishingRod) EnchantmentValue() int {
+	return 1
+}
+
+// EncodeItem ...
+func (FishingRod) EncodeItem() (name string, meta int16) {
+	return "minecraft:fishing_rod", 0
+}
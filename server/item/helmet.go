@@ -90,6 +90,17 @@ func (h Helmet) WithTrim(trim ArmourTrim) world.Item {
 	return h
 }
 
+// DyeColour returns the colour that the helmet is dyed if it is made of leather.
+func (h Helmet) DyeColour() (color.RGBA, bool) {
+	return leatherColour(h.Tier)
+}
+
+// WithDyeColour ...
+func (h Helmet) WithDyeColour(c color.RGBA) world.Item {
+	h.Tier = withLeatherColour(h.Tier, c)
+	return h
+}
+
 // EncodeItem ...
 func (h Helmet) EncodeItem() (name string, meta int16) {
 	return "minecraft:" + h.Tier.Name() + "_helmet", 0
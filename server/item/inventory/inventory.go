@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/df-mc/dragonfly/server/item"
+	"maps"
 	"math"
 	"slices"
 	"strings"
@@ -22,6 +23,15 @@ type Inventory struct {
 
 	f         SlotFunc
 	validator SlotValidatorFunc
+	viewers   map[Viewer]struct{}
+}
+
+// Viewer is a viewer of an Inventory, such as a player that has the inventory opened in a window. A Viewer
+// is notified of every slot changed in the Inventory while it is added using Inventory.AddViewer.
+type Viewer interface {
+	// ViewSlotChange views a change of a single slot in the inventory, in which the item was changed to the
+	// new item passed.
+	ViewSlotChange(slot int, newItem item.Stack)
 }
 
 // SlotFunc is a function called for each item changed in an Inventory.
@@ -64,6 +74,24 @@ func (inv *Inventory) SlotFunc(f SlotFunc) {
 	inv.f = f
 }
 
+// AddViewer adds a Viewer to the inventory, so that it is notified of every slot changed in the inventory
+// until it is removed using RemoveViewer.
+func (inv *Inventory) AddViewer(v Viewer) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	if inv.viewers == nil {
+		inv.viewers = make(map[Viewer]struct{}, 1)
+	}
+	inv.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a Viewer added using AddViewer from the inventory.
+func (inv *Inventory) RemoveViewer(v Viewer) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	delete(inv.viewers, v)
+}
+
 // SlotValidatorFunc changes the function that limits item placement in the inventory slot.
 func (inv *Inventory) SlotValidatorFunc(f SlotValidatorFunc) {
 	inv.mu.Lock()
@@ -376,8 +404,12 @@ func (inv *Inventory) setItem(slot int, it item.Stack) func() {
 	}
 	before := inv.slots[slot]
 	inv.slots[slot] = it
+	f, viewers := inv.f, slices.Collect(maps.Keys(inv.viewers))
 	return func() {
-		inv.f(slot, before, it)
+		f(slot, before, it)
+		for _, v := range viewers {
+			v.ViewSlotChange(slot, it)
+		}
 	}
 }
 
@@ -394,8 +426,8 @@ func (inv *Inventory) size() int {
 	return len(inv.slots)
 }
 
-// Close closes the inventory, freeing the function called for every slot change. It also clears any items
-// that may currently be in the inventory.
+// Close closes the inventory, freeing the function called for every slot change and removing all viewers. It
+// also clears any items that may currently be in the inventory.
 // The returned error is always nil.
 func (inv *Inventory) Close() error {
 	inv.mu.Lock()
@@ -403,6 +435,7 @@ func (inv *Inventory) Close() error {
 
 	inv.check()
 	inv.f = func(int, item.Stack, item.Stack) {}
+	inv.viewers = nil
 	return nil
 }
 
@@ -161,6 +161,22 @@ type Releasable interface {
 	Requirements() []Stack
 }
 
+// Drawable represents a Releasable item that is drawn while it is used, such as a bow. The longer the item is
+// drawn, up to its DrawDuration, the more force it is released with. For custom items, implementing Drawable
+// makes the client show the drawing animation while the item is used.
+type Drawable interface {
+	Releasable
+	// DrawDuration returns the duration that the item must be drawn for to be released with full force.
+	DrawDuration() time.Duration
+}
+
+// DrawForce returns the force, ranging from 0 to 1, that a Drawable item drawn for the duration passed is
+// released with. The force grows quickly at first and more slowly as the item is drawn further.
+func DrawForce(d Drawable, duration time.Duration) float64 {
+	x := duration.Truncate(time.Second/20).Seconds() / d.DrawDuration().Seconds()
+	return min((x*x+x*2)/3, 1)
+}
+
 // Chargeable represents an item that can be charged.
 type Chargeable interface {
 	// Charge is called when an item is being used.
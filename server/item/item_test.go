@@ -0,0 +1,45 @@
+package item_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// newTestWorld returns a synchronous world that is closed when the test finishes.
+func newTestWorld(t *testing.T) *world.World {
+	t.Helper()
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	return w
+}
+
+// doTx runs f in a transaction of the world passed and waits for it to finish.
+func doTx(t *testing.T, w *world.World, f func(tx *world.Tx)) {
+	t.Helper()
+	if err := w.Do(f).Wait(context.Background()); err != nil {
+		t.Fatalf("world task failed: %v", err)
+	}
+}
+
+// withPlayer runs f in the world with the player with the handle passed.
+func withPlayer(t *testing.T, w *world.World, handle *world.EntityHandle, f func(tx *world.Tx, p *player.Player)) {
+	t.Helper()
+	doTx(t, w, func(tx *world.Tx) {
+		e, ok := handle.Entity(tx)
+		if !ok {
+			t.Fatalf("player is no longer in the world")
+		}
+		f(tx, e.(*player.Player))
+	})
+}
+
+// advanceTicks advances the synchronous world passed by n ticks.
+func advanceTicks(w *world.World, n int) {
+	for range n {
+		w.AdvanceTick()
+	}
+}
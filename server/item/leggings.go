@@ -94,6 +94,17 @@ func (l Leggings) WithTrim(trim ArmourTrim) world.Item {
 	return l
 }
 
+// DyeColour returns the colour that the leggings are dyed if they are made of leather.
+func (l Leggings) DyeColour() (color.RGBA, bool) {
+	return leatherColour(l.Tier)
+}
+
+// WithDyeColour ...
+func (l Leggings) WithDyeColour(c color.RGBA) world.Item {
+	l.Tier = withLeatherColour(l.Tier, c)
+	return l
+}
+
 // EncodeItem ...
 func (l Leggings) EncodeItem() (name string, meta int16) {
 	return "minecraft:" + l.Tier.Name() + "_leggings", 0
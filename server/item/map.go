@@ -0,0 +1,49 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// EmptyMap is an item that may be used to create a FilledMap of the area
+// surrounding the user.
+type EmptyMap struct{}
+
+// Use ...
+func (EmptyMap) Use(tx *world.Tx, user User, ctx *UseContext) bool {
+	m := tx.World().NewMap(cube.PosFromVec3(user.Position()), 0)
+	ctx.SubtractFromCount(1)
+	ctx.NewItem = NewStack(FilledMap{MapID: m.ID()}, 1)
+	return true
+}
+
+// EncodeItem ...
+func (EmptyMap) EncodeItem() (name string, meta int16) {
+	return "minecraft:empty_map", 0
+}
+
+// FilledMap is an item that shows the pixels of a world.Map. The area around
+// a player holding the item is drawn onto its Map as the player explores it.
+type FilledMap struct {
+	// MapID is the ID of the world.Map shown by the item, as returned by
+	// world.Map.ID. The world.Map may be obtained using world.World.Map.
+	MapID int64
+}
+
+// EncodeNBT ...
+func (f FilledMap) EncodeNBT() map[string]any {
+	return map[string]any{"map_uuid": f.MapID}
+}
+
+// DecodeNBT ...
+func (f FilledMap) DecodeNBT(data map[string]any) any {
+	if id, ok := data["map_uuid"].(int64); ok {
+		f.MapID = id
+	}
+	return f
+}
+
+// EncodeItem ...
+func (FilledMap) EncodeItem() (name string, meta int16) {
+	return "minecraft:filled_map", 0
+}
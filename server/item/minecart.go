@@ -0,0 +1,31 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Minecart is an item used to place a minecart, a vehicle that may be ridden by a single entity.
+type Minecart struct{}
+
+// MaxCount ...
+func (Minecart) MaxCount() int {
+	return 1
+}
+
+// UseOnBlock places a minecart on the face of the block clicked. Rails are not yet implemented, so minecarts
+// may be placed on any block.
+func (Minecart) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user User, ctx *UseContext) bool {
+	opts := world.SpawnOptions{Rotation: cube.Rotation{user.Rotation().Yaw()}, Persistent: true}
+	if _, ok := tx.SpawnEntity("minecraft:minecart", spawnEggPosition(pos, face, tx), opts); !ok {
+		return false
+	}
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// EncodeItem ...
+func (Minecart) EncodeItem() (name string, meta int16) {
+	return "minecraft:minecart", 0
+}
@@ -118,3 +118,55 @@ func (r DecoratedPotRecipe) Match(input []Item) (output []item.Stack, ok bool) {
 func (r DecoratedPotRecipe) Block() string {
 	return r.block
 }
+
+// LeatherDyeRecipe is a dynamic recipe for dyeing leather armour. It takes a single item.Dyeable item that
+// can be dyed and one or more dyes placed anywhere in the crafting grid, mixing the colours of the dyes into
+// the current colour of the item.
+type LeatherDyeRecipe struct {
+	block string
+}
+
+// NewLeatherDyeRecipe creates a new leather dye recipe.
+func NewLeatherDyeRecipe() LeatherDyeRecipe {
+	return LeatherDyeRecipe{block: "crafting_table"}
+}
+
+// Match checks if the input holds exactly one dyeable item and at least one dye, with no other items, and
+// returns the item dyed the mixed colour of the dyes.
+func (r LeatherDyeRecipe) Match(input []Item) (output []item.Stack, ok bool) {
+	var (
+		dyeable item.Stack
+		dyes    []item.Colour
+	)
+	for _, it := range input {
+		if it.Empty() {
+			continue
+		}
+		s, ok := it.(item.Stack)
+		if !ok {
+			return nil, false
+		}
+		switch i := s.Item().(type) {
+		case item.Dye:
+			dyes = append(dyes, i.Colour)
+		case item.Dyeable:
+			if _, ok := i.DyeColour(); !ok || !dyeable.Empty() {
+				return nil, false
+			}
+			dyeable = s
+		default:
+			return nil, false
+		}
+	}
+	if dyeable.Empty() || len(dyes) == 0 {
+		return nil, false
+	}
+	d := dyeable.Item().(item.Dyeable)
+	cur, _ := d.DyeColour()
+	return []item.Stack{dyeable.WithItem(d.WithDyeColour(item.MixDyeColours(cur, dyes...)))}, true
+}
+
+// Block returns the block used to craft this recipe.
+func (r LeatherDyeRecipe) Block() string {
+	return r.block
+}
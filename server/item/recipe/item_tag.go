@@ -3,27 +3,29 @@ package recipe
 import (
 	_ "embed"
 	"encoding/json"
-)
 
-var (
-	//go:embed item_tags.json
-	itemTagData []byte
-	itemTags    = make(map[string][]string)
+	"github.com/df-mc/dragonfly/server/world"
 )
 
+//go:embed item_tags.json
+var itemTagData []byte
+
 func init() {
+	var itemTags map[string][]string
 	if err := json.Unmarshal(itemTagData, &itemTags); err != nil {
 		panic(err)
 	}
+	for tag, items := range itemTags {
+		world.RegisterItemTag(world.NewTag(tag, items...))
+	}
 }
 
 // ItemTag represents a recipe item that is identified by a tag, such as "minecraft:planks" or
-// "minecraft:digger" and so on.
+// "minecraft:digger" and so on. The items that match the ItemTag are those of the world.Tag registered
+// with world.RegisterItemTag under the same name.
 type ItemTag struct {
 	tag   string
 	count int
-
-	items []string
 }
 
 // NewItemTag creates a new item tag with the tag and count passed.
@@ -31,7 +33,7 @@ func NewItemTag(tag string, count int) ItemTag {
 	if count < 0 {
 		count = 0
 	}
-	return ItemTag{tag: tag, count: count, items: itemTags[tag]}
+	return ItemTag{tag: tag, count: count}
 }
 
 // Count ...
@@ -51,10 +53,6 @@ func (i ItemTag) Tag() string {
 
 // Contains returns true if the item tag contains the item with the name passed.
 func (i ItemTag) Contains(name string) bool {
-	for _, item := range i.items {
-		if item == name {
-			return true
-		}
-	}
-	return false
+	t, _ := world.ItemTag(i.tag)
+	return t.Contains(name)
 }
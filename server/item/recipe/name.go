@@ -0,0 +1,53 @@
+package recipe
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/item"
+)
+
+// Name returns a name that identifies the Recipe passed. The name is derived
+// from the type, block, inputs and outputs of the recipe, so that it remains
+// the same across restarts of the server. Names are used to refer to recipes
+// in the recipe book of players, for example when unlocking them.
+func Name(r Recipe) string {
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%T;%v;", r, r.Block())
+	if shaped, ok := r.(Shaped); ok {
+		_, _ = fmt.Fprintf(h, "%dx%d;", shaped.Shape().Width(), shaped.Shape().Height())
+	}
+	for _, i := range r.Input() {
+		switch i := i.(type) {
+		case item.Stack:
+			writeStackName(h, i)
+		case ItemTag:
+			_, _ = fmt.Fprintf(h, "#%v:%d,", i.Tag(), i.Count())
+		default:
+			_, _ = h.Write([]byte{','})
+		}
+	}
+	_, _ = h.Write([]byte{';'})
+
+	prefix := r.Block()
+	for _, o := range r.Output() {
+		writeStackName(h, o)
+		if prefix == r.Block() && !o.Empty() {
+			name, _ := o.Item().EncodeItem()
+			prefix = strings.TrimPrefix(name, "minecraft:")
+		}
+	}
+	return fmt.Sprintf("%v_%016x", prefix, h.Sum64())
+}
+
+// writeStackName writes the name, meta and count of an item.Stack to w.
+func writeStackName(w io.Writer, s item.Stack) {
+	if s.Empty() {
+		_, _ = w.Write([]byte{','})
+		return
+	}
+	name, meta := s.Item().EncodeItem()
+	_, _ = fmt.Fprintf(w, "%v:%d:%d,", name, meta, s.Count())
+}
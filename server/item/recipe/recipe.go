@@ -101,6 +101,30 @@ func NewPotion(input, reagent Item, output item.Stack) Potion {
 	}}
 }
 
+// Smelting is a recipe for smelting an item in a furnace, blast furnace or smoker. Smelting recipes are
+// preferred over the item.SmeltInfo of the input item, so that they may override vanilla smelting.
+type Smelting struct {
+	recipe
+	// experience is the experience gained for every item produced by the recipe.
+	experience float64
+}
+
+// NewSmelting creates a new smelting recipe and returns it. The recipe can only be performed in the block
+// passed, which is "furnace", "blast_furnace" or "smoker". Smelting the input produces the output and
+// grants the experience passed for every item in the output.
+func NewSmelting(input item.Stack, output item.Stack, experience float64, block string) Smelting {
+	return Smelting{recipe: recipe{
+		input:  []Item{input},
+		output: []item.Stack{output},
+		block:  block,
+	}, experience: experience}
+}
+
+// Experience returns the experience gained for every item produced by the recipe.
+func (r Smelting) Experience() float64 {
+	return r.experience
+}
+
 // Shaped is a recipe that has a specific shape that must be used to craft the output of the recipe.
 type Shaped struct {
 	recipe
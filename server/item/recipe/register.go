@@ -7,15 +7,19 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"sync"
 	"unsafe"
 )
 
-// recipes is a list of each recipe.
 var (
+	// mu protects the recipes registered, so that recipes may be registered while they are being used.
+	mu sync.RWMutex
+	// recipes is a list of each recipe.
 	recipes []Recipe
 	// dynamicRecipes is a list of each dynamic recipe.
 	dynamicRecipes []DynamicRecipe
-	// index maps an input hash to output stacks for each PotionContainerChange and Potion recipe.
+	// index maps an input hash to the recipe for each Smelting, PotionContainerChange and Potion recipe, by
+	// block.
 	index = make(map[string]map[string]Recipe)
 	// reagent maps the item name and an item.Stack.
 	reagent = make(map[string]item.Stack)
@@ -23,47 +27,77 @@ var (
 
 // Recipes returns each recipe in a slice.
 func Recipes() []Recipe {
+	mu.RLock()
+	defer mu.RUnlock()
 	return slices.Clone(recipes)
 }
 
 // DynamicRecipes returns each dynamic recipe in a slice.
 func DynamicRecipes() []DynamicRecipe {
+	mu.RLock()
+	defer mu.RUnlock()
 	return slices.Clone(dynamicRecipes)
 }
 
-// Register registers a new recipe.
+// Register registers a new recipe. Recipes may be registered at any time, but players only receive recipes
+// registered before they joined.
+// Smelting, Potion and PotionContainerChange recipes are indexed by their block and input, so that they can
+// be looked up using Lookup. Registering such a recipe with the same block and input as a recipe registered
+// earlier, such as a vanilla recipe, replaces that recipe.
 func Register(recipe Recipe) {
-	recipes = append(recipes, recipe)
-
-	_, ok := recipe.(PotionContainerChange)
-	p, okTwo := recipe.(Potion)
+	mu.Lock()
+	defer mu.Unlock()
 
-	if okTwo {
+	if p, ok := recipe.(Potion); ok {
 		stack := p.Input()[1].(item.Stack)
 		name, _ := stack.Item().EncodeItem()
 		reagent[name] = stack
 	}
 
-	if ok || okTwo {
-		input := make([]world.Item, len(recipe.Input()))
-		for i, stack := range recipe.Input() {
-			if s, ok := stack.(item.Stack); ok {
-				input[i] = s.Item()
-			}
-		}
-		hash := hashItems(input, !ok)
+	hash, indexed := indexHash(recipe)
+	if !indexed {
+		recipes = append(recipes, recipe)
+		return
+	}
+	block := recipe.Block()
+	if index[block] == nil {
+		index[block] = make(map[string]Recipe)
+	}
+	if _, ok := index[block][hash]; ok {
+		// Replace the recipe with the same input, so that it is no longer used or sent to players.
+		recipes = slices.DeleteFunc(recipes, func(r Recipe) bool {
+			h, ok := indexHash(r)
+			return ok && h == hash && r.Block() == block
+		})
+	}
+	index[block][hash] = recipe
+	recipes = append(recipes, recipe)
+}
 
-		block := recipe.Block()
-		if index[block] == nil {
-			index[block] = make(map[string]Recipe)
+// indexHash returns the hash of the input of the recipe passed that it is indexed by. False is returned if the
+// recipe is not indexed.
+func indexHash(recipe Recipe) (string, bool) {
+	_, containerChange := recipe.(PotionContainerChange)
+	_, potion := recipe.(Potion)
+	_, smelting := recipe.(Smelting)
+	if !containerChange && !potion && !smelting {
+		return "", false
+	}
+	input := make([]world.Item, len(recipe.Input()))
+	for i, stack := range recipe.Input() {
+		if s, ok := stack.(item.Stack); ok {
+			input[i] = s.Item()
 		}
-		index[block][hash] = recipe
 	}
+	return hashItems(input, !containerChange), true
 }
 
-// Perform performs the recipe with the given block and inputs and returns the outputs. If the inputs do not map to
-// any outputs, false is returned for the second return value.
-func Perform(block string, input ...world.Item) (output []item.Stack, ok bool) {
+// Lookup looks up the Smelting, Potion or PotionContainerChange recipe registered for the block and the
+// inputs passed. The recipe is found by a single map lookup of its inputs. If no recipe exists for the
+// inputs, false is returned.
+func Lookup(block string, input ...world.Item) (Recipe, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
 	blockInd, ok := index[block]
 	if !ok {
 		// Block specific index didn't exist.
@@ -72,9 +106,16 @@ func Perform(block string, input ...world.Item) (output []item.Stack, ok bool) {
 	r, ok := blockInd[hashItems(input, true)]
 	if !ok {
 		r, ok = blockInd[hashItems(input, false)]
-		if !ok {
-			return nil, false
-		}
+	}
+	return r, ok
+}
+
+// Perform performs the recipe with the given block and inputs and returns the outputs. If the inputs do not map to
+// any outputs, false is returned for the second return value.
+func Perform(block string, input ...world.Item) (output []item.Stack, ok bool) {
+	r, ok := Lookup(block, input...)
+	if !ok {
+		return nil, false
 	}
 	_, containerChange := r.(PotionContainerChange)
 	for ind, it := range r.Output() {
@@ -118,6 +159,8 @@ func hashItems(items []world.Item, useMeta bool) string {
 
 // ValidBrewingReagent checks if the world.Item is a brewing reagent.
 func ValidBrewingReagent(i world.Item) bool {
+	mu.RLock()
+	defer mu.RUnlock()
 	name, _ := i.EncodeItem()
 	_, exists := reagent[name]
 	return exists
@@ -126,5 +169,7 @@ func ValidBrewingReagent(i world.Item) bool {
 // RegisterDynamic registers a new dynamic recipe. Dynamic recipes are not sent to the client
 // and are validated server-side.
 func RegisterDynamic(recipe DynamicRecipe) {
+	mu.Lock()
+	defer mu.Unlock()
 	dynamicRecipes = append(dynamicRecipes, recipe)
 }
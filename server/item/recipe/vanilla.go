@@ -178,4 +178,5 @@ func registerVanilla() {
 
 	// Register dynamic recipes
 	RegisterDynamic(NewDecoratedPotRecipe())
+	RegisterDynamic(NewLeatherDyeRecipe())
 }
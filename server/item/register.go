@@ -32,6 +32,7 @@ func init() {
 	world.RegisterItem(Charcoal{})
 	world.RegisterItem(Chicken{Cooked: true})
 	world.RegisterItem(Chicken{})
+	world.RegisterItem(ChorusFruit{})
 	world.RegisterItem(ClayBall{})
 	world.RegisterItem(Clock{})
 	world.RegisterItem(Coal{})
@@ -50,14 +51,17 @@ func init() {
 	world.RegisterItem(Egg{})
 	world.RegisterItem(Elytra{})
 	world.RegisterItem(Emerald{})
+	world.RegisterItem(EmptyMap{})
 	world.RegisterItem(EnchantedApple{})
 	world.RegisterItem(EnchantedBook{})
 	world.RegisterItem(EnderEye{})
 	world.RegisterItem(EnderPearl{})
 	world.RegisterItem(Feather{})
 	world.RegisterItem(FermentedSpiderEye{})
+	world.RegisterItem(FilledMap{})
 	world.RegisterItem(FireCharge{})
 	world.RegisterItem(Firework{})
+	world.RegisterItem(FishingRod{})
 	world.RegisterItem(FlintAndSteel{})
 	world.RegisterItem(Flint{})
 	world.RegisterItem(GhastTear{})
@@ -80,6 +84,7 @@ func init() {
 	world.RegisterItem(Leather{})
 	world.RegisterItem(MagmaCream{})
 	world.RegisterItem(MelonSlice{})
+	world.RegisterItem(Minecart{})
 	world.RegisterItem(MushroomStew{})
 	world.RegisterItem(Mutton{Cooked: true})
 	world.RegisterItem(Mutton{})
@@ -110,6 +115,7 @@ func init() {
 	world.RegisterItem(RecoveryCompass{})
 	world.RegisterItem(ResinBrick{})
 	world.RegisterItem(RottenFlesh{})
+	world.RegisterItem(Saddle{})
 	world.RegisterItem(Salmon{Cooked: true})
 	world.RegisterItem(Salmon{})
 	world.RegisterItem(Scute{})
@@ -170,4 +176,7 @@ func init() {
 	for _, sherd := range SherdTypes() {
 		world.RegisterItem(PotterySherd{Type: sherd})
 	}
+	for _, b := range BoatTypes() {
+		world.RegisterItem(Boat{Type: b})
+	}
 }
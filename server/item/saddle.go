@@ -0,0 +1,29 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Saddle is an item that may be put on a horse, allowing the entity riding the horse to control it.
+type Saddle struct{}
+
+// MaxCount ...
+func (Saddle) MaxCount() int {
+	return 1
+}
+
+// UseOnEntity puts the saddle on the entity clicked, if it is able to wear a saddle and does not yet wear
+// one.
+func (Saddle) UseOnEntity(e world.Entity, _ *world.Tx, _ User, ctx *UseContext) bool {
+	s, ok := e.(interface{ Saddle() bool })
+	if !ok || !s.Saddle() {
+		return false
+	}
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// EncodeItem ...
+func (Saddle) EncodeItem() (name string, meta int16) {
+	return "minecraft:saddle", 0
+}
@@ -0,0 +1,71 @@
+package item
+
+import (
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// SpawnEgg is an item used to spawn an entity, typically a mob. SpawnEggs are
+// not registered by default. A SpawnEgg for an entity type registered in the
+// world.EntityRegistry, such as a custom mob, may be registered using
+// world.RegisterItem.
+type SpawnEgg struct {
+	// Entity is the name of the entity type that the SpawnEgg spawns, for
+	// example "minecraft:zombie".
+	Entity string
+}
+
+// UseOnBlock spawns the entity of the SpawnEgg on the face of the block
+// clicked. If the block clicked has no collision box, like tall grass, the
+// entity is spawned inside of it instead. Nothing is spawned if the entity may
+// not spawn at that position, as reported by world.Tx.CanSpawnAt.
+func (s SpawnEgg) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, _ User, ctx *UseContext) bool {
+	opts := world.SpawnOptions{Rotation: cube.Rotation{rand.Float64() * 360}, CheckConditions: true}
+	if _, ok := tx.SpawnEntity(s.Entity, spawnEggPosition(pos, face, tx), opts); !ok {
+		return false
+	}
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// UseOnEntity spawns a baby variant of the entity clicked if it is of the same
+// type as the entity spawned by the SpawnEgg.
+func (s SpawnEgg) UseOnEntity(e world.Entity, tx *world.Tx, _ User, ctx *UseContext) bool {
+	if e.H().Type().EncodeEntity() != s.Entity {
+		return false
+	}
+	opts := world.SpawnOptions{Rotation: cube.Rotation{e.Rotation().Yaw()}, NBT: map[string]any{"IsBaby": uint8(1)}}
+	if _, ok := tx.SpawnEntity(s.Entity, e.Position(), opts); !ok {
+		return false
+	}
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// spawnEggPosition returns the position that an entity is spawned at when a
+// SpawnEgg is used on the face of the block at pos. Entities spawned on top of
+// a block that is not a full block, such as a slab, are spawned on top of its
+// collision box.
+func spawnEggPosition(pos cube.Pos, face cube.Face, tx *world.Tx) mgl64.Vec3 {
+	boxes := tx.Block(pos).Model().BBox(pos, tx)
+	if len(boxes) == 0 {
+		return pos.Vec3Middle()
+	}
+	spawnPos := pos.Side(face).Vec3Middle()
+	if face == cube.FaceUp {
+		top := 0.0
+		for _, box := range boxes {
+			top = max(top, box.Max()[1])
+		}
+		spawnPos[1] = float64(pos[1]) + top
+	}
+	return spawnPos
+}
+
+// EncodeItem ...
+func (s SpawnEgg) EncodeItem() (name string, meta int16) {
+	return s.Entity + "_spawn_egg", 0
+}
@@ -0,0 +1,94 @@
+package item
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// RandomTeleportConfig holds the parameters used to search for a random safe
+// position near an entity to teleport it to, such as when eating chorus fruit.
+type RandomTeleportConfig struct {
+	// Radius is the maximum distance on every axis between the entity and
+	// the position it is teleported to. If 0, it defaults to 8.
+	Radius int
+	// Attempts is the number of random positions tried before giving up. If
+	// 0, it defaults to 16.
+	Attempts int
+}
+
+// Position searches for a random position within the Radius of pos that an
+// entity with the bounding box passed may safely be teleported to. It tries
+// up to Attempts random positions, moving each of them down to the ground
+// within the Radius. False is returned if no safe position was found.
+func (conf RandomTeleportConfig) Position(tx *world.Tx, pos mgl64.Vec3, box cube.BBox) (mgl64.Vec3, bool) {
+	radius, attempts := conf.Radius, conf.Attempts
+	if radius <= 0 {
+		radius = 8
+	}
+	if attempts <= 0 {
+		attempts = 16
+	}
+	origin, r := cube.PosFromVec3(pos), tx.Range()
+	minY, maxY := max(origin[1]-radius, r[0]), min(origin[1]+radius, r[1])
+	if minY > maxY {
+		return mgl64.Vec3{}, false
+	}
+	for range attempts {
+		x, z := origin[0]+rand.IntN(radius*2+1)-radius, origin[2]+rand.IntN(radius*2+1)-radius
+		for y := minY + rand.IntN(maxY-minY+1); y >= minY; y-- {
+			target := mgl64.Vec3{float64(x) + 0.5, float64(y), float64(z) + 0.5}
+			if SafeLanding(tx, target, box) {
+				return target, true
+			}
+		}
+	}
+	return mgl64.Vec3{}, false
+}
+
+// SafeLanding checks if an entity with the bounding box passed can safely
+// stand at pos. This is the case if the block below pos has a solid top face
+// and the entity would neither be inside any block nor inside a liquid.
+func SafeLanding(tx *world.Tx, pos mgl64.Vec3, box cube.BBox) bool {
+	below := cube.PosFromVec3(pos.Sub(mgl64.Vec3{0, 0.01}))
+	if below.OutOfBounds(tx.Range()) || !tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+		return false
+	}
+	box = box.Translate(pos)
+	min, max := box.Min(), box.Max()
+	for b := range cube.Range3D(
+		cube.Pos{int(math.Floor(min[0])), int(math.Floor(min[1])), int(math.Floor(min[2]))},
+		cube.Pos{int(math.Ceil(max[0])) - 1, int(math.Ceil(max[1])) - 1, int(math.Ceil(max[2])) - 1},
+	) {
+		if b.OutOfBounds(tx.Range()) {
+			return false
+		}
+		if _, ok := tx.Liquid(b); ok {
+			return false
+		}
+		for _, bb := range tx.Block(b).Model().BBox(b, tx) {
+			if bb.Translate(b.Vec3()).IntersectsWith(box) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// TeleportEntity teleports the entity passed to pos, playing a teleport sound
+// at the position it left and at the position it arrived at. False is
+// returned if the entity cannot be teleported.
+func TeleportEntity(tx *world.Tx, e world.Entity, pos mgl64.Vec3) bool {
+	t, ok := e.(interface{ Teleport(pos mgl64.Vec3) })
+	if !ok {
+		return false
+	}
+	tx.PlaySound(e.Position(), sound.Teleport{})
+	t.Teleport(pos)
+	tx.PlaySound(pos, sound.Teleport{})
+	return true
+}
@@ -10,6 +10,9 @@ type UseContext struct {
 	// IgnoreBBox specifies if placing the item should ignore the BBox of the player placing this. This is the case for
 	// items such as cocoa beans.
 	IgnoreBBox bool
+	// IgnoreSupport specifies if placing the item should ignore whether the block placed is supported by its
+	// neighbours, allowing blocks such as torches to be placed in mid-air.
+	IgnoreSupport bool
 	// NewItem is the item that is added after the item is used. If the player no longer has an item in the
 	// hand, it'll be added there.
 	NewItem Stack
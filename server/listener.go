@@ -24,8 +24,17 @@ type Listener interface {
 // listenerFunc may be used to return a *minecraft.Listener using a Config. It
 // is the standard listener used when UserConfig.Config() is called.
 func (uc UserConfig) listenerFunc(conf Config) (Listener, error) {
+	// The listener must let players beyond the maximum player count through
+	// so that they can use reserved slots or be queued. The Server enforces the
+	// limit itself in that case.
+	maxPlayers := conf.MaxPlayers
+	if conf.QueueWhenFull {
+		maxPlayers = 0
+	} else if maxPlayers != 0 {
+		maxPlayers += max(conf.ReservedSlots, 0)
+	}
 	cfg := minecraft.ListenConfig{
-		MaximumPlayers:         conf.MaxPlayers,
+		MaximumPlayers:         maxPlayers,
 		StatusProvider:         conf.StatusProvider,
 		AuthenticationDisabled: conf.AuthDisabled,
 		ResourcePacks:          conf.Resources,
@@ -0,0 +1,31 @@
+package bossbar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// newTestWorld returns a synchronous world that is closed when the test finishes.
+func newTestWorld(t *testing.T) *world.World {
+	t.Helper()
+	w := world.Config{Synchronous: true}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	return w
+}
+
+// doTx runs f in a transaction of the world passed and waits for it to finish.
+func doTx(t *testing.T, w *world.World, f func(tx *world.Tx)) {
+	t.Helper()
+	if err := w.Do(f).Wait(context.Background()); err != nil {
+		t.Fatalf("world task failed: %v", err)
+	}
+}
+
+// advanceTicks advances the synchronous world passed by n ticks.
+func advanceTicks(w *world.World, n int) {
+	for range n {
+		w.AdvanceTick()
+	}
+}
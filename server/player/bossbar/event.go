@@ -0,0 +1,192 @@
+package bossbar
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Phase is a timed phase of an Event. While a Phase is active, a boss bar
+// with its Text and the time left is shown to all players in the world of the
+// Event, emptying as the phase progresses.
+type Phase struct {
+	// Text is the text shown in the boss bar during the Phase. The time left
+	// in the Phase is shown after it.
+	Text string
+	// Duration is the duration of the Phase. It is rounded down to whole
+	// ticks and always lasts at least one tick.
+	Duration time.Duration
+	// Colour is the Colour of the boss bar during the Phase. The zero value
+	// of Colour is Pink.
+	Colour Colour
+	// Start is called when the Phase starts. If nil, nothing happens.
+	Start func(tx *world.Tx)
+	// End is called when the Phase ends after its Duration. End is not called
+	// if the Event is stopped using Event.Stop. If nil, nothing happens.
+	End func(tx *world.Tx)
+}
+
+// Viewer is a player that an Event shows its boss bar to.
+type Viewer interface {
+	// SendBossBar shows the BossBar passed to the Viewer.
+	SendBossBar(bar BossBar)
+	// RemoveBossBar removes the boss bar currently shown to the Viewer.
+	RemoveBossBar()
+}
+
+// Event runs a list of timed phases one after another in a world, such as
+// a countdown to defend a base for 5 minutes. During every phase, a boss bar
+// shared by all players in the world shows the phase and the time left in it.
+// An Event must be started using Event.Start and may only be used within the
+// world that it was started in.
+type Event struct {
+	phases []Phase
+	end    func(tx *world.Tx)
+
+	running bool
+	// run is incremented every time the Event is started, so that ticks
+	// scheduled by an earlier run are ignored after restarting it.
+	run   int
+	phase int
+	// left is the number of ticks left in the current phase and total the
+	// number of ticks that the current phase lasts.
+	left, total int64
+	bar         BossBar
+	// shown holds the viewers that the boss bar is currently shown to.
+	shown map[*world.EntityHandle]struct{}
+}
+
+// NewEvent creates an Event that runs the phases passed in order. end is
+// called after the last phase ended. end may be nil.
+func NewEvent(end func(tx *world.Tx), phases ...Phase) *Event {
+	return &Event{phases: phases, end: end, shown: map[*world.EntityHandle]struct{}{}}
+}
+
+// Start starts the Event in the world of the world.Tx passed, starting its
+// first phase. Nothing happens if the Event is already running or has no
+// phases.
+func (e *Event) Start(tx *world.Tx) {
+	if e.running || len(e.phases) == 0 {
+		return
+	}
+	e.running = true
+	e.run++
+	e.startPhase(tx, 0)
+	e.schedule(tx)
+}
+
+// Stop stops the Event without running the End function of the current phase
+// or the end function of the Event, and removes the boss bar from all players
+// that it was shown to.
+func (e *Event) Stop(tx *world.Tx) {
+	if !e.running {
+		return
+	}
+	e.running = false
+	e.removeBar(tx)
+}
+
+// Running checks if the Event is currently running.
+func (e *Event) Running() bool {
+	return e.running
+}
+
+// Phase returns the index of the phase that the Event is currently in.
+func (e *Event) Phase() int {
+	return e.phase
+}
+
+// Remaining returns the time left in the current phase of the Event.
+func (e *Event) Remaining() time.Duration {
+	return time.Duration(e.left) * (time.Second / 20)
+}
+
+// BossBar returns the boss bar shown to players in the world of the Event
+// for the current phase.
+func (e *Event) BossBar() BossBar {
+	return e.bar
+}
+
+// schedule schedules the next tick of the Event.
+func (e *Event) schedule(tx *world.Tx) {
+	run := e.run
+	tx.ScheduleFunc(time.Second/20, func(tx *world.Tx) {
+		if e.run == run {
+			e.tick(tx)
+		}
+	})
+}
+
+// tick progresses the current phase of the Event by a single tick, moving on
+// to the next phase once it ends.
+func (e *Event) tick(tx *world.Tx) {
+	if !e.running {
+		return
+	}
+	if e.left--; e.left > 0 {
+		e.updateBar(tx)
+		e.schedule(tx)
+		return
+	}
+	if end := e.phases[e.phase].End; end != nil {
+		end(tx)
+	}
+	if !e.running {
+		// The Event was stopped by the End function of the phase.
+		return
+	}
+	if e.phase+1 < len(e.phases) {
+		e.startPhase(tx, e.phase+1)
+		e.schedule(tx)
+		return
+	}
+	e.running = false
+	e.removeBar(tx)
+	if e.end != nil {
+		e.end(tx)
+	}
+}
+
+// startPhase starts the phase with the index passed.
+func (e *Event) startPhase(tx *world.Tx, i int) {
+	e.phase = i
+	e.total = max(int64(e.phases[i].Duration/(time.Second/20)), 1)
+	e.left = e.total
+	if start := e.phases[i].Start; start != nil {
+		if start(tx); !e.running {
+			// The Event was stopped by the Start function of the phase.
+			return
+		}
+	}
+	e.updateBar(tx)
+}
+
+// updateBar updates the boss bar to the time left in the current phase and
+// shows it to all players in the world.
+func (e *Event) updateBar(tx *world.Tx) {
+	p := e.phases[e.phase]
+	secs := (e.left + 19) / 20
+	e.bar = New(fmt.Sprintf("%v %d:%02d", p.Text, secs/60, secs%60)).
+		WithHealthPercentage(float64(e.left) / float64(e.total)).
+		WithColour(p.Colour)
+	for ent := range tx.Players() {
+		if v, ok := ent.(Viewer); ok {
+			v.SendBossBar(e.bar)
+			e.shown[ent.H()] = struct{}{}
+		}
+	}
+}
+
+// removeBar removes the boss bar from all players that it was shown to and
+// that are still in the world.
+func (e *Event) removeBar(tx *world.Tx) {
+	for h := range e.shown {
+		if ent, ok := h.Entity(tx); ok {
+			if v, ok := ent.(Viewer); ok {
+				v.RemoveBossBar()
+			}
+		}
+	}
+	clear(e.shown)
+}
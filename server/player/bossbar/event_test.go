@@ -0,0 +1,76 @@
+package bossbar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestEventPhaseCallbacks(t *testing.T) {
+	w := newTestWorld(t)
+
+	var start int64
+	fired := map[string]int64{}
+	record := func(name string) func(tx *world.Tx) {
+		return func(tx *world.Tx) { fired[name] = tx.CurrentTick() }
+	}
+	ev := NewEvent(record("end"),
+		Phase{Text: "Prepare", Duration: time.Second / 4, Start: record("prepare start"), End: record("prepare end")},
+		Phase{Text: "Defend", Duration: time.Second / 2, Start: record("defend start"), End: record("defend end")},
+	)
+	doTx(t, w, func(tx *world.Tx) {
+		start = tx.CurrentTick()
+		ev.Start(tx)
+	})
+	for i := range 20 {
+		advanceTicks(w, 1)
+		if want := i < 14; ev.Running() != want {
+			t.Fatalf("event running after %v ticks = %v, want %v", i+1, ev.Running(), want)
+		}
+	}
+	for name, want := range map[string]int64{
+		"prepare start": start,
+		"prepare end":   start + 5,
+		"defend start":  start + 5,
+		"defend end":    start + 15,
+		"end":           start + 15,
+	} {
+		if got, ok := fired[name]; !ok || got != want {
+			t.Errorf("%v callback fired on tick %v (fired: %v), want tick %v", name, got-start, ok, want-start)
+		}
+	}
+}
+
+func TestEventProgress(t *testing.T) {
+	w := newTestWorld(t)
+
+	ev := NewEvent(nil, Phase{Text: "Defend the base", Duration: time.Minute * 5, Colour: Red()})
+	doTx(t, w, func(tx *world.Tx) { ev.Start(tx) })
+	check := func(text string, health float64) {
+		t.Helper()
+		bar := ev.BossBar()
+		if bar.Text() != text || !mgl64.FloatEqual(bar.HealthPercentage(), health) || bar.Colour() != Red() {
+			t.Errorf("boss bar = %q with health %v, want %q with health %v", bar.Text(), bar.HealthPercentage(), text, health)
+		}
+	}
+	check("Defend the base 5:00", 1)
+	advanceTicks(w, 1)
+	check("Defend the base 5:00", 5999.0/6000)
+	advanceTicks(w, 19)
+	check("Defend the base 4:59", 299.0/300)
+	advanceTicks(w, 2980)
+	check("Defend the base 2:30", 0.5)
+	if ev.Remaining() != time.Second*150 {
+		t.Errorf("time remaining halfway through the event = %v, want 2m30s", ev.Remaining())
+	}
+
+	// Ticks scheduled before stopping the event do not progress it after it is restarted.
+	doTx(t, w, func(tx *world.Tx) {
+		ev.Stop(tx)
+		ev.Start(tx)
+	})
+	advanceTicks(w, 20)
+	check("Defend the base 4:59", 299.0/300)
+}
@@ -0,0 +1,188 @@
+package chat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// Languages holds the translations of translation keys for one or more
+// languages, as loaded from .lang files such as those of a resource pack, so
+// that translation keys may be resolved to the language of a player on the
+// server. Translation keys can be used in a Translation by passing a Key to
+// Translate. Languages is safe for concurrent use.
+type Languages struct {
+	fallback language.Tag
+
+	mu      sync.RWMutex
+	tags    []language.Tag
+	matcher language.Matcher
+	keys    map[language.Tag]map[string]string
+}
+
+// NewLanguages creates an empty Languages. Keys are resolved to the fallback
+// language passed if they have no translation in the language requested.
+func NewLanguages(fallback language.Tag) *Languages {
+	return &Languages{fallback: fallback, keys: map[language.Tag]map[string]string{}}
+}
+
+// Load reads a .lang file from the io.Reader passed and adds its translations
+// to the language passed, overwriting existing translations of the same keys.
+// Every line of a .lang file holds a translation in the form 'key=value'.
+// Empty lines and lines starting with '#' are ignored, as is anything after a
+// tab followed by '#' in a value.
+func (l *Languages) Load(tag language.Tag, r io.Reader) error {
+	keys := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("load language %v: line %v: expected key=value, got %q", tag, n, line)
+		}
+		if i := strings.Index(value, "\t#"); i != -1 {
+			value = value[:i]
+		}
+		keys[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("load language %v: %w", tag, err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.keys[tag]; !ok {
+		l.keys[tag] = map[string]string{}
+		l.tags = append(l.tags, tag)
+		l.matcher = language.NewMatcher(l.tags)
+	}
+	for key, value := range keys {
+		l.keys[tag][key] = value
+	}
+	return nil
+}
+
+// LoadFS loads all .lang files in the directory dir of the fs.FS passed, such
+// as the 'texts' directory of a resource pack. The language of a file is
+// parsed from its name, such as 'en_GB.lang' or 'nl_NL.lang'.
+func (l *Languages) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return fmt.Errorf("load languages: %w", err)
+	}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".lang")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		tag, err := language.Parse(strings.ReplaceAll(name, "_", "-"))
+		if err != nil {
+			return fmt.Errorf("load languages: parse language of %v: %w", entry.Name(), err)
+		}
+		f, err := fsys.Open(path.Join(dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("load languages: %w", err)
+		}
+		err = l.Load(tag, f)
+		_ = f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Translate returns the translation of the key passed in the language loaded
+// that best matches the language passed. If the key has no translation in
+// that language, its translation in the fallback language is returned. False
+// is returned if neither has a translation of the key.
+func (l *Languages) Translate(key string, tag language.Tag) (string, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.matcher != nil {
+		if _, i, conf := l.matcher.Match(tag); conf != language.No {
+			if value, ok := l.keys[l.tags[i]][key]; ok {
+				return value, true
+			}
+		}
+	}
+	value, ok := l.keys[l.fallback][key]
+	return value, ok
+}
+
+// Key returns a Key for the translation key passed, which may be passed to
+// Translate to create a Translation that is resolved using the Languages.
+func (l *Languages) Key(key string) Key {
+	return Key{l: l, key: key}
+}
+
+// Key is a TranslationString of a translation key that is resolved using
+// Languages. Translations in .lang files may hold parameters in the form of
+// '%s', which are filled out in order, or '%1$s' and '%1', which are filled
+// out by their position.
+type Key struct {
+	l   *Languages
+	key string
+}
+
+// Resolve returns the translation of the Key in the language passed. If the
+// Key has no translation, the key itself is returned.
+func (k Key) Resolve(l language.Tag) string {
+	if value, ok := k.l.Translate(k.key, l); ok {
+		return value
+	}
+	return k.key
+}
+
+// fillParams fills out the parameters in the translated string passed, in
+// the same way that the client does.
+func fillParams(s string, params []string) string {
+	var b strings.Builder
+	next := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] != '%' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		if s[i+1] == '%' {
+			b.WriteByte('%')
+			i++
+			continue
+		}
+		if s[i+1] == 's' || s[i+1] == 'd' {
+			if next < len(params) {
+				b.WriteString(params[next])
+			}
+			next++
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+			j++
+		}
+		n, err := strconv.Atoi(s[i+1 : j])
+		if err != nil {
+			b.WriteByte(s[i])
+			continue
+		}
+		if n > 0 && n <= len(params) {
+			b.WriteString(params[n-1])
+		}
+		if strings.HasPrefix(s[j:], "$s") || strings.HasPrefix(s[j:], "$d") {
+			j += 2
+		}
+		i = j - 1
+	}
+	return b.String()
+}
@@ -0,0 +1,64 @@
+package chat
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"golang.org/x/text/language"
+)
+
+// langTestLanguages returns Languages with English and Dutch test translations
+// loaded, using English as fallback.
+func langTestLanguages(t *testing.T) *Languages {
+	t.Helper()
+	langs := NewLanguages(language.BritishEnglish)
+	err := langs.LoadFS(fstest.MapFS{
+		"texts/en_GB.lang": {Data: []byte("## Test translations\ntest.greeting=Welcome, %s!\ntest.order=%2$s before %1$s\t# Reversed order.\ntest.english=Only in English\n")},
+		"texts/nl_NL.lang": {Data: []byte("test.greeting=Welkom, %s!\ntest.order=%2$s voor %1$s\n")},
+	}, "texts")
+	if err != nil {
+		t.Fatalf("load languages: %v", err)
+	}
+	return langs
+}
+
+func TestLanguagesLoad(t *testing.T) {
+	langs := langTestLanguages(t)
+	if err := langs.Load(language.German, strings.NewReader("test.greeting")); err == nil {
+		t.Errorf("loading a .lang file with a line without '=' did not fail")
+	}
+	if s, ok := langs.Translate("test.greeting", language.MustParse("nl-NL")); !ok || s != "Welkom, %s!" {
+		t.Errorf("translation of test.greeting in Dutch = %q, %v, want \"Welkom, %%s!\", true", s, ok)
+	}
+}
+
+func TestLanguagesTranslation(t *testing.T) {
+	langs := langTestLanguages(t)
+	english, dutch := language.BritishEnglish, language.MustParse("nl-NL")
+
+	greeting := Translate(langs.Key("test.greeting"), 1, "Hello, %v!")
+	order := Translate(langs.Key("test.order"), 2, "%v, then %v").Enc("<yellow>%v</yellow>")
+	tests := []struct {
+		tag  language.Tag
+		t    Translation
+		args []any
+		want string
+	}{
+		{tag: english, t: greeting, args: []any{"Steve"}, want: "§rWelcome, Steve!"},
+		{tag: dutch, t: greeting, args: []any{"Steve"}, want: "§rWelkom, Steve!"},
+		{tag: english, t: order, args: []any{"A", "B"}, want: "§r§eB before A§r"},
+		{tag: dutch, t: order, args: []any{"A", "B"}, want: "§r§eB voor A§r"},
+		// Keys missing in the language passed fall back to the default
+		// language, and languages that were not loaded use it entirely.
+		{tag: dutch, t: Translate(langs.Key("test.english"), 0, "Fallback"), want: "§rOnly in English"},
+		{tag: language.German, t: greeting, args: []any{"Steve"}, want: "§rWelcome, Steve!"},
+		// Keys missing in all languages use the fallback of the Translation.
+		{tag: dutch, t: Translate(langs.Key("test.missing"), 1, "Missing %v"), args: []any{"key"}, want: "§rMissing key"},
+	}
+	for _, test := range tests {
+		if got := test.t.F(test.args...).Format(test.tag); got != test.want {
+			t.Errorf("translation for locale %v = %q, want %q", test.tag, got, test.want)
+		}
+	}
+}
@@ -20,6 +20,8 @@ var MessageNoSleep = Translate(str("%tile.bed.noSleep"), 0, `You can only sleep
 var MessageBedIsOccupied = Translate(str("%tile.bed.occupied"), 0, `This bed is occupied`).Enc("<grey>%v</grey>")
 var MessageSleeping = Translate(str("%chat.type.sleeping"), 2, `%v is sleeping in a bed. To skip to dawn, %v more users need to sleep in beds at the same time.`)
 var MessageBedNotValid = Translate(str("%tile.bed.notValid"), 0, `Your home bed was missing or obstructed`)
+var MessageRespawnAnchorPointSet = Translate(str("%tile.respawn_anchor.respawnSet"), 0, `Respawn point set`).Enc("<grey>%v</grey>")
+var MessageRespawnAnchorNotValid = Translate(str("%tile.respawn_anchor.notValid"), 0, `Your respawn anchor was out of charges, missing or obstructed`)
 
 type str string
 
@@ -119,6 +121,19 @@ func (t translation) Params(l language.Tag) []string {
 	return params
 }
 
+// Format translates the translation to the language passed and fills out its
+// parameters, so that it may be used as a plain message. Only a Key can be
+// translated on the server, so for other TranslationStrings, or if the Key has
+// no translation, Format returns the same as String.
+func (t translation) Format(l language.Tag) string {
+	if k, ok := t.t.str.(Key); ok {
+		if value, ok := k.l.Translate(k.key, l); ok {
+			return fillParams(text.Colourf(t.t.format, value), t.Params(l))
+		}
+	}
+	return t.String()
+}
+
 // String formats and returns the fallback value of the translation.
 func (t translation) String() string {
 	return fmt.Sprintf(text.Colourf(t.t.format, t.t.fallback), t.params...)
@@ -0,0 +1,85 @@
+package player_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+func TestCommandBlockUpdateRejected(t *testing.T) {
+	for name, conf := range map[string]player.Config{
+		// Creative players may not edit command blocks without a command
+		// block editor, which is not set by default.
+		"without editor": {GameMode: world.GameModeCreative},
+		"at denied position": {GameMode: world.GameModeCreative, CommandBlockEditor: func(_ *player.Player, pos cube.Pos) bool {
+			return pos != commandBlockTestPos
+		}},
+		"in survival mode": {CommandBlockEditor: func(*player.Player, cube.Pos) bool { return true }},
+	} {
+		w, _, conn := commandBlockTestPlayer(t, conf)
+		conn.send(commandBlockTestUpdate())
+		select {
+		case <-conn.closed:
+		case <-time.After(time.Second * 5):
+			t.Fatalf("%v: session of player editing command block without permission was not closed", name)
+		}
+		if cmd := commandBlockTestCommand(t, w); cmd != "" {
+			t.Errorf("%v: command of command block edited without permission = %q, want it unchanged", name, cmd)
+		}
+	}
+}
+
+func TestCommandBlockUpdate(t *testing.T) {
+	w, _, conn := commandBlockTestPlayer(t, player.Config{GameMode: world.GameModeCreative, CommandBlockEditor: func(_ *player.Player, pos cube.Pos) bool {
+		return pos == commandBlockTestPos
+	}})
+	conn.send(commandBlockTestUpdate())
+	deadline := time.Now().Add(time.Second * 5)
+	for commandBlockTestCommand(t, w) != "/say hi" {
+		if time.Now().After(deadline) {
+			t.Fatalf("command block edited by permitted player was not updated in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// commandBlockTestPos is the position of the command block placed by
+// commandBlockTestPlayer.
+var commandBlockTestPos = cube.Pos{1, 64, 1}
+
+// commandBlockTestPlayer spawns a player with a session like
+// spawnSessionTestPlayer, standing on a stone block next to a command block.
+func commandBlockTestPlayer(t *testing.T, conf player.Config) (*world.World, *world.EntityHandle, *sessionTestConn) {
+	t.Helper()
+	w, handle, conn := spawnSessionTestPlayer(t, conf)
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		tx.SetBlock(cube.Pos{0, 63, 0}, block.Stone{}, nil)
+		tx.SetBlock(commandBlockTestPos, block.CommandBlock{Mode: block.ImpulseCommandBlock()}, nil)
+		p.Teleport(mgl64.Vec3{0.5, 64, 0.5})
+	})
+	return w, handle, conn
+}
+
+// commandBlockTestUpdate returns a packet that sets the command of the command
+// block at commandBlockTestPos.
+func commandBlockTestUpdate() *packet.CommandBlockUpdate {
+	pos := commandBlockTestPos
+	return &packet.CommandBlockUpdate{Block: true, Position: protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}, Command: "/say hi", NeedsRedstone: true}
+}
+
+// commandBlockTestCommand returns the command of the command block at
+// commandBlockTestPos.
+func commandBlockTestCommand(t *testing.T, w *world.World) (cmd string) {
+	t.Helper()
+	doTx(t, w, func(tx *world.Tx) {
+		cmd = tx.Block(commandBlockTestPos).(block.CommandBlock).Command
+	})
+	return cmd
+}
@@ -1,14 +1,16 @@
 package player
 
 import (
+	"maps"
 	"math/rand/v2"
+	"slices"
 	"time"
 
-	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/skin"
 	"github.com/df-mc/dragonfly/server/session"
 	"github.com/df-mc/dragonfly/server/world"
@@ -46,7 +48,64 @@ type Config struct {
 	EnderChestInventory    *inventory.Inventory
 	FireTicks              int64
 	FallDistance           float64
+	TimeSinceRest          time.Duration
 	Effects                []effect.Effect
+
+	// UnlockedRecipes holds the names of the recipes unlocked by the player,
+	// as returned by recipe.Name.
+	UnlockedRecipes []string
+	// Tags holds the tags of the player, as added using Player.AddTag.
+	Tags []string
+	// InventoryGroups holds the state of the player in every inventory group
+	// other than that of the world it is in. See world.Config.InventoryGroup.
+	InventoryGroups map[string]InventoryGroupState
+
+	// JoinMessage and QuitMessage are the messages broadcast to the global
+	// chat when the player joins or quits. These may be changed by the
+	// Handler of the player in HandleJoin and HandleQuitMessage. If set, they must
+	// have exactly 1 parameter, which is the name of the player.
+	JoinMessage, QuitMessage chat.Translation
+
+	// MovementPolicy is the MovementPolicy that the movement and interactions
+	// of the player are validated against.
+	MovementPolicy MovementPolicy
+	// MovementStates controls the effects of sneaking and sprinting on the
+	// player. See Player.SetMovementStates.
+	MovementStates MovementStates
+
+	// GameModeOverride specifies if the game mode of the player is kept when
+	// it joins or moves to a world that forces its default game mode. See
+	// world.World.ForceGameMode.
+	GameModeOverride bool
+	// FlightAllowed specifies if the player may fly regardless of its game
+	// mode. Flying specifies if the player is flying when it is created, which
+	// only has an effect if it is able to fly. See Player.SetFlightAllowed.
+	FlightAllowed, Flying bool
+	// FlightSpeed and VerticalFlightSpeed are the base horizontal and
+	// vertical flight speeds of the player. If 0, they default to 0.05 and
+	// 1.0 respectively. See Player.SetFlightSpeed.
+	FlightSpeed, VerticalFlightSpeed float64
+	// Invulnerable specifies if the player is invulnerable, so that it does
+	// not take damage or knock back. InvulnerabilityBypass is called to check
+	// if damage from a source is still dealt to the player while it is
+	// invulnerable. If nil, only void damage is dealt. See
+	// Player.SetInvulnerable.
+	Invulnerable          bool
+	InvulnerabilityBypass func(src world.DamageSource) bool
+	// CommandBlockEditor is called to check if the player may view and edit
+	// the command block at a position. If nil, the player may not edit any
+	// command blocks. See Player.SetCommandBlockEditor.
+	CommandBlockEditor func(p *Player, pos cube.Pos) bool
+	// TeleportReloadDistance is the distance in blocks that the player must be
+	// teleported over for its chunks to be sent again and for it to be removed
+	// from and re-added to its viewers, so that the client does not render
+	// stale terrain and other players do not see the player interpolate to its
+	// new position. TeleportReloadDistance is 256 if 0 and teleports never
+	// reload if it is negative. Teleports to other worlds always reload.
+	TeleportReloadDistance float64
+	// Totem controls what happens when a totem of undying held by the player
+	// saves it from death. See Player.SetTotemConfig.
+	Totem TotemConfig
 }
 
 // Apply applies fields from a Config to a world.EntityData, filling out empty
@@ -55,39 +114,56 @@ func (cfg Config) Apply(data *world.EntityData) {
 	conf := fillDefaults(cfg)
 
 	data.Name, data.Pos, data.Rot = conf.Name, conf.Position, conf.Rotation
+	data.Tags = slices.Clone(conf.Tags)
 	slot := uint32(conf.HeldSlot)
 	pdata := &playerData{
-		xuid:                conf.XUID,
-		ui:                  inventory.New(54, nil),
-		inv:                 conf.Inventory,
-		enderChest:          conf.EnderChestInventory,
-		offHand:             conf.OffHand,
-		armour:              conf.Armour,
-		hunger:              newHungerManager(),
-		health:              entity.NewHealthManager(conf.Health, conf.MaxHealth), // 20, 20
-		experience:          entity.NewExperienceManager(),
-		effects:             entity.NewEffectManager(conf.Effects...),
-		locale:              conf.Locale,
-		cooldowns:           make(map[string]time.Time),
-		mc:                  &entity.MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
-		heldSlot:            &slot,
-		gameMode:            conf.GameMode,
-		skin:                conf.Skin,
-		enchantSeed:         conf.EnchantmentSeed,
-		s:                   conf.Session,
-		h:                   NopHandler{},
-		speed:               0.1,
-		flightSpeed:         0.05,
-		verticalFlightSpeed: 1.0,
-		scale:               1.0,
-		airSupplyTicks:      conf.AirSupply,
-		maxAirSupplyTicks:   conf.MaxAirSupply,
-		breathing:           true,
-		nameTag:             conf.Name,
-		fireTicks:           conf.FireTicks,
-		fallDistance:        conf.FallDistance,
+		xuid:                   conf.XUID,
+		ui:                     inventory.New(54, nil),
+		inv:                    conf.Inventory,
+		enderChest:             conf.EnderChestInventory,
+		offHand:                conf.OffHand,
+		armour:                 conf.Armour,
+		hunger:                 newHungerManager(),
+		health:                 entity.NewHealthManager(conf.Health, conf.MaxHealth), // 20, 20
+		experience:             entity.NewExperienceManager(),
+		effects:                entity.NewEffectManager(conf.Effects...),
+		locale:                 conf.Locale,
+		cooldowns:              make(map[string]time.Time),
+		mc:                     &entity.MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
+		heldSlot:               &slot,
+		gameMode:               conf.GameMode,
+		gameModeOverride:       conf.GameModeOverride,
+		flightAllowed:          conf.FlightAllowed,
+		flying:                 conf.Flying && (conf.FlightAllowed || conf.GameMode.AllowsFlying()),
+		skin:                   conf.Skin,
+		enchantSeed:            conf.EnchantmentSeed,
+		s:                      conf.Session,
+		h:                      NopHandler{},
+		speed:                  0.1,
+		flightSpeed:            conf.FlightSpeed,
+		verticalFlightSpeed:    conf.VerticalFlightSpeed,
+		scale:                  1.0,
+		airSupplyTicks:         conf.AirSupply,
+		maxAirSupplyTicks:      conf.MaxAirSupply,
+		breathing:              true,
+		nameTag:                conf.Name,
+		fireTicks:              conf.FireTicks,
+		fallDistance:           conf.FallDistance,
+		restTicks:              int64(conf.TimeSinceRest / (time.Second / 20)),
+		unlockedRecipes:        make(map[string]struct{}, len(conf.UnlockedRecipes)),
+		inventoryGroups:        make(map[string]InventoryGroupState, len(conf.InventoryGroups)),
+		joinMessage:            conf.JoinMessage,
+		quitMessage:            conf.QuitMessage,
+		movementPolicy:         conf.MovementPolicy,
+		movementStates:         conf.MovementStates,
+		teleportReloadDistance: conf.TeleportReloadDistance,
+		totem:                  conf.Totem,
+		pickupRadius:           entity.DefaultPickupRadius,
 	}
+	pdata.invulnerable, pdata.invulnerabilityBypass = conf.Invulnerable, conf.InvulnerabilityBypass
+	pdata.commandBlockEditor = conf.CommandBlockEditor
 	playerUUID := conf.UUID
+	pdata.freeze = &entity.FreezeComputer{}
 	pdata.portalTravel = &entity.PortalTravelComputer{
 		Instantaneous: func(_, target world.Dimension) bool {
 			// End travel is always instant regardless of game mode; End portals target the End in either direction.
@@ -97,12 +173,10 @@ func (cfg Config) Apply(data *world.EntityData) {
 			e.(*Player).forceTeleport(pos)
 		},
 		SpawnPoint: func(tx *world.Tx) mgl64.Vec3 {
-			// Use the player's spawn only while its bed still exists and is unobstructed, like respawning.
-			pos := tx.World().PlayerSpawn(playerUUID)
-			if b, ok := tx.Block(pos).(block.Bed); ok && b.CanRespawnOn() {
-				if safe, ok := b.SafeSpawn(pos, tx); ok {
-					return safe.Vec3Middle()
-				}
+			// Use the player's spawn only while its respawn block still exists and is unobstructed, like
+			// respawning.
+			if safe, ok := respawnBlockSpawn(tx.World().PlayerSpawn(playerUUID), tx); ok {
+				return safe.Vec3Middle()
 			}
 			return tx.World().Spawn().Vec3Middle()
 		},
@@ -112,6 +186,10 @@ func (cfg Config) Apply(data *world.EntityData) {
 	}
 	pdata.hunger.foodLevel, pdata.hunger.foodTick, pdata.hunger.exhaustionLevel, pdata.hunger.saturationLevel = conf.Food, conf.FoodTick, conf.Exhaustion, conf.Saturation
 	pdata.experience.Add(conf.Experience)
+	for _, name := range conf.UnlockedRecipes {
+		pdata.unlockedRecipes[name] = struct{}{}
+	}
+	maps.Copy(pdata.inventoryGroups, conf.InventoryGroups)
 	data.Data = pdata
 }
 
@@ -147,5 +225,11 @@ func fillDefaults(conf Config) Config {
 	if conf.GameMode == nil {
 		conf.GameMode = world.GameModeSurvival
 	}
+	if conf.FlightSpeed == 0 {
+		conf.FlightSpeed = 0.05
+	}
+	if conf.VerticalFlightSpeed == 0 {
+		conf.VerticalFlightSpeed = 1.0
+	}
 	return conf
 }
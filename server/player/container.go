@@ -0,0 +1,81 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// ContainerType is a type of container window that an inventory may be opened in using
+// Player.OpenVirtualContainer.
+type ContainerType struct {
+	t        containerType
+	readOnly bool
+}
+
+type containerType uint8
+
+const (
+	containerChest containerType = iota
+	containerDoubleChest
+	containerHopper
+)
+
+// ContainerChest returns a ContainerType for a chest window with 27 slots.
+func ContainerChest() ContainerType {
+	return ContainerType{t: containerChest}
+}
+
+// ContainerDoubleChest returns a ContainerType for a double chest window with 54 slots.
+func ContainerDoubleChest() ContainerType {
+	return ContainerType{t: containerDoubleChest}
+}
+
+// ContainerHopper returns a ContainerType for a hopper window with 5 slots.
+func ContainerHopper() ContainerType {
+	return ContainerType{t: containerHopper}
+}
+
+// Size returns the number of slots of the ContainerType.
+func (t ContainerType) Size() int {
+	switch t.t {
+	case containerDoubleChest:
+		return 54
+	case containerHopper:
+		return 5
+	default:
+		return 27
+	}
+}
+
+// ReadOnly returns true if players are prevented from changing the contents of a container of the
+// ContainerType.
+func (t ContainerType) ReadOnly() bool {
+	return t.readOnly
+}
+
+// WithReadOnly returns a copy of the ContainerType that prevents players from changing the contents of the
+// container if readOnly is true. Slot clicks are still passed to the inventory.Handler of the inventory, which
+// makes read-only containers suitable for menus.
+func (t ContainerType) WithReadOnly(readOnly bool) ContainerType {
+	t.readOnly = readOnly
+	return t
+}
+
+// virtualContainer returns a session.VirtualContainer of the ContainerType that shows the inventory and
+// title passed, with its fake block at pos.
+func (t ContainerType) virtualContainer(inv *inventory.Inventory, title string, pos cube.Pos) session.VirtualContainer {
+	var b world.Block = block.Chest{CustomName: title}
+	if t.t == containerHopper {
+		b = block.Hopper{CustomName: title}
+	}
+	return session.VirtualContainer{
+		Inventory: inv,
+		Block:     b,
+		Pos:       pos,
+		Pair:      t.t == containerDoubleChest,
+		ReadOnly:  t.readOnly,
+	}
+}
@@ -0,0 +1,166 @@
+package player_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// containerTestHandler records the slots taken from an inventory.
+type containerTestHandler struct {
+	inventory.NopHandler
+	taken []int
+}
+
+func (h *containerTestHandler) HandleTake(_ *inventory.Context, slot int, _ item.Stack) {
+	h.taken = append(h.taken, slot)
+}
+
+func TestOpenVirtualContainer(t *testing.T) {
+	w, handle, conn := spawnSessionTestPlayer(t, player.Config{})
+	inv := inventory.New(27, nil)
+	_ = inv.SetItem(0, item.NewStack(block.Stone{}, 1))
+
+	conn.reset()
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		if err := p.OpenVirtualContainer(inv, "Menu", player.ContainerChest()); err != nil {
+			t.Fatalf("open virtual container: %v", err)
+		}
+	})
+	pks := waitForPackets(t, conn, func(pks []packet.Packet) bool {
+		_, ok := containerTestContent(pks)
+		return ok
+	})
+	open := packetsOf[*packet.ContainerOpen](pks)
+	if len(open) != 1 || open[0].ContainerType != protocol.ContainerTypeContainer {
+		t.Fatalf("expected a single chest window to be opened, got %v", open)
+	}
+	if len(packetsOf[*packet.UpdateBlock](pks)) != 1 {
+		t.Fatalf("expected a fake chest to be shown for the window")
+	}
+	if content, _ := containerTestContent(pks); len(content.Content) != 27 {
+		t.Fatalf("expected the 27 slots of the inventory to be sent, got %v", len(content.Content))
+	}
+
+	// Changes made to the inventory while it is opened must be shown in the
+	// window.
+	conn.reset()
+	_ = inv.SetItem(1, item.NewStack(block.Dirt{}, 1))
+	pks = waitForPackets(t, conn, func(pks []packet.Packet) bool {
+		return len(packetsOf[*packet.InventorySlot](pks)) > 0
+	})
+	if slot := packetsOf[*packet.InventorySlot](pks)[0]; slot.WindowID != uint32(open[0].WindowID) || slot.Slot != 1 {
+		t.Fatalf("expected slot 1 of window %v to be updated, got slot %v of window %v", open[0].WindowID, slot.Slot, slot.WindowID)
+	}
+
+	conn.reset()
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.CloseContainer()
+	})
+	pks = waitForPackets(t, conn, func(pks []packet.Packet) bool {
+		return len(packetsOf[*packet.ContainerClose](pks)) > 0 && len(packetsOf[*packet.UpdateBlock](pks)) > 0
+	})
+	if closed := packetsOf[*packet.ContainerClose](pks)[0]; closed.WindowID != open[0].WindowID || !closed.ServerSide {
+		t.Fatalf("expected window %v to be closed by the server, got %+v", open[0].WindowID, closed)
+	}
+
+	// After closing the window, the session must no longer view the
+	// inventory.
+	conn.reset()
+	_ = inv.SetItem(2, item.NewStack(block.Dirt{}, 1))
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.Message("done")
+	})
+	pks = waitForPackets(t, conn, func(pks []packet.Packet) bool {
+		return len(packetsOf[*packet.Text](pks)) > 0
+	})
+	if slots := packetsOf[*packet.InventorySlot](pks); len(slots) != 0 {
+		t.Fatalf("expected no slot updates after closing the window, got %v", len(slots))
+	}
+}
+
+func TestOpenVirtualContainerSize(t *testing.T) {
+	w, handle, _ := spawnSessionTestPlayer(t, player.Config{})
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		if err := p.OpenVirtualContainer(inventory.New(27, nil), "Menu", player.ContainerDoubleChest()); err == nil {
+			t.Fatalf("expected error opening a double chest window for an inventory with 27 slots")
+		}
+		if err := p.OpenVirtualContainer(inventory.New(5, nil), "Menu", player.ContainerHopper()); err != nil {
+			t.Fatalf("open hopper window: %v", err)
+		}
+	})
+}
+
+func TestVirtualContainerReadOnly(t *testing.T) {
+	for _, readOnly := range []bool{false, true} {
+		w, handle, conn := spawnSessionTestPlayer(t, player.Config{})
+		inv, h := inventory.New(27, nil), &containerTestHandler{}
+		inv.Handle(h)
+		_ = inv.SetItem(0, item.NewStack(block.Stone{}, 1))
+
+		conn.reset()
+		withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+			if err := p.OpenVirtualContainer(inv, "Menu", player.ContainerChest().WithReadOnly(readOnly)); err != nil {
+				t.Fatalf("open virtual container: %v", err)
+			}
+		})
+		content, _ := containerTestContent(waitForPackets(t, conn, func(pks []packet.Packet) bool {
+			_, ok := containerTestContent(pks)
+			return ok
+		}))
+
+		take := &protocol.TakeStackRequestAction{}
+		take.Count = 1
+		take.Source = protocol.StackRequestSlotInfo{
+			Container:      protocol.FullContainerName{ContainerID: protocol.ContainerLevelEntity},
+			StackNetworkID: content.Content[0].StackNetworkID,
+		}
+		take.Destination = protocol.StackRequestSlotInfo{
+			Container: protocol.FullContainerName{ContainerID: protocol.ContainerCombinedHotBarAndInventory},
+		}
+		conn.send(&packet.ItemStackRequest{Requests: []protocol.ItemStackRequest{{RequestID: 1, Actions: []protocol.StackRequestAction{take}}}})
+		response := packetsOf[*packet.ItemStackResponse](waitForPackets(t, conn, func(pks []packet.Packet) bool {
+			return len(packetsOf[*packet.ItemStackResponse](pks)) > 0
+		}))[0].Responses[0]
+
+		wantStatus, wantLeft := uint8(protocol.ItemStackResponseStatusOK), 0
+		if readOnly {
+			wantStatus, wantLeft = protocol.ItemStackResponseStatusError, 1
+		}
+		if response.Status != wantStatus {
+			t.Fatalf("read-only %v: expected response status %v, got %v", readOnly, wantStatus, response.Status)
+		}
+		withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+			if it, _ := inv.Item(0); it.Count() != wantLeft {
+				t.Fatalf("read-only %v: expected %v items left in the container, got %v", readOnly, wantLeft, it.Count())
+			}
+			if it, _ := p.Inventory().Item(0); it.Count() != 1-wantLeft {
+				t.Fatalf("read-only %v: expected %v items in the inventory of the player, got %v", readOnly, 1-wantLeft, it.Count())
+			}
+			if len(h.taken) != 1 || h.taken[0] != 0 {
+				t.Fatalf("read-only %v: expected the slot click to be passed to the handler, got %v", readOnly, h.taken)
+			}
+		})
+	}
+}
+
+// containerTestContent returns the InventoryContent packet sent for the window
+// opened by the first ContainerOpen packet in pks.
+func containerTestContent(pks []packet.Packet) (*packet.InventoryContent, bool) {
+	open := packetsOf[*packet.ContainerOpen](pks)
+	if len(open) == 0 {
+		return nil, false
+	}
+	for _, content := range packetsOf[*packet.InventoryContent](pks) {
+		if content.WindowID == uint32(open[0].WindowID) {
+			return content, true
+		}
+	}
+	return nil, false
+}
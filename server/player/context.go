@@ -10,6 +10,8 @@ import (
 type Context struct {
 	*world.Context
 	p *Player
+
+	dropMode DropMode
 }
 
 // newContext returns a Context for one event dispatch concerning p.
@@ -21,6 +23,11 @@ func newContext(p *Player) *Context {
 // callback.
 func (ctx *Context) Player() *Player { return ctx.p }
 
+// SetDropMode changes what happens with the drops of the block broken in
+// Handler.HandleBlockBreak. By default, the drops are spawned as item entities.
+// SetDropMode has no effect in other handlers.
+func (ctx *Context) SetDropMode(mode DropMode) { ctx.dropMode = mode }
+
 // Defer schedules f to run on the owner after the current callback completes,
 // with the player re-resolved for that moment. The task fails with
 // world.ErrEntityClosed if the player's handle closed, or with
@@ -0,0 +1,24 @@
+package player
+
+// DropMode specifies what happens with the items dropped by a block that is broken by a player. A DropMode
+// may be set in Handler.HandleBlockBreak using Context.SetDropMode.
+type DropMode struct {
+	mode uint8
+}
+
+// DropItems returns the DropMode that spawns the drops of a block as item entities where the block was
+// broken. It is the default DropMode.
+func DropItems() DropMode {
+	return DropMode{mode: 0}
+}
+
+// DropNothing returns the DropMode that discards the drops of a block, so that no item entities are spawned.
+func DropNothing() DropMode {
+	return DropMode{mode: 1}
+}
+
+// CollectDrops returns the DropMode that adds the drops of a block directly to the inventory of the player
+// that broke it. Drops that do not fit in the inventory are spawned as item entities instead.
+func CollectDrops() DropMode {
+	return DropMode{mode: 2}
+}
@@ -0,0 +1,98 @@
+package player_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// dropTestHandler replaces the drops of blocks broken with drops and sets the
+// DropMode passed.
+type dropTestHandler struct {
+	player.NopHandler
+	drops []item.Stack
+	mode  player.DropMode
+}
+
+func (h dropTestHandler) HandleBlockBreak(ctx *player.Context, _ cube.Pos, drops *[]item.Stack, _ *int) {
+	*drops = h.drops
+	ctx.SetDropMode(h.mode)
+}
+
+func TestDropModeCollectOverflow(t *testing.T) {
+	w := newTestWorld(t)
+	handle := spawnTestPlayer(t, w, player.Config{})
+	pos := cube.Pos{1, 64, 0}
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		tx.SetBlock(pos, block.Dirt{}, nil)
+		inv := p.Inventory()
+		for slot := range inv.Size() - 1 {
+			_ = inv.SetItem(slot, item.NewStack(block.Stone{}, 64))
+		}
+		_ = inv.SetItem(inv.Size()-1, item.NewStack(block.Dirt{}, 60))
+
+		p.Handle(dropTestHandler{drops: []item.Stack{item.NewStack(block.Dirt{}, 10)}, mode: player.CollectDrops()})
+		p.BreakBlock(pos)
+
+		if it, _ := inv.Item(inv.Size() - 1); it.Count() != 64 {
+			t.Errorf("dirt in inventory after collecting drops = %v, want 64", it.Count())
+		}
+		var dropped int
+		for e := range tx.Entities() {
+			if e.H().Type() == entity.ItemType {
+				dropped += e.(*entity.Ent).Behaviour().(*entity.ItemBehaviour).Item().Count()
+			}
+		}
+		if dropped != 6 {
+			t.Errorf("dirt dropped after collecting drops into a full inventory = %v, want 6", dropped)
+		}
+	})
+}
+
+func TestDropModeCollect(t *testing.T) {
+	w := newTestWorld(t)
+	handle := spawnTestPlayer(t, w, player.Config{})
+	pos := cube.Pos{1, 64, 0}
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		tx.SetBlock(pos, block.Dirt{}, nil)
+		p.Handle(dropTestHandler{drops: []item.Stack{item.NewStack(block.Dirt{}, 1)}, mode: player.CollectDrops()})
+		p.BreakBlock(pos)
+
+		if items := p.Inventory().Items(); len(items) != 1 || !items[0].Equal(item.NewStack(block.Dirt{}, 1)) {
+			t.Errorf("inventory after collecting drops = %v, want 1 dirt", items)
+		}
+		for e := range tx.Entities() {
+			if e.H().Type() == entity.ItemType {
+				t.Errorf("item entity spawned after collecting drops into an empty inventory")
+			}
+		}
+	})
+}
+
+func TestDropModeNothing(t *testing.T) {
+	w := newTestWorld(t)
+	handle := spawnTestPlayer(t, w, player.Config{})
+	pos := cube.Pos{1, 64, 0}
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		tx.SetBlock(pos, block.Dirt{}, nil)
+		p.Handle(dropTestHandler{drops: []item.Stack{item.NewStack(block.Dirt{}, 1)}, mode: player.DropNothing()})
+		p.BreakBlock(pos)
+
+		if _, ok := tx.Block(pos).(block.Air); !ok {
+			t.Fatalf("block was not broken")
+		}
+		for e := range tx.Entities() {
+			if e.H().Type() == entity.ItemType {
+				t.Errorf("item entity spawned after breaking a block with DropNothing")
+			}
+		}
+		if !p.Inventory().Empty() {
+			t.Errorf("inventory not empty after breaking a block with DropNothing")
+		}
+	})
+}
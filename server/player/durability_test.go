@@ -0,0 +1,92 @@
+package player_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// durabilityTestHandler counts the calls to HandleItemDamage.
+type durabilityTestHandler struct {
+	player.NopHandler
+	calls *int
+}
+
+func (h durabilityTestHandler) HandleItemDamage(*player.Context, item.Stack, *int) {
+	*h.calls++
+}
+
+// durabilityTestBreak breaks n stone blocks with the held item passed and
+// returns the item held afterwards.
+func durabilityTestBreak(t *testing.T, conf player.Config, held item.Stack, n int) item.Stack {
+	held, _ = durabilityTestBreakCalls(t, conf, held, n)
+	return held
+}
+
+// durabilityTestBreakCalls breaks n stone blocks with the held item passed and
+// returns the item held afterwards and the number of calls to
+// HandleItemDamage.
+func durabilityTestBreakCalls(t *testing.T, conf player.Config, held item.Stack, n int) (item.Stack, int) {
+	t.Helper()
+	w := newTestWorld(t)
+	handle := spawnTestPlayer(t, w, conf)
+	pos := cube.Pos{1, 64, 0}
+	var calls int
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.Handle(durabilityTestHandler{calls: &calls})
+		p.SetHeldItems(held, item.Stack{})
+		for range n {
+			tx.SetBlock(pos, block.Stone{}, nil)
+			p.BreakBlock(pos)
+		}
+		held, _ = p.HeldItems()
+	})
+	return held, calls
+}
+
+func TestItemDamagedOnBlockBreak(t *testing.T) {
+	pickaxe := item.NewStack(item.Pickaxe{Tier: item.ToolTierIron}, 1)
+	held, calls := durabilityTestBreakCalls(t, player.Config{}, pickaxe, 1)
+	if held.Durability() != pickaxe.MaxDurability()-1 || calls != 1 {
+		t.Errorf("pickaxe durability and HandleItemDamage calls after breaking a block = %v, %v, want %v, 1", held.Durability(), calls, pickaxe.MaxDurability()-1)
+	}
+}
+
+func TestUnbreakableItemNotDamaged(t *testing.T) {
+	pickaxe := item.NewStack(item.Pickaxe{Tier: item.ToolTierIron}, 1).AsUnbreakable()
+	held, calls := durabilityTestBreakCalls(t, player.Config{}, pickaxe, 3)
+	if held.Durability() != pickaxe.MaxDurability() {
+		t.Errorf("unbreakable pickaxe durability after breaking blocks = %v, want %v", held.Durability(), pickaxe.MaxDurability())
+	}
+	if calls != 0 {
+		t.Errorf("HandleItemDamage called %v times for an unbreakable pickaxe, want 0", calls)
+	}
+}
+
+func TestItemNotDamagedInCreative(t *testing.T) {
+	pickaxe := item.NewStack(item.Pickaxe{Tier: item.ToolTierIron}, 1)
+	if got := durabilityTestBreak(t, player.Config{GameMode: world.GameModeCreative}, pickaxe, 3).Durability(); got != pickaxe.MaxDurability() {
+		t.Errorf("pickaxe durability after breaking blocks in creative = %v, want %v", got, pickaxe.MaxDurability())
+	}
+}
+
+func TestUnbreakingReducesItemDamage(t *testing.T) {
+	const breaks = 200
+	pickaxe := item.NewStack(item.Pickaxe{Tier: item.ToolTierIron}, 1).WithEnchantments(item.NewEnchantment(enchantment.Unbreaking, 3))
+	lost := pickaxe.MaxDurability() - durabilityTestBreak(t, player.Config{}, pickaxe, breaks).Durability()
+	if lost <= 0 || lost >= breaks {
+		t.Errorf("durability lost by pickaxe with unbreaking III after %v breaks = %v, want between 0 and %v", breaks, lost, breaks)
+	}
+}
+
+func TestItemRemovedWhenBroken(t *testing.T) {
+	pickaxe := item.NewStack(item.Pickaxe{Tier: item.ToolTierIron}, 1).WithDurability(1)
+	if held := durabilityTestBreak(t, player.Config{}, pickaxe, 1); !held.Empty() {
+		t.Errorf("held item after breaking pickaxe = %v, want empty", held)
+	}
+}
@@ -0,0 +1,58 @@
+package player_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// gameModeTestWorld returns a synchronous world that forces the creative game
+// mode on players entering it.
+func gameModeTestWorld(t *testing.T) *world.World {
+	t.Helper()
+	w := newTestWorld(t)
+	w.SetDefaultGameMode(world.GameModeCreative)
+	w.SetForceGameMode(true)
+	return w
+}
+
+func TestForceGameModeOnJoin(t *testing.T) {
+	for _, override := range []bool{false, true} {
+		w := gameModeTestWorld(t)
+		var want world.GameMode = world.GameModeCreative
+		if override {
+			want = world.GameModeSurvival
+		}
+		doTx(t, w, func(tx *world.Tx) {
+			handle := world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 64, 0.5}}.New(player.Type, player.Config{
+				Name:             "player",
+				GameMode:         world.GameModeSurvival,
+				GameModeOverride: override,
+			})
+			// The game mode must be changed as soon as the player is added,
+			// without waiting for the player to be ticked.
+			if mode := tx.AddEntity(handle).(*player.Player).GameMode(); mode != want {
+				t.Fatalf("override %v: expected game mode %v after joining, got %v", override, want, mode)
+			}
+		})
+	}
+}
+
+func TestForceGameModeOnWorldChange(t *testing.T) {
+	from, to := newTestWorld(t), gameModeTestWorld(t)
+	handle := spawnTestPlayer(t, from, player.Config{GameMode: world.GameModeSurvival})
+
+	withPlayer(t, from, handle, func(tx *world.Tx, p *player.Player) {
+		if mode := p.GameMode(); mode != world.GameModeSurvival {
+			t.Fatalf("expected survival game mode in a world that does not force it, got %v", mode)
+		}
+		tx.RemoveEntity(p)
+	})
+	doTx(t, to, func(tx *world.Tx) {
+		if mode := tx.AddEntity(handle).(*player.Player).GameMode(); mode != world.GameModeCreative {
+			t.Fatalf("expected creative game mode after changing world, got %v", mode)
+		}
+	})
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/cmd"
 	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/skin"
 	"github.com/df-mc/dragonfly/server/session"
 	"github.com/df-mc/dragonfly/server/world"
@@ -19,6 +21,10 @@ type Handler interface {
 	// HandleMove handles the movement of a player. ctx.Cancel() may be called to cancel the movement event.
 	// The new position, yaw and pitch are passed.
 	HandleMove(ctx *Context, newPos mgl64.Vec3, newRot cube.Rotation)
+	// HandleMoveViolation handles a movement reported by the client of a player that violates the
+	// MovementPolicy of the player. The position of the player before and after the movement is passed.
+	// ctx.Cancel() may be called to reject the movement, in which case the player is moved back to oldPos.
+	HandleMoveViolation(ctx *Context, v MoveViolation, oldPos, newPos mgl64.Vec3)
 	// HandleJump handles the player jumping.
 	HandleJump(p *Player)
 	// HandleTeleport handles the teleportation of a player. ctx.Cancel() may be called to cancel it.
@@ -69,7 +75,8 @@ type Handler interface {
 	HandleStartBreak(ctx *Context, pos cube.Pos)
 	// HandleBlockBreak handles a block that is being broken by a player. ctx.Cancel() may be called to cancel
 	// the block being broken. A pointer to a slice of the block's drops is passed, and may be altered
-	// to change what items will actually be dropped.
+	// to change what items will actually be dropped. ctx.SetDropMode() may be called to discard the drops
+	// or to add them to the inventory of the player directly.
 	HandleBlockBreak(ctx *Context, pos cube.Pos, drops *[]item.Stack, xp *int)
 	// HandleBlockPlace handles the player placing a specific block at a position in its world. ctx.Cancel()
 	// may be called to cancel the block being placed.
@@ -92,6 +99,15 @@ type Handler interface {
 	// the item actually does anything when used on an entity. It is also called if the player is holding no
 	// item.
 	HandleItemUseOnEntity(ctx *Context, e world.Entity)
+	// HandleBlockInteract handles the player interacting with a block, such as opening a door or a chest, at
+	// the block position passed. The face clicked and the relative click position are passed along with the
+	// block interacted with. HandleBlockInteract is only called for blocks that may be activated. Cancelling
+	// the context prevents both the block from being activated and the held item from being used.
+	HandleBlockInteract(ctx *Context, pos cube.Pos, face cube.Face, clickPos mgl64.Vec3, b world.Block)
+	// HandleEntityInteract handles the player interacting with an entity passed to the method. The click
+	// position is the position on the entity that was clicked. Cancelling the context prevents the held item
+	// from being used on the entity.
+	HandleEntityInteract(ctx *Context, e world.Entity, clickPos mgl64.Vec3)
 	// HandleItemRelease handles the player releasing an item after using it for
 	// a particular duration. These include items such as bows.
 	HandleItemRelease(ctx *Context, item item.Stack, dur time.Duration)
@@ -121,6 +137,16 @@ type Handler interface {
 	HandleSignEdit(ctx *Context, pos cube.Pos, frontSide bool, oldText, newText string)
 	// HandleSleep handles the player beginning the sleep action. ctx.Cancel() may be called to cancel the action.
 	HandleSleep(ctx *Context, sendReminder *bool)
+	// HandlePhantomSpawn handles phantoms spawning at a position above the player because it has not slept for
+	// longer than entity.InsomniaThreshold. Dragonfly does not implement phantoms itself, so the Handler must
+	// spawn the number of phantoms passed for them to appear.
+	HandlePhantomSpawn(p *Player, pos cube.Pos, count int)
+	// HandleContainerOpen handles the player opening a container. For containers backed by a block, such as
+	// chests, b is the block at pos. For virtual containers opened using Player.OpenVirtualContainer, b is nil
+	// and pos is the position of the fake block shown to the player. inv is the inventory of the container, or
+	// nil if it has none, such as for crafting tables. ctx.Cancel() may be called to prevent the container
+	// from opening.
+	HandleContainerOpen(ctx *Context, pos cube.Pos, b world.Block, inv *inventory.Inventory)
 	// HandleLecternPageTurn handles the player turning a page in a lectern. ctx.Cancel() may be called to cancel the
 	// page turn. The page number may be changed by assigning to *page.
 	HandleLecternPageTurn(ctx *Context, pos cube.Pos, oldPage int, newPage *int)
@@ -143,6 +169,16 @@ type Handler interface {
 	// HandleCommandExecution handles the command execution of a player, who wrote a command in the chat.
 	// ctx.Cancel() may be called to cancel the command execution.
 	HandleCommandExecution(ctx *Context, command cmd.Command, args []string)
+	// HandleJoin handles a player joining the server, after it has spawned in its world. message holds the
+	// message broadcast to the global chat and may be changed to broadcast a different message. Custom
+	// messages must take exactly one parameter, which is the name of the player. ctx.Cancel() may be called
+	// to join without broadcasting any message.
+	HandleJoin(ctx *Context, message *chat.Translation)
+	// HandleQuitMessage handles the message broadcast to the global chat when a player quits. It is called
+	// right before HandleQuit. message may be changed to broadcast a different message. Custom messages must
+	// take exactly one parameter, which is the name of the player. ctx.Cancel() may be called to quit without
+	// broadcasting any message.
+	HandleQuitMessage(ctx *Context, message *chat.Translation)
 	// HandleQuit handles the closing of a player. It is always called when the player is disconnected,
 	// regardless of the reason.
 	HandleQuit(p *Player)
@@ -160,40 +196,47 @@ type NopHandler struct{}
 // Compile time check to make sure NopHandler implements Handler.
 var _ Handler = NopHandler{}
 
-func (NopHandler) HandleItemDrop(*Context, item.Stack)                                     {}
-func (NopHandler) HandleHeldSlotChange(*Context, int, int)                                 {}
-func (NopHandler) HandleMove(*Context, mgl64.Vec3, cube.Rotation)                          {}
-func (NopHandler) HandleJump(*Player)                                                      {}
-func (NopHandler) HandleTeleport(*Context, mgl64.Vec3)                                     {}
-func (NopHandler) HandleChangeWorld(*Player, *world.World, *world.World)                   {}
-func (NopHandler) HandleToggleSprint(*Context, bool)                                       {}
-func (NopHandler) HandleToggleSneak(*Context, bool)                                        {}
-func (NopHandler) HandleCommandExecution(*Context, cmd.Command, []string)                  {}
-func (NopHandler) HandleTransfer(*Context, *net.UDPAddr)                                   {}
-func (NopHandler) HandleChat(*Context, *string)                                            {}
-func (NopHandler) HandleSkinChange(*Context, *skin.Skin)                                   {}
-func (NopHandler) HandleFireExtinguish(*Context, cube.Pos)                                 {}
-func (NopHandler) HandleStartBreak(*Context, cube.Pos)                                     {}
-func (NopHandler) HandleBlockBreak(*Context, cube.Pos, *[]item.Stack, *int)                {}
-func (NopHandler) HandleBlockPlace(*Context, cube.Pos, world.Block)                        {}
-func (NopHandler) HandleBlockPick(*Context, cube.Pos, world.Block)                         {}
-func (NopHandler) HandleSignEdit(*Context, cube.Pos, bool, string, string)                 {}
-func (NopHandler) HandleSleep(*Context, *bool)                                             {}
-func (NopHandler) HandleLecternPageTurn(*Context, cube.Pos, int, *int)                     {}
-func (NopHandler) HandleItemPickup(*Context, *item.Stack)                                  {}
-func (NopHandler) HandleItemUse(*Context)                                                  {}
-func (NopHandler) HandleItemUseOnBlock(*Context, cube.Pos, cube.Face, mgl64.Vec3)          {}
-func (NopHandler) HandleItemUseOnEntity(*Context, world.Entity)                            {}
-func (NopHandler) HandleItemRelease(ctx *Context, item item.Stack, dur time.Duration)      {}
-func (NopHandler) HandleItemConsume(*Context, item.Stack)                                  {}
-func (NopHandler) HandleItemDamage(*Context, item.Stack, *int)                             {}
-func (NopHandler) HandleAttackEntity(*Context, world.Entity, *float64, *float64, *bool)    {}
-func (NopHandler) HandleExperienceGain(*Context, *int)                                     {}
-func (NopHandler) HandlePunchAir(*Context)                                                 {}
-func (NopHandler) HandleHurt(*Context, *float64, bool, *time.Duration, world.DamageSource) {}
-func (NopHandler) HandleHeal(*Context, *float64, world.HealingSource)                      {}
-func (NopHandler) HandleFoodLoss(*Context, int, *int)                                      {}
-func (NopHandler) HandleDeath(*Player, world.DamageSource, *bool)                          {}
-func (NopHandler) HandleRespawn(*Player, *mgl64.Vec3, **world.World)                       {}
-func (NopHandler) HandleQuit(*Player)                                                      {}
-func (NopHandler) HandleDiagnostics(*Player, session.Diagnostics)                          {}
+func (NopHandler) HandleItemDrop(*Context, item.Stack)                                        {}
+func (NopHandler) HandleHeldSlotChange(*Context, int, int)                                    {}
+func (NopHandler) HandleMove(*Context, mgl64.Vec3, cube.Rotation)                             {}
+func (NopHandler) HandleMoveViolation(*Context, MoveViolation, mgl64.Vec3, mgl64.Vec3)        {}
+func (NopHandler) HandleJump(*Player)                                                         {}
+func (NopHandler) HandleTeleport(*Context, mgl64.Vec3)                                        {}
+func (NopHandler) HandleChangeWorld(*Player, *world.World, *world.World)                      {}
+func (NopHandler) HandleToggleSprint(*Context, bool)                                          {}
+func (NopHandler) HandleToggleSneak(*Context, bool)                                           {}
+func (NopHandler) HandleCommandExecution(*Context, cmd.Command, []string)                     {}
+func (NopHandler) HandleTransfer(*Context, *net.UDPAddr)                                      {}
+func (NopHandler) HandleChat(*Context, *string)                                               {}
+func (NopHandler) HandleSkinChange(*Context, *skin.Skin)                                      {}
+func (NopHandler) HandleFireExtinguish(*Context, cube.Pos)                                    {}
+func (NopHandler) HandleStartBreak(*Context, cube.Pos)                                        {}
+func (NopHandler) HandleBlockBreak(*Context, cube.Pos, *[]item.Stack, *int)                   {}
+func (NopHandler) HandleBlockPlace(*Context, cube.Pos, world.Block)                           {}
+func (NopHandler) HandleBlockPick(*Context, cube.Pos, world.Block)                            {}
+func (NopHandler) HandleSignEdit(*Context, cube.Pos, bool, string, string)                    {}
+func (NopHandler) HandleSleep(*Context, *bool)                                                {}
+func (NopHandler) HandlePhantomSpawn(*Player, cube.Pos, int)                                  {}
+func (NopHandler) HandleLecternPageTurn(*Context, cube.Pos, int, *int)                        {}
+func (NopHandler) HandleContainerOpen(*Context, cube.Pos, world.Block, *inventory.Inventory)  {}
+func (NopHandler) HandleItemPickup(*Context, *item.Stack)                                     {}
+func (NopHandler) HandleItemUse(*Context)                                                     {}
+func (NopHandler) HandleItemUseOnBlock(*Context, cube.Pos, cube.Face, mgl64.Vec3)             {}
+func (NopHandler) HandleItemUseOnEntity(*Context, world.Entity)                               {}
+func (NopHandler) HandleBlockInteract(*Context, cube.Pos, cube.Face, mgl64.Vec3, world.Block) {}
+func (NopHandler) HandleEntityInteract(*Context, world.Entity, mgl64.Vec3)                    {}
+func (NopHandler) HandleItemRelease(ctx *Context, item item.Stack, dur time.Duration)         {}
+func (NopHandler) HandleItemConsume(*Context, item.Stack)                                     {}
+func (NopHandler) HandleItemDamage(*Context, item.Stack, *int)                                {}
+func (NopHandler) HandleAttackEntity(*Context, world.Entity, *float64, *float64, *bool)       {}
+func (NopHandler) HandleExperienceGain(*Context, *int)                                        {}
+func (NopHandler) HandlePunchAir(*Context)                                                    {}
+func (NopHandler) HandleHurt(*Context, *float64, bool, *time.Duration, world.DamageSource)    {}
+func (NopHandler) HandleHeal(*Context, *float64, world.HealingSource)                         {}
+func (NopHandler) HandleFoodLoss(*Context, int, *int)                                         {}
+func (NopHandler) HandleDeath(*Player, world.DamageSource, *bool)                             {}
+func (NopHandler) HandleRespawn(*Player, *mgl64.Vec3, **world.World)                          {}
+func (NopHandler) HandleJoin(*Context, *chat.Translation)                                     {}
+func (NopHandler) HandleQuitMessage(*Context, *chat.Translation)                              {}
+func (NopHandler) HandleQuit(*Player)                                                         {}
+func (NopHandler) HandleDiagnostics(*Player, session.Diagnostics)                             {}
@@ -0,0 +1,89 @@
+package player_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// interactTestHandler records the arguments passed to HandleBlockInteract and
+// HandleEntityInteract and optionally cancels them.
+type interactTestHandler struct {
+	player.NopHandler
+	cancel bool
+
+	pos      cube.Pos
+	face     cube.Face
+	clickPos mgl64.Vec3
+	b        world.Block
+	e        world.Entity
+	usedItem bool
+}
+
+func (h *interactTestHandler) HandleBlockInteract(ctx *player.Context, pos cube.Pos, face cube.Face, clickPos mgl64.Vec3, b world.Block) {
+	h.pos, h.face, h.clickPos, h.b = pos, face, clickPos, b
+	if h.cancel {
+		ctx.Cancel()
+	}
+}
+
+func (h *interactTestHandler) HandleEntityInteract(ctx *player.Context, e world.Entity, clickPos mgl64.Vec3) {
+	h.e, h.clickPos = e, clickPos
+	if h.cancel {
+		ctx.Cancel()
+	}
+}
+
+func (h *interactTestHandler) HandleItemUseOnEntity(*player.Context, world.Entity) {
+	h.usedItem = true
+}
+
+func TestHandleBlockInteract(t *testing.T) {
+	for _, cancel := range []bool{false, true} {
+		w := newTestWorld(t)
+		handle := spawnTestPlayer(t, w, player.Config{})
+		doorPos, clickPos := cube.Pos{1, 64, 0}, mgl64.Vec3{0, 0.25, 0.5}
+		h := &interactTestHandler{cancel: cancel}
+		withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+			tx.SetBlock(doorPos, block.WoodDoor{Wood: block.OakWood()}, nil)
+			p.Handle(h)
+			p.UseItemOnBlock(doorPos, cube.FaceWest, clickPos)
+
+			if h.pos != doorPos || h.face != cube.FaceWest || h.clickPos != clickPos {
+				t.Errorf("block interaction passed pos, face, click pos %v, %v, %v, want %v, %v, %v", h.pos, h.face, h.clickPos, doorPos, cube.FaceWest, clickPos)
+			}
+			if _, ok := h.b.(block.WoodDoor); !ok {
+				t.Errorf("block interaction passed block %#v, want wood door", h.b)
+			}
+			if open := tx.Block(doorPos).(block.WoodDoor).Open; open == cancel {
+				t.Errorf("door open after interaction (cancelled: %v) = %v, want %v", cancel, open, !cancel)
+			}
+		})
+	}
+}
+
+func TestHandleEntityInteract(t *testing.T) {
+	for _, cancel := range []bool{false, true} {
+		w := newTestWorld(t)
+		handle := spawnTestPlayer(t, w, player.Config{})
+		other := spawnTestPlayer(t, w, player.Config{Name: "other", Position: mgl64.Vec3{1.5, 64, 0.5}})
+		clickPos := mgl64.Vec3{1.5, 65, 0.2}
+		h := &interactTestHandler{cancel: cancel}
+		withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+			e, _ := other.Entity(tx)
+			p.Handle(h)
+			valid := p.InteractWithEntity(e, clickPos)
+
+			if h.e != e || h.clickPos != clickPos {
+				t.Errorf("entity interaction passed entity, click pos %v, %v, want %v, %v", h.e, h.clickPos, e, clickPos)
+			}
+			if valid == cancel || h.usedItem == cancel {
+				t.Errorf("interaction valid and item used (cancelled: %v) = %v, %v, want %v, %v", cancel, valid, h.usedItem, !cancel, !cancel)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package player
+
+import (
+	"maps"
+
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// InventoryGroupState holds the state of a Player that is kept separately for
+// every inventory group of the worlds it visits, as set using
+// world.Config.InventoryGroup. When a Player moves to a world with a different
+// inventory group, its state is stored for the group it leaves and replaced
+// with the state it last had in the group it enters.
+type InventoryGroupState struct {
+	// Inventory, OffHand and EnderChest hold the items in the main inventory,
+	// offhand and ender chest inventory of the player.
+	Inventory  []item.Stack
+	OffHand    item.Stack
+	EnderChest []item.Stack
+	// Helmet, Chestplate, Leggings and Boots are the armour items worn by the
+	// player.
+	Helmet, Chestplate, Leggings, Boots item.Stack
+	// HeldSlot is the hotbar slot held by the player.
+	HeldSlot int
+
+	Health, MaxHealth      float64
+	Food                   int
+	Saturation, Exhaustion float64
+	Experience             int
+	Effects                []effect.Effect
+}
+
+// newInventoryGroupState returns the InventoryGroupState of a player entering
+// an inventory group for the first time: It has no items, effects or
+// experience and full health and food.
+func newInventoryGroupState() InventoryGroupState {
+	return InventoryGroupState{Health: 20, MaxHealth: 20, Food: 20, Saturation: 5}
+}
+
+// InventoryGroups returns the InventoryGroupState that the player last had in
+// every inventory group other than that of the world it is currently in.
+func (p *Player) InventoryGroups() map[string]InventoryGroupState {
+	return maps.Clone(p.inventoryGroups)
+}
+
+// switchInventoryGroup stores the current InventoryGroupState of the player
+// for the inventory group of the world the player left and applies the state
+// it last had in the group of the world it entered.
+func (p *Player) switchInventoryGroup(tx *world.Tx, from, to string) {
+	// The container the player had opened belongs to the world it left, so
+	// it cannot be used any longer. Items still held on the cursor or in the
+	// crafting grid stay with the group they were obtained in, unless the
+	// inventory is full, in which case they are dropped.
+	p.session().CloseContainer(tx)
+	for _, it := range p.ui.Clear() {
+		if n, err := p.inv.AddItem(it); err != nil {
+			p.Drop(it.Grow(-n))
+		}
+	}
+
+	p.inventoryGroups[from] = p.inventoryGroupState()
+	state, ok := p.inventoryGroups[to]
+	if !ok {
+		state = newInventoryGroupState()
+	}
+	delete(p.inventoryGroups, to)
+	p.applyInventoryGroupState(state)
+}
+
+// inventoryGroupState returns the current InventoryGroupState of the player.
+func (p *Player) inventoryGroupState() InventoryGroupState {
+	offHand, _ := p.offHand.Item(0)
+	p.hunger.mu.RLock()
+	defer p.hunger.mu.RUnlock()
+	return InventoryGroupState{
+		Inventory:  p.inv.Slots(),
+		OffHand:    offHand,
+		EnderChest: p.enderChest.Slots(),
+		Helmet:     p.armour.Helmet(),
+		Chestplate: p.armour.Chestplate(),
+		Leggings:   p.armour.Leggings(),
+		Boots:      p.armour.Boots(),
+		HeldSlot:   int(*p.heldSlot),
+		Health:     p.Health(),
+		MaxHealth:  p.MaxHealth(),
+		Food:       p.hunger.foodLevel,
+		Saturation: p.hunger.saturationLevel,
+		Exhaustion: p.hunger.exhaustionLevel,
+		Experience: p.experience.Experience(),
+		Effects:    p.Effects(),
+	}
+}
+
+// applyInventoryGroupState replaces the inventories, health, food, experience
+// and effects of the player with those in the InventoryGroupState passed.
+// The inventories themselves are kept, so that their viewers and handlers are
+// retained.
+func (p *Player) applyInventoryGroupState(state InventoryGroupState) {
+	p.inv.Clear()
+	for slot, it := range state.Inventory {
+		_ = p.inv.SetItem(slot, it)
+	}
+	p.enderChest.Clear()
+	for slot, it := range state.EnderChest {
+		_ = p.enderChest.SetItem(slot, it)
+	}
+	_ = p.offHand.SetItem(0, state.OffHand)
+	p.armour.Set(state.Helmet, state.Chestplate, state.Leggings, state.Boots)
+	if state.HeldSlot >= 0 && state.HeldSlot <= 8 {
+		*p.heldSlot = uint32(state.HeldSlot)
+		p.session().SendHeldSlot(state.HeldSlot, p, false)
+	}
+
+	if state.MaxHealth > 0 {
+		p.health.SetMaxHealth(state.MaxHealth)
+	}
+	p.addHealth(state.Health - p.Health())
+
+	p.hunger.mu.Lock()
+	p.hunger.foodLevel, p.hunger.saturationLevel, p.hunger.exhaustionLevel = state.Food, state.Saturation, state.Exhaustion
+	p.hunger.mu.Unlock()
+	p.sendFood()
+
+	p.experience.Reset()
+	p.experience.Add(state.Experience)
+	p.session().SendExperience(p.ExperienceLevel(), p.ExperienceProgress())
+
+	for _, e := range p.Effects() {
+		p.RemoveEffect(e.Type())
+	}
+	for _, e := range state.Effects {
+		p.AddEffect(e)
+	}
+	for _, viewer := range p.viewers() {
+		viewer.ViewEntityItems(p)
+		viewer.ViewEntityArmour(p)
+	}
+}
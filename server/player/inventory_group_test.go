@@ -0,0 +1,104 @@
+package player_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// inventoryGroupTestWorld returns a synchronous world with the inventory group
+// passed.
+func inventoryGroupTestWorld(t *testing.T, group string) *world.World {
+	t.Helper()
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry, InventoryGroup: group}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	return w
+}
+
+// changeTestWorld moves the player with the handle passed from one world to
+// another.
+func changeTestWorld(t *testing.T, from, to *world.World, handle *world.EntityHandle) {
+	t.Helper()
+	withPlayer(t, from, handle, func(tx *world.Tx, p *player.Player) {
+		tx.RemoveEntity(p)
+	})
+	doTx(t, to, func(tx *world.Tx) {
+		tx.AddEntity(handle)
+	})
+}
+
+func TestInventoryGroupSwitch(t *testing.T) {
+	lobby, survival, other := inventoryGroupTestWorld(t, "lobby"), inventoryGroupTestWorld(t, "survival"), inventoryGroupTestWorld(t, "lobby")
+	handle := spawnTestPlayer(t, lobby, player.Config{})
+	withPlayer(t, lobby, handle, func(tx *world.Tx, p *player.Player) {
+		_ = p.Inventory().SetItem(0, item.NewStack(block.Stone{}, 4))
+		_ = p.EnderChestInventory().SetItem(0, item.NewStack(block.Dirt{}, 2))
+		p.SetFood(10)
+		p.AddExperience(30)
+	})
+
+	// Entering a new inventory group starts with an empty inventory and the
+	// state of a new player.
+	changeTestWorld(t, lobby, survival, handle)
+	withPlayer(t, survival, handle, func(tx *world.Tx, p *player.Player) {
+		if it, _ := p.Inventory().Item(0); !it.Empty() {
+			t.Fatalf("expected an empty inventory in a new inventory group, got %v", it)
+		}
+		if it, _ := p.EnderChestInventory().Item(0); !it.Empty() {
+			t.Fatalf("expected an empty ender chest in a new inventory group, got %v", it)
+		}
+		if p.Food() != 20 || p.Experience() != 0 {
+			t.Fatalf("expected 20 food and no experience in a new inventory group, got %v food and %v experience", p.Food(), p.Experience())
+		}
+		if _, ok := p.InventoryGroups()["lobby"]; !ok {
+			t.Fatalf("expected the state of the lobby inventory group to be stored")
+		}
+		_ = p.Inventory().SetItem(0, item.NewStack(block.Dirt{}, 1))
+	})
+
+	// Entering another world of the lobby inventory group restores the state
+	// the player had when leaving it.
+	changeTestWorld(t, survival, other, handle)
+	withPlayer(t, other, handle, func(tx *world.Tx, p *player.Player) {
+		if it, _ := p.Inventory().Item(0); it.Count() != 4 || !it.Comparable(item.NewStack(block.Stone{}, 1)) {
+			t.Fatalf("expected 4 stone to be restored, got %v", it)
+		}
+		if it, _ := p.EnderChestInventory().Item(0); it.Count() != 2 {
+			t.Fatalf("expected 2 dirt in the ender chest to be restored, got %v", it)
+		}
+		if p.Food() != 10 || p.Experience() != 30 {
+			t.Fatalf("expected 10 food and 30 experience to be restored, got %v food and %v experience", p.Food(), p.Experience())
+		}
+		if _, ok := p.InventoryGroups()["lobby"]; ok {
+			t.Fatalf("expected no stored state for the inventory group the player is in")
+		}
+	})
+
+	changeTestWorld(t, other, survival, handle)
+	withPlayer(t, survival, handle, func(tx *world.Tx, p *player.Player) {
+		if it, _ := p.Inventory().Item(0); it.Count() != 1 || !it.Comparable(item.NewStack(block.Dirt{}, 1)) {
+			t.Fatalf("expected 1 dirt to be restored in the survival inventory group, got %v", it)
+		}
+	})
+}
+
+func TestInventoryGroupSameGroup(t *testing.T) {
+	a, b := inventoryGroupTestWorld(t, "lobby"), inventoryGroupTestWorld(t, "lobby")
+	handle := spawnTestPlayer(t, a, player.Config{})
+	withPlayer(t, a, handle, func(tx *world.Tx, p *player.Player) {
+		_ = p.Inventory().SetItem(0, item.NewStack(block.Stone{}, 4))
+	})
+	changeTestWorld(t, a, b, handle)
+	withPlayer(t, b, handle, func(tx *world.Tx, p *player.Player) {
+		if it, _ := p.Inventory().Item(0); it.Count() != 4 {
+			t.Fatalf("expected the inventory to be kept in a world of the same inventory group, got %v", it)
+		}
+		if groups := p.InventoryGroups(); len(groups) != 0 {
+			t.Fatalf("expected no stored inventory group states, got %v", groups)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package player_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// itemStackRequestTestTake sets the first slot of the inventory of the player
+// to the stack passed and sends a request to the session that takes count
+// items from that slot and puts them in the slot dest of the inventory. The
+// status of the response to the request is returned.
+func itemStackRequestTestTake(t *testing.T, w *world.World, handle *world.EntityHandle, conn *sessionTestConn, it item.Stack, count, dest byte) uint8 {
+	t.Helper()
+	conn.reset()
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		_ = p.Inventory().SetItem(0, it)
+	})
+	slots := packetsOf[*packet.InventorySlot](waitForPackets(t, conn, func(pks []packet.Packet) bool {
+		return len(packetsOf[*packet.InventorySlot](pks)) > 0
+	}))
+
+	take := &protocol.TakeStackRequestAction{}
+	take.Count = count
+	take.Source = protocol.StackRequestSlotInfo{
+		Container:      protocol.FullContainerName{ContainerID: protocol.ContainerCombinedHotBarAndInventory},
+		StackNetworkID: slots[0].NewItem.StackNetworkID,
+	}
+	take.Destination = protocol.StackRequestSlotInfo{
+		Container: protocol.FullContainerName{ContainerID: protocol.ContainerCombinedHotBarAndInventory},
+		Slot:      dest,
+	}
+	if dest == 0 {
+		take.Destination.StackNetworkID = slots[0].NewItem.StackNetworkID
+	}
+	conn.send(&packet.ItemStackRequest{Requests: []protocol.ItemStackRequest{{RequestID: 1, Actions: []protocol.StackRequestAction{take}}}})
+	return packetsOf[*packet.ItemStackResponse](waitForPackets(t, conn, func(pks []packet.Packet) bool {
+		return len(packetsOf[*packet.ItemStackResponse](pks)) > 0
+	}))[0].Responses[0].Status
+}
+
+func TestItemStackRequestBalanced(t *testing.T) {
+	w, handle, conn := spawnSessionTestPlayer(t, player.Config{})
+	if status := itemStackRequestTestTake(t, w, handle, conn, item.NewStack(block.Stone{}, 2), 1, 1); status != protocol.ItemStackResponseStatusOK {
+		t.Fatalf("expected request moving an item to another slot to succeed, got status %v", status)
+	}
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		a, _ := p.Inventory().Item(0)
+		b, _ := p.Inventory().Item(1)
+		if a.Count() != 1 || b.Count() != 1 {
+			t.Fatalf("expected 1 item in both slots, got %v and %v", a.Count(), b.Count())
+		}
+	})
+}
+
+func TestItemStackRequestDuplicationRejected(t *testing.T) {
+	w, handle, conn := spawnSessionTestPlayer(t, player.Config{})
+	// Taking an item from a slot and putting it back in the same slot passes
+	// the checks of the action itself, but would add an item to the slot.
+	if status := itemStackRequestTestTake(t, w, handle, conn, item.NewStack(block.Stone{}, 1), 1, 0); status != protocol.ItemStackResponseStatusError {
+		t.Fatalf("expected request duplicating an item to be rejected, got status %v", status)
+	}
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		if it, _ := p.Inventory().Item(0); it.Count() != 1 {
+			t.Fatalf("expected the inventory to be rolled back to 1 item, got %v", it.Count())
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package player_test
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"golang.org/x/text/language"
+)
+
+// joinQuitTestHandler replaces or cancels the join and quit messages of a
+// player.
+type joinQuitTestHandler struct {
+	player.NopHandler
+	cancel  bool
+	message chat.Translation
+	quit    *bool
+}
+
+func (h joinQuitTestHandler) HandleJoin(ctx *player.Context, message *chat.Translation) {
+	h.handle(ctx, message)
+}
+
+func (h joinQuitTestHandler) HandleQuitMessage(ctx *player.Context, message *chat.Translation) {
+	h.handle(ctx, message)
+}
+
+func (h joinQuitTestHandler) HandleQuit(*player.Player) {
+	if h.quit != nil {
+		*h.quit = true
+	}
+}
+
+func (h joinQuitTestHandler) handle(ctx *player.Context, message *chat.Translation) {
+	if h.cancel {
+		ctx.Cancel()
+	}
+	if !h.message.Zero() {
+		*message = h.message
+	}
+}
+
+// joinQuitTestString is a chat.TranslationString that is the same in every
+// language.
+type joinQuitTestString string
+
+func (s joinQuitTestString) Resolve(language.Tag) string { return string(s) }
+
+// joinQuitTestSubscriber records the messages written to the chat it is
+// subscribed to.
+type joinQuitTestSubscriber struct {
+	id       uuid.UUID
+	mu       sync.Mutex
+	messages []string
+}
+
+func (s *joinQuitTestSubscriber) UUID() uuid.UUID { return s.id }
+
+func (s *joinQuitTestSubscriber) Message(a ...any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, fmt.Sprint(a...))
+}
+
+func (s *joinQuitTestSubscriber) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.messages)
+}
+
+// subscribeJoinQuitTest subscribes a joinQuitTestSubscriber to chat.Global
+// for the duration of the test.
+func subscribeJoinQuitTest(t *testing.T) *joinQuitTestSubscriber {
+	s := &joinQuitTestSubscriber{id: uuid.New()}
+	chat.Global.Subscribe(s)
+	t.Cleanup(func() { chat.Global.Unsubscribe(s) })
+	return s
+}
+
+var joinQuitTestMessage = chat.Translate(joinQuitTestString("custom"), 1, "%v arrived")
+
+func TestJoinMessage(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		h    joinQuitTestHandler
+		want []string
+	}{
+		{name: "default", want: []string{chat.MessageJoin.F("player").String()}},
+		{name: "custom", h: joinQuitTestHandler{message: joinQuitTestMessage}, want: []string{joinQuitTestMessage.F("player").String()}},
+		{name: "suppressed", h: joinQuitTestHandler{cancel: true}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sub := subscribeJoinQuitTest(t)
+			w := newTestWorld(t)
+			handle := spawnTestPlayer(t, w, player.Config{JoinMessage: chat.MessageJoin})
+			withPlayer(t, w, handle, func(_ *world.Tx, p *player.Player) {
+				p.Handle(tc.h)
+				p.Join()
+				p.Join()
+			})
+			if got := sub.received(); !slices.Equal(got, tc.want) {
+				t.Errorf("messages broadcast on join = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestQuitMessage(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		h    joinQuitTestHandler
+		want []string
+	}{
+		{name: "default", want: []string{chat.MessageQuit.F("player").String()}},
+		{name: "custom", h: joinQuitTestHandler{message: joinQuitTestMessage}, want: []string{joinQuitTestMessage.F("player").String()}},
+		{name: "suppressed", h: joinQuitTestHandler{cancel: true}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sub := subscribeJoinQuitTest(t)
+			w, handle, conn := spawnSessionTestPlayer(t, player.Config{QuitMessage: chat.MessageQuit})
+			quit := false
+			tc.h.quit = &quit
+			withPlayer(t, w, handle, func(_ *world.Tx, p *player.Player) {
+				p.Handle(tc.h)
+				conn.reset()
+				_ = p.Close()
+			})
+			if got := sub.received(); !slices.Equal(got, tc.want) {
+				t.Errorf("messages broadcast on quit = %q, want %q", got, tc.want)
+			}
+			if !quit {
+				t.Errorf("HandleQuit was not called")
+			}
+			if n := len(packetsOf[*packet.Text](conn.packets())); n != 0 {
+				t.Errorf("quitting player was sent %v text packets, want 0", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package player
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// tickMaps draws the area surrounding the player onto the maps of the filled
+// map items it is holding and sends the changes of these maps to the player.
+func (p *Player) tickMaps(tx *world.Tx) {
+	main, off := p.HeldItems()
+	for _, held := range [...]item.Stack{main, off} {
+		filled, ok := held.Item().(item.FilledMap)
+		if !ok {
+			continue
+		}
+		m, ok := tx.World().Map(filled.MapID)
+		if !ok {
+			continue
+		}
+		m.Explore(tx, p.Position())
+		p.session().ViewMap(m, p.mapMarkers(tx, m))
+	}
+}
+
+// mapMarkers returns the markers of the player and of other players within
+// the area shown by the world.Map passed. No markers are returned if the map
+// was created in a different dimension.
+func (p *Player) mapMarkers(tx *world.Tx, m *world.Map) []world.MapMarker {
+	if tx.World().Dimension() != m.Dimension() {
+		return nil
+	}
+	markers := []world.MapMarker{m.PlayerMarker(p.Position(), p.Rotation().Yaw())}
+	for e := range tx.Players() {
+		other, ok := e.(*Player)
+		if !ok || other == p || other.Invisible() {
+			continue
+		}
+		if marker := m.PlayerMarker(other.Position(), other.Rotation().Yaw()); marker.Type == world.MapMarkerPlayer {
+			markers = append(markers, marker)
+		}
+	}
+	return markers
+}
@@ -0,0 +1,260 @@
+package player
+
+import (
+	"math"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// MovementPolicy holds the limits that the movement and interactions of a Player are validated against
+// server-side. The zero value of MovementPolicy uses the default reach distances and does not validate the
+// movement of a player.
+type MovementPolicy struct {
+	// Reach is the maximum distance in blocks between the eyes of a player that is not in creative mode and
+	// a block or entity that it interacts with. If 0, a Reach of 8 blocks is used.
+	Reach float64
+	// CreativeReach is the maximum distance in blocks between the eyes of a player in creative mode and a
+	// block or entity that it interacts with. If 0, a CreativeReach of 14 blocks is used.
+	CreativeReach float64
+	// AttackReach is the maximum distance in blocks between the eyes of a player that is not in creative mode
+	// and the bounding box of an entity that it attacks. If 0, the Reach of the MovementPolicy is used.
+	AttackReach float64
+	// CreativeAttackReach is the maximum distance in blocks between the eyes of a player in creative mode and
+	// the bounding box of an entity that it attacks. If 0, the CreativeReach of the MovementPolicy is used.
+	CreativeAttackReach float64
+	// ReachTolerance is a distance in blocks added to all reach distances of the MovementPolicy. It accounts
+	// for the latency of players, which may make targets seem further away on the server than on the client.
+	// If 0, no tolerance is added.
+	ReachTolerance float64
+	// MaxSpeed is the maximum horizontal distance in blocks that a player with the default movement speed
+	// may move in a single tick. The limit is scaled with the movement speed of the player, so that
+	// sprinting and speed effects are taken into account. If 0, the speed of players is not validated.
+	MaxSpeed float64
+	// MaxAirTicks is the maximum number of consecutive ticks that a player may move through the air without
+	// descending. If 0, players are not checked for flying.
+	MaxAirTicks int
+}
+
+// reach returns the maximum reach of a player in blocks, depending on if it is in creative mode.
+func (m MovementPolicy) reach(creative bool) float64 {
+	if creative {
+		if m.CreativeReach == 0 {
+			return 14 + m.ReachTolerance
+		}
+		return m.CreativeReach + m.ReachTolerance
+	}
+	if m.Reach == 0 {
+		return 8 + m.ReachTolerance
+	}
+	return m.Reach + m.ReachTolerance
+}
+
+// attackReach returns the maximum distance in blocks at which a player may attack an entity, depending on if
+// it is in creative mode.
+func (m MovementPolicy) attackReach(creative bool) float64 {
+	if creative && m.CreativeAttackReach != 0 {
+		return m.CreativeAttackReach + m.ReachTolerance
+	} else if !creative && m.AttackReach != 0 {
+		return m.AttackReach + m.ReachTolerance
+	}
+	return m.reach(creative)
+}
+
+// MovementStates controls the effects of sneaking and sprinting on the movement of a Player. The zero value of
+// MovementStates uses the vanilla effects.
+type MovementStates struct {
+	// SneakSpeed is the multiplier applied to the movement speed of a player while it is sneaking. If 0, a
+	// SneakSpeed of 0.3 is used.
+	SneakSpeed float64
+	// SprintSpeed is the multiplier applied to the movement speed of a player while it is sprinting. If 0, a
+	// SprintSpeed of 1.3 is used.
+	SprintSpeed float64
+	// SprintExhaustion is the exhaustion that a player sprinting receives for every block moved. If 0, a
+	// SprintExhaustion of 0.1 is used.
+	SprintExhaustion float64
+	// SprintJumpExhaustion is the exhaustion that a player receives when jumping while sprinting. If 0, a
+	// SprintJumpExhaustion of 0.2 is used.
+	SprintJumpExhaustion float64
+	// SprintKnockBack is the additional horizontal knockback dealt by a player attacking while sprinting. If 0,
+	// a SprintKnockBack of 0.5 is used.
+	SprintKnockBack float64
+	// DisableEdgeProtection disables the protection of sneaking players from walking off the edges of blocks.
+	// Edge protection only applies to players whose movement is simulated by the server. Players with a
+	// session are protected from walking off edges by their client.
+	DisableEdgeProtection bool
+}
+
+// sneakSpeed returns the multiplier applied to the speed of sneaking players.
+func (m MovementStates) sneakSpeed() float64 {
+	if m.SneakSpeed == 0 {
+		return 0.3
+	}
+	return m.SneakSpeed
+}
+
+// sprintSpeed returns the multiplier applied to the speed of sprinting players.
+func (m MovementStates) sprintSpeed() float64 {
+	if m.SprintSpeed == 0 {
+		return 1.3
+	}
+	return m.SprintSpeed
+}
+
+// sprintExhaustion returns the exhaustion per block moved of sprinting players.
+func (m MovementStates) sprintExhaustion() float64 {
+	if m.SprintExhaustion == 0 {
+		return 0.1
+	}
+	return m.SprintExhaustion
+}
+
+// sprintJumpExhaustion returns the exhaustion of players jumping while sprinting.
+func (m MovementStates) sprintJumpExhaustion() float64 {
+	if m.SprintJumpExhaustion == 0 {
+		return 0.2
+	}
+	return m.SprintJumpExhaustion
+}
+
+// sprintKnockBack returns the additional knockback dealt by players attacking while sprinting.
+func (m MovementStates) sprintKnockBack() float64 {
+	if m.SprintKnockBack == 0 {
+		return 0.5
+	}
+	return m.SprintKnockBack
+}
+
+// sprintJumpBoost is the horizontal velocity added in the direction that a player is facing when it jumps
+// while sprinting.
+const sprintJumpBoost = 0.2
+
+// movementSpeed returns the speed of the player with the effect of sneaking applied. Sprinting is already
+// applied to the speed returned by Speed.
+func (p *Player) movementSpeed() float64 {
+	if p.sneaking && !p.Flying() {
+		return p.speed * p.movementStates.sneakSpeed()
+	}
+	return p.speed
+}
+
+// backOffFromEdge reduces the horizontal movement delta passed so that a sneaking player standing on the ground
+// does not walk off the edge of the block it is standing on. The movement is reduced in steps of 0.05 blocks for
+// as long as there would be no block within step height below the player after moving.
+func (p *Player) backOffFromEdge(delta mgl64.Vec3) mgl64.Vec3 {
+	if !p.sneaking || p.Flying() || p.movementStates.DisableEdgeProtection || delta[1] > 0 {
+		return delta
+	}
+	const step, stepHeight = 0.05, 0.6
+	box := world.EntityBBox(p).Translate(p.Position())
+	unsupported := func(dx, dz float64) bool {
+		return !p.blockCollision(box.Translate(mgl64.Vec3{dx, -stepHeight, dz}))
+	}
+	approach := func(v float64) float64 {
+		if math.Abs(v) < step {
+			return 0
+		} else if v > 0 {
+			return v - step
+		}
+		return v + step
+	}
+	dx, dz := delta[0], delta[2]
+	for dx != 0 && unsupported(dx, 0) {
+		dx = approach(dx)
+	}
+	for dz != 0 && unsupported(0, dz) {
+		dz = approach(dz)
+	}
+	for dx != 0 && dz != 0 && unsupported(dx, dz) {
+		dx, dz = approach(dx), approach(dz)
+	}
+	return mgl64.Vec3{dx, delta[1], dz}
+}
+
+// blockCollision checks if the bounding box passed intersects with the model of any block.
+func (p *Player) blockCollision(box cube.BBox) bool {
+	low, high := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
+	for x := low[0]; x <= high[0]; x++ {
+		for y := low[1]; y <= high[1]; y++ {
+			for z := low[2]; z <= high[2]; z++ {
+				pos := cube.Pos{x, y, z}
+				for _, bb := range p.tx.Block(pos).Model().BBox(pos, p.tx) {
+					if bb.Translate(pos.Vec3()).IntersectsWith(box) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// MoveViolation is a violation of a MovementPolicy by the movement of a player. It is passed to
+// Handler.HandleMoveViolation.
+type MoveViolation struct {
+	violation uint8
+}
+
+// SpeedViolation returns the MoveViolation of a player moving faster horizontally than allowed by the
+// MovementPolicy.MaxSpeed.
+func SpeedViolation() MoveViolation {
+	return MoveViolation{violation: 0}
+}
+
+// FlyViolation returns the MoveViolation of a player staying in the air without descending for longer than
+// allowed by the MovementPolicy.MaxAirTicks.
+func FlyViolation() MoveViolation {
+	return MoveViolation{violation: 1}
+}
+
+// movementGraceTicks is the number of ticks after being knocked back or finishing a spin attack during which
+// the movement of a player is not validated, as it may still be moving faster than it could on its own.
+const movementGraceTicks = 20
+
+// validateMovement checks if the movement of the player from oldPos to newPos violates its MovementPolicy.
+// Players that are flying, gliding with an elytra, riding an entity, spin attacking or that were recently
+// knocked back move legitimately fast and are never checked. Players that are able to fly are never checked
+// for flying.
+func (p *Player) validateMovement(oldPos, newPos mgl64.Vec3) (MoveViolation, bool) {
+	policy := p.movementPolicy
+	if _, riding := p.Riding(); riding || p.Flying() || p.Gliding() || p.spinAttacking || p.movementGraceTicks > 0 {
+		p.airTicks = 0
+		return MoveViolation{}, false
+	}
+	delta := newPos.Sub(oldPos)
+	if policy.MaxSpeed > 0 {
+		horizontal := mgl64.Vec2{delta[0], delta[2]}.Len()
+		if horizontal > policy.MaxSpeed*(p.movementSpeed()/0.1) {
+			return SpeedViolation(), true
+		}
+	}
+	if policy.MaxAirTicks > 0 {
+		if p.OnGround() || delta[1] < 0 || p.CanFly() || p.supportedInAir(newPos) {
+			p.airTicks = 0
+		} else if p.airTicks++; p.airTicks > policy.MaxAirTicks {
+			p.airTicks = 0
+			return FlyViolation(), true
+		}
+	}
+	return MoveViolation{}, false
+}
+
+// supportedInAir checks if the player at pos may legitimately move through the air without descending, for
+// example because it is climbing a ladder, swimming or levitating.
+func (p *Player) supportedInAir(pos mgl64.Vec3) bool {
+	if _, ok := p.Effect(effect.Levitation); ok {
+		return true
+	}
+	bpos := cube.PosFromVec3(pos)
+	if _, ok := p.tx.Liquid(bpos); ok {
+		return true
+	}
+	switch p.tx.Block(bpos).(type) {
+	case block.Ladder, block.Vines:
+		return true
+	}
+	return false
+}
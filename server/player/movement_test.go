@@ -0,0 +1,189 @@
+package player_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// moveTestHandler records the calls to HandleMoveViolation and cancels them
+// if cancel is true.
+type moveTestHandler struct {
+	player.NopHandler
+	cancel bool
+
+	violations     []player.MoveViolation
+	oldPos, newPos mgl64.Vec3
+}
+
+func (h *moveTestHandler) HandleMoveViolation(ctx *player.Context, v player.MoveViolation, oldPos, newPos mgl64.Vec3) {
+	h.violations = append(h.violations, v)
+	h.oldPos, h.newPos = oldPos, newPos
+	if h.cancel {
+		ctx.Cancel()
+	}
+}
+
+// moveTestPolicy is the MovementPolicy of players in movement tests. Players
+// may move at most half a block every tick.
+var moveTestPolicy = player.MovementPolicy{MaxSpeed: 0.5}
+
+func TestClientMoveSpeedViolation(t *testing.T) {
+	for _, cancel := range []bool{false, true} {
+		w := newTestWorld(t)
+		handle := spawnTestPlayer(t, w, player.Config{MovementPolicy: moveTestPolicy})
+		h := &moveTestHandler{cancel: cancel}
+
+		withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+			p.Handle(h)
+			start := p.Position()
+			p.ClientMove(mgl64.Vec3{3, 0, 0}, 0, 0)
+
+			if len(h.violations) != 1 || h.violations[0] != player.SpeedViolation() {
+				t.Fatalf("cancel %v: expected a single speed violation, got %v", cancel, h.violations)
+			}
+			if h.oldPos != start || h.newPos != start.Add(mgl64.Vec3{3, 0, 0}) {
+				t.Fatalf("cancel %v: expected violation from %v to %v, got %v to %v", cancel, start, start.Add(mgl64.Vec3{3, 0, 0}), h.oldPos, h.newPos)
+			}
+			want := h.newPos
+			if cancel {
+				want = start
+			}
+			if pos := p.Position(); pos != want {
+				t.Fatalf("cancel %v: expected player at %v after the movement, got %v", cancel, want, pos)
+			}
+		})
+	}
+}
+
+func TestClientMoveWithinLimits(t *testing.T) {
+	w := newTestWorld(t)
+	handle := spawnTestPlayer(t, w, player.Config{MovementPolicy: moveTestPolicy})
+	h := &moveTestHandler{cancel: true}
+
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.Handle(h)
+		start := p.Position()
+		p.ClientMove(mgl64.Vec3{0.2, 0, 0}, 0, 0)
+		if len(h.violations) != 0 {
+			t.Fatalf("expected no violations for a slow movement, got %v", h.violations)
+		}
+		if pos := p.Position(); pos != start.Add(mgl64.Vec3{0.2, 0, 0}) {
+			t.Fatalf("expected player to move, got %v", pos)
+		}
+	})
+}
+
+func TestMoveNotValidated(t *testing.T) {
+	w := newTestWorld(t)
+	handle := spawnTestPlayer(t, w, player.Config{MovementPolicy: moveTestPolicy})
+	h := &moveTestHandler{cancel: true}
+
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.Handle(h)
+		start := p.Position()
+		p.Move(mgl64.Vec3{3, 0, 0}, 0, 0)
+		if len(h.violations) != 0 {
+			t.Fatalf("expected movement by the server not to be validated, got %v", h.violations)
+		}
+		if pos := p.Position(); pos != start.Add(mgl64.Vec3{3, 0, 0}) {
+			t.Fatalf("expected player to move, got %v", pos)
+		}
+	})
+}
+
+func TestClientMoveExempt(t *testing.T) {
+	tests := map[string]func(p *player.Player){
+		"elytra": func(p *player.Player) {
+			p.Armour().SetChestplate(item.NewStack(item.Elytra{}, 1))
+			p.StartGliding()
+			if !p.Gliding() {
+				t.Fatalf("expected player with elytra to glide")
+			}
+		},
+		"riptide": func(p *player.Player) {
+			p.StartSpinAttack()
+		},
+		"riptide finished": func(p *player.Player) {
+			p.StartSpinAttack()
+			p.StopSpinAttack()
+		},
+		"knockback": func(p *player.Player) {
+			p.KnockBack(p.Position().Sub(mgl64.Vec3{1, 0, 0}), 0.5, 0.4)
+		},
+	}
+	for name, f := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := newTestWorld(t)
+			handle := spawnTestPlayer(t, w, player.Config{MovementPolicy: moveTestPolicy})
+			h := &moveTestHandler{cancel: true}
+
+			withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+				p.Handle(h)
+				f(p)
+				start := p.Position()
+				p.ClientMove(mgl64.Vec3{2, 0, 0}, 0, 0)
+				if len(h.violations) != 0 {
+					t.Fatalf("expected no violations, got %v", h.violations)
+				}
+				if pos := p.Position(); pos != start.Add(mgl64.Vec3{2, 0, 0}) {
+					t.Fatalf("expected player to move to %v, got %v", start.Add(mgl64.Vec3{2, 0, 0}), pos)
+				}
+			})
+		})
+	}
+}
+
+func TestClientMoveKnockBackGraceExpires(t *testing.T) {
+	w := newTestWorld(t)
+	handle := spawnTestPlayer(t, w, player.Config{MovementPolicy: moveTestPolicy})
+	h := &moveTestHandler{cancel: true}
+
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.Handle(h)
+		p.KnockBack(p.Position().Sub(mgl64.Vec3{1, 0, 0}), 0.5, 0)
+	})
+	advanceTicks(w, 40)
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.ClientMove(mgl64.Vec3{3, 0, 0}, 0, 0)
+		if len(h.violations) != 1 {
+			t.Fatalf("expected movement to be validated again after knockback, got %v violations", len(h.violations))
+		}
+	})
+}
+
+func TestPlayerAuthInputSpeedViolation(t *testing.T) {
+	w, handle, conn := spawnSessionTestPlayer(t, player.Config{MovementPolicy: moveTestPolicy})
+	h := &moveTestHandler{cancel: true}
+	var start mgl64.Vec3
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.Handle(h)
+		start = p.Position()
+	})
+
+	conn.reset()
+	conn.send(&packet.PlayerAuthInput{
+		Position:  mgl32.Vec3{float32(start[0]) + 3, float32(start[1]) + 1.62, float32(start[2])},
+		InputData: protocol.NewBitset(packet.PlayerAuthInputBitsetSize),
+	})
+	pks := waitForPackets(t, conn, func(pks []packet.Packet) bool {
+		return len(packetsOf[*packet.MovePlayer](pks)) > 0
+	})
+	if pk := packetsOf[*packet.MovePlayer](pks)[0]; pk.Mode != packet.MoveModeTeleport {
+		t.Fatalf("expected player to be teleported back, got move mode %v", pk.Mode)
+	}
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		if len(h.violations) != 1 || h.violations[0] != player.SpeedViolation() {
+			t.Fatalf("expected a single speed violation, got %v", h.violations)
+		}
+		if pos := p.Position(); pos != start {
+			t.Fatalf("expected player to stay at %v, got %v", start, pos)
+		}
+	})
+}
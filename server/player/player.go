@@ -3,6 +3,7 @@ package player
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"math/rand/v2"
 	"net"
@@ -47,26 +48,44 @@ type playerData struct {
 	absorptionHealth  float64
 	scale             float64
 
-	gameMode world.GameMode
-	skin     skin.Skin
-	s        *session.Session
-	h        Handler
+	gameMode         world.GameMode
+	gameModeOverride bool
+	flightAllowed    bool
+	skin             skin.Skin
+	s                *session.Session
+	h                Handler
 
 	inv, offHand, enderChest, ui *inventory.Inventory
 	armour                       *inventory.Armour
 	heldSlot                     *uint32
 
 	sneaking, sprinting, swimming, gliding, crawling, flying,
-	invisible, immobile, onGround, usingItem bool
+	invisible, immobile, onGround, usingItem, glowing bool
 
 	sleeping bool
 	sleepPos cube.Pos
 
-	usingSince time.Time
+	noClip, noEntityCollision bool
+
+	invulnerable          bool
+	invulnerabilityBypass func(src world.DamageSource) bool
+
+	commandBlockEditor func(p *Player, pos cube.Pos) bool
+
+	// usingSince is the tick at which the player started using the item it is currently using.
+	usingSince int64
 
 	glideTicks   int64
 	fireTicks    int64
 	fallDistance float64
+	stepDistance float64
+	// soulSpeedLevel is the level of the Soul Speed enchantment that the speed of the player is currently
+	// boosted by, or 0 if the player is not on soul sand or soul soil.
+	soulSpeedLevel int
+
+	// restTicks is the number of ticks since the player last slept or died. phantomTicks is the number of
+	// ticks until it is next checked if phantoms should spawn around the player.
+	restTicks, phantomTicks int64
 
 	breathing         bool
 	airSupplyTicks    int
@@ -74,6 +93,12 @@ type playerData struct {
 
 	cooldowns map[string]time.Time
 
+	unlockedRecipes map[string]struct{}
+
+	// inventoryGroups holds the state of the player in every inventory group
+	// other than that of its current world.
+	inventoryGroups map[string]InventoryGroupState
+
 	speed               float64
 	flightSpeed         float64
 	verticalFlightSpeed float64
@@ -92,8 +117,28 @@ type playerData struct {
 
 	enchantSeed int64
 
+	joinMessage, quitMessage chat.Translation
+	joined                   bool
+
+	movementPolicy MovementPolicy
+	movementStates MovementStates
+	airTicks       int
+	// movementGraceTicks is the number of ticks for which the movement of the player is not validated, for
+	// example because it was knocked back.
+	movementGraceTicks int
+	spinAttacking      bool
+
+	teleportReloadDistance float64
+	totem                  TotemConfig
+
+	pickupRadius float64
+	pickupFilter func(s item.Stack) bool
+
 	mc           *entity.MovementComputer
 	portalTravel *entity.PortalTravelComputer
+	freeze       *entity.FreezeComputer
+
+	riding *world.EntityHandle
 
 	collidedVertically, collidedHorizontally bool
 
@@ -243,6 +288,15 @@ func (p *Player) Messaget(t chat.Translation, a ...any) {
 	p.session().SendTranslation(t, p.locale, a)
 }
 
+// Translate translates the Translation passed to the locale of the player and
+// parameterises it using the arguments passed, returning it as a plain message.
+// Only translations of a chat.Key can be resolved on the server, so that the
+// fallback of the Translation is returned for other translations. Translate
+// panics if an incorrect amount of arguments is passed.
+func (p *Player) Translate(t chat.Translation, a ...any) string {
+	return t.F(a...).Format(p.locale)
+}
+
 // SendPopup sends a formatted popup to the player. The popup is shown above the hotbar of the player and
 // overwrites/is overwritten by the name of the item equipped.
 // The popup is formatted following the rules of fmt.Sprintln without a newline at the end.
@@ -295,6 +349,20 @@ func (p *Player) SendTitle(t title.Title) {
 	}
 }
 
+// RemoveTitle removes the title, subtitle and action text currently shown to the player, if any. The durations
+// of titles sent after are reset to the defaults of the client, until a new title.Title is sent with
+// Player.SendTitle.
+func (p *Player) RemoveTitle() {
+	p.session().RemoveTitle()
+}
+
+// SendActionBar sends a formatted action bar message to the player. The message is shown above the hotbar of
+// the player, similar to a popup, for the duration of the last title sent to the player.
+// The message is formatted following the rules of fmt.Sprintln without a newline at the end.
+func (p *Player) SendActionBar(a ...any) {
+	p.session().SendActionBarMessage(format(a))
+}
+
 // SendScoreboard sends a scoreboard to the player. The scoreboard will be present indefinitely until removed
 // by the caller.
 // SendScoreboard may be called at any time to change the scoreboard of the player.
@@ -443,6 +511,56 @@ func (p *Player) NameTag() string {
 	return p.nameTag
 }
 
+// Tags returns the tags of the player, as added using AddTag.
+func (p *Player) Tags() []string {
+	return slices.Clone(p.data.Tags)
+}
+
+// HasTag checks if the player has the tag passed.
+func (p *Player) HasTag(tag string) bool {
+	return slices.Contains(p.data.Tags, tag)
+}
+
+// AddTag adds a tag to the player. Tags are saved with the player data and
+// may be used to select the player in commands. False is returned if the
+// player already had the tag.
+func (p *Player) AddTag(tag string) bool {
+	if p.HasTag(tag) {
+		return false
+	}
+	p.data.Tags = append(p.data.Tags, tag)
+	return true
+}
+
+// RemoveTag removes a tag from the player. False is returned if the player
+// did not have the tag.
+func (p *Player) RemoveTag(tag string) bool {
+	n := len(p.data.Tags)
+	p.data.Tags = slices.DeleteFunc(p.data.Tags, func(t string) bool { return t == tag })
+	return len(p.data.Tags) != n
+}
+
+// Score returns the score of the player for the objective with the name
+// passed in the world.Objectives of its world. False is returned if the
+// player has no score for the objective.
+func (p *Player) Score(objective string) (int, bool) {
+	return p.tx.World().Objectives().Score(objective, p.UUID())
+}
+
+// SendObjective sends a scoreboard to the player that displays the highest
+// scores of the world.Objective passed. Like SendScoreboard, the scoreboard
+// is not updated automatically when the scores of the objective change.
+func (p *Player) SendObjective(o *world.Objective) {
+	sb := scoreboard.New(o.DisplayName())
+	for i, s := range o.Scores() {
+		if i == 15 {
+			break
+		}
+		sb.Set(i, fmt.Sprintf("%v: %v", s.Name, s.Score))
+	}
+	p.SendScoreboard(sb)
+}
+
 // SetScoreTag changes the score tag displayed over the player in-game. The score tag is displayed under the player's
 // name tag.
 func (p *Player) SetScoreTag(a ...any) {
@@ -469,6 +587,32 @@ func (p *Player) Speed() float64 {
 	return p.speed
 }
 
+// MovementPolicy returns the MovementPolicy that the movement and interactions of the player are validated
+// against.
+func (p *Player) MovementPolicy() MovementPolicy {
+	return p.movementPolicy
+}
+
+// SetMovementPolicy changes the MovementPolicy that the movement and interactions of the player are validated
+// against.
+func (p *Player) SetMovementPolicy(policy MovementPolicy) {
+	p.movementPolicy, p.airTicks = policy, 0
+}
+
+// MovementStates returns the MovementStates that control the effects of sneaking and sprinting on the player.
+func (p *Player) MovementStates() MovementStates {
+	return p.movementStates
+}
+
+// SetMovementStates changes the MovementStates that control the effects of sneaking and sprinting on the
+// player. If the player is sprinting, its speed is updated to the new sprint speed.
+func (p *Player) SetMovementStates(states MovementStates) {
+	if p.sprinting {
+		p.SetSpeed(p.speed / p.movementStates.sprintSpeed() * states.sprintSpeed())
+	}
+	p.movementStates = states
+}
+
 // SetFlightSpeed sets the flight speed of the player. The value passed represents the base speed, which is
 // multiplied by 10 to obtain the actual blocks/tick speed that the player will then obtain while flying.
 func (p *Player) SetFlightSpeed(flightSpeed float64) {
@@ -543,6 +687,8 @@ func (p *Player) Heal(health float64, source world.HealingSource) float64 {
 // updateFallState is called to update the entities falling state.
 func (p *Player) updateFallState(distanceThisTick float64) {
 	switch {
+	case p.Flying():
+		p.ResetFallDistance()
 	case p.OnGround():
 		p.fallDistance -= distanceThisTick
 		if p.fallDistance > 3 {
@@ -558,17 +704,7 @@ func (p *Player) updateFallState(distanceThisTick float64) {
 
 // fall is called when a falling entity hits the ground.
 func (p *Player) fall(distance float64) {
-	pos := cube.PosFromVec3(p.Position())
-	b := p.tx.Block(pos)
-
-	if len(b.Model().BBox(pos, p.tx)) == 0 {
-		pos = pos.Sub(cube.Pos{0, 1})
-		b = p.tx.Block(pos)
-	}
-	if h, ok := b.(block.EntityLander); ok {
-		h.EntityLand(pos, p.tx, p, &distance)
-	}
-	dmg := distance - 3
+	dmg := entity.Land(p, distance, p.tx) - 3
 	if boost, ok := p.Effect(effect.JumpBoost); ok {
 		dmg -= float64(boost.Level())
 	}
@@ -590,6 +726,12 @@ func (p *Player) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 	if _, ok := p.Effect(effect.FireResistance); (ok && src.Fire()) || p.Dead() || !p.GameMode().AllowsTakingDamage() || dmg < 0 {
 		return 0, false
 	}
+	if p.invulnerable && !p.bypassesInvulnerability(src) {
+		return 0, false
+	}
+	if attacker, ok := damageAttacker(src); ok && !p.tx.World().DamageAllowed(attacker, p) {
+		return 0, false
+	}
 	totalDamage := p.FinalDamageFrom(dmg, src)
 	damageLeft := totalDamage
 
@@ -606,6 +748,7 @@ func (p *Player) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 		return 0, false
 	}
 	p.setAttackImmunity(immunity, totalDamage)
+	finalDamage := damageLeft
 
 	if a := p.Absorption(); a > 0 {
 		remaining := a - damageLeft
@@ -616,21 +759,15 @@ func (p *Player) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 		}
 	}
 
-	if p.Health()-damageLeft <= mgl64.Epsilon && !src.IgnoreTotem() {
-		hand, offHand := p.HeldItems()
-		if _, ok := offHand.Item().(item.Totem); ok {
-			p.applyTotemEffects()
-			p.SetHeldItems(hand, offHand.Grow(-1))
-			return 0, false
-		} else if _, ok := hand.Item().(item.Totem); ok {
-			p.applyTotemEffects()
-			p.SetHeldItems(hand.Grow(-1), offHand)
-			return 0, false
-		}
+	if p.Health()-damageLeft <= mgl64.Epsilon && !src.IgnoreTotem() && p.useTotem() {
+		return 0, false
 	}
 
 	p.addHealth(-damageLeft)
 
+	attacker, _ := damageAttacker(src)
+	p.tx.World().ReportDamage(p.tx, world.Damage{Attacker: attacker, Victim: p, Damage: dmg, FinalDamage: finalDamage, Source: src})
+
 	if src.ReducedByArmour() {
 		p.Exhaust(0.1)
 		p.Armour().Damage(dmg, p.damageItem)
@@ -659,6 +796,7 @@ func (p *Player) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 	}
 
 	p.Wake()
+	p.stopConsuming()
 
 	if p.Dead() {
 		p.kill(src)
@@ -666,23 +804,49 @@ func (p *Player) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 	return totalDamage, true
 }
 
-// applyTotemEffects is an unexported function that is used to handle totem effects.
-func (p *Player) applyTotemEffects() {
-	p.addHealth(2 - p.Health())
+// Invulnerable checks if the player is invulnerable. An invulnerable player is not hurt by damage, unless
+// the damage source bypasses invulnerability, and is not knocked back.
+func (p *Player) Invulnerable() bool {
+	return p.invulnerable
+}
 
-	for _, e := range p.Effects() {
-		p.RemoveEffect(e.Type())
+// SetInvulnerable sets if the player is invulnerable, independently of its game mode. While invulnerable, the
+// player only takes damage from sources that bypass invulnerability, as decided by the function set using
+// SetInvulnerabilityBypass, and is not knocked back.
+func (p *Player) SetInvulnerable(v bool) {
+	if p.invulnerable == v {
+		return
 	}
+	p.invulnerable = v
+	p.session().SendAbilities(p)
+}
 
-	p.AddEffect(effect.New(effect.Regeneration, 2, time.Second*40))
-	p.AddEffect(effect.New(effect.FireResistance, 1, time.Second*40))
-	p.AddEffect(effect.New(effect.Absorption, 2, time.Second*5))
+// SetInvulnerabilityBypass sets the function called to check if damage from a source is still dealt to the
+// player while it is invulnerable. If f is nil, only entity.VoidDamageSource bypasses invulnerability, so that
+// invulnerable players that fall out of the world still die.
+func (p *Player) SetInvulnerabilityBypass(f func(src world.DamageSource) bool) {
+	p.invulnerabilityBypass = f
+}
 
-	p.tx.PlaySound(p.Position(), sound.Totem{})
+// bypassesInvulnerability checks if damage from the source passed is dealt to the player while it is
+// invulnerable.
+func (p *Player) bypassesInvulnerability(src world.DamageSource) bool {
+	if p.invulnerabilityBypass != nil {
+		return p.invulnerabilityBypass(src)
+	}
+	_, void := src.(entity.VoidDamageSource)
+	return void
+}
 
-	for _, viewer := range p.viewers() {
-		viewer.ViewEntityAction(p, entity.TotemUseAction{})
+// damageAttacker returns the entity responsible for the damage source passed, if any.
+func damageAttacker(src world.DamageSource) (world.Entity, bool) {
+	switch s := src.(type) {
+	case entity.AttackDamageSource:
+		return s.Attacker, s.Attacker != nil
+	case entity.ProjectileDamageSource:
+		return s.Owner, s.Owner != nil
 	}
+	return nil, false
 }
 
 // FinalDamageFrom resolves the final damage received by the player if it is attacked by the source passed
@@ -700,10 +864,13 @@ func (p *Player) FinalDamageFrom(dmg float64, src world.DamageSource) float64 {
 }
 
 // Explode ...
-func (p *Player) Explode(explosionPos mgl64.Vec3, impact float64, c block.ExplosionConfig) {
-	diff := p.Position().Sub(explosionPos)
-	p.Hurt(math.Floor((impact*impact+impact)*3.5*c.Size*2+1), entity.ExplosionDamageSource{})
-	p.knockBack(explosionPos, impact, diff[1]/diff.Len()*impact)
+func (p *Player) Explode(_ mgl64.Vec3, impact world.ExplosionImpact, _ block.ExplosionConfig) {
+	if impact.Damage > 0 {
+		p.Hurt(impact.Damage, entity.ExplosionDamageSource{})
+	}
+	if impact.Knockback != (mgl64.Vec3{}) && !p.invulnerable {
+		p.SetVelocity(impact.Knockback.Mul(1 - p.Armour().KnockBackResistance()))
+	}
 }
 
 // SetAbsorption sets the absorption health of a player. This extra health shows as golden hearts and do not
@@ -723,7 +890,7 @@ func (p *Player) Absorption() float64 {
 // source of the velocity, typically the position of an attacking entity. The source is used to calculate the
 // direction which the entity should be knocked back in.
 func (p *Player) KnockBack(src mgl64.Vec3, force, height float64) {
-	if p.Dead() || !p.GameMode().AllowsTakingDamage() {
+	if p.Dead() || !p.GameMode().AllowsTakingDamage() || p.invulnerable {
 		return
 	}
 	p.knockBack(src, force, height)
@@ -741,6 +908,7 @@ func (p *Player) knockBack(src mgl64.Vec3, force, height float64) {
 	velocity[1] = height
 
 	p.SetVelocity(velocity.Mul(1 - p.Armour().KnockBackResistance()))
+	p.movementGraceTicks = movementGraceTicks
 }
 
 // setAttackImmunity sets the duration the player is immune to entity attacks.
@@ -814,6 +982,11 @@ func (*Player) BeaconAffected() bool {
 	return true
 }
 
+// ConduitAffected ...
+func (*Player) ConduitAffected() bool {
+	return true
+}
+
 // Exhaust exhausts the player by the amount of points passed if the player is in survival mode. If the total
 // exhaustion level exceeds 4, a saturation point, or food point, if saturation is 0, will be subtracted.
 func (p *Player) Exhaust(points float64) {
@@ -868,6 +1041,8 @@ func (p *Player) kill(src world.DamageSource) {
 
 	keepInv := false
 	p.Handler().HandleDeath(p, src, &keepInv)
+	p.tx.World().Objectives().AddCriteriaScore(p, world.ObjectiveCriteriaDeathCount, 1)
+	p.restTicks = 0
 	p.StopSneaking()
 	p.StopSprinting()
 
@@ -961,8 +1136,14 @@ func (p *Player) respawn(f func(p *Player)) {
 	blockPos, w, spawnObstructed, _ := p.spawnLocation()
 	pos := blockPos.Vec3Middle()
 
+	spawnBlockPos := p.tx.World().PlayerSpawn(p.UUID())
+	spawnBlock := p.tx.Block(spawnBlockPos)
 	if spawnObstructed {
-		p.Messaget(chat.MessageBedNotValid)
+		if _, ok := spawnBlock.(block.RespawnAnchor); ok {
+			p.Messaget(chat.MessageRespawnAnchorNotValid)
+		} else {
+			p.Messaget(chat.MessageBedNotValid)
+		}
 	}
 
 	p.addHealth(p.MaxHealth())
@@ -971,7 +1152,15 @@ func (p *Player) respawn(f func(p *Player)) {
 	p.Extinguish()
 	p.ResetFallDistance()
 
+	spawnWorld, spawnPos := w, pos
 	p.Handler().HandleRespawn(p, &pos, &w)
+	// Players respawning at the world spawn are spread over the spawn radius, unless the handler changed the
+	// respawn location.
+	randomSpawn := blockPos == w.Spawn() && w == spawnWorld && pos == spawnPos
+	if b, ok := spawnBlock.(block.RespawnBlock); ok && !spawnObstructed && w == p.tx.World() && w == spawnWorld && pos == spawnPos {
+		// Respawning at a respawn block, such as a respawn anchor, may use up one of its charges.
+		b.RespawnOn(spawnBlockPos, p.tx)
+	}
 
 	sess := p.session()
 	src := p.tx.World()
@@ -987,6 +1176,9 @@ func (p *Player) respawn(f func(p *Player)) {
 		np.quit("respawn failed")
 	}
 	task := w.Do(func(tx *world.Tx) {
+		if randomSpawn {
+			pos = tx.RandomSpawn().Vec3Middle()
+		}
 		np := tx.AddEntity(handle).(*Player)
 		np.Teleport(pos)
 		np.session().SendRespawn(pos, p)
@@ -1029,11 +1221,8 @@ func (p *Player) spawnLocation() (playerSpawn cube.Pos, w *world.World, spawnBlo
 	w = tx.World()
 	previousDimension = w.Dimension()
 	playerSpawn = w.PlayerSpawn(p.UUID())
-	if b, ok := tx.Block(playerSpawn).(block.Bed); ok && b.CanRespawnOn() {
-		pos, ok := b.SafeSpawn(playerSpawn, tx)
-		if ok {
-			return pos, w, false, previousDimension
-		}
+	if pos, ok := respawnBlockSpawn(playerSpawn, tx); ok {
+		return pos, w, false, previousDimension
 	}
 
 	// We can use the principle here that returning through a portal of a specific dimension inside that dimension will
@@ -1043,8 +1232,17 @@ func (p *Player) spawnLocation() (playerSpawn cube.Pos, w *world.World, spawnBlo
 	return worldSpawn, w, playerSpawn != worldSpawn, previousDimension
 }
 
+// respawnBlockSpawn returns the position at which a player with its spawn point at pos respawns, if the block at
+// pos is a block.RespawnBlock that the player can currently respawn at.
+func respawnBlockSpawn(pos cube.Pos, tx *world.Tx) (cube.Pos, bool) {
+	if b, ok := tx.Block(pos).(block.RespawnBlock); ok && b.CanRespawnOn() {
+		return b.SafeSpawn(pos, tx)
+	}
+	return cube.Pos{}, false
+}
+
 // StartSprinting makes a player start sprinting, increasing the speed of the player by 30% and making
-// particles show up under the feet. The player will only start sprinting if its food level is high enough.
+// particles show up under the feet. The speed increase may be changed using MovementStates.SprintSpeed. The player will only start sprinting if its food level is high enough.
 // If the player is sneaking when calling StartSprinting, it is stopped from sneaking.
 func (p *Player) StartSprinting() {
 	if !p.hunger.canSprint() && p.GameMode().AllowsTakingDamage() || p.crawling || p.sprinting {
@@ -1056,7 +1254,7 @@ func (p *Player) StartSprinting() {
 	}
 	p.StopSneaking()
 	p.sprinting = true
-	p.SetSpeed(p.speed * 1.3)
+	p.SetSpeed(p.speed * p.movementStates.sprintSpeed())
 	p.updateState()
 }
 
@@ -1075,7 +1273,7 @@ func (p *Player) StopSprinting() {
 		return
 	}
 	p.sprinting = false
-	p.SetSpeed(p.speed / 1.3)
+	p.SetSpeed(p.speed / p.movementStates.sprintSpeed())
 	p.updateState()
 }
 
@@ -1155,7 +1353,7 @@ func (p *Player) StartCrawling() {
 	if p.crawling {
 		return
 	}
-	for _, corner := range p.H().Type().BBox(p).Translate(p.Position()).Corners() {
+	for _, corner := range world.EntityBBox(p).Translate(p.Position()).Corners() {
 		if _, isAir := p.tx.Block(cube.PosFromVec3(corner).Add(cube.Pos{0, 1, 0})).(block.Air); !isAir {
 			p.crawling = true
 			break
@@ -1207,10 +1405,66 @@ func (p *Player) StopGliding() {
 	p.updateState()
 }
 
+// StartSpinAttack makes the player start a spin attack, which the client of a player does when it is launched
+// by a trident with the Riptide enchantment. The movement of a player is not validated while it is spin
+// attacking.
+func (p *Player) StartSpinAttack() {
+	p.spinAttacking = true
+}
+
+// SpinAttacking checks if the player is currently spin attacking.
+func (p *Player) SpinAttacking() bool {
+	return p.spinAttacking
+}
+
+// StopSpinAttack makes the player stop its spin attack if it is currently doing so. The player keeps some of
+// its speed afterwards, so its movement is validated again only after a short time.
+func (p *Player) StopSpinAttack() {
+	if !p.spinAttacking {
+		return
+	}
+	p.spinAttacking = false
+	p.movementGraceTicks = movementGraceTicks
+}
+
+// SetFlightAllowed sets if the player may fly regardless of its game mode, for example to allow a player in
+// survival mode to fly. A player that is allowed to fly does not take fall damage while flying and is not
+// checked for flying by its MovementPolicy. If flight is no longer allowed and the game mode of the player
+// does not allow flying either, the player stops flying and falls down.
+func (p *Player) SetFlightAllowed(allowed bool) {
+	p.flightAllowed = allowed
+	if !p.CanFly() && p.flying {
+		p.flying = false
+	}
+	p.session().SendAbilities(p)
+}
+
+// FlightAllowed returns true if the player was allowed to fly regardless of its game mode using
+// Player.SetFlightAllowed.
+func (p *Player) FlightAllowed() bool {
+	return p.flightAllowed
+}
+
+// CanFly returns true if the player is able to fly, either because its game mode allows flying or because
+// flight was allowed using Player.SetFlightAllowed.
+func (p *Player) CanFly() bool {
+	return p.flightAllowed || p.GameMode().AllowsFlying()
+}
+
+// SetFlying makes the player start or stop flying. A player can only start flying if Player.CanFly returns
+// true.
+func (p *Player) SetFlying(flying bool) {
+	if flying {
+		p.StartFlying()
+		return
+	}
+	p.StopFlying()
+}
+
 // StartFlying makes the player start flying if they aren't already. It requires the player to be in a gamemode which
-// allows flying.
+// allows flying or to be allowed to fly using Player.SetFlightAllowed.
 func (p *Player) StartFlying() {
-	if !p.GameMode().AllowsFlying() || p.Flying() {
+	if !p.CanFly() || p.Flying() {
 		return
 	}
 	p.flying = true
@@ -1232,7 +1486,7 @@ func (p *Player) StopFlying() {
 }
 
 // Jump makes the player jump if they are on ground. It exhausts the player by 0.05 food points, an additional 0.15
-// is exhausted if the player is sprint jumping.
+// is exhausted if the player is sprint jumping. A sprint jump also boosts the player forward.
 func (p *Player) Jump() {
 	if p.Dead() {
 		return
@@ -1244,10 +1498,19 @@ func (p *Player) Jump() {
 		if e, ok := p.Effect(effect.JumpBoost); ok {
 			jumpVel = float64(e.Level()) / 10
 		}
+		if f, ok := p.tx.Block(cube.PosFromVec3(p.Position().Sub(mgl64.Vec3{0, 0.5000001}))).(interface {
+			JumpFactor() float64
+		}); ok {
+			// Blocks such as honey blocks make it harder to jump off them.
+			jumpVel *= f.JumpFactor()
+		}
 		p.data.Vel = mgl64.Vec3{0, jumpVel}
+		if p.Sprinting() {
+			p.data.Vel = p.data.Vel.Add(cube.Rotation{p.Rotation().Yaw(), 0}.Vec3().Mul(sprintJumpBoost))
+		}
 	}
 	if p.Sprinting() {
-		p.Exhaust(0.2)
+		p.Exhaust(p.movementStates.sprintJumpExhaustion())
 	} else {
 		p.Exhaust(0.05)
 	}
@@ -1281,6 +1544,7 @@ func (p *Player) Sleep(pos cube.Pos) {
 	p.data.Pos = pos.Vec3Middle().Add(mgl64.Vec3{0, 0.5625})
 	p.sleeping = true
 	p.sleepPos = pos
+	p.restTicks = 0
 
 	p.session().SendPlayerSpawn(pos.Vec3())
 
@@ -1323,6 +1587,32 @@ func (p *Player) Sleeping() (cube.Pos, bool) {
 	return p.sleepPos, true
 }
 
+// TimeSinceRest returns the time since the player last slept in a bed or died. Phantoms may spawn around
+// players that have not rested for longer than entity.InsomniaThreshold.
+func (p *Player) TimeSinceRest() time.Duration {
+	return time.Duration(p.restTicks) * time.Second / 20
+}
+
+// tickInsomnia ticks the time since the player last rested and periodically checks if phantoms should spawn
+// around the player, passing them to the Handler of the player if so.
+func (p *Player) tickInsomnia(tx *world.Tx) {
+	if !p.sleeping {
+		p.restTicks++
+	}
+	if p.phantomTicks--; p.phantomTicks > 0 {
+		return
+	}
+	// Vanilla checks for phantom spawns every 60 to 120 seconds.
+	p.phantomTicks = 1200 + rand.Int64N(1200)
+	if !p.GameMode().AllowsTakingDamage() {
+		return
+	}
+	r := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	if pos, count, ok := entity.PhantomSpawn(p, tx, r); ok {
+		p.Handler().HandlePhantomSpawn(p, pos, count)
+	}
+}
+
 // SendSleepingIndicator displays a notification to the player on the amount of sleeping players in the world.
 func (p *Player) SendSleepingIndicator(sleeping, max int) {
 	p.session().ViewSleepingPlayers(sleeping, max)
@@ -1352,6 +1642,22 @@ func (p *Player) Invisible() bool {
 	return p.invisible
 }
 
+// SetGlowing sets if the player is glowing. A glowing player is highlighted to viewers in the colour returned by
+// world.Teams.GlowColour. Because Bedrock clients do not draw outlines around entities, the name tag of a glowing
+// player is shown in that colour instead.
+func (p *Player) SetGlowing(v bool) {
+	if p.glowing == v {
+		return
+	}
+	p.glowing = v
+	p.updateState()
+}
+
+// Glowing checks if the player is currently glowing.
+func (p *Player) Glowing() bool {
+	return p.glowing
+}
+
 // SetImmobile prevents the player from moving around, but still allows them to look around.
 func (p *Player) SetImmobile() {
 	if p.Immobile() {
@@ -1375,6 +1681,88 @@ func (p *Player) Immobile() bool {
 	return p.immobile
 }
 
+// NoClip returns true if the player passes through blocks instead of colliding
+// with them. Players with no-clip enabled do not suffocate in blocks.
+func (p *Player) NoClip() bool {
+	return p.noClip
+}
+
+// SetNoClip sets if the player passes through blocks instead of colliding with
+// them, independently of its game mode. When collision with blocks is enabled
+// again, the player is moved out of any blocks it is inside of.
+func (p *Player) SetNoClip(v bool) {
+	if p.noClip == v {
+		return
+	}
+	p.noClip = v
+	p.session().SendAbilities(p)
+	p.updateState()
+
+	if !v {
+		if pos := entity.EscapeBlocks(p.tx, p, p.Position()); pos != p.Position() {
+			p.Teleport(pos)
+		}
+	}
+}
+
+// NoEntityCollision returns true if the player does not collide with other
+// entities, such as projectiles.
+func (p *Player) NoEntityCollision() bool {
+	return p.noEntityCollision
+}
+
+// SetNoEntityCollision sets if the player does not collide with other
+// entities, such as projectiles.
+func (p *Player) SetNoEntityCollision(v bool) {
+	p.noEntityCollision = v
+}
+
+// UnlockRecipes unlocks the recipes with the names passed, as returned by
+// recipe.Name, for the player. If the server has recipe unlocking enabled,
+// only unlocked recipes show up in the recipe book of the player. Recipes that
+// were already unlocked are ignored.
+func (p *Player) UnlockRecipes(names ...string) {
+	unlocked := make([]string, 0, len(names))
+	for _, name := range names {
+		if _, ok := p.unlockedRecipes[name]; !ok {
+			p.unlockedRecipes[name] = struct{}{}
+			unlocked = append(unlocked, name)
+		}
+	}
+	if len(unlocked) > 0 {
+		p.session().SendRecipesUnlocked(unlocked)
+	}
+}
+
+// LockRecipes removes the recipes with the names passed, as returned by
+// recipe.Name, from the recipes unlocked by the player. Recipes that were not
+// unlocked are ignored.
+func (p *Player) LockRecipes(names ...string) {
+	locked := make([]string, 0, len(names))
+	for _, name := range names {
+		if _, ok := p.unlockedRecipes[name]; ok {
+			delete(p.unlockedRecipes, name)
+			locked = append(locked, name)
+		}
+	}
+	if len(locked) > 0 {
+		p.session().SendRecipesLocked(locked)
+	}
+}
+
+// RecipeUnlocked checks if the recipe with the name passed, as returned by
+// recipe.Name, is unlocked by the player.
+func (p *Player) RecipeUnlocked(name string) bool {
+	_, ok := p.unlockedRecipes[name]
+	return ok
+}
+
+// UnlockedRecipes returns the names of all recipes unlocked by the player,
+// sorted alphabetically.
+func (p *Player) UnlockedRecipes() []string {
+	return slices.Sorted(maps.Keys(p.unlockedRecipes))
+}
+
 // FireProof checks if the Player is currently fireproof. True is returned if the player has a fireResistance effect or
 // if it is in creative mode.
 func (p *Player) FireProof() bool {
@@ -1404,6 +1792,29 @@ func (p *Player) Extinguish() {
 	p.SetOnFire(0)
 }
 
+// EnterPowderSnow marks the player as being inside powder snow, freezing it over time if it is not wearing
+// leather armour.
+func (p *Player) EnterPowderSnow() {
+	p.freeze.EnterPowderSnow()
+}
+
+// FrozenTicks returns the amount of ticks the player has been freezing for in powder snow.
+func (p *Player) FrozenTicks() int {
+	return p.freeze.FrozenTicks()
+}
+
+// SetFrozenTicks sets the amount of ticks the player has been freezing for. The value is clamped between 0
+// and entity.MaxFrozenTicks.
+func (p *Player) SetFrozenTicks(ticks int) {
+	p.freeze.SetFrozenTicks(ticks)
+	p.updateState()
+}
+
+// FreezingStrength returns a value between 0 and 1 that indicates how frozen the player is.
+func (p *Player) FreezingStrength() float64 {
+	return p.freeze.FreezingStrength()
+}
+
 // Inventory returns the inventory of the player. This inventory holds the items stored in the normal part of
 // the inventory and the hotbar. It also includes the item in the main hand as returned by Player.HeldItems().
 func (p *Player) Inventory() *inventory.Inventory {
@@ -1476,7 +1887,7 @@ func (p *Player) SetGameMode(mode world.GameMode) {
 	previous := p.GameMode()
 	p.gameMode = mode
 
-	if !mode.AllowsFlying() {
+	if !p.CanFly() {
 		p.StopFlying()
 	}
 	if !mode.Visible() {
@@ -1501,6 +1912,48 @@ func (p *Player) GameMode() world.GameMode {
 	return p.gameMode
 }
 
+// SetGameModeOverride sets if the player keeps its game mode when joining or
+// moving to a world that forces its default game mode. If false, the game mode
+// of the player is changed to the default game mode of such a world.
+func (p *Player) SetGameModeOverride(v bool) {
+	p.gameModeOverride = v
+}
+
+// GameModeOverride returns true if the player keeps its game mode in worlds
+// that force their default game mode. See Player.SetGameModeOverride.
+func (p *Player) GameModeOverride() bool {
+	return p.gameModeOverride
+}
+
+// handleWorldChange handles the player being in a different world than the last time it was opened in a
+// transaction. It is called every time the player is opened, so that the inventory group and game mode of
+// the player are updated as soon as it is added to a new world, before anything else may happen to it.
+func (p *Player) handleWorldChange(tx *world.Tx) {
+	prev := p.prevWorld
+	if prev == tx.World() {
+		return
+	}
+	p.prevWorld = tx.World()
+	if from, to := prev.InventoryGroup(), tx.World().InventoryGroup(); prev != nil && from != to {
+		p.switchInventoryGroup(tx, from, to)
+	}
+	p.enforceGameMode(tx.World())
+	if prev != nil {
+		p.Handler().HandleChangeWorld(p, prev, tx.World())
+	}
+}
+
+// enforceGameMode changes the game mode of the player to the default game mode
+// of the world passed if the world forces it and the player has no override.
+func (p *Player) enforceGameMode(w *world.World) {
+	if !w.ForceGameMode() || p.gameModeOverride {
+		return
+	}
+	if mode := w.DefaultGameMode(); p.GameMode() != mode {
+		p.SetGameMode(mode)
+	}
+}
+
 // HasCooldown returns true if the item passed has an active cooldown, meaning it currently cannot be used again. If the
 // world.Item passed is nil, HasCooldown always returns false.
 func (p *Player) HasCooldown(item world.Item) bool {
@@ -1544,7 +1997,7 @@ func (p *Player) UseItem() {
 	i, left := p.HeldItems()
 	it := i.Item()
 
-	if cd, ok := it.(item.Cooldown); ok {
+	if cd, ok := it.(item.Cooldown); ok && cd.Cooldown() > 0 {
 		p.SetCooldown(it, cd.Cooldown())
 	}
 
@@ -1552,7 +2005,7 @@ func (p *Player) UseItem() {
 		if !p.canRelease() {
 			return
 		}
-		p.usingSince, p.usingItem = time.Now(), true
+		p.startUsingItem()
 		p.updateState()
 	}
 	switch usable := it.(type) {
@@ -1561,7 +2014,7 @@ func (p *Player) UseItem() {
 		if !p.usingItem {
 			if !usable.ReleaseCharge(p, p.tx, useCtx) && usable.CanCharge(p, p.tx, useCtx) {
 				// If the item was not charged yet, start charging.
-				p.usingSince, p.usingItem = time.Now(), true
+				p.startUsingItem()
 			}
 			p.handleUseContext(useCtx)
 			p.updateState()
@@ -1587,38 +2040,63 @@ func (p *Player) UseItem() {
 		p.SetHeldItems(p.subtractItem(p.damageItem(i, useCtx.Damage), useCtx.CountSub), left)
 		p.addNewItem(useCtx)
 	case item.Consumable:
-		if c, ok := usable.(interface{ CanConsume() bool }); ok && !c.CanConsume() {
-			p.ReleaseItem()
-			return
-		}
-		if !usable.AlwaysConsumable() && p.GameMode().AllowsTakingDamage() && p.Food() >= 20 {
-			// The item.Consumable is not always consumable, the player is not in creative mode and the
-			// food bar is filled: The item cannot be consumed.
+		if !p.canConsume(usable) {
 			p.ReleaseItem()
 			return
 		}
 		if !p.usingItem {
-			// Consumable starts being consumed: Set the start timestamp and update the using state to viewers.
-			p.usingItem, p.usingSince = true, time.Now()
+			// Consumable starts being consumed: Set the start tick and update the using state to viewers.
+			p.startUsingItem()
 			p.updateState()
 			return
 		}
 		// The player is currently using the item held. This is a signal the item was consumed, so we
 		// consume it and start using it again.
-		useCtx, dur := p.useContext(), p.useDuration()
-		if dur < usable.ConsumeDuration() {
-			// The required duration for consuming this item was not met, so we don't consume it.
-			return
-		}
-		// Reset the duration for the next item to be consumed.
-		p.usingSince = time.Now()
-		ctx := newContext(p)
-		if p.Handler().HandleItemConsume(ctx, i); ctx.Cancelled() {
-			return
-		}
-		useCtx.CountSub, useCtx.NewItem = 1, usable.Consume(p.tx, p)
-		p.handleUseContext(useCtx)
-		p.tx.PlaySound(p.Position().Add(mgl64.Vec3{0, 1.5}), sound.Burp{})
+		p.consumeItem(i, usable)
+	}
+}
+
+// consumeItem consumes the item.Consumable held by the player if it has been using it for at least the
+// duration that consuming it takes. The player keeps using the item afterwards, so that it may start
+// consuming the next item right away.
+func (p *Player) consumeItem(i item.Stack, c item.Consumable) {
+	useCtx, dur := p.useContext(), p.useDuration()
+	if dur < c.ConsumeDuration() {
+		// The required duration for consuming this item was not met, so we don't consume it.
+		return
+	}
+	if !p.canConsume(c) {
+		p.stopConsuming()
+		return
+	}
+	// Reset the duration for the next item to be consumed.
+	p.usingSince = p.tx.CurrentTick()
+	ctx := newContext(p)
+	if p.Handler().HandleItemConsume(ctx, i); ctx.Cancelled() {
+		return
+	}
+	useCtx.CountSub, useCtx.NewItem = 1, c.Consume(p.tx, p)
+	p.handleUseContext(useCtx)
+	p.tx.PlaySound(p.Position().Add(mgl64.Vec3{0, 1.5}), sound.Burp{})
+}
+
+// canConsume checks if the player is currently able to consume the item.Consumable passed.
+func (p *Player) canConsume(c item.Consumable) bool {
+	if cc, ok := c.(interface{ CanConsume() bool }); ok && !cc.CanConsume() {
+		return false
+	}
+	// Items that are not always consumable cannot be consumed with a full food bar, unless the player is in
+	// creative mode.
+	return c.AlwaysConsumable() || !p.GameMode().AllowsTakingDamage() || p.Food() < 20
+}
+
+// stopConsuming makes the player stop consuming the item.Consumable it is holding, if it is consuming one. The
+// player has to start using the item again to consume it.
+func (p *Player) stopConsuming() {
+	held, _ := p.HeldItems()
+	if _, ok := held.Item().(item.Consumable); ok && p.usingItem {
+		p.usingItem = false
+		p.updateState()
 	}
 }
 
@@ -1629,7 +2107,11 @@ func (p *Player) UseItem() {
 // the item started being used.
 func (p *Player) ReleaseItem() {
 	if !p.usingItem || !p.canRelease() || !p.GameMode().AllowsInteraction() {
-		p.usingItem = false
+		if p.usingItem {
+			// The player stopped using an item that is not released, such as food that was not fully eaten.
+			p.usingItem = false
+			p.updateState()
+		}
 		return
 	}
 	p.usingItem = false
@@ -1696,9 +2178,15 @@ func (p *Player) handleUseContext(ctx *item.UseContext) {
 	}
 }
 
-// useDuration returns the duration the player has been using the item in the main hand.
+// startUsingItem makes the player start using the item in the main hand from the current tick.
+func (p *Player) startUsingItem() {
+	p.usingSince, p.usingItem = p.tx.CurrentTick(), true
+}
+
+// useDuration returns the duration the player has been using the item in the main hand. The duration is
+// measured in ticks, including the tick in which the player started using the item.
 func (p *Player) useDuration() time.Duration {
-	return time.Since(p.usingSince) + time.Second/20
+	return time.Duration(p.tx.CurrentTick()-p.usingSince+1) * (time.Second / 20)
 }
 
 // UsingItem checks if the Player is currently using an item. True is returned if the Player is currently eating an
@@ -1730,6 +2218,11 @@ func (p *Player) UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec
 		// If a player is sneaking, it will not activate the block clicked, unless it is not holding any
 		// items, in which case the block will be activated as usual.
 		if !p.Sneaking() || i.Empty() {
+			ctx = newContext(p)
+			if p.Handler().HandleBlockInteract(ctx, pos, face, clickPos, b); ctx.Cancelled() {
+				p.resendNearbyBlocks(pos, face)
+				return
+			}
 			// The block was activated: Blocks such as doors must always have precedence over the item being
 			// used.
 			if useCtx := p.useContext(); act.Activate(pos, face, p.tx, p, useCtx) {
@@ -1763,7 +2256,7 @@ func (p *Player) UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec
 		if replaceable, ok := p.tx.Block(replacedPos).(block.Replaceable); !ok || !replaceable.ReplaceableBy(ib) || replacedPos.OutOfBounds(p.tx.Range()) {
 			return
 		}
-		if !p.placeBlock(replacedPos, ib, false) || p.GameMode().CreativeInventory() {
+		if !p.placeBlock(replacedPos, ib, nil) || p.GameMode().CreativeInventory() {
 			return
 		}
 		p.SetHeldItems(p.subtractItem(i, 1), left)
@@ -1774,35 +2267,160 @@ func (p *Player) UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec
 // within range of the player.
 // If the item held in the main hand of the player does nothing when used on an entity, nothing will happen.
 func (p *Player) UseItemOnEntity(e world.Entity) bool {
+	valid, _ := p.useItemOnEntity(e)
+	return valid
+}
+
+// useItemOnEntity uses the item held in the main hand of the player on the entity passed. It returns if the
+// use was valid and if the item did anything when used on the entity.
+func (p *Player) useItemOnEntity(e world.Entity) (valid, used bool) {
 	if !p.canReach(e.Position()) {
-		return false
+		return false, false
 	}
 	ctx := newContext(p)
 	if p.Handler().HandleItemUseOnEntity(ctx, e); ctx.Cancelled() {
-		return false
+		return false, false
 	}
 	i, left := p.HeldItems()
 	usable, ok := i.Item().(item.UsableOnEntity)
 	if !ok {
-		return true
+		return true, false
 	}
 	useCtx := p.useContext()
 	if !usable.UseOnEntity(e, p.tx, p, useCtx) {
-		return true
+		return true, false
 	}
 	p.SwingArm()
 	p.SetHeldItems(p.subtractItem(p.damageItem(i, useCtx.Damage), useCtx.CountSub), left)
 	p.addNewItem(useCtx)
+	return true, true
+}
+
+// InteractWithEntity interacts with the entity passed, provided it is within range of the player. The click
+// position is the position on the entity clicked by the player. If the interaction is not cancelled, the item
+// held in the main hand of the player is used on the entity.
+func (p *Player) InteractWithEntity(e world.Entity, clickPos mgl64.Vec3) bool {
+	if !p.canReach(e.Position()) {
+		return false
+	}
+	ctx := newContext(p)
+	if p.Handler().HandleEntityInteract(ctx, e, clickPos); ctx.Cancelled() {
+		return false
+	}
+	valid, used := p.useItemOnEntity(e)
+	if r, ok := e.(entity.Rideable); ok && valid && !used && !p.Sneaking() {
+		// Items that do something when used on the entity, such as a saddle used on a horse, take precedence
+		// over mounting the entity.
+		p.Mount(r)
+	}
+	return valid
+}
+
+// Mount makes the player start riding the entity passed, such as a boat. If the player was already riding
+// another entity, it stops riding it first. False is returned if the entity had no seat left for the player.
+func (p *Player) Mount(r entity.Rideable) bool {
+	if p.riding == r.H() {
+		return true
+	}
+	p.leaveVehicle()
+	p.Wake()
+	p.riding = r.H()
+	if !r.AddRider(p) {
+		p.riding = nil
+		return false
+	}
+	p.StopSprinting()
 	return true
 }
 
+// Dismount makes the player stop riding the entity it is currently riding. The player is moved to a position
+// next to the entity. Dismount does nothing if the player is not riding an entity.
+func (p *Player) Dismount() {
+	if r, ok := p.leaveVehicle(); ok {
+		p.teleport(r.DismountPosition())
+	}
+}
+
+// Riding returns the handle of the entity that the player is currently riding. False is returned if the
+// player is not riding any entity.
+func (p *Player) Riding() (*world.EntityHandle, bool) {
+	return p.riding, p.riding != nil
+}
+
+// Driving checks if the player is riding an entity and controls its movement.
+func (p *Player) Driving() bool {
+	r, ok := p.vehicle()
+	if !ok {
+		return false
+	}
+	riders := r.Riders()
+	return len(riders) > 0 && riders[0] == p.H()
+}
+
+// SteerVehicle passes movement input of the player, such as the direction it moves in and whether it is
+// jumping, to the entity it is riding. The input is ignored if the player does not control the entity it rides.
+func (p *Player) SteerVehicle(input entity.RideInput) {
+	if r, ok := p.vehicle(); ok {
+		r.Steer(p, input)
+	}
+}
+
+// vehicle returns the entity that the player is currently riding, if it is in the same world as the player.
+func (p *Player) vehicle() (entity.Rideable, bool) {
+	if p.riding == nil {
+		return nil, false
+	}
+	e, ok := p.riding.Entity(p.tx)
+	if !ok {
+		return nil, false
+	}
+	r, ok := e.(entity.Rideable)
+	return r, ok
+}
+
+// leaveVehicle makes the player stop riding the entity it is riding, returning that entity if it is still in
+// the same world as the player.
+func (p *Player) leaveVehicle() (entity.Rideable, bool) {
+	if p.riding == nil {
+		return nil, false
+	}
+	r, ok := p.vehicle()
+	p.riding = nil
+	if ok {
+		r.RemoveRider(p)
+	} else {
+		p.updateState()
+	}
+	return r, ok
+}
+
+// tickRiding moves the player along with the entity it is riding. If the entity was removed or no longer
+// carries the player, for example because its chunk was unloaded, the player stops riding it.
+func (p *Player) tickRiding() {
+	if p.riding == nil {
+		return
+	}
+	r, ok := p.vehicle()
+	if !ok || !slices.Contains(r.Riders(), p.H()) {
+		p.riding = nil
+		p.updateState()
+		return
+	}
+	if pos, ok := r.SeatPosition(p); ok {
+		p.data.Pos = pos
+		p.data.Vel = mgl64.Vec3{}
+		p.ResetFallDistance()
+	}
+}
+
 // AttackEntity uses the item held in the main hand of the player to attack the entity passed, provided it is
 // within range of the player.
 // The damage dealt to the entity will depend on the item held by the player and any effects the player may
 // have.
-// If the player cannot reach the entity at its position, the method returns immediately.
+// If the entity is further away from the player than the attack reach of its MovementPolicy, the method
+// returns immediately.
 func (p *Player) AttackEntity(e world.Entity) bool {
-	if !p.canReach(e.Position()) {
+	if !p.canAttack(e) {
 		return false
 	}
 
@@ -1824,6 +2442,9 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 		force += inc
 		height += inc
 	}
+	if p.Sprinting() {
+		force += p.movementStates.sprintKnockBack()
+	}
 
 	ctx := newContext(p)
 	if p.Handler().HandleAttackEntity(ctx, e, &force, &height, &critical); ctx.Cancelled() {
@@ -1832,6 +2453,9 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 	p.SwingArm()
 
 	if !isLiving {
+		if b, ok := e.(entity.Breakable); ok {
+			return b.Hit(i.AttackDamage(), entity.AttackDamageSource{Attacker: p})
+		}
 		return false
 	}
 
@@ -1844,7 +2468,7 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 	}
 	if s, ok := i.Enchantment(enchantment.Sharpness); ok {
 		dmg += enchantment.Sharpness.Addend(s.Level())
-		for _, v := range p.tx.Viewers(living.Position()) {
+		for _, v := range p.tx.EntityViewers(living) {
 			v.ViewEntityAction(living, entity.EnchantedHitAction{})
 		}
 	}
@@ -1864,7 +2488,7 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 		return true
 	}
 	if critical {
-		for _, v := range p.tx.Viewers(living.Position()) {
+		for _, v := range p.tx.EntityViewers(living) {
 			v.ViewEntityAction(living, entity.CriticalHitAction{})
 		}
 	}
@@ -2018,8 +2642,7 @@ func (p *Player) ContinueBreaking(face cube.Face) {
 // An item.UseContext may be passed to obtain information on if the block placement was successful. (SubCount will
 // be incremented). Nil may also be passed for the context parameter.
 func (p *Player) PlaceBlock(pos cube.Pos, b world.Block, ctx *item.UseContext) {
-	ignoreBBox := ctx != nil && ctx.IgnoreBBox
-	if !p.placeBlock(pos, b, ignoreBBox) {
+	if !p.placeBlock(pos, b, ctx) {
 		return
 	}
 	if ctx != nil {
@@ -2028,13 +2651,14 @@ func (p *Player) PlaceBlock(pos cube.Pos, b world.Block, ctx *item.UseContext) {
 }
 
 // placeBlock makes the player place the block passed at the position passed, granted it is within the range
-// of the player. A bool is returned indicating if a block was placed successfully.
-func (p *Player) placeBlock(pos cube.Pos, b world.Block, ignoreBBox bool) bool {
-	if !p.canReach(pos.Vec3Centre()) || !p.GameMode().AllowsEditing() {
+// of the player and the block would be supported at the position. The useCtx passed may be nil. A bool is
+// returned indicating if a block was placed successfully.
+func (p *Player) placeBlock(pos cube.Pos, b world.Block, useCtx *item.UseContext) bool {
+	if !p.canReach(pos.Vec3Centre()) || !p.GameMode().AllowsEditing() || !block.PlaceableAt(p.tx, pos, b, useCtx) {
 		p.resendNearbyBlocks(pos, cube.Faces()...)
 		return false
 	}
-	if obstructed, selfOnly := p.obstructedPos(pos, b); obstructed && !ignoreBBox {
+	if obstructed, selfOnly := p.obstructedPos(pos, b); obstructed && (useCtx == nil || !useCtx.IgnoreBBox) {
 		if !selfOnly {
 			// Only resend blocks if there were other entities blocking the
 			// placement than the player itself. Resending blocks placed inside
@@ -2051,6 +2675,7 @@ func (p *Player) placeBlock(pos cube.Pos, b world.Block, ignoreBBox bool) bool {
 	}
 	p.tx.SetBlock(pos, b, nil)
 	p.tx.PlaySound(pos.Vec3(), sound.BlockPlace{Block: b})
+	p.tx.EmitVibration(world.Vibration{Pos: pos.Vec3Centre(), Frequency: world.VibrationBlockPlace, Source: p})
 	p.SwingArm()
 	return true
 }
@@ -2073,7 +2698,7 @@ func (p *Player) obstructedPos(pos cube.Pos, b world.Block) (obstructed, selfOnl
 		case entity.ItemType, entity.ArrowType, entity.ExperienceOrbType:
 			continue
 		default:
-			if cube.AnyIntersections(blockBoxes, t.BBox(e).Translate(e.Position()).Grow(-1e-4)) {
+			if cube.AnyIntersections(blockBoxes, world.EntityBBox(e).Translate(e.Position()).Grow(-1e-4)) {
 				obstructed = true
 				if e.H() == p.handle {
 					continue
@@ -2121,6 +2746,7 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 	p.SwingArm()
 	p.tx.SetBlock(pos, nil, nil)
 	p.tx.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: b})
+	p.tx.EmitVibration(world.Vibration{Pos: pos.Vec3Centre(), Frequency: world.VibrationBlockBreak, Source: p})
 
 	if breakable, ok := b.(block.Breakable); ok {
 		info := breakable.BreakInfo()
@@ -2131,10 +2757,7 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 			p.tx.AddEntity(orb)
 		}
 	}
-	for _, drop := range drops {
-		opts := world.EntitySpawnOpts{Position: pos.Vec3Centre(), Velocity: mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1}}
-		p.tx.AddEntity(entity.NewItem(opts, drop))
-	}
+	p.dropBlockItems(pos, drops, ctx.dropMode)
 
 	p.Exhaust(0.005)
 	// Only blocks that naturally break instantly (zero hardness) cost no durability; a block made to break
@@ -2147,6 +2770,23 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 	}
 }
 
+// dropBlockItems handles the drops of a block broken at the position passed according to the DropMode passed.
+func (p *Player) dropBlockItems(pos cube.Pos, drops []item.Stack, mode DropMode) {
+	if mode == DropNothing() {
+		return
+	}
+	for _, drop := range drops {
+		if mode == CollectDrops() {
+			n, _ := p.Inventory().AddItem(drop)
+			if drop = drop.Grow(-n); drop.Empty() {
+				continue
+			}
+		}
+		opts := world.EntitySpawnOpts{Position: pos.Vec3Centre(), Velocity: mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1}}
+		p.tx.AddEntity(entity.NewItem(opts, drop))
+	}
+}
+
 // drops returns the drops that the player can get from the block passed using the item held.
 func (p *Player) drops(held item.Stack, b world.Block) []item.Stack {
 	t, ok := held.Item().(item.Tool)
@@ -2231,23 +2871,75 @@ func (p *Player) Teleport(pos mgl64.Vec3) {
 // It also wakes up the player from sleep.
 func (p *Player) forceTeleport(pos mgl64.Vec3) {
 	p.Wake()
+	p.leaveVehicle()
 	p.teleport(pos)
 }
 
 // teleport teleports the player to a target position in the world without updating non-positional state.
+// Teleports over a distance of at least the teleport reload distance of the player re-add the player to
+// its viewers instead of moving it, and make the session of the player send all chunks around it again.
 func (p *Player) teleport(pos mgl64.Vec3) {
-	for _, v := range p.viewers() {
+	var s world.Viewer = p.session()
+	viewers := p.viewers()
+
+	reload := p.teleportReloads(pos)
+	for _, v := range viewers {
+		if reload && v != s {
+			v.HideEntity(p)
+			continue
+		}
 		v.ViewEntityTeleport(p, pos)
 	}
 	p.data.Pos = pos
 	p.data.Vel = mgl64.Vec3{}
 	p.ResetFallDistance()
+	if !reload {
+		return
+	}
+	p.session().ReloadChunks()
+	// Viewers that can still see the player at its new position are shown the player again. The others
+	// are updated by the world once it notices the player moved to a different chunk.
+	for _, v := range p.tx.Viewers(pos) {
+		if v != s && slices.Contains(viewers, v) {
+			v.ViewEntity(p)
+			v.ViewEntityItems(p)
+			v.ViewEntityArmour(p)
+		}
+	}
+}
+
+// teleportReloads checks if teleporting the player to the position passed should reload the chunks and
+// the entity of the player for viewers.
+func (p *Player) teleportReloads(pos mgl64.Vec3) bool {
+	dist := p.teleportReloadDistance
+	if dist < 0 {
+		return false
+	} else if dist == 0 {
+		dist = 256
+	}
+	return p.Position().Sub(pos).Len() >= dist
 }
 
 // Move moves the player from one position to another in the world, by adding the delta passed to the current
 // position of the player.
 // Move also rotates the player, adding deltaYaw and deltaPitch to the respective values.
+// Movement by Move is not validated against the MovementPolicy of the player. ClientMove should be used for
+// movement reported by the client of the player instead.
 func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
+	p.move(deltaPos, deltaYaw, deltaPitch, false)
+}
+
+// ClientMove moves the player like Move, but validates the movement against the MovementPolicy of the player
+// first. ClientMove is used for movement reported by the client of the player. If the movement violates the
+// MovementPolicy, Handler.HandleMoveViolation is called and the player is moved back if the movement is
+// rejected.
+func (p *Player) ClientMove(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
+	p.move(deltaPos, deltaYaw, deltaPitch, true)
+}
+
+// move moves and rotates the player by the deltas passed. If validate is true, the movement is validated
+// against the MovementPolicy of the player.
+func (p *Player) move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64, validate bool) {
 	if p.Dead() || (deltaPos.ApproxEqual(mgl64.Vec3{}) && mgl64.FloatEqual(deltaYaw, 0) && mgl64.FloatEqual(deltaPitch, 0)) {
 		p.onGround = true
 		p.updateFallState(deltaPos.Y())
@@ -2265,6 +2957,16 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 		pos         = p.Position()
 		res, resRot = pos.Add(deltaPos), p.Rotation().Add(cube.Rotation{deltaYaw, deltaPitch})
 	)
+	if validate {
+		if v, ok := p.validateMovement(pos, res); ok {
+			ctx := newContext(p)
+			if p.Handler().HandleMoveViolation(ctx, v, pos, res); ctx.Cancelled() {
+				// Snap the player back to the position it had before the movement.
+				p.teleport(pos)
+				return
+			}
+		}
+	}
 	ctx := newContext(p)
 	if p.Handler().HandleMove(ctx, res, resRot); ctx.Cancelled() {
 		if p.session() != session.Nop && pos.ApproxEqual(p.Position()) {
@@ -2310,11 +3012,77 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 
 	p.onGround = p.checkOnGround(deltaPos)
 	p.updateFallState(deltaPos.Y())
+	p.updateStepVibration(horizontalVel.Len())
+	if cube.PosFromVec3(pos) != cube.PosFromVec3(res) {
+		p.frostWalk()
+	}
+	p.updateSoulSpeed(horizontalVel.Len())
 
 	if p.Swimming() {
 		p.Exhaust(0.01 * horizontalVel.Len())
 	} else if p.Sprinting() {
-		p.Exhaust(0.1 * horizontalVel.Len())
+		p.Exhaust(p.movementStates.sprintExhaustion() * horizontalVel.Len())
+	}
+}
+
+// updateStepVibration emits a step vibration for every block that the player
+// walks on the ground. Sneaking players and players walking on wool do not
+// emit step vibrations.
+func (p *Player) updateStepVibration(dist float64) {
+	if !p.onGround || p.Sneaking() || !p.GameMode().Visible() {
+		return
+	}
+	if p.stepDistance += dist; p.stepDistance < 1 {
+		return
+	}
+	p.stepDistance = 0
+
+	pos := cube.PosFromVec3(p.Position())
+	if _, wool := p.tx.Block(pos.Side(cube.FaceDown)).(block.Wool); wool {
+		return
+	}
+	if _, carpet := p.tx.Block(pos).(block.Carpet); carpet {
+		return
+	}
+	p.tx.EmitVibration(world.Vibration{Pos: p.Position(), Frequency: world.VibrationStep, Source: p})
+}
+
+// frostWalk freezes the still water below and around the player into frosted ice if it is on the ground and
+// wearing boots with the Frost Walker enchantment. It is called when the player moves into a different block.
+func (p *Player) frostWalk() {
+	e, ok := p.Armour().Boots().Enchantment(enchantment.FrostWalker)
+	if !ok || !p.onGround {
+		return
+	}
+	radius := enchantment.FrostWalker.Radius(e.Level())
+	below := cube.PosFromVec3(p.Position()).Side(cube.FaceDown)
+	for x := -radius; x <= radius; x++ {
+		for z := -radius; z <= radius; z++ {
+			if x*x+z*z <= radius*radius {
+				block.FrostedIce{}.Freeze(below.Add(cube.Pos{x, 0, z}), p.tx)
+			}
+		}
+	}
+}
+
+// updateSoulSpeed boosts the speed of the player while it is on soul sand or soul soil wearing boots with
+// the Soul Speed enchantment, and resets the speed once it leaves these blocks. Walking on these blocks
+// occasionally damages the boots.
+func (p *Player) updateSoulSpeed(dist float64) {
+	boots := p.Armour().Boots()
+	level := 0
+	if e, ok := boots.Enchantment(enchantment.SoulSpeed); ok && p.onGround {
+		switch p.tx.Block(cube.PosFromVec3(p.Position()).Side(cube.FaceDown)).(type) {
+		case block.SoulSand, block.SoulSoil:
+			level = e.Level()
+		}
+	}
+	if level != p.soulSpeedLevel {
+		p.SetSpeed(p.speed / enchantment.SoulSpeed.SpeedMultiplier(p.soulSpeedLevel) * enchantment.SoulSpeed.SpeedMultiplier(level))
+		p.soulSpeedLevel = level
+	}
+	if level > 0 && dist > 0 && rand.Float64() < 0.04 {
+		p.armour.SetBoots(p.damageItem(boots, 1))
 	}
 }
 
@@ -2361,6 +3129,16 @@ func (p *Player) SetVelocity(velocity mgl64.Vec3) {
 	}
 }
 
+// LookAt rotates the player so that it looks at the position passed. Unlike
+// mobs, the player is rotated immediately rather than turning its head over
+// multiple ticks.
+func (p *Player) LookAt(pos mgl64.Vec3) {
+	p.data.Rot = entity.LookRotation(p.Position().Add(mgl64.Vec3{0, p.EyeHeight()}), pos)
+	for _, v := range p.viewers() {
+		v.ViewEntityTeleport(p, p.Position())
+	}
+}
+
 // Rotation returns the yaw and pitch of the player in degrees. Yaw is horizontal rotation (rotation around the
 // vertical axis, 0 when facing forward), pitch is vertical rotation (rotation around the horizontal axis, also 0
 // when facing forward).
@@ -2389,6 +3167,32 @@ func (p *Player) Collect(s item.Stack) (int, bool) {
 	return added, true
 }
 
+// SetPickupRadius sets the horizontal distance from item entities within which
+// the player picks them up. This may be used to attract items from further
+// away, like a magnet. The radius is limited to entity.MaxPickupRadius.
+func (p *Player) SetPickupRadius(radius float64) {
+	p.pickupRadius = max(0, min(radius, entity.MaxPickupRadius))
+}
+
+// PickupRadius returns the horizontal distance from item entities within which
+// the player picks them up. By default, this is entity.DefaultPickupRadius.
+func (p *Player) PickupRadius() float64 {
+	return p.pickupRadius
+}
+
+// SetPickupFilter sets a function that decides which item stacks the player
+// picks up from the ground. Item entities for which f returns false are left
+// on the ground. Passing nil removes the filter.
+func (p *Player) SetPickupFilter(f func(s item.Stack) bool) {
+	p.pickupFilter = f
+}
+
+// CanPickup checks if the player picks up the item stack passed from the
+// ground, as decided by the filter set using SetPickupFilter.
+func (p *Player) CanPickup(s item.Stack) bool {
+	return p.pickupFilter == nil || p.pickupFilter(s)
+}
+
 // Experience returns the amount of experience the player has.
 func (p *Player) Experience() int {
 	return p.experience.Experience()
@@ -2525,14 +3329,17 @@ func (p *Player) mendItems(xp int) int {
 // ground.
 // The dropped item entity has a pickup delay of 2 seconds.
 // The number of items that was dropped in the end is returned. It is generally the count of the stack passed
-// or 0 if dropping the item.Stack was cancelled.
+// or 0 if dropping the item.Stack was cancelled or the world's chunk entity limit was reached.
 func (p *Player) Drop(s item.Stack) int {
 	ctx := newContext(p)
 	if p.Handler().HandleItemDrop(ctx, s); ctx.Cancelled() {
 		return 0
 	}
 	opts := world.EntitySpawnOpts{Position: p.Position().Add(mgl64.Vec3{0, 1.4}), Velocity: p.Rotation().Vec3().Mul(0.4)}
-	p.tx.AddEntity(entity.NewItemPickupDelay(opts, s, time.Second*2))
+	if p.tx.AddEntity(entity.NewItemPickupDelay(opts, s, time.Second*2)) == nil {
+		// The chunk the item would be dropped in holds too many entities.
+		return 0
+	}
 	return s.Count()
 }
 
@@ -2540,8 +3347,69 @@ func (p *Player) Drop(s item.Stack) int {
 // present at that location, OpenBlockContainer does nothing.
 // OpenBlockContainer will also do nothing if the player has no session connected to it.
 func (p *Player) OpenBlockContainer(pos cube.Pos, tx *world.Tx) {
+	if p.session() == session.Nop {
+		return
+	}
+	var inv *inventory.Inventory
+	switch b := tx.Block(pos).(type) {
+	case block.Container:
+		inv = b.Inventory(tx, pos)
+	case block.EnderChest:
+		inv = p.enderChest
+	}
+	ctx := newContext(p)
+	if p.Handler().HandleContainerOpen(ctx, pos, tx.Block(pos), inv); ctx.Cancelled() {
+		return
+	}
+	p.session().OpenBlockContainer(pos, tx)
+}
+
+// OpenVirtualContainer opens a container window holding the inventory passed that is not backed by a block
+// in the world, such as a menu or a shop. The title is shown at the top of the window and may include colour
+// codes. Slot clicks are passed to the inventory.Handler of the inventory, and changes to the inventory are
+// rejected if the ContainerType is read-only. Changes made to the inventory while the window is opened are
+// shown to the player.
+// An error is returned if the inventory has fewer slots than ContainerType.Size(). OpenVirtualContainer does
+// nothing if the player has no session connected to it.
+func (p *Player) OpenVirtualContainer(inv *inventory.Inventory, title string, kind ContainerType) error {
+	if inv.Size() < kind.Size() {
+		return fmt.Errorf("open virtual container: inventory has %v slots, but the container type needs %v", inv.Size(), kind.Size())
+	}
+	if p.session() == session.Nop {
+		return nil
+	}
+	// The fake block is shown below the player, which keeps it out of sight while being close enough
+	// for the client to open the window.
+	pos := cube.PosFromVec3(p.Position()).Sub(cube.Pos{0, 2, 0})
+	if pos.OutOfBounds(p.tx.Range()) {
+		pos = cube.PosFromVec3(p.Position()).Add(cube.Pos{0, 3, 0})
+	}
+	ctx := newContext(p)
+	if p.Handler().HandleContainerOpen(ctx, pos, nil, inv); ctx.Cancelled() {
+		return nil
+	}
+	p.session().OpenVirtualContainer(kind.virtualContainer(inv, title, pos), p.tx)
+	return nil
+}
+
+// SetCommandBlockEditor sets the function called to check if the player may view and edit the command block
+// at a position. Command blocks run their commands with the permissions of game directors, so no player may
+// edit command blocks while f is nil, which is the default.
+func (p *Player) SetCommandBlockEditor(f func(p *Player, pos cube.Pos) bool) {
+	p.commandBlockEditor = f
+}
+
+// CanEditCommandBlock checks if the player may view and edit the command block at the position passed. This
+// is only the case if the player has a creative inventory and the function set using SetCommandBlockEditor
+// returns true.
+func (p *Player) CanEditCommandBlock(pos cube.Pos) bool {
+	return p.GameMode().CreativeInventory() && p.commandBlockEditor != nil && p.commandBlockEditor(p, pos)
+}
+
+// CloseContainer closes the container that the player currently has opened, if any.
+func (p *Player) CloseContainer() {
 	if p.session() != session.Nop {
-		p.session().OpenBlockContainer(pos, tx)
+		p.session().CloseContainer(p.tx)
 	}
 }
 
@@ -2594,6 +3462,10 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 		}
 	}
 
+	if p.movementGraceTicks > 0 {
+		p.movementGraceTicks--
+	}
+	p.tickRiding()
 	p.checkBlockCollisions(p.data.Vel)
 	p.onGround = p.checkOnGround(mgl64.Vec3{})
 	p.checkEntitySteppers()
@@ -2606,10 +3478,13 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 	if p.Position()[1] < float64(p.tx.Range()[0]) {
 		p.Hurt(4, entity.VoidDamageSource{})
 	}
-	if p.insideOfSolid() {
+	if !p.noClip && p.insideOfSolid() {
 		p.Hurt(1, entity.SuffocationDamageSource{})
 	}
 
+	p.tickFreezing()
+	p.tickInsomnia(tx)
+
 	if p.OnFireDuration() > 0 {
 		p.fireTicks -= 1
 		if !p.GameMode().AllowsTakingDamage() || p.OnFireDuration() <= 0 || p.tx.RainingAt(cube.PosFromVec3(p.Position())) {
@@ -2631,8 +3506,13 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 	}
 
 	if p.usingItem {
-		if c, ok := held.Item().(item.Chargeable); ok {
-			c.ContinueCharge(p, tx, p.useContext(), p.useDuration())
+		switch it := held.Item().(type) {
+		case item.Chargeable:
+			it.ContinueCharge(p, tx, p.useContext(), p.useDuration())
+		case item.Consumable:
+			// Finish consuming the item once its consume duration has elapsed, regardless of whether the
+			// client signalled that it finished consuming it.
+			p.consumeItem(held, it)
 		}
 	}
 	if p.breaking {
@@ -2645,20 +3525,26 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 		}
 	}
 
+	p.tickMaps(tx)
+
 	p.session().SendDebugShapes(tx.World().Dimension())
 	p.session().SendHudUpdates()
 
-	if p.prevWorld != tx.World() && p.prevWorld != nil {
-		p.Handler().HandleChangeWorld(p, p.prevWorld, tx.World())
-	}
-	p.prevWorld = tx.World()
-
 	if p.session() == session.Nop && !p.Immobile() {
+		onGround := p.OnGround()
+		p.mc.Buoyancy.Drag = p.waterDrag()
 		m := p.mc.TickMovement(p, p.Position(), p.Velocity(), p.Rotation(), p.tx)
-		m.Send()
+		delta := m.Position().Sub(p.Position())
+		if backedOff := p.backOffFromEdge(delta); onGround && backedOff != delta {
+			// The movement would have made the sneaking player walk off an edge. Only the movement that
+			// was backed off from the edge is sent to viewers by Move.
+			delta = backedOff
+		} else {
+			m.Send()
+		}
 
 		p.data.Vel = m.Velocity()
-		p.Move(m.Position().Sub(p.Position()), 0, 0)
+		p.Move(delta, 0, 0)
 	} else {
 		p.data.Vel = mgl64.Vec3{}
 	}
@@ -2666,6 +3552,18 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 	p.portalTravel.StopPortalContact()
 }
 
+// waterDrag returns the drag applied to the velocity of the player while it is in water, which is reduced
+// by the Depth Strider enchantment on its boots.
+func (p *Player) waterDrag() float64 {
+	if e, ok := p.Armour().Boots().Enchantment(enchantment.DepthStrider); ok {
+		return enchantment.DepthStrider.Drag(waterDrag, e.Level(), p.OnGround())
+	}
+	return waterDrag
+}
+
+// waterDrag is the drag applied to the velocity of a player in water without the Depth Strider enchantment.
+const waterDrag = 0.2
+
 // TravelThroughPortal handles the player touching a portal block.
 func (p *Player) TravelThroughPortal(tx *world.Tx, target world.Dimension) {
 	if !p.GameMode().HasCollision() {
@@ -2730,6 +3628,43 @@ func (p *Player) tickAirSupply() {
 	}
 }
 
+// tickFreezing ticks the freezing of the player in powder snow. The player freezes while in powder snow and
+// not wearing any leather armour, and is hurt every two seconds while fully frozen.
+func (p *Player) tickFreezing() {
+	before := p.freeze.FrozenTicks()
+	if p.freeze.Tick(p.canFreeze()) {
+		p.Hurt(1, entity.FreezingDamageSource{})
+	}
+	if p.freeze.FrozenTicks() != before {
+		p.updateState()
+	}
+}
+
+// canFreeze checks if the player can freeze in powder snow. Players in a game mode that does not allow taking
+// damage and players wearing any leather armour cannot freeze.
+func (p *Player) canFreeze() bool {
+	if !p.GameMode().AllowsTakingDamage() {
+		return false
+	}
+	for _, it := range p.armour.Items() {
+		var tier item.ArmourTier
+		switch a := it.Item().(type) {
+		case item.Helmet:
+			tier = a.Tier
+		case item.Chestplate:
+			tier = a.Tier
+		case item.Leggings:
+			tier = a.Tier
+		case item.Boots:
+			tier = a.Tier
+		}
+		if _, ok := tier.(item.ArmourTierLeather); ok {
+			return false
+		}
+	}
+	return true
+}
+
 // tickFood ticks food related functionality, such as the depletion of the food bar and regeneration if it
 // is full enough.
 func (p *Player) tickFood() {
@@ -2832,7 +3767,7 @@ func (p *Player) insideOfWater() bool {
 // insideOfSolid returns true if the player is inside a solid block.
 func (p *Player) insideOfSolid() bool {
 	pos := cube.PosFromVec3(entity.EyePosition(p))
-	b, box := p.tx.Block(pos), p.handle.Type().BBox(p).Translate(p.Position())
+	b, box := p.tx.Block(pos), world.EntityBBox(p).Translate(p.Position())
 
 	_, solid := b.Model().(model.Solid)
 	if !solid {
@@ -2854,7 +3789,11 @@ func (p *Player) insideOfSolid() bool {
 
 // checkCollisions checks the player's block collisions.
 func (p *Player) checkBlockCollisions(vel mgl64.Vec3) {
-	entityBBox := Type.BBox(p).Translate(p.Position())
+	if p.noClip {
+		p.collidedHorizontally, p.collidedVertically = false, false
+		return
+	}
+	entityBBox := world.EntityBBox(p).Translate(p.Position())
 	deltaX, deltaY, deltaZ := vel[0], vel[1], vel[2]
 
 	p.checkEntityInsiders(entityBBox)
@@ -2938,7 +3877,7 @@ func (p *Player) checkEntitySteppers() {
 	if !p.OnGround() {
 		return
 	}
-	box := Type.BBox(p).Translate(p.Position()).Grow(-0.0001)
+	box := world.EntityBBox(p).Translate(p.Position()).Grow(-0.0001)
 	low, high := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
 	y := int(math.Floor(box.Min()[1] - 0.0001))
 
@@ -2955,7 +3894,7 @@ func (p *Player) checkEntitySteppers() {
 
 // checkOnGround checks if the player is currently considered to be on the ground.
 func (p *Player) checkOnGround(deltaPos mgl64.Vec3) bool {
-	box := Type.BBox(p).Translate(p.Position()).Extend(mgl64.Vec3{0, -0.05}).Extend(deltaPos.Mul(-1.0))
+	box := world.EntityBBox(p).Translate(p.Position()).Extend(mgl64.Vec3{0, -0.05}).Extend(deltaPos.Mul(-1.0))
 	b := box.Grow(1)
 
 	epsilon := mgl64.Vec3{mgl64.Epsilon, mgl64.Epsilon, mgl64.Epsilon}
@@ -3085,10 +4024,13 @@ func (p *Player) TurnLecternPage(pos cube.Pos, page int) error {
 }
 
 // updateState updates the state of the player to all viewers of the player.
+// The session of the player is updated directly if it is not yet viewing the
+// chunk of the player.
 func (p *Player) updateState() {
-	for _, v := range p.viewers() {
-		v.ViewEntityState(p)
+	if p.s != nil && slices.Index(p.tx.Viewers(p.Position()), world.Viewer(p.s)) == -1 {
+		p.s.ViewEntityState(p)
 	}
+	p.tx.UpdateEntityState(p)
 }
 
 // Breathing checks if the player is currently able to breathe. If it's underwater and the player does not
@@ -3194,9 +4136,9 @@ func (p *Player) InputLocked(l input.Lock) bool {
 
 // damageItem damages the item stack passed with the damage passed and returns the new stack. If the item
 // broke, a breaking sound is played.
-// If the player is not survival, the original stack is returned.
+// If the player is not survival or if the item is unbreakable, the original stack is returned.
 func (p *Player) damageItem(s item.Stack, d int) item.Stack {
-	if p.GameMode().CreativeInventory() || d == 0 || s.MaxDurability() == -1 {
+	if p.GameMode().CreativeInventory() || d == 0 || s.MaxDurability() == -1 || s.Unbreakable() {
 		return s
 	}
 	ctx := newContext(p)
@@ -3245,8 +4187,21 @@ func (p *Player) addNewItem(ctx *item.UseContext) {
 // is either survival or creative mode.
 func (p *Player) canReach(pos mgl64.Vec3) bool {
 	dist := entity.EyePosition(p).Sub(pos).Len()
-	return !p.Dead() && p.GameMode().AllowsInteraction() &&
-		(dist <= 8.0 || (dist <= 14.0 && p.GameMode().CreativeInventory()))
+	return !p.Dead() && p.GameMode().AllowsInteraction() && dist <= p.movementPolicy.reach(p.GameMode().CreativeInventory())
+}
+
+// canAttack checks if a player can reach the entity passed to attack it. The distance between the eyes of the
+// player and the closest point on the bounding box of the entity is checked against the attack reach of the
+// player, which depends on if the player is in creative mode.
+func (p *Player) canAttack(e world.Entity) bool {
+	box, eye := world.EntityBBox(e).Translate(e.Position()), entity.EyePosition(p)
+	closest := mgl64.Vec3{
+		mgl64.Clamp(eye[0], box.Min()[0], box.Max()[0]),
+		mgl64.Clamp(eye[1], box.Min()[1], box.Max()[1]),
+		mgl64.Clamp(eye[2], box.Min()[2], box.Max()[2]),
+	}
+	dist := eye.Sub(closest).Len()
+	return !p.Dead() && p.GameMode().AllowsInteraction() && dist <= p.movementPolicy.attackReach(p.GameMode().CreativeInventory())
 }
 
 // Disconnect closes the player and removes it from the world.
@@ -3289,8 +4244,11 @@ func (p *Player) close(msg string) {
 }
 
 func (p *Player) quit(msg string) {
+	ctx, quitMessage := newContext(p), p.quitMessage
+	p.h.HandleQuitMessage(ctx, &quitMessage)
 	p.h.HandleQuit(p)
 	p.h = NopHandler{}
+	p.leaveVehicle()
 
 	if s := p.s; s != nil {
 		s.Disconnect(msg)
@@ -3299,6 +4257,11 @@ func (p *Player) quit(msg string) {
 		// the world starts closing.
 		s.Close(p.tx, p)
 		s.CloseConnection()
+		// The quit message is only broadcast after the session was closed, so
+		// that the player itself no longer receives it.
+		if !ctx.Cancelled() && !quitMessage.Zero() {
+			chat.Global.Writet(quitMessage, p.Name())
+		}
 		return
 	}
 	// Only remove the player from the world if it's not attached to a session. If it is attached to a session, the
@@ -3307,12 +4270,29 @@ func (p *Player) quit(msg string) {
 	_ = p.handle.Close()
 }
 
+// Join broadcasts the join message of the player to the global chat after
+// calling Handler.HandleJoin. Join is called by the server once the player
+// has spawned and a Handler had the chance to be set. Only the first call to
+// Join has any effect.
+func (p *Player) Join() {
+	if p.joined {
+		return
+	}
+	p.joined = true
+
+	ctx, joinMessage := newContext(p), p.joinMessage
+	if p.h.HandleJoin(ctx, &joinMessage); ctx.Cancelled() || joinMessage.Zero() {
+		return
+	}
+	chat.Global.Writet(joinMessage, p.Name())
+}
+
 // Data returns the player data that needs to be saved. This is used when the player
 // gets disconnected and the player provider needs to save the data.
 func (p *Player) Data() Config {
 	p.hunger.mu.RLock()
 	defer p.hunger.mu.RUnlock()
-	return Config{
+	conf := Config{
 		Session:             p.s,
 		Skin:                p.skin,
 		XUID:                p.xuid,
@@ -3340,8 +4320,22 @@ func (p *Player) Data() Config {
 		EnderChestInventory: p.enderChest,
 		FireTicks:           p.fireTicks,
 		FallDistance:        p.fallDistance,
+		TimeSinceRest:       p.TimeSinceRest(),
 		Effects:             p.Effects(),
-	}
+		UnlockedRecipes:     p.UnlockedRecipes(),
+		Tags:                p.Tags(),
+		InventoryGroups:     p.InventoryGroups(),
+		MovementPolicy:      p.movementPolicy,
+		MovementStates:      p.movementStates,
+		GameModeOverride:    p.gameModeOverride,
+		FlightAllowed:       p.flightAllowed,
+		Flying:              p.flying,
+		FlightSpeed:         p.flightSpeed,
+		VerticalFlightSpeed: p.verticalFlightSpeed,
+	}
+	conf.Invulnerable, conf.InvulnerabilityBypass = p.invulnerable, p.invulnerabilityBypass
+	conf.CommandBlockEditor = p.commandBlockEditor
+	return conf
 }
 
 // session returns the network session of the player. If it has one, it is returned. If not, a no-op session
@@ -3422,7 +4416,7 @@ func (p *Player) broadcastArmour(_ int, before, after item.Stack) {
 
 // viewers returns a list of all viewers of the Player.
 func (p *Player) viewers() []world.Viewer {
-	viewers := p.tx.Viewers(p.Position())
+	viewers := p.tx.EntityViewers(p)
 	var s world.Viewer = p.session()
 	if slices.Index(viewers, s) == -1 && p.s != nil {
 		return append(viewers, p.s)
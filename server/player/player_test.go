@@ -0,0 +1,67 @@
+package player_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	_ "github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// newTestWorld returns a synchronous world that is closed when the test finishes.
+func newTestWorld(t *testing.T) *world.World {
+	t.Helper()
+	w := world.Config{Synchronous: true, Entities: entity.DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	return w
+}
+
+// doTx runs f in a transaction of the world passed and waits for it to finish.
+func doTx(t *testing.T, w *world.World, f func(tx *world.Tx)) {
+	t.Helper()
+	if err := w.Do(f).Wait(context.Background()); err != nil {
+		t.Fatalf("world task failed: %v", err)
+	}
+}
+
+// spawnTestPlayer spawns a player standing on a stone block at y=63 and
+// returns its handle. The name and position of conf are filled out if empty.
+func spawnTestPlayer(t *testing.T, w *world.World, conf player.Config) *world.EntityHandle {
+	t.Helper()
+	if conf.Name == "" {
+		conf.Name = "player"
+	}
+	if conf.Position == (mgl64.Vec3{}) {
+		conf.Position = mgl64.Vec3{0.5, 64, 0.5}
+	}
+	var handle *world.EntityHandle
+	doTx(t, w, func(tx *world.Tx) {
+		tx.SetBlock(cube.PosFromVec3(conf.Position).Side(cube.FaceDown), block.Stone{}, nil)
+		handle = tx.AddEntity(world.EntitySpawnOpts{}.New(player.Type, conf)).H()
+	})
+	return handle
+}
+
+// withPlayer runs f in the world with the player with the handle passed.
+func withPlayer(t *testing.T, w *world.World, handle *world.EntityHandle, f func(tx *world.Tx, p *player.Player)) {
+	t.Helper()
+	doTx(t, w, func(tx *world.Tx) {
+		e, ok := handle.Entity(tx)
+		if !ok {
+			t.Fatalf("player is no longer in the world")
+		}
+		f(tx, e.(*player.Player))
+	})
+}
+
+// advanceTicks advances the synchronous world passed by n ticks.
+func advanceTicks(w *world.World, n int) {
+	for range n {
+		w.AdvanceTick()
+	}
+}
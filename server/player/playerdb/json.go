@@ -32,9 +32,18 @@ func (p *Provider) fromJson(d jsonData, lookupWorld func(world.Dimension) *world
 		MaxAirSupply:        d.MaxAirSupply,
 		EnchantmentSeed:     d.EnchantmentSeed,
 		GameMode:            mode,
+		GameModeOverride:    d.GameModeOverride,
+		FlightAllowed:       d.FlightAllowed,
+		Flying:              d.Flying,
+		FlightSpeed:         d.FlightSpeed,
+		VerticalFlightSpeed: d.VerticalFlightSpeed,
 		Effects:             dataToEffects(d.Effects),
 		FireTicks:           d.FireTicks,
 		FallDistance:        d.FallDistance,
+		TimeSinceRest:       time.Duration(d.TimeSinceRest) * time.Second / 20,
+		UnlockedRecipes:     d.UnlockedRecipes,
+		Tags:                d.Tags,
+		Invulnerable:        d.Invulnerable,
 		Inventory:           inventory.New(36, nil),
 		EnderChestInventory: inventory.New(27, nil),
 		OffHand:             inventory.New(1, nil),
@@ -54,6 +63,12 @@ func (p *Provider) fromJson(d jsonData, lookupWorld func(world.Dimension) *world
 	for slot, stack := range echest {
 		_ = conf.EnderChestInventory.SetItem(slot, stack)
 	}
+	if len(d.InventoryGroups) > 0 {
+		conf.InventoryGroups = make(map[string]player.InventoryGroupState, len(d.InventoryGroups))
+		for group, data := range d.InventoryGroups {
+			conf.InventoryGroups[group] = dataToInventoryGroup(data)
+		}
+	}
 	return conf, lookupWorld(dim)
 }
 
@@ -61,6 +76,13 @@ func (p *Provider) toJson(d player.Config, w *world.World) jsonData {
 	dim, _ := world.DimensionID(w.Dimension())
 	mode, _ := world.GameModeID(d.GameMode)
 	offHand, _ := d.OffHand.Item(0)
+	var groups map[string]jsonInventoryGroup
+	if len(d.InventoryGroups) > 0 {
+		groups = make(map[string]jsonInventoryGroup, len(d.InventoryGroups))
+		for group, state := range d.InventoryGroups {
+			groups[group] = inventoryGroupToData(state)
+		}
+	}
 	return jsonData{
 		UUID:            d.UUID.String(),
 		Username:        d.Name,
@@ -82,6 +104,7 @@ func (p *Provider) toJson(d player.Config, w *world.World) jsonData {
 		Effects:         effectsToData(d.Effects),
 		FireTicks:       d.FireTicks,
 		FallDistance:    d.FallDistance,
+		TimeSinceRest:   int64(d.TimeSinceRest / (time.Second / 20)),
 		Inventory: invToData(InventoryData{
 			Items:        d.Inventory.Slots(),
 			Boots:        d.Armour.Boots(),
@@ -93,6 +116,60 @@ func (p *Provider) toJson(d player.Config, w *world.World) jsonData {
 		}),
 		EnderChestInventory: encodeItems(d.EnderChestInventory.Slots()),
 		Dimension:           uint8(dim),
+		GameModeOverride:    d.GameModeOverride,
+		FlightAllowed:       d.FlightAllowed,
+		Flying:              d.Flying,
+		FlightSpeed:         d.FlightSpeed,
+		VerticalFlightSpeed: d.VerticalFlightSpeed,
+		UnlockedRecipes:     d.UnlockedRecipes,
+		Tags:                d.Tags,
+		Invulnerable:        d.Invulnerable,
+		InventoryGroups:     groups,
+	}
+}
+
+func inventoryGroupToData(state player.InventoryGroupState) jsonInventoryGroup {
+	return jsonInventoryGroup{
+		Inventory: invToData(InventoryData{
+			Items:        state.Inventory,
+			Boots:        state.Boots,
+			Leggings:     state.Leggings,
+			Chestplate:   state.Chestplate,
+			Helmet:       state.Helmet,
+			OffHand:      state.OffHand,
+			MainHandSlot: uint32(state.HeldSlot),
+		}),
+		EnderChestInventory: encodeItems(state.EnderChest),
+		Health:              state.Health,
+		MaxHealth:           state.MaxHealth,
+		Hunger:              state.Food,
+		ExhaustionLevel:     state.Exhaustion,
+		SaturationLevel:     state.Saturation,
+		Experience:          state.Experience,
+		Effects:             effectsToData(state.Effects),
+	}
+}
+
+func dataToInventoryGroup(d jsonInventoryGroup) player.InventoryGroupState {
+	invData := dataToInv(d.Inventory)
+	echest := make([]item.Stack, 27)
+	decodeItems(d.EnderChestInventory, echest)
+	return player.InventoryGroupState{
+		Inventory:  invData.Items,
+		OffHand:    invData.OffHand,
+		EnderChest: echest,
+		Helmet:     invData.Helmet,
+		Chestplate: invData.Chestplate,
+		Leggings:   invData.Leggings,
+		Boots:      invData.Boots,
+		HeldSlot:   int(invData.MainHandSlot),
+		Health:     d.Health,
+		MaxHealth:  d.MaxHealth,
+		Food:       d.Hunger,
+		Exhaustion: d.ExhaustionLevel,
+		Saturation: d.SaturationLevel,
+		Experience: d.Experience,
+		Effects:    dataToEffects(d.Effects),
 	}
 }
 
@@ -110,12 +187,30 @@ type jsonData struct {
 	Experience                       int
 	AirSupply, MaxAirSupply          int
 	GameMode                         uint8
+	GameModeOverride                 bool
+	FlightAllowed, Flying            bool
+	FlightSpeed, VerticalFlightSpeed float64
 	Inventory                        jsonInventoryData
 	EnderChestInventory              []jsonSlot
 	Effects                          []jsonEffect
 	FireTicks                        int64
 	FallDistance                     float64
+	TimeSinceRest                    int64
 	Dimension                        uint8
+	UnlockedRecipes                  []string
+	Tags                             []string                      `json:",omitempty"`
+	Invulnerable                     bool                          `json:",omitempty"`
+	InventoryGroups                  map[string]jsonInventoryGroup `json:",omitempty"`
+}
+
+type jsonInventoryGroup struct {
+	Inventory                        jsonInventoryData
+	EnderChestInventory              []jsonSlot
+	Health, MaxHealth                float64
+	Hunger                           int
+	ExhaustionLevel, SaturationLevel float64
+	Experience                       int
+	Effects                          []jsonEffect
 }
 
 type jsonInventoryData struct {
@@ -0,0 +1,178 @@
+package player_test
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/recipe"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/playerdb"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+func TestUnlockRecipes(t *testing.T) {
+	w := newTestWorld(t)
+	handle := spawnTestPlayer(t, w, player.Config{UnlockedRecipes: []string{"b"}})
+
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		if !p.RecipeUnlocked("b") {
+			t.Fatalf("expected recipe from config to be unlocked")
+		}
+		p.UnlockRecipes("c", "a", "b")
+		if got := p.UnlockedRecipes(); !slices.Equal(got, []string{"a", "b", "c"}) {
+			t.Fatalf("expected sorted unlocked recipes [a b c], got %v", got)
+		}
+		p.LockRecipes("b", "d")
+		if p.RecipeUnlocked("b") {
+			t.Fatalf("expected locked recipe to no longer be unlocked")
+		}
+		if got := p.UnlockedRecipes(); !slices.Equal(got, []string{"a", "c"}) {
+			t.Fatalf("expected unlocked recipes [a c], got %v", got)
+		}
+	})
+}
+
+func TestUnlockRecipesSentToClient(t *testing.T) {
+	w, handle, conn := spawnSessionTestPlayerWith(t, session.Config{RecipeUnlocking: true}, player.Config{UnlockedRecipes: []string{"a"}})
+	pks := waitForPackets(t, conn, func(pks []packet.Packet) bool {
+		return len(packetsOf[*packet.UnlockedRecipes](pks)) > 0
+	})
+	if pk := packetsOf[*packet.UnlockedRecipes](pks)[0]; pk.UnlockType != packet.UnlockedRecipesTypeInitiallyUnlocked || !slices.Equal(pk.Recipes, []string{"a"}) {
+		t.Fatalf("expected initially unlocked recipes [a], got %v: %v", pk.UnlockType, pk.Recipes)
+	}
+
+	conn.reset()
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.UnlockRecipes("a", "b")
+		p.LockRecipes("a")
+	})
+	unlocked := packetsOf[*packet.UnlockedRecipes](waitForPackets(t, conn, func(pks []packet.Packet) bool {
+		return len(packetsOf[*packet.UnlockedRecipes](pks)) >= 2
+	}))
+	if unlocked[0].UnlockType != packet.UnlockedRecipesTypeNewlyUnlocked || !slices.Equal(unlocked[0].Recipes, []string{"b"}) {
+		t.Fatalf("expected only recipe b to be newly unlocked, got %v: %v", unlocked[0].UnlockType, unlocked[0].Recipes)
+	}
+	if unlocked[1].UnlockType != packet.UnlockedRecipesTypeRemoveUnlocked || !slices.Equal(unlocked[1].Recipes, []string{"a"}) {
+		t.Fatalf("expected recipe a to be removed, got %v: %v", unlocked[1].UnlockType, unlocked[1].Recipes)
+	}
+}
+
+func TestUnlockedRecipesPersisted(t *testing.T) {
+	prov, err := playerdb.NewProvider(t.TempDir())
+	if err != nil {
+		t.Fatalf("open provider: %v", err)
+	}
+	t.Cleanup(func() { _ = prov.Close() })
+
+	w := newTestWorld(t)
+	id := uuid.New()
+	name := recipe.Name(craftingTestRecipe)
+	handle := spawnTestPlayer(t, w, player.Config{UUID: id})
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.UnlockRecipes(name)
+		if err := prov.Save(id, p.Data(), w); err != nil {
+			t.Fatalf("save player data: %v", err)
+		}
+	})
+
+	conf, _, err := prov.Load(id, func(world.Dimension) *world.World { return w })
+	if err != nil {
+		t.Fatalf("load player data: %v", err)
+	}
+	conf.Position = conf.Position.Add([3]float64{2, 0, 0})
+	handle = spawnTestPlayer(t, w, conf)
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		if got := p.UnlockedRecipes(); !slices.Equal(got, []string{name}) {
+			t.Fatalf("expected unlocked recipes [%v] after loading, got %v", name, got)
+		}
+	})
+}
+
+func TestCraftingAutoRefill(t *testing.T) {
+	for _, refill := range []bool{false, true} {
+		networkID, input := registerCraftingTestRecipe(t)
+		w, handle, conn := spawnSessionTestPlayerWith(t, session.Config{CraftingAutoRefill: refill}, player.Config{})
+
+		conn.reset()
+		withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+			_ = p.Inventory().SetItem(1, input.Grow(2))
+		})
+		slots := packetsOf[*packet.InventorySlot](waitForPackets(t, conn, func(pks []packet.Packet) bool {
+			return len(packetsOf[*packet.InventorySlot](pks)) > 0
+		}))
+
+		// Move a single item into the crafting grid and craft the recipe
+		// with it.
+		take := &protocol.TakeStackRequestAction{}
+		take.Count = 1
+		take.Source = protocol.StackRequestSlotInfo{
+			Container:      protocol.FullContainerName{ContainerID: protocol.ContainerCombinedHotBarAndInventory},
+			Slot:           1,
+			StackNetworkID: slots[0].NewItem.StackNetworkID,
+		}
+		take.Destination = protocol.StackRequestSlotInfo{
+			Container: protocol.FullContainerName{ContainerID: protocol.ContainerCraftingInput},
+			Slot:      craftingTestGridSlot,
+		}
+		conn.send(&packet.ItemStackRequest{Requests: []protocol.ItemStackRequest{{
+			RequestID: 1,
+			Actions:   []protocol.StackRequestAction{take, &protocol.CraftRecipeStackRequestAction{RecipeNetworkID: networkID, NumberOfCrafts: 1}},
+		}}})
+		responses := packetsOf[*packet.ItemStackResponse](waitForPackets(t, conn, func(pks []packet.Packet) bool {
+			return len(packetsOf[*packet.ItemStackResponse](pks)) > 0
+		}))
+		if status := responses[0].Responses[0].Status; status != protocol.ItemStackResponseStatusOK {
+			t.Fatalf("refill %v: expected crafting request to succeed, got status %v", refill, status)
+		}
+
+		grid, left := 0, 2
+		if refill {
+			grid, left = 1, 1
+		}
+		for _, container := range responses[0].Responses[0].ContainerInfo {
+			for _, slot := range container.SlotInfo {
+				if container.Container.ContainerID == protocol.ContainerCraftingInput && slot.Slot == craftingTestGridSlot && int(slot.Count) != grid {
+					t.Fatalf("refill %v: expected %v items in the crafting grid, got %v", refill, grid, slot.Count)
+				}
+			}
+		}
+		withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+			if it, _ := p.Inventory().Item(1); it.Count() != left {
+				t.Fatalf("refill %v: expected %v items left in the inventory, got %v", refill, left, it.Count())
+			}
+		})
+	}
+}
+
+// craftingTestGridSlot is the first slot of the 2x2 crafting grid of the
+// player in the crafting input container.
+const craftingTestGridSlot = 28
+
+// craftingTestRecipe is a shapeless crafting table recipe with a single input
+// item. It is registered on the first call to registerCraftingTestRecipe.
+var craftingTestRecipe = recipe.NewShapeless([]recipe.Item{item.NewStack(block.Stone{}, 1)}, item.NewStack(block.StoneBricks{}, 1), "crafting_table")
+
+var craftingTestRecipeOnce sync.Once
+
+// registerCraftingTestRecipe registers craftingTestRecipe and returns its
+// network ID, as sent to the client by sessions created afterwards, and its
+// input.
+func registerCraftingTestRecipe(t *testing.T) (uint32, item.Stack) {
+	t.Helper()
+	craftingTestRecipeOnce.Do(func() { recipe.Register(craftingTestRecipe) })
+	name := recipe.Name(craftingTestRecipe)
+	for index, r := range recipe.Recipes() {
+		if recipe.Name(r) == name {
+			return uint32(index) + 1, craftingTestRecipe.Input()[0].(item.Stack)
+		}
+	}
+	t.Fatalf("crafting test recipe was not registered")
+	return 0, item.Stack{}
+}
@@ -0,0 +1,144 @@
+package player_test
+
+import (
+	"context"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// spawnSessionTestPlayer spawns a player with a session in a new world and
+// returns the world, the handle of the player and the connection of its
+// session. Unlike worlds returned by newTestWorld, the world is not
+// synchronous, because sessions tick on their own.
+func spawnSessionTestPlayer(t *testing.T, conf player.Config) (*world.World, *world.EntityHandle, *sessionTestConn) {
+	t.Helper()
+	return spawnSessionTestPlayerWith(t, session.Config{}, conf)
+}
+
+// spawnSessionTestPlayerWith spawns a player like spawnSessionTestPlayer, but
+// creates its session using the session.Config passed.
+func spawnSessionTestPlayerWith(t *testing.T, sconf session.Config, conf player.Config) (*world.World, *world.EntityHandle, *sessionTestConn) {
+	t.Helper()
+	w := world.Config{Entities: entity.DefaultRegistry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	conn := &sessionTestConn{in: make(chan packet.Packet, 16), closed: make(chan struct{})}
+	sconf.HandleStop = func(*world.Tx, session.Controllable) {}
+	s := sconf.New(conn)
+	if conf.Name == "" {
+		conf.Name = "player"
+	}
+	conf.Session = s
+	handle := world.EntitySpawnOpts{Position: mgl64.Vec3{0.5, 64, 0.5}}.New(player.Type, conf)
+	s.SetHandle(handle, conf.Skin)
+	doTx(t, w, func(tx *world.Tx) {
+		s.Spawn(tx.AddEntity(handle).(*player.Player), tx)
+	})
+	t.Cleanup(func() {
+		_ = w.Do(func(tx *world.Tx) {
+			if e, ok := handle.Entity(tx); ok {
+				_ = e.(*player.Player).Close()
+			}
+		}).Wait(context.Background())
+	})
+	return w, handle, conn
+}
+
+// waitForPackets waits until the packets written to conn satisfy f and
+// returns them. The test fails if that does not happen within five seconds.
+func waitForPackets(t *testing.T, conn *sessionTestConn, f func(pks []packet.Packet) bool) []packet.Packet {
+	t.Helper()
+	deadline := time.Now().Add(time.Second * 5)
+	for time.Now().Before(deadline) {
+		if pks := conn.packets(); f(pks) {
+			return pks
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("expected packets were not written in time")
+	return nil
+}
+
+// packetsOf returns the packets of type T in pks.
+func packetsOf[T packet.Packet](pks []packet.Packet) []T {
+	var found []T
+	for _, pk := range pks {
+		if pk, ok := pk.(T); ok {
+			found = append(found, pk)
+		}
+	}
+	return found
+}
+
+// sessionTestConn is a session.Conn that records the packets written to it
+// and returns the packets passed to send when the session reads from it.
+type sessionTestConn struct {
+	mu     sync.Mutex
+	sent   []packet.Packet
+	in     chan packet.Packet
+	closed chan struct{}
+	once   sync.Once
+}
+
+// reset clears the packets written to the connection so far.
+func (c *sessionTestConn) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent = nil
+}
+
+// packets returns the packets written to the connection since it was created
+// or last reset.
+func (c *sessionTestConn) packets() []packet.Packet {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.sent)
+}
+
+// send makes the session read pk from the connection, as if it was sent
+// by the client.
+func (c *sessionTestConn) send(pk packet.Packet) {
+	c.in <- pk
+}
+
+func (c *sessionTestConn) WritePacket(pk packet.Packet) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent = append(c.sent, pk)
+	return nil
+}
+
+func (c *sessionTestConn) Close() error {
+	c.once.Do(func() { close(c.closed) })
+	return nil
+}
+
+func (c *sessionTestConn) ReadPacket() (packet.Packet, error) {
+	select {
+	case pk := <-c.in:
+		return pk, nil
+	case <-c.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (c *sessionTestConn) IdentityData() login.IdentityData                           { return login.IdentityData{} }
+func (c *sessionTestConn) ClientData() login.ClientData                               { return login.ClientData{} }
+func (c *sessionTestConn) ClientCacheEnabled() bool                                   { return false }
+func (c *sessionTestConn) ChunkRadius() int                                           { return 0 }
+func (c *sessionTestConn) Latency() time.Duration                                     { return 0 }
+func (c *sessionTestConn) Flush() error                                               { return nil }
+func (c *sessionTestConn) RemoteAddr() net.Addr                                       { return &net.UDPAddr{} }
+func (c *sessionTestConn) StartGameContext(context.Context, minecraft.GameData) error { return nil }
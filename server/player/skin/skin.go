@@ -1,6 +1,8 @@
 package skin
 
 import (
+	"encoding/json"
+	"fmt"
 	"image"
 	"image/color"
 )
@@ -75,3 +77,40 @@ func (s Skin) At(x, y int) color.Color {
 		A: s.Pix[offset+3],
 	}
 }
+
+// Validate checks if the skin holds valid data. An error is returned if the dimensions of the skin are not one
+// of 64x32, 64x64, 128x64, 128x128 or 256x256 (Persona skins may have any size), if the pixel data of the
+// skin, its cape or any of its animations does not match their dimensions, or if the Model is not valid JSON.
+func (s Skin) Validate() error {
+	if s.w <= 0 || s.h <= 0 {
+		return fmt.Errorf("invalid skin dimensions %vx%v", s.w, s.h)
+	}
+	if !s.Persona && !validDimensions(s.w, s.h) {
+		return fmt.Errorf("invalid skin dimensions %vx%v: must be 64x32, 64x64, 128x64, 128x128 or 256x256", s.w, s.h)
+	}
+	if len(s.Pix) != s.w*s.h*4 {
+		return fmt.Errorf("skin pixel data has length %v, expected %v for %vx%v skin", len(s.Pix), s.w*s.h*4, s.w, s.h)
+	}
+	if len(s.Model) != 0 && !json.Valid(s.Model) {
+		return fmt.Errorf("skin model is not valid JSON")
+	}
+	if b := s.Cape.Bounds(); len(s.Cape.Pix) != b.Dx()*b.Dy()*4 {
+		return fmt.Errorf("cape pixel data has length %v, expected %v for %vx%v cape", len(s.Cape.Pix), b.Dx()*b.Dy()*4, b.Dx(), b.Dy())
+	}
+	for i, anim := range s.Animations {
+		if b := anim.Bounds(); len(anim.Pix) != b.Dx()*b.Dy()*4 {
+			return fmt.Errorf("animation %v pixel data has length %v, expected %v for %vx%v animation", i, len(anim.Pix), b.Dx()*b.Dy()*4, b.Dx(), b.Dy())
+		}
+	}
+	return nil
+}
+
+// validDimensions checks if a skin with the width and height passed has valid dimensions for a skin that does
+// not use the Persona system.
+func validDimensions(w, h int) bool {
+	switch {
+	case w == 64 && (h == 32 || h == 64), w == 128 && (h == 64 || h == 128), w == 256 && h == 256:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package skin
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	valid := New(128, 128)
+	valid.Model = []byte(`{"format_version": "1.12.0"}`)
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("validate 128x128 skin: %v", err)
+	}
+	// Persona skins may have dimensions that other skins may not have.
+	persona := New(100, 100)
+	persona.Persona = true
+	if err := persona.Validate(); err != nil {
+		t.Fatalf("validate persona skin: %v", err)
+	}
+
+	shortPix := New(64, 64)
+	shortPix.Pix = shortPix.Pix[:100]
+	badModel := New(64, 64)
+	badModel.Model = []byte(`{"geometry"`)
+	badCape := New(64, 64)
+	badCape.Cape = NewCape(64, 32)
+	badCape.Cape.Pix = badCape.Cape.Pix[:4]
+	badAnimation := New(64, 32)
+	badAnimation.Animations = []Animation{NewAnimation(32, 32, 0, AnimationHead)}
+	badAnimation.Animations[0].Pix = nil
+
+	for name, s := range map[string]Skin{
+		"dimensions":      New(10, 10),
+		"empty":           {},
+		"pixel data":      shortPix,
+		"model":           badModel,
+		"cape pixel data": badCape,
+		"animation":       badAnimation,
+	} {
+		if err := s.Validate(); err == nil {
+			t.Errorf("skin with malformed %v was valid, want an error", name)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package player_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// skinTestViewer is a world.Viewer that records the skins of the players it is
+// shown the skin of.
+type skinTestViewer struct {
+	world.NopViewer
+	skins []skin.Skin
+}
+
+func (v *skinTestViewer) ViewSkin(e world.Entity) {
+	v.skins = append(v.skins, e.(*player.Player).Skin())
+}
+
+func TestSetSkinShownToViewers(t *testing.T) {
+	w := newTestWorld(t)
+	handle := spawnTestPlayer(t, w, player.Config{})
+
+	v := &skinTestViewer{}
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		l := world.NewLoader(2, w, v)
+		defer l.Close(tx)
+		l.Move(tx, mgl64.Vec3{0.5, 64, 0.5})
+		l.Load(tx, 100)
+
+		s := skin.New(128, 128)
+		s.Pix[0] = 0xff
+		p.SetSkin(s)
+		if len(v.skins) != 1 || v.skins[0].Bounds().Dx() != 128 || v.skins[0].Pix[0] != 0xff {
+			t.Fatalf("expected the new 128x128 skin to be shown to the viewer, got %v skins", len(v.skins))
+		}
+		if got := p.Skin(); got.Bounds().Dx() != 128 {
+			t.Fatalf("expected skin of width 128 after the skin change, got %v", got.Bounds().Dx())
+		}
+	})
+}
+
+// skinTestPacket returns a PlayerSkin packet with a skin of the dimensions
+// passed and pixel data matching them.
+func skinTestPacket(w, h int) *packet.PlayerSkin {
+	return &packet.PlayerSkin{Skin: protocol.Skin{
+		SkinID:            "skin",
+		SkinImageWidth:    uint32(w),
+		SkinImageHeight:   uint32(h),
+		SkinData:          make([]byte, w*h*4),
+		SkinGeometry:      []byte(`{}`),
+		SkinResourcePatch: []byte(`{}`),
+	}}
+}
+
+func TestPlayerSkinPacketRejectsMalformedSkins(t *testing.T) {
+	w, handle, conn := spawnSessionTestPlayer(t, player.Config{Skin: skin.New(64, 32)})
+
+	// A client sending a malformed skin is sent its current skin again and
+	// is not disconnected.
+	conn.reset()
+	conn.send(skinTestPacket(10, 10))
+	pks := waitForPackets(t, conn, func(pks []packet.Packet) bool {
+		return len(packetsOf[*packet.PlayerSkin](pks)) > 0
+	})
+	if pk := packetsOf[*packet.PlayerSkin](pks)[0]; pk.UUID != handle.UUID() || pk.Skin.SkinImageWidth != 64 || pk.Skin.SkinImageHeight != 32 {
+		t.Fatalf("expected the current 64x32 skin to be resent, got a %vx%v skin", pk.Skin.SkinImageWidth, pk.Skin.SkinImageHeight)
+	}
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		if b := p.Skin().Bounds(); b.Dx() != 64 || b.Dy() != 32 {
+			t.Fatalf("expected the skin to stay 64x32 after a malformed skin change, got %vx%v", b.Dx(), b.Dy())
+		}
+	})
+
+	// The session must still handle packets after the malformed skin, so a
+	// valid skin sent afterwards is applied.
+	conn.send(skinTestPacket(64, 64))
+	waitForPackets(t, conn, func([]packet.Packet) bool {
+		var changed bool
+		withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+			changed = p.Skin().Bounds().Dy() == 64
+		})
+		return changed
+	})
+}
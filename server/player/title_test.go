@@ -0,0 +1,69 @@
+package player_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/title"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+func TestPlayerSendTitle(t *testing.T) {
+	titles := titleTestPackets(t, func(p *player.Player) {
+		p.SendTitle(title.New("title").WithSubtitle("subtitle").WithActionText("action").
+			WithFadeInDuration(time.Second / 2).WithDuration(time.Second * 3).WithFadeOutDuration(time.Second))
+	})
+	want := []packet.SetTitle{
+		{ActionType: packet.TitleActionSetDurations, FadeInDuration: 10, RemainDuration: 60, FadeOutDuration: 20},
+		{ActionType: packet.TitleActionSetTitle, Text: "title"},
+		{ActionType: packet.TitleActionSetSubtitle, Text: "subtitle"},
+		{ActionType: packet.TitleActionSetActionBar, Text: "action"},
+	}
+	if !slices.Equal(titles, want) {
+		t.Errorf("title packets sent = %+v, want %+v", titles, want)
+	}
+}
+
+func TestPlayerSendActionBar(t *testing.T) {
+	titles := titleTestPackets(t, func(p *player.Player) {
+		p.SendActionBar("action", 1)
+	})
+	if want := []packet.SetTitle{{ActionType: packet.TitleActionSetActionBar, Text: "action 1"}}; !slices.Equal(titles, want) {
+		t.Errorf("action bar packets sent = %+v, want %+v", titles, want)
+	}
+}
+
+func TestPlayerRemoveTitle(t *testing.T) {
+	titles := titleTestPackets(t, func(p *player.Player) {
+		p.RemoveTitle()
+	})
+	want := []packet.SetTitle{{ActionType: packet.TitleActionClear}, {ActionType: packet.TitleActionReset}}
+	if !slices.Equal(titles, want) {
+		t.Errorf("title packets sent on removal = %+v, want %+v", titles, want)
+	}
+}
+
+// titleTestPackets calls f with a player that has a session and returns the
+// packet.SetTitle packets sent to the player while f was called.
+func titleTestPackets(t *testing.T, f func(p *player.Player)) []packet.SetTitle {
+	t.Helper()
+	w, handle, conn := spawnSessionTestPlayer(t, player.Config{})
+	conn.reset()
+	withPlayer(t, w, handle, func(_ *world.Tx, p *player.Player) {
+		f(p)
+		// Send a packet after f so that all packets sent by f have been
+		// written once this packet arrives.
+		p.SendTip("done")
+	})
+	pks := waitForPackets(t, conn, func(pks []packet.Packet) bool {
+		return len(packetsOf[*packet.Text](pks)) > 0
+	})
+	var titles []packet.SetTitle
+	for _, pk := range packetsOf[*packet.SetTitle](pks) {
+		titles = append(titles, *pk)
+	}
+	return titles
+}
@@ -0,0 +1,92 @@
+package player
+
+import (
+	"time"
+
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world/sound"
+)
+
+// TotemConfig controls what happens when a totem of undying held by a Player saves it from lethal damage. The
+// zero value of TotemConfig behaves like vanilla.
+type TotemConfig struct {
+	// Disabled specifies if totems of undying never prevent the death of the player.
+	Disabled bool
+	// Health is the health that the player is left with after a totem is used. If 0, it defaults to 1.
+	Health float64
+	// Effects are the effects added to the player after a totem is used and its harmful effects are cleared.
+	// If nil, Regeneration II for 40 seconds, Fire Resistance for 40 seconds and Absorption II for 5 seconds
+	// are added.
+	Effects []effect.Effect
+}
+
+// health returns the health that the player is left with after using a totem.
+func (conf TotemConfig) health() float64 {
+	if conf.Health <= 0 {
+		return 1
+	}
+	return conf.Health
+}
+
+// effects returns the effects added to the player after using a totem.
+func (conf TotemConfig) effects() []effect.Effect {
+	if conf.Effects == nil {
+		return []effect.Effect{
+			effect.New(effect.Regeneration, 2, time.Second*40),
+			effect.New(effect.FireResistance, 1, time.Second*40),
+			effect.New(effect.Absorption, 2, time.Second*5),
+		}
+	}
+	return conf.Effects
+}
+
+// TotemConfig returns the TotemConfig that controls the use of totems of undying by the player.
+func (p *Player) TotemConfig() TotemConfig {
+	return p.totem
+}
+
+// SetTotemConfig changes the TotemConfig that controls the use of totems of undying by the player.
+func (p *Player) SetTotemConfig(conf TotemConfig) {
+	p.totem = conf
+}
+
+// useTotem attempts to use a totem of undying held in either hand of the player to prevent its death,
+// preferring the off hand. If the player holds no totem, or if totems are disabled, false is returned.
+func (p *Player) useTotem() bool {
+	if p.totem.Disabled {
+		return false
+	}
+	hand, offHand := p.HeldItems()
+	if _, ok := offHand.Item().(item.Totem); ok {
+		p.SetHeldItems(hand, offHand.Grow(-1))
+	} else if _, ok := hand.Item().(item.Totem); ok {
+		p.SetHeldItems(hand.Grow(-1), offHand)
+	} else {
+		return false
+	}
+	p.applyTotemEffects()
+	return true
+}
+
+// applyTotemEffects leaves the player with the health of its TotemConfig, clears its harmful effects and adds
+// the effects of the TotemConfig, after which the totem animation is shown to its viewers.
+func (p *Player) applyTotemEffects() {
+	p.addHealth(p.totem.health() - p.Health())
+
+	for _, e := range p.Effects() {
+		if effect.Harmful(e.Type()) {
+			p.RemoveEffect(e.Type())
+		}
+	}
+	for _, e := range p.totem.effects() {
+		p.AddEffect(e)
+	}
+
+	p.tx.PlaySound(p.Position(), sound.Totem{})
+
+	for _, viewer := range p.viewers() {
+		viewer.ViewEntityAction(p, entity.TotemUseAction{})
+	}
+}
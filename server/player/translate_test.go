@@ -0,0 +1,30 @@
+package player_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/player/chat"
+	"github.com/df-mc/dragonfly/server/world"
+	"golang.org/x/text/language"
+)
+
+func TestPlayerTranslate(t *testing.T) {
+	langs := chat.NewLanguages(language.BritishEnglish)
+	if err := langs.Load(language.MustParse("nl-NL"), strings.NewReader("test.greeting=Welkom, %s!\n")); err != nil {
+		t.Fatalf("load language: %v", err)
+	}
+	greeting := chat.Translate(langs.Key("test.greeting"), 1, "Hello, %v!")
+
+	w := newTestWorld(t)
+	english := spawnTestPlayer(t, w, player.Config{Name: "english", Locale: language.BritishEnglish})
+	dutch := spawnTestPlayer(t, w, player.Config{Name: "dutch", Locale: language.MustParse("nl-NL")})
+	for handle, want := range map[*world.EntityHandle]string{english: "§rHello, Steve!", dutch: "§rWelkom, Steve!"} {
+		withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+			if got := p.Translate(greeting, "Steve"); got != want {
+				t.Errorf("translation for player with locale %v = %q, want %q", p.Locale(), got, want)
+			}
+		})
+	}
+}
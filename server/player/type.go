@@ -39,6 +39,7 @@ func (t ptype) Open(tx *world.Tx, handle *world.EntityHandle, data *world.Entity
 		pd.offHand.SlotFunc(p.broadcastItems)
 		pd.armour.Inventory().SlotFunc(p.broadcastArmour)
 	}
+	p.handleWorldChange(tx)
 	return p
 }
 
@@ -46,17 +47,16 @@ func (ptype) EncodeEntity() string   { return "minecraft:player" }
 func (ptype) NetworkOffset() float64 { return 1.621 }
 func (ptype) BBox(e world.Entity) cube.BBox {
 	p := e.(*Player)
-	s := p.Scale()
 	_, sleeping := p.Sleeping()
 	switch {
 	case sleeping:
-		return cube.Box(-0.1*s, 0, -0.1*s, 0.1*s, 0.2*s, 0.1*s)
+		return cube.Box(-0.1, 0, -0.1, 0.1, 0.2, 0.1)
 	case p.Gliding(), p.Swimming(), p.Crawling():
-		return cube.Box(-0.3*s, 0, -0.3*s, 0.3*s, 0.6*s, 0.3*s)
+		return cube.Box(-0.3, 0, -0.3, 0.3, 0.6, 0.3)
 	case p.Sneaking():
-		return cube.Box(-0.3*s, 0, -0.3*s, 0.3*s, 1.49*s, 0.3*s)
+		return cube.Box(-0.3, 0, -0.3, 0.3, 1.49, 0.3)
 	default:
-		return cube.Box(-0.3*s, 0, -0.3*s, 0.3*s, 1.8*s, 0.3*s)
+		return cube.Box(-0.3, 0, -0.3, 0.3, 1.8, 0.3)
 	}
 }
 func (t ptype) DecodeNBT(map[string]any, *world.EntityData) {}
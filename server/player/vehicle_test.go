@@ -0,0 +1,267 @@
+package player_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+func TestBoatItemPlacesBoat(t *testing.T) {
+	w := newTestWorld(t)
+	handle := spawnTestPlayer(t, w, player.Config{})
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		tx.SetBlock(cube.Pos{2, 63, 0}, block.Stone{}, nil)
+		p.SetHeldItems(item.NewStack(item.Boat{Type: item.BoatTypeSpruce()}, 1), item.Stack{})
+		p.UseItemOnBlock(cube.Pos{2, 63, 0}, cube.FaceUp, mgl64.Vec3{0.5, 1, 0.5})
+
+		boat := vehicleTestEntity(t, tx, entity.BoatType)
+		if want := (mgl64.Vec3{2.5, 64, 0.5}); boat.Position() != want {
+			t.Errorf("boat placed at %v, want %v", boat.Position(), want)
+		}
+		if wood := boat.Behaviour().(*entity.BoatBehaviour).Wood(); wood != block.SpruceWood() {
+			t.Errorf("boat placed with wood %v, want spruce", wood)
+		}
+		if held, _ := p.HeldItems(); !held.Empty() {
+			t.Errorf("held item after placing boat = %v, want empty", held)
+		}
+	})
+}
+
+func TestBoatBreaksAfterHits(t *testing.T) {
+	w := newTestWorld(t)
+	handle := spawnTestPlayer(t, w, player.Config{})
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		boat := tx.AddEntity(entity.NewBoat(world.EntitySpawnOpts{Position: mgl64.Vec3{1.5, 64, 0.5}}, block.BirchWood()))
+		for range 4 {
+			p.AttackEntity(boat)
+		}
+		if _, ok := boat.H().Entity(tx); !ok {
+			t.Fatalf("boat broke after 4 hits, want it to break after 5")
+		}
+		p.AttackEntity(boat)
+		if _, ok := boat.H().Entity(tx); ok {
+			t.Fatalf("boat did not break after 5 hits")
+		}
+		drop := vehicleTestEntity(t, tx, entity.ItemType).Behaviour().(*entity.ItemBehaviour).Item()
+		if want := item.NewStack(item.Boat{Type: item.BoatTypeBirch()}, 1); !drop.Equal(want) {
+			t.Errorf("broken boat dropped %v, want %v", drop, want)
+		}
+	})
+}
+
+func TestBoatBreaksInstantlyInCreative(t *testing.T) {
+	w := newTestWorld(t)
+	handle := spawnTestPlayer(t, w, player.Config{GameMode: world.GameModeCreative})
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		boat := tx.AddEntity(entity.NewBoat(world.EntitySpawnOpts{Position: mgl64.Vec3{1.5, 64, 0.5}}, block.OakWood()))
+		p.AttackEntity(boat)
+		if _, ok := boat.H().Entity(tx); ok {
+			t.Fatalf("boat hit by creative player did not break")
+		}
+		if n := len(slices.Collect(tx.Entities())); n != 1 {
+			t.Errorf("%v entities after boat was broken in creative, want only the player", n)
+		}
+	})
+}
+
+func TestHorseRequiresSaddleToSteer(t *testing.T) {
+	w := newTestWorld(t)
+	handle := spawnTestPlayer(t, w, player.Config{})
+	horse := world.EntitySpawnOpts{Position: mgl64.Vec3{1.5, 64, 0.5}}.New(entity.HorseType, entity.HorseBehaviourConfig{Gravity: 0.08, Drag: 0.02, Speed: 0.7})
+	doTx(t, w, func(tx *world.Tx) {
+		tx.SetBlock(cube.Pos{1, 63, 0}, block.Stone{}, nil)
+		tx.AddEntity(horse)
+	})
+	advanceTicks(w, 2)
+
+	steer := func() (moved float64) {
+		withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+			p.SteerVehicle(entity.RideInput{Forward: 1})
+		})
+		before := vehicleTestPosition(t, w, horse)
+		advanceTicks(w, 1)
+		return vehicleTestPosition(t, w, horse).Sub(before)[2]
+	}
+
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		e, _ := horse.Entity(tx)
+		if !p.InteractWithEntity(e, e.Position()) {
+			t.Fatalf("interaction with horse was not valid")
+		}
+		if r, ok := p.Riding(); !ok || r != horse {
+			t.Fatalf("player did not mount horse")
+		}
+	})
+	if moved := steer(); moved > 1e-9 {
+		t.Errorf("horse without saddle moved %v forward, want it not to be steered", moved)
+	}
+
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.Dismount()
+		p.SetHeldItems(item.NewStack(item.Saddle{}, 1), item.Stack{})
+		e, _ := horse.Entity(tx)
+		p.InteractWithEntity(e, e.Position())
+		if held, _ := p.HeldItems(); !held.Empty() || !e.(*entity.Ent).Saddled() {
+			t.Fatalf("held item and horse saddled after using saddle = %v, %v, want empty, true", held, e.(*entity.Ent).Saddled())
+		}
+		if _, ok := p.Riding(); ok {
+			t.Fatalf("player mounted horse while putting a saddle on it")
+		}
+		p.InteractWithEntity(e, e.Position())
+	})
+	if moved := steer(); moved <= 0 {
+		t.Errorf("saddled horse moved %v forward, want it to move forward", moved)
+	}
+}
+
+func TestMinecartSteer(t *testing.T) {
+	w := newTestWorld(t)
+	handle := spawnTestPlayer(t, w, player.Config{})
+	var minecart *world.EntityHandle
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		tx.SetBlock(cube.Pos{1, 63, 0}, block.Stone{}, nil)
+		p.SetHeldItems(item.NewStack(item.Minecart{}, 1), item.Stack{})
+		p.UseItemOnBlock(cube.Pos{1, 63, 0}, cube.FaceUp, mgl64.Vec3{0.5, 1, 0.5})
+		e := vehicleTestEntity(t, tx, entity.MinecartType)
+		minecart = e.H()
+		p.InteractWithEntity(e, e.Position())
+		if r, ok := p.Riding(); !ok || r != minecart {
+			t.Fatalf("player did not mount minecart")
+		}
+	})
+	advanceTicks(w, 2)
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.SteerVehicle(entity.RideInput{Forward: 1})
+	})
+	before := vehicleTestPosition(t, w, minecart)
+	advanceTicks(w, 1)
+	if moved := vehicleTestPosition(t, w, minecart).Sub(before)[2]; moved <= 0 {
+		t.Errorf("minecart steered forward moved %v, want it to move forward", moved)
+	}
+}
+
+func TestPlayerAuthInputSteersVehicle(t *testing.T) {
+	w, handle, conn := spawnSessionTestPlayer(t, player.Config{})
+	b := &vehicleTestBehaviour{ride: &entity.RideComputer{Seats: []mgl64.Vec3{{0, 1, 0}}}}
+	var pos mgl32.Vec3
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		vehicle := tx.AddEntity(world.EntitySpawnOpts{Position: p.Position()}.New(vehicleTestType{}, b)).(*entity.Ent)
+		if !p.Mount(vehicle) {
+			t.Fatalf("player could not mount custom vehicle")
+		}
+		pos = vec64To32(p.Position().Add(mgl64.Vec3{0, 1.62}))
+	})
+
+	jump := protocol.NewBitset(packet.PlayerAuthInputBitsetSize)
+	jump.Set(packet.InputFlagJumpDown)
+	for _, test := range []struct {
+		name  string
+		move  mgl32.Vec2
+		input protocol.Bitset
+		want  entity.RideInput
+	}{
+		// The X component of the move vector is the sideways input, which is
+		// positive when moving left, and the Y component the forward input.
+		{name: "forward and right", move: mgl32.Vec2{-1, 1}, want: entity.RideInput{Forward: 1, Strafe: -1}},
+		{name: "backward and left", move: mgl32.Vec2{0.5, -0.5}, want: entity.RideInput{Forward: -0.5, Strafe: 0.5}},
+		{name: "jumping", move: mgl32.Vec2{0, 1}, input: jump, want: entity.RideInput{Forward: 1, Jumping: true}},
+	} {
+		if test.input.Len() == 0 {
+			test.input = protocol.NewBitset(packet.PlayerAuthInputBitsetSize)
+		}
+		conn.send(&packet.PlayerAuthInput{Position: pos, MoveVector: test.move, InputData: test.input})
+		if got := vehicleTestInput(t, w, b); got != test.want {
+			t.Errorf("vehicle input after moving %v = %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
+
+// vehicleTestEntity returns the only entity of the type passed in the world.
+func vehicleTestEntity(t *testing.T, tx *world.Tx, typ world.EntityType) *entity.Ent {
+	t.Helper()
+	var found []world.Entity
+	for e := range tx.Entities() {
+		if e.H().Type() == typ {
+			found = append(found, e)
+		}
+	}
+	if len(found) != 1 {
+		t.Fatalf("found %v entities of type %v, want 1", len(found), typ.EncodeEntity())
+	}
+	return found[0].(*entity.Ent)
+}
+
+// vehicleTestPosition returns the position of the entity with the handle passed.
+func vehicleTestPosition(t *testing.T, w *world.World, handle *world.EntityHandle) (pos mgl64.Vec3) {
+	t.Helper()
+	doTx(t, w, func(tx *world.Tx) {
+		e, ok := handle.Entity(tx)
+		if !ok {
+			t.Fatalf("entity is no longer in the world")
+		}
+		pos = e.Position()
+	})
+	return pos
+}
+
+// vehicleTestInput waits until the vehicle with the behaviour passed has
+// been steered and returns the input passed to it. The test fails if that
+// does not happen within five seconds.
+func vehicleTestInput(t *testing.T, w *world.World, b *vehicleTestBehaviour) (input entity.RideInput) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second * 5)
+	for time.Now().Before(deadline) {
+		doTx(t, w, func(tx *world.Tx) {
+			input = b.ride.Input()
+		})
+		if input != (entity.RideInput{}) {
+			return input
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("vehicle was not steered in time")
+	return input
+}
+
+// vec64To32 converts a mgl64.Vec3 to a mgl32.Vec3.
+func vec64To32(vec mgl64.Vec3) mgl32.Vec3 {
+	return mgl32.Vec3{float32(vec[0]), float32(vec[1]), float32(vec[2])}
+}
+
+// vehicleTestBehaviour is the entity.Behaviour of a custom vehicle. It keeps
+// the input of its rider until it is read by the test.
+type vehicleTestBehaviour struct {
+	ride *entity.RideComputer
+}
+
+func (b *vehicleTestBehaviour) Apply(data *world.EntityData) { data.Data = b }
+
+func (b *vehicleTestBehaviour) RideComputer() *entity.RideComputer { return b.ride }
+
+func (b *vehicleTestBehaviour) Tick(e *entity.Ent, tx *world.Tx) *entity.Movement {
+	b.ride.Tick(e, tx)
+	return nil
+}
+
+type vehicleTestType struct{}
+
+func (vehicleTestType) Open(tx *world.Tx, handle *world.EntityHandle, data *world.EntityData) world.Entity {
+	return entity.Open(tx, handle, data)
+}
+func (vehicleTestType) EncodeEntity() string { return "dragonfly:vehicle_test_vehicle" }
+func (vehicleTestType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.5, 0, -0.5, 0.5, 1, 0.5)
+}
+func (vehicleTestType) DecodeNBT(map[string]any, *world.EntityData) {}
+func (vehicleTestType) EncodeNBT(*world.EntityData) map[string]any  { return nil }
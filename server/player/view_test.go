@@ -0,0 +1,31 @@
+package player_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+func TestSessionViewsPlayerInReloadedChunk(t *testing.T) {
+	w, handle, conn := spawnSessionTestPlayer(t, player.Config{TeleportReloadDistance: 1})
+
+	// Teleporting reloads the chunks around the player, including the chunk
+	// that the player is in. The session is shown the player in it while the
+	// chunk is loaded, after which the world must keep running.
+	conn.reset()
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.Teleport(mgl64.Vec3{2.5, 64, 0.5})
+	})
+	waitForPackets(t, conn, func(pks []packet.Packet) bool {
+		return len(packetsOf[*packet.LevelChunk](pks)) > 0
+	})
+	withPlayer(t, w, handle, func(tx *world.Tx, p *player.Player) {
+		p.Message("done")
+	})
+	waitForPackets(t, conn, func(pks []packet.Packet) bool {
+		return len(packetsOf[*packet.Text](pks)) > 0
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	_ "embed"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"iter"
 	"maps"
@@ -17,6 +18,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/internal/blockinternal"
 	"github.com/df-mc/dragonfly/server/internal/iteminternal"
 	"github.com/df-mc/dragonfly/server/internal/sliceutil"
@@ -53,6 +55,10 @@ type Server struct {
 	listeners []Listener
 	incoming  chan incoming
 
+	// slots limits the amount of players that may be connected to the server
+	// at the same time.
+	slots *playerSlots
+
 	pmu sync.RWMutex
 	// p holds a map of all players currently connected to the server. When they
 	// leave, they are removed from the map.
@@ -143,12 +149,15 @@ func (srv *Server) Accept() iter.Seq[*player.Player] {
 			ret, err := world.Call(context.Background(), inc.w, func(tx *world.Tx) (bool, error) {
 				p := tx.AddEntity(inc.p.handle).(*player.Player)
 				inc.s.Spawn(p, tx)
-				return !yield(p), nil
+				stop := !yield(p)
+				p.Join()
+				return stop, nil
 			})
 			if err != nil {
 				srv.pmu.Lock()
 				delete(srv.p, inc.p.handle.UUID())
 				srv.pmu.Unlock()
+				srv.slots.release(inc.p.handle.UUID())
 				srv.pwg.Done()
 				// Join failed before spawn: the entity was never added, so close
 				// the orphaned handle and fully tear the session down (Disconnect
@@ -348,9 +357,9 @@ func (srv *Server) close() {
 }
 
 // listen makes the Server listen for new connections from the Listener passed.
-// This may be used to listen for players on different interfaces. Note that
-// the maximum player count of additional Listeners added is not enforced
-// automatically. The limit must be enforced by the Listener.
+// This may be used to listen for players on different interfaces. The maximum
+// player count of the Server is enforced for all Listeners, regardless of
+// any limit enforced by the Listener itself.
 func (srv *Server) listen(l Listener) {
 	wg := new(sync.WaitGroup)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -377,11 +386,41 @@ func (srv *Server) listen(l Listener) {
 				_ = c.Close()
 				return
 			}
+			if err := srv.parseSkin(c.ClientData()).Validate(); err != nil {
+				_ = c.WritePacket(&packet.Disconnect{Message: "Invalid skin."})
+				_ = c.Close()
+				srv.conf.Log.Debug("join failed: "+err.Error(), "raddr", c.RemoteAddr())
+				return
+			}
+			if err := srv.acquireSlot(ctx, c); err != nil {
+				msg := "Server is full."
+				if errors.Is(err, errAlreadyLoggedIn) {
+					msg = "Already logged in."
+				}
+				_ = c.WritePacket(&packet.Disconnect{Message: msg})
+				_ = c.Close()
+				srv.conf.Log.Debug("join failed: "+err.Error(), "raddr", c.RemoteAddr())
+				return
+			}
 			srv.finaliseConn(ctx, c, l)
 		}()
 	}
 }
 
+// acquireSlot acquires a player slot for the connection passed. If the server
+// is full and QueueWhenFull is set, the connection is queued until a slot
+// frees up or the connection is closed.
+func (srv *Server) acquireSlot(ctx context.Context, c session.Conn) error {
+	id := uuid.MustParse(c.IdentityData().Identity)
+	reserved := srv.conf.Reserved != nil && srv.conf.Reserved(c.IdentityData())
+	return srv.slots.acquire(ctx, id, reserved, srv.conf.QueueWhenFull, func(position int) error {
+		if err := c.WritePacket(&packet.Text{TextType: packet.TextTypeRaw, Message: fmt.Sprintf(srv.conf.QueueMessage, position)}); err != nil {
+			return err
+		}
+		return c.Flush()
+	})
+}
+
 // startListening starts making the EncodeBlock listener listen, accepting new
 // connections from players.
 func (srv *Server) startListening() {
@@ -398,11 +437,13 @@ func (srv *Server) startListening() {
 // registered custom blocks. It allows block components to be created only once
 // at startup.
 func (srv *Server) makeBlockEntries() {
-	custom := slices.Collect(maps.Values(srv.conf.Blocks.CustomBlocks()))
+	custom := srv.conf.Blocks.CustomBlocks()
 	srv.customBlocks = make([]protocol.BlockEntry, len(custom))
 
-	for i, b := range custom {
-		name, _ := b.EncodeBlock()
+	// Block IDs are assigned in the order of the identifiers of the blocks, so that they are the same across
+	// restarts.
+	for i, name := range slices.Sorted(maps.Keys(custom)) {
+		b := custom[name]
 		srv.customBlocks[i] = protocol.BlockEntry{
 			Name:       name,
 			Properties: blockinternal.Components(name, b, 10000+int32(i)),
@@ -415,23 +456,10 @@ func (srv *Server) makeBlockEntries() {
 // at startup
 func (srv *Server) makeItemComponents() {
 	custom := world.CustomItems()
-	srv.customItems = make([]protocol.ItemEntry, len(custom))
+	srv.customItems = make([]protocol.ItemEntry, 0, len(custom))
 
 	for _, it := range custom {
-		name, _ := it.EncodeItem()
-		rid, _, _ := world.ItemRuntimeID(it)
-		_, isCustomBlock := it.(world.CustomBlock)
-		var entryVersion int32 = protocol.ItemEntryVersionDataDriven
-		if isCustomBlock {
-			entryVersion = protocol.ItemEntryVersionNone
-		}
-		srv.customItems = append(srv.customItems, protocol.ItemEntry{
-			Name:           name,
-			ComponentBased: !isCustomBlock,
-			RuntimeID:      int16(rid),
-			Version:        entryVersion,
-			Data:           iteminternal.Components(it),
-		})
+		srv.customItems = append(srv.customItems, iteminternal.Entry(it))
 	}
 }
 
@@ -452,10 +480,20 @@ func (srv *Server) finaliseConn(ctx context.Context, conn session.Conn, l Listen
 	d, w, err := srv.conf.PlayerProvider.Load(id, srv.dimension)
 	if err != nil {
 		w = srv.world
-		d.Position = w.Spawn().Vec3Centre()
+		spawn, err := world.Call(ctx, w, func(tx *world.Tx) (cube.Pos, error) {
+			return tx.RandomSpawn(), nil
+		})
+		if err != nil {
+			spawn = w.Spawn()
+		}
+		d.Position = spawn.Vec3Centre()
+		d.GameMode = w.DefaultGameMode()
+	} else if w.ForceGameMode() && !d.GameModeOverride {
 		d.GameMode = w.DefaultGameMode()
 	}
 
+	gm, _ := world.GameModeID(d.GameMode)
+	data.PlayerGameMode = int32(gm)
 	data.PlayerPosition = vec64To32(d.Position).Add(mgl32.Vec3{0, 1.62})
 	dim, _ := world.DimensionID(w.Dimension())
 	data.Dimension = int32(dim)
@@ -465,11 +503,13 @@ func (srv *Server) finaliseConn(ctx context.Context, conn session.Conn, l Listen
 
 	if err := conn.StartGameContext(ctx, data); err != nil {
 		_ = l.Disconnect(conn, "Connection timeout.")
+		srv.slots.release(id)
 
 		srv.conf.Log.Debug("spawn failed: "+err.Error(), "raddr", conn.RemoteAddr())
 		return
 	}
 	if _, ok := srv.Player(id); ok {
+		// The slot is held by the player already online with this UUID, so it is not released here.
 		_ = l.Disconnect(conn, "Already logged in.")
 		srv.conf.Log.Debug("spawn failed: already logged in", "raddr", conn.RemoteAddr())
 		return
@@ -503,6 +543,7 @@ func (srv *Server) defaultGameData() minecraft.GameData {
 		GameRules: []protocol.GameRule{
 			{Name: "naturalregeneration", Value: false},
 			{Name: "locatorBar", Value: false},
+			{Name: "recipesunlock", Value: srv.conf.RecipeUnlocking},
 		},
 
 		ServerAuthoritativeInventory: true,
@@ -531,6 +572,7 @@ func (srv *Server) handleSessionClose(tx *world.Tx, c session.Controllable) {
 	_, ok := srv.p[c.UUID()]
 	delete(srv.p, c.UUID())
 	srv.pmu.Unlock()
+	srv.slots.release(c.UUID())
 	if !ok {
 		// When a player disconnects immediately after a session is started, it
 		// might not be added to the players map yet. This is expected, but we
@@ -556,11 +598,15 @@ func (srv *Server) createPlayer(id uuid.UUID, conn session.Conn, conf player.Con
 	s := session.Config{
 		Log:            srv.conf.Log,
 		MaxChunkRadius: srv.conf.MaxChunkRadius,
+		ChunksPerTick:  srv.conf.ChunksPerTick,
 		EmoteChatMuted: srv.conf.MuteEmoteChat,
-		JoinMessage:    srv.conf.JoinMessage,
-		QuitMessage:    srv.conf.QuitMessage,
 		HandleStop:     srv.handleSessionClose,
 		BlockRegistry:  w.BlockRegistry(),
+
+		RecipeUnlocking:    srv.conf.RecipeUnlocking,
+		CraftingAutoRefill: srv.conf.CraftingAutoRefill,
+
+		DisableInventoryValidation: srv.conf.DisableInventoryValidation,
 	}.New(conn)
 
 	conf.Name = conn.IdentityData().DisplayName
@@ -569,6 +615,8 @@ func (srv *Server) createPlayer(id uuid.UUID, conn session.Conn, conf player.Con
 	conf.Locale, _ = language.Parse(strings.Replace(conn.ClientData().LanguageCode, "_", "-", 1))
 	conf.Skin = srv.parseSkin(conn.ClientData())
 	conf.Session = s
+	conf.JoinMessage, conf.QuitMessage = srv.conf.JoinMessage, srv.conf.QuitMessage
+	conf.MovementPolicy = srv.conf.MovementPolicy
 
 	handle := world.EntitySpawnOpts{Position: conf.Position, ID: id}.New(player.Type, conf)
 	s.SetHandle(handle, conf.Skin)
@@ -588,11 +636,18 @@ func (srv *Server) createWorld(dim world.Dimension, nether, end **world.World) *
 		Provider:            srv.conf.WorldProvider,
 		Generator:           srv.conf.Generator(dim),
 		RandomTickSpeed:     srv.conf.RandomTickSpeed,
+		ScheduledTickBudget: srv.conf.ScheduledTickBudget,
 		ReadOnly:            srv.conf.ReadOnlyWorld,
 		SaveInterval:        srv.conf.SaveInterval,
 		ChunkUnloadInterval: srv.conf.ChunkUnloadInterval,
 		Entities:            srv.conf.Entities,
 		Blocks:              srv.conf.Blocks,
+
+		ChunkEntityLimit:     srv.conf.ChunkEntityLimit,
+		LimitedEntity:        srv.conf.LimitedEntity,
+		RemoveOldestEntities: srv.conf.RemoveOldestEntities,
+		Teams:                srv.conf.Teams,
+		DamageListener:       srv.conf.DamageListener,
 		PortalDestination: func(dim world.Dimension) *world.World {
 			switch dim {
 			case world.Nether:
@@ -604,6 +659,9 @@ func (srv *Server) createWorld(dim world.Dimension, nether, end **world.World) *
 			}
 		},
 	}
+	if srv.conf.BiomeSource != nil {
+		conf.BiomeSource = srv.conf.BiomeSource(dim)
+	}
 	w := conf.New()
 	logger.Info("Opened dimension.", "name", w.Name())
 	return w
@@ -3,6 +3,7 @@ package session
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/inventory"
@@ -39,7 +40,7 @@ type Controllable interface {
 	SetHeldItems(right, left item.Stack)
 	SetHeldSlot(slot int) error
 
-	Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64)
+	ClientMove(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64)
 
 	Speed() float64
 	FlightSpeed() float64
@@ -52,12 +53,20 @@ type Controllable interface {
 	ExecuteCommand(commandLine string)
 	GameMode() world.GameMode
 	SetGameMode(mode world.GameMode)
+	NoClip() bool
+	Invulnerable() bool
+	CanEditCommandBlock(pos cube.Pos) bool
+	UnlockedRecipes() []string
 	Effects() []effect.Effect
 
 	UseItem()
 	ReleaseItem()
 	UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec3)
 	UseItemOnEntity(e world.Entity) bool
+	InteractWithEntity(e world.Entity, clickPos mgl64.Vec3) bool
+	Riding() (*world.EntityHandle, bool)
+	Dismount()
+	SteerVehicle(input entity.RideInput)
 	BreakBlock(pos cube.Pos)
 	PickBlock(pos cube.Pos)
 	AttackEntity(e world.Entity) bool
@@ -92,12 +101,15 @@ type Controllable interface {
 	StartCrawling()
 	Crawling() bool
 	StopCrawling()
+	CanFly() bool
 	StartFlying()
 	Flying() bool
 	StopFlying()
 	StartGliding()
 	Gliding() bool
 	StopGliding()
+	StartSpinAttack()
+	StopSpinAttack()
 	Jump()
 
 	StartBreaking(pos cube.Pos, face cube.Face)
@@ -14,12 +14,13 @@ import (
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/text"
 )
 
 // parseEntityMetadata returns an entity metadata object with default values. It is equivalent to setting
 // all properties to their default values and disabling all flags.
 func (s *Session) parseEntityMetadata(e world.Entity) protocol.EntityMetadata {
-	bb := e.H().Type().BBox(e)
+	bb := world.EntityBBox(e)
 	m := protocol.NewEntityMetadata()
 
 	m[protocol.EntityDataKeyWidth] = float32(bb.Width())
@@ -37,12 +38,49 @@ func (s *Session) parseEntityMetadata(e world.Entity) protocol.EntityMetadata {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagLingering)
 	}
 	s.addSpecificMetadata(e, m)
-	if ent, ok := e.(*entity.Ent); ok {
+	if ent, ok := e.(interface{ Behaviour() entity.Behaviour }); ok {
 		s.addSpecificMetadata(ent.Behaviour(), m)
 	}
+	if s.ent != nil && e.H() != s.ent && !s.teams().CollisionAllowed(e.H().UUID(), s.ent.UUID()) {
+		m.UnsetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagHasCollision)
+	}
 	return m
 }
 
+// teams returns the world.Teams of the world that the session is currently
+// in, or nil if it has none.
+func (s *Session) teams() *world.Teams {
+	// The world of the chunk loader can't be used here: Entities are shown
+	// while the loader is locked.
+	if w := s.viewedWorld.Load(); w != nil {
+		return w.Teams()
+	}
+	return nil
+}
+
+// teamNameTag returns the name tag of e as shown to the session according to
+// the Team that e is a member of.
+func (s *Session) teamNameTag(e any, nameTag string) string {
+	ent, ok := e.(world.Entity)
+	if !ok || s.ent == nil {
+		return nameTag
+	}
+	return s.teams().NameTag(ent.H().UUID(), nameTag, s.ent.UUID())
+}
+
+// glowNameTag returns the name tag of a glowing entity e as shown to the
+// session. Bedrock clients do not draw outlines around entities, so glowing
+// entities are highlighted by showing their name tag, which is visible through
+// walls, in their glow colour instead. The name tag is left empty if its Team
+// hides it from the session.
+func (s *Session) glowNameTag(e any, teamNameTag, nameTag string) string {
+	ent, ok := e.(world.Entity)
+	if !ok || teamNameTag == "" {
+		return teamNameTag
+	}
+	return s.teams().GlowColour(ent.H().UUID()) + nameTag + text.Reset
+}
+
 func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if sn, ok := e.(sneaker); ok && sn.Sneaking() {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagSneaking)
@@ -62,6 +100,12 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if bb, ok := e.(baby); ok && bb.Baby() {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagBaby)
 	}
+	if l, ok := e.(lover); ok && l.InLove() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagInLove)
+	}
+	if p, ok := e.(powered); ok && p.Powered() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagPowered)
+	}
 	if b, ok := e.(breather); ok {
 		m[protocol.EntityDataKeyAirSupply] = int16(b.AirSupply().Milliseconds() / 50)
 		m[protocol.EntityDataKeyAirSupplyMax] = int16(b.MaxAirSupply().Milliseconds() / 50)
@@ -78,6 +122,9 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if o, ok := e.(onFire); ok && o.OnFireDuration() > 0 {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagOnFire)
 	}
+	if f, ok := e.(freezing); ok {
+		m[protocol.EntityDataKeyFreezingEffectStrength] = float32(f.FreezingStrength())
+	}
 	if u, ok := e.(using); ok && u.UsingItem() {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagUsingItem)
 	}
@@ -85,7 +132,8 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagCritical)
 	}
 	if g, ok := e.(gameMode); ok {
-		if g.GameMode().HasCollision() {
+		n, noClip := e.(noClipper)
+		if g.GameMode().HasCollision() && (!noClip || !n.NoClip()) {
 			m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagHasCollision)
 		}
 	}
@@ -100,6 +148,15 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	} else if o, ok := e.(owned); ok && o.Owner() != nil {
 		m[protocol.EntityDataKeyOwner] = int64(s.handleRuntimeID(o.Owner()))
 	}
+	if h, ok := e.(hooking); ok && h.HookedEntity() != nil {
+		m[protocol.EntityDataKeyTarget] = int64(s.handleRuntimeID(h.HookedEntity()))
+	}
+	if l, ok := e.(leashed); ok {
+		m[protocol.EntityDataKeyLeashHolder] = int64(-1)
+		if h := l.LeashHolder(); h != nil {
+			m[protocol.EntityDataKeyLeashHolder] = int64(s.handleRuntimeID(h))
+		}
+	}
 	if sc, ok := e.(scaled); ok {
 		m[protocol.EntityDataKeyScale] = float32(sc.Scale())
 	}
@@ -108,12 +165,20 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagIgnited)
 	}
 	if n, ok := e.(named); ok {
-		name := n.NameTag()
+		name := s.teamNameTag(e, n.NameTag())
+		if g, ok := e.(glower); ok && g.Glowing() {
+			name = s.glowNameTag(e, name, n.NameTag())
+		}
 		m[protocol.EntityDataKeyName] = name
 		if name == "" {
 			m[protocol.EntityDataKeyAlwaysShowNameTag] = uint8(0)
 			m.UnsetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagAlwaysShowName)
 			m.UnsetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagShowName)
+		} else if sn, ok := e.(sneaker); ok && sn.Sneaking() {
+			// The name tag of a sneaking entity is only shown up close and not through blocks.
+			m[protocol.EntityDataKeyAlwaysShowNameTag] = uint8(0)
+			m.UnsetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagAlwaysShowName)
+			m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagShowName)
 		} else {
 			m[protocol.EntityDataKeyAlwaysShowNameTag] = uint8(1)
 			m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagAlwaysShowName)
@@ -123,6 +188,20 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if sc, ok := e.(scoreTag); ok {
 		m[protocol.EntityDataKeyScore] = sc.ScoreTag()
 	}
+	if r, ok := e.(rider); ok {
+		if _, riding := r.Riding(); riding {
+			m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagRiding)
+		}
+	}
+	if v, ok := e.(variant); ok {
+		m[protocol.EntityDataKeyVariant] = v.Variant()
+	}
+	if sa, ok := e.(saddled); ok && sa.Saddled() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagSaddled)
+	}
+	if t, ok := e.(tamed); ok && t.Tamed() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagTamed)
+	}
 	if sl, ok := e.(sleeper); ok {
 		if pos, ok := sl.Sleeping(); ok {
 			m[protocol.EntityDataKeyBedPosition] = protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
@@ -212,6 +291,14 @@ type baby interface {
 	Baby() bool
 }
 
+type lover interface {
+	InLove() bool
+}
+
+type powered interface {
+	Powered() bool
+}
+
 type breather interface {
 	Breathing() bool
 	AirSupply() time.Duration
@@ -230,14 +317,26 @@ type scaled interface {
 	Scale() float64
 }
 
+type leashed interface {
+	LeashHolder() *world.EntityHandle
+}
+
 type owned interface {
 	Owner() *world.EntityHandle
 }
 
+type hooking interface {
+	HookedEntity() *world.EntityHandle
+}
+
 type named interface {
 	NameTag() string
 }
 
+type glower interface {
+	Glowing() bool
+}
+
 type scoreTag interface {
 	ScoreTag() string
 }
@@ -259,6 +358,10 @@ type onFire interface {
 	OnFireDuration() time.Duration
 }
 
+type freezing interface {
+	FreezingStrength() float64
+}
+
 type effectBearer interface {
 	Effects() []effect.Effect
 }
@@ -284,10 +387,30 @@ type gameMode interface {
 	GameMode() world.GameMode
 }
 
+type noClipper interface {
+	NoClip() bool
+}
+
 type sleeper interface {
 	Sleeping() (cube.Pos, bool)
 }
 
+type rider interface {
+	Riding() (*world.EntityHandle, bool)
+}
+
+type variant interface {
+	Variant() int32
+}
+
+type saddled interface {
+	Saddled() bool
+}
+
+type tamed interface {
+	Tamed() bool
+}
+
 type tnt interface {
 	Fuse() time.Duration
 }
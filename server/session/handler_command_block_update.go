@@ -0,0 +1,47 @@
+package session
+
+import (
+	"fmt"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// CommandBlockUpdateHandler handles the CommandBlockUpdate packet, sent when a player edits a command block.
+type CommandBlockUpdateHandler struct{}
+
+// Handle ...
+func (CommandBlockUpdateHandler) Handle(p packet.Packet, _ *Session, tx *world.Tx, c Controllable) error {
+	pk := p.(*packet.CommandBlockUpdate)
+	if !pk.Block {
+		// Command block minecarts are not implemented.
+		return nil
+	}
+	pos := blockPosFromProtocol(pk.Position)
+	if !c.CanEditCommandBlock(pos) {
+		return fmt.Errorf("player may not edit the command block at %v", pos)
+	}
+	if !canReach(c, pos.Vec3Middle()) {
+		return fmt.Errorf("block at %v is not within reach", pos)
+	}
+	cb, ok := tx.Block(pos).(block.CommandBlock)
+	if !ok {
+		return fmt.Errorf("block at %v is not a command block", pos)
+	}
+	if pk.Mode >= uint32(len(block.CommandBlockModes())) {
+		return fmt.Errorf("invalid command block mode %v", pk.Mode)
+	}
+	cb.Mode = block.CommandBlockModes()[pk.Mode]
+	cb.Conditional = pk.Conditional
+	cb.Auto = !pk.NeedsRedstone
+	cb.Command = pk.Command
+	cb.CustomName = pk.Name
+	cb.TrackOutput = pk.ShouldTrackOutput
+	cb.TickDelay = int(pk.TickDelay)
+	if !cb.TrackOutput {
+		cb.LastOutput = ""
+	}
+	cb.Update(pos, tx)
+	return nil
+}
@@ -54,6 +54,9 @@ func (h *ItemStackRequestHandler) handleCraft(a *protocol.CraftRecipeStackReques
 			}
 			processed, consumed[slot-offset] = true, true
 			st := has.Grow(-expected.Count() * timesCrafted)
+			if s.conf.CraftingAutoRefill {
+				st = h.refillCraftingSlot(s, tx, st, has)
+			}
 			h.setItemInSlot(protocol.StackRequestSlotInfo{
 				Container: protocol.FullContainerName{ContainerID: protocol.ContainerCraftingInput},
 				Slot:      byte(slot),
@@ -67,6 +70,29 @@ func (h *ItemStackRequestHandler) handleCraft(a *protocol.CraftRecipeStackReques
 	return h.createResults(s, tx, repeatStacks(craft.Output(), timesCrafted)...)
 }
 
+// refillCraftingSlot refills a slot of the crafting grid that held the stack has before crafting and holds left
+// after crafting, using matching items from the inventory. The slot is refilled up to the count it held before
+// crafting, if possible. The new stack in the slot is returned.
+func (h *ItemStackRequestHandler) refillCraftingSlot(s *Session, tx *world.Tx, left, has item.Stack) item.Stack {
+	need := has.Count() - left.Count()
+	for slot, it := range s.inv.Slots() {
+		if need == 0 {
+			break
+		}
+		if it.Empty() || !it.Comparable(has) {
+			// Not the same item.
+			continue
+		}
+		n := min(need, it.Count())
+		need -= n
+		h.setItemInSlot(protocol.StackRequestSlotInfo{
+			Container: protocol.FullContainerName{ContainerID: protocol.ContainerCombinedHotBarAndInventory},
+			Slot:      byte(slot),
+		}, it.Grow(-n), s, tx)
+	}
+	return has.Grow(-need)
+}
+
 // handleAutoCraft handles the AutoCraftRecipe request action.
 func (h *ItemStackRequestHandler) handleAutoCraft(a *protocol.AutoCraftRecipeStackRequestAction, s *Session, tx *world.Tx) error {
 	craft, ok := s.recipes[a.RecipeNetworkID]
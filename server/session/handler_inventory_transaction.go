@@ -159,7 +159,7 @@ func (h *InventoryTransactionHandler) handleUseItemOnEntityTransaction(data *pro
 	var valid bool
 	switch data.ActionType {
 	case protocol.UseItemOnEntityActionInteract:
-		valid = c.UseItemOnEntity(e)
+		valid = c.InteractWithEntity(e, vec32To64(data.ClickedPosition))
 	case protocol.UseItemOnEntityActionAttack:
 		valid = c.AttackEntity(e)
 	default:
@@ -24,10 +24,25 @@ type ItemStackRequestHandler struct {
 
 	pendingResults []item.Stack
 
+	// original holds the items that slots changed by the current request held
+	// before the request. It is used to revert the request and to verify that
+	// items were not created out of nothing.
+	original map[slotKey]item.Stack
+	// removed holds the items that the current request removed from the
+	// inventories of the player, for example by dropping them.
+	removed []item.Stack
+
 	current       time.Time
 	ignoreDestroy bool
 }
 
+// slotKey identifies a slot in an inventory. Multiple container IDs may point
+// to the same inventory, so slots are identified by the inventory itself.
+type slotKey struct {
+	inv  *inventory.Inventory
+	slot int
+}
+
 // responseChange represents a change in a specific item stack response. It holds the timestamp of the
 // response which is used to get rid of changes that the client will have received.
 type responseChange struct {
@@ -35,11 +50,10 @@ type responseChange struct {
 	timestamp time.Time
 }
 
-// changeInfo holds information on a slot change initiated by an item stack request. It holds both the new and the old
-// item information and is used for reverting and verifying.
+// changeInfo holds information on a slot change initiated by an item stack request. It holds the new item
+// information sent to the client in the response.
 type changeInfo struct {
-	after  protocol.StackResponseSlotInfo
-	before item.Stack
+	after protocol.StackResponseSlotInfo
 }
 
 // Handle ...
@@ -72,7 +86,16 @@ func (h *ItemStackRequestHandler) handleRequest(req protocol.ItemStackRequest, s
 		h.ignoreDestroy = false
 	}()
 
+	// Requests that craft items or take them from the creative inventory create
+	// and consume items, so only other requests must conserve them.
+	conserves := !s.conf.DisableInventoryValidation
 	for _, action := range req.Actions {
+		switch action.(type) {
+		case *protocol.TakeStackRequestAction, *protocol.PlaceStackRequestAction, *protocol.SwapStackRequestAction,
+			*protocol.DestroyStackRequestAction, *protocol.DropStackRequestAction, *protocol.MineBlockStackRequestAction:
+		default:
+			conserves = false
+		}
 		switch a := action.(type) {
 		case *protocol.TakeStackRequestAction:
 			err = h.handleTake(a, s, tx, c)
@@ -127,9 +150,51 @@ func (h *ItemStackRequestHandler) handleRequest(req protocol.ItemStackRequest, s
 			return
 		}
 	}
+	if conserves {
+		err = h.verifyConservation()
+	}
 	return
 }
 
+// verifyConservation verifies that the items in all slots changed by the
+// current request, together with the items removed by it, are the same as the
+// items in these slots before the request. An error is returned if the
+// request created or destroyed items, such as when a client attempts to
+// duplicate items.
+func (h *ItemStackRequestHandler) verifyConservation() error {
+	type itemCount struct {
+		it item.Stack
+		n  int
+	}
+	var counts []itemCount
+	add := func(it item.Stack, n int) {
+		if it.Empty() {
+			return
+		}
+		for i, c := range counts {
+			if c.it.Comparable(it) {
+				counts[i].n += n
+				return
+			}
+		}
+		counts = append(counts, itemCount{it: it, n: n})
+	}
+	for key, before := range h.original {
+		after, _ := key.inv.Item(key.slot)
+		add(before, before.Count())
+		add(after, -after.Count())
+	}
+	for _, it := range h.removed {
+		add(it, -it.Count())
+	}
+	for _, c := range counts {
+		if c.n != 0 {
+			return fmt.Errorf("request changed the count of %v by %v without crafting", c.it.Item(), -c.n)
+		}
+	}
+	return nil
+}
+
 // handleTake handles a Take stack request action.
 func (h *ItemStackRequestHandler) handleTake(a *protocol.TakeStackRequestAction, s *Session, tx *world.Tx, c Controllable) error {
 	return h.handleTransfer(a.Source, a.Destination, a.Count, s, tx, c)
@@ -165,6 +230,9 @@ func (h *ItemStackRequestHandler) handleTransfer(from, to protocol.StackRequestS
 
 	ctx := event.C(inventory.Holder(c))
 	_ = call(ctx, int(from.Slot), i.Grow(int(count)-i.Count()), invA.Handler().HandleTake)
+	if s.readOnly(invA) || s.readOnly(invB) {
+		return fmt.Errorf("client tried transferring %v in a read-only container", i)
+	}
 	err := call(ctx, int(to.Slot), i.Grow(int(count)-i.Count()), invB.Handler().HandlePlace)
 	if err != nil {
 		return err
@@ -189,6 +257,9 @@ func (h *ItemStackRequestHandler) handleSwap(a *protocol.SwapStackRequestAction,
 
 	ctx := event.C(inventory.Holder(c))
 	_ = call(ctx, int(a.Source.Slot), i, invA.Handler().HandleTake)
+	if s.readOnly(invA) || s.readOnly(invB) {
+		return fmt.Errorf("client tried swapping %v and %v in a read-only container", i, dest)
+	}
 	_ = call(ctx, int(a.Source.Slot), dest, invA.Handler().HandlePlace)
 	_ = call(ctx, int(a.Destination.Slot), dest, invB.Handler().HandleTake)
 	err := call(ctx, int(a.Destination.Slot), i, invB.Handler().HandlePlace)
@@ -230,8 +301,12 @@ func (h *ItemStackRequestHandler) handleDestroy(a *protocol.DestroyStackRequestA
 	if i.Count() < int(a.Count) {
 		return fmt.Errorf("client attempted to destroy %v items, but only %v present", a.Count, i.Count())
 	}
+	if inv, _ := s.invByID(int32(a.Source.Container.ContainerID), tx); s.readOnly(inv) {
+		return fmt.Errorf("client tried destroying %v in a read-only container", i)
+	}
 
 	h.setItemInSlot(a.Source, i.Grow(-int(a.Count)), s, tx)
+	h.removed = append(h.removed, i.Grow(int(a.Count)-i.Count()))
 	return nil
 }
 
@@ -250,9 +325,13 @@ func (h *ItemStackRequestHandler) handleDrop(a *protocol.DropStackRequestAction,
 	if err := call(event.C(inventory.Holder(c)), int(a.Source.Slot), i.Grow(int(a.Count)-i.Count()), inv.Handler().HandleDrop); err != nil {
 		return err
 	}
+	if s.readOnly(inv) {
+		return fmt.Errorf("client tried dropping %v from a read-only container", i)
+	}
 
 	n := c.Drop(i.Grow(int(a.Count) - i.Count()))
 	h.setItemInSlot(a.Source, i.Grow(-n), s, tx)
+	h.removed = append(h.removed, i.Grow(n-i.Count()))
 	return nil
 }
 
@@ -422,7 +501,13 @@ func (h *ItemStackRequestHandler) setItemInSlot(slot protocol.StackRequestSlotIn
 		sl = 0
 	}
 
-	before, _ := inv.Item(sl)
+	if h.original == nil {
+		h.original = map[slotKey]item.Stack{}
+	}
+	key := slotKey{inv: inv, slot: sl}
+	if _, ok := h.original[key]; !ok {
+		h.original[key], _ = inv.Item(sl)
+	}
 	_ = inv.SetItem(sl, i)
 
 	respSlot := protocol.StackResponseSlotInfo{
@@ -436,10 +521,7 @@ func (h *ItemStackRequestHandler) setItemInSlot(slot protocol.StackRequestSlotIn
 	if h.changes[slot.Container.ContainerID] == nil {
 		h.changes[slot.Container.ContainerID] = map[byte]changeInfo{}
 	}
-	h.changes[slot.Container.ContainerID][slot.Slot] = changeInfo{
-		after:  respSlot,
-		before: before,
-	}
+	h.changes[slot.Container.ContainerID][slot.Slot] = changeInfo{after: respSlot}
 
 	if h.responseChanges[h.currentRequest] == nil {
 		h.responseChanges[h.currentRequest] = map[*inventory.Inventory]map[byte]responseChange{}
@@ -473,7 +555,7 @@ func (h *ItemStackRequestHandler) resolve(id int32, s *Session) {
 	}}})
 
 	h.changes = map[byte]map[byte]changeInfo{}
-	h.pendingResults = nil
+	h.pendingResults, h.original, h.removed = nil, nil, nil
 }
 
 // reject rejects the item stack request sent by the client so that it is reverted client-side.
@@ -486,15 +568,12 @@ func (h *ItemStackRequestHandler) reject(id int32, s *Session, tx *world.Tx) {
 	})
 
 	// Revert changes that we already made for valid actions.
-	for container, slots := range h.changes {
-		for slot, info := range slots {
-			inv, _ := s.invByID(int32(container), tx)
-			_ = inv.SetItem(int(slot), info.before)
-		}
+	for key, before := range h.original {
+		_ = key.inv.SetItem(key.slot, before)
 	}
 
 	h.changes = map[byte]map[byte]changeInfo{}
-	h.pendingResults = nil
+	h.pendingResults, h.original, h.removed = nil, nil, nil
 }
 
 // call uses an event.Context, slot and item.Stack to call the event handler function passed. An error is returned if
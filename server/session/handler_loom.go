@@ -3,7 +3,6 @@ package session
 import (
 	"fmt"
 	"github.com/df-mc/dragonfly/server/block"
-	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 )
@@ -28,7 +27,7 @@ func (h *ItemStackRequestHandler) handleLoomCraft(a *protocol.CraftLoomRecipeSta
 		return fmt.Errorf("times crafted must be least 1")
 	}
 
-	// Next, check if the input slot has a valid banner item.
+	// Next, check if the input slots hold enough items.
 	input, _ := h.itemInSlot(protocol.StackRequestSlotInfo{
 		Container: protocol.FullContainerName{ContainerID: protocol.ContainerLoomInput},
 		Slot:      loomInputSlot,
@@ -36,15 +35,6 @@ func (h *ItemStackRequestHandler) handleLoomCraft(a *protocol.CraftLoomRecipeSta
 	if input.Count() < timesCrafted {
 		return fmt.Errorf("input item count is less than times crafted")
 	}
-	b, ok := input.Item().(block.Banner)
-	if !ok {
-		return fmt.Errorf("input item is not a banner")
-	}
-	if b.Illager {
-		return fmt.Errorf("input item is an illager banner")
-	}
-
-	// Do the same with the input dye.
 	dye, _ := h.itemInSlot(protocol.StackRequestSlotInfo{
 		Container: protocol.FullContainerName{ContainerID: protocol.ContainerLoomDye},
 		Slot:      loomDyeSlot,
@@ -52,42 +42,23 @@ func (h *ItemStackRequestHandler) handleLoomCraft(a *protocol.CraftLoomRecipeSta
 	if dye.Count() < timesCrafted {
 		return fmt.Errorf("dye item count is less than times crafted")
 	}
-	d, ok := dye.Item().(item.Dye)
-	if !ok {
-		return fmt.Errorf("dye item is not a dye")
-	}
 
 	// The action contains the pattern that the client wanted to apply, so parse the ID and check if it is a valid
 	// pattern.
-	expectedPattern, exists := block.BannerPatternByID(a.Pattern)
+	pattern, exists := block.BannerPatternByID(a.Pattern)
 	if !exists {
 		return fmt.Errorf("unknown banner pattern id %q", a.Pattern)
 	}
-
-	// Some banner patterns have equivalent banner pattern items that are required to craft the pattern. If the expected
-	// pattern has a pattern item, check if the player input the correct pattern item.
-	pattern, _ := h.itemInSlot(protocol.StackRequestSlotInfo{
+	patternItem, _ := h.itemInSlot(protocol.StackRequestSlotInfo{
 		Container: protocol.FullContainerName{ContainerID: protocol.ContainerLoomMaterial},
 		Slot:      loomPatternSlot,
 	}, s, tx)
-	if expectedPatternItem, hasPatternItem := expectedPattern.Item(); hasPatternItem {
-		if pattern.Empty() {
-			return fmt.Errorf("pattern item is empty but the pattern is required")
-		}
-		p, ok := pattern.Item().(item.BannerPattern)
-		if !ok {
-			return fmt.Errorf("pattern item is not a banner pattern")
-		}
-		if expectedPatternItem != p.Type {
-			return fmt.Errorf("pattern item does not match the expected pattern")
-		}
+	result, ok := block.LoomResult(input, dye, patternItem, pattern)
+	if !ok {
+		return fmt.Errorf("banner, dye and pattern item cannot be used to apply pattern %q", a.Pattern)
 	}
 
-	// Add a new pattern layer onto the banner, and create the result.
-	b.Patterns = append(b.Patterns, block.BannerPatternLayer{
-		Type:   expectedPattern,
-		Colour: d.Colour,
-	})
+	// Consume the banner and dye, and create the result.
 	h.setItemInSlot(protocol.StackRequestSlotInfo{
 		Container: protocol.FullContainerName{ContainerID: protocol.ContainerLoomInput},
 		Slot:      loomInputSlot,
@@ -96,5 +67,5 @@ func (h *ItemStackRequestHandler) handleLoomCraft(a *protocol.CraftLoomRecipeSta
 		Container: protocol.FullContainerName{ContainerID: protocol.ContainerLoomDye},
 		Slot:      loomDyeSlot,
 	}, dye.Grow(-timesCrafted), s, tx)
-	return h.createResults(s, tx, input.WithItem(b))
+	return h.createResults(s, tx, result.Grow(timesCrafted-1))
 }
@@ -0,0 +1,20 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// MapInfoRequestHandler handles the MapInfoRequest packet, sent by the client when it first shows a map.
+type MapInfoRequestHandler struct{}
+
+// Handle ...
+func (MapInfoRequestHandler) Handle(p packet.Packet, s *Session, tx *world.Tx, _ Controllable) error {
+	pk := p.(*packet.MapInfoRequest)
+	// Filled map items may refer to maps that no longer exist, for example
+	// after the server restarted, so unknown maps are not an error.
+	if m, ok := tx.World().Map(pk.MapID); ok {
+		s.sendMap(m)
+	}
+	return nil
+}
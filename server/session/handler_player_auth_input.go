@@ -5,6 +5,7 @@ import (
 	"math"
 
 	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl32"
 	"github.com/go-gl/mathgl/mgl64"
@@ -61,8 +62,20 @@ func (h PlayerAuthInputHandler) handleMovement(pk *packet.PlayerAuthInput, s *Se
 		}
 	}
 
+	if _, riding := c.Riding(); riding {
+		// The position of riders follows the entity they ride, so only the rotation of the player is updated
+		// and its movement input is passed on to the entity instead.
+		deltaPos = mgl64.Vec3{}
+		c.SteerVehicle(entity.RideInput{
+			Forward:  float64(pk.MoveVector[1]),
+			Strafe:   float64(pk.MoveVector[0]),
+			Jumping:  pk.InputData.Load(packet.InputFlagJumpDown),
+			Sneaking: pk.InputData.Load(packet.InputFlagSneakDown),
+		})
+	}
+
 	s.moving = true
-	c.Move(deltaPos, deltaYaw, deltaPitch)
+	c.ClientMove(deltaPos, deltaYaw, deltaPitch)
 	return nil
 }
 
@@ -122,6 +135,12 @@ func (h PlayerAuthInputHandler) handleInputFlags(flags protocol.Bitset, s *Sessi
 	if flags.Load(packet.InputFlagStopGliding) {
 		c.StopGliding()
 	}
+	if flags.Load(packet.InputFlagStartSpinAttack) {
+		c.StartSpinAttack()
+	}
+	if flags.Load(packet.InputFlagStopSpinAttack) {
+		c.StopSpinAttack()
+	}
 	if flags.Load(packet.InputFlagStartJumping) {
 		c.Jump()
 	}
@@ -137,7 +156,7 @@ func (h PlayerAuthInputHandler) handleInputFlags(flags protocol.Bitset, s *Sessi
 		c.PunchAir()
 	}
 	if flags.Load(packet.InputFlagStartFlying) {
-		if !c.GameMode().AllowsFlying() {
+		if !c.CanFly() {
 			s.conf.Log.Debug("process packet: PlayerAuthInput: flying flag enabled while unable to fly")
 			s.SendAbilities(c)
 		} else {
@@ -10,13 +10,20 @@ import (
 type PlayerSkinHandler struct{}
 
 // Handle ...
-func (PlayerSkinHandler) Handle(p packet.Packet, _ *Session, _ *world.Tx, c Controllable) error {
+func (PlayerSkinHandler) Handle(p packet.Packet, s *Session, _ *world.Tx, c Controllable) error {
 	pk := p.(*packet.PlayerSkin)
 
 	playerSkin, err := protocolToSkin(pk.Skin)
 	if err != nil {
 		return fmt.Errorf("error decoding skin: %w", err)
 	}
+	if err := playerSkin.Validate(); err != nil {
+		// The client already shows the new skin to itself, so the current skin is sent again to undo that. A
+		// malformed skin isn't worth disconnecting the client for.
+		s.conf.Log.Debug("process packet: PlayerSkin: " + err.Error())
+		s.ViewSkin(c)
+		return nil
+	}
 
 	c.SetSkin(playerSkin)
 
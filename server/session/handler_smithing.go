@@ -2,7 +2,7 @@ package session
 
 import (
 	"fmt"
-	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/item/recipe"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
@@ -33,31 +33,25 @@ func (h *ItemStackRequestHandler) handleSmithing(a *protocol.CraftRecipeStackReq
 		return fmt.Errorf("recipe with network id %v is not a smithing recipe", a.RecipeNetworkID)
 	}
 
-	// Check if the input item and material item match what the recipe requires.
-	expectedInputs := craft.Input()
+	// Check if the input item, material and template match what the recipe requires.
 	input, _ := h.itemInSlot(protocol.StackRequestSlotInfo{
 		Container: protocol.FullContainerName{ContainerID: protocol.ContainerSmithingTableInput},
 		Slot:      smithingInputSlot,
 	}, s, tx)
-	if !matchingStacks(input, expectedInputs[0]) {
-		return fmt.Errorf("input item is not the same as expected input")
-	}
 	material, _ := h.itemInSlot(protocol.StackRequestSlotInfo{
 		Container: protocol.FullContainerName{ContainerID: protocol.ContainerSmithingTableMaterial},
 		Slot:      smithingMaterialSlot,
 	}, s, tx)
-	if !matchingStacks(material, expectedInputs[1]) {
-		return fmt.Errorf("material item is not the same as expected material")
-	}
 	template, _ := h.itemInSlot(protocol.StackRequestSlotInfo{
 		Container: protocol.FullContainerName{ContainerID: protocol.ContainerSmithingTableTemplate},
 		Slot:      smithingTemplateSlot,
 	}, s, tx)
-	if !matchingStacks(template, expectedInputs[2]) {
-		return fmt.Errorf("template item is not the same as expected template")
+	result, ok := block.SmithingResult(craft, input, material, template)
+	if !ok {
+		return fmt.Errorf("input, material and template do not match recipe with network id %v", a.RecipeNetworkID)
 	}
 
-	// Create the output using the input stack as reference and the recipe's output item type.
+	// Consume the inputs and create the output.
 	h.setItemInSlot(protocol.StackRequestSlotInfo{
 		Container: protocol.FullContainerName{ContainerID: protocol.ContainerSmithingTableInput},
 		Slot:      smithingInputSlot,
@@ -71,21 +65,5 @@ func (h *ItemStackRequestHandler) handleSmithing(a *protocol.CraftRecipeStackReq
 		Slot:      smithingTemplateSlot,
 	}, template.Grow(-1), s, tx)
 
-	if _, ok = craft.(recipe.SmithingTrim); ok {
-		var trim item.ArmourTrim
-		if t, ok := template.Item().(item.SmithingTemplate); ok {
-			trim.Template = t.Template
-		} else {
-			return fmt.Errorf("template item is not a smithing template")
-		}
-		if trim.Material, ok = material.Item().(item.ArmourTrimMaterial); !ok {
-			return fmt.Errorf("material item is not an armour trim material")
-		}
-		trimmable, ok := input.Item().(item.Trimmable)
-		if !ok {
-			return fmt.Errorf("input item is not trimmable")
-		}
-		return h.createResults(s, tx, input.WithItem(trimmable.WithTrim(trim)))
-	}
-	return h.createResults(s, tx, input.WithItem(craft.Output()[0].Item()))
+	return h.createResults(s, tx, result)
 }
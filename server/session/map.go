@@ -0,0 +1,101 @@
+package session
+
+import (
+	"image/color"
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// mapView holds the state of a world.Map as it was last sent to the client.
+type mapView struct {
+	version uint64
+	markers []world.MapMarker
+}
+
+// ViewMap sends the pixels and markers of the world.Map passed that changed
+// since they were last sent to the client. Nothing is sent if the client did
+// not yet request the map.
+func (s *Session) ViewMap(m *world.Map, markers []world.MapMarker) {
+	s.mapMu.Lock()
+	defer s.mapMu.Unlock()
+	view, ok := s.maps[m.ID()]
+	if !ok {
+		return
+	}
+	pk := s.mapPacket(m)
+	if m.Version() != view.version {
+		var update world.MapUpdate
+		update, view.version = m.Changes(view.version)
+		mapTexture(pk, update)
+	}
+	if !slices.Equal(markers, view.markers) {
+		view.markers = markers
+		mapDecorations(pk, markers)
+	}
+	if pk.UpdateFlags != 0 {
+		s.writePacket(pk)
+	}
+}
+
+// sendMap sends all pixels of the world.Map passed to the client after the
+// client requested it. From then on, changes to the map are sent using
+// ViewMap.
+func (s *Session) sendMap(m *world.Map) {
+	s.mapMu.Lock()
+	defer s.mapMu.Unlock()
+	if s.maps == nil {
+		s.maps = make(map[int64]*mapView)
+	}
+	update, version := m.Changes(0)
+	s.maps[m.ID()] = &mapView{version: version}
+
+	pk := s.mapPacket(m)
+	pk.UpdateFlags |= packet.MapUpdateFlagInitialisation
+	pk.MapsIncludedIn = []int64{m.ID()}
+	mapTexture(pk, update)
+	s.writePacket(pk)
+}
+
+// mapPacket returns a ClientBoundMapItemData packet for the world.Map passed
+// without any update flags set.
+func (s *Session) mapPacket(m *world.Map) *packet.ClientBoundMapItemData {
+	centre := m.Centre()
+	return &packet.ClientBoundMapItemData{
+		MapID:     m.ID(),
+		Dimension: byte(s.dimensionID(m.Dimension())),
+		LockedMap: m.Locked(),
+		Origin:    protocol.BlockPos{int32(centre[0]), int32(centre[1]), int32(centre[2])},
+		Scale:     byte(m.Scale()),
+	}
+}
+
+// mapTexture adds the pixels of the world.MapUpdate passed to the packet, if
+// any pixels changed.
+func mapTexture(pk *packet.ClientBoundMapItemData, update world.MapUpdate) {
+	if update.Area.Empty() {
+		return
+	}
+	pk.UpdateFlags |= packet.MapUpdateFlagTexture
+	pk.XOffset, pk.YOffset = int32(update.Area.Min.X), int32(update.Area.Min.Y)
+	pk.Width, pk.Height = int32(update.Area.Dx()), int32(update.Area.Dy())
+	pk.Pixels = update.Pixels
+}
+
+// mapDecorations adds the world.MapMarkers passed to the packet as
+// decorations.
+func mapDecorations(pk *packet.ClientBoundMapItemData, markers []world.MapMarker) {
+	pk.UpdateFlags |= packet.MapUpdateFlagDecoration
+	pk.Decorations = make([]protocol.MapDecoration, 0, len(markers))
+	for _, marker := range markers {
+		pk.Decorations = append(pk.Decorations, protocol.MapDecoration{
+			Type:     byte(marker.Type),
+			Rotation: marker.Rotation,
+			X:        byte(marker.X),
+			Y:        byte(marker.Y),
+			Colour:   color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		})
+	}
+}
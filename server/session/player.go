@@ -1,6 +1,7 @@
 package session
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"image/color"
@@ -12,6 +13,7 @@ import (
 	_ "unsafe" // Imported for compiler directives.
 
 	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
@@ -29,7 +31,6 @@ import (
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl32"
 	"github.com/go-gl/mathgl/mgl64"
-	"github.com/google/uuid"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 )
@@ -66,13 +67,41 @@ func (s *Session) StartShowingEntity(e world.Entity) {
 	}
 }
 
+// CloseContainer closes the container that the player currently has opened, if any.
+func (s *Session) CloseContainer(tx *world.Tx) {
+	s.closeCurrentContainer(tx, false)
+}
+
 // closeCurrentContainer closes the container the player might currently have open.
 func (s *Session) closeCurrentContainer(tx *world.Tx, clientRequested bool) {
 	if !s.closeWindow(clientRequested) {
 		return
 	}
-
+	w := s.openedWorld.Swap(nil)
+	if c := s.openedVirtual.Swap(nil); c != nil {
+		c.Inventory.RemoveViewer(s)
+		if w == tx.World() {
+			// Revert the fake blocks shown for the virtual container.
+			for _, pos := range c.positions() {
+				s.ViewBlockUpdate(pos, tx.Block(pos), 0)
+			}
+		}
+		return
+	}
 	pos := *s.openedPos.Load()
+	if w != nil && w != tx.World() {
+		// The container was opened in a world the controllable has since
+		// left, so the session is removed as viewer in a transaction of that
+		// world. Waiting for it here could block both worlds.
+		w.Do(func(tx *world.Tx) { s.removeContainerViewer(tx, pos) })
+		return
+	}
+	s.removeContainerViewer(tx, pos)
+}
+
+// removeContainerViewer removes the session as viewer of the container block
+// at the position passed.
+func (s *Session) removeContainerViewer(tx *world.Tx, pos cube.Pos) {
 	b := tx.Block(pos)
 	if container, ok := b.(block.Container); ok {
 		container.RemoveViewer(s, tx, pos)
@@ -124,7 +153,7 @@ func (s *Session) sendRecipes() {
 		switch i := i.(type) {
 		case recipe.Shapeless:
 			recipes = append(recipes, &protocol.ShapelessRecipe{
-				RecipeID:        uuid.New().String(),
+				RecipeID:        recipe.Name(i),
 				Priority:        int32(i.Priority()),
 				Input:           stacksToIngredientItems(s.br, i.Input()),
 				Output:          stacksToRecipeStacks(s.br, i.Output()),
@@ -133,7 +162,7 @@ func (s *Session) sendRecipes() {
 			})
 		case recipe.Shaped:
 			recipes = append(recipes, &protocol.ShapedRecipe{
-				RecipeID:        uuid.New().String(),
+				RecipeID:        recipe.Name(i),
 				Priority:        int32(i.Priority()),
 				Width:           int32(i.Shape().Width()),
 				Height:          int32(i.Shape().Height()),
@@ -145,7 +174,7 @@ func (s *Session) sendRecipes() {
 		case recipe.SmithingTransform:
 			input, output := stacksToIngredientItems(s.br, i.Input()), stacksToRecipeStacks(s.br, i.Output())
 			recipes = append(recipes, &protocol.SmithingTransformRecipe{
-				RecipeID:        uuid.New().String(),
+				RecipeID:        recipe.Name(i),
 				Base:            input[0],
 				Addition:        input[1],
 				Template:        input[2],
@@ -156,7 +185,7 @@ func (s *Session) sendRecipes() {
 		case recipe.SmithingTrim:
 			input := stacksToIngredientItems(s.br, i.Input())
 			recipes = append(recipes, &protocol.SmithingTrimRecipe{
-				RecipeID:        uuid.New().String(),
+				RecipeID:        recipe.Name(i),
 				Base:            input[0],
 				Addition:        input[1],
 				Template:        input[2],
@@ -277,6 +306,11 @@ func (s *Session) invByID(id int32, tx *world.Tx) (*inventory.Inventory, bool) {
 		if !s.containerOpened.Load() {
 			return nil, false
 		}
+		if s.openedVirtual.Load() != nil {
+			// Virtual containers are not backed by a block, so only the inventory of the window itself may
+			// be accessed.
+			return s.openedWindow.Load(), id == protocol.ContainerLevelEntity
+		}
 		switch id {
 		case protocol.ContainerLevelEntity:
 			return s.openedWindow.Load(), true
@@ -312,6 +346,10 @@ func (s *Session) invByID(id int32, tx *world.Tx) (*inventory.Inventory, bool) {
 			if _, ok := tx.Block(*s.openedPos.Load()).(block.Stonecutter); ok {
 				return s.ui, true
 			}
+		case protocol.ContainerCartographyInput, protocol.ContainerCartographyAdditional:
+			if _, ok := tx.Block(*s.openedPos.Load()).(block.CartographyTable); ok {
+				return s.ui, true
+			}
 		case protocol.ContainerGrindstoneInput, protocol.ContainerGrindstoneAdditional:
 			if _, ok := tx.Block(*s.openedPos.Load()).(block.Grindstone); ok {
 				return s.ui, true
@@ -489,12 +527,15 @@ func (s *Session) SendGameMode(c Controllable) {
 // SendAbilities sends the abilities of the Controllable entity of the session to the client.
 func (s *Session) SendAbilities(c Controllable) {
 	mode, abilities := c.GameMode(), uint32(0)
-	if mode.AllowsFlying() {
+	if c.CanFly() {
 		abilities |= protocol.AbilityMayFly
 		if c.Flying() {
 			abilities |= protocol.AbilityFlying
 		}
 	}
+	if c.NoClip() {
+		abilities |= protocol.AbilityNoClip
+	}
 	if !mode.HasCollision() {
 		abilities |= protocol.AbilityNoClip
 		defer c.StartFlying()
@@ -502,7 +543,7 @@ func (s *Session) SendAbilities(c Controllable) {
 		// flight. In order to allow this, we force the client to be flying through a MovePlayer packet.
 		s.ViewEntityTeleport(c, c.Position())
 	}
-	if !mode.AllowsTakingDamage() {
+	if !mode.AllowsTakingDamage() || c.Invulnerable() {
 		abilities |= protocol.AbilityInvulnerable
 	}
 	if mode.CreativeInventory() {
@@ -604,6 +645,19 @@ func (s *Session) EnableInstantRespawn(enable bool) {
 	s.sendGameRules([]protocol.GameRule{{Name: "doimmediaterespawn", Value: enable}})
 }
 
+// SendRecipesUnlocked sends the names of recipes newly unlocked by the
+// Controllable to the client, so that they show up in its recipe book.
+func (s *Session) SendRecipesUnlocked(names []string) {
+	s.writePacket(&packet.UnlockedRecipes{UnlockType: packet.UnlockedRecipesTypeNewlyUnlocked, Recipes: names})
+}
+
+// SendRecipesLocked sends the names of recipes that are no longer unlocked by
+// the Controllable to the client, so that they are removed from its recipe
+// book.
+func (s *Session) SendRecipesLocked(names []string) {
+	s.writePacket(&packet.UnlockedRecipes{UnlockType: packet.UnlockedRecipesTypeRemoveUnlocked, Recipes: names})
+}
+
 // HandleInventories starts handling the inventories of the Controllable entity of the session. It sends packets when
 // slots in the inventory are changed.
 func (s *Session) HandleInventories(tx *world.Tx, c Controllable, inv, offHand, enderChest, ui *inventory.Inventory, armour *inventory.Armour, heldSlot *uint32) {
@@ -694,7 +748,7 @@ func (s *Session) uiInventoryFunc(tx *world.Tx, c Controllable) inventory.SlotFu
 
 // SendHeldSlot sends the currently held hotbar slot.
 func (s *Session) SendHeldSlot(slot int, c Controllable, force bool) {
-	if s.changingSlot.Load() && !force {
+	if s == Nop || (s.changingSlot.Load() && !force) {
 		return
 	}
 	mainHand, _ := c.HeldItems()
@@ -1089,6 +1143,11 @@ func creativeContent(br world.BlockRegistry) ([]protocol.CreativeGroup, []protoc
 			GroupIndex:            uint32(group),
 		})
 	}
+	// Items registered later, such as custom items, may be part of a group that was registered earlier. The
+	// items are sorted by their group so that the order of the groups remains stable.
+	slices.SortStableFunc(it, func(a, b protocol.CreativeItem) int {
+		return cmp.Compare(a.GroupIndex, b.GroupIndex)
+	})
 	return groups, it
 }
 
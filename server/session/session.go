@@ -77,13 +77,21 @@ type Session struct {
 	openedContainerID              atomic.Uint32
 	openedWindow                   atomic.Pointer[inventory.Inventory]
 	openedPos                      atomic.Pointer[cube.Pos]
+	openedVirtual                  atomic.Pointer[VirtualContainer]
+	openedWorld                    atomic.Pointer[world.World]
 	swingingArm                    atomic.Bool
 	changingSlot                   atomic.Bool
 	changingDimension              atomic.Bool
+	reloadChunks                   atomic.Bool
 	moving                         bool
 
 	lastChunkPos world.ChunkPos
 
+	// viewedWorld is the world that chunks and entities are currently shown
+	// from. Unlike the world of chunkLoader, it may be read while the loader
+	// is showing entities to the session.
+	viewedWorld atomic.Pointer[world.World]
+
 	recipes map[uint32]recipe.Recipe
 
 	blobMu                sync.Mutex
@@ -95,6 +103,11 @@ type Session struct {
 	hudUpdates map[hud.Element]bool
 	hiddenHud  map[hud.Element]struct{}
 
+	// maps holds the state of the maps that the client requested, indexed by
+	// their ID.
+	mapMu sync.Mutex
+	maps  map[int64]*mapView
+
 	debugShapesMu     sync.RWMutex
 	debugShapes       map[int]debug.Shape
 	debugShapeUpdates []debugShapeUpdate
@@ -160,10 +173,23 @@ type Config struct {
 	Log *slog.Logger
 
 	MaxChunkRadius int
+	// ChunksPerTick is the maximum number of chunks sent to the client every
+	// tick. If 0 or lower, up to 4 chunks are sent every tick.
+	ChunksPerTick int
 
 	EmoteChatMuted bool
 
-	JoinMessage, QuitMessage chat.Translation
+	// RecipeUnlocking specifies if the recipe book of the client only shows
+	// the recipes unlocked by the Controllable.
+	RecipeUnlocking bool
+	// CraftingAutoRefill specifies if the crafting grid is refilled from the
+	// inventory of the Controllable after crafting.
+	CraftingAutoRefill bool
+	// DisableInventoryValidation disables verifying that inventory actions of
+	// the client that do not craft items conserve the items moved around.
+	// Actions that would create or destroy items are rejected and reverted
+	// client-side unless validation is disabled.
+	DisableInventoryValidation bool
 
 	// HandleStop is called once when the Session is closed. The transaction is
 	// nil if the Controllable could not be restored to any world, such as when
@@ -261,6 +287,7 @@ func (s *Session) Spawn(c Controllable, tx *world.Tx) {
 	s.SendFood(c.Food(), 0, 0)
 
 	pos := c.Position()
+	s.viewedWorld.Store(tx.World())
 	s.chunkLoader = world.NewLoader(int(s.chunkRadius), tx.World(), s)
 	s.chunkLoader.Move(tx, pos)
 	s.writePacket(&packet.NetworkChunkPublisherUpdate{
@@ -281,11 +308,12 @@ func (s *Session) Spawn(c Controllable, tx *world.Tx) {
 	s.sendInv(s.offHand, protocol.WindowIDOffHand)
 	s.sendInv(s.armour.Inventory(), protocol.WindowIDArmour)
 
-	chat.Global.Subscribe(c)
-	if !s.conf.JoinMessage.Zero() {
-		chat.Global.Writet(s.conf.JoinMessage, s.conn.IdentityData().DisplayName)
+	if s.conf.RecipeUnlocking {
+		s.writePacket(&packet.UnlockedRecipes{UnlockType: packet.UnlockedRecipesTypeInitiallyUnlocked, Recipes: c.UnlockedRecipes()})
 	}
 
+	chat.Global.Subscribe(c)
+
 	go s.background()
 	go s.handlePackets()
 }
@@ -323,9 +351,6 @@ func (s *Session) close(tx *world.Tx, c Controllable) {
 		s.chunkLoader.Close(tx)
 	}
 
-	if !s.conf.QuitMessage.Zero() {
-		chat.Global.Writet(s.conf.QuitMessage, s.conn.IdentityData().DisplayName)
-	}
 	chat.Global.Unsubscribe(c)
 
 	// Note: Be aware of where RemoveEntity is called. This must not be done too
@@ -478,7 +503,7 @@ func (s *Session) background() {
 	}
 }
 
-// sendChunks sends the next up to 4 chunks to the connection. What chunks are loaded depends on the connection of
+// sendChunks sends the next up to Config.ChunksPerTick chunks to the connection. What chunks are loaded depends on the connection of
 // the chunk loader and the chunks that were previously loaded.
 func (s *Session) sendChunks(tx *world.Tx, c Controllable) {
 	var worldSwitched bool
@@ -488,8 +513,14 @@ func (s *Session) sendChunks(tx *world.Tx, c Controllable) {
 	}
 	pos := c.Position()
 	s.chunkLoader.Move(tx, pos)
+	reload := s.reloadChunks.Swap(false)
+	if reload && !worldSwitched {
+		// Switching worlds already resets the chunks loaded, so only reload
+		// them if the player stayed in the same world.
+		s.chunkLoader.Reload(tx)
+	}
 	chunkPos := world.ChunkPos{int32(pos[0]) << 4, int32(pos[2]) << 4}
-	if s.lastChunkPos != chunkPos || worldSwitched {
+	if s.lastChunkPos != chunkPos || worldSwitched || reload {
 		s.lastChunkPos = chunkPos
 		s.writePacket(&packet.NetworkChunkPublisherUpdate{
 			Position: protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
@@ -501,9 +532,11 @@ func (s *Session) sendChunks(tx *world.Tx, c Controllable) {
 	const maxChunkTransactions = 8
 	toLoad := maxChunkTransactions - len(s.openChunkTransactions)
 	s.blobMu.Unlock()
-	if toLoad > 4 {
-		toLoad = 4
+	perTick := s.conf.ChunksPerTick
+	if perTick <= 0 {
+		perTick = 4
 	}
+	toLoad = min(toLoad, perTick)
 	s.chunkLoader.Load(tx, toLoad)
 }
 
@@ -522,6 +555,7 @@ func (s *Session) handleWorldSwitch(w *world.World, tx *world.Tx, c Controllable
 		s.changeDimension(int32(dim), false, c)
 	}
 	s.ViewEntityTeleport(c, c.Position())
+	s.viewedWorld.Store(w)
 	s.chunkLoader.ChangeWorld(tx, w)
 }
 
@@ -549,6 +583,13 @@ func (s *Session) changeDimension(dim int32, silent bool, c Controllable) {
 	})
 }
 
+// ReloadChunks makes the Session send all chunks around the player again the
+// next time chunks are sent, for example after teleporting over a large
+// distance, so that the client does not keep rendering stale chunks.
+func (s *Session) ReloadChunks() {
+	s.reloadChunks.Store(true)
+}
+
 // ChangingDimension returns whether the session is currently changing dimension or not.
 func (s *Session) ChangingDimension() bool {
 	return s.changingDimension.Load()
@@ -589,6 +630,7 @@ func (s *Session) registerHandlers() {
 		packet.IDBookEdit:                  &BookEditHandler{},
 		packet.IDBossEvent:                 nil,
 		packet.IDClientCacheBlobStatus:     &ClientCacheBlobStatusHandler{},
+		packet.IDCommandBlockUpdate:        &CommandBlockUpdateHandler{},
 		packet.IDCommandRequest:            &CommandRequestHandler{},
 		packet.IDContainerClose:            &ContainerCloseHandler{},
 		packet.IDEmote:                     &EmoteHandler{},
@@ -598,6 +640,7 @@ func (s *Session) registerHandlers() {
 		packet.IDInventoryTransaction:      &InventoryTransactionHandler{},
 		packet.IDItemStackRequest:          &ItemStackRequestHandler{changes: map[byte]map[byte]changeInfo{}, responseChanges: map[int32]map[*inventory.Inventory]map[byte]responseChange{}},
 		packet.IDLecternUpdate:             &LecternUpdateHandler{},
+		packet.IDMapInfoRequest:            &MapInfoRequestHandler{},
 		packet.IDMobEquipment:              &MobEquipmentHandler{},
 		packet.IDModalFormResponse:         &ModalFormResponseHandler{forms: make(map[uint32]form.Form)},
 		packet.IDMovePlayer:                nil,
@@ -184,3 +184,9 @@ func (s *Session) SendSubtitle(text string) {
 func (s *Session) SendActionBarMessage(text string) {
 	s.writePacket(&packet.SetTitle{ActionType: packet.TitleActionSetActionBar, Text: text})
 }
+
+// RemoveTitle ...
+func (s *Session) RemoveTitle() {
+	s.writePacket(&packet.SetTitle{ActionType: packet.TitleActionClear})
+	s.writePacket(&packet.SetTitle{ActionType: packet.TitleActionReset})
+}
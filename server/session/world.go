@@ -72,6 +72,8 @@ func (s *Session) ViewEntity(e world.Entity) {
 		s.entities[runtimeID] = e.H()
 	}
 	s.entityMutex.Unlock()
+	// Links with entities ridden by or riding the entity can only be shown once the entity is spawned.
+	defer s.viewEntityLinks(e)
 
 	yaw, pitch := e.Rotation().Elem()
 	metadata := s.entityMetadata(e)
@@ -151,7 +153,7 @@ func (s *Session) ViewEntity(e world.Entity) {
 		Velocity:        vec64To32(vel),
 		Pitch:           float32(pitch),
 		Yaw:             float32(yaw),
-		HeadYaw:         float32(yaw),
+		HeadYaw:         float32(headYaw(e, yaw)),
 		BodyYaw:         float32(yaw),
 	})
 }
@@ -220,7 +222,7 @@ func (s *Session) viewEntityAbsoluteMovement(id uint64, e world.Entity, pos mgl6
 	s.writePacket(&packet.MoveActorAbsolute{
 		EntityRuntimeID: id,
 		Position:        vec64To32(pos.Add(entityOffset(e))),
-		Rotation:        vec64To32(mgl64.Vec3{rot.Pitch(), rot.Yaw(), rot.Yaw()}),
+		Rotation:        vec64To32(mgl64.Vec3{rot.Pitch(), rot.Yaw(), headYaw(e, rot.Yaw())}),
 		Flags:           flags,
 	})
 }
@@ -244,6 +246,16 @@ func entityOffset(e world.Entity) mgl64.Vec3 {
 	return mgl64.Vec3{}
 }
 
+// headYaw returns the yaw of the head of an entity with the body yaw passed.
+// Entities that do not have a head yaw of their own face the same way as
+// their body.
+func headYaw(e world.Entity, yaw float64) float64 {
+	if h, ok := e.(interface{ HeadYaw() float64 }); ok {
+		return h.HeadYaw()
+	}
+	return yaw
+}
+
 // ViewTime ...
 func (s *Session) ViewTime(time int) {
 	s.writePacket(&packet.SetTime{Time: int32(time)})
@@ -281,7 +293,7 @@ func (s *Session) ViewEntityTeleport(e world.Entity, position mgl64.Vec3) {
 	s.writePacket(&packet.MoveActorAbsolute{
 		EntityRuntimeID: id,
 		Position:        vec64To32(position.Add(entityOffset(e))),
-		Rotation:        vec64To32(mgl64.Vec3{pitch, yaw, yaw}),
+		Rotation:        vec64To32(mgl64.Vec3{pitch, yaw, headYaw(e, yaw)}),
 		Flags:           packet.MoveFlagTeleport,
 	})
 }
@@ -474,6 +486,11 @@ func (s *Session) ViewParticle(pos mgl64.Vec3, p world.Particle) {
 			EventType: packet.LevelEventParticleLegacyEvent | 19,
 			Position:  vec64To32(pos),
 		})
+	case particle.Bubble:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventParticlesBubble,
+			Position:  vec64To32(pos),
+		})
 	case particle.Dust:
 		s.writePacket(&packet.LevelEvent{
 			EventType: packet.LevelEventParticleLegacyEvent | 33,
@@ -677,10 +694,27 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		pk.SoundType = packet.SoundEventEnderEyePlaced
 	case sound.EndPortalCreated:
 		pk.SoundType = packet.SoundEventEndPortalCreated
+	case sound.PortalTrigger:
+		pk.SoundType = packet.SoundEventPortal
+	case sound.PortalTravel:
+		pk.SoundType = packet.SoundEventPortalTravel
+	case sound.SculkSensorPowerOn:
+		pk.SoundType = packet.SoundEventSculkSensorPowerOn
+	case sound.SculkSensorPowerOff:
+		pk.SoundType = packet.SoundEventSculkSensorPowerOff
 	case sound.Burning:
 		pk.SoundType = packet.SoundEventPlayerHurtOnFire
 	case sound.Drowning:
 		pk.SoundType = packet.SoundEventPlayerHurtDrown
+	case sound.EntityAmbient:
+		pk.SoundType, pk.EntityType, pk.BabyMob = packet.SoundEventAmbient, so.EntityType, so.Baby
+	case sound.EntityHurt:
+		pk.SoundType, pk.EntityType, pk.BabyMob = packet.SoundEventHurt, so.EntityType, so.Baby
+	case sound.EntityDeath:
+		pk.SoundType, pk.EntityType, pk.BabyMob = packet.SoundEventDeath, so.EntityType, so.Baby
+	case sound.EntityStep:
+		pk.SoundType, pk.EntityType, pk.BabyMob = packet.SoundEventStep, so.EntityType, so.Baby
+		pk.ExtraData = int32(s.br.BlockRuntimeID(so.Block))
 	case sound.Fall:
 		pk.EntityType = "minecraft:player"
 		if so.Distance > 4 {
@@ -752,12 +786,20 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 			pk.SoundType = packet.SoundEventAttackNoDamage
 		}
 	case sound.BucketFill:
+		if so.PowderSnow {
+			pk.SoundType = packet.SoundEventBucketFillPowderSnow
+			break
+		}
 		if _, water := so.Liquid.(block.Water); water {
 			pk.SoundType = packet.SoundEventBucketFillWater
 			break
 		}
 		pk.SoundType = packet.SoundEventBucketFillLava
 	case sound.BucketEmpty:
+		if so.PowderSnow {
+			pk.SoundType = packet.SoundEventBucketEmptyPowderSnow
+			break
+		}
 		if _, water := so.Liquid.(block.Water); water {
 			pk.SoundType = packet.SoundEventBucketEmptyWater
 			break
@@ -856,6 +898,12 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		return
 	case sound.ComposterEmpty:
 		pk.SoundType = packet.SoundEventComposterEmpty
+	case sound.RespawnAnchorCharge:
+		pk.SoundType = packet.SoundEventRespawnAnchorCharge
+	case sound.RespawnAnchorSetSpawn:
+		pk.SoundType = packet.SoundEventRespawnAnchorSetSpawn
+	case sound.RespawnAnchorDeplete:
+		pk.SoundType = packet.SoundEventRespawnAnchorDeplete
 	case sound.ComposterFill:
 		pk.SoundType = packet.SoundEventComposterFill
 	case sound.ComposterFillLayer:
@@ -866,6 +914,10 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		pk.SoundType = packet.SoundEventPowerOn
 	case sound.PowerOff:
 		pk.SoundType = packet.SoundEventPowerOff
+	case sound.PistonExtend:
+		pk.SoundType = packet.SoundEventPistonOut
+	case sound.PistonRetract:
+		pk.SoundType = packet.SoundEventPistonIn
 	case sound.LecternBookPlace:
 		pk.SoundType = packet.SoundEventLecternBookPlace
 	case sound.Totem:
@@ -888,6 +940,21 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		return
 	case sound.DecoratedPotInsertFailed:
 		pk.SoundType = packet.SoundEventDecoratedPotInsertFail
+	case sound.Custom:
+		volume, pitch := so.Volume, so.Pitch
+		if volume == 0 {
+			volume = 1
+		}
+		if pitch == 0 {
+			pitch = 1
+		}
+		s.writePacket(&packet.PlaySound{
+			SoundName: so.Name,
+			Position:  vec64To32(pos),
+			Volume:    float32(volume),
+			Pitch:     float32(pitch),
+		})
+		return
 	case sound.LightningExplode:
 		s.writePacket(&packet.PlaySound{
 			SoundName: "ambient.weather.lightning.impact",
@@ -920,6 +987,15 @@ func (s *Session) ViewSound(pos mgl64.Vec3, soundType world.Sound) {
 	s.playSound(pos, soundType, false)
 }
 
+// ViewLevelEvent ...
+func (s *Session) ViewLevelEvent(pos mgl64.Vec3, event world.LevelEvent, data int32) {
+	s.writePacket(&packet.LevelEvent{
+		EventType: int32(event),
+		Position:  vec64To32(pos),
+		EventData: data,
+	})
+}
+
 // OpenSign ...
 func (s *Session) OpenSign(pos cube.Pos, frontSide bool) {
 	blockPos := protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
@@ -985,21 +1061,58 @@ func (s *Session) ViewBrewingUpdate(prevBrewTime, brewTime time.Duration, prevFu
 
 // ViewBlockUpdate ...
 func (s *Session) ViewBlockUpdate(pos cube.Pos, b world.Block, layer int) {
-	blockPos := protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
 	s.writePacket(&packet.UpdateBlock{
-		Position:          blockPos,
+		Position:          protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
 		NewBlockRuntimeID: s.br.BlockRuntimeID(b),
 		Flags:             packet.BlockUpdateNetwork,
 		Layer:             uint32(layer),
 	})
-	if v, ok := b.(world.NBTer); ok {
-		if nbtData := v.EncodeNBT(); nbtData != nil {
-			nbtData["x"], nbtData["y"], nbtData["z"] = int32(pos.X()), int32(pos.Y()), int32(pos.Z())
-			s.writePacket(&packet.BlockActorData{
-				Position: blockPos,
-				NBTData:  nbtData,
-			})
+	s.viewBlockEntityData(pos, b)
+}
+
+// ViewBlockUpdates sends the blocks changed in a chunk using one UpdateSubChunkBlocks packet for every
+// sub chunk that blocks were changed in.
+func (s *Session) ViewBlockUpdates(pos world.ChunkPos, changes []world.BlockChange) {
+	var pks []*packet.UpdateSubChunkBlocks
+	subChunks := make(map[int32]*packet.UpdateSubChunkBlocks)
+	for _, change := range changes {
+		y := int32(change.Pos[1]) >> 4
+		pk, ok := subChunks[y]
+		if !ok {
+			pk = &packet.UpdateSubChunkBlocks{Position: protocol.BlockPos{pos[0], y, pos[1]}}
+			subChunks[y], pks = pk, append(pks, pk)
+		}
+		entry := protocol.BlockChangeEntry{
+			BlockPos:       protocol.BlockPos{int32(change.Pos[0]), int32(change.Pos[1]), int32(change.Pos[2])},
+			BlockRuntimeID: s.br.BlockRuntimeID(change.Block),
+			Flags:          packet.BlockUpdateNetwork,
 		}
+		if change.Layer == 1 {
+			pk.Extra = append(pk.Extra, entry)
+			continue
+		}
+		pk.Blocks = append(pk.Blocks, entry)
+	}
+	for _, pk := range pks {
+		s.writePacket(pk)
+	}
+	for _, change := range changes {
+		s.viewBlockEntityData(change.Pos, change.Block)
+	}
+}
+
+// viewBlockEntityData sends the block entity data of the block passed, if it has any.
+func (s *Session) viewBlockEntityData(pos cube.Pos, b world.Block) {
+	v, ok := b.(world.NBTer)
+	if !ok {
+		return
+	}
+	if nbtData := v.EncodeNBT(); nbtData != nil {
+		nbtData["x"], nbtData["y"], nbtData["z"] = int32(pos.X()), int32(pos.Y()), int32(pos.Z())
+		s.writePacket(&packet.BlockActorData{
+			Position: protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
+			NBTData:  nbtData,
+		})
 	}
 }
 
@@ -1091,6 +1204,11 @@ func (s *Session) ViewEntityAction(e world.Entity, a world.EntityAction) {
 			EntityRuntimeID: s.entityRuntimeID(e),
 			EventType:       packet.ActorEventTalismanActivate,
 		})
+	case entity.FishingHookBiteAction:
+		s.writePacket(&packet.ActorEvent{
+			EntityRuntimeID: s.entityRuntimeID(e),
+			EventType:       packet.ActorEventFishhookHookTime,
+		})
 	}
 }
 
@@ -1160,7 +1278,7 @@ func (s *Session) ViewEntityAnimation(e world.Entity, a world.EntityAnimation) {
 
 // OpenBlockContainer ...
 func (s *Session) OpenBlockContainer(pos cube.Pos, tx *world.Tx) {
-	if s.containerOpened.Load() && *s.openedPos.Load() == pos {
+	if s.containerOpened.Load() && s.openedVirtual.Load() == nil && *s.openedPos.Load() == pos {
 		return
 	}
 	s.closeCurrentContainer(tx, false)
@@ -1179,6 +1297,7 @@ func (s *Session) OpenBlockContainer(pos cube.Pos, tx *world.Tx) {
 		inv := inventory.New(1, nil)
 		s.openedWindow.Store(inv)
 		s.openedPos.Store(&pos)
+		s.openedWorld.Store(tx.World())
 	}
 
 	var containerType byte
@@ -1201,6 +1320,10 @@ func (s *Session) OpenBlockContainer(pos cube.Pos, tx *world.Tx) {
 		containerType = protocol.ContainerTypeStonecutter
 	case block.SmithingTable:
 		containerType = protocol.ContainerTypeSmithingTable
+	case block.CartographyTable:
+		containerType = protocol.ContainerTypeCartography
+	case block.CommandBlock:
+		containerType = protocol.ContainerTypeCommandBlock
 	case block.EnderChest:
 		b.AddViewer(tx, pos)
 
@@ -1227,6 +1350,7 @@ func (s *Session) openNormalContainer(b block.Container, pos cube.Pos, tx *world
 	inv := b.Inventory(tx, pos)
 	s.openedWindow.Store(inv)
 	s.openedPos.Store(&pos)
+	s.openedWorld.Store(tx.World())
 
 	var containerType byte
 	switch b.(type) {
@@ -1252,6 +1376,87 @@ func (s *Session) openNormalContainer(b block.Container, pos cube.Pos, tx *world
 	s.sendInv(b.Inventory(tx, pos), uint32(nextID))
 }
 
+// VirtualContainer is a container window that is not backed by a block in the world, such as a menu or a
+// shop. It is opened using Session.OpenVirtualContainer.
+type VirtualContainer struct {
+	// Inventory is the inventory shown in the window. Slot clicks are passed to its inventory.Handler.
+	Inventory *inventory.Inventory
+	// Block is the block that is shown to the client at Pos for the window to open. It must be a block.Chest
+	// or a block.Hopper. Its custom name is shown as the title of the window.
+	Block world.Block
+	// Pos is the position that Block is shown at. The block is reverted client-side when the window is
+	// closed.
+	Pos cube.Pos
+	// Pair specifies if a second chest should be shown east of Pos to open a double chest window.
+	Pair bool
+	// ReadOnly specifies if the client is prevented from changing the contents of Inventory. Slot clicks
+	// are still passed to the inventory.Handler of Inventory.
+	ReadOnly bool
+}
+
+// positions returns the positions of the fake blocks shown for the VirtualContainer.
+func (c VirtualContainer) positions() []cube.Pos {
+	if c.Pair {
+		return []cube.Pos{c.Pos, c.Pos.Side(cube.FaceEast)}
+	}
+	return []cube.Pos{c.Pos}
+}
+
+// OpenVirtualContainer opens a container window that is not backed by a block in the world. Any container
+// currently opened is closed first. The session views the inventory of the container until it is closed, so
+// that changes made to it are shown in the window.
+func (s *Session) OpenVirtualContainer(c VirtualContainer, tx *world.Tx) {
+	s.closeCurrentContainer(tx, false)
+
+	containerType := byte(protocol.ContainerTypeContainer)
+	if _, ok := c.Block.(block.Hopper); ok {
+		containerType = protocol.ContainerTypeHopper
+	}
+	for i, pos := range c.positions() {
+		blockPos := protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
+		s.writePacket(&packet.UpdateBlock{
+			Position:          blockPos,
+			NewBlockRuntimeID: s.br.BlockRuntimeID(c.Block),
+			Flags:             packet.BlockUpdateNetwork,
+		})
+		nbtData := map[string]any{}
+		if v, ok := c.Block.(world.NBTer); ok {
+			nbtData = v.EncodeNBT()
+		}
+		nbtData["x"], nbtData["y"], nbtData["z"] = blockPos[0], blockPos[1], blockPos[2]
+		if c.Pair {
+			pair := c.positions()[1-i]
+			nbtData["pairx"], nbtData["pairz"] = int32(pair[0]), int32(pair[2])
+			nbtData["pairlead"] = boolByte(i == 0)
+		}
+		s.writePacket(&packet.BlockActorData{Position: blockPos, NBTData: nbtData})
+	}
+
+	nextID := s.nextWindowID()
+	s.containerOpened.Store(true)
+	s.openedWindow.Store(c.Inventory)
+	s.openedPos.Store(&c.Pos)
+	s.openedWorld.Store(tx.World())
+	s.openedVirtual.Store(&c)
+	s.openedContainerID.Store(uint32(containerType))
+	c.Inventory.AddViewer(s)
+
+	s.writePacket(&packet.ContainerOpen{
+		WindowID:                nextID,
+		ContainerType:           containerType,
+		ContainerPosition:       protocol.BlockPos{int32(c.Pos[0]), int32(c.Pos[1]), int32(c.Pos[2])},
+		ContainerEntityUniqueID: -1,
+	})
+	s.sendInv(c.Inventory, uint32(nextID))
+}
+
+// readOnly checks if the inventory passed is the inventory of a read-only VirtualContainer that is currently
+// opened.
+func (s *Session) readOnly(inv *inventory.Inventory) bool {
+	c := s.openedVirtual.Load()
+	return c != nil && c.ReadOnly && s.containerOpened.Load() && inv == c.Inventory
+}
+
 // ViewSlotChange ...
 func (s *Session) ViewSlotChange(slot int, newItem item.Stack) {
 	if !s.containerOpened.Load() {
@@ -1380,6 +1585,67 @@ func (s *Session) ViewEntityWake(e world.Entity) {
 	})
 }
 
+// ViewEntityMount ...
+func (s *Session) ViewEntityMount(rider, vehicle world.Entity, driver bool) {
+	linkType := byte(protocol.EntityLinkPassenger)
+	if driver {
+		linkType = protocol.EntityLinkRider
+	}
+	s.writeEntityLink(rider.H(), vehicle.H(), linkType)
+	s.ViewEntityState(rider)
+}
+
+// ViewEntityDismount ...
+func (s *Session) ViewEntityDismount(rider, vehicle world.Entity) {
+	s.writeEntityLink(rider.H(), vehicle.H(), protocol.EntityLinkRemove)
+	s.ViewEntityState(rider)
+}
+
+// viewEntityLinks shows the links between the entity passed and the entities it rides or is ridden by, if
+// those entities are also viewed by the session.
+func (s *Session) viewEntityLinks(e world.Entity) {
+	if r, ok := e.(interface{ Riders() []*world.EntityHandle }); ok {
+		for i, rider := range r.Riders() {
+			linkType := byte(protocol.EntityLinkPassenger)
+			if i == 0 {
+				linkType = protocol.EntityLinkRider
+			}
+			s.writeEntityLink(rider, e.H(), linkType)
+		}
+	}
+	if r, ok := e.(interface {
+		Riding() (*world.EntityHandle, bool)
+		Driving() bool
+	}); ok {
+		if vehicle, riding := r.Riding(); riding {
+			linkType := byte(protocol.EntityLinkPassenger)
+			if r.Driving() {
+				linkType = protocol.EntityLinkRider
+			}
+			s.writeEntityLink(e.H(), vehicle, linkType)
+		}
+	}
+}
+
+// writeEntityLink sends a link of the type passed between a rider and the vehicle it rides. Nothing is sent
+// if either of the entities is not viewed by the session.
+func (s *Session) writeEntityLink(rider, vehicle *world.EntityHandle, linkType byte) {
+	s.entityMutex.RLock()
+	riderID, riderOK := s.entityRuntimeIDs[rider]
+	vehicleID, vehicleOK := s.entityRuntimeIDs[vehicle]
+	s.entityMutex.RUnlock()
+	if !riderOK || !vehicleOK {
+		return
+	}
+	s.writePacket(&packet.SetActorLink{EntityLink: protocol.EntityLink{
+		RiddenEntityUniqueID: int64(vehicleID),
+		RiderEntityUniqueID:  int64(riderID),
+		Type:                 linkType,
+		Immediate:            linkType == protocol.EntityLinkRemove,
+		RiderInitiated:       true,
+	}})
+}
+
 // nextWindowID produces the next window ID for a new window. It is an int of 1-99.
 func (s *Session) nextWindowID() byte {
 	if s.openedWindowID.CompareAndSwap(99, 1) {
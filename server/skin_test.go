@@ -0,0 +1,30 @@
+package server
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+)
+
+func TestParseSkinValidated(t *testing.T) {
+	srv := &Server{}
+	data := login.ClientData{
+		SkinID:            "skin",
+		SkinImageWidth:    64,
+		SkinImageHeight:   32,
+		SkinData:          base64.StdEncoding.EncodeToString(make([]byte, 64*32*4)),
+		SkinGeometry:      base64.StdEncoding.EncodeToString([]byte(`{}`)),
+		SkinResourcePatch: base64.StdEncoding.EncodeToString([]byte(`{}`)),
+	}
+	if err := srv.parseSkin(data).Validate(); err != nil {
+		t.Fatalf("validate skin of client data: %v", err)
+	}
+
+	// Players joining with a skin that isn't valid are disconnected before
+	// they are spawned.
+	data.SkinImageHeight = 64
+	if err := srv.parseSkin(data).Validate(); err == nil {
+		t.Fatalf("expected pixel data of a 64x32 skin to be invalid for a 64x64 skin")
+	}
+}
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// errServerFull is returned by playerSlots.acquire if no slot was
+	// available and the player was not queued.
+	errServerFull = errors.New("server full")
+	// errAlreadyLoggedIn is returned by playerSlots.acquire if a player with
+	// the same UUID already holds a slot or is queued for one.
+	errAlreadyLoggedIn = errors.New("already logged in")
+)
+
+// queueNotifyInterval is the interval at which queued players are notified of
+// their position in the queue.
+const queueNotifyInterval = time.Second * 5
+
+// playerSlots limits the amount of players that may be connected to the Server
+// at the same time. Players hold a slot from the moment they are allowed to
+// join until their session is closed, so that concurrent joins can never
+// exceed the cap. Reserved players may use a number of additional slots above
+// the cap.
+type playerSlots struct {
+	mu       sync.Mutex
+	max      int
+	reserved int
+	used     map[uuid.UUID]struct{}
+	queue    []*slotWaiter
+}
+
+// slotWaiter is a player waiting in the queue of a playerSlots for a slot to
+// free up. granted is closed once the player was given a slot.
+type slotWaiter struct {
+	id       uuid.UUID
+	reserved bool
+	granted  chan struct{}
+}
+
+// newPlayerSlots creates a playerSlots with a maximum amount of players and an
+// amount of additional reserved slots. If maxPlayers is 0, the amount of
+// players is not limited.
+func newPlayerSlots(maxPlayers, reserved int) *playerSlots {
+	return &playerSlots{max: maxPlayers, reserved: max(reserved, 0), used: make(map[uuid.UUID]struct{})}
+}
+
+// acquire acquires a slot for the player with the UUID passed. If no slot is
+// available and queue is false, errServerFull is returned. If queue is true,
+// acquire instead blocks until a slot frees up, calling notify with the
+// position of the player in the queue immediately and at a regular interval
+// afterwards. If notify returns an error or the context is cancelled, the
+// player is removed from the queue and the error is returned.
+func (s *playerSlots) acquire(ctx context.Context, id uuid.UUID, reserved, queue bool, notify func(position int) error) error {
+	s.mu.Lock()
+	if s.holds(id) {
+		s.mu.Unlock()
+		return errAlreadyLoggedIn
+	}
+	// Reserved players may skip the queue, but other players must wait for
+	// their turn if anyone is queued.
+	if s.available(reserved) && (reserved || len(s.queue) == 0) {
+		s.used[id] = struct{}{}
+		s.mu.Unlock()
+		return nil
+	}
+	if !queue {
+		s.mu.Unlock()
+		return errServerFull
+	}
+	w := &slotWaiter{id: id, reserved: reserved, granted: make(chan struct{})}
+	s.queue = append(s.queue, w)
+	s.mu.Unlock()
+
+	t := time.NewTicker(queueNotifyInterval)
+	defer t.Stop()
+	for {
+		if pos := s.position(w); pos > 0 {
+			if err := notify(pos); err != nil {
+				s.leave(w)
+				return err
+			}
+		}
+		select {
+		case <-w.granted:
+			return nil
+		case <-ctx.Done():
+			s.leave(w)
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// release releases the slot held by the player with the UUID passed, granting
+// it to the next player in the queue that may use it. Calling release for a
+// player that does not hold a slot is a no-op.
+func (s *playerSlots) release(id uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.used[id]; !ok {
+		return
+	}
+	delete(s.used, id)
+	s.grant()
+}
+
+// leave removes the slotWaiter passed from the queue. If the waiter was granted
+// a slot in the meantime, the slot is released again.
+func (s *playerSlots) leave(w *slotWaiter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-w.granted:
+		delete(s.used, w.id)
+		s.grant()
+	default:
+		s.queue = slices.DeleteFunc(s.queue, func(other *slotWaiter) bool { return other == w })
+	}
+}
+
+// grant grants free slots to players in the queue in the order they joined it.
+// Reserved players further down the queue may be granted a reserved slot while
+// other players are still waiting. grant must be called with s.mu held.
+func (s *playerSlots) grant() {
+	s.queue = slices.DeleteFunc(s.queue, func(w *slotWaiter) bool {
+		if !s.available(w.reserved) {
+			return false
+		}
+		s.used[w.id] = struct{}{}
+		close(w.granted)
+		return true
+	})
+}
+
+// position returns the 1-based position of the slotWaiter passed in the queue,
+// or 0 if it is no longer queued.
+func (s *playerSlots) position(w *slotWaiter) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Index(s.queue, w) + 1
+}
+
+// available checks if a slot is available for a player. Reserved players may
+// also use the reserved slots. available must be called with s.mu held.
+func (s *playerSlots) available(reserved bool) bool {
+	if s.max == 0 || len(s.used) < s.max {
+		return true
+	}
+	return reserved && len(s.used) < s.max+s.reserved
+}
+
+// holds checks if the player with the UUID passed holds a slot or is queued for
+// one. holds must be called with s.mu held.
+func (s *playerSlots) holds(id uuid.UUID) bool {
+	if _, ok := s.used[id]; ok {
+		return true
+	}
+	return slices.ContainsFunc(s.queue, func(w *slotWaiter) bool { return w.id == id })
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestPlayerSlotsReserved(t *testing.T) {
+	s := newPlayerSlots(1, 1)
+	ctx := context.Background()
+
+	if err := s.acquire(ctx, uuid.New(), false, false, nil); err != nil {
+		t.Fatalf("acquire first slot: %v", err)
+	}
+	if err := s.acquire(ctx, uuid.New(), false, false, nil); !errors.Is(err, errServerFull) {
+		t.Fatalf("acquire slot on full server = %v, want %v", err, errServerFull)
+	}
+	reserved := uuid.New()
+	if err := s.acquire(ctx, reserved, true, false, nil); err != nil {
+		t.Fatalf("acquire reserved slot on full server: %v", err)
+	}
+	if err := s.acquire(ctx, uuid.New(), true, false, nil); !errors.Is(err, errServerFull) {
+		t.Fatalf("acquire slot with all reserved slots used = %v, want %v", err, errServerFull)
+	}
+	if err := s.acquire(ctx, reserved, true, false, nil); !errors.Is(err, errAlreadyLoggedIn) {
+		t.Fatalf("acquire second slot for the same player = %v, want %v", err, errAlreadyLoggedIn)
+	}
+
+	// The player in the normal slot is still online, so releasing the reserved slot does not make room.
+	s.release(reserved)
+	if err := s.acquire(ctx, uuid.New(), false, false, nil); !errors.Is(err, errServerFull) {
+		t.Fatalf("acquire normal slot after reserved player left = %v, want %v", err, errServerFull)
+	}
+}
+
+func TestPlayerSlotsQueue(t *testing.T) {
+	s := newPlayerSlots(1, 0)
+	ctx := context.Background()
+
+	online := uuid.New()
+	if err := s.acquire(ctx, online, false, true, nil); err != nil {
+		t.Fatalf("acquire first slot: %v", err)
+	}
+
+	positions := make(chan int, 1)
+	granted := make(chan error, 1)
+	go func() {
+		granted <- s.acquire(ctx, uuid.New(), false, true, func(position int) error {
+			positions <- position
+			return nil
+		})
+	}()
+	if pos := <-positions; pos != 1 {
+		t.Fatalf("position of first queued player = %v, want 1", pos)
+	}
+
+	// A player that gives up waiting leaves the queue without taking a slot.
+	cancelled, cancel := context.WithCancel(ctx)
+	leaving := make(chan error, 1)
+	go func() {
+		leaving <- s.acquire(cancelled, uuid.New(), false, true, func(int) error { return nil })
+	}()
+	for s.queued() != 2 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-leaving; !errors.Is(err, context.Canceled) {
+		t.Fatalf("acquire with cancelled context = %v, want %v", err, context.Canceled)
+	}
+
+	s.release(online)
+	select {
+	case err := <-granted:
+		if err != nil {
+			t.Fatalf("queued player was not granted a slot: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("queued player was not granted a slot after a player left")
+	}
+	if s.queued() != 0 {
+		t.Errorf("players left in queue = %v, want 0", s.queued())
+	}
+}
+
+func TestPlayerSlotsConcurrentAcquire(t *testing.T) {
+	const players, slots = 64, 10
+	s := newPlayerSlots(slots, 0)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		acquired int
+	)
+	for range players {
+		wg.Go(func() {
+			if err := s.acquire(context.Background(), uuid.New(), false, false, nil); err == nil {
+				mu.Lock()
+				acquired++
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+	if acquired != slots {
+		t.Errorf("slots acquired concurrently = %v, want %v", acquired, slots)
+	}
+}
+
+// queued returns the amount of players currently in the queue.
+func (s *playerSlots) queued() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue)
+}
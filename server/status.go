@@ -9,11 +9,17 @@ import (
 // server, but the server name may be changed at any time.
 type statusProvider struct {
 	name string
+	// maxPlayers is the maximum player count shown. If 0, the maximum player
+	// count of the listener is shown.
+	maxPlayers int
 }
 
 // ServerStatus returns the player count, max players and the server's name as
 // a minecraft.ServerStatus.
 func (s statusProvider) ServerStatus(playerCount, maxPlayers int) minecraft.ServerStatus {
+	if s.maxPlayers != 0 {
+		maxPlayers = s.maxPlayers
+	}
 	return minecraft.ServerStatus{
 		ServerName:  s.name,
 		PlayerCount: playerCount,
@@ -0,0 +1,84 @@
+package world_test
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/generator"
+)
+
+func TestSingleBiomeSource(t *testing.T) {
+	w := world.Config{
+		Synchronous: true,
+		Generator:   generator.NewFlat(biome.Plains{}, nil),
+		BiomeSource: generator.NewSingleBiome(biome.Desert{}),
+	}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	w.Do(func(tx *world.Tx) {
+		for _, pos := range []cube.Pos{{0, 0, 0}, {15, 100, 15}, {-1, -64, -1}, {100, 64, -37}, {-250, 319, 1000}} {
+			if b := tx.Biome(pos); b != (biome.Desert{}) {
+				t.Errorf("biome at %v = %v, want desert everywhere", pos, b)
+			}
+		}
+	})
+}
+
+func TestBiomeMapSource(t *testing.T) {
+	src := generator.NewBiomeMap(16, map[[2]int]world.Biome{
+		{0, 0}:   biome.Forest{},
+		{-1, 0}:  biome.Jungle{},
+		{2, -3}:  biome.IceSpikes{},
+		{-1, -1}: biome.Ocean{},
+	}, biome.Plains{})
+	w := world.Config{Synchronous: true, Generator: generator.NewFlat(biome.Desert{}, nil), BiomeSource: src}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	tests := []struct {
+		pos  cube.Pos
+		want world.Biome
+	}{
+		{pos: cube.Pos{0, 64, 0}, want: biome.Forest{}},
+		{pos: cube.Pos{15, -20, 15}, want: biome.Forest{}},
+		{pos: cube.Pos{-1, 64, 0}, want: biome.Jungle{}},
+		{pos: cube.Pos{-16, 64, 15}, want: biome.Jungle{}},
+		{pos: cube.Pos{-17, 64, 0}, want: biome.Plains{}},
+		{pos: cube.Pos{40, 64, -48}, want: biome.IceSpikes{}},
+		{pos: cube.Pos{-1, 64, -1}, want: biome.Ocean{}},
+		{pos: cube.Pos{16, 64, 0}, want: biome.Plains{}},
+	}
+	w.Do(func(tx *world.Tx) {
+		for _, test := range tests {
+			if b := src.Biome(test.pos[0], test.pos[2]); b != test.want {
+				t.Errorf("biome map source at %v = %v, want %v", test.pos, b, test.want)
+			}
+			if b := tx.Biome(test.pos); b != test.want {
+				t.Errorf("biome generated at %v = %v, want %v", test.pos, b, test.want)
+			}
+		}
+	})
+}
+
+func TestCheckerboardBiomeSource(t *testing.T) {
+	src := generator.NewCheckerboard(4, biome.Plains{}, biome.Desert{})
+	tests := []struct {
+		x, z int
+		want world.Biome
+	}{
+		{x: 0, z: 0, want: biome.Plains{}},
+		{x: 3, z: 3, want: biome.Plains{}},
+		{x: 4, z: 0, want: biome.Desert{}},
+		{x: 0, z: 4, want: biome.Desert{}},
+		{x: 4, z: 4, want: biome.Plains{}},
+		{x: -1, z: 0, want: biome.Desert{}},
+		{x: -1, z: -1, want: biome.Plains{}},
+		{x: -5, z: 0, want: biome.Plains{}},
+	}
+	for _, test := range tests {
+		if b := src.Biome(test.x, test.z); b != test.want {
+			t.Errorf("checkerboard biome at %v, %v = %v, want %v", test.x, test.z, b, test.want)
+		}
+	}
+}
@@ -135,6 +135,34 @@ type RandomTicker interface {
 	RandomTick(pos cube.Pos, tx *Tx, r *rand.Rand)
 }
 
+// PrecipitationReceiver represents a block that is affected by rain or snow
+// falling on it, such as a cauldron that fills up with water or a layer of
+// snow that grows while it is snowing. Every tick, a random column in each
+// loaded chunk with a 1/16 chance receives precipitation. The block at the
+// highest position of the column exposed to the sky and the block directly
+// below it are passed the precipitation.
+type PrecipitationReceiver interface {
+	// ReceivePrecipitation handles rain or snow falling on the block at the
+	// position passed. snow is true if it is snowing rather than raining.
+	// Like in RandomTick, a rand.Rand is passed which may be used to generate
+	// values randomly without locking.
+	ReceivePrecipitation(pos cube.Pos, tx *Tx, r *rand.Rand, snow bool)
+}
+
+// ConditionalRandomTicker is a RandomTicker that only wants to be ticked
+// randomly in some of its states, such as leaves that are persistent or that
+// do not need to check for decay. Block states for which WantsRandomTick
+// returns false are never picked for a random tick, and sub chunks without any
+// states that want random ticks are skipped altogether.
+type ConditionalRandomTicker interface {
+	RandomTicker
+	// WantsRandomTick returns true if the block state should receive random
+	// ticks. WantsRandomTick is called once for every block state when the
+	// BlockRegistry is finalised, so it must not depend on anything other
+	// than the block state itself.
+	WantsRandomTick() bool
+}
+
 // ScheduledTicker represents a block that executes an action when it has a block update scheduled, such as
 // when a block adjacent to it is broken.
 type ScheduledTicker interface {
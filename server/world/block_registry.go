@@ -157,6 +157,15 @@ func (br *BasicBlockRegistry) RandomTickBlock(rid uint32) bool {
 	return br.blockInfos[rid].get(blockFlagRandomTick)
 }
 
+// wantsRandomTick checks if the block passed should receive random ticks.
+func wantsRandomTick(b Block) bool {
+	if c, ok := b.(ConditionalRandomTicker); ok {
+		return c.WantsRandomTick()
+	}
+	_, ok := b.(RandomTicker)
+	return ok
+}
+
 func (br *BasicBlockRegistry) FilteringBlock(rid uint32) uint8 {
 	if !br.finalized {
 		panic("BlockRegistry.FilteringBlock called on non finalized BlockRegistry")
@@ -389,7 +398,7 @@ func (br *BasicBlockRegistry) Finalize() {
 		if _, ok := b.(NBTer); ok {
 			info.set(blockFlagNBT)
 		}
-		if _, ok := b.(RandomTicker); ok {
+		if wantsRandomTick(b) {
 			info.set(blockFlagRandomTick)
 		}
 		if _, ok := b.(Liquid); ok {
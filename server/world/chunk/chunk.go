@@ -145,6 +145,11 @@ func (chunk *Chunk) Light(x uint8, y int16, z uint8) uint8 {
 	return sky
 }
 
+// BlockLight returns the block light level at a specific position in the chunk.
+func (chunk *Chunk) BlockLight(x uint8, y int16, z uint8) uint8 {
+	return chunk.SubChunk(y).BlockLight(x&15, uint8(y&15), z&15)
+}
+
 // SkyLight returns the skylight level at a specific position in the chunk.
 func (chunk *Chunk) SkyLight(x uint8, y int16, z uint8) uint8 {
 	return chunk.SubChunk(y).SkyLight(x&15, uint8(y&15), z&15)
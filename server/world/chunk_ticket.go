@@ -0,0 +1,108 @@
+package world
+
+import (
+	"maps"
+	"slices"
+)
+
+// ChunkTicketLevel is the level of a chunk ticket added using
+// World.AddChunkTicket. It decides if a chunk kept loaded by the ticket is
+// also ticked.
+type ChunkTicketLevel int
+
+const (
+	// ChunkTicketLoaded keeps a chunk loaded without ticking it. Blocks and
+	// entities in the chunk are only ticked while viewers are nearby.
+	ChunkTicketLoaded ChunkTicketLevel = iota
+	// ChunkTicketTicking keeps a chunk loaded and ticks the blocks and
+	// entities in it as if a viewer was nearby.
+	ChunkTicketTicking
+)
+
+// chunkTicket holds the amount of tickets of each ChunkTicketLevel added to a
+// chunk.
+type chunkTicket struct {
+	loaded, ticking int
+}
+
+// AddChunkTicket adds a ticket with the ChunkTicketLevel passed to the chunk
+// at pos. A chunk with at least one ticket is kept loaded, regardless of the
+// viewers in the World, and is loaded during the next tick if it is not
+// loaded yet.
+// Tickets are reference counted: Adding a ticket to a chunk multiple times
+// requires removing it the same amount of times using RemoveChunkTicket
+// before the chunk may be unloaded again.
+func (w *World) AddChunkTicket(pos ChunkPos, level ChunkTicketLevel) {
+	w.ticketMu.Lock()
+	defer w.ticketMu.Unlock()
+	if w.tickets == nil {
+		w.tickets = make(map[ChunkPos]chunkTicket)
+	}
+	t := w.tickets[pos]
+	if level == ChunkTicketTicking {
+		t.ticking++
+	} else {
+		t.loaded++
+	}
+	w.tickets[pos] = t
+}
+
+// RemoveChunkTicket removes a ticket with the ChunkTicketLevel passed that was
+// previously added to the chunk at pos using AddChunkTicket. Once all tickets
+// of a chunk are removed, the chunk is unloaded as usual when no viewers are
+// using it. RemoveChunkTicket returns false if the chunk had no ticket with
+// the ChunkTicketLevel passed.
+func (w *World) RemoveChunkTicket(pos ChunkPos, level ChunkTicketLevel) bool {
+	w.ticketMu.Lock()
+	defer w.ticketMu.Unlock()
+	t, ok := w.tickets[pos]
+	if !ok {
+		return false
+	}
+	n := &t.loaded
+	if level == ChunkTicketTicking {
+		n = &t.ticking
+	}
+	if *n == 0 {
+		return false
+	}
+	*n--
+	if t == (chunkTicket{}) {
+		delete(w.tickets, pos)
+		return true
+	}
+	w.tickets[pos] = t
+	return true
+}
+
+// ticketed checks if the chunk at pos has at least one ticket. ticking is
+// true if one of these tickets has the ChunkTicketTicking level.
+func (w *World) ticketed(pos ChunkPos) (ok, ticking bool) {
+	w.ticketMu.Lock()
+	defer w.ticketMu.Unlock()
+	t, ok := w.tickets[pos]
+	return ok, t.ticking > 0
+}
+
+// tickingTickets checks if any chunk in the World has a ticket with the
+// ChunkTicketTicking level.
+func (w *World) tickingTickets() bool {
+	w.ticketMu.Lock()
+	defer w.ticketMu.Unlock()
+	for _, t := range w.tickets {
+		if t.ticking > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// loadTicketedChunks loads all chunks with a ticket that are not yet loaded.
+func (w *World) loadTicketedChunks() {
+	w.ticketMu.Lock()
+	positions := slices.Collect(maps.Keys(w.tickets))
+	w.ticketMu.Unlock()
+	for _, pos := range positions {
+		w.chunk(pos)
+	}
+}
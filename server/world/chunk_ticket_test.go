@@ -0,0 +1,67 @@
+package world
+
+import "testing"
+
+// chunkTicketTestLoaded closes all unused chunks of the World passed and checks if the chunk at pos is still
+// loaded afterwards.
+func chunkTicketTestLoaded(w *World, pos ChunkPos) (loaded bool) {
+	<-w.exec(func(tx *Tx) {
+		tx.World().closeUnusedChunks(tx)
+		_, loaded = tx.World().chunks[pos]
+	})
+	return loaded
+}
+
+func TestChunkTicketKeepsChunkLoaded(t *testing.T) {
+	w := Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := ChunkPos{10, -4}
+	w.AddChunkTicket(pos, ChunkTicketLoaded)
+	w.AdvanceTick()
+	if !chunkTicketTestLoaded(w, pos) {
+		t.Fatalf("chunk with ticket is not loaded, want it to be loaded without viewers")
+	}
+	if !w.RemoveChunkTicket(pos, ChunkTicketLoaded) {
+		t.Fatalf("ticket added to chunk could not be removed")
+	}
+	if chunkTicketTestLoaded(w, pos) {
+		t.Errorf("chunk is still loaded after removing its ticket, want it to be unloaded")
+	}
+	if w.RemoveChunkTicket(pos, ChunkTicketLoaded) {
+		t.Errorf("ticket was removed from a chunk without tickets")
+	}
+}
+
+func TestChunkTicketsReferenceCounted(t *testing.T) {
+	w := Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := ChunkPos{3, 3}
+	w.AddChunkTicket(pos, ChunkTicketLoaded)
+	w.AddChunkTicket(pos, ChunkTicketLoaded)
+	w.AddChunkTicket(pos, ChunkTicketTicking)
+	w.AdvanceTick()
+
+	if w.RemoveChunkTicket(ChunkPos{3, 4}, ChunkTicketLoaded) {
+		t.Errorf("ticket was removed from a different chunk")
+	}
+	for i, level := range []ChunkTicketLevel{ChunkTicketTicking, ChunkTicketLoaded} {
+		if !w.RemoveChunkTicket(pos, level) {
+			t.Fatalf("ticket %v added to chunk could not be removed", i)
+		}
+		if !chunkTicketTestLoaded(w, pos) {
+			t.Fatalf("chunk is unloaded after removing %v of its 3 tickets, want it to stay loaded", i+1)
+		}
+	}
+	if w.RemoveChunkTicket(pos, ChunkTicketTicking) {
+		t.Errorf("ticking ticket was removed twice after being added once")
+	}
+	if _, ticking := w.ticketed(pos); ticking {
+		t.Errorf("chunk is ticking after its ticking ticket was removed")
+	}
+	w.RemoveChunkTicket(pos, ChunkTicketLoaded)
+	if chunkTicketTestLoaded(w, pos) {
+		t.Errorf("chunk is still loaded after removing all of its tickets, want it to be unloaded")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"log/slog"
 	"math/rand/v2"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type blockRegistrySetter interface {
@@ -35,6 +37,19 @@ type Config struct {
 	// the World. If set to nil, the Generator used will be NopGenerator, which
 	// generates completely empty chunks.
 	Generator Generator
+	// BiomeSource, if not nil, overrides the biomes of chunks generated by the
+	// Generator. The biomes it returns are set before Features are placed, so
+	// that they affect both the features and the colours of grass and water
+	// in newly generated chunks. Chunks already stored by the Provider are not
+	// changed.
+	BiomeSource BiomeSource
+	// Features holds the features, such as ore veins, trees and structures,
+	// that are placed in chunks after they are generated by the Generator.
+	// Features are placed in the order of this slice. The placement of each
+	// feature depends only on the seed of the World, the position of the
+	// chunk and the name of the feature, so that regenerating a chunk with
+	// the same seed reproduces identical results.
+	Features []Feature
 	// ReadOnly specifies if the World should be read-only, meaning no new data
 	// will be written to the Provider.
 	ReadOnly bool
@@ -55,6 +70,19 @@ type Config struct {
 	// will stop random ticking altogether, while setting it higher results in
 	// faster ticking.
 	RandomTickSpeed int
+	// ScheduledTickBudget is the maximum number of scheduled block updates,
+	// such as those of flowing liquids or redstone components, that are
+	// processed in a single tick. If more updates are due, they are taken
+	// round-robin from the chunks that have updates due, so that a single
+	// chunk with many updates cannot hold up other chunks, and the remaining
+	// updates are deferred to the next tick. If set to 0 or lower, all
+	// updates due are processed every tick.
+	ScheduledTickBudget int
+	// SnowAccumulationHeight is the maximum number of snow layers that
+	// accumulate on top of each other while it is snowing. By default, snow
+	// only forms a single layer, so the default value is 1. Setting this value
+	// to -1 or lower will stop snow from accumulating altogether.
+	SnowAccumulationHeight int
 	// RandSource is the rand.Source used for generation of random numbers in a
 	// World, such as when selecting blocks to tick or when deciding where to
 	// strike lightning. If set to nil, RandSource defaults to a `rand.PCG`
@@ -63,9 +91,70 @@ type Config struct {
 	// statistical distribution, which is acceptable here.
 	// See https://go.dev/blog/chacha8rand.
 	RandSource rand.Source
+	// EntityViewRadius is the radius in blocks around a viewer within which
+	// entities are shown to it. If set to 0 or lower, entities are shown to all
+	// viewers that have the chunk of the entity loaded. If set to a positive
+	// value, entities are spawned for a viewer as they enter the radius and
+	// despawned as they leave it, so that viewers don't receive entities too
+	// far away to see.
+	EntityViewRadius float64
+	// BatchEntityState specifies if changes to the state of entities, such as
+	// their name tag or whether they are on fire, are batched. If true, all
+	// state changes of an entity made within a tick are viewed by viewers
+	// once, at the end of the tick, instead of once for every change.
+	BatchEntityState bool
+	// BatchBlockUpdates specifies if block changes are batched. If true, all
+	// blocks changed in a chunk within a tick are viewed by viewers at once,
+	// at the end of the tick, using Viewer.ViewBlockUpdates. A chunk with
+	// only a single block changed is still viewed using
+	// Viewer.ViewBlockUpdate.
+	BatchBlockUpdates bool
+	// SameTeam is called to check if two entities are on the same team when
+	// one of them is damaged by the other. If SameTeam returns true, the
+	// damage and the knock back are cancelled. If nil, entities are never on
+	// the same team.
+	SameTeam func(attacker, victim Entity) bool
+	// DamageListener is called every time an entity in the World is hurt
+	// successfully, after the damage has been reduced and dealt to the
+	// entity, but before the entity dies if the damage was lethal. If nil,
+	// no function is called.
+	DamageListener func(tx *Tx, d Damage)
+	// Teams holds the teams that entities in the World may be members of.
+	// Members of the same Team cannot damage each other unless the Team has
+	// friendly fire enabled, and their name tags and collision are shown to
+	// viewers according to the rules of the Team. Teams may be shared between
+	// worlds. If nil, entities are not on any team.
+	Teams *Teams
+	// Objectives holds the scoreboard objectives that track the scores of
+	// entities in the World. Objectives may be shared between worlds. If nil,
+	// entities in the World have no scores.
+	Objectives *Objectives
+	// ChunkEntityLimit is the maximum number of entities counted by
+	// LimitedEntity that a single chunk may hold. If an entity counted is
+	// added to a chunk that already holds this many of them, the entity is
+	// either not added or the oldest entities counted in the chunk are
+	// removed, depending on RemoveOldestEntities. If set to 0 or lower,
+	// chunks may hold any number of entities.
+	ChunkEntityLimit int
+	// LimitedEntity is called to check if entities of an EntityType count
+	// towards the ChunkEntityLimit. If nil, all entities other than players
+	// count towards the limit. Players are never counted or removed, even if
+	// LimitedEntity returns true for them.
+	LimitedEntity func(t EntityType) bool
+	// RemoveOldestEntities specifies if the oldest entities counted towards
+	// the ChunkEntityLimit are removed to make space for a new entity in a
+	// chunk that is full. If false, the new entity is not added to the World
+	// instead.
+	RemoveOldestEntities bool
 	// Entities is an EntityRegistry with all Entity types registered that may
 	// be added to the World.
 	Entities EntityRegistry
+	// InventoryGroup is the inventory group of the World. Players moving
+	// between worlds with different inventory groups have their inventory,
+	// experience, health, food and effects swapped for the ones they had in
+	// the group of the world they enter. Worlds with an empty inventory group
+	// share the same group.
+	InventoryGroup string
 
 	// Blocks is the BlockRegistry used by the World.
 	// If left nil, DefaultBlockRegistry is used. For a non-default registry,
@@ -113,6 +202,9 @@ func (conf Config) New() *World {
 	if conf.RandomTickSpeed == 0 {
 		conf.RandomTickSpeed = 3
 	}
+	if conf.SnowAccumulationHeight == 0 {
+		conf.SnowAccumulationHeight = 1
+	}
 	if conf.Blocks == nil {
 		conf.Blocks = DefaultBlockRegistry
 	}
@@ -134,6 +226,7 @@ func (conf Config) New() *World {
 		scheduledUpdates: newScheduledTickQueue(s.CurrentTick),
 		redstone:         newRedstoneEngine(s.CurrentTick),
 		entities:         make(map[*EntityHandle]ChunkPos),
+		entityIDs:        make(map[uuid.UUID]*EntityHandle),
 		viewers:          make(map[*Loader]Viewer),
 		chunks:           make(map[ChunkPos]*Column),
 		queueClosing:     make(chan struct{}),
@@ -147,6 +240,9 @@ func (conf Config) New() *World {
 		set:              s,
 	}
 	w.weather = weather{w: w}
+	if conf.Teams != nil {
+		conf.Teams.attach(w)
+	}
 	var h Handler = NopHandler{}
 	w.handler.Store(&h)
 
@@ -81,6 +81,14 @@ type (
 		LavaSpreadDuration() time.Duration
 		WeatherCycle() bool
 		TimeCycle() bool
+		// BedsWork specifies if players can sleep in beds and set their spawn
+		// point with them in the Dimension. Beds explode when used in a
+		// Dimension where they do not work.
+		BedsWork() bool
+		// RespawnAnchorsWork specifies if players can set their spawn point
+		// with respawn anchors in the Dimension. Charged respawn anchors
+		// explode when used in a Dimension where they do not work.
+		RespawnAnchorsWork() bool
 	}
 	overworld struct{}
 	nether    struct{}
@@ -92,6 +100,8 @@ func (overworld) WaterEvaporates() bool             { return false }
 func (overworld) LavaSpreadDuration() time.Duration { return time.Second * 3 / 2 }
 func (overworld) WeatherCycle() bool                { return true }
 func (overworld) TimeCycle() bool                   { return true }
+func (overworld) BedsWork() bool                    { return true }
+func (overworld) RespawnAnchorsWork() bool          { return false }
 func (overworld) String() string                    { return "Overworld" }
 
 func (nether) Range() cube.Range                 { return cube.Range{0, 127} }
@@ -99,6 +109,8 @@ func (nether) WaterEvaporates() bool             { return true }
 func (nether) LavaSpreadDuration() time.Duration { return time.Second / 4 }
 func (nether) WeatherCycle() bool                { return false }
 func (nether) TimeCycle() bool                   { return false }
+func (nether) BedsWork() bool                    { return false }
+func (nether) RespawnAnchorsWork() bool          { return true }
 func (nether) String() string                    { return "Nether" }
 
 func (end) Range() cube.Range                 { return cube.Range{0, 255} }
@@ -106,4 +118,6 @@ func (end) WaterEvaporates() bool             { return false }
 func (end) LavaSpreadDuration() time.Duration { return time.Second * 3 / 2 }
 func (end) WeatherCycle() bool                { return false }
 func (end) TimeCycle() bool                   { return false }
+func (end) BedsWork() bool                    { return false }
+func (end) RespawnAnchorsWork() bool          { return false }
 func (end) String() string                    { return "End" }
@@ -24,7 +24,9 @@ type EntityType interface {
 	// returns the type of the Minecraft Entity, for example
 	// 'minecraft:falling_block'.
 	EncodeEntity() string
-	// BBox returns the bounding box of an Entity with this EntityType.
+	// BBox returns the bounding box of an Entity with this EntityType, at the
+	// default scale of the Entity. EntityBBox should be used to obtain the
+	// actual hitbox of an Entity.
 	BBox(e Entity) cube.BBox
 	// DecodeNBT reads the fields from the NBT data map passed and converts it
 	// to an Entity of the same EntityType.
@@ -34,6 +36,37 @@ type EntityType interface {
 	EncodeNBT(data *EntityData) map[string]any
 }
 
+// SpawnValidator is an EntityType that restricts the positions that its
+// entities may spawn at, such as to prevent land mobs from spawning in walls
+// or liquids. Tx.CanSpawnAt uses SpawnValidator to check if an entity may
+// spawn at a position.
+type SpawnValidator interface {
+	EntityType
+	// CanSpawnAt checks if an entity of the EntityType may spawn with its feet
+	// at the position passed.
+	CanSpawnAt(tx *Tx, pos mgl64.Vec3) bool
+}
+
+// EntityBBox returns the hitbox of the Entity passed, relative to its
+// position. It is the BBox of the EntityType of the Entity, multiplied by the
+// scale of the Entity if it has a Scale method and halved if it has a Baby
+// method that returns true. EntityBBox is used for both the collision of an
+// Entity with blocks and for finding the entities hit by projectiles and
+// other rays.
+func EntityBBox(e Entity) cube.BBox {
+	box, s := e.H().Type().BBox(e), 1.0
+	if sc, ok := e.(interface{ Scale() float64 }); ok {
+		s = sc.Scale()
+	}
+	if b, ok := e.(interface{ Baby() bool }); ok && b.Baby() {
+		s *= 0.5
+	}
+	if s == 1 {
+		return box
+	}
+	return box.Mul(s)
+}
+
 // EntityConfig is used to configure the initial settings of an Entity upon
 // creation using NewEntity.
 type EntityConfig interface {
@@ -82,6 +115,9 @@ type EntitySpawnOpts struct {
 	ID uuid.UUID
 	// NameTag is the name tag that the entity is spawned with.
 	NameTag string
+	// Persistent specifies if the entity is spawned persistent, meaning it is
+	// never despawned for being far away from players.
+	Persistent bool
 }
 
 // New creates an EntityHandle using an EntityType and EntityConfig passed. The
@@ -103,7 +139,7 @@ func (opts EntitySpawnOpts) New(t EntityType, conf EntityConfig) *EntityHandle {
 	}
 	handle.worldless.Store(true)
 	handle.data.Pos, handle.data.Rot, handle.data.Vel = opts.Position, opts.Rotation, opts.Velocity
-	handle.data.Name = opts.NameTag
+	handle.data.Name, handle.data.Persistent = opts.NameTag, opts.Persistent
 	conf.Apply(&handle.data)
 	return handle
 }
@@ -116,6 +152,47 @@ func NewEntity(t EntityType, conf EntityConfig) *EntityHandle {
 	return opts.New(t, conf)
 }
 
+// SpawnOptions holds options for spawning an entity by the name of its
+// EntityType using Tx.SpawnEntity.
+type SpawnOptions struct {
+	// Rotation is the rotation that the entity is spawned with.
+	Rotation cube.Rotation
+	// Velocity is the initial velocity of the entity.
+	Velocity mgl64.Vec3
+	// NameTag is the name tag that the entity is spawned with.
+	NameTag string
+	// Persistent specifies if the entity is spawned persistent, meaning it is
+	// never despawned for being far away from players. If false, the
+	// persistence is read from NBT instead.
+	Persistent bool
+	// NBT holds NBT data that the entity is decoded from, in the same format
+	// as entities stored in a world. The position, rotation, velocity and name
+	// tag are not read from NBT and are instead set using the fields above.
+	NBT map[string]any
+	// CheckConditions specifies if the entity is only spawned if Tx.CanSpawnAt
+	// reports that it may spawn at the position passed. It should be set for
+	// natural spawns and spawns by spawners or spawn eggs, but not for
+	// entities that replace an existing entity, such as converted entities.
+	CheckConditions bool
+}
+
+// New creates an EntityHandle with the EntityType passed at the position
+// passed, using the options in opts.
+func (opts SpawnOptions) New(t EntityType, pos mgl64.Vec3) *EntityHandle {
+	id := uuid.New()
+	clear(id[:8])
+
+	data := maps.Clone(opts.NBT)
+	if data == nil {
+		data = map[string]any{}
+	}
+	handle := entityFromData(t, int64(binary.LittleEndian.Uint64(id[8:])), data)
+	handle.data.Pos, handle.data.Rot, handle.data.Vel = pos, opts.Rotation, opts.Velocity
+	handle.data.Name = opts.NameTag
+	handle.data.Persistent = handle.data.Persistent || opts.Persistent
+	return handle
+}
+
 // entityFromData reads an entity from the decoded NBT data passed and returns
 // an EntityHandle.
 func entityFromData(t EntityType, id int64, data map[string]any) *EntityHandle {
@@ -387,8 +464,8 @@ func (e *EntityHandle) setAndUnlockWorldAt(w *World, pos mgl64.Vec3) {
 	e.cond.Broadcast()
 }
 
-// decodeNBT decodes the position, velocity, rotation, age, on-fire duration and
-// name tag of an entity.
+// decodeNBT decodes the position, velocity, rotation, age, on-fire duration,
+// name tag, tags, persistence and scale of an entity.
 func (e *EntityHandle) decodeNBT(m map[string]any) {
 	e.data.Pos = readVec3(m, "Pos")
 	e.data.Vel = readVec3(m, "Motion")
@@ -396,20 +473,42 @@ func (e *EntityHandle) decodeNBT(m map[string]any) {
 	e.data.Age = time.Duration(readInt16(m, "Age")) * (time.Second / 20)
 	e.data.FireDuration = time.Duration(readInt16(m, "Fire")) * time.Second / 20
 	e.data.Name, _ = m["NameTag"].(string)
+	e.data.Tags = readStrings(m, "Tags")
+	e.data.Persistent = readBool(m, "Persistent")
+	if sc, ok := m["Scale"].(float32); ok {
+		e.data.Scale = float64(sc)
+	}
 }
 
-// encodeNBT encodes the position, velocity, rotation, age, on-fire duration and
-// name tag of an entity.
+// EncodeNBT encodes the entity that the EntityHandle points to into NBT, in
+// the same format that entities are stored in a world, including the data of
+// its EntityType and its identifier. EncodeNBT must only be called while the
+// entity is open in a transaction.
+func (e *EntityHandle) EncodeNBT() map[string]any {
+	data := e.encodeNBT()
+	maps.Copy(data, e.t.EncodeNBT(&e.data))
+	data["identifier"] = e.t.EncodeEntity()
+	return data
+}
+
+// encodeNBT encodes the position, velocity, rotation, age, on-fire duration,
+// name tag, tags, persistence and scale of an entity.
 func (e *EntityHandle) encodeNBT() map[string]any {
-	return map[string]any{
-		"Pos":     []float32{float32(e.data.Pos[0]), float32(e.data.Pos[1]), float32(e.data.Pos[2])},
-		"Motion":  []float32{float32(e.data.Vel[0]), float32(e.data.Vel[1]), float32(e.data.Vel[2])},
-		"Yaw":     float32(e.data.Rot[0]),
-		"Pitch":   float32(e.data.Rot[1]),
-		"Fire":    int16(e.data.FireDuration.Seconds() * 20),
-		"Age":     int16(e.data.Age / (time.Second * 20)),
-		"NameTag": e.data.Name,
+	m := map[string]any{
+		"Pos":        []float32{float32(e.data.Pos[0]), float32(e.data.Pos[1]), float32(e.data.Pos[2])},
+		"Motion":     []float32{float32(e.data.Vel[0]), float32(e.data.Vel[1]), float32(e.data.Vel[2])},
+		"Yaw":        float32(e.data.Rot[0]),
+		"Pitch":      float32(e.data.Rot[1]),
+		"Fire":       int16(e.data.FireDuration.Seconds() * 20),
+		"Age":        int16(e.data.Age / (time.Second * 20)),
+		"NameTag":    e.data.Name,
+		"Tags":       slices.Clone(e.data.Tags),
+		"Persistent": writeBool(e.data.Persistent),
+	}
+	if e.data.Scale != 0 && e.data.Scale != 1 {
+		m["Scale"] = float32(e.data.Scale)
 	}
+	return m
 }
 
 // EntityData holds data shared by every entity. It is kept in an EntityHandle.
@@ -417,8 +516,15 @@ type EntityData struct {
 	Pos, Vel     mgl64.Vec3
 	Rot          cube.Rotation
 	Name         string
+	Tags         []string
 	FireDuration time.Duration
 	Age          time.Duration
+	// Persistent specifies if the entity is never despawned for being far
+	// away from players.
+	Persistent bool
+	// Scale is the scale of the entity, by which its bounding box is
+	// multiplied. A Scale of 0 is treated as the default scale of 1.
+	Scale float64
 
 	Data any
 }
@@ -469,6 +575,25 @@ type DamageSource interface {
 	IgnoreTotem() bool
 }
 
+// Damage holds the result of a successful hit on an Entity, as passed to
+// Config.DamageListener. It may be used to show damage indicators, to
+// combat-tag players or to log fights.
+type Damage struct {
+	// Attacker is the Entity responsible for the damage, such as the Entity
+	// that attacked the Victim or the owner of a projectile that hit it.
+	// Attacker is nil for damage without an attacker, such as fall damage.
+	Attacker Entity
+	// Victim is the Entity that was hurt.
+	Victim Entity
+	// Damage is the damage dealt to the Victim before any reductions.
+	Damage float64
+	// FinalDamage is the damage taken by the Victim after reductions, such as
+	// those of armour, effects and attack immunity, have been applied.
+	FinalDamage float64
+	// Source is the DamageSource of the damage.
+	Source DamageSource
+}
+
 // HealingSource represents a source of healing for an Entity. This source may
 // be passed to the Heal() method of a living Entity.
 type HealingSource interface {
@@ -499,6 +624,7 @@ type EntityRegistryConfig struct {
 	Snowball           func(opts EntitySpawnOpts, owner Entity) *EntityHandle
 	SplashPotion       func(opts EntitySpawnOpts, t any, owner Entity) *EntityHandle
 	Lightning          func(opts EntitySpawnOpts) *EntityHandle
+	FishingHook        func(opts EntitySpawnOpts, owner Entity, lure, luckOfTheSea int) *EntityHandle
 }
 
 // ArrowSpawnConfig holds the options used to spawn an arrow entity.
@@ -554,6 +680,23 @@ func (reg EntityRegistry) Types() []EntityType {
 	return slices.Collect(maps.Values(reg.ent))
 }
 
+// readStrings reads a list of strings from a map at the key passed.
+func readStrings(x map[string]any, k string) []string {
+	switch v := x[k].(type) {
+	case []string:
+		return slices.Clone(v)
+	case []any:
+		s := make([]string, 0, len(v))
+		for _, elem := range v {
+			if str, ok := elem.(string); ok {
+				s = append(s, str)
+			}
+		}
+		return s
+	}
+	return nil
+}
+
 func readVec3(x map[string]any, k string) mgl64.Vec3 {
 	if i, ok := x[k].([]any); ok {
 		if len(i) != 3 {
@@ -587,3 +730,15 @@ func readInt16(m map[string]any, k string) int16 {
 	v, _ := m[k].(int16)
 	return v
 }
+
+func readBool(m map[string]any, k string) bool {
+	v, _ := m[k].(uint8)
+	return v == 1
+}
+
+func writeBool(v bool) uint8 {
+	if v {
+		return 1
+	}
+	return 0
+}
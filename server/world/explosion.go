@@ -0,0 +1,17 @@
+package world
+
+import "github.com/go-gl/mathgl/mgl64"
+
+// ExplosionImpact holds the impact of an explosion on an Entity. It is
+// computed before the explosion is handled, so that the damage and knockback
+// may be changed by a Handler before they are applied.
+type ExplosionImpact struct {
+	// Entity is the Entity affected by the explosion.
+	Entity Entity
+	// Damage is the damage dealt to the Entity by the explosion, before armour
+	// and effects are taken into account. Entities that cannot be hurt, such
+	// as item entities, are destroyed if Damage is above 0.
+	Damage float64
+	// Knockback is the velocity that the Entity is knocked back with.
+	Knockback mgl64.Vec3
+}
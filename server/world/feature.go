@@ -0,0 +1,151 @@
+package world
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// Feature is a feature placed in chunks after they are generated, such as an
+// ore vein, a tree or a structure. Features are placed deterministically: The
+// positions at which a Feature is placed in a chunk and the random numbers
+// passed to Place depend only on the seed of the World, the position of the
+// chunk and the name of the Feature.
+type Feature interface {
+	// Name returns the name of the Feature. The name must be unique among the
+	// features of a World and is used to seed the placement of the Feature, so
+	// that features placed in the same chunk are placed independently of each
+	// other.
+	Name() string
+	// Placement returns the FeaturePlacement that determines at which
+	// positions in a chunk the Feature is placed.
+	Placement() FeaturePlacement
+	// Place places the Feature at a position in the FeatureChunk passed. r
+	// should be used for any random decisions made while placing the Feature
+	// to keep the placement deterministic.
+	Place(c *FeatureChunk, pos cube.Pos, r *rand.Rand)
+}
+
+// FeaturePlacement specifies how many times and at which positions a Feature
+// is placed in every chunk.
+type FeaturePlacement struct {
+	// Count is the number of attempts made to place the Feature in a chunk.
+	Count int
+	// Chance is the chance, between 0 and 1, that an attempt to place the
+	// Feature succeeds. If set to 0, every attempt succeeds.
+	Chance float64
+	// MinY and MaxY are the bounds of the Y coordinates, inclusive, that the
+	// Feature is placed at. The X and Z coordinates are always selected from
+	// the whole chunk.
+	MinY, MaxY int
+}
+
+// FeatureRandom returns a *rand.Rand used to place the Feature with the name
+// passed in the chunk at the ChunkPos passed, in a World with the seed passed.
+// The same seed, position and name always produce the same sequence of random
+// numbers.
+func FeatureRandom(seed int64, pos ChunkPos, name string) *rand.Rand {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	// Mix the chunk coordinates into the hash of the name so that every chunk
+	// and every feature has its own sequence.
+	s := h.Sum64() ^ uint64(uint32(pos[0]))*0x9e3779b97f4a7c15 ^ uint64(uint32(pos[1]))*0xc2b2ae3d27d4eb4f
+	return rand.New(rand.NewPCG(uint64(seed), s))
+}
+
+// FeaturePositions returns the positions at which the Feature passed is
+// placed in the chunk at the ChunkPos passed, in a World with the seed passed.
+// FeaturePositions may be used to find out what would generate at a position
+// without generating the chunk.
+func FeaturePositions(seed int64, pos ChunkPos, f Feature) []cube.Pos {
+	positions, _ := featurePositions(seed, pos, f)
+	return positions
+}
+
+// featurePositions returns the positions at which the Feature passed is placed
+// in a chunk, along with the *rand.Rand used to select them, which is then
+// passed to Feature.Place.
+func featurePositions(seed int64, pos ChunkPos, f Feature) ([]cube.Pos, *rand.Rand) {
+	r, p := FeatureRandom(seed, pos, f.Name()), f.Placement()
+	positions := make([]cube.Pos, 0, max(p.Count, 0))
+	for range p.Count {
+		x, z := r.IntN(16), r.IntN(16)
+		y := p.MinY
+		if p.MaxY > p.MinY {
+			y += r.IntN(p.MaxY - p.MinY + 1)
+		}
+		if p.Chance > 0 && r.Float64() >= p.Chance {
+			continue
+		}
+		positions = append(positions, cube.Pos{int(pos[0])<<4 + x, y, int(pos[1])<<4 + z})
+	}
+	return positions, r
+}
+
+// FeatureChunk is a chunk that a Feature is placed in. Blocks are read and set
+// using world positions. Because chunks are generated one at a time, a
+// FeatureChunk only holds the blocks of its own chunk: Blocks outside of it
+// are read as air and setting them has no effect.
+type FeatureChunk struct {
+	pos    ChunkPos
+	c      *chunk.Chunk
+	blocks BlockRegistry
+}
+
+// Pos returns the ChunkPos of the FeatureChunk.
+func (c *FeatureChunk) Pos() ChunkPos {
+	return c.pos
+}
+
+// Range returns the vertical range of the FeatureChunk.
+func (c *FeatureChunk) Range() cube.Range {
+	return c.c.Range()
+}
+
+// Block returns the block at a position in the FeatureChunk. Air is returned
+// if the position is outside the FeatureChunk.
+func (c *FeatureChunk) Block(pos cube.Pos) Block {
+	if !c.within(pos) {
+		return c.blocks.Air()
+	}
+	return c.blocks.BlockByRuntimeIDOrAir(c.c.Block(uint8(pos[0]&15), int16(pos[1]), uint8(pos[2]&15), 0))
+}
+
+// SetBlock sets the block at a position in the FeatureChunk. Nothing happens
+// if the position is outside the FeatureChunk.
+func (c *FeatureChunk) SetBlock(pos cube.Pos, b Block) {
+	if !c.within(pos) {
+		return
+	}
+	c.c.SetBlock(uint8(pos[0]&15), int16(pos[1]), uint8(pos[2]&15), 0, c.blocks.BlockRuntimeID(b))
+}
+
+// within checks if a position is within the FeatureChunk.
+func (c *FeatureChunk) within(pos cube.Pos) bool {
+	return chunkPosFromBlockPos(pos) == c.pos && !pos.OutOfBounds(c.c.Range())
+}
+
+// FeaturePositions returns the positions at which the Feature passed is
+// placed in the chunk at the ChunkPos passed, based on the seed of the World.
+func (w *World) FeaturePositions(f Feature, pos ChunkPos) []cube.Pos {
+	return FeaturePositions(w.Seed(), pos, f)
+}
+
+// placeFeatures places the Features of the World in a newly generated chunk.
+func (w *World) placeFeatures(pos ChunkPos, c *chunk.Chunk) {
+	if len(w.conf.Features) == 0 {
+		return
+	}
+	seed := w.Seed()
+	fc := &FeatureChunk{pos: pos, c: c, blocks: w.conf.Blocks}
+	for _, f := range w.conf.Features {
+		positions, r := featurePositions(seed, pos, f)
+		for _, p := range positions {
+			if !p.OutOfBounds(c.Range()) {
+				f.Place(fc, p, r)
+			}
+		}
+	}
+}
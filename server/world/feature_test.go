@@ -0,0 +1,94 @@
+package world
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// featureTestVein is a Feature that places small veins of solid blocks.
+type featureTestVein struct{}
+
+func (featureTestVein) Name() string { return "test:vein" }
+func (featureTestVein) Placement() FeaturePlacement {
+	return FeaturePlacement{Count: 8, Chance: 0.75, MinY: 0, MaxY: 60}
+}
+func (featureTestVein) Place(c *FeatureChunk, pos cube.Pos, r *rand.Rand) {
+	for range 4 {
+		c.SetBlock(pos, redstoneSolidBlock{})
+		pos = pos.Add(cube.Pos{r.IntN(3) - 1, r.IntN(3) - 1, r.IntN(3) - 1})
+	}
+}
+
+// featureTestWorld returns a World with the seed passed that places
+// featureTestVein in its chunks.
+func featureTestWorld(t *testing.T, seed int64) *World {
+	registry := NewBlockRegistry()
+	registry.RegisterBlockState(BlockState{Name: "test:solid_block", Properties: map[string]any{}})
+	registry.RegisterBlock(redstoneSolidBlock{})
+	registry.RegisterBlock(spawnTestAir{})
+	s := defaultSettings()
+	s.Seed = seed
+	w := Config{Blocks: registry, Provider: NopProvider{Set: s}, Features: []Feature{featureTestVein{}}}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	return w
+}
+
+// featureTestBlocks returns the positions of all solid blocks placed by
+// features in the chunk at the ChunkPos passed.
+func featureTestBlocks(t *testing.T, w *World, pos ChunkPos) []cube.Pos {
+	var solid []cube.Pos
+	spawnTestDo(t, w, func(tx *Tx) {
+		min, max := cube.Pos{int(pos[0]) << 4, 0, int(pos[1]) << 4}, cube.Pos{int(pos[0])<<4 + 15, 63, int(pos[1])<<4 + 15}
+		for p := range cube.Range3D(min, max) {
+			if _, ok := tx.Block(p).(redstoneSolidBlock); ok {
+				solid = append(solid, p)
+			}
+		}
+	})
+	return solid
+}
+
+func TestFeaturePlacementDeterministic(t *testing.T) {
+	for _, pos := range []ChunkPos{{0, 0}, {-3, 7}, {100, -100}} {
+		a, b := featureTestWorld(t, 1234), featureTestWorld(t, 1234)
+		if a.Seed() != 1234 {
+			t.Fatalf("seed of world = %v, want 1234", a.Seed())
+		}
+		blocksA, blocksB := featureTestBlocks(t, a, pos), featureTestBlocks(t, b, pos)
+		if len(blocksA) == 0 {
+			t.Fatalf("no features placed in chunk %v, want at least one", pos)
+		}
+		if !slices.Equal(blocksA, blocksB) {
+			t.Errorf("features placed in chunk %v differ between worlds with the same seed", pos)
+		}
+		positions := a.FeaturePositions(featureTestVein{}, pos)
+		if !slices.Equal(positions, FeaturePositions(1234, pos, featureTestVein{})) {
+			t.Errorf("feature positions in chunk %v differ between calls with the same seed", pos)
+		}
+		for _, p := range positions {
+			if chunkPosFromBlockPos(p) != pos || p[1] < 0 || p[1] > 60 {
+				t.Errorf("feature position %v is outside chunk %v or its placement bounds", p, pos)
+			}
+			if !slices.Contains(blocksA, p) {
+				t.Errorf("feature position %v has no block placed at it", p)
+			}
+		}
+	}
+}
+
+func TestFeaturePlacementDiverges(t *testing.T) {
+	pos := ChunkPos{2, -5}
+	if slices.Equal(FeaturePositions(1, pos, featureTestVein{}), FeaturePositions(2, pos, featureTestVein{})) {
+		t.Errorf("feature positions in chunk %v are identical for different seeds", pos)
+	}
+	if slices.Equal(FeaturePositions(1, pos, featureTestVein{}), FeaturePositions(1, ChunkPos{3, -5}, featureTestVein{})) {
+		t.Errorf("feature positions are identical for different chunks")
+	}
+	a, b := featureTestWorld(t, 1), featureTestWorld(t, 2)
+	if slices.Equal(featureTestBlocks(t, a, pos), featureTestBlocks(t, b, pos)) {
+		t.Errorf("features placed in chunk %v are identical for different seeds", pos)
+	}
+}
@@ -24,3 +24,29 @@ func (NopGenerator) GenerateChunk(ChunkPos, *chunk.Chunk) {}
 
 // DefaultSpawn ...
 func (NopGenerator) DefaultSpawn(Dimension) cube.Pos { return cube.Pos{} }
+
+// BiomeSource provides the biome of every column of blocks in a World. A
+// BiomeSource may be set in Config.BiomeSource to override the biomes placed by
+// the Generator, for example to generate a world with only a single biome.
+type BiomeSource interface {
+	// Biome returns the Biome of the column of blocks at the x and z
+	// coordinates passed.
+	Biome(x, z int) Biome
+}
+
+// applyBiomeSource sets the biomes of the chunk at the ChunkPos passed to
+// those returned by the BiomeSource passed, overwriting the biomes set by the
+// Generator.
+func applyBiomeSource(src BiomeSource, pos ChunkPos, c *chunk.Chunk) {
+	minY, maxY := int16(c.Range().Min()), int16(c.Range().Max())
+	baseX, baseZ := int(pos[0])<<4, int(pos[1])<<4
+
+	for x := range uint8(16) {
+		for z := range uint8(16) {
+			id := uint32(src.Biome(baseX+int(x), baseZ+int(z)).EncodeBiome())
+			for y := minY; y <= maxY; y++ {
+				c.SetBiome(x, y, z, id)
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// SingleBiome is a world.BiomeSource that returns the same biome for every column of blocks, so that a world
+// consists of only a single biome. It may be constructed by calling NewSingleBiome.
+type SingleBiome struct {
+	biome world.Biome
+}
+
+// NewSingleBiome creates a SingleBiome source that returns the world.Biome passed everywhere.
+func NewSingleBiome(biome world.Biome) SingleBiome {
+	return SingleBiome{biome: biome}
+}
+
+// Biome ...
+func (s SingleBiome) Biome(int, int) world.Biome {
+	return s.biome
+}
+
+// BiomeFunc is a function that implements world.BiomeSource, returning the biome at the x and z coordinates
+// passed.
+type BiomeFunc func(x, z int) world.Biome
+
+// Biome ...
+func (f BiomeFunc) Biome(x, z int) world.Biome {
+	return f(x, z)
+}
+
+// BiomeMap is a world.BiomeSource that divides a world into square cells, each with a biome set in a map. It
+// may be constructed by calling NewBiomeMap.
+type BiomeMap struct {
+	size     int
+	cells    map[[2]int]world.Biome
+	fallback world.Biome
+}
+
+// NewBiomeMap creates a BiomeMap with cells of size by size blocks. The cell that a column of blocks is in is
+// found by dividing its x and z coordinates by size, rounding down, so that the cell {0, 0} spans the blocks
+// from 0 to size-1 on both axes. Cells that are not present in the map passed have the fallback biome. A size
+// of 1 or lower makes every cell a single column of blocks.
+func NewBiomeMap(size int, cells map[[2]int]world.Biome, fallback world.Biome) BiomeMap {
+	return BiomeMap{size: max(size, 1), cells: cells, fallback: fallback}
+}
+
+// Biome ...
+func (m BiomeMap) Biome(x, z int) world.Biome {
+	if b, ok := m.cells[[2]int{floorDiv(x, m.size), floorDiv(z, m.size)}]; ok {
+		return b
+	}
+	return m.fallback
+}
+
+// Checkerboard is a world.BiomeSource that divides a world into square cells of alternating biomes, which is
+// mostly useful for testing biome-dependent behaviour. It may be constructed by calling NewCheckerboard.
+type Checkerboard struct {
+	size   int
+	biomes []world.Biome
+}
+
+// NewCheckerboard creates a Checkerboard with cells of size by size blocks that cycle through the biomes
+// passed. With two biomes, neighbouring cells always have a different biome. A size of 1 or lower makes every
+// cell a single column of blocks. NewCheckerboard panics if no biomes are passed.
+func NewCheckerboard(size int, biomes ...world.Biome) Checkerboard {
+	if len(biomes) == 0 {
+		panic("cannot create checkerboard without biomes")
+	}
+	return Checkerboard{size: max(size, 1), biomes: biomes}
+}
+
+// Biome ...
+func (c Checkerboard) Biome(x, z int) world.Biome {
+	n := len(c.biomes)
+	i := (floorDiv(x, c.size) + floorDiv(z, c.size)) % n
+	return c.biomes[(i+n)%n]
+}
+
+// floorDiv divides a by b, rounding down towards negative infinity rather than towards zero.
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
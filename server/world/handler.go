@@ -56,10 +56,12 @@ type Handler interface {
 	// through a call to Tx.RemoveEntity.
 	HandleEntityDespawn(tx *Tx, e Entity)
 	// HandleExplosion handles an explosion in the world. ctx.Cancel() may be called
-	// to cancel the explosion.
-	// The affected entities, affected blocks, item drop chance, and whether the
-	// explosion spawns fire may be altered.
-	HandleExplosion(ctx *Context, position mgl64.Vec3, entities *[]Entity, blocks *[]cube.Pos, itemDropChance *float64, spawnFire *bool)
+	// to cancel the explosion. HandleExplosion is called before any blocks are
+	// removed and before any entities are damaged.
+	// The affected entities along with the damage and knockback they receive,
+	// affected blocks, item drop chance, and whether the explosion spawns fire
+	// may be altered.
+	HandleExplosion(ctx *Context, position mgl64.Vec3, entities *[]ExplosionImpact, blocks *[]cube.Pos, itemDropChance *float64, spawnFire *bool)
 	// HandleRedstoneUpdate handles a redstone update proposed by the World redstone engine. ctx.Cancel() may be
 	// called to suppress the proposed redstone mutation and any propagation from that mutation.
 	HandleRedstoneUpdate(ctx *Context, update RedstoneUpdate)
@@ -78,16 +80,17 @@ var _ Handler = (*NopHandler)(nil)
 // Users may embed NopHandler to avoid having to implement each method.
 type NopHandler struct{}
 
-func (NopHandler) HandleLiquidFlow(*Context, cube.Pos, cube.Pos, Liquid, Block)                  {}
-func (NopHandler) HandleLiquidDecay(*Context, cube.Pos, Liquid, Liquid)                          {}
-func (NopHandler) HandleLiquidHarden(*Context, cube.Pos, Block, Block, Block)                    {}
-func (NopHandler) HandleSound(*Context, Sound, mgl64.Vec3)                                       {}
-func (NopHandler) HandleFireSpread(*Context, cube.Pos, cube.Pos)                                 {}
-func (NopHandler) HandleBlockBurn(*Context, cube.Pos)                                            {}
-func (NopHandler) HandleCropTrample(*Context, cube.Pos)                                          {}
-func (NopHandler) HandleLeavesDecay(*Context, cube.Pos)                                          {}
-func (NopHandler) HandleEntitySpawn(*Tx, Entity)                                                 {}
-func (NopHandler) HandleEntityDespawn(*Tx, Entity)                                               {}
-func (NopHandler) HandleExplosion(*Context, mgl64.Vec3, *[]Entity, *[]cube.Pos, *float64, *bool) {}
-func (NopHandler) HandleRedstoneUpdate(*Context, RedstoneUpdate)                                 {}
-func (NopHandler) HandleClose(*Tx)                                                               {}
+func (NopHandler) HandleLiquidFlow(*Context, cube.Pos, cube.Pos, Liquid, Block) {}
+func (NopHandler) HandleLiquidDecay(*Context, cube.Pos, Liquid, Liquid)         {}
+func (NopHandler) HandleLiquidHarden(*Context, cube.Pos, Block, Block, Block)   {}
+func (NopHandler) HandleSound(*Context, Sound, mgl64.Vec3)                      {}
+func (NopHandler) HandleFireSpread(*Context, cube.Pos, cube.Pos)                {}
+func (NopHandler) HandleBlockBurn(*Context, cube.Pos)                           {}
+func (NopHandler) HandleCropTrample(*Context, cube.Pos)                         {}
+func (NopHandler) HandleLeavesDecay(*Context, cube.Pos)                         {}
+func (NopHandler) HandleEntitySpawn(*Tx, Entity)                                {}
+func (NopHandler) HandleEntityDespawn(*Tx, Entity)                              {}
+func (NopHandler) HandleExplosion(*Context, mgl64.Vec3, *[]ExplosionImpact, *[]cube.Pos, *float64, *bool) {
+}
+func (NopHandler) HandleRedstoneUpdate(*Context, RedstoneUpdate) {}
+func (NopHandler) HandleClose(*Tx)                               {}
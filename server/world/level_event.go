@@ -0,0 +1,112 @@
+package world
+
+import (
+	"image/color"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// LevelEvent is an event that occurs in a World and is shown to viewers as a
+// combination of particles and sounds, such as the particles and sound of a
+// block being broken. LevelEvents are broadcast using Tx.BroadcastLevelEvent,
+// together with data specific to the event. The values of the LevelEvent
+// constants are the IDs of the level events in the Bedrock Edition protocol.
+type LevelEvent int32
+
+const (
+	// LevelEventClick plays the click sound of a dispenser or dropper. It has
+	// no data.
+	LevelEventClick LevelEvent = 1000
+	// LevelEventClickFail plays the sound of a dispenser or dropper failing
+	// to dispense an item. It has no data.
+	LevelEventClickFail LevelEvent = 1001
+	// LevelEventLaunch plays the sound of a dispenser launching a projectile.
+	// It has no data.
+	LevelEventLaunch LevelEvent = 1002
+	// LevelEventDoorOpen plays the sound of a door being opened. It has no
+	// data.
+	LevelEventDoorOpen LevelEvent = 1003
+	// LevelEventExtinguish plays the fizz sound of fire or lava being
+	// extinguished. It has no data.
+	LevelEventExtinguish LevelEvent = 1004
+	// LevelEventFuse plays the sound of TNT being ignited. It has no data.
+	LevelEventFuse LevelEvent = 1005
+	// LevelEventEndermanTeleport plays the sound of an enderman teleporting.
+	// It has no data.
+	LevelEventEndermanTeleport LevelEvent = 1018
+	// LevelEventAnvilBreak plays the sound of an anvil breaking. It has no
+	// data.
+	LevelEventAnvilBreak LevelEvent = 1020
+	// LevelEventAnvilUse plays the sound of an anvil being used. It has no
+	// data.
+	LevelEventAnvilUse LevelEvent = 1021
+	// LevelEventAnvilLand plays the sound of a falling anvil landing. It has
+	// no data.
+	LevelEventAnvilLand LevelEvent = 1022
+	// LevelEventTotemUse plays the sound of a totem of undying being used. It
+	// has no data.
+	LevelEventTotemUse LevelEvent = 1052
+	// LevelEventBlockBreak shows the particles and plays the sound of a block
+	// being broken. Its data is the block broken, as returned by
+	// Tx.BlockEventData.
+	LevelEventBlockBreak LevelEvent = 2001
+	// LevelEventSplash shows the particles and plays the sound of a splash
+	// potion breaking. Its data is the colour of the particles as an ARGB
+	// value, as returned by ColourEventData.
+	LevelEventSplash LevelEvent = 2002
+	// LevelEventEyeOfEnderDeath shows the particles of an eye of ender
+	// breaking. It has no data.
+	LevelEventEyeOfEnderDeath LevelEvent = 2003
+	// LevelEventMobSpawn shows the particles of a mob spawner spawning an
+	// entity. It has no data.
+	LevelEventMobSpawn LevelEvent = 2004
+	// LevelEventCropGrowth shows the particles of bone meal being used on a
+	// block. Its data is the number of particles shown.
+	LevelEventCropGrowth LevelEvent = 2005
+	// LevelEventDenyBlock shows the particles of a block being denied. It has
+	// no data.
+	LevelEventDenyBlock LevelEvent = 2008
+	// LevelEventPortalTravel shows the particles of an entity travelling
+	// through a portal or teleporting. It has no data.
+	LevelEventPortalTravel LevelEvent = 2013
+	// LevelEventBlockCrack shows the particles of a block being punched. Its
+	// data is the block punched and the face punched, as returned by
+	// Tx.BlockFaceEventData.
+	LevelEventBlockCrack LevelEvent = 2014
+	// LevelEventEvaporate shows the particles of water evaporating, for
+	// example when placed in the Nether. It has no data.
+	LevelEventEvaporate LevelEvent = 2020
+	// LevelEventExplosion shows the particles of an explosion. It has no data.
+	LevelEventExplosion LevelEvent = 2025
+	// LevelEventWaxOn shows the particles of a copper block being waxed. It
+	// has no data.
+	LevelEventWaxOn LevelEvent = 2030
+	// LevelEventWaxOff shows the particles of the wax of a copper block being
+	// removed. It has no data.
+	LevelEventWaxOff LevelEvent = 2031
+	// LevelEventScrape shows the particles of the oxidation of a copper block
+	// being scraped off. It has no data.
+	LevelEventScrape LevelEvent = 2032
+	// LevelEventElectricSpark shows the spark particles of a lightning rod. It
+	// has no data.
+	LevelEventElectricSpark LevelEvent = 2033
+)
+
+// BlockEventData returns the data of a LevelEvent that refers to a block,
+// such as LevelEventBlockBreak, for the Block passed.
+func (tx *Tx) BlockEventData(b Block) int32 {
+	return int32(tx.World().conf.Blocks.BlockRuntimeID(b))
+}
+
+// BlockFaceEventData returns the data of a LevelEvent that refers to a face
+// of a block, such as LevelEventBlockCrack, for the Block and cube.Face
+// passed.
+func (tx *Tx) BlockFaceEventData(b Block, face cube.Face) int32 {
+	return tx.BlockEventData(b) | int32(face)<<24
+}
+
+// ColourEventData returns the data of a LevelEvent that holds a colour, such
+// as LevelEventSplash, for the colour passed.
+func ColourEventData(c color.RGBA) int32 {
+	return int32(c.A)<<24 | int32(c.R)<<16 | int32(c.G)<<8 | int32(c.B)
+}
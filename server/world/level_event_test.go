@@ -0,0 +1,96 @@
+package world
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+func TestLevelEventIDs(t *testing.T) {
+	for event, want := range map[LevelEvent]int32{
+		LevelEventClick:            packet.LevelEventSoundClick,
+		LevelEventDoorOpen:         packet.LevelEventSoundOpenDoor,
+		LevelEventExtinguish:       packet.LevelEventSoundFizz,
+		LevelEventEndermanTeleport: packet.LevelEventSoundEndermanTeleport,
+		LevelEventAnvilLand:        packet.LevelEventSoundAnvilLand,
+		LevelEventTotemUse:         packet.LevelEventSoundTotemUsed,
+		LevelEventBlockBreak:       packet.LevelEventParticlesDestroyBlock,
+		LevelEventSplash:           packet.LevelEventParticlesPotionSplash,
+		LevelEventCropGrowth:       packet.LevelEventParticleCropGrowth,
+		LevelEventPortalTravel:     packet.LevelEventParticlesTeleport,
+		LevelEventBlockCrack:       packet.LevelEventParticlesCrackBlock,
+		LevelEventEvaporate:        packet.LevelEventParticlesEvaporateWater,
+		LevelEventExplosion:        packet.LevelEventParticlesExplosion,
+		LevelEventWaxOn:            packet.LevelEventWaxOn,
+		LevelEventScrape:           packet.LevelEventScrape,
+		LevelEventElectricSpark:    packet.LevelEventParticlesElectricSpark,
+	} {
+		if int32(event) != want {
+			t.Errorf("level event %v, want protocol ID %v", int32(event), want)
+		}
+	}
+}
+
+func TestLevelEventData(t *testing.T) {
+	registry := scheduledTickTestRegistry()
+	w := Config{Synchronous: true, Blocks: registry}.New()
+	defer w.Close()
+
+	b := scheduledTickTestBlock{}
+	rid := int32(registry.BlockRuntimeID(b))
+	runWorld(w, func(tx *Tx) {
+		if got := tx.BlockEventData(b); got != rid {
+			t.Errorf("block event data = %v, want runtime ID %v", got, rid)
+		}
+		if got, want := tx.BlockFaceEventData(b, cube.FaceEast), rid|int32(cube.FaceEast)<<24; got != want {
+			t.Errorf("block face event data = %#x, want %#x", got, want)
+		}
+	})
+	if got, want := ColourEventData(color.RGBA{R: 0x12, G: 0x34, B: 0x56, A: 0xff}), int32(-0xedcbaa); got != want {
+		t.Errorf("colour event data = %#x, want %#x", got, want)
+	}
+}
+
+func TestBroadcastLevelEventViewers(t *testing.T) {
+	w := Config{Synchronous: true}.New()
+	defer w.Close()
+
+	near, far := &levelEventTestViewer{}, &levelEventTestViewer{}
+	pos := mgl64.Vec3{8.5, 64, 8.5}
+	runWorld(w, func(tx *Tx) {
+		nearLoader, farLoader := NewLoader(2, w, near), NewLoader(2, w, far)
+		defer nearLoader.Close(tx)
+		defer farLoader.Close(tx)
+		farLoader.Move(tx, mgl64.Vec3{1000, 64, 1000})
+		nearLoader.Load(tx, 100)
+		farLoader.Load(tx, 100)
+
+		tx.BroadcastLevelEvent(pos, LevelEventCropGrowth, 15)
+	})
+
+	want := levelEventTestEvent{pos: pos, event: LevelEventCropGrowth, data: 15}
+	if len(near.events) != 1 || near.events[0] != want {
+		t.Fatalf("level events received by viewer in range = %v, want [%v]", near.events, want)
+	}
+	if len(far.events) != 0 {
+		t.Errorf("level events received by viewer out of range = %v, want none", far.events)
+	}
+}
+
+type levelEventTestViewer struct {
+	NopViewer
+	events []levelEventTestEvent
+}
+
+type levelEventTestEvent struct {
+	pos   mgl64.Vec3
+	event LevelEvent
+	data  int32
+}
+
+func (v *levelEventTestViewer) ViewLevelEvent(pos mgl64.Vec3, event LevelEvent, data int32) {
+	v.events = append(v.events, levelEventTestEvent{pos: pos, event: event, data: data})
+}
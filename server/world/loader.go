@@ -1,10 +1,13 @@
 package world
 
 import (
-	"github.com/go-gl/mathgl/mgl64"
+	"cmp"
 	"maps"
 	"math"
+	"slices"
 	"sync"
+
+	"github.com/go-gl/mathgl/mgl64"
 )
 
 // Loader implements the loading of the world. A loader can typically be moved around the world to load
@@ -20,6 +23,10 @@ type Loader struct {
 	loadQueue []ChunkPos
 	loaded    map[ChunkPos]*Column
 
+	trackMu sync.Mutex
+	vec     mgl64.Vec3
+	tracked map[*EntityHandle]struct{}
+
 	closed bool
 }
 
@@ -28,7 +35,7 @@ type Loader struct {
 // The Viewer passed will handle the loading of chunks, including the viewing of entities that were loaded in
 // those chunks.
 func NewLoader(chunkRadius int, world *World, v Viewer) *Loader {
-	l := &Loader{r: chunkRadius, loaded: make(map[ChunkPos]*Column), viewer: v}
+	l := &Loader{r: chunkRadius, loaded: make(map[ChunkPos]*Column), tracked: make(map[*EntityHandle]struct{}), viewer: v}
 	l.world(world)
 	return l
 }
@@ -53,6 +60,7 @@ func (l *Loader) ChangeWorld(tx *Tx, new *World) {
 		}
 	})
 	clear(l.loaded)
+	l.clearTracked()
 	l.w.viewerMu.Lock()
 	delete(l.w.viewers, l)
 	l.w.viewerMu.Unlock()
@@ -75,6 +83,10 @@ func (l *Loader) Move(tx *Tx, pos mgl64.Vec3) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	l.trackMu.Lock()
+	l.vec = pos
+	l.trackMu.Unlock()
+
 	chunkPos := chunkPosFromVec3(pos)
 	if chunkPos == l.pos {
 		return
@@ -84,6 +96,24 @@ func (l *Loader) Move(tx *Tx, pos mgl64.Vec3) {
 	l.populateLoadQueue()
 }
 
+// Reload unloads all chunks currently loaded, hiding the entities in them from the Viewer, so that all chunks
+// around the Loader are viewed again by subsequent calls to Load. Reload may be used after the Viewer was
+// moved over a large distance, to prevent it from seeing stale chunks.
+func (l *Loader) Reload(tx *Tx) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return
+	}
+	for pos := range l.loaded {
+		l.w.removeViewer(tx, pos, l)
+	}
+	clear(l.loaded)
+	l.clearTracked()
+	l.populateLoadQueue()
+}
+
 // Load loads n chunks around the centre of the chunk, starting with the middle and working outwards. For
 // every chunk loaded, the Viewer passed through construction in New has its ViewChunk method called.
 // Load does nothing for n <= 0.
@@ -91,9 +121,12 @@ func (l *Loader) Load(tx *Tx, n int) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	if l.closed || l.w == nil {
+	if l.closed || l.w == nil || n <= 0 {
 		return
 	}
+	tm := l.w.timings.Load()
+	defer tm.measure(TimingsChunkSend, tm.now())
+
 	for i := 0; i < n; i++ {
 		if len(l.loadQueue) == 0 {
 			break
@@ -132,6 +165,7 @@ func (l *Loader) Close(tx *Tx) {
 		tx.World().removeViewer(tx, pos, l)
 	}
 	l.loaded = map[ChunkPos]*Column{}
+	l.clearTracked()
 
 	l.w.viewerMu.Lock()
 	delete(l.w.viewers, l)
@@ -141,6 +175,59 @@ func (l *Loader) Close(tx *Tx) {
 	l.viewer = nil
 }
 
+// position returns the exact position that the Loader was last moved to.
+func (l *Loader) position() mgl64.Vec3 {
+	l.trackMu.Lock()
+	defer l.trackMu.Unlock()
+	return l.vec
+}
+
+// track marks the entity of the EntityHandle passed as shown to the Loader's viewer. False is returned if the
+// entity was already tracked.
+func (l *Loader) track(handle *EntityHandle) bool {
+	l.trackMu.Lock()
+	defer l.trackMu.Unlock()
+	if _, ok := l.tracked[handle]; ok {
+		return false
+	}
+	l.tracked[handle] = struct{}{}
+	return true
+}
+
+// untrack marks the entity of the EntityHandle passed as no longer shown to the Loader's viewer. False is
+// returned if the entity was not tracked.
+func (l *Loader) untrack(handle *EntityHandle) bool {
+	l.trackMu.Lock()
+	defer l.trackMu.Unlock()
+	if _, ok := l.tracked[handle]; !ok {
+		return false
+	}
+	delete(l.tracked, handle)
+	return true
+}
+
+// tracking checks if the entity of the EntityHandle passed is shown to the Loader's viewer.
+func (l *Loader) tracking(handle *EntityHandle) bool {
+	l.trackMu.Lock()
+	defer l.trackMu.Unlock()
+	_, ok := l.tracked[handle]
+	return ok
+}
+
+// trackedEntities returns a copy of the set of entities currently shown to the Loader's viewer.
+func (l *Loader) trackedEntities() map[*EntityHandle]struct{} {
+	l.trackMu.Lock()
+	defer l.trackMu.Unlock()
+	return maps.Clone(l.tracked)
+}
+
+// clearTracked clears the set of entities shown to the Loader's viewer.
+func (l *Loader) clearTracked() {
+	l.trackMu.Lock()
+	clear(l.tracked)
+	l.trackMu.Unlock()
+}
+
 // world sets the loader's world, adds them to the world's viewer list, then starts populating the load queue.
 // This is only here to get rid of duplicated code, ChangeWorld should be used instead of this.
 func (l *Loader) world(new *World) {
@@ -162,20 +249,18 @@ func (l *Loader) evictUnused(tx *Tx) {
 	}
 }
 
-// populateLoadQueue populates the load queue of the loader. This method is called once to create the order in
-// which chunks around the position the loader is now in should be loaded. Chunks are ordered to be loaded
-// from the middle outwards.
+// populateLoadQueue populates the load queue of the loader. This method is called whenever the loader moves
+// to a different chunk or changes radius, so that chunks queued are reprioritised. Chunks are ordered to be
+// loaded from the middle outwards: The chunk the loader is in is always loaded first, after which chunks
+// closer to it are loaded before chunks further away.
 func (l *Loader) populateLoadQueue() {
-	// We'll first load the chunk positions to load in a map indexed by the distance to the centre (basically,
-	// what precedence it should have), and put them in the loadQueue in that order.
-	queue := map[int32][]ChunkPos{}
+	l.loadQueue = l.loadQueue[:0]
 
 	r := int32(l.r)
 	for x := -r; x <= r; x++ {
 		for z := -r; z <= r; z++ {
 			distance := math.Sqrt(float64(x*x) + float64(z*z))
-			chunkDistance := int32(math.Round(distance))
-			if chunkDistance > r {
+			if int32(math.Round(distance)) >= r && (x != 0 || z != 0) {
 				// The chunk was outside the chunk radius.
 				continue
 			}
@@ -184,16 +269,16 @@ func (l *Loader) populateLoadQueue() {
 				// The chunk was already loaded, so we don't need to do anything.
 				continue
 			}
-			if m, ok := queue[chunkDistance]; ok {
-				queue[chunkDistance] = append(m, pos)
-				continue
-			}
-			queue[chunkDistance] = []ChunkPos{pos}
+			l.loadQueue = append(l.loadQueue, pos)
 		}
 	}
+	slices.SortStableFunc(l.loadQueue, func(a, b ChunkPos) int {
+		return cmp.Compare(l.distanceSquared(a), l.distanceSquared(b))
+	})
+}
 
-	l.loadQueue = l.loadQueue[:0]
-	for i := int32(0); i < r; i++ {
-		l.loadQueue = append(l.loadQueue, queue[i]...)
-	}
+// distanceSquared returns the squared distance between the chunk the loader is in and the ChunkPos passed.
+func (l *Loader) distanceSquared(pos ChunkPos) int64 {
+	x, z := int64(pos[0]-l.pos[0]), int64(pos[1]-l.pos[1])
+	return x*x + z*z
 }
@@ -0,0 +1,110 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestLoaderLoadRespectsLimit(t *testing.T) {
+	w := Config{Synchronous: true}.New()
+	defer w.Close()
+
+	v := &loaderTestViewer{}
+	runWorld(w, func(tx *Tx) {
+		l := NewLoader(4, w, v)
+		defer l.Close(tx)
+
+		for range 3 {
+			before := len(v.viewed)
+			l.Load(tx, 5)
+			if n := len(v.viewed) - before; n != 5 {
+				t.Fatalf("chunks loaded = %v, want 5", n)
+			}
+		}
+		l.Load(tx, 0)
+		if len(v.viewed) != 15 {
+			t.Fatalf("chunks loaded after loading 0 chunks = %v, want 15", len(v.viewed))
+		}
+	})
+}
+
+func TestLoaderLoadsNearestFirst(t *testing.T) {
+	w := Config{Synchronous: true}.New()
+	defer w.Close()
+
+	v := &loaderTestViewer{}
+	runWorld(w, func(tx *Tx) {
+		l := NewLoader(4, w, v)
+		defer l.Close(tx)
+
+		l.Load(tx, 10)
+		if v.viewed[0] != (ChunkPos{}) {
+			t.Fatalf("first chunk loaded = %v, want %v", v.viewed[0], ChunkPos{})
+		}
+		assertNearestFirst(t, v.viewed, ChunkPos{})
+
+		// Moving while chunks are still queued should reprioritise the queue
+		// around the new position.
+		centre := ChunkPos{20, -20}
+		l.Move(tx, mgl64.Vec3{float64(centre[0]<<4) + 8, 64, float64(centre[1]<<4) + 8})
+		v.viewed = nil
+		l.Load(tx, 1000)
+		if v.viewed[0] != centre {
+			t.Fatalf("first chunk loaded after moving = %v, want %v", v.viewed[0], centre)
+		}
+		assertNearestFirst(t, v.viewed, centre)
+	})
+}
+
+func assertNearestFirst(t *testing.T, viewed []ChunkPos, centre ChunkPos) {
+	t.Helper()
+	last := int32(0)
+	for _, pos := range viewed {
+		x, z := pos[0]-centre[0], pos[1]-centre[1]
+		if dist := x*x + z*z; dist < last {
+			t.Fatalf("chunk %v loaded after a chunk further away from %v", pos, centre)
+		} else {
+			last = dist
+		}
+	}
+}
+
+type loaderTestViewer struct {
+	NopViewer
+	viewed []ChunkPos
+}
+
+func (v *loaderTestViewer) ViewChunk(pos ChunkPos, _ Dimension, _ map[cube.Pos]Block, _ *chunk.Chunk) {
+	v.viewed = append(v.viewed, pos)
+}
+
+func TestLoaderReload(t *testing.T) {
+	w := Config{Synchronous: true}.New()
+	defer w.Close()
+
+	v := &loaderTestViewer{}
+	runWorld(w, func(tx *Tx) {
+		l := NewLoader(2, w, v)
+		defer l.Close(tx)
+
+		l.Load(tx, 1000)
+		loaded := len(v.viewed)
+		v.viewed = nil
+		l.Load(tx, 1000)
+		if len(v.viewed) != 0 {
+			t.Fatalf("chunks loaded again without reloading = %v, want 0", len(v.viewed))
+		}
+
+		l.Reload(tx)
+		if _, ok := l.Chunk(ChunkPos{}); ok {
+			t.Fatalf("chunk still loaded after reloading")
+		}
+		l.Load(tx, 1000)
+		if len(v.viewed) != loaded || v.viewed[0] != (ChunkPos{}) {
+			t.Fatalf("chunks viewed after reloading = %v, want the same %v chunks nearest first", len(v.viewed), loaded)
+		}
+	})
+}
@@ -0,0 +1,406 @@
+package world
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"math/rand/v2"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// MapSize is the width and height in pixels of a Map.
+const MapSize = 128
+
+// MaxMapScale is the highest scale a Map may have. A Map with this scale shows
+// an area of 2048x2048 blocks.
+const MaxMapScale = 4
+
+// MapColourer is implemented by blocks that are shown on a Map. Blocks that do
+// not implement MapColourer, such as air and glass, are not drawn: The first
+// block below them that does is drawn instead.
+type MapColourer interface {
+	// MapColour returns the base colour of the block on a Map. The colour is
+	// shaded depending on the height of the block relative to the block north
+	// of it.
+	MapColour() color.RGBA
+}
+
+// Map holds the pixels of a map shown in filled map items. A Map shows the
+// area of the World surrounding its centre, which is drawn as players holding
+// the map explore it, but plugins may also draw custom images onto it.
+// A Map is safe for concurrent use. Maps are not saved by the Provider of a
+// World.
+type Map struct {
+	id      int64
+	centre  cube.Pos
+	scale   int
+	dim     Dimension
+	explore int
+
+	mu       sync.Mutex
+	locked   bool
+	pixels   [MapSize * MapSize]color.RGBA
+	versions [MapSize * MapSize]uint64
+	version  uint64
+}
+
+// MapUpdate is an update of the pixels of a Map, as returned by Map.Changes.
+type MapUpdate struct {
+	// Area is the area of the Map that changed. Pixels outside the area did
+	// not change. Area is empty if no pixels changed.
+	Area image.Rectangle
+	// Pixels holds the colours of all pixels in Area, ordered by row.
+	Pixels []color.RGBA
+}
+
+// MapMarkerType is the type of MapMarker, which decides the icon shown for it
+// on a Map.
+type MapMarkerType uint8
+
+const (
+	// MapMarkerPlayer is the marker of a player on the Map, pointing in the
+	// direction the player is facing.
+	MapMarkerPlayer MapMarkerType = 0
+	// MapMarkerPlayerOffMap is the marker of a player outside the area shown
+	// by the Map, drawn at the edge of the Map closest to it.
+	MapMarkerPlayerOffMap MapMarkerType = 6
+)
+
+// MapMarker is a marker shown on top of the pixels of a Map, such as the
+// position of a player.
+type MapMarker struct {
+	// Type is the type of the marker.
+	Type MapMarkerType
+	// X and Y are the position of the marker relative to the centre of the
+	// Map. They range from -128 to 127, which is twice the resolution of the
+	// pixels of the Map.
+	X, Y int8
+	// Rotation is the rotation of the marker in 16ths of a full rotation.
+	Rotation uint8
+}
+
+// NewMap creates a new Map in the World with the scale passed, which must be
+// in the range 0-MaxMapScale. Like in vanilla, the centre of the Map is
+// aligned to a grid of the size of the Map, so that maps of the same scale
+// created close to each other show the same area.
+func (w *World) NewMap(pos cube.Pos, scale int) *Map {
+	scale = max(0, min(scale, MaxMapScale))
+	size := MapSize << scale
+	align := func(v int) int {
+		return int(math.Floor(float64(v+MapSize/2)/float64(size)))*size + size/2 - MapSize/2
+	}
+	m := &Map{centre: cube.Pos{align(pos[0]), 0, align(pos[2])}, scale: scale, dim: w.Dimension(), version: 1}
+
+	w.mapMu.Lock()
+	defer w.mapMu.Unlock()
+	if w.maps == nil {
+		w.maps = make(map[int64]*Map)
+	}
+	for {
+		// Map IDs are shared by all worlds and are cached by clients, so they
+		// are chosen randomly rather than counted up.
+		if m.id = rand.Int64(); m.id == 0 || w.maps[m.id] != nil {
+			continue
+		}
+		w.maps[m.id] = m
+		return m
+	}
+}
+
+// Map returns the Map with the ID passed, as found in filled map items. False
+// is returned if no Map with the ID was created in the World.
+func (w *World) Map(id int64) (*Map, bool) {
+	if w == nil {
+		return nil, false
+	}
+	w.mapMu.Lock()
+	defer w.mapMu.Unlock()
+	m, ok := w.maps[id]
+	return m, ok
+}
+
+// ID returns the unique ID of the Map.
+func (m *Map) ID() int64 {
+	return m.id
+}
+
+// Centre returns the block position at the centre of the area shown by the
+// Map. Its Y value is always 0.
+func (m *Map) Centre() cube.Pos {
+	return m.centre
+}
+
+// Scale returns the scale of the Map. Every pixel of the Map covers 2^scale by
+// 2^scale blocks.
+func (m *Map) Scale() int {
+	return m.scale
+}
+
+// Dimension returns the Dimension of the World that the Map was created in.
+func (m *Map) Dimension() Dimension {
+	return m.dim
+}
+
+// Locked checks if the Map is locked. The World is no longer drawn onto a
+// locked Map, so that custom images drawn onto it are kept.
+func (m *Map) Locked() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.locked
+}
+
+// Lock locks or unlocks the Map. The World is not drawn onto a locked Map.
+func (m *Map) Lock(locked bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.locked = locked
+}
+
+// Pixel returns the colour of the pixel of the Map at x and y. Pixels that
+// were not yet drawn are fully transparent.
+func (m *Map) Pixel(x, y int) color.RGBA {
+	if x < 0 || y < 0 || x >= MapSize || y >= MapSize {
+		return color.RGBA{}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.pixels[y*MapSize+x]
+}
+
+// SetPixel changes the colour of the pixel of the Map at x and y. Pixels
+// outside the Map are ignored.
+func (m *Map) SetPixel(x, y int, c color.RGBA) {
+	if x < 0 || y < 0 || x >= MapSize || y >= MapSize {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.setPixel(x, y, c)
+}
+
+// DrawImage draws the image passed onto the Map, with the top left corner of
+// its bounds placed at the pixel at pos. Parts of the image that fall outside
+// the Map are cut off. DrawImage may be used in combination with Lock to show
+// custom images on a Map.
+func (m *Map) DrawImage(img image.Image, pos image.Point) {
+	b := img.Bounds()
+	area := image.Rect(0, 0, MapSize, MapSize).Intersect(b.Sub(b.Min).Add(pos))
+	converted := image.NewRGBA(area)
+	draw.Draw(converted, area, img, b.Min.Add(area.Min.Sub(pos)), draw.Src)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			m.setPixel(x, y, converted.RGBAAt(x, y))
+		}
+	}
+}
+
+// Image returns an image holding all pixels of the Map.
+func (m *Map) Image() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, MapSize, MapSize))
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, c := range m.pixels {
+		img.SetRGBA(i%MapSize, i/MapSize, c)
+	}
+	return img
+}
+
+// Version returns the current version of the pixels of the Map. The version
+// starts at 1, increases every time a pixel changes and may be passed to
+// Changes.
+func (m *Map) Version() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.version
+}
+
+// Changes returns a MapUpdate holding the smallest area that contains all
+// pixels changed after the version passed, together with the current
+// version. Passing a version of 0 returns an update of the entire Map.
+func (m *Map) Changes(since uint64) (MapUpdate, uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if since == 0 {
+		return MapUpdate{Area: image.Rect(0, 0, MapSize, MapSize), Pixels: append([]color.RGBA(nil), m.pixels[:]...)}, m.version
+	}
+	var area image.Rectangle
+	for i, v := range m.versions {
+		if v > since {
+			area = area.Union(image.Rect(i%MapSize, i/MapSize, i%MapSize+1, i/MapSize+1))
+		}
+	}
+	update := MapUpdate{Area: area, Pixels: make([]color.RGBA, 0, area.Dx()*area.Dy())}
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		update.Pixels = append(update.Pixels, m.pixels[y*MapSize+area.Min.X:y*MapSize+area.Max.X]...)
+	}
+	return update, m.version
+}
+
+// setPixel sets the pixel at x and y to c and marks it as changed if c differs
+// from its current colour. m.mu must be held when calling setPixel.
+func (m *Map) setPixel(x, y int, c color.RGBA) {
+	i := y*MapSize + x
+	if m.pixels[i] == c {
+		return
+	}
+	m.version++
+	m.pixels[i], m.versions[i] = c, m.version
+}
+
+// mapExploreColumns is the amount of calls to Map.Explore it takes to draw
+// every column of pixels around a position once. Like in vanilla, only one in
+// every mapExploreColumns columns is drawn per call, to spread the cost of
+// drawing a Map over multiple ticks.
+const mapExploreColumns = 16
+
+// Explore draws the area of the World surrounding pos onto the Map, as done
+// for players holding the Map. Only pixels within 128 blocks of pos whose
+// chunks are loaded are drawn, and only one in every 16 columns of pixels is
+// drawn per call, so that the full area is drawn after 16 calls. Nothing is
+// drawn if the Map is locked or if the World of the Tx is of a different
+// Dimension than the Map.
+// Every pixel shows the colour of the highest block implementing MapColourer
+// at the centre of the blocks it covers, shaded depending on its height
+// relative to the pixel north of it, or, for water, depending on the depth of
+// the water.
+func (m *Map) Explore(tx *Tx, pos mgl64.Vec3) {
+	if m.Locked() || tx.World().Dimension() != m.dim {
+		return
+	}
+	m.mu.Lock()
+	column := m.explore
+	m.explore = (m.explore + 1) % mapExploreColumns
+	m.mu.Unlock()
+
+	s := 1 << m.scale
+	minX, minZ := m.centre[0]-MapSize/2*s, m.centre[2]-MapSize/2*s
+	px, pz := (int(math.Floor(pos[0]))-minX)/s, (int(math.Floor(pos[2]))-minZ)/s
+	radius := MapSize / s
+
+	for x := max(0, px-radius+1); x < min(MapSize, px+radius); x++ {
+		if x%mapExploreColumns != column {
+			continue
+		}
+		blockX := minX + x*s + s/2
+		for z := max(0, pz-radius+1); z < min(MapSize, pz+radius); z++ {
+			if dx, dz := x-px, z-pz; dx*dx+dz*dz >= (radius-2)*(radius-2) {
+				continue
+			}
+			blockZ := minZ + z*s + s/2
+			c, height, water, ok := m.sample(tx, blockX, blockZ)
+			if !ok {
+				continue
+			}
+			if water {
+				m.SetPixel(x, z, mapWaterShade(c, height, x+z))
+				continue
+			}
+			northHeight := height
+			if _, h, _, ok := m.sample(tx, blockX, blockZ-s); ok {
+				northHeight = h
+			}
+			m.SetPixel(x, z, mapShade(c, height-northHeight))
+		}
+	}
+}
+
+// sample returns the base colour drawn at the x and z passed and the height of
+// the block. If the block is water, true is returned and the height returned
+// is the depth of the water. False is returned if the chunk of the column is
+// not loaded or if no block in the column implements MapColourer.
+func (m *Map) sample(tx *Tx, x, z int) (c color.RGBA, height int, water bool, ok bool) {
+	w := tx.World()
+	if _, ok := w.chunks[chunkPosFromBlockPos(cube.Pos{x, 0, z})]; !ok {
+		return c, 0, false, false
+	}
+	for y := w.highestBlock(x, z); y >= w.Range()[0]; y-- {
+		b, ok := w.block(cube.Pos{x, y, z}).(MapColourer)
+		if !ok {
+			continue
+		}
+		if l, ok := b.(Liquid); !ok || l.LiquidType() != "water" {
+			return b.MapColour(), y, false, true
+		}
+		// Water is drawn darker the deeper it is, so the depth of the water
+		// is returned rather than its height.
+		depth := 1
+		for ; depth < 16 && y-depth >= w.Range()[0]; depth++ {
+			if l, ok := w.block(cube.Pos{x, y - depth, z}).(Liquid); !ok || l.LiquidType() != "water" {
+				break
+			}
+		}
+		return b.MapColour(), depth, true, true
+	}
+	return c, 0, false, false
+}
+
+// mapShade shades the colour passed depending on the height difference with
+// the pixel north of it: Higher blocks are drawn brighter, lower blocks
+// darker.
+func mapShade(c color.RGBA, diff int) color.RGBA {
+	switch {
+	case diff > 0:
+		return mapBrightness(c, 255)
+	case diff < 0:
+		return mapBrightness(c, 180)
+	}
+	return mapBrightness(c, 220)
+}
+
+// mapWaterShade shades the colour of water passed depending on its depth. The
+// parity passed alternates the shade of neighbouring pixels for depths in
+// between two shades, creating a checkerboard pattern.
+func mapWaterShade(c color.RGBA, depth, parity int) color.RGBA {
+	v := float64(depth)*0.1 + float64(parity&1)*0.2
+	switch {
+	case v < 0.5:
+		return mapBrightness(c, 255)
+	case v > 0.9:
+		return mapBrightness(c, 180)
+	}
+	return mapBrightness(c, 220)
+}
+
+// mapBrightness multiplies the RGB channels of the colour passed by
+// brightness/255.
+func mapBrightness(c color.RGBA, brightness uint32) color.RGBA {
+	return color.RGBA{
+		R: uint8(uint32(c.R) * brightness / 255),
+		G: uint8(uint32(c.G) * brightness / 255),
+		B: uint8(uint32(c.B) * brightness / 255),
+		A: 0xff,
+	}
+}
+
+// PlayerMarker returns the MapMarker of a player at the position passed,
+// facing the yaw passed. Players outside the area shown by the Map are shown
+// at the edge of the Map using a MapMarkerPlayerOffMap marker.
+func (m *Map) PlayerMarker(pos mgl64.Vec3, yaw float64) MapMarker {
+	s := float64(int(1) << m.scale)
+	x := (pos[0] - float64(m.centre[0])) / s * 2
+	y := (pos[2] - float64(m.centre[2])) / s * 2
+	if x < -128 || x >= 128 || y < -128 || y >= 128 {
+		return MapMarker{
+			Type: MapMarkerPlayerOffMap,
+			X:    int8(mgl64.Clamp(x, -128, 127)),
+			Y:    int8(mgl64.Clamp(y, -128, 127)),
+		}
+	}
+	rot := math.Mod(yaw, 360)
+	if rot < 0 {
+		rot += 360
+	}
+	return MapMarker{
+		Type:     MapMarkerPlayer,
+		X:        int8(math.Floor(x)),
+		Y:        int8(math.Floor(y)),
+		Rotation: uint8(math.Round(rot*16/360)) & 15,
+	}
+}
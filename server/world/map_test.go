@@ -0,0 +1,88 @@
+package world
+
+import (
+	"image"
+	"image/color"
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestMapDrawImageChanges(t *testing.T) {
+	w := Config{Synchronous: true}.New()
+	defer w.Close()
+
+	m := w.NewMap(cube.Pos{}, 0)
+	if got, ok := w.Map(m.ID()); !ok || got != m {
+		t.Fatal("expected map to be found by its ID")
+	}
+	full, version := m.Changes(0)
+	if full.Area != image.Rect(0, 0, MapSize, MapSize) || len(full.Pixels) != MapSize*MapSize {
+		t.Fatalf("initial update covers %v with %v pixels, want the full map", full.Area, len(full.Pixels))
+	}
+
+	red, blue := color.RGBA{R: 0xff, A: 0xff}, color.RGBA{B: 0xff, A: 0xff}
+	img := image.NewRGBA(image.Rect(5, 5, 7, 8))
+	for y := 5; y < 8; y++ {
+		img.SetRGBA(5, y, red)
+		img.SetRGBA(6, y, blue)
+	}
+	m.DrawImage(img, image.Pt(10, 20))
+	update, newVersion := m.Changes(version)
+	if update.Area != image.Rect(10, 20, 12, 23) {
+		t.Fatalf("changed area = %v, want the area of the image", update.Area)
+	}
+	if want := []color.RGBA{red, blue, red, blue, red, blue}; !slices.Equal(update.Pixels, want) {
+		t.Errorf("changed pixels = %v, want %v", update.Pixels, want)
+	}
+
+	// Drawing the same image again changes no pixels, so there are no
+	// changes to send.
+	m.DrawImage(img, image.Pt(10, 20))
+	if update, _ := m.Changes(newVersion); !update.Area.Empty() || len(update.Pixels) != 0 {
+		t.Errorf("update after redrawing the same image = %v, want no changes", update)
+	}
+
+	// Changing a single pixel and drawing an image cut off at the edge of the
+	// map results in an update covering both.
+	m.SetPixel(0, 0, blue)
+	m.DrawImage(img, image.Pt(MapSize-1, MapSize-2))
+	update, _ = m.Changes(newVersion)
+	if update.Area != image.Rect(0, 0, MapSize, MapSize) || len(update.Pixels) != MapSize*MapSize {
+		t.Fatalf("changed area = %v with %v pixels, want the full map", update.Area, len(update.Pixels))
+	}
+	if m.Pixel(0, 0) != blue || m.Pixel(MapSize-1, MapSize-2) != red || m.Pixel(MapSize-1, MapSize-1) != red {
+		t.Error("expected pixels to be set by SetPixel and DrawImage")
+	}
+	if m.Pixel(MapSize-2, MapSize-1) != (color.RGBA{}) {
+		t.Error("expected pixels left of the image to be left untouched")
+	}
+}
+
+func TestMapPlayerMarker(t *testing.T) {
+	w := Config{Synchronous: true}.New()
+	defer w.Close()
+
+	m := w.NewMap(cube.Pos{200, 64, 200}, 1)
+	if c := m.Centre(); c != (cube.Pos{320, 0, 320}) {
+		t.Fatalf("centre of map = %v, want 320, 0, 320", c)
+	}
+	tests := []struct {
+		name string
+		pos  mgl64.Vec3
+		yaw  float64
+		want MapMarker
+	}{
+		{name: "centre", pos: mgl64.Vec3{320, 64, 320}, want: MapMarker{Type: MapMarkerPlayer}},
+		{name: "east facing west", pos: mgl64.Vec3{352, 64, 320}, yaw: 90, want: MapMarker{Type: MapMarkerPlayer, X: 32, Rotation: 4}},
+		{name: "north west facing north", pos: mgl64.Vec3{256, 64, 192}, yaw: -180, want: MapMarker{Type: MapMarkerPlayer, X: -64, Y: -128, Rotation: 8}},
+		{name: "off map", pos: mgl64.Vec3{1000, 64, 320}, yaw: 45, want: MapMarker{Type: MapMarkerPlayerOffMap, X: 127}},
+	}
+	for _, test := range tests {
+		if marker := m.PlayerMarker(test.pos, test.yaw); marker != test.want {
+			t.Errorf("%v: marker = %+v, want %+v", test.name, marker, test.want)
+		}
+	}
+}
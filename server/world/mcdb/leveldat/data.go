@@ -170,7 +170,7 @@ func (d *Data) FillDefault() {
 	d.Abilities.VerticalFlySpeed = 1.0
 	d.BaseGameVersion = "*"
 	d.CommandBlockOutput = true
-	d.CommandBlocksEnabled = true
+	d.CommandBlocksEnabled = false
 	d.CommandsEnabled = true
 	d.Difficulty = 2
 	d.DoDayLightCycle = true
@@ -235,6 +235,7 @@ func (d *Data) Settings() *world.Settings {
 	mode, _ := world.GameModeByID(int(d.GameType))
 	return &world.Settings{
 		Name:            d.LevelName,
+		Seed:            d.RandomSeed,
 		Spawn:           cube.Pos{int(d.SpawnX), int(d.SpawnY), int(d.SpawnZ)},
 		Time:            d.Time,
 		TimeCycle:       d.DoDayLightCycle,
@@ -245,14 +246,25 @@ func (d *Data) Settings() *world.Settings {
 		WeatherCycle:    d.DoWeatherCycle,
 		CurrentTick:     d.CurrentTick,
 		DefaultGameMode: mode,
+		ForceGameMode:   d.ForceGameType,
 		Difficulty:      difficulty,
 		TickRange:       d.ServerChunkTickRange,
+
+		CommandBlocksEnabled:  d.CommandBlocksEnabled,
+		MaxCommandChainLength: d.MaxCommandChainLength,
+		FunctionCommandLimit:  d.FunctionCommandLimit,
+		PvP:                   d.PVP,
+		FireTick:              d.DoFireTick,
+		Insomnia:              d.DoInsomnia,
+		MobGriefing:           d.MobGriefing,
+		SpawnRadius:           d.SpawnRadius,
 	}
 }
 
 // PutSettings updates d with the Settings stored in s.
 func (d *Data) PutSettings(s *world.Settings) {
 	d.LevelName = s.Name
+	d.RandomSeed = s.Seed
 	d.SpawnX, d.SpawnY, d.SpawnZ = int32(s.Spawn.X()), int32(s.Spawn.Y()), int32(s.Spawn.Z())
 	d.LimitedWorldOriginX, d.LimitedWorldOriginY, d.LimitedWorldOriginZ = d.SpawnX, d.SpawnY, d.SpawnZ
 	d.Time = s.Time
@@ -268,8 +280,17 @@ func (d *Data) PutSettings(s *world.Settings) {
 	}
 	d.CurrentTick = s.CurrentTick
 	d.ServerChunkTickRange = s.TickRange
+	d.CommandBlocksEnabled = s.CommandBlocksEnabled
+	d.MaxCommandChainLength = s.MaxCommandChainLength
+	d.FunctionCommandLimit = s.FunctionCommandLimit
+	d.PVP = s.PvP
+	d.DoFireTick = s.FireTick
+	d.DoInsomnia = s.Insomnia
+	d.MobGriefing = s.MobGriefing
+	d.SpawnRadius = s.SpawnRadius
 	mode, _ := world.GameModeID(s.DefaultGameMode)
 	d.GameType = int32(mode)
+	d.ForceGameType = s.ForceGameMode
 	difficulty, _ := world.DifficultyID(s.Difficulty)
 	d.Difficulty = int32(difficulty)
 }
@@ -0,0 +1,219 @@
+package world
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// ObjectiveCriteria is the criteria of an Objective. It decides which events
+// change the scores of the Objective, other than scores set directly.
+type ObjectiveCriteria int
+
+const (
+	// ObjectiveCriteriaDummy is the criteria of objectives whose scores are
+	// only ever changed directly, such as by commands.
+	ObjectiveCriteriaDummy ObjectiveCriteria = iota
+	// ObjectiveCriteriaDeathCount is the criteria of objectives whose scores
+	// are incremented every time a player dies.
+	ObjectiveCriteriaDeathCount
+)
+
+// String returns the name of the ObjectiveCriteria as used in commands, such
+// as 'dummy'.
+func (c ObjectiveCriteria) String() string {
+	if c == ObjectiveCriteriaDeathCount {
+		return "deathCount"
+	}
+	return "dummy"
+}
+
+// Objectives is a set of scoreboard Objectives that track integer scores of
+// entities. Objectives is set for a World using Config.Objectives and may be
+// shared between multiple worlds, so that entities keep their scores when
+// changing worlds.
+// Objectives is safe for concurrent use.
+type Objectives struct {
+	mu         sync.RWMutex
+	objectives map[string]*Objective
+}
+
+// NewObjectives creates an empty Objectives.
+func NewObjectives() *Objectives {
+	return &Objectives{objectives: make(map[string]*Objective)}
+}
+
+// Create creates a new Objective with the name, display name and criteria
+// passed. If an Objective with the name already exists, it is returned and
+// false is returned.
+func (o *Objectives) Create(name, displayName string, criteria ObjectiveCriteria) (*Objective, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if objective, ok := o.objectives[name]; ok {
+		return objective, false
+	}
+	objective := &Objective{name: name, displayName: displayName, criteria: criteria, scores: make(map[uuid.UUID]ObjectiveScore)}
+	o.objectives[name] = objective
+	return objective, true
+}
+
+// Objective looks up the Objective with the name passed. False is returned if
+// no Objective with the name exists.
+func (o *Objectives) Objective(name string) (*Objective, bool) {
+	if o == nil {
+		return nil, false
+	}
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	objective, ok := o.objectives[name]
+	return objective, ok
+}
+
+// All returns all Objectives, sorted by name.
+func (o *Objectives) All() []*Objective {
+	if o == nil {
+		return nil
+	}
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	objectives := make([]*Objective, 0, len(o.objectives))
+	for _, objective := range o.objectives {
+		objectives = append(objectives, objective)
+	}
+	slices.SortFunc(objectives, func(a, b *Objective) int {
+		return cmp.Compare(a.name, b.name)
+	})
+	return objectives
+}
+
+// Remove removes the Objective with the name passed, along with all of its
+// scores.
+func (o *Objectives) Remove(name string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.objectives, name)
+}
+
+// Score returns the score of the entity with the UUID passed for the
+// Objective with the name passed. False is returned if the Objective does not
+// exist or if the entity has no score for it.
+func (o *Objectives) Score(objective string, id uuid.UUID) (int, bool) {
+	obj, ok := o.Objective(objective)
+	if !ok {
+		return 0, false
+	}
+	return obj.Score(id)
+}
+
+// AddCriteriaScore adds delta to the score of the Entity passed for all
+// Objectives with the ObjectiveCriteria passed.
+func (o *Objectives) AddCriteriaScore(e Entity, criteria ObjectiveCriteria, delta int) {
+	for _, objective := range o.All() {
+		if objective.criteria == criteria {
+			objective.AddScore(e, delta)
+		}
+	}
+}
+
+// ObjectiveScore is the score of an entity for an Objective.
+type ObjectiveScore struct {
+	// ID is the UUID of the entity that holds the score.
+	ID uuid.UUID
+	// Name is the name of the entity at the time its score was last changed.
+	// It is used to display the score on scoreboards.
+	Name string
+	// Score is the integer score of the entity.
+	Score int
+}
+
+// Objective is a scoreboard objective that tracks an integer score for every
+// entity that has a score for it. Scores of an Objective may be used to
+// filter target selectors and may be displayed on scoreboards.
+// Objective is safe for concurrent use.
+type Objective struct {
+	name, displayName string
+	criteria          ObjectiveCriteria
+
+	mu     sync.RWMutex
+	scores map[uuid.UUID]ObjectiveScore
+}
+
+// Name returns the name of the Objective, which is used to refer to it in
+// commands and target selectors.
+func (o *Objective) Name() string {
+	return o.name
+}
+
+// DisplayName returns the name that the Objective is displayed with on
+// scoreboards.
+func (o *Objective) DisplayName() string {
+	return o.displayName
+}
+
+// Criteria returns the ObjectiveCriteria of the Objective.
+func (o *Objective) Criteria() ObjectiveCriteria {
+	return o.criteria
+}
+
+// Score returns the score of the entity with the UUID passed. False is
+// returned if the entity has no score for the Objective.
+func (o *Objective) Score(id uuid.UUID) (int, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	s, ok := o.scores[id]
+	return s.Score, ok
+}
+
+// SetScore sets the score of the Entity passed to score.
+func (o *Objective) SetScore(e Entity, score int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.scores[e.H().UUID()] = ObjectiveScore{ID: e.H().UUID(), Name: scoreName(e), Score: score}
+}
+
+// AddScore adds delta to the score of the Entity passed and returns the new
+// score. An Entity without a score for the Objective starts at a score of 0.
+func (o *Objective) AddScore(e Entity, delta int) int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	score := o.scores[e.H().UUID()].Score + delta
+	o.scores[e.H().UUID()] = ObjectiveScore{ID: e.H().UUID(), Name: scoreName(e), Score: score}
+	return score
+}
+
+// ResetScore removes the score of the entity with the UUID passed from the
+// Objective.
+func (o *Objective) ResetScore(id uuid.UUID) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.scores, id)
+}
+
+// Scores returns all scores of the Objective, sorted from the highest score
+// to the lowest. Equal scores are sorted by name.
+func (o *Objective) Scores() []ObjectiveScore {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	scores := make([]ObjectiveScore, 0, len(o.scores))
+	for _, s := range o.scores {
+		scores = append(scores, s)
+	}
+	slices.SortFunc(scores, func(a, b ObjectiveScore) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.Name, b.Name))
+	})
+	return scores
+}
+
+// scoreName returns the name that the score of the Entity passed is displayed
+// with: The name of a player or the name tag of other entities.
+func scoreName(e Entity) string {
+	if named, ok := e.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	if tagged, ok := e.(interface{ NameTag() string }); ok && tagged.NameTag() != "" {
+		return tagged.NameTag()
+	}
+	return e.H().UUID().String()
+}
@@ -0,0 +1,76 @@
+package world
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+func TestObjectiveScores(t *testing.T) {
+	objectives := NewObjectives()
+	points, created := objectives.Create("points", "Points", ObjectiveCriteriaDummy)
+	if !created {
+		t.Fatal("expected objective to be created")
+	}
+	if _, created := objectives.Create("points", "Other", ObjectiveCriteriaDummy); created {
+		t.Fatal("expected existing objective not to be created again")
+	}
+	deaths, _ := objectives.Create("deaths", "Deaths", ObjectiveCriteriaDeathCount)
+
+	a, b := newTeamTestEntity(testPlayerType{}), newTeamTestEntity(testEntityType{})
+	if _, ok := points.Score(a.H().UUID()); ok {
+		t.Fatal("expected entity without score to have no score")
+	}
+	points.SetScore(a, 5)
+	if score := points.AddScore(a, 3); score != 8 {
+		t.Errorf("score after adding 3 to 5 = %v, want 8", score)
+	}
+	if score := points.AddScore(b, -2); score != -2 {
+		t.Errorf("score after adding -2 to no score = %v, want -2", score)
+	}
+	if score, ok := objectives.Score("points", a.H().UUID()); !ok || score != 8 {
+		t.Errorf("score looked up through objectives = %v, %v, want 8, true", score, ok)
+	}
+	if _, ok := objectives.Score("unknown", a.H().UUID()); ok {
+		t.Error("expected no score for unknown objective")
+	}
+	if scores := points.Scores(); len(scores) != 2 || scores[0].ID != a.H().UUID() || scores[1].Score != -2 {
+		t.Errorf("scores = %v, want scores sorted from highest to lowest", scores)
+	}
+
+	objectives.AddCriteriaScore(a, ObjectiveCriteriaDeathCount, 1)
+	if score, _ := deaths.Score(a.H().UUID()); score != 1 {
+		t.Errorf("death count = %v, want 1", score)
+	}
+	if score, _ := points.Score(a.H().UUID()); score != 8 {
+		t.Errorf("dummy score changed by criteria to %v, want 8", score)
+	}
+
+	points.ResetScore(a.H().UUID())
+	if _, ok := points.Score(a.H().UUID()); ok {
+		t.Error("expected reset score to be removed")
+	}
+	objectives.Remove("points")
+	if all := objectives.All(); len(all) != 1 || all[0] != deaths {
+		t.Errorf("objectives after removal = %v, want only deaths", all)
+	}
+}
+
+func TestEntityTagsNBT(t *testing.T) {
+	h := EntitySpawnOpts{}.New(testEntityType{}, testEntityConfig{})
+	h.data.Tags = []string{"red", "spawned"}
+
+	b, err := nbt.Marshal(h.encodeNBT())
+	if err != nil {
+		t.Fatalf("encode entity: %v", err)
+	}
+	var m map[string]any
+	if err := nbt.Unmarshal(b, &m); err != nil {
+		t.Fatalf("decode entity: %v", err)
+	}
+	decoded := entityFromData(testEntityType{}, 1, m)
+	if !slices.Equal(decoded.data.Tags, []string{"red", "spawned"}) {
+		t.Errorf("tags after saving and loading = %v, want [red spawned]", decoded.data.Tags)
+	}
+}
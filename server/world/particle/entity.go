@@ -32,3 +32,6 @@ type Effect struct {
 
 // EntityFlame is a particle shown when an entity is set on fire.
 type EntityFlame struct{ particle }
+
+// Bubble is a particle shown in water, such as when a fish bites a fishing hook.
+type Bubble struct{ particle }
@@ -166,6 +166,27 @@ func TestFireChargeActivatesNetherPortal(t *testing.T) {
 	})
 }
 
+func TestFlintAndSteelOnInvalidFramePlacesFire(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	origin := cube.Pos{8, 10, 8}
+	mustDo(t, w, func(tx *world.Tx) {
+		buildVerticalFrame(tx, origin, cube.Z, 2, 3)
+		tx.SetBlock(origin.Add(cube.Pos{0, 3, 1}), nil, nil)
+
+		if ok := (item.FlintAndSteel{}).UseOnBlock(origin.Side(cube.FaceDown), cube.FaceUp, cube.Pos{}.Vec3(), tx, nil, &item.UseContext{}); !ok {
+			t.Fatal("FlintAndSteel.UseOnBlock() = false, want true")
+		}
+		if _, ok := tx.Block(origin).(block.Fire); !ok {
+			t.Fatalf("block lit in invalid frame = %T, want block.Fire", tx.Block(origin))
+		}
+		if _, ok := tx.Block(origin.Add(cube.Pos{0, 1, 1})).(block.Portal); ok {
+			t.Fatal("FlintAndSteel.UseOnBlock() activated a portal in an invalid frame")
+		}
+	})
+}
+
 func TestActivatedPortalCleanupOnBrokenFrame(t *testing.T) {
 	w := world.Config{Synchronous: true}.New()
 	t.Cleanup(func() { _ = w.Close() })
@@ -30,7 +30,7 @@ func (minimalRedstoneTestHandler) HandleCropTrample(*Context, cube.Pos)
 func (minimalRedstoneTestHandler) HandleLeavesDecay(*Context, cube.Pos)                         {}
 func (minimalRedstoneTestHandler) HandleEntitySpawn(*Tx, Entity)                                {}
 func (minimalRedstoneTestHandler) HandleEntityDespawn(*Tx, Entity)                              {}
-func (minimalRedstoneTestHandler) HandleExplosion(*Context, mgl64.Vec3, *[]Entity, *[]cube.Pos, *float64, *bool) {
+func (minimalRedstoneTestHandler) HandleExplosion(*Context, mgl64.Vec3, *[]ExplosionImpact, *[]cube.Pos, *float64, *bool) {
 }
 func (minimalRedstoneTestHandler) HandleClose(*Tx) {}
 
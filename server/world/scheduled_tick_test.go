@@ -0,0 +1,86 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+func TestScheduledTickBudgetRoundRobin(t *testing.T) {
+	w := Config{Synchronous: true, ScheduledTickBudget: 3}.New()
+	defer w.Close()
+
+	a, b, c := ChunkPos{0, 0}, ChunkPos{1, 0}, ChunkPos{2, 0}
+	queue := newScheduledTickQueue(0)
+	// Chunk a has all of its updates scheduled before those of the other
+	// chunks, so it would be drained first without a budget.
+	for i := range 8 {
+		queue.ticks = append(queue.ticks, scheduledTick{pos: cube.Pos{i, 4, 0}, t: 1, bhash: 1})
+	}
+	for i := range 2 {
+		queue.ticks = append(queue.ticks, scheduledTick{pos: cube.Pos{16 + i, 4, 0}, t: 1, bhash: 1})
+	}
+	queue.ticks = append(queue.ticks, scheduledTick{pos: cube.Pos{32, 4, 0}, t: 1, bhash: 1})
+
+	remaining := func() map[ChunkPos]int {
+		m := map[ChunkPos]int{}
+		for _, t := range queue.ticks {
+			m[chunkPosFromBlockPos(t.pos)]++
+		}
+		return m
+	}
+	want := []map[ChunkPos]int{
+		{a: 7, b: 1},
+		{a: 5},
+		{a: 2},
+		{},
+	}
+	for tick, want := range want {
+		<-w.exec(func(tx *Tx) {
+			queue.tick(tx, int64(tick+1))
+		})
+		got := remaining()
+		for _, pos := range []ChunkPos{a, b, c} {
+			if got[pos] != want[pos] {
+				t.Errorf("tick %v: updates left in chunk %v = %v, want %v", tick+1, pos, got[pos], want[pos])
+			}
+		}
+	}
+}
+
+func TestScheduledTickBudgetDeferredFirst(t *testing.T) {
+	w := Config{Synchronous: true, ScheduledTickBudget: 2}.New()
+	defer w.Close()
+
+	queue := newScheduledTickQueue(0)
+	for i := range 4 {
+		queue.ticks = append(queue.ticks, scheduledTick{pos: cube.Pos{i * 16, 4, 0}, t: 1, bhash: 1})
+	}
+	<-w.exec(func(tx *Tx) {
+		queue.tick(tx, 1)
+	})
+	// Updates scheduled for the next tick in the chunks that were served
+	// must not be processed before the updates deferred from the last tick.
+	queue.ticks = append(queue.ticks, scheduledTick{pos: cube.Pos{1, 4, 0}, t: 2, bhash: 1})
+	queue.ticks = append(queue.ticks, scheduledTick{pos: cube.Pos{17, 4, 0}, t: 2, bhash: 1})
+	<-w.exec(func(tx *Tx) {
+		queue.tick(tx, 2)
+	})
+	if len(queue.ticks) != 2 {
+		t.Fatalf("updates left after two ticks = %v, want 2", len(queue.ticks))
+	}
+	for _, tick := range queue.ticks {
+		if tick.t != 2 {
+			t.Errorf("update scheduled for tick %v at %v was left in the queue, want deferred updates to be processed first", tick.t, tick.pos)
+		}
+	}
+
+	// Without a budget, all updates due are processed at once.
+	w.conf.ScheduledTickBudget = 0
+	<-w.exec(func(tx *Tx) {
+		queue.tick(tx, 3)
+	})
+	if len(queue.ticks) != 0 {
+		t.Errorf("updates left without a budget = %v, want 0", len(queue.ticks))
+	}
+}
@@ -1,6 +1,8 @@
 package world
 
 import (
+	"math"
+	"math/rand/v2"
 	"sync"
 	"sync/atomic"
 
@@ -15,6 +17,9 @@ type Settings struct {
 
 	// Name is the display name of the World.
 	Name string
+	// Seed is the seed of the World. It is used to place the Features of the World deterministically, so that
+	// chunks generated with the same seed have identical features.
+	Seed int64
 	// Spawn is the spawn position of the World. New players that join the world will be spawned here.
 	Spawn cube.Pos
 	// Time is the current time of the World. It advances every tick if TimeCycle is set to true.
@@ -38,22 +43,57 @@ type Settings struct {
 	CurrentTick int64
 	// DefaultGameMode is the GameMode assigned to players that join the World for the first time.
 	DefaultGameMode GameMode
+	// ForceGameMode specifies if players entering the World have their game mode changed to the DefaultGameMode,
+	// rather than keeping the game mode they had.
+	ForceGameMode bool
 	// Difficulty is the difficulty of the World. Behaviour of hunger, regeneration and monsters differs based on the
 	// difficulty of the world.
 	Difficulty Difficulty
 	// TickRange is the radius in chunks around a Viewer that has its blocks and entities ticked when the world is
 	// ticked. If set to 0, blocks and entities will never be ticked.
 	TickRange int32
+	// CommandBlocksEnabled specifies if command blocks in the World execute their commands when triggered.
+	// Command blocks run their commands with the permissions of game directors, so CommandBlocksEnabled is
+	// false by default.
+	CommandBlocksEnabled bool
+	// MaxCommandChainLength is the maximum number of chain command blocks that may be executed as a result of
+	// a single command block being triggered.
+	MaxCommandChainLength int32
+	// FunctionCommandLimit is the maximum number of command lines that may be executed by a single function,
+	// including the lines of functions run by that function.
+	FunctionCommandLimit int32
+	// PvP specifies if players in the World can damage other players.
+	PvP bool
+	// FireTick specifies if fire in the World spreads to and burns flammable blocks. If false, fire neither
+	// spreads nor burns out by itself.
+	FireTick bool
+	// Insomnia specifies if phantoms spawn around players in the World that have not slept for several days.
+	Insomnia bool
+	// MobGriefing specifies if entities other than players are able to change blocks in the World, such as
+	// by trampling farmland.
+	MobGriefing bool
+	// SpawnRadius is the radius in blocks around the Spawn within which players without a spawn position of
+	// their own are spawned. If 0, these players are spawned at the Spawn exactly.
+	SpawnRadius int32
 }
 
 // defaultSettings returns the default Settings for a new World.
 func defaultSettings() *Settings {
 	return &Settings{
 		Name:            "World",
+		Seed:            rand.Int64(),
 		DefaultGameMode: GameModeSurvival,
 		Difficulty:      DifficultyNormal,
 		TimeCycle:       true,
 		WeatherCycle:    true,
 		TickRange:       6,
+
+		CommandBlocksEnabled:  false,
+		MaxCommandChainLength: math.MaxUint16,
+		FunctionCommandLimit:  10000,
+		PvP:                   true,
+		FireTick:              true,
+		Insomnia:              true,
+		MobGriefing:           true,
 	}
 }
@@ -197,6 +197,12 @@ type PowerOn struct{ sound }
 // PowerOff is a sound played when a redstone component is powered off.
 type PowerOff struct{ sound }
 
+// PistonExtend is a sound played when a piston extends.
+type PistonExtend struct{ sound }
+
+// PistonRetract is a sound played when a piston retracts.
+type PistonRetract struct{ sound }
+
 // LecternBookPlace is a sound played when a book is placed in a lectern.
 type LecternBookPlace struct{ sound }
 
@@ -228,6 +234,27 @@ type EnderEyePlaced struct{ sound }
 // EndPortalCreated is a sound played when a complete end portal frame ring is activated.
 type EndPortalCreated struct{ sound }
 
+// PortalTrigger is a sound played when an entity starts waiting inside a nether portal to travel through it.
+type PortalTrigger struct{ sound }
+
+// PortalTravel is a sound played when an entity arrives in another dimension after travelling through a portal.
+type PortalTravel struct{ sound }
+
+// SculkSensorPowerOn is a sound played when a sculk sensor detects a vibration and starts emitting redstone power.
+type SculkSensorPowerOn struct{ sound }
+
+// SculkSensorPowerOff is a sound played when a sculk sensor stops emitting redstone power.
+type SculkSensorPowerOff struct{ sound }
+
+// RespawnAnchorCharge is a sound played when a respawn anchor is charged using glowstone.
+type RespawnAnchorCharge struct{ sound }
+
+// RespawnAnchorSetSpawn is a sound played when a player sets its spawn point using a respawn anchor.
+type RespawnAnchorSetSpawn struct{ sound }
+
+// RespawnAnchorDeplete is a sound played when a player respawns at a respawn anchor, using up one of its charges.
+type RespawnAnchorDeplete struct{ sound }
+
 // sound implements the world.Sound interface.
 type sound struct{}
 
@@ -0,0 +1,16 @@
+package sound
+
+// Custom is a sound defined by a resource pack, played using the name of its sound event, such as
+// "mypack.ability.dash". It is positioned at the position that it is played at, so that viewers hear it
+// coming from that position and at a volume that depends on their distance to it.
+type Custom struct {
+	sound
+
+	// Name is the name of the sound event as defined in the sound definitions of the resource pack.
+	Name string
+	// Volume is the volume that the sound is played with. The distance from which the sound can be heard
+	// grows with its volume. A Volume of 0 plays the sound at a volume of 1.
+	Volume float64
+	// Pitch is the pitch that the sound is played with. A Pitch of 0 plays the sound at a pitch of 1.
+	Pitch float64
+}
@@ -1,5 +1,7 @@
 package sound
 
+import "github.com/df-mc/dragonfly/server/world"
+
 // Attack is a sound played when an entity, most notably a player, attacks another entity.
 type Attack struct {
 	// Damage specifies if the attack actually dealt damage to the other entity. If set to false, the sound
@@ -9,6 +11,52 @@ type Attack struct {
 	sound
 }
 
+// EntityAmbient is a sound occasionally played by a living entity, such as the groan of a zombie. The sound
+// played depends on the type of the entity.
+type EntityAmbient struct {
+	// EntityType is the encoded type of the entity, such as 'minecraft:zombie'.
+	EntityType string
+	// Baby specifies if the entity is a baby, which makes the sound higher pitched.
+	Baby bool
+
+	sound
+}
+
+// EntityHurt is a sound played when a living entity is hurt. The sound played depends on the type of the
+// entity.
+type EntityHurt struct {
+	// EntityType is the encoded type of the entity, such as 'minecraft:zombie'.
+	EntityType string
+	// Baby specifies if the entity is a baby, which makes the sound higher pitched.
+	Baby bool
+
+	sound
+}
+
+// EntityDeath is a sound played when a living entity dies. The sound played depends on the type of the
+// entity.
+type EntityDeath struct {
+	// EntityType is the encoded type of the entity, such as 'minecraft:zombie'.
+	EntityType string
+	// Baby specifies if the entity is a baby, which makes the sound higher pitched.
+	Baby bool
+
+	sound
+}
+
+// EntityStep is a sound played when a living entity walks over a block. The sound played depends on both
+// the type of the entity and the block.
+type EntityStep struct {
+	// EntityType is the encoded type of the entity, such as 'minecraft:zombie'.
+	EntityType string
+	// Block is the block that the entity walked over.
+	Block world.Block
+	// Baby specifies if the entity is a baby, which makes the sound higher pitched.
+	Baby bool
+
+	sound
+}
+
 // Drowning is a sound played when an entity is drowning in water.
 type Drowning struct{ sound }
 
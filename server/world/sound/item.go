@@ -32,6 +32,8 @@ type EquipItem struct {
 type BucketFill struct {
 	// Liquid is the liquid that the bucket is filled up with.
 	Liquid world.Liquid
+	// PowderSnow is true if the bucket was filled with powder snow instead of a liquid.
+	PowderSnow bool
 
 	sound
 }
@@ -40,6 +42,8 @@ type BucketFill struct {
 type BucketEmpty struct {
 	// Liquid is the liquid that the bucket places into the world.
 	Liquid world.Liquid
+	// PowderSnow is true if the bucket placed powder snow instead of a liquid.
+	PowderSnow bool
 
 	sound
 }
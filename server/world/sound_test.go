@@ -0,0 +1,53 @@
+package world_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestCustomSoundViewers(t *testing.T) {
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	near, far := &soundTestViewer{}, &soundTestViewer{}
+	pos := mgl64.Vec3{8.5, 64, 8.5}
+	played := sound.Custom{Name: "mypack.ability.dash", Volume: 2, Pitch: 1.5}
+	w.Do(func(tx *world.Tx) {
+		nearLoader, farLoader := world.NewLoader(2, w, near), world.NewLoader(2, w, far)
+		defer nearLoader.Close(tx)
+		defer farLoader.Close(tx)
+		farLoader.Move(tx, mgl64.Vec3{1000, 64, 1000})
+		nearLoader.Load(tx, 100)
+		farLoader.Load(tx, 100)
+
+		tx.PlaySound(pos, played)
+	}).Wait(context.Background())
+
+	if len(near.sounds) != 1 {
+		t.Fatalf("sounds received by viewer in range = %v, want 1", len(near.sounds))
+	}
+	if got := near.sounds[0]; got.s != played || got.pos != pos {
+		t.Errorf("sound received = %#v at %v, want %#v at %v", got.s, got.pos, played, pos)
+	}
+	if len(far.sounds) != 0 {
+		t.Errorf("sounds received by viewer out of range = %v, want 0", len(far.sounds))
+	}
+}
+
+type soundTestViewer struct {
+	world.NopViewer
+	sounds []soundTestEvent
+}
+
+type soundTestEvent struct {
+	pos mgl64.Vec3
+	s   world.Sound
+}
+
+func (v *soundTestViewer) ViewSound(pos mgl64.Vec3, s world.Sound) {
+	v.sounds = append(v.sounds, soundTestEvent{pos: pos, s: s})
+}
@@ -0,0 +1,94 @@
+package world
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// spawnAttempts is the number of random positions tried by RandomSpawn before
+// falling back to the world spawn.
+const spawnAttempts = 16
+
+// RandomSpawn returns a random position within the spawn radius of the world
+// spawn that is safe to stand on. If the spawn radius of the World is 0 or no
+// safe position could be found, the world spawn is returned.
+func (tx *Tx) RandomSpawn() cube.Pos {
+	w := tx.World()
+	spawn, radius := w.Spawn(), w.SpawnRadius()
+	if radius <= 0 {
+		return spawn
+	}
+	for range spawnAttempts {
+		x := spawn.X() + w.r.IntN(radius*2+1) - radius
+		z := spawn.Z() + w.r.IntN(radius*2+1) - radius
+		if pos, ok := tx.safeSurface(x, z); ok {
+			return pos
+		}
+	}
+	return spawn
+}
+
+// SpreadPositions returns n positions that are safe to stand on, spread over
+// the area within maxRange blocks of centre on both the X and Z axis. All
+// positions returned are at least minDistance blocks apart horizontally. The
+// positions are at the centre of the bottom of the block that is stood in.
+// SpreadPositions may be used to spread players or teams of players over an
+// area. An error is returned if not enough positions could be found.
+func (tx *Tx) SpreadPositions(centre mgl64.Vec2, n int, minDistance, maxRange float64) ([]mgl64.Vec3, error) {
+	if n < 0 || minDistance < 0 || maxRange < 0 {
+		return nil, fmt.Errorf("spread positions: n, min distance and max range must not be negative")
+	}
+	w := tx.World()
+	positions := make([]mgl64.Vec3, 0, n)
+	for attempts := 0; len(positions) < n; attempts++ {
+		if attempts >= n*1000 {
+			return nil, fmt.Errorf("spread positions: could only find %v of %v positions at least %v blocks apart within %v blocks of %v", len(positions), n, minDistance, maxRange, centre)
+		}
+		x := int(math.Floor(centre[0] + (w.r.Float64()*2-1)*maxRange))
+		z := int(math.Floor(centre[1] + (w.r.Float64()*2-1)*maxRange))
+		pos, ok := tx.safeSurface(x, z)
+		if !ok {
+			continue
+		}
+		candidate := pos.Vec3Middle()
+		if !spreadApart(positions, candidate, minDistance) {
+			continue
+		}
+		positions = append(positions, candidate)
+	}
+	return positions, nil
+}
+
+// spreadApart checks if the position passed is at least minDistance blocks
+// away horizontally from all positions passed.
+func spreadApart(positions []mgl64.Vec3, pos mgl64.Vec3, minDistance float64) bool {
+	for _, other := range positions {
+		if (mgl64.Vec2{pos[0] - other[0], pos[2] - other[2]}).Len() < minDistance {
+			return false
+		}
+	}
+	return true
+}
+
+// safeSurface returns the position on top of the highest block at the x and z
+// passed if it is safe to stand in. Positions on top of liquids or with
+// obstructing blocks where the feet or head of an entity would be are not safe.
+func (tx *Tx) safeSurface(x, z int) (cube.Pos, bool) {
+	ground := cube.Pos{x, tx.HighestBlock(x, z), z}
+	if ground.OutOfBounds(tx.Range()) || ground[1]+2 > tx.Range()[1] {
+		return cube.Pos{}, false
+	}
+	if _, ok := tx.Liquid(ground); ok || !tx.Block(ground).Model().FaceSolid(ground, cube.FaceUp, tx) {
+		return cube.Pos{}, false
+	}
+	feet := ground.Side(cube.FaceUp)
+	for _, pos := range [...]cube.Pos{feet, feet.Side(cube.FaceUp)} {
+		if _, ok := tx.Liquid(pos); ok || len(tx.Block(pos).Model().BBox(pos, tx)) != 0 {
+			return cube.Pos{}, false
+		}
+	}
+	return feet, true
+}
@@ -0,0 +1,95 @@
+package world
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// spawnTestWorld returns a World with a solid floor at y=63 between -32 and
+// 32 on the X and Z axis.
+func spawnTestWorld(t *testing.T) *World {
+	registry := NewBlockRegistry()
+	registry.RegisterBlockState(BlockState{Name: "test:solid_block", Properties: map[string]any{}})
+	registry.RegisterBlock(redstoneSolidBlock{})
+	registry.RegisterBlock(spawnTestAir{})
+	w := Config{Blocks: registry}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	spawnTestDo(t, w, func(tx *Tx) {
+		for pos := range cube.Range3D(cube.Pos{-32, 63, -32}, cube.Pos{32, 63, 32}) {
+			tx.SetBlock(pos, redstoneSolidBlock{}, nil)
+		}
+	})
+	return w
+}
+
+func spawnTestDo(t *testing.T, w *World, f func(tx *Tx)) {
+	t.Helper()
+	if err := w.Do(f).Wait(context.Background()); err != nil {
+		t.Fatalf("world task failed: %v", err)
+	}
+}
+
+func TestRandomSpawnWithinRadius(t *testing.T) {
+	w := spawnTestWorld(t)
+	spawn := cube.Pos{0, 64, 0}
+	w.SetSpawn(spawn)
+
+	spawnTestDo(t, w, func(tx *Tx) {
+		if pos := tx.RandomSpawn(); pos != spawn {
+			t.Errorf("random spawn without spawn radius was %v, want %v", pos, spawn)
+		}
+		w.SetSpawnRadius(10)
+		for range 100 {
+			pos := tx.RandomSpawn()
+			if abs(pos.X()) > 10 || abs(pos.Z()) > 10 || pos.Y() != 64 {
+				t.Errorf("random spawn %v is not on the floor within 10 blocks of %v", pos, spawn)
+			}
+		}
+	})
+}
+
+func TestSpreadPositionsMinDistance(t *testing.T) {
+	w := spawnTestWorld(t)
+	centre := mgl64.Vec2{0.5, 0.5}
+
+	spawnTestDo(t, w, func(tx *Tx) {
+		positions, err := tx.SpreadPositions(centre, 8, 5, 20)
+		if err != nil {
+			t.Errorf("spread positions: %v", err)
+		}
+		if len(positions) != 8 {
+			t.Errorf("spread %v positions, want 8", len(positions))
+		}
+		for i, a := range positions {
+			if math.Abs(a[0]-centre[0]) > 21 || math.Abs(a[2]-centre[1]) > 21 || a[1] != 64 {
+				t.Errorf("spread position %v is not on the floor within range of %v", a, centre)
+			}
+			for _, b := range positions[i+1:] {
+				if d := (mgl64.Vec2{a[0] - b[0], a[2] - b[2]}).Len(); d < 5 {
+					t.Errorf("spread positions %v and %v are %v blocks apart, want at least 5", a, b, d)
+				}
+			}
+		}
+
+		if _, err := tx.SpreadPositions(centre, 50, 20, 10); err == nil {
+			t.Error("expected an error spreading more positions than fit in the range")
+		}
+	})
+}
+
+// spawnTestAir is air that entities can stand in, unlike the unknown block
+// that air is decoded to in a registry without block implementations.
+type spawnTestAir struct{}
+
+func (spawnTestAir) EncodeBlock() (string, map[string]any) { return "minecraft:air", nil }
+func (spawnTestAir) Hash() (uint64, uint64)                { return 1 << 58, 0 }
+func (spawnTestAir) Model() BlockModel                     { return spawnTestAirModel{} }
+
+type spawnTestAirModel struct{}
+
+func (spawnTestAirModel) BBox(cube.Pos, BlockSource) []cube.BBox          { return nil }
+func (spawnTestAirModel) FaceSolid(cube.Pos, cube.Face, BlockSource) bool { return false }
@@ -0,0 +1,137 @@
+package world
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Tag is a named group of blocks or items, such as 'minecraft:logs' or
+// 'minecraft:wool'. Tags allow recipes, placement rules and other game logic
+// to refer to a group of blocks or items without listing each of them.
+// Members of a Tag are identified by their name, as returned by
+// Block.EncodeBlock or Item.EncodeItem. A Tag is immutable: Use Tag.With to
+// create a Tag with additional members.
+type Tag struct {
+	name    string
+	members map[string]struct{}
+}
+
+// NewTag creates a Tag with the name and members passed.
+func NewTag(name string, members ...string) Tag {
+	t := Tag{name: name, members: make(map[string]struct{}, len(members))}
+	for _, m := range members {
+		t.members[m] = struct{}{}
+	}
+	return t
+}
+
+// Name returns the name of the Tag, such as 'minecraft:logs'.
+func (t Tag) Name() string {
+	return t.name
+}
+
+// Contains checks if the block or item with the name passed is a member of
+// the Tag.
+func (t Tag) Contains(name string) bool {
+	_, ok := t.members[name]
+	return ok
+}
+
+// ContainsBlock checks if the Block passed is a member of the Tag.
+func (t Tag) ContainsBlock(b Block) bool {
+	name, _ := b.EncodeBlock()
+	return t.Contains(name)
+}
+
+// ContainsItem checks if the Item passed is a member of the Tag.
+func (t Tag) ContainsItem(i Item) bool {
+	name, _ := i.EncodeItem()
+	return t.Contains(name)
+}
+
+// Len returns the number of members of the Tag.
+func (t Tag) Len() int {
+	return len(t.members)
+}
+
+// Members returns an iterator over the names of all members of the Tag in
+// alphabetical order.
+func (t Tag) Members() iter.Seq[string] {
+	return slices.Values(slices.Sorted(maps.Keys(t.members)))
+}
+
+// With returns a copy of the Tag with the members passed added to it.
+func (t Tag) With(members ...string) Tag {
+	return NewTag(t.name, append(slices.Collect(maps.Keys(t.members)), members...)...)
+}
+
+var (
+	tagMu     sync.RWMutex
+	blockTags = map[string]Tag{}
+	itemTags  = map[string]Tag{}
+)
+
+// RegisterBlockTag registers the Tag passed as a tag of blocks, so that it
+// may be obtained using BlockTag. A block tag previously registered with the
+// same name is replaced. Use Tag.With on the existing tag to add members to
+// it instead.
+func RegisterBlockTag(t Tag) {
+	tagMu.Lock()
+	defer tagMu.Unlock()
+	blockTags[t.name] = t
+}
+
+// RegisterItemTag registers the Tag passed as a tag of items, so that it may
+// be obtained using ItemTag. An item tag previously registered with the same
+// name is replaced. Use Tag.With on the existing tag to add members to it
+// instead.
+func RegisterItemTag(t Tag) {
+	tagMu.Lock()
+	defer tagMu.Unlock()
+	itemTags[t.name] = t
+}
+
+// BlockTag returns the block tag registered with the name passed. If no such
+// tag was registered, an empty Tag is returned along with false.
+func BlockTag(name string) (Tag, bool) {
+	tagMu.RLock()
+	defer tagMu.RUnlock()
+	t, ok := blockTags[name]
+	return t, ok
+}
+
+// ItemTag returns the item tag registered with the name passed. If no such
+// tag was registered, an empty Tag is returned along with false.
+func ItemTag(name string) (Tag, bool) {
+	tagMu.RLock()
+	defer tagMu.RUnlock()
+	t, ok := itemTags[name]
+	return t, ok
+}
+
+// BlockTags returns an iterator over all registered block tags in
+// alphabetical order of their names.
+func BlockTags() iter.Seq[Tag] {
+	return sortedTags(blockTags)
+}
+
+// ItemTags returns an iterator over all registered item tags in
+// alphabetical order of their names.
+func ItemTags() iter.Seq[Tag] {
+	return sortedTags(itemTags)
+}
+
+// sortedTags returns an iterator over the tags in the map passed in
+// alphabetical order of their names.
+func sortedTags(m map[string]Tag) iter.Seq[Tag] {
+	tagMu.RLock()
+	names := slices.Sorted(maps.Keys(m))
+	tags := make([]Tag, len(names))
+	for i, name := range names {
+		tags[i] = m[name]
+	}
+	tagMu.RUnlock()
+	return slices.Values(tags)
+}
@@ -0,0 +1,82 @@
+package world
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTagMembers(t *testing.T) {
+	tag := NewTag("test:colours", "test:red", "test:green", "test:red")
+	if tag.Name() != "test:colours" || tag.Len() != 2 {
+		t.Fatalf("tag = %v with %v members, want test:colours with 2 members", tag.Name(), tag.Len())
+	}
+	if !tag.Contains("test:green") || tag.Contains("test:blue") {
+		t.Errorf("tag membership of test:green and test:blue = %v, %v, want true, false", tag.Contains("test:green"), tag.Contains("test:blue"))
+	}
+	with := tag.With("test:blue")
+	if got := slices.Collect(with.Members()); !slices.Equal(got, []string{"test:blue", "test:green", "test:red"}) {
+		t.Errorf("members of tag with test:blue added = %v, want [test:blue test:green test:red]", got)
+	}
+	if tag.Contains("test:blue") {
+		t.Errorf("adding members to a tag changed the original tag")
+	}
+}
+
+func TestRegisterCustomTag(t *testing.T) {
+	if _, ok := BlockTag("test:custom"); ok {
+		t.Fatalf("block tag test:custom found before it was registered")
+	}
+	RegisterBlockTag(NewTag("test:custom", "test:block"))
+	RegisterItemTag(NewTag("test:custom", "test:item"))
+	t.Cleanup(func() {
+		tagMu.Lock()
+		delete(blockTags, "test:custom")
+		delete(itemTags, "test:custom")
+		tagMu.Unlock()
+	})
+
+	blocks, _ := BlockTag("test:custom")
+	items, _ := ItemTag("test:custom")
+	if !blocks.Contains("test:block") || blocks.Contains("test:item") {
+		t.Errorf("block tag test:custom = %v, want only test:block", slices.Collect(blocks.Members()))
+	}
+	if !items.Contains("test:item") || items.Contains("test:block") {
+		t.Errorf("item tag test:custom = %v, want only test:item", slices.Collect(items.Members()))
+	}
+	if !slices.ContainsFunc(slices.Collect(BlockTags()), func(tag Tag) bool { return tag.Name() == "test:custom" }) {
+		t.Errorf("registered block tag test:custom not found among all block tags")
+	}
+
+	// Registering a tag with the same name replaces the tag.
+	RegisterBlockTag(blocks.With("test:other_block"))
+	if blocks, _ := BlockTag("test:custom"); blocks.Len() != 2 {
+		t.Errorf("block tag test:custom has %v members after adding a member, want 2", blocks.Len())
+	}
+}
+
+func TestTagContainsConstantTime(t *testing.T) {
+	small, large := NewTag("test:small", "test:0"), NewTag("test:large")
+	members := make([]string, 100000)
+	for i := range members {
+		members[i] = fmt.Sprintf("test:%v", i)
+	}
+	large = large.With(members...)
+
+	lookup := func(tag Tag) time.Duration {
+		start := time.Now()
+		for range 100000 {
+			tag.Contains("test:missing")
+		}
+		return time.Since(start)
+	}
+	// A linear search through the large tag would be many thousands of times
+	// slower than through the small tag.
+	if s, l := lookup(small), lookup(large); l > max(s, time.Millisecond)*50 {
+		t.Errorf("membership queries took %v on a tag with %v members and %v with 1 member, want constant time", l, large.Len(), s)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { large.Contains("test:5000") }); allocs != 0 {
+		t.Errorf("membership query allocated %v times, want 0", allocs)
+	}
+}
@@ -392,3 +392,27 @@ func testContext(t *testing.T) context.Context {
 	t.Cleanup(cancel)
 	return ctx
 }
+
+func TestScheduleFuncRunsOnTick(t *testing.T) {
+	w := Config{Synchronous: true}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	var start int64
+	ran := map[string]int64{}
+	spawnTestDo(t, w, func(tx *Tx) {
+		start = tx.CurrentTick()
+		tx.ScheduleFunc(0, func(tx *Tx) { ran["zero"] = tx.CurrentTick() })
+		tx.ScheduleFunc(time.Second/4, func(tx *Tx) {
+			ran["five"] = tx.CurrentTick()
+			tx.ScheduleFunc(time.Second/20, func(tx *Tx) { ran["nested"] = tx.CurrentTick() })
+		})
+	})
+	for range 10 {
+		w.AdvanceTick()
+	}
+	for name, want := range map[string]int64{"zero": start + 1, "five": start + 5, "nested": start + 6} {
+		if got, ok := ran[name]; !ok || got != want {
+			t.Errorf("scheduled func %q ran on tick %v (ran: %v), want tick %v", name, got, ok, want)
+		}
+	}
+}
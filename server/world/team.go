@@ -0,0 +1,389 @@
+package world
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+)
+
+// TeamRule is a rule of a Team that specifies to which entities a property of
+// the Team's members, such as their name tag or collision, applies.
+type TeamRule int
+
+const (
+	// TeamRuleAlways applies the property to all entities.
+	TeamRuleAlways TeamRule = iota
+	// TeamRuleNever applies the property to no entities.
+	TeamRuleNever
+	// TeamRuleOwnTeam applies the property only to members of the same team.
+	TeamRuleOwnTeam
+	// TeamRuleOtherTeams applies the property only to entities that are not
+	// members of the same team.
+	TeamRuleOtherTeams
+)
+
+// applies checks if the TeamRule applies to an entity that is (or isn't) on
+// the same team.
+func (r TeamRule) applies(sameTeam bool) bool {
+	switch r {
+	case TeamRuleNever:
+		return false
+	case TeamRuleOwnTeam:
+		return sameTeam
+	case TeamRuleOtherTeams:
+		return !sameTeam
+	default:
+		return true
+	}
+}
+
+// Teams is a set of Teams that entities may be members of. An entity is a
+// member of at most one Team of a Teams at a time. Teams is set for a World
+// using Config.Teams and may be shared between multiple worlds, so that
+// entities keep their Team when changing worlds.
+// Teams is safe for concurrent use.
+type Teams struct {
+	mu      sync.RWMutex
+	teams   map[string]*Team
+	members map[uuid.UUID]*Team
+	worlds  []*World
+}
+
+// NewTeams creates an empty Teams.
+func NewTeams() *Teams {
+	return &Teams{teams: make(map[string]*Team), members: make(map[uuid.UUID]*Team)}
+}
+
+// Create creates a new Team with the name passed. By default, the Team has no
+// colour, friendly fire disabled and name tags and collision that apply to all
+// entities. If a Team with the name already exists, it is returned and false
+// is returned.
+func (t *Teams) Create(name string) (*Team, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if team, ok := t.teams[name]; ok {
+		return team, false
+	}
+	team := &Team{teams: t, name: name, members: make(map[uuid.UUID]struct{})}
+	t.teams[name] = team
+	return team, true
+}
+
+// Team looks up the Team with the name passed. False is returned if no Team
+// with the name exists.
+func (t *Teams) Team(name string) (*Team, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	team, ok := t.teams[name]
+	return team, ok
+}
+
+// All returns all Teams, sorted by name.
+func (t *Teams) All() []*Team {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	teams := make([]*Team, 0, len(t.teams))
+	for _, team := range t.teams {
+		teams = append(teams, team)
+	}
+	slices.SortFunc(teams, func(a, b *Team) int {
+		return cmp.Compare(a.name, b.name)
+	})
+	return teams
+}
+
+// Remove removes the Team with the name passed. All members of the Team are
+// removed from it.
+func (t *Teams) Remove(name string) {
+	t.mu.Lock()
+	team, ok := t.teams[name]
+	if !ok {
+		t.mu.Unlock()
+		return
+	}
+	delete(t.teams, name)
+	members := team.memberIDs()
+	for _, id := range members {
+		delete(t.members, id)
+	}
+	team.members = make(map[uuid.UUID]struct{})
+	t.mu.Unlock()
+
+	t.changed(members)
+}
+
+// Of returns the Team that the entity with the UUID passed is a member of.
+// False is returned if the entity is not on any Team.
+func (t *Teams) Of(id uuid.UUID) (*Team, bool) {
+	if t == nil {
+		return nil, false
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	team, ok := t.members[id]
+	return team, ok
+}
+
+// SameTeam checks if the entities with the UUIDs passed are members of the
+// same Team.
+func (t *Teams) SameTeam(a, b uuid.UUID) bool {
+	team, ok := t.Of(a)
+	if !ok {
+		return false
+	}
+	other, ok := t.Of(b)
+	return ok && team == other
+}
+
+// DamageAllowed checks if the entity with the UUID attacker may damage the
+// entity with the UUID victim. Damage is not allowed between members of the
+// same Team unless the Team has friendly fire enabled.
+func (t *Teams) DamageAllowed(attacker, victim uuid.UUID) bool {
+	if !t.SameTeam(attacker, victim) {
+		return true
+	}
+	team, _ := t.Of(attacker)
+	return team.FriendlyFire()
+}
+
+// CollisionAllowed checks if the entities with the UUIDs passed collide with,
+// and may thus push, each other according to the collision rules of their
+// Teams. Entities that are not on a Team collide with all entities that the
+// collision rule of the other entity's Team allows.
+func (t *Teams) CollisionAllowed(a, b uuid.UUID) bool {
+	same := t.SameTeam(a, b)
+	if team, ok := t.Of(a); ok && !team.CollisionRule().applies(same) {
+		return false
+	}
+	team, ok := t.Of(b)
+	return !ok || team.CollisionRule().applies(same)
+}
+
+// NameTag returns the name tag that the entity with the UUID passed is shown
+// with to the viewer with the UUID viewer. The name tag passed is prefixed
+// with the colour of the Team of the entity. An empty string is returned if
+// the name tag visibility of the Team does not apply to the viewer.
+func (t *Teams) NameTag(id uuid.UUID, nameTag string, viewer uuid.UUID) string {
+	team, ok := t.Of(id)
+	if !ok || nameTag == "" {
+		return nameTag
+	}
+	if id != viewer && !team.NameTagVisibility().applies(t.SameTeam(id, viewer)) {
+		return ""
+	}
+	if colour := team.Colour(); colour != "" {
+		return colour + nameTag + text.Reset
+	}
+	return nameTag
+}
+
+// GlowColour returns the colour code that the entity with the UUID passed is
+// highlighted in while it is glowing. This is the Colour of the Team of the
+// entity, or text.White if it is not on a Team or if its Team has no colour.
+func (t *Teams) GlowColour(id uuid.UUID) string {
+	if team, ok := t.Of(id); ok {
+		if colour := team.Colour(); colour != "" {
+			return colour
+		}
+	}
+	return text.White
+}
+
+// attach attaches the Teams to the World passed, so that changes to the
+// Teams are shown to viewers of the entities in the World.
+func (t *Teams) attach(w *World) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.worlds = append(t.worlds, w)
+}
+
+// detach detaches the Teams from the World passed.
+func (t *Teams) detach(w *World) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.worlds = slices.DeleteFunc(t.worlds, func(other *World) bool {
+		return other == w
+	})
+}
+
+// changed shows the current state of the entities with the UUIDs passed to
+// their viewers in all worlds that the Teams is attached to. Because the name
+// tags and collision of members depend on the Team of the viewer, callers
+// also pass the teammates of entities that joined or left a Team.
+func (t *Teams) changed(ids []uuid.UUID) {
+	if len(ids) == 0 {
+		return
+	}
+	t.mu.RLock()
+	worlds := slices.Clone(t.worlds)
+	t.mu.RUnlock()
+
+	for _, w := range worlds {
+		w.Do(func(tx *Tx) {
+			for _, id := range ids {
+				if e, ok := tx.EntityByUUID(id); ok {
+					tx.UpdateEntityState(e)
+				}
+			}
+		})
+	}
+}
+
+// Team is a group of entities on a Teams. Members of a Team may be shown with
+// a coloured name tag, cannot damage each other unless friendly fire is
+// enabled and may have their name tags and collision limited to members of
+// the same team or of other teams.
+// Team is safe for concurrent use.
+type Team struct {
+	teams *Teams
+	name  string
+
+	// The fields below are guarded by teams.mu.
+	colour             string
+	friendlyFire       bool
+	nameTag, collision TeamRule
+	members            map[uuid.UUID]struct{}
+}
+
+// Name returns the name of the Team.
+func (t *Team) Name() string {
+	return t.name
+}
+
+// Colour returns the colour code that the name tags of members of the Team
+// are prefixed with, such as text.Red. An empty string is returned if the
+// Team has no colour.
+func (t *Team) Colour() string {
+	t.teams.mu.RLock()
+	defer t.teams.mu.RUnlock()
+	return t.colour
+}
+
+// SetColour sets the colour code that the name tags of members of the Team
+// are prefixed with, such as text.Red. An empty string removes the colour.
+func (t *Team) SetColour(colour string) {
+	t.update(func() { t.colour = colour })
+}
+
+// FriendlyFire returns true if members of the Team may damage each other.
+func (t *Team) FriendlyFire() bool {
+	t.teams.mu.RLock()
+	defer t.teams.mu.RUnlock()
+	return t.friendlyFire
+}
+
+// SetFriendlyFire sets if members of the Team may damage each other.
+func (t *Team) SetFriendlyFire(friendlyFire bool) {
+	t.teams.mu.Lock()
+	defer t.teams.mu.Unlock()
+	t.friendlyFire = friendlyFire
+}
+
+// NameTagVisibility returns the TeamRule that specifies to which viewers the
+// name tags of members of the Team are shown.
+func (t *Team) NameTagVisibility() TeamRule {
+	t.teams.mu.RLock()
+	defer t.teams.mu.RUnlock()
+	return t.nameTag
+}
+
+// SetNameTagVisibility sets the TeamRule that specifies to which viewers the
+// name tags of members of the Team are shown.
+func (t *Team) SetNameTagVisibility(rule TeamRule) {
+	t.update(func() { t.nameTag = rule })
+}
+
+// CollisionRule returns the TeamRule that specifies with which entities the
+// members of the Team collide.
+func (t *Team) CollisionRule() TeamRule {
+	t.teams.mu.RLock()
+	defer t.teams.mu.RUnlock()
+	return t.collision
+}
+
+// SetCollisionRule sets the TeamRule that specifies with which entities the
+// members of the Team collide.
+func (t *Team) SetCollisionRule(rule TeamRule) {
+	t.update(func() { t.collision = rule })
+}
+
+// Add adds the entities passed to the Team. Entities that are a member of
+// another Team are removed from that Team first. Add does nothing if the Team
+// was removed from its Teams.
+func (t *Team) Add(e ...Entity) {
+	t.teams.mu.Lock()
+	if t.teams.teams[t.name] != t {
+		t.teams.mu.Unlock()
+		return
+	}
+	var ids []uuid.UUID
+	for _, ent := range e {
+		id := ent.H().UUID()
+		if prev, ok := t.teams.members[id]; ok {
+			ids = append(ids, prev.memberIDs()...)
+			delete(prev.members, id)
+		}
+		t.teams.members[id] = t
+		t.members[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	ids = append(ids, t.memberIDs()...)
+	t.teams.mu.Unlock()
+
+	t.teams.changed(ids)
+}
+
+// Remove removes the entities passed from the Team.
+func (t *Team) Remove(e ...Entity) {
+	t.teams.mu.Lock()
+	ids := t.memberIDs()
+	for _, ent := range e {
+		id := ent.H().UUID()
+		if _, ok := t.members[id]; ok {
+			delete(t.members, id)
+			delete(t.teams.members, id)
+		}
+	}
+	t.teams.mu.Unlock()
+
+	t.teams.changed(ids)
+}
+
+// Members returns the UUIDs of all members of the Team.
+func (t *Team) Members() []uuid.UUID {
+	t.teams.mu.RLock()
+	defer t.teams.mu.RUnlock()
+	return t.memberIDs()
+}
+
+// Has checks if the entity passed is a member of the Team.
+func (t *Team) Has(e Entity) bool {
+	t.teams.mu.RLock()
+	defer t.teams.mu.RUnlock()
+	_, ok := t.members[e.H().UUID()]
+	return ok
+}
+
+// update calls f to change the Team and shows the changes to viewers of the
+// members of the Team.
+func (t *Team) update(f func()) {
+	t.teams.mu.Lock()
+	f()
+	ids := t.memberIDs()
+	t.teams.mu.Unlock()
+
+	t.teams.changed(ids)
+}
+
+// memberIDs returns the UUIDs of all members of the Team. t.teams.mu must be
+// held when calling memberIDs.
+func (t *Team) memberIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(t.members))
+	for id := range t.members {
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,144 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/sandertv/gophertunnel/minecraft/text"
+)
+
+func newTeamTestEntity(t EntityType) Entity {
+	h := EntitySpawnOpts{}.New(t, testEntityConfig{})
+	return &testEntity{handle: h, data: &h.data}
+}
+
+func TestTeamFriendlyFire(t *testing.T) {
+	teams := NewTeams()
+	w := Config{Synchronous: true, Teams: teams}.New()
+	defer w.Close()
+
+	a, b, c := newTeamTestEntity(testPlayerType{}), newTeamTestEntity(testPlayerType{}), newTeamTestEntity(testEntityType{})
+	red, _ := teams.Create("red")
+	blue, _ := teams.Create("blue")
+	red.Add(a, b)
+	blue.Add(c)
+
+	if w.DamageAllowed(a, b) || w.DamageAllowed(b, a) {
+		t.Fatal("expected damage between teammates to be cancelled without friendly fire")
+	}
+	if !w.DamageAllowed(a, c) || !w.DamageAllowed(c, b) {
+		t.Fatal("expected damage between members of different teams to be allowed")
+	}
+	red.SetFriendlyFire(true)
+	if !w.DamageAllowed(a, b) {
+		t.Fatal("expected damage between teammates to be allowed with friendly fire")
+	}
+	red.SetFriendlyFire(false)
+	blue.Add(b)
+	if !w.DamageAllowed(a, b) || w.DamageAllowed(b, c) {
+		t.Fatal("expected an entity joining another team to leave its previous team")
+	}
+	teams.Remove("blue")
+	if _, ok := teams.Of(c.H().UUID()); ok || !w.DamageAllowed(b, c) {
+		t.Fatal("expected members of a removed team to no longer be on a team")
+	}
+}
+
+func TestTeamNameTag(t *testing.T) {
+	teams := NewTeams()
+	a, b, other, none := newTeamTestEntity(testPlayerType{}), newTeamTestEntity(testPlayerType{}), newTeamTestEntity(testPlayerType{}), newTeamTestEntity(testPlayerType{})
+	red, _ := teams.Create("red")
+	red.SetColour(text.Red)
+	red.Add(a, b)
+	blue, _ := teams.Create("blue")
+	blue.Add(other)
+
+	tests := []struct {
+		name   string
+		rule   TeamRule
+		viewer Entity
+		want   string
+	}{
+		{name: "teammate", rule: TeamRuleAlways, viewer: b, want: text.Red + "Steve" + text.Reset},
+		{name: "other team", rule: TeamRuleAlways, viewer: other, want: text.Red + "Steve" + text.Reset},
+		{name: "self", rule: TeamRuleNever, viewer: a, want: text.Red + "Steve" + text.Reset},
+		{name: "never", rule: TeamRuleNever, viewer: b},
+		{name: "own team", rule: TeamRuleOwnTeam, viewer: b, want: text.Red + "Steve" + text.Reset},
+		{name: "own team hidden", rule: TeamRuleOwnTeam, viewer: none},
+		{name: "other teams", rule: TeamRuleOtherTeams, viewer: other, want: text.Red + "Steve" + text.Reset},
+		{name: "other teams hidden", rule: TeamRuleOtherTeams, viewer: b},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			red.SetNameTagVisibility(test.rule)
+			if got := teams.NameTag(a.H().UUID(), "Steve", test.viewer.H().UUID()); got != test.want {
+				t.Errorf("name tag = %q, want %q", got, test.want)
+			}
+		})
+	}
+	if got := teams.NameTag(none.H().UUID(), "Alex", a.H().UUID()); got != "Alex" {
+		t.Errorf("name tag of entity without team = %q, want %q", got, "Alex")
+	}
+	var nilTeams *Teams
+	if got := nilTeams.NameTag(a.H().UUID(), "Steve", b.H().UUID()); got != "Steve" {
+		t.Errorf("name tag without teams = %q, want %q", got, "Steve")
+	}
+}
+
+func TestTeamCollisionAllowed(t *testing.T) {
+	teams := NewTeams()
+	a, b, other := newTeamTestEntity(testPlayerType{}), newTeamTestEntity(testPlayerType{}), newTeamTestEntity(testPlayerType{})
+	red, _ := teams.Create("red")
+	red.Add(a, b)
+
+	if !teams.CollisionAllowed(a.H().UUID(), other.H().UUID()) {
+		t.Fatal("expected entities to collide by default")
+	}
+	red.SetCollisionRule(TeamRuleOtherTeams)
+	if teams.CollisionAllowed(a.H().UUID(), b.H().UUID()) || !teams.CollisionAllowed(other.H().UUID(), a.H().UUID()) {
+		t.Fatal("expected only entities of other teams to collide with TeamRuleOtherTeams")
+	}
+	red.SetCollisionRule(TeamRuleNever)
+	if teams.CollisionAllowed(other.H().UUID(), a.H().UUID()) {
+		t.Fatal("expected no entities to collide with TeamRuleNever")
+	}
+}
+
+func TestTeamGlowColour(t *testing.T) {
+	teams := NewTeams()
+	a, b, none := newTeamTestEntity(testPlayerType{}), newTeamTestEntity(testPlayerType{}), newTeamTestEntity(testPlayerType{})
+	red, _ := teams.Create("red")
+	red.SetColour(text.Red)
+	red.Add(a)
+	plain, _ := teams.Create("plain")
+	plain.Add(b)
+
+	tests := []struct {
+		name   string
+		entity Entity
+		want   string
+	}{
+		{name: "coloured team", entity: a, want: text.Red},
+		{name: "team without colour", entity: b, want: text.White},
+		{name: "no team", entity: none, want: text.White},
+	}
+	for _, test := range tests {
+		if c := teams.GlowColour(test.entity.H().UUID()); c != test.want {
+			t.Errorf("%v: glow colour = %q, want %q", test.name, c, test.want)
+		}
+	}
+
+	// Changing the colour of a team changes the glow colour of its members,
+	// and leaving the team resets it.
+	red.SetColour(text.Aqua)
+	if c := teams.GlowColour(a.H().UUID()); c != text.Aqua {
+		t.Errorf("glow colour after changing team colour = %q, want %q", c, text.Aqua)
+	}
+	red.Remove(a)
+	if c := teams.GlowColour(a.H().UUID()); c != text.White {
+		t.Errorf("glow colour after leaving team = %q, want %q", c, text.White)
+	}
+	var nilTeams *Teams
+	if c := nilTeams.GlowColour(a.H().UUID()); c != text.White {
+		t.Errorf("glow colour without teams = %q, want %q", c, text.White)
+	}
+}
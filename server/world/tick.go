@@ -1,6 +1,7 @@
 package world
 
 import (
+	"cmp"
 	"maps"
 	"math/rand/v2"
 	"slices"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/internal/sliceutil"
+	"github.com/df-mc/dragonfly/server/world/chunk"
 )
 
 // ticker implements World ticking methods.
@@ -46,6 +48,7 @@ func (w *World) AdvanceTick() {
 func (t ticker) tick(tx *Tx) {
 	viewers, loaders := tx.World().allViewers()
 	w := tx.World()
+	w.loadTicketedChunks()
 
 	w.set.Lock()
 	if s := w.set.Spawn; s[1] > tx.Range()[1] && w.Dimension() == Overworld {
@@ -53,9 +56,10 @@ func (t ticker) tick(tx *Tx) {
 		// the player should spawn at the highest position in the world.
 		w.set.Spawn[1] = w.highestObstructingBlock(s[0], s[2]) + 1
 	}
-	if len(viewers) == 0 && w.set.CurrentTick != 0 && !w.conf.Synchronous {
-		// Don't continue ticking if no viewers are in the world. Synchronous
-		// worlds only tick on explicit AdvanceTick calls, so they always tick.
+	if len(viewers) == 0 && w.set.CurrentTick != 0 && !w.conf.Synchronous && !w.tickingTickets() {
+		// Don't continue ticking if no viewers are in the world and no chunks
+		// are kept ticking by a chunk ticket. Synchronous worlds only tick on
+		// explicit AdvanceTick calls, so they always tick.
 		w.set.Unlock()
 		return
 	}
@@ -93,15 +97,100 @@ func (t ticker) tick(tx *Tx) {
 			}
 		}
 	}
+	if rain {
+		w.tickPrecipitation(tx)
+	}
 	if thunder {
 		w.tickLightning(tx)
 	}
 
+	tm := w.timings.Load()
+	start := tm.now()
+	defer tm.measureTick(start)
+
 	t.tickEntities(tx, tick)
+	tm.measure(TimingsEntityTick, start)
+
+	now := tm.now()
+	t.trackEntities(tx, loaders)
+	tm.measure(TimingsEntityTracking, now)
+
+	now = tm.now()
 	w.scheduledUpdates.tick(tx, tick)
+	w.runScheduledFuncs(tx, tick)
+	tm.measure(TimingsScheduledTick, now)
+
+	now = tm.now()
 	t.tickBlocksRandomly(tx, loaders, tick)
+	tm.measure(TimingsRandomTick, now)
+
+	now = tm.now()
 	t.performNeighbourUpdates(tx)
+	tm.measure(TimingsNeighbourUpdate, now)
+
+	now = tm.now()
 	w.redstone.tick(tx, tick)
+	tm.measure(TimingsRedstone, now)
+
+	t.flushBlockUpdates(tx)
+	t.flushEntityStates(tx)
+}
+
+// flushBlockUpdates shows the blocks changed in every chunk during the tick to
+// the viewers of the chunk, if Config.BatchBlockUpdates is set. Only the last
+// change of every block is viewed.
+func (t ticker) flushBlockUpdates(tx *Tx) {
+	w := tx.World()
+	for pos, changes := range w.blockUpdates {
+		c, ok := w.chunks[pos]
+		if !ok {
+			continue
+		}
+		changes = lastBlockChanges(changes)
+		for _, v := range c.viewers {
+			if len(changes) == 1 {
+				v.ViewBlockUpdate(changes[0].Pos, changes[0].Block, changes[0].Layer)
+				continue
+			}
+			v.ViewBlockUpdates(pos, changes)
+		}
+	}
+	clear(w.blockUpdates)
+}
+
+// lastBlockChanges removes all but the last change of every block and layer
+// from the changes passed, keeping the order of the changes left.
+func lastBlockChanges(changes []BlockChange) []BlockChange {
+	type key struct {
+		pos   cube.Pos
+		layer int
+	}
+	seen := make(map[key]struct{}, len(changes))
+	last := make([]BlockChange, 0, len(changes))
+	for i := len(changes) - 1; i >= 0; i-- {
+		k := key{pos: changes[i].Pos, layer: changes[i].Layer}
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		last = append(last, changes[i])
+	}
+	slices.Reverse(last)
+	return last
+}
+
+// flushEntityStates shows the state of all entities whose state changed during
+// the tick to their viewers, if Config.BatchEntityState is set.
+func (t ticker) flushEntityStates(tx *Tx) {
+	w := tx.World()
+	for handle := range w.entityStates {
+		if e, ok := handle.Entity(tx); ok {
+			for _, v := range w.entityViewers(e) {
+				v.ViewEntityState(e)
+			}
+		}
+	}
+	clear(w.entityStates)
 }
 
 // performNeighbourUpdates performs all block updates that came as a result of a neighbouring block being changed.
@@ -126,10 +215,11 @@ func (t ticker) performNeighbourUpdates(tx *Tx) {
 // tickBlocksRandomly executes random block ticks in loaded chunks within range of loaders.
 func (t ticker) tickBlocksRandomly(tx *Tx, loaders []*Loader, tick int64) {
 	var (
-		r             = int32(tx.World().tickRange())
-		g             randUint4
-		blockEntities []cube.Pos
-		randomBlocks  []cube.Pos
+		r            = int32(tx.World().tickRange())
+		g            randUint4
+		tickers      []cube.Pos
+		randomBlocks []cube.Pos
+		subs         []int
 	)
 	if r == 0 {
 		// NOP if the simulation distance is 0.
@@ -150,23 +240,34 @@ func (t ticker) tickBlocksRandomly(tx *Tx, loaders []*Loader, tick int64) {
 	}
 
 	for pos, c := range tx.World().chunks {
-		if !t.anyWithinDistance(pos, loaded, r) {
-			// No loaders in this chunk that are within the simulation distance, so proceed to the next.
+		if _, ticking := tx.World().ticketed(pos); !ticking && !t.anyWithinDistance(pos, loaded, r) {
+			// No loaders in this chunk that are within the simulation distance and no ticket that keeps the
+			// chunk ticking, so proceed to the next.
 			continue
 		}
-		blockEntities = append(blockEntities, slices.Collect(maps.Keys(c.BlockEntities))...)
+		tickers = append(tickers, slices.Collect(maps.Keys(c.tickers))...)
 
 		cx, cz := int(pos[0]<<4), int(pos[1]<<4)
 
+		// Sub chunks without any blocks in their palette that want random
+		// ticks, such as those filled with stone or persistent leaves, are
+		// skipped right away.
+		subs = subs[:0]
+		for i, sub := range c.Sub() {
+			if !sub.Empty() && randomTickable(sub.Layers()[0].Palette(), tx.World().conf.Blocks) {
+				subs = append(subs, i)
+			}
+		}
+		if len(subs) == 0 {
+			continue
+		}
+
 		// We generate up to j random positions for every sub chunk.
 		for j := 0; j < tx.World().conf.RandomTickSpeed; j++ {
 			x, y, z := g.uint4(tx.World().r), g.uint4(tx.World().r), g.uint4(tx.World().r)
 
-			for i, sub := range c.Sub() {
-				if sub.Empty() {
-					// SubChunk is empty, so skip it right away.
-					continue
-				}
+			for _, i := range subs {
+				sub := c.Sub()[i]
 				// Generally we would want to make sure the block has its block entities, but provided blocks
 				// with block entities are generally ticked already, we are safe to assume that blocks
 				// implementing the RandomTicker don't rely on additional block entity data.
@@ -187,13 +288,24 @@ func (t ticker) tickBlocksRandomly(tx *Tx, loaders []*Loader, tick int64) {
 			rb.RandomTick(pos, tx, tx.World().r)
 		}
 	}
-	for _, pos := range blockEntities {
+	for _, pos := range tickers {
 		if tb, ok := tx.Block(pos).(TickerBlock); ok {
 			tb.Tick(tick, pos, tx)
 		}
 	}
 }
 
+// randomTickable checks if any of the values in the Palette passed are blocks
+// that want random ticks according to the BlockRegistry passed.
+func randomTickable(p *chunk.Palette, reg BlockRegistry) bool {
+	for i := range p.Len() {
+		if reg.RandomTickBlock(p.Value(uint16(i))) {
+			return true
+		}
+	}
+	return false
+}
+
 // anyWithinDistance checks if any of the ChunkPos loaded are within the distance r of the ChunkPos pos.
 func (t ticker) anyWithinDistance(pos ChunkPos, loaded []ChunkPos, r int32) bool {
 	for _, chunkPos := range loaded {
@@ -235,6 +347,10 @@ func (t ticker) tickEntities(tx *Tx, tick int64) {
 				viewers = old.viewers
 			}
 
+			if tx.World().conf.EntityViewRadius > 0 {
+				// Entities are shown and hidden by trackEntities if an entity view radius is set.
+				viewers = nil
+			}
 			for _, viewer := range viewers {
 				if slices.Index(c.viewers, viewer) == -1 {
 					// First we hide the entity from all loaders that were previously viewing it, but no
@@ -243,7 +359,7 @@ func (t ticker) tickEntities(tx *Tx, tick int64) {
 				}
 			}
 			for _, viewer := range c.viewers {
-				if slices.Index(viewers, viewer) == -1 {
+				if tx.World().conf.EntityViewRadius <= 0 && slices.Index(viewers, viewer) == -1 {
 					// Then we show the entity to all loaders that are now viewing the entity in the new
 					// chunk.
 					showEntity(e, viewer)
@@ -251,7 +367,7 @@ func (t ticker) tickEntities(tx *Tx, tick int64) {
 			}
 		}
 
-		if tx.World().conf.Synchronous || len(c.viewers) > 0 {
+		if _, ticking := tx.World().ticketed(chunkPos); tx.World().conf.Synchronous || len(c.viewers) > 0 || ticking {
 			if te, ok := e.(TickerEntity); ok {
 				te.Tick(tx, tick)
 			}
@@ -259,6 +375,39 @@ func (t ticker) tickEntities(tx *Tx, tick int64) {
 	}
 }
 
+// trackEntities shows entities to loaders as they enter the entity view radius of the World and hides them as
+// they leave it. trackEntities does nothing if no entity view radius is set.
+func (t ticker) trackEntities(tx *Tx, loaders []*Loader) {
+	r := tx.World().conf.EntityViewRadius
+	if r <= 0 {
+		return
+	}
+	for _, l := range loaders {
+		if l.viewer == nil {
+			// The loader was closed while ticking.
+			continue
+		}
+		inRadius := make(map[*EntityHandle]Entity)
+		for e := range tx.World().entitiesInViewRadius(tx, l, r) {
+			inRadius[e.H()] = e
+		}
+		for handle := range l.trackedEntities() {
+			if _, ok := inRadius[handle]; ok {
+				continue
+			}
+			l.untrack(handle)
+			if e, ok := handle.Entity(tx); ok {
+				l.viewer.HideEntity(e)
+			}
+		}
+		for handle, e := range inRadius {
+			if l.track(handle) {
+				showEntity(e, l.viewer)
+			}
+		}
+	}
+}
+
 // randUint4 is a structure used to generate random uint4s.
 type randUint4 struct {
 	x uint64
@@ -298,6 +447,29 @@ type scheduledTickIndex struct {
 	hash uint64
 }
 
+// scheduledFunc is a function scheduled to run on a specific tick using
+// Tx.ScheduleFunc.
+type scheduledFunc struct {
+	t int64
+	f func(tx *Tx)
+}
+
+// runScheduledFuncs runs all functions scheduled using Tx.ScheduleFunc that
+// are due on the tick passed, in the order that they were scheduled in.
+func (w *World) runScheduledFuncs(tx *Tx, tick int64) {
+	var due []scheduledFunc
+	w.scheduledFuncs = slices.DeleteFunc(w.scheduledFuncs, func(f scheduledFunc) bool {
+		if f.t > tick {
+			return false
+		}
+		due = append(due, f)
+		return true
+	})
+	for _, f := range due {
+		f.f(tx)
+	}
+}
+
 // newScheduledTickQueue creates a queue for scheduled block ticks.
 func newScheduledTickQueue(tick int64) *scheduledTickQueue {
 	return &scheduledTickQueue{furthestTicks: make(map[scheduledTickIndex]int64), currentTick: tick}
@@ -305,15 +477,17 @@ func newScheduledTickQueue(tick int64) *scheduledTickQueue {
 
 // tick processes scheduled ticks, calling ScheduledTicker.ScheduledTick for any
 // block update that is scheduled for the tick passed, and removing it from the
-// queue.
+// queue. If Config.ScheduledTickBudget is positive, at most that many updates
+// are processed, taken round-robin from the chunks with updates due. The
+// remaining updates stay in the queue and are processed in later ticks.
 func (queue *scheduledTickQueue) tick(tx *Tx, tick int64) {
 	queue.currentTick = tick
 
 	w := tx.World()
-	for _, t := range queue.ticks {
-		if t.t > tick {
-			continue
-		}
+	ticks := queue.ticks
+	due := queue.due(tick, w.conf.ScheduledTickBudget)
+	for _, i := range due {
+		t := ticks[i]
 		b := tx.Block(t.pos)
 		if ticker, ok := b.(ScheduledTicker); ok && w.conf.Blocks.BlockHash(b) == t.bhash {
 			ticker.ScheduledTick(t.pos, tx, w.r)
@@ -324,13 +498,70 @@ func (queue *scheduledTickQueue) tick(tx *Tx, tick int64) {
 		}
 	}
 
-	// Clear scheduled ticks that were processed from the queue.
-	queue.ticks = slices.DeleteFunc(queue.ticks, func(t scheduledTick) bool {
-		return t.t <= tick
-	})
-	maps.DeleteFunc(queue.furthestTicks, func(index scheduledTickIndex, t int64) bool {
-		return t <= tick
+	// Clear scheduled ticks that were processed from the queue. Ticks
+	// scheduled while processing were appended after the ticks processed.
+	processed := make([]bool, len(ticks))
+	for _, i := range due {
+		processed[i] = true
+	}
+	n := 0
+	for i, t := range queue.ticks {
+		if i < len(processed) && processed[i] {
+			index := scheduledTickIndex{pos: t.pos, hash: t.bhash}
+			if furthest, ok := queue.furthestTicks[index]; ok && furthest <= tick {
+				delete(queue.furthestTicks, index)
+			}
+			continue
+		}
+		queue.ticks[n] = t
+		n++
+	}
+	clear(queue.ticks[n:])
+	queue.ticks = queue.ticks[:n]
+}
+
+// due returns the indices of the scheduled ticks in the queue that should be
+// processed on the tick passed. If budget is 0 or lower, all ticks due are
+// returned in the order that they were scheduled in. Otherwise, at most budget
+// ticks are returned. These are taken round-robin from the chunks that have
+// ticks due, so that a single chunk with many updates cannot hold up the
+// updates of other chunks. Chunks are visited in the order of their oldest
+// tick due, so that chunks that had updates deferred are served first.
+func (queue *scheduledTickQueue) due(tick int64, budget int) []int {
+	var due []int
+	for i, t := range queue.ticks {
+		if t.t <= tick {
+			due = append(due, i)
+		}
+	}
+	if budget <= 0 || len(due) <= budget {
+		return due
+	}
+	slices.SortStableFunc(due, func(a, b int) int {
+		return cmp.Compare(queue.ticks[a].t, queue.ticks[b].t)
 	})
+	var chunks [][]int
+	indices := make(map[ChunkPos]int)
+	for _, i := range due {
+		pos := chunkPosFromBlockPos(queue.ticks[i].pos)
+		c, ok := indices[pos]
+		if !ok {
+			c = len(chunks)
+			indices[pos] = c
+			chunks = append(chunks, nil)
+		}
+		chunks[c] = append(chunks[c], i)
+	}
+	selected := make([]int, 0, budget)
+	for round := 0; len(selected) < budget; round++ {
+		for _, c := range chunks {
+			if round < len(c) && len(selected) < budget {
+				selected = append(selected, c[round])
+			}
+		}
+	}
+	slices.Sort(selected)
+	return selected
 }
 
 // schedule schedules a block update at the position passed for the block type
@@ -0,0 +1,153 @@
+package world
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// TestRandomTickSelectsWantingBlocks verifies that only blocks that want
+// random ticks are picked by the random tick selection loop.
+func TestRandomTickSelectsWantingBlocks(t *testing.T) {
+	w := Config{Synchronous: true, Blocks: randomTickTestRegistry()}.New()
+	defer w.Close()
+
+	var ticked []cube.Pos
+	randomTickTestBlockTicks = &ticked
+	defer func() { randomTickTestBlockTicks = nil }()
+
+	runWorld(w, func(tx *Tx) {
+		for x := range 16 {
+			for z := range 16 {
+				tx.SetBlock(cube.Pos{x, 64, z}, randomTickTestBlock{Wants: true}, nil)
+				tx.SetBlock(cube.Pos{x, 65, z}, randomTickTestBlock{}, nil)
+				tx.SetBlock(cube.Pos{x, 66, z}, randomTickInertTestBlock{}, nil)
+				// A sub chunk without any blocks that want random ticks.
+				tx.SetBlock(cube.Pos{x, 80, z}, randomTickTestBlock{}, nil)
+			}
+		}
+		for i := range 2000 {
+			ticker{}.tickBlocksRandomly(tx, nil, int64(i))
+		}
+	})
+
+	if len(ticked) == 0 {
+		t.Fatal("expected blocks that want random ticks to be ticked")
+	}
+	for _, pos := range ticked {
+		if pos[1] != 64 {
+			t.Fatalf("block at %v that does not want random ticks was ticked", pos)
+		}
+	}
+}
+
+// BenchmarkRandomTickSelection benchmarks random tick selection in a chunk
+// filled mostly with blocks that do not want random ticks, comparing the
+// palette check against sampling every non-empty sub chunk.
+func BenchmarkRandomTickSelection(b *testing.B) {
+	w := Config{Synchronous: true, Blocks: randomTickTestRegistry()}.New()
+	defer w.Close()
+
+	runWorld(w, func(tx *Tx) {
+		for x := range 16 {
+			for z := range 16 {
+				for y := range 128 {
+					tx.SetBlock(cube.Pos{x, y, z}, randomTickTestBlock{}, nil)
+				}
+			}
+		}
+		tx.SetBlock(cube.Pos{0, 130, 0}, randomTickTestBlock{Wants: true}, nil)
+		c := tx.World().chunks[ChunkPos{}]
+
+		// sample picks random positions in the sub chunks passed like
+		// tickBlocksRandomly does and returns the number of positions found.
+		reg, r := tx.World().conf.Blocks, tx.World().r
+		sample := func(g *randUint4, subs []int) (n int) {
+			for range tx.World().conf.RandomTickSpeed {
+				x, y, z := g.uint4(r), g.uint4(r), g.uint4(r)
+				for _, i := range subs {
+					if reg.RandomTickBlock(c.Sub()[i].Layers()[0].At(x, y, z)) {
+						n++
+						x, y, z = g.uint4(r), g.uint4(r), g.uint4(r)
+					}
+				}
+			}
+			return n
+		}
+		b.Run("Palette", func(b *testing.B) {
+			var (
+				g    randUint4
+				subs []int
+			)
+			for b.Loop() {
+				subs = subs[:0]
+				for i, sub := range c.Sub() {
+					if !sub.Empty() && randomTickable(sub.Layers()[0].Palette(), reg) {
+						subs = append(subs, i)
+					}
+				}
+				sample(&g, subs)
+			}
+		})
+		b.Run("Naive", func(b *testing.B) {
+			var (
+				g    randUint4
+				subs []int
+			)
+			for b.Loop() {
+				subs = subs[:0]
+				for i, sub := range c.Sub() {
+					if !sub.Empty() {
+						subs = append(subs, i)
+					}
+				}
+				sample(&g, subs)
+			}
+		})
+	})
+}
+
+// randomTickTestBlockTicks holds the positions of randomTickTestBlocks that
+// were ticked. Tests using it must stay serial.
+var randomTickTestBlockTicks *[]cube.Pos
+
+type randomTickTestBlock struct {
+	Wants bool
+}
+
+func (randomTickTestBlock) RandomTick(pos cube.Pos, _ *Tx, _ *rand.Rand) {
+	if randomTickTestBlockTicks != nil {
+		*randomTickTestBlockTicks = append(*randomTickTestBlockTicks, pos)
+	}
+}
+func (b randomTickTestBlock) WantsRandomTick() bool { return b.Wants }
+func (b randomTickTestBlock) EncodeBlock() (string, map[string]any) {
+	return "test:random_tick", map[string]any{"wants": b.Wants}
+}
+func (b randomTickTestBlock) Hash() (uint64, uint64) {
+	if b.Wants {
+		return 1 << 54, 1
+	}
+	return 1 << 54, 0
+}
+func (randomTickTestBlock) Model() BlockModel { return redstoneCancellationModel{} }
+
+type randomTickInertTestBlock struct{}
+
+func (randomTickInertTestBlock) EncodeBlock() (string, map[string]any) {
+	return "test:random_tick_inert", nil
+}
+func (randomTickInertTestBlock) Hash() (uint64, uint64) { return 1 << 55, 0 }
+func (randomTickInertTestBlock) Model() BlockModel      { return redstoneCancellationModel{} }
+
+func randomTickTestRegistry() BlockRegistry {
+	registry := NewBlockRegistry()
+	for _, wants := range []bool{false, true} {
+		registry.RegisterBlockState(BlockState{Name: "test:random_tick", Properties: map[string]any{"wants": wants}})
+		registry.RegisterBlock(randomTickTestBlock{Wants: wants})
+	}
+	registry.RegisterBlockState(BlockState{Name: "test:random_tick_inert", Properties: map[string]any{}})
+	registry.RegisterBlock(randomTickInertTestBlock{})
+	return registry
+}
@@ -0,0 +1,210 @@
+package world
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TimingsSystem is a system of a World whose tick cost is measured while
+// timings are enabled using World.StartTimings.
+type TimingsSystem int
+
+const (
+	// TimingsEntityTick measures the ticking of entities.
+	TimingsEntityTick TimingsSystem = iota
+	// TimingsEntityTracking measures the spatial queries that show and hide
+	// entities to viewers within the entity view radius.
+	TimingsEntityTracking
+	// TimingsScheduledTick measures scheduled block updates.
+	TimingsScheduledTick
+	// TimingsRandomTick measures random block ticks.
+	TimingsRandomTick
+	// TimingsNeighbourUpdate measures block updates caused by neighbouring
+	// blocks changing.
+	TimingsNeighbourUpdate
+	// TimingsRedstone measures the propagation of redstone power.
+	TimingsRedstone
+	// TimingsChunkSend measures the loading and sending of chunks to viewers.
+	TimingsChunkSend
+	timingsSystemCount
+)
+
+// String returns the name of the TimingsSystem.
+func (s TimingsSystem) String() string {
+	switch s {
+	case TimingsEntityTick:
+		return "entity tick"
+	case TimingsEntityTracking:
+		return "entity tracking"
+	case TimingsScheduledTick:
+		return "scheduled tick"
+	case TimingsRandomTick:
+		return "random tick"
+	case TimingsNeighbourUpdate:
+		return "neighbour update"
+	case TimingsRedstone:
+		return "redstone"
+	case TimingsChunkSend:
+		return "chunk send"
+	}
+	return "unknown"
+}
+
+// SystemTimings holds the measured tick cost of a single TimingsSystem.
+type SystemTimings struct {
+	// System is the TimingsSystem measured.
+	System TimingsSystem
+	// Total is the total time spent in the system.
+	Total time.Duration
+	// Max is the longest time spent in the system in a single call.
+	Max time.Duration
+	// Calls is the number of times the system was measured.
+	Calls int
+}
+
+// Average returns the average time spent in the system per call.
+func (s SystemTimings) Average() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Calls)
+}
+
+// Report is a report of the tick cost of the systems of a World over a
+// sampling window, as returned by World.StopTimings.
+type Report struct {
+	// Duration is the duration of the sampling window.
+	Duration time.Duration
+	// Ticks is the number of ticks performed in the sampling window.
+	Ticks int
+	// TickTotal is the total time spent ticking, and TickMax is the longest
+	// time spent on a single tick.
+	TickTotal, TickMax time.Duration
+	// Systems holds the timings of all systems that were measured at least
+	// once, sorted from the most to the least total time spent.
+	Systems []SystemTimings
+}
+
+// Worst returns the SystemTimings of the system that the most time was spent
+// in. False is returned if no systems were measured.
+func (r Report) Worst() (SystemTimings, bool) {
+	if len(r.Systems) == 0 {
+		return SystemTimings{}, false
+	}
+	return r.Systems[0], true
+}
+
+// String returns a human-readable summary of the Report, listing the systems
+// from the most to the least expensive.
+func (r Report) String() string {
+	var b strings.Builder
+	avg := time.Duration(0)
+	if r.Ticks > 0 {
+		avg = r.TickTotal / time.Duration(r.Ticks)
+	}
+	_, _ = fmt.Fprintf(&b, "Timings over %v: %d ticks, avg %v, max %v\n", r.Duration.Round(time.Millisecond), r.Ticks, avg, r.TickMax)
+	for _, s := range r.Systems {
+		share := 0.0
+		if r.TickTotal > 0 {
+			share = float64(s.Total) / float64(r.TickTotal) * 100
+		}
+		_, _ = fmt.Fprintf(&b, "  %-16s total %v (%.1f%%), avg %v, max %v, %d calls\n", s.System, s.Total, share, s.Average(), s.Max, s.Calls)
+	}
+	return b.String()
+}
+
+// timings aggregates the tick cost of the systems of a World. A nil *timings
+// is valid and records nothing, which keeps the overhead of disabled timings
+// to a nil check.
+type timings struct {
+	mu      sync.Mutex
+	start   time.Time
+	ticks   int
+	tick    SystemTimings
+	systems [timingsSystemCount]SystemTimings
+}
+
+// now returns the current time if t is non-nil. The zero time is returned
+// otherwise, so that time is not looked up while timings are disabled.
+func (t *timings) now() time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// measure records the time passed since start as spent in the system passed.
+func (t *timings) measure(s TimingsSystem, start time.Time) {
+	if t != nil {
+		t.record(s, time.Since(start))
+	}
+}
+
+// measureTick records the time passed since start as spent on a full tick.
+func (t *timings) measureTick(start time.Time) {
+	if t == nil {
+		return
+	}
+	d := time.Since(start)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.ticks++
+	t.tick.Total += d
+	t.tick.Max = max(t.tick.Max, d)
+}
+
+// record records d as spent in the system passed.
+func (t *timings) record(s TimingsSystem, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sys := &t.systems[s]
+	sys.Total += d
+	sys.Max = max(sys.Max, d)
+	sys.Calls++
+}
+
+// report creates a Report of the timings recorded so far.
+func (t *timings) report() Report {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r := Report{Duration: time.Since(t.start), Ticks: t.ticks, TickTotal: t.tick.Total, TickMax: t.tick.Max}
+	for i, s := range t.systems {
+		if s.Calls > 0 {
+			s.System = TimingsSystem(i)
+			r.Systems = append(r.Systems, s)
+		}
+	}
+	slices.SortStableFunc(r.Systems, func(a, b SystemTimings) int {
+		return cmp.Compare(b.Total, a.Total)
+	})
+	return r
+}
+
+// StartTimings starts measuring the tick cost of the systems of the World,
+// such as entity ticking, random ticks and chunk sending. The timings are
+// collected until StopTimings is called. Calling StartTimings while timings
+// are already enabled discards the timings collected so far.
+func (w *World) StartTimings() {
+	if w == nil {
+		return
+	}
+	w.timings.Store(&timings{start: time.Now()})
+}
+
+// StopTimings stops measuring the tick cost of the systems of the World and
+// returns a Report of the timings collected since StartTimings was called.
+// An empty Report is returned if timings were not enabled.
+func (w *World) StopTimings() Report {
+	if w == nil {
+		return Report{}
+	}
+	t := w.timings.Swap(nil)
+	if t == nil {
+		return Report{}
+	}
+	return t.report()
+}
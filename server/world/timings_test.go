@@ -0,0 +1,93 @@
+package world
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestTimingsAggregate(t *testing.T) {
+	tm := &timings{start: time.Now()}
+	for _, d := range []time.Duration{time.Millisecond, 3 * time.Millisecond, 2 * time.Millisecond} {
+		start := time.Now()
+		tm.record(TimingsEntityTick, d)
+		tm.record(TimingsRandomTick, d/2)
+		tm.measureTick(start)
+	}
+	tm.record(TimingsChunkSend, 5*time.Millisecond)
+
+	r := tm.report()
+	if r.Ticks != 3 {
+		t.Errorf("ticks = %v, want 3", r.Ticks)
+	}
+	if len(r.Systems) != 3 {
+		t.Fatalf("systems = %v, want 3 measured systems", r.Systems)
+	}
+	want := []SystemTimings{
+		{System: TimingsEntityTick, Total: 6 * time.Millisecond, Max: 3 * time.Millisecond, Calls: 3},
+		{System: TimingsChunkSend, Total: 5 * time.Millisecond, Max: 5 * time.Millisecond, Calls: 1},
+		{System: TimingsRandomTick, Total: 3 * time.Millisecond, Max: 1500 * time.Microsecond, Calls: 3},
+	}
+	for i, s := range r.Systems {
+		if s != want[i] {
+			t.Errorf("systems[%d] = %+v, want %+v", i, s, want[i])
+		}
+	}
+	if avg := r.Systems[0].Average(); avg != 2*time.Millisecond {
+		t.Errorf("average entity tick = %v, want 2ms", avg)
+	}
+}
+
+func TestTimingsReportWorstSystem(t *testing.T) {
+	w := Config{Synchronous: true}.New()
+	defer w.Close()
+
+	runWorld(w, func(tx *Tx) {
+		tx.AddEntity(EntitySpawnOpts{Position: mgl64.Vec3{0, 64, 0}}.New(timingsTestEntityType{}, testEntityConfig{}))
+	})
+	if r := w.StopTimings(); r.Ticks != 0 {
+		t.Fatalf("report without timings enabled = %+v, want empty report", r)
+	}
+
+	w.StartTimings()
+	for range 5 {
+		w.AdvanceTick()
+	}
+	r := w.StopTimings()
+	if r.Ticks != 5 {
+		t.Errorf("ticks = %v, want 5", r.Ticks)
+	}
+	worst, ok := r.Worst()
+	if !ok || worst.System != TimingsEntityTick {
+		t.Fatalf("worst system = %v, want %v:\n%v", worst.System, TimingsEntityTick, r)
+	}
+	if worst.Total < 5*timingsTestEntityTickCost || worst.Calls != 5 {
+		t.Errorf("entity tick timings = %+v, want 5 calls taking at least %v", worst, 5*timingsTestEntityTickCost)
+	}
+
+	w.AdvanceTick()
+	if r := w.StopTimings(); r.Ticks != 0 {
+		t.Errorf("ticks measured after stopping timings = %v, want 0", r.Ticks)
+	}
+}
+
+// timingsTestEntityTickCost is the time that a timingsTestEntity spends on
+// every tick.
+const timingsTestEntityTickCost = 2 * time.Millisecond
+
+type timingsTestEntityType struct{ testEntityType }
+
+func (timingsTestEntityType) Open(_ *Tx, handle *EntityHandle, data *EntityData) Entity {
+	return &timingsTestEntity{testEntity{handle: handle, data: data}}
+}
+
+func (timingsTestEntityType) EncodeEntity() string {
+	return "dragonfly:timings_test_entity"
+}
+
+type timingsTestEntity struct{ testEntity }
+
+func (e *timingsTestEntity) Tick(*Tx, int64) {
+	time.Sleep(timingsTestEntityTickCost)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
 )
 
 // Tx is the owner transaction handle passed to world callbacks. It is the
@@ -141,6 +142,18 @@ func (tx *Tx) ScheduleBlockUpdate(pos cube.Pos, b Block, delay time.Duration) {
 	tx.World().scheduleBlockUpdate(pos, b, delay)
 }
 
+// ScheduleFunc schedules f to be run with the Tx of the tick that is the delay
+// passed after the current tick. The delay is rounded down to whole ticks and
+// is always at least one tick. Unlike World.DoAfter, which waits for the delay
+// to pass in real time, ScheduleFunc follows the ticks of the World, so that f
+// is never run early or late if the World ticks slower or faster than usual.
+// Functions that are still scheduled when the World is closed are not run.
+func (tx *Tx) ScheduleFunc(delay time.Duration, f func(tx *Tx)) {
+	w := tx.World()
+	at := tx.CurrentTick() + int64(max(delay/(time.Second/20), 1))
+	w.scheduledFuncs = append(w.scheduledFuncs, scheduledFunc{t: at, f: f})
+}
+
 // HighestLightBlocker gets the Y value of the highest fully light blocking
 // block at the x and z values passed in the World.
 func (tx *Tx) HighestLightBlocker(x, z int) int {
@@ -170,6 +183,13 @@ func (tx *Tx) SkyLight(pos cube.Pos) uint8 {
 	return tx.World().skyLight(pos)
 }
 
+// BlockLight returns the block light level at the position passed. Unlike
+// Light and SkyLight, this light level is only influenced by blocks that emit
+// light, such as torches.
+func (tx *Tx) BlockLight(pos cube.Pos) uint8 {
+	return tx.World().blockLight(pos)
+}
+
 // SetBiome sets the Biome at the position passed. If a chunk is not yet loaded
 // at that position, the chunk is first loaded or generated if it could not be
 // found in the world save.
@@ -242,22 +262,71 @@ func (tx *Tx) PlaySound(pos mgl64.Vec3, s Sound) {
 	tx.World().playSound(tx, pos, s)
 }
 
+// BroadcastLevelEvent broadcasts a LevelEvent at a position in the World to
+// all viewers of that position. The data passed is specific to the
+// LevelEvent, as described in the documentation of the LevelEvent constants.
+func (tx *Tx) BroadcastLevelEvent(pos mgl64.Vec3, event LevelEvent, data int32) {
+	tx.World().broadcastLevelEvent(pos, event, data)
+}
+
+// EmitVibration emits a Vibration in the World. The nearest VibrationListener
+// that has the position of the Vibration within its range and is able to
+// detect it is notified of the Vibration.
+func (tx *Tx) EmitVibration(v Vibration) {
+	tx.World().emitVibration(tx, v)
+}
+
 // AddEntity adds an EntityHandle to a World. The Entity will be visible to all
 // viewers of the World that have the chunk at the EntityHandle's position. If
 // the chunk that the EntityHandle is in is not yet loaded, it will first be
 // loaded. AddEntity panics if the EntityHandle is already in a world.
-// AddEntity returns the Entity created by the EntityHandle.
+// AddEntity returns the Entity created by the EntityHandle. If the chunk has
+// reached the Config.ChunkEntityLimit, the EntityHandle is closed and nil is
+// returned instead.
 func (tx *Tx) AddEntity(e *EntityHandle) Entity {
 	return tx.World().addEntity(tx, e)
 }
 
 // AddEntityAt adds an EntityHandle to a World at the position passed. The Entity will be visible to all viewers of
 // the World that have the chunk at the position passed. AddEntityAt panics if the EntityHandle is already in a world.
-// AddEntityAt returns the Entity created by the EntityHandle.
+// AddEntityAt returns the Entity created by the EntityHandle, or nil if the chunk at the position passed has reached
+// the Config.ChunkEntityLimit.
 func (tx *Tx) AddEntityAt(e *EntityHandle, pos mgl64.Vec3) Entity {
 	return tx.World().addEntityAt(tx, e, pos)
 }
 
+// SpawnEntity creates an entity with the EntityType registered under the name
+// passed in the EntityRegistry of the World, such as "minecraft:boat", and
+// adds it to the World at the position passed. The entity is decoded from the
+// SpawnOptions.NBT, if set. SpawnEntity returns false if no EntityType is
+// registered under the name passed, if SpawnOptions.CheckConditions is set and
+// the entity may not spawn at the position, or if the entity could not be
+// added, for example because the chunk at the position has reached the
+// Config.ChunkEntityLimit.
+func (tx *Tx) SpawnEntity(name string, pos mgl64.Vec3, opts SpawnOptions) (Entity, bool) {
+	t, ok := tx.World().EntityRegistry().Lookup(name)
+	if !ok || (opts.CheckConditions && !tx.CanSpawnAt(t, pos)) {
+		return nil, false
+	}
+	e := tx.AddEntity(opts.New(t, pos))
+	return e, e != nil
+}
+
+// CanSpawnAt checks if an entity of the EntityType passed may spawn with its
+// feet at the position passed. Entities never spawn outside the height range
+// of the World. If the EntityType implements SpawnValidator, its CanSpawnAt
+// method decides whether the entity may spawn. Otherwise, entities may spawn
+// anywhere within the World.
+func (tx *Tx) CanSpawnAt(t EntityType, pos mgl64.Vec3) bool {
+	if cube.PosFromVec3(pos).OutOfBounds(tx.Range()) {
+		return false
+	}
+	if v, ok := t.(SpawnValidator); ok {
+		return v.CanSpawnAt(tx, pos)
+	}
+	return true
+}
+
 // RemoveEntity removes an Entity from the World that is currently present in
 // it. Any viewers of the Entity will no longer be able to see it.
 // RemoveEntity returns the EntityHandle of the Entity. After removing an Entity
@@ -277,6 +346,16 @@ func (tx *Tx) Entities() iter.Seq[Entity] {
 	return tx.World().allEntities(tx)
 }
 
+// EntityByUUID looks up the Entity in the World with the UUID passed. False is
+// returned if no such entity is currently loaded in the World.
+func (tx *Tx) EntityByUUID(id uuid.UUID) (Entity, bool) {
+	handle, ok := tx.World().entityIDs[id]
+	if !ok {
+		return nil, false
+	}
+	return handle.Entity(tx)
+}
+
 // Players returns an iterator that yields all player entities in the World.
 func (tx *Tx) Players() iter.Seq[Entity] {
 	return tx.World().allPlayers(tx)
@@ -287,6 +366,23 @@ func (tx *Tx) Viewers(pos mgl64.Vec3) []Viewer {
 	return tx.World().viewersOf(pos)
 }
 
+// EntityViewers returns all viewers that the Entity passed is shown to. If
+// Config.EntityViewRadius is set, these are only the viewers that have the
+// Entity within their entity view radius. Otherwise, these are the viewers of
+// the chunk that the Entity is in. Movement and state updates of the Entity
+// should be sent to these viewers.
+func (tx *Tx) EntityViewers(e Entity) []Viewer {
+	return tx.World().entityViewers(e)
+}
+
+// UpdateEntityState shows the current state of the Entity passed, such as its
+// name tag or whether it is on fire, to all viewers of the Entity. If
+// Config.BatchEntityState is set, viewers are only updated at the end of the
+// tick, once for every Entity whose state changed during it.
+func (tx *Tx) UpdateEntityState(e Entity) {
+	tx.World().updateEntityState(e)
+}
+
 // Sleepers returns an iterator that yields all sleeping entities currently added to the World.
 func (tx *Tx) Sleepers() iter.Seq[Sleeper] {
 	ent := tx.Entities()
@@ -0,0 +1,96 @@
+package world
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Vibration is a vibration emitted in a World by an action such as an entity
+// stepping on a block or a block being placed. Vibrations are detected by
+// VibrationListeners near the position they are emitted at.
+type Vibration struct {
+	// Pos is the position that the Vibration was emitted at.
+	Pos mgl64.Vec3
+	// Frequency is the frequency of the Vibration, ranging from 1 to 15.
+	// Different actions emit vibrations of different frequencies, such as
+	// VibrationStep and VibrationBlockPlace.
+	Frequency int
+	// Source is the Entity that caused the Vibration. Source is nil if the
+	// Vibration was not caused by an entity.
+	Source Entity
+}
+
+const (
+	// VibrationStep is the Frequency of a Vibration emitted by an entity
+	// stepping on a block.
+	VibrationStep = 1
+	// VibrationProjectileLand is the Frequency of a Vibration emitted by a
+	// projectile landing on a block.
+	VibrationProjectileLand = 8
+	// VibrationBlockPlace is the Frequency of a Vibration emitted by a block
+	// being placed.
+	VibrationBlockPlace = 12
+	// VibrationBlockBreak is the Frequency of a Vibration emitted by a block
+	// being broken.
+	VibrationBlockBreak = 13
+)
+
+// MaxVibrationRange is the maximum range in blocks that a VibrationListener
+// may detect vibrations in.
+const MaxVibrationRange = 16
+
+// VibrationListener is a Block with block entity data that detects Vibrations
+// emitted near it, such as a sculk sensor.
+type VibrationListener interface {
+	NBTer
+	// VibrationRange returns the radius in blocks around the VibrationListener
+	// within which it detects vibrations. The range may not exceed
+	// MaxVibrationRange.
+	VibrationRange() float64
+	// ListenVibration is called when a Vibration is emitted within the
+	// VibrationRange of the VibrationListener at the position passed.
+	// ListenVibration returns true if the VibrationListener detected the
+	// Vibration, or false if it could not, for example because the
+	// VibrationListener is on cooldown or the Vibration was occluded.
+	ListenVibration(pos cube.Pos, tx *Tx, v Vibration) bool
+}
+
+// emitVibration emits the Vibration passed. Only the nearest VibrationListener
+// that detects the Vibration is notified of it.
+func (w *World) emitVibration(tx *Tx, v Vibration) {
+	type candidate struct {
+		pos  cube.Pos
+		l    VibrationListener
+		dist float64
+	}
+	var candidates []candidate
+
+	minPos := chunkPosFromVec3(v.Pos.Sub(mgl64.Vec3{MaxVibrationRange, 0, MaxVibrationRange}))
+	maxPos := chunkPosFromVec3(v.Pos.Add(mgl64.Vec3{MaxVibrationRange, 0, MaxVibrationRange}))
+	for x := minPos[0]; x <= maxPos[0]; x++ {
+		for z := minPos[1]; z <= maxPos[1]; z++ {
+			// Vibrations don't cause any chunks to be loaded.
+			c, ok := w.chunks[ChunkPos{x, z}]
+			if !ok {
+				continue
+			}
+			for pos := range c.listeners {
+				l := c.BlockEntities[pos].(VibrationListener)
+				if dist := pos.Vec3Centre().Sub(v.Pos).Len(); dist <= min(l.VibrationRange(), MaxVibrationRange) {
+					candidates = append(candidates, candidate{pos: pos, l: l, dist: dist})
+				}
+			}
+		}
+	}
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		return cmp.Compare(a.dist, b.dist)
+	})
+	for _, c := range candidates {
+		if c.l.ListenVibration(c.pos, tx, v) {
+			return
+		}
+	}
+}
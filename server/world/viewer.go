@@ -62,9 +62,16 @@ type Viewer interface {
 	ViewParticle(pos mgl64.Vec3, p Particle)
 	// ViewSound is called when a sound is played in the world.
 	ViewSound(pos mgl64.Vec3, s Sound)
+	// ViewLevelEvent is called when a LevelEvent is broadcast in the world. The data passed is specific to
+	// the LevelEvent.
+	ViewLevelEvent(pos mgl64.Vec3, event LevelEvent, data int32)
 	// ViewBlockUpdate views the updating of a block. It is called when a block is set at the position passed
 	// to the method.
 	ViewBlockUpdate(pos cube.Pos, b Block, layer int)
+	// ViewBlockUpdates views the updating of multiple blocks in the chunk at the position passed at once. It
+	// is called at the end of a tick with all blocks changed in the chunk during that tick if
+	// Config.BatchBlockUpdates is set.
+	ViewBlockUpdates(pos ChunkPos, changes []BlockChange)
 	// ViewBlockAction views an action performed by a block. Available actions may be found in the `action`
 	// package, and include things such as a chest opening.
 	ViewBlockAction(pos cube.Pos, a BlockAction)
@@ -78,6 +85,22 @@ type Viewer interface {
 	ViewWeather(raining, thunder bool)
 	// ViewEntityWake views an entity waking up from a bed.
 	ViewEntityWake(e Entity)
+	// ViewEntityMount views an Entity starting to ride another Entity. Driver is true if the rider controls
+	// the movement of the vehicle.
+	ViewEntityMount(rider, vehicle Entity, driver bool)
+	// ViewEntityDismount views an Entity stopping to ride another Entity.
+	ViewEntityDismount(rider, vehicle Entity)
+}
+
+// BlockChange is a change of a block at a position, as viewed using Viewer.ViewBlockUpdates.
+type BlockChange struct {
+	// Pos is the position of the block changed.
+	Pos cube.Pos
+	// Block is the new block at Pos.
+	Block Block
+	// Layer is the layer of the block changed: 0 for the foreground layer and 1 for the layer holding
+	// liquids displaced by blocks.
+	Layer int
 }
 
 // NopViewer is a Viewer implementation that does not implement any behaviour. It may be embedded by other structs to
@@ -104,7 +127,9 @@ func (NopViewer) ViewEntityState(Entity)
 func (NopViewer) ViewEntityAnimation(Entity, EntityAnimation)                                {}
 func (NopViewer) ViewParticle(mgl64.Vec3, Particle)                                          {}
 func (NopViewer) ViewSound(mgl64.Vec3, Sound)                                                {}
+func (NopViewer) ViewLevelEvent(mgl64.Vec3, LevelEvent, int32)                               {}
 func (NopViewer) ViewBlockUpdate(cube.Pos, Block, int)                                       {}
+func (NopViewer) ViewBlockUpdates(ChunkPos, []BlockChange)                                   {}
 func (NopViewer) ViewBlockAction(cube.Pos, BlockAction)                                      {}
 func (NopViewer) ViewEmote(Entity, uuid.UUID)                                                {}
 func (NopViewer) ViewSkin(Entity)                                                            {}
@@ -112,5 +137,7 @@ func (NopViewer) ViewWorldSpawn(cube.Pos)
 func (NopViewer) ViewWeather(bool, bool)                                                     {}
 func (NopViewer) ViewBrewingUpdate(time.Duration, time.Duration, int32, int32, int32, int32) {}
 func (NopViewer) ViewEntityWake(Entity)                                                      {}
+func (NopViewer) ViewEntityMount(Entity, Entity, bool)                                       {}
+func (NopViewer) ViewEntityDismount(Entity, Entity)                                          {}
 func (NopViewer) ViewFurnaceUpdate(time.Duration, time.Duration, time.Duration, time.Duration, time.Duration, time.Duration) {
 }
@@ -28,7 +28,7 @@ func (w weather) snowingAt(pos cube.Pos) bool {
 	if w.w == nil || !w.w.Dimension().WeatherCycle() {
 		return false
 	}
-	if b := w.w.biome(pos); b.Rainfall() == 0 || w.w.temperature(pos) > 0.15 {
+	if b := w.w.biome(pos); b == nil || b.Rainfall() == 0 || w.w.temperature(pos) > 0.15 {
 		return false
 	}
 	w.w.set.Lock()
@@ -45,7 +45,7 @@ func (w weather) rainingAt(pos cube.Pos) bool {
 	if w.w == nil || !w.w.Dimension().WeatherCycle() {
 		return false
 	}
-	if b := w.w.biome(pos); b.Rainfall() == 0 || w.w.temperature(pos) <= 0.15 {
+	if b := w.w.biome(pos); b == nil || b.Rainfall() == 0 || w.w.temperature(pos) <= 0.15 {
 		return false
 	}
 	w.w.set.Lock()
@@ -185,6 +185,46 @@ func (w weather) enableWeatherCycle(v bool) {
 	w.w.set.WeatherCycle = v
 }
 
+// tickPrecipitation iterates over all loaded chunks in the World, letting rain
+// or snow fall on a random column in each one with a 1/16 chance.
+func (w weather) tickPrecipitation(tx *Tx) {
+	positions := make([]ChunkPos, 0, len(w.w.chunks)/16)
+	for pos := range w.w.chunks {
+		if w.w.r.IntN(16) == 0 {
+			positions = append(positions, pos)
+		}
+	}
+	for _, c := range positions {
+		v := w.w.r.Int32()
+		x, z := int(c[0]<<4+(v&0xf)), int(c[1]<<4+((v>>8)&0xf))
+		w.precipitate(tx, cube.Pos{x, w.w.highestObstructingBlock(x, z) + 1, z})
+	}
+}
+
+// precipitate lets rain or snow fall at the position passed, which is the
+// position directly above the highest obstructing block in its column. If it
+// snows, the position is empty and the top face of the block below is solid, a
+// layer of snow is formed on top of it. Otherwise, the PrecipitationReceivers
+// at and below the position receive the precipitation.
+func (w weather) precipitate(tx *Tx, pos cube.Pos) {
+	snow := w.snowingAt(pos)
+	if !snow && !w.rainingAt(pos) {
+		return
+	}
+	below := pos.Side(cube.FaceDown)
+	if snow && w.w.SnowAccumulationHeight() > 0 && w.w.conf.Blocks.BlockRuntimeID(tx.Block(pos)) == w.w.conf.Blocks.AirRuntimeID() && tx.Block(below).Model().FaceSolid(below, cube.FaceUp, tx) {
+		if layer, ok := w.w.conf.Blocks.BlockByName("minecraft:snow_layer", map[string]any{"height": int32(0), "covered_bit": false}); ok {
+			tx.SetBlock(pos, layer, nil)
+			return
+		}
+	}
+	for _, p := range []cube.Pos{pos, below} {
+		if r, ok := tx.Block(p).(PrecipitationReceiver); ok {
+			r.ReceivePrecipitation(p, tx, w.w.r, snow)
+		}
+	}
+}
+
 // tickLightning iterates over all loaded chunks in the World, striking
 // lightning in each one with a 1/100,000 chance.
 func (w weather) tickLightning(tx *Tx) {
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"iter"
-	"maps"
 	"math/rand/v2"
 	"slices"
 	"sync"
@@ -68,6 +67,8 @@ type World struct {
 	// that the Entity was in. These are tracked so that a call to RemoveEntity
 	// can find the correct Entity.
 	entities map[*EntityHandle]ChunkPos
+	// entityIDs holds the same entities as entities, indexed by their UUID.
+	entityIDs map[uuid.UUID]*EntityHandle
 
 	r *rand.Rand
 
@@ -76,11 +77,36 @@ type World struct {
 	// tick value passed, the block update will be performed and the entry will
 	// be removed from the map.
 	scheduledUpdates *scheduledTickQueue
+	// scheduledFuncs holds the functions scheduled using Tx.ScheduleFunc that
+	// have not yet been run.
+	scheduledFuncs   []scheduledFunc
 	redstone         *redstoneEngine
 	neighbourUpdates []neighbourUpdate
+	// entityStates holds the entities whose state changed during the current
+	// tick if Config.BatchEntityState is set. Their state is viewed by
+	// viewers at the end of the tick.
+	entityStates map[*EntityHandle]struct{}
+	// blockUpdates holds the blocks changed in every chunk during the current
+	// tick if Config.BatchBlockUpdates is set. They are viewed by viewers at
+	// the end of the tick.
+	blockUpdates map[ChunkPos][]BlockChange
 
 	viewerMu sync.Mutex
 	viewers  map[*Loader]Viewer
+
+	// maps holds all maps created in the World using NewMap, indexed by
+	// their ID.
+	mapMu sync.Mutex
+	maps  map[int64]*Map
+
+	// tickets holds the chunk tickets added using AddChunkTicket, which keep
+	// the chunks they are added to loaded.
+	ticketMu sync.Mutex
+	tickets  map[ChunkPos]chunkTicket
+
+	// timings holds the timings collected since StartTimings was called. It
+	// is nil if timings are disabled.
+	timings atomic.Pointer[timings]
 }
 
 // transaction is a type that may be added to the transaction queue of a World.
@@ -115,6 +141,16 @@ func (w *World) Dimension() Dimension {
 	return w.conf.Dim
 }
 
+// InventoryGroup returns the inventory group of the World, as set in
+// Config.InventoryGroup. Players have a separate inventory for every
+// inventory group of the worlds they visit.
+func (w *World) InventoryGroup() string {
+	if w == nil {
+		return ""
+	}
+	return w.conf.InventoryGroup
+}
+
 // Range returns the range in blocks of the World (min and max). It is
 // equivalent to calling World.Dimension().Range().
 func (w *World) Range() cube.Range {
@@ -249,10 +285,8 @@ func (w *World) blockInChunk(c *Column, pos cube.Pos) Block {
 		// Despite being a block with NBT, the block didn't actually have any
 		// stored NBT yet. We add it here and update the block.
 		nbtB := w.conf.Blocks.BlockByRuntimeIDOrAir(rid).(NBTer).DecodeNBT(map[string]any{}).(Block)
-		c.BlockEntities[pos] = nbtB
-		for _, v := range c.viewers {
-			v.ViewBlockUpdate(pos, nbtB, 0)
-		}
+		c.setBlockEntity(pos, nbtB)
+		w.viewBlockUpdate(c, pos, nbtB, 0)
 		return nbtB
 	}
 	return w.conf.Blocks.BlockByRuntimeIDOrAir(rid)
@@ -373,13 +407,11 @@ func (w *World) setBlock(pos cube.Pos, b Block, opts *SetOpts) {
 	c.modified = true
 	c.SetBlock(x, y, z, 0, rid)
 	if w.conf.Blocks.NBTBlock(rid) {
-		c.BlockEntities[pos] = b
+		c.setBlockEntity(pos, b)
 	} else {
-		delete(c.BlockEntities, pos)
+		c.setBlockEntity(pos, nil)
 	}
 
-	viewers := slices.Clone(c.viewers)
-
 	if !opts.DisableLiquidDisplacement {
 		var secondLayer Block
 
@@ -398,6 +430,14 @@ func (w *World) setBlock(pos cube.Pos, b Block, opts *SetOpts) {
 					c.SetBlock(x, y, z, 1, before)
 					secondLayer = l
 				}
+			} else if li := c.Block(x, y, z, 1); li != airRID {
+				// The block replaced displaced a liquid itself. The liquid
+				// only remains if the new block is able to displace it too,
+				// which is not the case for a double slab, for example.
+				if l, ok := w.conf.Blocks.BlockByRuntimeIDOrAir(li).(Liquid); !ok || !b.(LiquidDisplacer).CanDisplace(l) {
+					c.SetBlock(x, y, z, 1, airRID)
+					secondLayer = w.conf.Blocks.Air()
+				}
 			}
 		} else if li := c.Block(x, y, z, 1); li != airRID {
 			c.SetBlock(x, y, z, 1, airRID)
@@ -405,9 +445,7 @@ func (w *World) setBlock(pos cube.Pos, b Block, opts *SetOpts) {
 		}
 
 		if secondLayer != nil {
-			for _, viewer := range viewers {
-				viewer.ViewBlockUpdate(pos, secondLayer, 1)
-			}
+			w.viewBlockUpdate(c, pos, secondLayer, 1)
 		}
 	}
 
@@ -415,9 +453,7 @@ func (w *World) setBlock(pos cube.Pos, b Block, opts *SetOpts) {
 		w.redstone.forget(pos)
 	}
 
-	for _, viewer := range viewers {
-		viewer.ViewBlockUpdate(pos, b, 0)
-	}
+	w.viewBlockUpdate(c, pos, b, 0)
 
 	if !opts.DisableBlockUpdates {
 		w.doBlockUpdatesAround(pos)
@@ -500,9 +536,9 @@ func (w *World) buildStructure(pos cube.Pos, s Structure) {
 
 								nbtPos := cube.Pos{xOffset, yOffset, zOffset}
 								if w.conf.Blocks.NBTBlock(rid) {
-									c.BlockEntities[nbtPos] = b
+									c.setBlockEntity(nbtPos, b)
 								} else {
-									delete(c.BlockEntities, nbtPos)
+									c.setBlockEntity(nbtPos, nil)
 								}
 							}
 							if liq != nil {
@@ -585,14 +621,10 @@ func (w *World) setLiquid(pos cube.Pos, b Liquid) {
 	rid := w.conf.Blocks.BlockRuntimeID(b)
 	if w.removeLiquids(c, pos) {
 		c.SetBlock(x, y, z, 0, rid)
-		for _, v := range c.viewers {
-			v.ViewBlockUpdate(pos, b, 0)
-		}
+		w.viewBlockUpdate(c, pos, b, 0)
 	} else {
 		c.SetBlock(x, y, z, 1, rid)
-		for _, v := range c.viewers {
-			v.ViewBlockUpdate(pos, b, 1)
-		}
+		w.viewBlockUpdate(c, pos, b, 1)
 	}
 	c.modified = true
 
@@ -610,16 +642,12 @@ func (w *World) removeLiquids(c *Column, pos cube.Pos) bool {
 	noneLeft := false
 	if noLeft, changed := w.removeLiquidOnLayer(c.Chunk, x, y, z, 0); noLeft {
 		if changed {
-			for _, v := range c.viewers {
-				v.ViewBlockUpdate(pos, air, 0)
-			}
+			w.viewBlockUpdate(c, pos, air, 0)
 		}
 		noneLeft = true
 	}
 	if _, changed := w.removeLiquidOnLayer(c.Chunk, x, y, z, 1); changed {
-		for _, v := range c.viewers {
-			v.ViewBlockUpdate(pos, air, 1)
-		}
+		w.viewBlockUpdate(c, pos, air, 1)
 	}
 	return noneLeft
 }
@@ -693,6 +721,26 @@ func (w *World) skyLight(pos cube.Pos) uint8 {
 	return w.chunk(chunkPosFromBlockPos(pos)).SkyLight(uint8(pos[0]), int16(pos[1]), uint8(pos[2]))
 }
 
+// blockLight returns the block light level at the position passed. This light
+// level is only influenced by blocks that emit light, such as torches, and not
+// by the sky.
+func (w *World) blockLight(pos cube.Pos) uint8 {
+	if pos[1] < w.ra[0] || pos[1] > w.ra[1] {
+		return 0
+	}
+	return w.chunk(chunkPosFromBlockPos(pos)).BlockLight(uint8(pos[0]), int16(pos[1]), uint8(pos[2]))
+}
+
+// SnowAccumulationHeight returns the maximum number of snow layers that
+// accumulate on top of each other while it is snowing in the World, as set in
+// Config.SnowAccumulationHeight.
+func (w *World) SnowAccumulationHeight() int {
+	if w == nil {
+		return 0
+	}
+	return max(w.conf.SnowAccumulationHeight, 0)
+}
+
 // Time returns the current time of the world. The time is incremented every
 // 1/20th of a second, unless World.StopTime() is called.
 func (w *World) Time() int {
@@ -791,6 +839,14 @@ func (w *World) playSound(tx *Tx, pos mgl64.Vec3, s Sound) {
 	}
 }
 
+// broadcastLevelEvent broadcasts a LevelEvent at a position in the World to
+// all viewers of that position.
+func (w *World) broadcastLevelEvent(pos mgl64.Vec3, event LevelEvent, data int32) {
+	for _, viewer := range w.viewersOf(pos) {
+		viewer.ViewLevelEvent(pos, event, data)
+	}
+}
+
 // addEntity adds an EntityHandle to a World. The Entity will be visible to all
 // viewers of the World that have the chunk at the EntityHandle's position. If
 // the chunk that the EntityHandle is in is not yet loaded, it will first be
@@ -802,23 +858,75 @@ func (w *World) addEntity(tx *Tx, handle *EntityHandle) Entity {
 
 // addEntityAt adds an EntityHandle to a World at the position passed.
 func (w *World) addEntityAt(tx *Tx, handle *EntityHandle, pos mgl64.Vec3) Entity {
+	if w.entityLimitReached(tx, handle.t, chunkPosFromVec3(pos)) {
+		_ = handle.Close()
+		return nil
+	}
 	handle.setAndUnlockWorldAt(w, pos)
 	chunkPos := chunkPosFromVec3(handle.data.Pos)
 	w.entities[handle] = chunkPos
+	w.entityIDs[handle.id] = handle
 
 	c := w.chunk(chunkPos)
 	c.Entities, c.modified = append(c.Entities, handle), true
 
 	e := handle.mustEntity(tx)
-	for _, v := range c.viewers {
-		// Show the entity to all viewers in the chunk of the entity.
-		showEntity(e, v)
+	if r := w.conf.EntityViewRadius; r > 0 {
+		for _, l := range c.loaders {
+			// Show the entity only to loaders in the chunk that have it within their entity view radius.
+			if l.position().Sub(handle.data.Pos).Len() <= r && l.track(handle) {
+				showEntity(e, l.viewer)
+			}
+		}
+	} else {
+		for _, v := range c.viewers {
+			// Show the entity to all viewers in the chunk of the entity.
+			showEntity(e, v)
+		}
 	}
 	w.Handler().HandleEntitySpawn(tx, e)
 	handle.markWorldReady(w)
 	return e
 }
 
+// entityLimitReached checks if the chunk at the ChunkPos passed holds
+// Config.ChunkEntityLimit entities counted towards the limit already, so that
+// no entity of the EntityType passed may be added to it. If
+// Config.RemoveOldestEntities is true, the oldest entities counted are removed
+// from the chunk instead and false is returned.
+func (w *World) entityLimitReached(tx *Tx, t EntityType, pos ChunkPos) bool {
+	if w.conf.ChunkEntityLimit <= 0 || !w.limitedEntity(t) {
+		return false
+	}
+	var limited []*EntityHandle
+	for _, handle := range w.chunk(pos).Entities {
+		if w.limitedEntity(handle.t) {
+			limited = append(limited, handle)
+		}
+	}
+	if len(limited) < w.conf.ChunkEntityLimit {
+		return false
+	}
+	if !w.conf.RemoveOldestEntities {
+		return true
+	}
+	// Entities are appended to a chunk as they are added, so the entities
+	// first in the chunk are the oldest.
+	for _, handle := range limited[:len(limited)-w.conf.ChunkEntityLimit+1] {
+		_ = handle.mustEntity(tx).Close()
+	}
+	return false
+}
+
+// limitedEntity checks if entities of the EntityType passed count towards the
+// Config.ChunkEntityLimit.
+func (w *World) limitedEntity(t EntityType) bool {
+	if t.EncodeEntity() == "minecraft:player" {
+		return false
+	}
+	return w.conf.LimitedEntity == nil || w.conf.LimitedEntity(t)
+}
+
 // removeEntity removes an Entity from the World that is currently present in
 // it. Any viewers of the Entity will no longer be able to see it.
 // removeEntity returns the EntityHandle of the Entity. After removing an Entity
@@ -836,10 +944,22 @@ func (w *World) removeEntity(e Entity, tx *Tx) *EntityHandle {
 	c.Entities, c.modified = sliceutil.DeleteVal(c.Entities, handle), true
 
 	w.removeEntityFromViewLayers(e)
-	for _, v := range c.viewers {
-		v.HideEntity(e)
+	if w.conf.EntityViewRadius > 0 {
+		// The entity may be tracked by loaders that are not viewing its current chunk, so we hide it from
+		// every loader that tracks it.
+		_, loaders := w.allViewers()
+		for _, l := range loaders {
+			if l.untrack(handle) {
+				l.viewer.HideEntity(e)
+			}
+		}
+	} else {
+		for _, v := range c.viewers {
+			v.HideEntity(e)
+		}
 	}
 	delete(w.entities, handle)
+	delete(w.entityIDs, handle.id)
 	handle.unsetAndLockWorld()
 	return handle
 }
@@ -951,6 +1071,29 @@ func (w *World) SetSpawn(pos cube.Pos) {
 	}
 }
 
+// SpawnRadius returns the radius in blocks around the spawn of the world
+// within which players without a spawn position of their own are spawned.
+func (w *World) SpawnRadius() int {
+	if w == nil {
+		return 0
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return int(w.set.SpawnRadius)
+}
+
+// SetSpawnRadius changes the radius in blocks around the spawn of the world
+// within which players without a spawn position of their own are spawned. A
+// radius of 0 spawns these players at the spawn exactly.
+func (w *World) SetSpawnRadius(r int) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.SpawnRadius = int32(max(r, 0))
+}
+
 // PlayerSpawn returns the spawn position of a player with a UUID in this World.
 func (w *World) PlayerSpawn(id uuid.UUID) cube.Pos {
 	if w == nil {
@@ -1031,6 +1174,29 @@ func (w *World) SetDefaultGameMode(mode GameMode) {
 	w.set.DefaultGameMode = mode
 }
 
+// ForceGameMode checks if players entering the world have their game mode
+// changed to the default game mode of the world.
+func (w *World) ForceGameMode() bool {
+	if w == nil {
+		return false
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.ForceGameMode
+}
+
+// SetForceGameMode changes if players entering the world have their game mode
+// changed to the default game mode of the world. Players already in the world
+// keep their game mode.
+func (w *World) SetForceGameMode(v bool) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.ForceGameMode = v
+}
+
 // Difficulty returns the difficulty of the world. Properties of mobs in the
 // world and the player's hunger will depend on this difficulty.
 func (w *World) Difficulty() Difficulty {
@@ -1042,6 +1208,16 @@ func (w *World) Difficulty() Difficulty {
 	return w.set.Difficulty
 }
 
+// Seed returns the seed of the World, which is used to place its Features.
+func (w *World) Seed() int64 {
+	if w == nil {
+		return 0
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.Seed
+}
+
 // SetDifficulty changes the difficulty of a world.
 func (w *World) SetDifficulty(d Difficulty) {
 	if w == nil {
@@ -1052,6 +1228,209 @@ func (w *World) SetDifficulty(d Difficulty) {
 	w.set.Difficulty = d
 }
 
+// CommandBlocksEnabled checks if command blocks in the world execute their
+// commands when triggered.
+func (w *World) CommandBlocksEnabled() bool {
+	if w == nil {
+		return false
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.CommandBlocksEnabled
+}
+
+// SetCommandBlocksEnabled changes if command blocks in the world execute their
+// commands when triggered.
+func (w *World) SetCommandBlocksEnabled(v bool) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.CommandBlocksEnabled = v
+}
+
+// PvP checks if players in the world can damage other players.
+func (w *World) PvP() bool {
+	if w == nil {
+		return false
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.PvP
+}
+
+// SetPvP changes if players in the world can damage other players.
+func (w *World) SetPvP(v bool) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.PvP = v
+}
+
+// FireTick checks if fire in the world spreads to and burns flammable blocks.
+func (w *World) FireTick() bool {
+	if w == nil {
+		return false
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.FireTick
+}
+
+// SetFireTick changes if fire in the world spreads to and burns flammable
+// blocks.
+func (w *World) SetFireTick(v bool) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.FireTick = v
+}
+
+// Insomnia checks if phantoms spawn around players in the world that have
+// not slept for several days.
+func (w *World) Insomnia() bool {
+	if w == nil {
+		return false
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.Insomnia
+}
+
+// SetInsomnia changes if phantoms spawn around players in the world that have
+// not slept for several days.
+func (w *World) SetInsomnia(v bool) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.Insomnia = v
+}
+
+// MobGriefing checks if entities other than players are able to change
+// blocks in the world, such as by trampling farmland.
+func (w *World) MobGriefing() bool {
+	if w == nil {
+		return false
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.MobGriefing
+}
+
+// SetMobGriefing changes if entities other than players are able to change
+// blocks in the world, such as by trampling farmland.
+func (w *World) SetMobGriefing(v bool) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.MobGriefing = v
+}
+
+// DamageAllowed checks if the attacker passed may damage the victim passed. A
+// player cannot damage another player if PvP is disabled in the world, and an
+// entity cannot damage an entity on the same team as specified by
+// Config.SameTeam or on the same Team of Config.Teams without friendly fire.
+// Entities can always damage themselves.
+func (w *World) DamageAllowed(attacker, victim Entity) bool {
+	if w == nil || attacker == nil || victim == nil || attacker.H() == victim.H() {
+		return true
+	}
+	if isPlayer(attacker) && isPlayer(victim) && !w.PvP() {
+		return false
+	}
+	if !w.conf.Teams.DamageAllowed(attacker.H().UUID(), victim.H().UUID()) {
+		return false
+	}
+	return w.conf.SameTeam == nil || !w.conf.SameTeam(attacker, victim)
+}
+
+// ReportDamage passes the Damage to the Config.DamageListener of the World.
+// ReportDamage should be called by living entities every time they are hurt
+// successfully, after the damage has been dealt.
+func (w *World) ReportDamage(tx *Tx, d Damage) {
+	if w == nil || w.conf.DamageListener == nil {
+		return
+	}
+	w.conf.DamageListener(tx, d)
+}
+
+// Teams returns the Teams that entities in the World may be members of, as
+// set in Config.Teams. Nil is returned if the World has no Teams.
+func (w *World) Teams() *Teams {
+	if w == nil {
+		return nil
+	}
+	return w.conf.Teams
+}
+
+// Objectives returns the scoreboard Objectives that track the scores of
+// entities in the World, as set in Config.Objectives. Nil is returned if the
+// World has no Objectives.
+func (w *World) Objectives() *Objectives {
+	if w == nil {
+		return nil
+	}
+	return w.conf.Objectives
+}
+
+// isPlayer checks if the Entity passed is a player.
+func isPlayer(e Entity) bool {
+	return e.H().Type().EncodeEntity() == "minecraft:player"
+}
+
+// MaxCommandChainLength returns the maximum number of chain command blocks that
+// are executed as a result of a single command block being triggered.
+func (w *World) MaxCommandChainLength() int {
+	if w == nil {
+		return 0
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return int(w.set.MaxCommandChainLength)
+}
+
+// SetMaxCommandChainLength changes the maximum number of chain command blocks
+// that are executed as a result of a single command block being triggered.
+func (w *World) SetMaxCommandChainLength(v int) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.MaxCommandChainLength = int32(v)
+}
+
+// FunctionCommandLimit returns the maximum number of command lines executed by
+// a single function run in the world.
+func (w *World) FunctionCommandLimit() int {
+	if w == nil {
+		return 0
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return int(w.set.FunctionCommandLimit)
+}
+
+// SetFunctionCommandLimit changes the maximum number of command lines executed
+// by a single function run in the world.
+func (w *World) SetFunctionCommandLimit(v int) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.FunctionCommandLimit = int32(v)
+}
+
 // scheduleBlockUpdate schedules a block update at the position passed for the
 // block type passed after a specific delay. If the block at that position does
 // not handle block updates, nothing will happen.
@@ -1104,6 +1483,79 @@ func (w *World) Handle(h Handler) {
 	w.handler.Store(&h)
 }
 
+// entitiesInViewRadius returns an iterator that yields all entities within a
+// radius r around the position of the Loader passed, that are in a chunk
+// loaded by the Loader.
+func (w *World) entitiesInViewRadius(tx *Tx, l *Loader, r float64) iter.Seq[Entity] {
+	pos := l.position()
+	box := cube.Box(pos[0]-r, pos[1]-r, pos[2]-r, pos[0]+r, pos[1]+r, pos[2]+r)
+	return func(yield func(Entity) bool) {
+		for e := range w.entitiesWithin(tx, box) {
+			handle := e.H()
+			if handle.data.Pos.Sub(pos).Len() > r {
+				continue
+			}
+			if c, ok := w.chunks[w.entities[handle]]; !ok || !slices.Contains(c.loaders, l) {
+				continue
+			}
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// updateEntityState shows the state of the Entity passed to all viewers of
+// it, or delays this until the end of the tick if Config.BatchEntityState is
+// set.
+func (w *World) updateEntityState(e Entity) {
+	if !w.conf.BatchEntityState {
+		for _, v := range w.entityViewers(e) {
+			v.ViewEntityState(e)
+		}
+		return
+	}
+	if w.entityStates == nil {
+		w.entityStates = make(map[*EntityHandle]struct{})
+	}
+	w.entityStates[e.H()] = struct{}{}
+}
+
+// viewBlockUpdate shows a block update in the Column passed to all viewers of
+// it, or delays this until the end of the tick if Config.BatchBlockUpdates is
+// set.
+func (w *World) viewBlockUpdate(c *Column, pos cube.Pos, b Block, layer int) {
+	if !w.conf.BatchBlockUpdates {
+		for _, v := range c.viewers {
+			v.ViewBlockUpdate(pos, b, layer)
+		}
+		return
+	}
+	if len(c.viewers) == 0 {
+		return
+	}
+	if w.blockUpdates == nil {
+		w.blockUpdates = make(map[ChunkPos][]BlockChange)
+	}
+	chunkPos := chunkPosFromBlockPos(pos)
+	w.blockUpdates[chunkPos] = append(w.blockUpdates[chunkPos], BlockChange{Pos: pos, Block: b, Layer: layer})
+}
+
+// entityViewers returns all viewers that the Entity passed is shown to.
+func (w *World) entityViewers(e Entity) []Viewer {
+	if w.conf.EntityViewRadius <= 0 {
+		return w.viewersOf(e.Position())
+	}
+	all, loaders := w.allViewers()
+	viewers := make([]Viewer, 0, len(loaders))
+	for i, l := range loaders {
+		if l.tracking(e.H()) {
+			viewers = append(viewers, all[i])
+		}
+	}
+	return viewers
+}
+
 // viewersOf returns all viewers viewing the position passed.
 func (w *World) viewersOf(pos mgl64.Vec3) []Viewer {
 	c, ok := w.chunks[chunkPosFromVec3(pos)]
@@ -1211,6 +1663,9 @@ func (w *World) close() {
 
 	close(w.queueClosing)
 	w.queueing.Wait()
+	if w.conf.Teams != nil {
+		w.conf.Teams.detach(w)
+	}
 
 	if w.set.ref.Add(-1); !w.advance {
 		return
@@ -1258,6 +1713,10 @@ func (w *World) addViewer(tx *Tx, c *Column, loader *Loader) {
 	c.viewers = append(c.viewers, loader.viewer)
 	c.loaders = append(c.loaders, loader)
 
+	if w.conf.EntityViewRadius > 0 {
+		// Entities are shown to the viewer during the next tick if they are within its entity view radius.
+		return
+	}
 	for _, entity := range c.Entities {
 		showEntity(entity.mustEntity(tx), loader.viewer)
 	}
@@ -1280,8 +1739,11 @@ func (w *World) removeViewer(tx *Tx, pos ChunkPos, loader *Loader) {
 	}
 
 	// Hide all entities in the chunk from the viewer.
+	culling := w.conf.EntityViewRadius > 0
 	for _, entity := range c.Entities {
-		loader.viewer.HideEntity(entity.mustEntity(tx))
+		if !culling || loader.untrack(entity) {
+			loader.viewer.HideEntity(entity.mustEntity(tx))
+		}
 	}
 }
 
@@ -1330,6 +1792,7 @@ func (w *World) loadChunk(pos ChunkPos) (*Column, error) {
 		w.chunks[pos] = col
 		for _, e := range col.Entities {
 			w.entities[e] = pos
+			w.entityIDs[e.id] = e
 			e.setAndUnlockWorld(w)
 			e.markWorldReady(w)
 		}
@@ -1340,6 +1803,10 @@ func (w *World) loadChunk(pos ChunkPos) (*Column, error) {
 		w.chunks[pos] = col
 
 		w.conf.Generator.GenerateChunk(pos, col.Chunk)
+		if w.conf.BiomeSource != nil {
+			applyBiomeSource(w.conf.BiomeSource, pos, col.Chunk)
+		}
+		w.placeFeatures(pos, col.Chunk)
 		return col, nil
 	default:
 		return newColumn(chunk.New(w.conf.Blocks, w.Range())), err
@@ -1406,10 +1873,11 @@ func (w *World) autoSave() {
 	}
 }
 
-// closeUnusedChunk closes all chunks currently not in use by any viewer.
+// closeUnusedChunk closes all chunks currently not in use by any viewer and
+// without any chunk tickets.
 func (w *World) closeUnusedChunks(tx *Tx) {
 	for pos, c := range w.chunks {
-		if len(c.viewers) == 0 {
+		if ticketed, _ := w.ticketed(pos); len(c.viewers) == 0 && !ticketed {
 			w.closeChunk(tx, pos, c)
 		}
 	}
@@ -1421,16 +1889,50 @@ type Column struct {
 	modified bool
 
 	*chunk.Chunk
-	Entities      []*EntityHandle
+	Entities []*EntityHandle
+	// BlockEntities holds the blocks with block entity data in the Column.
+	// BlockEntities should not be modified directly, as the Column keeps an
+	// index of the block entities that must be ticked or that listen to
+	// vibrations.
 	BlockEntities map[cube.Pos]Block
 
+	// tickers holds the positions of the block entities in BlockEntities that
+	// implement TickerBlock, so that only these are visited every tick.
+	tickers map[cube.Pos]struct{}
+	// listeners holds the positions of the block entities in BlockEntities
+	// that implement VibrationListener.
+	listeners map[cube.Pos]struct{}
+
 	viewers []Viewer
 	loaders []*Loader
 }
 
 // newColumn returns a new Column wrapper around the chunk.Chunk passed.
 func newColumn(c *chunk.Chunk) *Column {
-	return &Column{Chunk: c, BlockEntities: map[cube.Pos]Block{}}
+	return &Column{Chunk: c, BlockEntities: map[cube.Pos]Block{}, tickers: map[cube.Pos]struct{}{}, listeners: map[cube.Pos]struct{}{}}
+}
+
+// setBlockEntity sets the block entity at pos to b and updates the indices of
+// block entities that must be ticked or that listen to vibrations. If b is nil,
+// the block entity at pos is removed.
+func (col *Column) setBlockEntity(pos cube.Pos, b Block) {
+	if b == nil {
+		delete(col.BlockEntities, pos)
+		delete(col.tickers, pos)
+		delete(col.listeners, pos)
+		return
+	}
+	col.BlockEntities[pos] = b
+	if _, ok := b.(TickerBlock); ok {
+		col.tickers[pos] = struct{}{}
+	} else {
+		delete(col.tickers, pos)
+	}
+	if _, ok := b.(VibrationListener); ok {
+		col.listeners[pos] = struct{}{}
+	} else {
+		delete(col.listeners, pos)
+	}
 }
 
 // columnTo converts a Column to a chunk.Column so that it can be written to
@@ -1445,10 +1947,7 @@ func (w *World) columnTo(col *Column, pos ChunkPos) *chunk.Column {
 		Tick:            w.scheduledUpdates.currentTick,
 	}
 	for _, e := range col.Entities {
-		data := e.encodeNBT()
-		maps.Copy(data, e.t.EncodeNBT(&e.data))
-		data["identifier"] = e.t.EncodeEntity()
-		c.Entities = append(c.Entities, chunk.Entity{ID: int64(binary.LittleEndian.Uint64(e.id[8:])), Data: data})
+		c.Entities = append(c.Entities, chunk.Entity{ID: int64(binary.LittleEndian.Uint64(e.id[8:])), Data: e.EncodeNBT()})
 	}
 	for pos, be := range col.BlockEntities {
 		c.BlockEntities = append(c.BlockEntities, chunk.BlockEntity{Pos: pos, Data: be.(NBTer).EncodeNBT()})
@@ -1466,6 +1965,8 @@ func (w *World) columnFrom(c *chunk.Column, _ ChunkPos) *Column {
 		Chunk:         c.Chunk,
 		Entities:      make([]*EntityHandle, 0, len(c.Entities)),
 		BlockEntities: make(map[cube.Pos]Block, len(c.BlockEntities)),
+		tickers:       make(map[cube.Pos]struct{}),
+		listeners:     make(map[cube.Pos]struct{}),
 	}
 	for _, e := range c.Entities {
 		eid, ok := e.Data["identifier"].(string)
@@ -1492,7 +1993,7 @@ func (w *World) columnFrom(c *chunk.Column, _ ChunkPos) *Column {
 			w.conf.Log.Error("read column: block with nbt does not implement NBTer", "block", fmt.Sprintf("%#v", b))
 			continue
 		}
-		col.BlockEntities[be.Pos] = nb.DecodeNBT(be.Data).(Block)
+		col.setBlockEntity(be.Pos, nb.DecodeNBT(be.Data).(Block))
 	}
 	scheduled, savedTick := make([]scheduledTick, 0, len(c.ScheduledBlocks)), c.Tick
 	for _, t := range c.ScheduledBlocks {
@@ -2,6 +2,7 @@ package world
 
 import (
 	"context"
+	"slices"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -137,7 +138,7 @@ func TestSynchronousAdvanceTickTicksViewerlessBlockEntities(t *testing.T) {
 			t.Fatal("expected chest block to be registered")
 		}
 		col.SetBlock(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0, tx.World().conf.Blocks.BlockRuntimeID(chest))
-		col.BlockEntities[pos] = tb
+		col.setBlockEntity(pos, tb)
 	})
 
 	w.AdvanceTick()
@@ -146,6 +147,50 @@ func TestSynchronousAdvanceTickTicksViewerlessBlockEntities(t *testing.T) {
 	}
 }
 
+func TestBlockEntityTickIndex(t *testing.T) {
+	w := Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 4, 0}
+	chunkPos := chunkPosFromBlockPos(pos)
+	tb := &testTickerBlock{}
+	<-w.exec(func(tx *Tx) {
+		col := tx.World().chunk(chunkPos)
+		chest, _ := tx.World().conf.Blocks.BlockByName("minecraft:chest", map[string]any{"minecraft:cardinal_direction": "north"})
+		col.SetBlock(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0, tx.World().conf.Blocks.BlockRuntimeID(chest))
+		col.setBlockEntity(pos, tb)
+		col.setBlockEntity(cube.Pos{1, 4, 0}, chest)
+		if len(col.tickers) != 1 {
+			t.Errorf("expected only the ticking block entity to be indexed, got %v", col.tickers)
+		}
+	})
+	w.AdvanceTick()
+	if tb.ticks != 1 {
+		t.Fatalf("expected placed block entity to tick once, got %v ticks", tb.ticks)
+	}
+
+	<-w.exec(func(tx *Tx) {
+		tx.SetBlock(pos, nil, nil)
+		if _, ok := tx.World().chunk(chunkPos).tickers[pos]; ok {
+			t.Error("expected broken block entity to be removed from the tick index")
+		}
+	})
+	w.AdvanceTick()
+	if tb.ticks != 1 {
+		t.Fatalf("expected broken block entity not to tick, got %v ticks", tb.ticks)
+	}
+
+	<-w.exec(func(tx *Tx) {
+		col := tx.World().chunk(chunkPos)
+		col.setBlockEntity(pos, tb)
+		tx.World().closeChunk(tx, chunkPos, col)
+	})
+	w.AdvanceTick()
+	if tb.ticks != 1 {
+		t.Fatalf("expected block entity in unloaded chunk not to tick, got %v ticks", tb.ticks)
+	}
+}
+
 type testEntityConfig struct{}
 
 func (testEntityConfig) Apply(*EntityData) {}
@@ -257,3 +302,221 @@ func (*testTickerBlock) EncodeNBT() map[string]any {
 func (b *testTickerBlock) Tick(int64, cube.Pos, *Tx) {
 	b.ticks++
 }
+
+// TestEntityViewRadiusTracksEntities verifies that entities are only shown to a
+// viewer while they are within the entity view radius of the World.
+func TestEntityViewRadiusTracksEntities(t *testing.T) {
+	w := Config{Synchronous: true, EntityViewRadius: 16}.New()
+	defer w.Close()
+
+	v := &trackingTestViewer{shown: map[*EntityHandle]bool{}}
+	l := NewLoader(2, w, v)
+	h := EntitySpawnOpts{Position: mgl64.Vec3{30, 4, 0}}.New(testEntityType{}, testEntityConfig{})
+	<-w.exec(func(tx *Tx) {
+		l.Move(tx, mgl64.Vec3{0, 4, 0})
+		l.Load(tx, 64)
+		tx.AddEntity(h)
+	})
+	if v.shown[h] {
+		t.Fatal("expected entity outside of the entity view radius not to be shown on spawn")
+	}
+
+	h.data.Pos = mgl64.Vec3{8, 4, 0}
+	w.AdvanceTick()
+	if !v.shown[h] {
+		t.Fatal("expected entity to be shown after entering the entity view radius")
+	}
+
+	h.data.Pos = mgl64.Vec3{30, 4, 0}
+	w.AdvanceTick()
+	if v.shown[h] {
+		t.Fatal("expected entity to be hidden after leaving the entity view radius")
+	}
+
+	h.data.Pos = mgl64.Vec3{8, 4, 0}
+	w.AdvanceTick()
+	<-w.exec(func(tx *Tx) {
+		tx.RemoveEntity(h.mustEntity(tx))
+	})
+	if v.shown[h] {
+		t.Fatal("expected entity to be hidden after being removed")
+	}
+}
+
+// TestEntityViewRadiusEntityViewers verifies that only viewers tracking an
+// entity are returned as its viewers, so that viewers of its chunk that do not
+// have the entity shown are not sent its movement or state.
+func TestEntityViewRadiusEntityViewers(t *testing.T) {
+	w := Config{Synchronous: true, EntityViewRadius: 16}.New()
+	defer w.Close()
+
+	near, far := &trackingTestViewer{shown: map[*EntityHandle]bool{}}, &trackingTestViewer{shown: map[*EntityHandle]bool{}}
+	nearLoader, farLoader := NewLoader(2, w, near), NewLoader(2, w, far)
+	h := EntitySpawnOpts{Position: mgl64.Vec3{8, 4, 0}}.New(testEntityType{}, testEntityConfig{})
+	<-w.exec(func(tx *Tx) {
+		nearLoader.Move(tx, mgl64.Vec3{0, 4, 0})
+		nearLoader.Load(tx, 64)
+		farLoader.Move(tx, mgl64.Vec3{8, 4, 30})
+		farLoader.Load(tx, 64)
+		e := tx.AddEntity(h)
+
+		if viewers := tx.Viewers(e.Position()); !slices.Contains(viewers, Viewer(far)) {
+			t.Fatalf("expected viewer outside of the entity view radius to view the chunk of the entity")
+		}
+		if viewers := tx.EntityViewers(e); len(viewers) != 1 || viewers[0] != Viewer(near) {
+			t.Errorf("entity viewers = %v, want only the viewer within the entity view radius", viewers)
+		}
+		tx.UpdateEntityState(e)
+	})
+	if far.states != 0 || near.states != 1 {
+		t.Errorf("entity state viewed %v times by viewer outside of radius and %v times by viewer within it, want 0 and 1", far.states, near.states)
+	}
+}
+
+type trackingTestViewer struct {
+	NopViewer
+	shown  map[*EntityHandle]bool
+	states int
+}
+
+func (v *trackingTestViewer) ViewEntityState(Entity) {
+	v.states++
+}
+
+func (v *trackingTestViewer) ViewEntity(e Entity) {
+	v.shown[e.H()] = true
+}
+
+func (v *trackingTestViewer) HideEntity(e Entity) {
+	delete(v.shown, e.H())
+}
+
+type testPlayerType struct{ testEntityType }
+
+func (testPlayerType) EncodeEntity() string {
+	return "minecraft:player"
+}
+
+func TestWorldDamageAllowed(t *testing.T) {
+	newEntity := func(t EntityType) Entity {
+		h := EntitySpawnOpts{}.New(t, testEntityConfig{})
+		return &testEntity{handle: h, data: &h.data}
+	}
+	a, b, mob := newEntity(testPlayerType{}), newEntity(testPlayerType{}), newEntity(testEntityType{})
+	teams := map[Entity]int{a: 1, b: 2, mob: 1}
+
+	w := Config{SameTeam: func(attacker, victim Entity) bool {
+		return teams[attacker] == teams[victim]
+	}}.New()
+	defer w.Close()
+
+	if !w.DamageAllowed(a, b) {
+		t.Fatal("expected players on different teams to damage each other with PvP enabled")
+	}
+	w.SetPvP(false)
+	if w.DamageAllowed(a, b) {
+		t.Fatal("expected player damage to be cancelled with PvP disabled")
+	}
+	if !w.DamageAllowed(b, mob) || !w.DamageAllowed(mob, b) {
+		t.Fatal("expected damage between players and other entities with PvP disabled")
+	}
+	if w.DamageAllowed(a, mob) || w.DamageAllowed(mob, a) {
+		t.Fatal("expected damage between entities on the same team to be cancelled")
+	}
+	if !w.DamageAllowed(a, a) {
+		t.Fatal("expected entities to always be able to damage themselves")
+	}
+}
+
+// TestChunkEntityLimitBlocksSpawns verifies that entities counted towards the
+// chunk entity limit are not added to a full chunk, while entities of other
+// types and players still are.
+func TestChunkEntityLimitBlocksSpawns(t *testing.T) {
+	w := Config{Synchronous: true, ChunkEntityLimit: 2, LimitedEntity: func(t EntityType) bool {
+		_, ok := t.(taskTestEntityType)
+		return ok
+	}}.New()
+	defer w.Close()
+
+	<-w.exec(func(tx *Tx) {
+		for i := range 2 {
+			if tx.AddEntity(NewEntity(taskTestEntityType{}, taskTestEntityConfig{})) == nil {
+				t.Errorf("expected entity %d to be added below the chunk entity limit", i)
+			}
+		}
+		h := NewEntity(taskTestEntityType{}, taskTestEntityConfig{})
+		if tx.AddEntity(h) != nil {
+			t.Error("expected entity to be blocked by the chunk entity limit")
+		}
+		if !h.Closed() {
+			t.Error("expected blocked entity handle to be closed")
+		}
+		if tx.AddEntity(NewEntity(testEntityType{}, testEntityConfig{})) == nil {
+			t.Error("expected entity not counted towards the chunk entity limit to be added")
+		}
+		if tx.AddEntity(NewEntity(testPlayerType{}, testEntityConfig{})) == nil {
+			t.Error("expected player to be added regardless of the chunk entity limit")
+		}
+		if n := len(w.chunk(ChunkPos{}).Entities); n != 4 {
+			t.Errorf("expected chunk to hold 4 entities, got %d", n)
+		}
+	})
+}
+
+// TestChunkEntityLimitRemovesOldest verifies that the oldest entities in a
+// full chunk are removed to make space for new ones if RemoveOldestEntities is
+// set.
+func TestChunkEntityLimitRemovesOldest(t *testing.T) {
+	w := Config{Synchronous: true, ChunkEntityLimit: 2, RemoveOldestEntities: true}.New()
+	defer w.Close()
+
+	<-w.exec(func(tx *Tx) {
+		handles := make([]*EntityHandle, 3)
+		for i := range handles {
+			handles[i] = NewEntity(taskTestEntityType{}, taskTestEntityConfig{})
+			if tx.AddEntity(handles[i]) == nil {
+				t.Errorf("expected entity %d to be added", i)
+			}
+		}
+		if !handles[0].Closed() {
+			t.Error("expected oldest entity to be removed from the full chunk")
+		}
+		if c := w.chunk(ChunkPos{}); len(c.Entities) != 2 || c.Entities[0] != handles[1] || c.Entities[1] != handles[2] {
+			t.Errorf("expected chunk to hold the 2 newest entities, got %v", c.Entities)
+		}
+	})
+}
+
+// TestSpawnEntity verifies that Tx.SpawnEntity resolves entity types by name
+// from the entity registry, decodes the NBT passed and ignores unknown names.
+func TestSpawnEntity(t *testing.T) {
+	w := Config{Synchronous: true, Entities: EntityRegistryConfig{}.New([]EntityType{testEntityType{}})}.New()
+	defer w.Close()
+
+	<-w.exec(func(tx *Tx) {
+		if e, ok := tx.SpawnEntity("dragonfly:unknown", mgl64.Vec3{}, SpawnOptions{}); ok || e != nil {
+			t.Errorf("expected unknown entity type not to be spawned, got %v", e)
+		}
+		pos := mgl64.Vec3{1.5, 64, 2.5}
+		e, ok := tx.SpawnEntity("dragonfly:test_entity", pos, SpawnOptions{
+			NameTag: "test",
+			NBT:     map[string]any{"Pos": []float32{0, 0, 0}, "Fire": int16(40)},
+		})
+		if !ok {
+			t.Fatal("expected registered entity type to be spawned")
+		}
+		if _, ok := e.H().Type().(testEntityType); !ok {
+			t.Errorf("expected spawned entity to be of type testEntityType, got %T", e.H().Type())
+		}
+		if e.Position() != pos {
+			t.Errorf("expected spawned entity at %v, got %v", pos, e.Position())
+		}
+		data := e.(*testEntity).data
+		if data.Name != "test" || data.FireDuration != time.Second*2 {
+			t.Errorf("expected name tag test and 2s of fire from NBT, got %q and %v", data.Name, data.FireDuration)
+		}
+		if _, ok := tx.EntityByUUID(e.H().UUID()); !ok {
+			t.Error("expected spawned entity to be in the world")
+		}
+	})
+}